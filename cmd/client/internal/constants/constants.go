@@ -14,6 +14,8 @@ const (
 	Account
 	File
 	Bank
+	SSH
+	Cert
 )
 
 func (m Mode) String() string {
@@ -37,6 +39,10 @@ func (t ObjType) String() string {
 		return "file"
 	case Bank:
 		return "bank"
+	case SSH:
+		return "ssh key"
+	case Cert:
+		return "certificate"
 	default:
 		return "unknown"
 	}
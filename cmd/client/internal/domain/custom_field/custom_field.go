@@ -0,0 +1,108 @@
+package custom_field
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	TypeText   = "text"
+	TypeHidden = "hidden"
+	TypeURL    = "url"
+)
+
+// Placeholder shows the input format of a custom field
+const Placeholder = "[hidden|url] name=value"
+
+// Field is a user-defined key/value pair of an item
+type Field struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Parse reads "name=value", "hidden name=value" or "url name=value", empty input gives nil
+func Parse(line string) (*Field, error) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil, nil
+	}
+
+	f := Field{Type: TypeText}
+
+	if kind, rest, ok := strings.Cut(line, " "); ok {
+		switch kind {
+		case TypeText, TypeHidden, TypeURL:
+			f.Type, line = kind, rest
+		}
+	}
+
+	name, value, ok := strings.Cut(line, "=")
+	if !ok {
+		return nil, fmt.Errorf("custom field must be name=value, got %q", line)
+	}
+
+	f.Name = strings.TrimSpace(name)
+	f.Value = strings.TrimSpace(value)
+	if f.Name == "" {
+		return nil, fmt.Errorf("custom field name is empty in %q", line)
+	}
+
+	return &f, nil
+}
+
+// ParseAll parses the inputs in order and skips the empty ones
+func ParseAll(lines []string) ([]Field, error) {
+	fields := make([]Field, 0, len(lines))
+	for _, line := range lines {
+		f, err := Parse(line)
+		if err != nil {
+			return nil, err
+		}
+		if f != nil {
+			fields = append(fields, *f)
+		}
+	}
+	return fields, nil
+}
+
+// Format is the inverse of Parse, used to prefill inputs
+func Format(f Field) string {
+	if f.Type == "" || f.Type == TypeText {
+		return fmt.Sprintf("%s=%s", f.Name, f.Value)
+	}
+	return fmt.Sprintf("%s %s=%s", f.Type, f.Name, f.Value)
+}
+
+// Render is the line of a field on the get pages, hidden values are masked unless reveal is set
+func Render(f Field, reveal bool) string {
+	value := f.Value
+	if f.Type == TypeHidden && !reveal {
+		value = "********"
+	}
+	return fmt.Sprintf("%s: %s", f.Name, value)
+}
+
+// RenderAll is the custom fields block of the get pages, empty when there are no fields
+func RenderAll(fields []Field, reveal bool) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("Custom fields:\n")
+	for _, f := range fields {
+		fmt.Fprintf(&b, "  %s\n", Render(f, reveal))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// Plain lists all fields with their values, used when an item leaves the vault as a secret link
+func Plain(fields []Field) string {
+	var b strings.Builder
+	for _, f := range fields {
+		fmt.Fprintf(&b, "%s\n", Render(f, true))
+	}
+	return b.String()
+}
@@ -0,0 +1,42 @@
+package expiry
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateLayout is the format of expiry dates typed in forms
+const DateLayout = "2006-01-02"
+
+// Parse reads an optional expiry date, an empty string means the object does not expire
+func Parse(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.ParseInLocation(DateLayout, value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expiry date %q, expected YYYY-MM-DD", value)
+	}
+
+	return &t, nil
+}
+
+// Describe renders an expiry date relative to now, e.g. "expires in 5 days (2026-03-01)"
+func Describe(t time.Time, now time.Time) string {
+	date := t.Local().Format(DateLayout)
+	days := int(t.Sub(now).Hours() / 24)
+
+	switch {
+	case !now.Before(t):
+		return fmt.Sprintf("expired (%s)", date)
+	case days == 0:
+		return fmt.Sprintf("expires today (%s)", date)
+	case days == 1:
+		return fmt.Sprintf("expires tomorrow (%s)", date)
+	default:
+		return fmt.Sprintf("expires in %d days (%s)", days, date)
+	}
+}
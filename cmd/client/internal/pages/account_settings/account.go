@@ -0,0 +1,398 @@
+package account_settings
+
+import (
+	"client/internal/app"
+	domain "client/internal/domain/token"
+	"client/pkg/http_request_sender"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Session struct {
+	ID         int64     `json:"id"`
+	DeviceName string    `json:"device_name"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Current    bool      `json:"current"`
+}
+
+type changePasswordRequest struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+type changePasswordResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type changeUsernameRequest struct {
+	Password string `json:"password"`
+	Username string `json:"username"`
+}
+
+type deleteAccountRequest struct {
+	Password string `json:"password"`
+}
+
+// ChangePassword replaces the password, the server signs out other sessions and returns new tokens
+func ChangePassword(ctx context.Context, app *app.Ctx, oldPassword, newPassword string) (*domain.Token, error) {
+	var respData changePasswordResponse
+
+	const url = "http://127.0.0.1:8080/user/account/password"
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.POST,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Data:   changePasswordRequest{OldPassword: oldPassword, NewPassword: newPassword},
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"POST %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	tokens := domain.NewToken()
+	if err := tokens.SetJWTToken(respData.Token); err != nil {
+		return nil, err
+	}
+	tokens.SetRefreshToken(respData.RefreshToken)
+
+	return tokens, nil
+}
+
+func ChangeUsername(ctx context.Context, app *app.Ctx, password, username string) error {
+	const url = "http://127.0.0.1:8080/user/account/username"
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.POST,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Data:   changeUsernameRequest{Password: password, Username: username},
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return fmt.Errorf(
+			"POST %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	return nil
+}
+
+// DeleteAccount removes the user with all items and files
+func DeleteAccount(ctx context.Context, app *app.Ctx, password string) error {
+	const url = "http://127.0.0.1:8080/user/account"
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.DELETE,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Data:   deleteAccountRequest{Password: password},
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != http.StatusNoContent {
+		return fmt.Errorf(
+			"DELETE %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	return nil
+}
+
+// GetSessions returns the signed-in devices of the user, the current one is marked
+func GetSessions(ctx context.Context, app *app.Ctx) ([]Session, error) {
+	var out []Session
+
+	const url = "http://127.0.0.1:8080/user/sessions"
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.GET,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"GET %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &out); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return out, nil
+}
+
+// RevokeSession signs a device out
+func RevokeSession(ctx context.Context, app *app.Ctx, sessionID int64) error {
+	url := fmt.Sprintf("http://127.0.0.1:8080/user/sessions/%d", sessionID)
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.DELETE,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != http.StatusNoContent {
+		return fmt.Errorf(
+			"DELETE %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	return nil
+}
+
+// Logout signs this device out, with everywhere every device of the user is signed out
+func Logout(ctx context.Context, app *app.Ctx, everywhere bool) error {
+	url := "http://127.0.0.1:8080/user/auth/logout"
+	if everywhere {
+		url += "/all"
+	}
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.POST,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != http.StatusNoContent {
+		return fmt.Errorf(
+			"POST %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	return nil
+}
+
+type TwoFactorStatus struct {
+	Enabled           bool `json:"enabled"`
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+}
+
+// TwoFactorSetup is the pending secret, the uri is rendered as a QR code for authenticator apps
+type TwoFactorSetup struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type twoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type recoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func GetTwoFactorStatus(ctx context.Context, app *app.Ctx) (*TwoFactorStatus, error) {
+	var out TwoFactorStatus
+
+	const url = "http://127.0.0.1:8080/2fa/"
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.GET,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"GET %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &out); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return &out, nil
+}
+
+// SetupTwoFactor starts an enrollment, 2FA stays off until EnableTwoFactor confirms a code
+func SetupTwoFactor(ctx context.Context, app *app.Ctx) (*TwoFactorSetup, error) {
+	var out TwoFactorSetup
+
+	const url = "http://127.0.0.1:8080/2fa/setup"
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.POST,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"POST %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &out); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return &out, nil
+}
+
+// EnableTwoFactor turns 2FA on with a code of the new secret and returns the recovery codes
+func EnableTwoFactor(ctx context.Context, app *app.Ctx, code string) ([]string, error) {
+	return sendRecoveryCodesRequest(ctx, app, "http://127.0.0.1:8080/2fa/enable", code)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes, the old ones stop working
+func RegenerateRecoveryCodes(ctx context.Context, app *app.Ctx, code string) ([]string, error) {
+	return sendRecoveryCodesRequest(ctx, app, "http://127.0.0.1:8080/2fa/recovery-codes", code)
+}
+
+// DisableTwoFactor turns 2FA off, a current or a recovery code is required
+func DisableTwoFactor(ctx context.Context, app *app.Ctx, code string) error {
+	const url = "http://127.0.0.1:8080/2fa/disable"
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.POST,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Data:   twoFactorCodeRequest{Code: code},
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != http.StatusNoContent {
+		return fmt.Errorf(
+			"POST %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	return nil
+}
+
+func sendRecoveryCodesRequest(ctx context.Context, app *app.Ctx, url, code string) ([]string, error) {
+	var out recoveryCodesResponse
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.POST,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Data:   twoFactorCodeRequest{Code: code},
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"POST %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &out); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return out.RecoveryCodes, nil
+}
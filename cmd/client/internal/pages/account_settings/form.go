@@ -0,0 +1,244 @@
+package account_settings
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type submittedMsg struct {
+	err error
+}
+
+// FormModel asks for the inputs of one account change, the password is always asked again
+type FormModel struct {
+	title  string
+	inputs []textinput.Model
+	focus  int
+
+	submit func(values []string) error
+	done   string
+	// final is set when nothing can be done after success, like a deleted account
+	final bool
+
+	loading  bool
+	finished bool
+	status   string
+}
+
+func newPasswordForm(app *app.Ctx) tea.Model {
+	return newForm("Change password", []textinput.Model{
+		passwordInput("Current password: "),
+		passwordInput("New password: "),
+		passwordInput("Repeat new password: "),
+	}, func(v []string) error {
+		if v[1] != v[2] {
+			return errors.New("new passwords do not match")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		tokens, err := ChangePassword(ctx, app, v[0], v[1])
+		if err != nil {
+			return err
+		}
+		app.SetToken(tokens)
+		return nil
+	}, "Password changed, other sessions are signed out", false)
+}
+
+func newUsernameForm(app *app.Ctx) tea.Model {
+	username := textinput.New()
+	username.Prompt = "New username: "
+	username.CharLimit = 64
+
+	return newForm("Change username", []textinput.Model{
+		username,
+		passwordInput("Password: "),
+	}, func(v []string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		return ChangeUsername(ctx, app, v[1], strings.TrimSpace(v[0]))
+	}, "Username changed", false)
+}
+
+func newDeleteForm(app *app.Ctx) tea.Model {
+	confirm := textinput.New()
+	confirm.Prompt = `Type "delete" to confirm: `
+	confirm.CharLimit = 16
+
+	return newForm("Delete account, every item and file is removed for good", []textinput.Model{
+		passwordInput("Password: "),
+		confirm,
+	}, func(v []string) error {
+		if v[1] != "delete" {
+			return errors.New(`type "delete" to confirm`)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		return DeleteAccount(ctx, app, v[0])
+	}, "Account deleted", true)
+}
+
+// newLogoutForm signs out, the tokens are dropped on success and the client has to be restarted
+func newLogoutForm(app *app.Ctx, everywhere bool) tea.Model {
+	confirm := textinput.New()
+	confirm.Prompt = `Type "logout" to confirm: `
+	confirm.CharLimit = 16
+
+	title, done := "Sign out of this device", "Signed out"
+	if everywhere {
+		title, done = "Sign out of every device, this one included", "Signed out everywhere"
+	}
+
+	return newForm(title, []textinput.Model{confirm}, func(v []string) error {
+		if v[0] != "logout" {
+			return errors.New(`type "logout" to confirm`)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := Logout(ctx, app, everywhere); err != nil {
+			return err
+		}
+		app.CreateNewSession()
+		return nil
+	}, done, true)
+}
+
+func newForm(title string, inputs []textinput.Model, submit func([]string) error, done string, final bool) *FormModel {
+	inputs[0].Focus()
+	return &FormModel{
+		title:  title,
+		inputs: inputs,
+		submit: submit,
+		done:   done,
+		final:  final,
+	}
+}
+
+func passwordInput(prompt string) textinput.Model {
+	in := textinput.New()
+	in.Prompt = prompt
+	in.CharLimit = 128
+	in.EchoMode = textinput.EchoPassword
+	in.EchoCharacter = '*'
+	return in
+}
+
+func (m FormModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m FormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case submittedMsg:
+		m.loading = false
+		if x.err != nil {
+			m.status = "error: " + x.err.Error()
+			return m, nil
+		}
+		m.status = m.done
+		for i := range m.inputs {
+			m.inputs[i].SetValue("")
+		}
+		if m.final {
+			m.finished = true
+			m.status += ", press q to quit"
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.finished {
+			if x.String() == "q" || x.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch x.String() {
+		case "up":
+			m.setFocus(m.focus - 1)
+			return m, nil
+
+		case "down", "tab":
+			m.setFocus(m.focus + 1)
+			return m, nil
+
+		case "enter":
+			if m.loading {
+				return m, nil
+			}
+			if m.focus < len(m.inputs)-1 {
+				m.setFocus(m.focus + 1)
+				return m, nil
+			}
+			m.loading = true
+			m.status = ""
+			return m, m.submitCmd()
+
+		case "esc":
+			return m, nav.PreviousPageCmd()
+
+		case "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.inputs[m.focus], cmd = m.inputs[m.focus].Update(msg)
+	return m, cmd
+}
+
+func (m *FormModel) setFocus(idx int) {
+	if idx < 0 || idx >= len(m.inputs) {
+		return
+	}
+	m.inputs[m.focus].Blur()
+	m.focus = idx
+	m.inputs[m.focus].Focus()
+}
+
+func (m FormModel) View() string {
+	var b strings.Builder
+	b.WriteString(m.title + "\n\n")
+
+	for i := range m.inputs {
+		b.WriteString(m.inputs[i].View())
+		b.WriteString("\n")
+	}
+
+	if m.loading {
+		b.WriteString("\nSaving...\n")
+	}
+	if m.status != "" {
+		b.WriteString("\n" + m.status + "\n")
+	}
+
+	b.WriteString("\n(↑/↓ переключение, Enter подтвердить, esc назад)\n")
+	return b.String()
+}
+
+func (m FormModel) submitCmd() tea.Cmd {
+	values := make([]string, len(m.inputs))
+	for i := range m.inputs {
+		values[i] = m.inputs[i].Value()
+	}
+
+	submit := m.submit
+	return func() tea.Msg {
+		return submittedMsg{err: submit(values)}
+	}
+}
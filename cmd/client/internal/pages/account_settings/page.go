@@ -0,0 +1,97 @@
+package account_settings
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type Model struct {
+	app    *app.Ctx
+	items  []string
+	cursor int
+}
+
+const (
+	Sessions   = "sessions"
+	TwoFactor  = "two-factor authentication"
+	Password   = "change password"
+	Username   = "change username"
+	Delete     = "delete account"
+	SignOut    = "sign out"
+	SignOutAll = "sign out everywhere"
+)
+
+func NewPage(app *app.Ctx) tea.Model {
+	return &Model{
+		app:   app,
+		items: []string{Sessions, TwoFactor, Password, Username, SignOut, SignOutAll, Delete},
+	}
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+	case tea.KeyMsg:
+		switch x.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case "enter":
+			switch m.items[m.cursor] {
+			case Sessions:
+				return m, nav.NextPageCmd(newSessionsPage(m.app))
+			case TwoFactor:
+				return m, nav.NextPageCmd(newTwoFactorPage(m.app))
+			case Password:
+				return m, nav.NextPageCmd(newPasswordForm(m.app))
+			case Username:
+				return m, nav.NextPageCmd(newUsernameForm(m.app))
+			case SignOut:
+				return m, nav.NextPageCmd(newLogoutForm(m.app, false))
+			case SignOutAll:
+				return m, nav.NextPageCmd(newLogoutForm(m.app, true))
+			case Delete:
+				return m, nav.NextPageCmd(newDeleteForm(m.app))
+			}
+			return m, nil
+
+		case "b":
+			return m, nav.PreviousPageCmd()
+
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString("Account\n\n")
+
+	for i, item := range m.items {
+		cursor := " "
+		if m.cursor == i {
+			cursor = ">"
+		}
+		b.WriteString(fmt.Sprintf("%s %s\n", cursor, item))
+	}
+
+	b.WriteString("\n[↑/↓] переключение   [Enter] выбрать   [b] назад\n")
+	return b.String()
+}
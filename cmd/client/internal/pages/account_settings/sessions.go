@@ -0,0 +1,168 @@
+package account_settings
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type sessionsLoadedMsg struct {
+	sessions []Session
+	err      error
+}
+
+type sessionRevokedMsg struct {
+	status string
+	err    error
+}
+
+// SessionsModel lists the signed-in devices, "x" signs the selected one out
+type SessionsModel struct {
+	app *app.Ctx
+
+	loading  bool
+	sessions []Session
+	cursor   int
+
+	status string
+}
+
+func newSessionsPage(app *app.Ctx) tea.Model {
+	return &SessionsModel{app: app, loading: true}
+}
+
+func (m SessionsModel) Init() tea.Cmd {
+	return m.fetch()
+}
+
+func (m SessionsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case sessionsLoadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.sessions = x.sessions
+		m.cursor = min(m.cursor, max(len(m.sessions)-1, 0))
+		return m, nil
+
+	case sessionRevokedMsg:
+		if x.err != nil {
+			m.loading = false
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.status = x.status
+		return m, m.fetch()
+
+	case tea.KeyMsg:
+		if m.loading {
+			if x.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch x.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.cursor < len(m.sessions)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case "x":
+			if len(m.sessions) == 0 {
+				return m, nil
+			}
+			s := m.sessions[m.cursor]
+			if s.Current {
+				m.status = "this device can't be signed out here"
+				return m, nil
+			}
+			m.loading = true
+			return m, m.revokeCmd(s)
+
+		case "r":
+			m.loading = true
+			return m, m.fetch()
+
+		case "esc", "b":
+			return m, nav.PreviousPageCmd()
+		}
+	}
+
+	return m, nil
+}
+
+func (m SessionsModel) View() string {
+	var b strings.Builder
+
+	b.WriteString("Sessions\n\n")
+
+	if m.loading {
+		b.WriteString("Loading...\n")
+		return b.String()
+	}
+
+	if len(m.sessions) == 0 {
+		b.WriteString("(no sessions)\n")
+	}
+
+	for i, s := range m.sessions {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		current := ""
+		if s.Current {
+			current = " (this device)"
+		}
+		fmt.Fprintf(&b, "%s%s%s - %s, last used %s, signed in %s\n", prefix, s.DeviceName, current, s.IP,
+			s.LastUsedAt.Local().Format(time.DateTime), s.CreatedAt.Local().Format(time.DateOnly))
+	}
+
+	if m.status != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.status)
+	}
+
+	b.WriteString("\n[↑/↓] переключение   [x] завершить сессию   [r] обновить   [esc] назад\n")
+	return b.String()
+}
+
+func (m SessionsModel) fetch() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		sessions, err := GetSessions(ctx, m.app)
+		return sessionsLoadedMsg{sessions: sessions, err: err}
+	}
+}
+
+func (m SessionsModel) revokeCmd(s Session) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := RevokeSession(ctx, m.app, s.ID); err != nil {
+			return sessionRevokedMsg{err: err}
+		}
+		return sessionRevokedMsg{status: fmt.Sprintf("%s signed out", s.DeviceName)}
+	}
+}
@@ -0,0 +1,296 @@
+package account_settings
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/skip2/go-qrcode"
+)
+
+type twoFactorMode int
+
+const (
+	modeStatus twoFactorMode = iota
+	// modeSetup shows the QR code of the pending secret and waits for a code of it
+	modeSetup
+	modeDisable
+	modeRenew
+	// modeCodes shows the recovery codes, they can't be seen again after leaving the page
+	modeCodes
+)
+
+type twoFactorLoadedMsg struct {
+	status *TwoFactorStatus
+	err    error
+}
+
+type twoFactorSetupMsg struct {
+	setup *TwoFactorSetup
+	err   error
+}
+
+type twoFactorDoneMsg struct {
+	codes  []string
+	status string
+	err    error
+}
+
+// TwoFactorModel turns TOTP on and off, "s" starts the setup, "d" disables, "n" issues new recovery codes
+type TwoFactorModel struct {
+	app *app.Ctx
+
+	mode    twoFactorMode
+	loading bool
+	state   *TwoFactorStatus
+	setup   *TwoFactorSetup
+	codes   []string
+	input   textinput.Model
+
+	status string
+}
+
+func newTwoFactorPage(app *app.Ctx) tea.Model {
+	code := textinput.New()
+	code.Prompt = "Code: "
+	code.CharLimit = 32
+
+	return &TwoFactorModel{app: app, loading: true, input: code}
+}
+
+func (m TwoFactorModel) Init() tea.Cmd {
+	return m.fetch()
+}
+
+func (m TwoFactorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case twoFactorLoadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.state = x.status
+		return m, nil
+
+	case twoFactorSetupMsg:
+		m.loading = false
+		if x.err != nil {
+			m.status = "error: " + x.err.Error()
+			return m, nil
+		}
+		m.setup = x.setup
+		m.askCode(modeSetup)
+		return m, textinput.Blink
+
+	case twoFactorDoneMsg:
+		m.loading = false
+		if x.err != nil {
+			m.status = "error: " + x.err.Error()
+			m.input.SetValue("")
+			return m, nil
+		}
+		m.status = x.status
+		m.setup = nil
+		m.input.Blur()
+		if len(x.codes) > 0 {
+			m.codes = x.codes
+			m.mode = modeCodes
+			return m, nil
+		}
+		m.mode = modeStatus
+		m.loading = true
+		return m, m.fetch()
+
+	case tea.KeyMsg:
+		if m.loading {
+			if x.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		if m.mode == modeSetup || m.mode == modeDisable || m.mode == modeRenew {
+			return m.updateCode(x)
+		}
+
+		switch x.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+
+		case "s":
+			if m.mode != modeStatus || m.state.Enabled {
+				return m, nil
+			}
+			m.loading = true
+			m.status = ""
+			return m, m.setupCmd()
+
+		case "d":
+			if m.mode == modeStatus && m.state.Enabled {
+				m.askCode(modeDisable)
+				return m, textinput.Blink
+			}
+			return m, nil
+
+		case "n":
+			if m.mode == modeStatus && m.state.Enabled {
+				m.askCode(modeRenew)
+				return m, textinput.Blink
+			}
+			return m, nil
+
+		case "esc", "b":
+			if m.mode == modeCodes {
+				m.codes = nil
+				m.mode = modeStatus
+				m.loading = true
+				return m, m.fetch()
+			}
+			return m, nav.PreviousPageCmd()
+		}
+	}
+
+	return m, nil
+}
+
+// updateCode handles the code input of the setup, disable and renew steps
+func (m TwoFactorModel) updateCode(x tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch x.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc":
+		m.mode = modeStatus
+		m.setup = nil
+		m.status = ""
+		m.input.Blur()
+		return m, nil
+
+	case "enter":
+		code := strings.TrimSpace(m.input.Value())
+		if code == "" {
+			return m, nil
+		}
+		m.loading = true
+		m.status = ""
+		return m, m.submitCmd(m.mode, code)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(x)
+	return m, cmd
+}
+
+func (m *TwoFactorModel) askCode(mode twoFactorMode) {
+	m.mode = mode
+	m.status = ""
+	m.input.SetValue("")
+	m.input.Focus()
+}
+
+func (m TwoFactorModel) View() string {
+	var b strings.Builder
+
+	b.WriteString("Two-factor authentication\n\n")
+
+	if m.loading {
+		b.WriteString("Loading...\n")
+		return b.String()
+	}
+
+	switch m.mode {
+	case modeStatus:
+		if m.state.Enabled {
+			fmt.Fprintf(&b, "Enabled, %d recovery codes left\n", m.state.RecoveryCodesLeft)
+		} else {
+			b.WriteString("Disabled, the master password alone signs in\n")
+		}
+
+	case modeSetup:
+		b.WriteString("Scan the QR code with an authenticator app and enter the code it shows\n\n")
+		if qr, err := qrcode.New(m.setup.URI, qrcode.Medium); err == nil {
+			b.WriteString(qr.ToSmallString(false))
+		}
+		fmt.Fprintf(&b, "\nSecret: %s\n%s\n\n", m.setup.Secret, m.setup.URI)
+		b.WriteString(m.input.View() + "\n")
+
+	case modeDisable:
+		b.WriteString("Enter a code of the authenticator app or a recovery code to disable 2FA\n\n")
+		b.WriteString(m.input.View() + "\n")
+
+	case modeRenew:
+		b.WriteString("Enter a code of the authenticator app to replace the recovery codes\n\n")
+		b.WriteString(m.input.View() + "\n")
+
+	case modeCodes:
+		b.WriteString("Recovery codes, each signs in once without the app. Save them now, they are not shown again\n\n")
+		for _, c := range m.codes {
+			fmt.Fprintf(&b, "  %s\n", c)
+		}
+	}
+
+	if m.status != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.status)
+	}
+
+	switch m.mode {
+	case modeStatus:
+		if m.state.Enabled {
+			b.WriteString("\n[d] отключить   [n] новые коды восстановления   [esc] назад\n")
+		} else {
+			b.WriteString("\n[s] включить   [esc] назад\n")
+		}
+	case modeCodes:
+		b.WriteString("\n[esc] назад\n")
+	default:
+		b.WriteString("\n[Enter] подтвердить   [esc] отмена\n")
+	}
+	return b.String()
+}
+
+func (m TwoFactorModel) fetch() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		status, err := GetTwoFactorStatus(ctx, m.app)
+		return twoFactorLoadedMsg{status: status, err: err}
+	}
+}
+
+func (m TwoFactorModel) setupCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		setup, err := SetupTwoFactor(ctx, m.app)
+		return twoFactorSetupMsg{setup: setup, err: err}
+	}
+}
+
+func (m TwoFactorModel) submitCmd(mode twoFactorMode, code string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		switch mode {
+		case modeSetup:
+			codes, err := EnableTwoFactor(ctx, m.app, code)
+			return twoFactorDoneMsg{codes: codes, status: "2FA enabled", err: err}
+		case modeRenew:
+			codes, err := RegenerateRecoveryCodes(ctx, m.app, code)
+			return twoFactorDoneMsg{codes: codes, status: "Recovery codes replaced", err: err}
+		default:
+			err := DisableTwoFactor(ctx, m.app, code)
+			return twoFactorDoneMsg{status: "2FA disabled", err: err}
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package attachments
+
+import (
+	"client/internal/app"
+	"client/internal/pages/obj_file/load"
+	"client/pkg/http_request_sender"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// item types files can be attached to
+const (
+	ItemAccount = "account"
+	ItemCard    = "card"
+	ItemText    = "text"
+)
+
+type Attachment struct {
+	ID          int64     `json:"attachment_id"`
+	FileID      int64     `json:"file_id"`
+	Title       string    `json:"title"`
+	SizeBytes   int64     `json:"size_bytes"`
+	ContentType string    `json:"content_type"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// LoadedMsg is sent by LoadCmd, get pages keep the list to render their attachments section
+type LoadedMsg struct {
+	Items []Attachment
+	Err   error
+}
+
+// List gets the files attached to an item
+func List(ctx context.Context, app *app.Ctx, itemType string, itemID int64) ([]Attachment, error) {
+	var respData []Attachment
+
+	url := fmt.Sprintf("http://127.0.0.1:8080/attachment/%s/%d", itemType, itemID)
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.GET,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// nothing attached
+	if response.StatusCode() == http.StatusNoContent {
+		return nil, nil
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"GET %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return respData, nil
+}
+
+// Attach links an uploaded file to an item
+func Attach(ctx context.Context, app *app.Ctx, itemType string, itemID, fileID int64) error {
+	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.POST, http_request_sender.SendDataCmd{
+		URL:    fmt.Sprintf("http://127.0.0.1:8080/attachment/%s/%d", itemType, itemID),
+		Data:   map[string]int64{"file_id": fileID},
+		Client: app.HTTP,
+		JWT:    app.GetToken(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return errors.New(string(response.Body()))
+	}
+
+	return nil
+}
+
+// Download saves an attached file, it works for items shared with the user too
+func Download(app *app.Ctx, itemType string, itemID, fileID int64) (string, error) {
+	url := fmt.Sprintf("http://127.0.0.1:8080/attachment/%s/%d/%d", itemType, itemID, fileID)
+	return load.Download(app, url, fmt.Sprintf("file-%d", fileID))
+}
+
+// Detach removes the link, the file stays in the file list
+func Detach(ctx context.Context, app *app.Ctx, itemType string, itemID, fileID int64) error {
+	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.DELETE, http_request_sender.SendDataCmd{
+		URL:    fmt.Sprintf("http://127.0.0.1:8080/attachment/%s/%d/%d", itemType, itemID, fileID),
+		Client: app.HTTP,
+		JWT:    app.GetToken(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != http.StatusNoContent {
+		return errors.New(string(response.Body()))
+	}
+
+	return nil
+}
+
+// LoadCmd fetches the attachments of an item in the background
+func LoadCmd(app *app.Ctx, itemType string, itemID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		items, err := List(ctx, app, itemType, itemID)
+		return LoadedMsg{Items: items, Err: err}
+	}
+}
+
+// Render is the attachments section of a get page, empty when nothing is attached
+func Render(items []Attachment) string {
+	if len(items) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("Attachments:\n")
+	for _, it := range items {
+		fmt.Fprintf(&b, "  - %s (%s)\n", it.Title, formatSize(it.SizeBytes))
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
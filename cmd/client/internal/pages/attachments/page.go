@@ -0,0 +1,239 @@
+package attachments
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+	file_list "client/internal/pages/obj_file/list"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type filesLoadedMsg struct {
+	items []file_list.File
+	err   error
+}
+
+// doneMsg reports a finished download, attach or detach
+type doneMsg struct {
+	status string
+	err    error
+}
+
+// Model lists the attachments of one item, "a" switches to the list of
+// uploaded files to pick a new attachment
+type Model struct {
+	app      *app.Ctx
+	itemType string
+	itemID   int64
+
+	loading bool
+	items   []Attachment
+	cursor  int
+
+	// picking is true while the user chooses a file to attach
+	picking bool
+	files   []file_list.File
+
+	status string
+}
+
+func NewPage(app *app.Ctx, itemType string, itemID int64) tea.Model {
+	return &Model{
+		app:      app,
+		itemType: itemType,
+		itemID:   itemID,
+		loading:  true,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return LoadCmd(m.app, m.itemType, m.itemID)
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case LoadedMsg:
+		m.loading = false
+		if x.Err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.Err))
+		}
+		m.items = x.Items
+		m.cursor = min(m.cursor, max(len(m.items)-1, 0))
+		return m, nil
+
+	case filesLoadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.files, m.picking, m.cursor = x.items, true, 0
+		return m, nil
+
+	case doneMsg:
+		if x.err != nil {
+			m.loading = false
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.status, m.picking, m.loading = x.status, false, true
+		return m, LoadCmd(m.app, m.itemType, m.itemID)
+
+	case tea.KeyMsg:
+		if m.loading {
+			if x.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch x.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.cursor < m.size()-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case "enter":
+			if m.size() == 0 {
+				return m, nil
+			}
+			m.loading = true
+			if m.picking {
+				return m, m.attachCmd(m.files[m.cursor].ID)
+			}
+			return m, downloadCmd(m.app, m.itemType, m.itemID, m.items[m.cursor].FileID)
+
+		case "a":
+			if m.picking {
+				return m, nil
+			}
+			m.loading = true
+			return m, fetchFilesCmd(m.app)
+
+		case "x":
+			if m.picking || len(m.items) == 0 {
+				return m, nil
+			}
+			m.loading = true
+			return m, m.detachCmd(m.items[m.cursor].FileID)
+
+		case "esc", "tab":
+			if m.picking {
+				m.picking, m.cursor = false, 0
+				return m, nil
+			}
+			return m, nav.PreviousPageCmd()
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+
+	if m.picking {
+		b.WriteString("Choose a file to attach\n\n")
+	} else {
+		fmt.Fprintf(&b, "Attachments of %s #%d\n\n", m.itemType, m.itemID)
+	}
+
+	if m.loading {
+		b.WriteString("Loading...\n")
+		return b.String()
+	}
+
+	if m.size() == 0 {
+		b.WriteString("(empty)\n")
+	}
+
+	for i := 0; i < m.size(); i++ {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		if m.picking {
+			fmt.Fprintf(&b, "%s%s\n", prefix, m.files[i].Title)
+		} else {
+			fmt.Fprintf(&b, "%s%s (%s)\n", prefix, m.items[i].Title, formatSize(m.items[i].SizeBytes))
+		}
+	}
+
+	if m.status != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.status)
+	}
+
+	if m.picking {
+		b.WriteString("\n[↑/↓] переключение   [enter] прикрепить   [esc] отмена\n")
+	} else {
+		b.WriteString("\n[↑/↓] переключение   [enter] скачать   [a] прикрепить   [x] открепить   [esc] назад\n")
+	}
+	return b.String()
+}
+
+// size is the length of the list on screen
+func (m Model) size() int {
+	if m.picking {
+		return len(m.files)
+	}
+	return len(m.items)
+}
+
+func (m Model) attachCmd(fileID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := Attach(ctx, m.app, m.itemType, m.itemID, fileID); err != nil {
+			return doneMsg{err: err}
+		}
+		return doneMsg{status: "File attached"}
+	}
+}
+
+func (m Model) detachCmd(fileID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := Detach(ctx, m.app, m.itemType, m.itemID, fileID); err != nil {
+			return doneMsg{err: err}
+		}
+		return doneMsg{status: "File detached"}
+	}
+}
+
+func downloadCmd(app *app.Ctx, itemType string, itemID, fileID int64) tea.Cmd {
+	return func() tea.Msg {
+		path, err := Download(app, itemType, itemID, fileID)
+		if err != nil {
+			return doneMsg{err: err}
+		}
+		return doneMsg{status: "Saved to " + path}
+	}
+}
+
+func fetchFilesCmd(app *app.Ctx) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		items, err := file_list.GetFileList(ctx, app)
+		return filesLoadedMsg{items: items, err: err}
+	}
+}
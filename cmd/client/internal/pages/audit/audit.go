@@ -0,0 +1,131 @@
+package audit
+
+import (
+	"client/internal/app"
+	"client/pkg/http_request_sender"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Actions are the audit filters cycled on the page, "" shows every action
+var Actions = []string{"", "login", "login_failed", "refresh", "item_read", "decrypt", "create", "update", "delete", "download",
+	"password_change", "username_change", "account_delete", "session_revoke", "logout", "logout_all", "refresh_reuse",
+	"two_factor_enable", "two_factor_disable", "recovery_code_use", "recovery_codes_renew"}
+
+type Event struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	OwnerID   int64     `json:"owner_id"`
+	Action    string    `json:"action"`
+	ItemType  string    `json:"item_type"`
+	ItemID    int64     `json:"item_id"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	Details   string    `json:"details"`
+	CreatedAt time.Time `json:"created_at"`
+	Hash      string    `json:"hash"`
+}
+
+type Verification struct {
+	Valid      bool      `json:"valid"`
+	VerifiedAt time.Time `json:"verified_at"`
+}
+
+// GetEvents gets the newest events older than before, before == 0 starts from the newest one
+func GetEvents(ctx context.Context, app *app.Ctx, action string, before int64) ([]Event, error) {
+	var respData []Event
+
+	q := url.Values{}
+	if action != "" {
+		q.Set("action", action)
+	}
+	if before != 0 {
+		q.Set("before", strconv.FormatInt(before, 10))
+	}
+
+	u := "http://127.0.0.1:8080/audit"
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.GET,
+		http_request_sender.SendDataCmd{
+			URL:    u,
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() == http.StatusNoContent {
+		return nil, nil
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"GET %s failed: status=%d body=%s",
+			u,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return respData, nil
+}
+
+// Verify returns the latest check of the hash chain, the server runs it on a schedule
+func Verify(ctx context.Context, app *app.Ctx) (*Verification, error) {
+	var respData Verification
+
+	const url = "http://127.0.0.1:8080/audit/verify"
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.GET,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"GET %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return &respData, nil
+}
+
+// Describe is the result of the chain check shown under the list
+func (v *Verification) Describe() string {
+	at := v.VerifiedAt.Local().Format("2006-01-02 15:04")
+	if v.Valid {
+		return fmt.Sprintf("chain was intact at %s", at)
+	}
+	return fmt.Sprintf("chain was BROKEN at %s, earlier entries were changed or removed", at)
+}
@@ -0,0 +1,213 @@
+package audit
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type listLoadedMsg struct {
+	items []Event
+	err   error
+}
+
+type verifiedMsg struct {
+	res *Verification
+	err error
+}
+
+type Model struct {
+	app     *app.Ctx
+	loading bool
+	items   []Event
+	cursor  int
+	// action is the index in Actions
+	action int
+	// pages keeps the before ids of the pages shown so far, the last one is the current page
+	pages  []int64
+	verify string
+}
+
+func NewPage(app *app.Ctx) tea.Model {
+	return &Model{
+		app:     app,
+		loading: true,
+		pages:   []int64{0},
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return fetchListCmd(m.app, Actions[m.action], 0)
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case listLoadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.items = x.items
+		m.cursor = 0
+		return m, nil
+
+	case verifiedMsg:
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.verify = x.res.Describe()
+		return m, nil
+
+	case tea.KeyMsg:
+		switch x.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case "f":
+			if m.loading {
+				return m, nil
+			}
+			m.action = (m.action + 1) % len(Actions)
+			return m.reload()
+
+		case "n":
+			// older events, the server pages back by id
+			if m.loading || len(m.items) == 0 {
+				return m, nil
+			}
+			m.pages = append(m.pages, m.items[len(m.items)-1].ID)
+			return m, m.load()
+
+		case "p":
+			if m.loading || len(m.pages) < 2 {
+				return m, nil
+			}
+			m.pages = m.pages[:len(m.pages)-1]
+			return m, m.load()
+
+		case "v":
+			m.verify = "verifying..."
+			return m, verifyCmd(m.app)
+
+		case "r":
+			return m.reload()
+
+		case "b":
+			return m, nav.PreviousPageCmd()
+
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString("Audit log\n\n")
+
+	filter := Actions[m.action]
+	if filter == "" {
+		filter = "all"
+	}
+	fmt.Fprintf(&b, "Action: %s   Page: %d\n\n", filter, len(m.pages))
+
+	if m.loading {
+		b.WriteString("Loading...\n")
+		return b.String()
+	}
+
+	if len(m.items) == 0 {
+		b.WriteString("No events\n")
+	}
+
+	for i, it := range m.items {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		fmt.Fprintf(&b, "%s%s  %-12s %s\n", prefix, it.CreatedAt.Local().Format("2006-01-02 15:04:05"), it.Action, describe(it))
+	}
+
+	if len(m.items) > 0 {
+		it := m.items[m.cursor]
+		fmt.Fprintf(&b, "\n#%d  ip: %s  agent: %s\nhash: %s\n", it.ID, it.IP, it.UserAgent, it.Hash)
+	}
+
+	if m.verify != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.verify)
+	}
+
+	b.WriteString("\n[↑/↓] переключение   [f] фильтр   [n/p] старше/новее   [v] проверить цепочку   [r] обновить   [b] назад\n")
+	return b.String()
+}
+
+func (m Model) reload() (tea.Model, tea.Cmd) {
+	m.pages = []int64{0}
+	return m, m.load()
+}
+
+func (m *Model) load() tea.Cmd {
+	m.loading = true
+	return fetchListCmd(m.app, Actions[m.action], m.pages[len(m.pages)-1])
+}
+
+// describe names the item and who acted, events of other users happen on shared items
+func describe(e Event) string {
+	var parts []string
+	if e.ItemType != "" {
+		parts = append(parts, fmt.Sprintf("%s #%d", e.ItemType, e.ItemID))
+	}
+	if e.OwnerID != 0 && e.UserID != e.OwnerID {
+		parts = append(parts, fmt.Sprintf("by user #%d", e.UserID))
+	}
+	if e.Details != "" {
+		parts = append(parts, e.Details)
+	}
+	return strings.Join(parts, "  ")
+}
+
+func fetchListCmd(app *app.Ctx, action string, before int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		items, err := GetEvents(ctx, app, action, before)
+		return listLoadedMsg{
+			items: items,
+			err:   err,
+		}
+	}
+}
+
+func verifyCmd(app *app.Ctx) tea.Cmd {
+	return func() tea.Msg {
+		// the whole chain is walked, it takes longer than a list
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		res, err := Verify(ctx, app)
+		return verifiedMsg{
+			res: res,
+			err: err,
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package emergency
+
+import (
+	"client/internal/app"
+	"client/pkg/http_request_sender"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// access types, takeover also lets the contact change items
+var AccessTypes = []string{"view", "takeover"}
+
+type Contact struct {
+	ID          int64      `json:"contact_id"`
+	Grantor     string     `json:"grantor"`
+	Grantee     string     `json:"grantee"`
+	AccessType  string     `json:"access_type"`
+	WaitHours   int64      `json:"wait_hours"`
+	Status      string     `json:"status"`
+	RequestedAt *time.Time `json:"requested_at,omitempty"`
+	AccessAt    *time.Time `json:"access_at,omitempty"`
+	Granted     bool       `json:"granted"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type Item struct {
+	Type  string `json:"type"`
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+// Trusted returns emergency contacts of the current user
+func Trusted(ctx context.Context, app *app.Ctx) ([]Contact, error) {
+	var out []Contact
+	return out, get(ctx, app, "http://127.0.0.1:8080/emergency/trusted", &out)
+}
+
+// Granted returns users who made the current user their emergency contact
+func Granted(ctx context.Context, app *app.Ctx) ([]Contact, error) {
+	var out []Contact
+	return out, get(ctx, app, "http://127.0.0.1:8080/emergency/granted", &out)
+}
+
+// Vault lists items of the grantor once access is granted
+func Vault(ctx context.Context, app *app.Ctx, contactID int64) ([]Item, error) {
+	var out []Item
+	return out, get(ctx, app, fmt.Sprintf("http://127.0.0.1:8080/emergency/%d/vault", contactID), &out)
+}
+
+// Add makes username a trusted contact, zero waitHours uses the server default
+func Add(ctx context.Context, app *app.Ctx, username, accessType string, waitHours int64) error {
+	data := map[string]any{"username": username, "access_type": accessType, "wait_hours": waitHours}
+	return send(ctx, app, http_request_sender.POST, "http://127.0.0.1:8080/emergency/", data, http.StatusOK)
+}
+
+// Remove revokes a contact, for the contact it steps down
+func Remove(ctx context.Context, app *app.Ctx, contactID int64) error {
+	url := fmt.Sprintf("http://127.0.0.1:8080/emergency/%d", contactID)
+	return send(ctx, app, http_request_sender.DELETE, url, nil, http.StatusNoContent)
+}
+
+// Transition runs request, approve or reject on the contact
+func Transition(ctx context.Context, app *app.Ctx, contactID int64, action string) error {
+	url := fmt.Sprintf("http://127.0.0.1:8080/emergency/%d/%s", contactID, action)
+	return send(ctx, app, http_request_sender.POST, url, nil, http.StatusOK)
+}
+
+// NextAccessType cycles through AccessTypes
+func NextAccessType(t string) string {
+	for i, a := range AccessTypes {
+		if a == t {
+			return AccessTypes[(i+1)%len(AccessTypes)]
+		}
+	}
+	return AccessTypes[0]
+}
+
+// Describe is the state of the contact shown in lists
+func Describe(c Contact) string {
+	switch {
+	case c.Granted:
+		return "access granted"
+	case c.Status == "requested" && c.AccessAt != nil:
+		return "requested, access at " + c.AccessAt.Local().Format("2006-01-02 15:04")
+	default:
+		return c.Status
+	}
+}
+
+// help func
+
+func get(ctx context.Context, app *app.Ctx, url string, out any) error {
+	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.GET, http_request_sender.SendDataCmd{
+		URL:    url,
+		Client: app.HTTP,
+		JWT:    app.GetToken(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// empty list
+	if response.StatusCode() == http.StatusNoContent {
+		return nil
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return fmt.Errorf(
+			"GET %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), out); err != nil {
+		return fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+func send(ctx context.Context, app *app.Ctx, method http_request_sender.Method, url string, data any, want int) error {
+	response, err := http_request_sender.SendJSONRequest(ctx, method, http_request_sender.SendDataCmd{
+		URL:    url,
+		Data:   data,
+		Client: app.HTTP,
+		JWT:    app.GetToken(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != want {
+		return errors.New(string(response.Body()))
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package emergency
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type grantedLoadedMsg struct {
+	contacts []Contact
+	err      error
+}
+
+// GrantedModel lists users who trust the current user, enter requests access
+// or opens the vault once the waiting period is over
+type GrantedModel struct {
+	app *app.Ctx
+
+	loading  bool
+	contacts []Contact
+	cursor   int
+
+	status string
+}
+
+func NewGrantedPage(app *app.Ctx) tea.Model {
+	return &GrantedModel{
+		app:     app,
+		loading: true,
+	}
+}
+
+func (m GrantedModel) Init() tea.Cmd {
+	return m.fetch()
+}
+
+func (m GrantedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case grantedLoadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.contacts = x.contacts
+		m.cursor = min(m.cursor, max(len(m.contacts)-1, 0))
+		return m, nil
+
+	case doneMsg:
+		if x.err != nil {
+			m.loading = false
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.status = x.status
+		return m, m.fetch()
+
+	case tea.KeyMsg:
+		if m.loading {
+			if x.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch x.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.cursor < len(m.contacts)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case "enter":
+			if len(m.contacts) == 0 {
+				return m, nil
+			}
+			c := m.contacts[m.cursor]
+			switch {
+			case c.Granted:
+				return m, nav.NextPageCmd(NewVaultPage(m.app, c))
+			case c.Status == "requested":
+				m.status = "waiting period is running"
+				return m, nil
+			}
+			m.loading = true
+			return m, transitionCmd(m.app, c.ID, "request", fmt.Sprintf("access to the vault of %s requested", c.Grantor))
+
+		case "x":
+			if len(m.contacts) == 0 {
+				return m, nil
+			}
+			c := m.contacts[m.cursor]
+			m.loading = true
+			return m, m.removeCmd(c)
+
+		case "r":
+			m.loading = true
+			return m, m.fetch()
+
+		case "esc", "b":
+			return m, nav.PreviousPageCmd()
+		}
+	}
+
+	return m, nil
+}
+
+func (m GrantedModel) View() string {
+	var b strings.Builder
+
+	b.WriteString("Trusted by\n\n")
+
+	if m.loading {
+		b.WriteString("Loading...\n")
+		return b.String()
+	}
+
+	if len(m.contacts) == 0 {
+		b.WriteString("(nobody)\n")
+	}
+
+	for i, c := range m.contacts {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		fmt.Fprintf(&b, "%s%s (%s, wait %dh) - %s\n", prefix, c.Grantor, c.AccessType, c.WaitHours, Describe(c))
+	}
+
+	if m.status != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.status)
+	}
+
+	b.WriteString("\n[↑/↓] переключение   [enter] запросить / открыть   [x] отказаться   [r] обновить   [esc] назад\n")
+	return b.String()
+}
+
+func (m GrantedModel) fetch() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		contacts, err := Granted(ctx, m.app)
+		return grantedLoadedMsg{contacts: contacts, err: err}
+	}
+}
+
+func (m GrantedModel) removeCmd(c Contact) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := Remove(ctx, m.app, c.ID); err != nil {
+			return doneMsg{err: err}
+		}
+		return doneMsg{status: fmt.Sprintf("you are no longer a contact of %s", c.Grantor)}
+	}
+}
@@ -0,0 +1,289 @@
+package emergency
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type loadedMsg struct {
+	contacts []Contact
+	err      error
+}
+
+// doneMsg reports a finished change of a contact
+type doneMsg struct {
+	status string
+	err    error
+}
+
+// Model lists trusted contacts of the user, "n" asks for a username and the waiting
+// period, tab cycles the access type before adding
+type Model struct {
+	app *app.Ctx
+
+	loading  bool
+	contacts []Contact
+	cursor   int
+
+	adding     bool
+	username   textinput.Model
+	wait       textinput.Model
+	accessType string
+
+	status string
+}
+
+func NewPage(app *app.Ctx) tea.Model {
+	username := textinput.New()
+	username.Prompt = "Username: "
+	username.CharLimit = 64
+
+	wait := textinput.New()
+	wait.Prompt = "Wait (hours, empty = default): "
+	wait.CharLimit = 5
+
+	return &Model{
+		app:        app,
+		loading:    true,
+		username:   username,
+		wait:       wait,
+		accessType: AccessTypes[0],
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.fetch()
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case loadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.contacts = x.contacts
+		m.cursor = min(m.cursor, max(len(m.contacts)-1, 0))
+		return m, nil
+
+	case doneMsg:
+		if x.err != nil {
+			m.loading = false
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.status = x.status
+		return m, m.fetch()
+
+	case tea.KeyMsg:
+		if m.adding {
+			switch x.String() {
+			case "enter":
+				username := strings.TrimSpace(m.username.Value())
+				waitHours, err := parseHours(m.wait.Value())
+				if err != nil {
+					m.status = "wait must be a number of hours"
+					return m, nil
+				}
+				m.adding = false
+				m.username.Blur()
+				m.wait.Blur()
+				if username == "" {
+					return m, nil
+				}
+				m.loading = true
+				return m, m.addCmd(username, m.accessType, waitHours)
+			case "tab":
+				m.accessType = NextAccessType(m.accessType)
+				return m, nil
+			case "up", "down":
+				// switches between the username and the waiting period
+				if m.username.Focused() {
+					m.username.Blur()
+					return m, m.wait.Focus()
+				}
+				m.wait.Blur()
+				return m, m.username.Focus()
+			case "esc":
+				m.adding = false
+				m.username.Blur()
+				m.wait.Blur()
+				return m, nil
+			}
+
+			var cmd tea.Cmd
+			if m.username.Focused() {
+				m.username, cmd = m.username.Update(msg)
+			} else {
+				m.wait, cmd = m.wait.Update(msg)
+			}
+			return m, cmd
+		}
+
+		if m.loading {
+			if x.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch x.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.cursor < len(m.contacts)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case "n":
+			m.adding, m.accessType, m.status = true, AccessTypes[0], ""
+			m.username.SetValue("")
+			m.wait.SetValue("")
+			return m, m.username.Focus()
+
+		case "a":
+			// approve skips the rest of the waiting period
+			return m.transition("approve", "approved")
+
+		case "d":
+			return m.transition("reject", "rejected")
+
+		case "x":
+			if len(m.contacts) == 0 {
+				return m, nil
+			}
+			m.loading = true
+			return m, m.removeCmd(m.contacts[m.cursor])
+
+		case "g":
+			return m, nav.NextPageCmd(NewGrantedPage(m.app))
+
+		case "esc", "b":
+			return m, nav.PreviousPageCmd()
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString("Emergency contacts\n\n")
+
+	if m.adding {
+		b.WriteString(m.username.View() + "\n")
+		b.WriteString(m.wait.View() + "\n")
+		fmt.Fprintf(&b, "Access: %s\n\n", m.accessType)
+		if m.status != "" {
+			fmt.Fprintf(&b, "%s\n\n", m.status)
+		}
+		b.WriteString("[enter] добавить   [↑/↓] поле   [tab] сменить доступ   [esc] отмена\n")
+		return b.String()
+	}
+
+	if m.loading {
+		b.WriteString("Loading...\n")
+		return b.String()
+	}
+
+	if len(m.contacts) == 0 {
+		b.WriteString("(no contacts)\n")
+	}
+
+	for i, c := range m.contacts {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		fmt.Fprintf(&b, "%s%s (%s, wait %dh) - %s\n", prefix, c.Grantee, c.AccessType, c.WaitHours, Describe(c))
+	}
+
+	if m.status != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.status)
+	}
+
+	b.WriteString("\n[↑/↓] переключение   [n] добавить   [a] одобрить   [d] отклонить   [x] удалить   [g] мне доверяют   [esc] назад\n")
+	return b.String()
+}
+
+func (m Model) transition(action, done string) (tea.Model, tea.Cmd) {
+	if len(m.contacts) == 0 {
+		return m, nil
+	}
+	c := m.contacts[m.cursor]
+	m.loading = true
+	return m, transitionCmd(m.app, c.ID, action, fmt.Sprintf("request of %s %s", c.Grantee, done))
+}
+
+func (m Model) fetch() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		contacts, err := Trusted(ctx, m.app)
+		return loadedMsg{contacts: contacts, err: err}
+	}
+}
+
+func (m Model) addCmd(username, accessType string, waitHours int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := Add(ctx, m.app, username, accessType, waitHours); err != nil {
+			return doneMsg{err: err}
+		}
+		return doneMsg{status: fmt.Sprintf("%s added with %s access", username, accessType)}
+	}
+}
+
+func (m Model) removeCmd(c Contact) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := Remove(ctx, m.app, c.ID); err != nil {
+			return doneMsg{err: err}
+		}
+		return doneMsg{status: fmt.Sprintf("%s removed", c.Grantee)}
+	}
+}
+
+func transitionCmd(app *app.Ctx, contactID int64, action, status string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := Transition(ctx, app, contactID, action); err != nil {
+			return doneMsg{err: err}
+		}
+		return doneMsg{status: status}
+	}
+}
+
+func parseHours(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
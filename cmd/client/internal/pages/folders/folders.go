@@ -0,0 +1,124 @@
+package folders
+
+import (
+	"client/internal/app"
+	"client/pkg/http_request_sender"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// object types returned in folder listings
+const (
+	ItemAccount = "account"
+	ItemCard    = "card"
+	ItemText    = "text"
+	ItemFile    = "file"
+	ItemSSH     = "ssh"
+	ItemCert    = "cert"
+)
+
+type Folder struct {
+	ID        int64     `json:"folder_id"`
+	ParentID  *int64    `json:"parent_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Item struct {
+	Type  string `json:"type"`
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+type Contents struct {
+	Folder  *Folder  `json:"folder"`
+	Folders []Folder `json:"folders"`
+	Items   []Item   `json:"items"`
+}
+
+// GetContents lists a folder with objects of all types, nil folderID is the root
+func GetContents(ctx context.Context, app *app.Ctx, folderID *int64) (*Contents, error) {
+	url := "http://127.0.0.1:8080/folder/root"
+	if folderID != nil {
+		url = fmt.Sprintf("http://127.0.0.1:8080/folder/%d", *folderID)
+	}
+
+	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.GET, http_request_sender.SendDataCmd{
+		URL:    url,
+		Client: app.HTTP,
+		JWT:    app.GetToken(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"GET %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	var respData Contents
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return &respData, nil
+}
+
+func CreateFolder(ctx context.Context, app *app.Ctx, parentID *int64, name string) error {
+	return send(ctx, app, http_request_sender.POST, "http://127.0.0.1:8080/folder/", map[string]any{
+		"name":      name,
+		"parent_id": parentID,
+	}, http.StatusOK)
+}
+
+func RenameFolder(ctx context.Context, app *app.Ctx, folderID int64, name string) error {
+	url := fmt.Sprintf("http://127.0.0.1:8080/folder/%d", folderID)
+	return send(ctx, app, http_request_sender.PUT, url, map[string]string{"name": name}, http.StatusOK)
+}
+
+// MoveFolder puts a folder under parentID or into the root when parentID is nil
+func MoveFolder(ctx context.Context, app *app.Ctx, folderID int64, parentID *int64) error {
+	url := fmt.Sprintf("http://127.0.0.1:8080/folder/%d/move", folderID)
+	return send(ctx, app, http_request_sender.PUT, url, map[string]*int64{"parent_id": parentID}, http.StatusOK)
+}
+
+// DeleteFolder removes the folder with subfolders, objects inside are moved to the root
+func DeleteFolder(ctx context.Context, app *app.Ctx, folderID int64) error {
+	url := fmt.Sprintf("http://127.0.0.1:8080/folder/%d", folderID)
+	return send(ctx, app, http_request_sender.DELETE, url, nil, http.StatusNoContent)
+}
+
+// MoveItem places an object into folderID or into the root when folderID is nil
+func MoveItem(ctx context.Context, app *app.Ctx, it Item, folderID *int64) error {
+	url := fmt.Sprintf("http://127.0.0.1:8080/folder/item/%s/%d", it.Type, it.ID)
+	return send(ctx, app, http_request_sender.PUT, url, map[string]*int64{"folder_id": folderID}, http.StatusNoContent)
+}
+
+// help func
+
+func send(ctx context.Context, app *app.Ctx, method http_request_sender.Method, url string, data any, want int) error {
+	response, err := http_request_sender.SendJSONRequest(ctx, method, http_request_sender.SendDataCmd{
+		URL:    url,
+		Data:   data,
+		Client: app.HTTP,
+		JWT:    app.GetToken(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != want {
+		return errors.New(string(response.Body()))
+	}
+
+	return nil
+}
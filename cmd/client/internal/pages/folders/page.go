@@ -0,0 +1,337 @@
+package folders
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+	get_account "client/internal/pages/obj_account/get"
+	get_card "client/internal/pages/obj_card/get"
+	get_cert "client/internal/pages/obj_cert/get"
+	"client/internal/pages/obj_file/load"
+	get_ssh "client/internal/pages/obj_ssh/get"
+	get_text "client/internal/pages/obj_text/get"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type contentsLoadedMsg struct {
+	contents *Contents
+	err      error
+}
+
+// doneMsg reports a finished create, rename, delete or move
+type doneMsg struct {
+	err error
+}
+
+type inputMode int
+
+const (
+	inputNone inputMode = iota
+	inputCreate
+	inputRename
+)
+
+// entry is a row of the listing, either a subfolder or an object
+type entry struct {
+	folder *Folder
+	item   *Item
+}
+
+func (e entry) title() string {
+	if e.folder != nil {
+		return e.folder.Name + "/"
+	}
+	return fmt.Sprintf("%s [%s]", e.item.Title, e.item.Type)
+}
+
+// Model browses the folder tree, path is the way from the root to the open folder
+type Model struct {
+	app     *app.Ctx
+	loading bool
+	path    []Folder
+	entries []entry
+	cursor  int
+
+	// moving is the folder or object cut with "m", "p" puts it into the open folder
+	moving *entry
+
+	mode  inputMode
+	input textinput.Model
+}
+
+func NewPage(app *app.Ctx) tea.Model {
+	input := textinput.New()
+	input.Prompt = "Name: "
+	input.CharLimit = 128
+
+	return &Model{
+		app:     app,
+		loading: true,
+		input:   input,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.fetch()
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case contentsLoadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		entries := make([]entry, 0, len(x.contents.Folders)+len(x.contents.Items))
+		for i := range x.contents.Folders {
+			entries = append(entries, entry{folder: &x.contents.Folders[i]})
+		}
+		for i := range x.contents.Items {
+			entries = append(entries, entry{item: &x.contents.Items[i]})
+		}
+		m.entries = entries
+		if m.cursor >= len(m.entries) {
+			m.cursor = 0
+		}
+		return m, nil
+
+	case doneMsg:
+		if x.err != nil {
+			m.loading = false
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		return m, m.fetch()
+
+	case tea.KeyMsg:
+		if m.mode != inputNone {
+			switch x.String() {
+			case "enter":
+				name := strings.TrimSpace(m.input.Value())
+				mode := m.mode
+				m.mode = inputNone
+				m.input.Blur()
+				if name == "" {
+					return m, nil
+				}
+				m.loading = true
+				if mode == inputCreate {
+					return m, m.run(func(ctx context.Context) error {
+						return CreateFolder(ctx, m.app, m.current(), name)
+					})
+				}
+				id := m.entries[m.cursor].folder.ID
+				return m, m.run(func(ctx context.Context) error {
+					return RenameFolder(ctx, m.app, id, name)
+				})
+			case "esc":
+				m.mode = inputNone
+				m.input.Blur()
+				return m, nil
+			}
+
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+		}
+
+		if m.loading {
+			if x.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch x.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.cursor < len(m.entries)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case "enter":
+			if len(m.entries) == 0 {
+				return m, nil
+			}
+			e := m.entries[m.cursor]
+			if e.folder != nil {
+				m.path = append(m.path[:len(m.path):len(m.path)], *e.folder)
+				m.cursor, m.loading = 0, true
+				return m, m.fetch()
+			}
+			if page := m.itemPage(*e.item); page != nil {
+				return m, nav.NextPageCmd(page)
+			}
+			return m, nil
+
+		case "backspace", "esc":
+			if len(m.path) == 0 {
+				return m, nav.PreviousPageCmd()
+			}
+			m.path = m.path[:len(m.path)-1]
+			m.cursor, m.loading = 0, true
+			return m, m.fetch()
+
+		case "n":
+			m.mode = inputCreate
+			m.input.SetValue("")
+			return m, m.input.Focus()
+
+		case "r":
+			if len(m.entries) == 0 || m.entries[m.cursor].folder == nil {
+				return m, nil
+			}
+			m.mode = inputRename
+			m.input.SetValue(m.entries[m.cursor].folder.Name)
+			return m, m.input.Focus()
+
+		case "d":
+			if len(m.entries) == 0 || m.entries[m.cursor].folder == nil {
+				return m, nil
+			}
+			id := m.entries[m.cursor].folder.ID
+			m.loading = true
+			return m, m.run(func(ctx context.Context) error {
+				return DeleteFolder(ctx, m.app, id)
+			})
+
+		case "m":
+			if len(m.entries) == 0 {
+				return m, nil
+			}
+			e := m.entries[m.cursor]
+			m.moving = &e
+			return m, nil
+
+		case "p":
+			if m.moving == nil {
+				return m, nil
+			}
+			e, target := *m.moving, m.current()
+			m.moving, m.loading = nil, true
+			if e.folder != nil {
+				return m, m.run(func(ctx context.Context) error {
+					return MoveFolder(ctx, m.app, e.folder.ID, target)
+				})
+			}
+			return m, m.run(func(ctx context.Context) error {
+				return MoveItem(ctx, m.app, *e.item, target)
+			})
+
+		case "b":
+			return m, nav.PreviousPageCmd()
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString("Folders\n")
+	fmt.Fprintf(&b, "/%s\n\n", m.breadcrumbs())
+
+	if m.mode != inputNone {
+		b.WriteString(m.input.View() + "\n\n")
+	}
+
+	if m.loading {
+		b.WriteString("Loading...\n")
+		return b.String()
+	}
+
+	if len(m.entries) == 0 {
+		b.WriteString("(empty)\n")
+	}
+
+	for i, e := range m.entries {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		b.WriteString(prefix + e.title() + "\n")
+	}
+
+	if m.moving != nil {
+		fmt.Fprintf(&b, "\nMoving: %s\n", m.moving.title())
+	}
+
+	b.WriteString("\n[↑/↓] переключение   [enter] открыть   [esc] вверх   [n] новая папка   [r] переименовать   [d] удалить\n")
+	b.WriteString("[m] вырезать   [p] вставить сюда   [b] назад\n")
+	return b.String()
+}
+
+// current is the open folder, nil for the root
+func (m Model) current() *int64 {
+	if len(m.path) == 0 {
+		return nil
+	}
+	id := m.path[len(m.path)-1].ID
+	return &id
+}
+
+func (m Model) breadcrumbs() string {
+	names := make([]string, 0, len(m.path))
+	for _, f := range m.path {
+		names = append(names, f.Name)
+	}
+	return strings.Join(names, "/")
+}
+
+// itemPage is the page of an object, the same pages the per-type lists open
+func (m Model) itemPage(it Item) tea.Model {
+	switch it.Type {
+	case ItemAccount:
+		return get_account.NewPage(m.app, it.ID)
+	case ItemCard:
+		return get_card.NewPage(m.app, it.ID)
+	case ItemText:
+		return get_text.NewPage(m.app, it.ID)
+	case ItemFile:
+		return load.NewPage(m.app, it.ID)
+	case ItemSSH:
+		return get_ssh.NewPage(m.app, it.ID)
+	case ItemCert:
+		return get_cert.NewPage(m.app, it.ID)
+	default:
+		return nil
+	}
+}
+
+func (m Model) fetch() tea.Cmd {
+	folderID := m.current()
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		c, err := GetContents(ctx, m.app, folderID)
+		return contentsLoadedMsg{contents: c, err: err}
+	}
+}
+
+func (m Model) run(fn func(ctx context.Context) error) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		return doneMsg{err: fn(ctx)}
+	}
+}
@@ -0,0 +1,88 @@
+package login
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"strings"
+
+	mainPage "client/internal/pages/main"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CodeModel is the second step of a login with 2FA, wrong codes can be retried until the challenge runs out
+type CodeModel struct {
+	input     textinput.Model
+	challenge string
+	app       *app.Ctx
+	status    string
+}
+
+func newCodePage(app *app.Ctx, challenge string) tea.Model {
+	code := textinput.New()
+	code.Placeholder = "123456 or recovery code"
+	code.Prompt = "Code: "
+	code.CharLimit = 32
+	code.Focus()
+
+	return &CodeModel{
+		input:     code,
+		challenge: challenge,
+		app:       app,
+	}
+}
+
+func (m CodeModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m CodeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "enter":
+			code := strings.TrimSpace(m.input.Value())
+			if code == "" {
+				return m, nil
+			}
+
+			tokens, err := LoginTwoFactor(m.app.HTTP, m.challenge, code)
+			if err != nil {
+				m.status = "error: " + err.Error()
+				m.input.SetValue("")
+				return m, nil
+			}
+
+			m.app.CreateNewSession()
+			m.app.SetToken(tokens)
+
+			return m, nav.NextPageCmd(mainPage.NewPage(m.app))
+
+		case "esc":
+			return m, nav.PreviousPageCmd()
+
+		case "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m CodeModel) View() string {
+	var b strings.Builder
+	b.WriteString("Two-factor authentication\n\n")
+	b.WriteString("Enter the code of the authenticator app or one of the recovery codes\n\n")
+
+	b.WriteString(m.input.View())
+	b.WriteString("\n")
+
+	if m.status != "" {
+		b.WriteString("\n" + m.status + "\n")
+	}
+
+	b.WriteString("\n(Enter подтвердить, esc назад)\n")
+	return b.String()
+}
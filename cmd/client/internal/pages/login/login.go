@@ -6,22 +6,33 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"os"
 
 	"github.com/go-resty/resty/v2"
 )
 
 type loginRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+	DeviceName string `json:"device_name"`
 }
 
 type loginResponse struct {
-	Token        string `json:"token"`
-	RefreshToken string `json:"refresh_token"`
+	Token             string `json:"token"`
+	RefreshToken      string `json:"refresh_token"`
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	Challenge         string `json:"challenge"`
 }
 
-// Login create new User, get tokens, save in keyring
-func Login(client *resty.Client, username, password string) (*domain.Token, error) {
+type loginTwoFactorRequest struct {
+	Challenge string `json:"challenge"`
+	Code      string `json:"code"`
+}
+
+// Login create new User, get tokens, save in keyring. With 2FA on no tokens are returned,
+// the challenge is finished by LoginTwoFactor with a code
+func Login(client *resty.Client, username, password string) (*domain.Token, string, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -31,6 +42,8 @@ func Login(client *resty.Client, username, password string) (*domain.Token, erro
 	)
 	reqData.Username = username
 	reqData.Password = password
+	// the server lists sessions by device, the host name tells them apart
+	reqData.DeviceName, _ = os.Hostname()
 
 	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.POST, http_request_sender.SendDataCmd{
 		URL:    "http://127.0.0.1:8080/user/auth/login", // fixme: create path from config
@@ -39,17 +52,63 @@ func Login(client *resty.Client, username, password string) (*domain.Token, erro
 	})
 
 	if err != nil {
-		return nil, err // fixme: add custom err
+		return nil, "", err // fixme: add custom err
 	}
 
 	err = json.Unmarshal(response.Body(), respData)
 	if err != nil {
+		return nil, "", fmt.Errorf(`json unmarshal response: %s`, err)
+	}
+
+	if respData.TwoFactorRequired {
+		return nil, respData.Challenge, nil
+	}
+
+	tokens, err := newTokens(respData)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return tokens, "", nil
+}
+
+// LoginTwoFactor trades the challenge of Login and a TOTP or recovery code for tokens
+func LoginTwoFactor(client *resty.Client, challenge, code string) (*domain.Token, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const url = "http://127.0.0.1:8080/user/auth/login/2fa"
+
+	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.POST, http_request_sender.SendDataCmd{
+		URL:    url,
+		Data:   loginTwoFactorRequest{Challenge: challenge, Code: code},
+		Client: client,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"POST %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	respData := new(loginResponse)
+	if err := json.Unmarshal(response.Body(), respData); err != nil {
 		return nil, fmt.Errorf(`json unmarshal response: %s`, err)
 	}
 
+	return newTokens(respData)
+}
+
+func newTokens(respData *loginResponse) (*domain.Token, error) {
 	tokens := domain.NewToken()
 
-	err = tokens.SetJWTToken(respData.Token)
+	err := tokens.SetJWTToken(respData.Token)
 	if err != nil {
 		return nil, err // fixme: add custom err
 	}
@@ -66,10 +66,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.focus == submitIndex {
 				username := strings.TrimSpace(m.inputs[0].Value())
 				password := m.inputs[1].Value()
-				tokens, err := Login(m.app.HTTP, username, password)
+				tokens, challenge, err := Login(m.app.HTTP, username, password)
 				if err != nil {
 					return m, nav.NextPageCmd(errorPage.New(err))
 				}
+				if challenge != "" {
+					return m, nav.NextPageCmd(newCodePage(m.app, challenge))
+				}
 
 				m.app.CreateNewSession()
 				m.app.SetToken(tokens)
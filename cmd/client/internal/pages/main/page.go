@@ -4,41 +4,83 @@ import (
 	"client/internal/app"
 	"client/internal/constants"
 	nav "client/internal/navigator"
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"client/internal/pages/account_settings"
+	"client/internal/pages/audit"
+	"client/internal/pages/emergency"
+	"client/internal/pages/folders"
+	"client/internal/pages/notifications"
 	"client/internal/pages/obj_types"
+	"client/internal/pages/orgs"
+	"client/internal/pages/report_health"
+	"client/internal/pages/secret_links"
+	"client/internal/pages/shared_with_me"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+type unreadLoadedMsg struct {
+	count int
+	err   error
+}
+
 type Model struct {
 	app    *app.Ctx
 	items  []string
 	cursor int
+	unread int
 }
 
 const (
 	MyStorage = "my storage"
+	Folders   = "folders"
+	Shared    = "shared with me"
+	Orgs      = "organizations"
+	Emergency = "emergency access"
+	Links     = "secret links"
 	Upload    = "upload"
+	Health    = "vault health"
+	Reminders = "notifications"
+	Audit     = "audit log"
+	Account   = "account"
 )
 
 func NewPage(app *app.Ctx) tea.Model {
 	return &Model{
 		items: []string{
 			MyStorage,
+			Folders,
+			Shared,
+			Orgs,
+			Emergency,
+			Links,
 			Upload,
+			Health,
+			Reminders,
+			Audit,
+			Account,
 		},
 		cursor: 0,
 		app:    app,
 	}
 }
 
-func (m Model) Init() tea.Cmd { return nil }
+func (m Model) Init() tea.Cmd { return fetchUnreadCmd(m.app) }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
+	case unreadLoadedMsg:
+		// the badge is best effort, the menu works without it
+		if msg.err == nil {
+			m.unread = msg.count
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		switch msg.String() {
 
@@ -61,9 +103,42 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// LIST mode (показать списки объектов)
 				return m, nav.NextPageCmd(obj_types.NewPage(m.app, constants.ModeList))
 
+			case Folders:
+				// tree view of all objects
+				return m, nav.NextPageCmd(folders.NewPage(m.app))
+
+			case Shared:
+				// items other users gave access to
+				return m, nav.NextPageCmd(shared_with_me.NewPage(m.app))
+
+			case Orgs:
+				// team vaults with member roles
+				return m, nav.NextPageCmd(orgs.NewPage(m.app))
+
+			case Emergency:
+				// trusted contacts and vaults of users who trust us
+				return m, nav.NextPageCmd(emergency.NewPage(m.app))
+
+			case Links:
+				// one-time links for people outside the system
+				return m, nav.NextPageCmd(secret_links.NewPage(m.app))
+
 			case Upload:
 				// CREATE mode (создать новый объект)
 				return m, nav.NextPageCmd(obj_types.NewPage(m.app, constants.ModeCreate))
+
+			case Health:
+				return m, nav.NextPageCmd(report_health.NewPage(m.app))
+
+			case Reminders:
+				return m, nav.NextPageCmd(notifications.NewPage(m.app))
+
+			case Audit:
+				// who opened which item and when
+				return m, nav.NextPageCmd(audit.NewPage(m.app))
+
+			case Account:
+				return m, nav.NextPageCmd(account_settings.NewPage(m.app))
 			}
 			return m, nil
 		case "b":
@@ -87,9 +162,25 @@ func (m Model) View() string {
 		if m.cursor == i {
 			cursor = ">"
 		}
+		if item == Reminders && m.unread > 0 {
+			item = fmt.Sprintf("%s (%d)", item, m.unread)
+		}
 		b.WriteString(fmt.Sprintf("%s %s\n", cursor, item))
 	}
 
 	b.WriteString("\n[↑/↓] переключение   [Enter] выбрать   [b] назад)\n")
 	return b.String()
 }
+
+func fetchUnreadCmd(app *app.Ctx) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		count, err := notifications.CountUnread(ctx, app)
+		return unreadLoadedMsg{
+			count: count,
+			err:   err,
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package notifications
+
+import (
+	"client/internal/app"
+	"client/pkg/http_request_sender"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	ObjectAccount = "account"
+	ObjectCard    = "card"
+	ObjectText    = "text"
+	ObjectFile    = "file"
+	ObjectCert    = "cert"
+)
+
+type Notification struct {
+	ID         int64     `json:"id"`
+	ObjectType string    `json:"object_type"`
+	ObjectID   int64     `json:"object_id"`
+	Title      string    `json:"title"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Expired    bool      `json:"expired"`
+	Read       bool      `json:"read"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type unreadResponse struct {
+	Unread int `json:"unread"`
+}
+
+// GetNotifications gets the expiry reminders of the user
+func GetNotifications(ctx context.Context, app *app.Ctx) ([]Notification, error) {
+	var respData []Notification
+
+	const url = "http://127.0.0.1:8080/notifications"
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.GET,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"GET %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return respData, nil
+}
+
+// CountUnread gets the number of unread reminders, used for the main page badge
+func CountUnread(ctx context.Context, app *app.Ctx) (int, error) {
+	var respData unreadResponse
+
+	const url = "http://127.0.0.1:8080/notifications/unread"
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.GET,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return 0, fmt.Errorf(
+			"GET %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		return 0, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return respData.Unread, nil
+}
+
+// MarkRead marks one reminder as read, id == 0 marks all of them
+func MarkRead(ctx context.Context, app *app.Ctx, id int64) error {
+	url := "http://127.0.0.1:8080/notifications/read"
+	if id != 0 {
+		url = fmt.Sprintf("%s/%d", url, id)
+	}
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.POST,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return fmt.Errorf(
+			"POST %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	return nil
+}
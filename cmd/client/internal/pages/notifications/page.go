@@ -0,0 +1,186 @@
+package notifications
+
+import (
+	"client/internal/app"
+	"client/internal/domain/expiry"
+	nav "client/internal/navigator"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+	get_account "client/internal/pages/obj_account/get"
+	get_card "client/internal/pages/obj_card/get"
+	get_cert "client/internal/pages/obj_cert/get"
+	get_text "client/internal/pages/obj_text/get"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type listLoadedMsg struct {
+	items []Notification
+	err   error
+}
+
+type markedMsg struct {
+	// open is the page of the object the reminder is about, nil for a plain refresh
+	open tea.Model
+	err  error
+}
+
+type Model struct {
+	app     *app.Ctx
+	loading bool
+	items   []Notification
+	cursor  int
+}
+
+func NewPage(app *app.Ctx) tea.Model {
+	return &Model{
+		app:     app,
+		loading: true,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return fetchListCmd(m.app)
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case listLoadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.items = x.items
+		if m.cursor >= len(m.items) {
+			m.cursor = 0
+		}
+		return m, nil
+
+	case markedMsg:
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		if x.open != nil {
+			return m, nav.NextPageCmd(x.open)
+		}
+		m.loading = true
+		return m, fetchListCmd(m.app)
+
+	case tea.KeyMsg:
+		switch x.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case "enter":
+			if m.loading || len(m.items) == 0 {
+				return m, nil
+			}
+			it := m.items[m.cursor]
+			return m, markReadCmd(m.app, it.ID, m.objectPage(it))
+
+		case "a":
+			if m.loading {
+				return m, nil
+			}
+			return m, markReadCmd(m.app, 0, nil)
+
+		case "r":
+			m.loading = true
+			return m, fetchListCmd(m.app)
+
+		case "b":
+			return m, nav.PreviousPageCmd()
+
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+// objectPage is the page the reminder leads to, files have no page of their own
+func (m Model) objectPage(it Notification) tea.Model {
+	switch it.ObjectType {
+	case ObjectAccount:
+		return get_account.NewPage(m.app, it.ObjectID)
+	case ObjectCard:
+		return get_card.NewPage(m.app, it.ObjectID)
+	case ObjectText:
+		return get_text.NewPage(m.app, it.ObjectID)
+	case ObjectCert:
+		return get_cert.NewPage(m.app, it.ObjectID)
+	default:
+		return nil
+	}
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString("Notifications\n\n")
+
+	if m.loading {
+		b.WriteString("Loading...\n")
+		return b.String()
+	}
+
+	if len(m.items) == 0 {
+		b.WriteString("No reminders\n")
+	}
+
+	now := time.Now()
+	for i, it := range m.items {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		mark := "*"
+		if it.Read {
+			mark = " "
+		}
+		fmt.Fprintf(&b, "%s%s %s [%s]  %s\n", prefix, mark, it.Title, it.ObjectType, expiry.Describe(it.ExpiresAt, now))
+	}
+
+	b.WriteString("\n[↑/↓] переключение   [enter] открыть   [a] прочитать все   [r] обновить   [b] назад\n")
+	return b.String()
+}
+
+func fetchListCmd(app *app.Ctx) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		items, err := GetNotifications(ctx, app)
+		return listLoadedMsg{
+			items: items,
+			err:   err,
+		}
+	}
+}
+
+func markReadCmd(app *app.Ctx, id int64, open tea.Model) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		err := MarkRead(ctx, app, id)
+		return markedMsg{
+			open: open,
+			err:  err,
+		}
+	}
+}
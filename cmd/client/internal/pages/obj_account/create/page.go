@@ -2,7 +2,9 @@ package create
 
 import (
 	"client/internal/app"
+	"client/internal/domain/custom_field"
 	nav "client/internal/navigator"
+	"client/internal/services/passgen"
 	"strings"
 
 	errorPage "client/internal/pages/error"
@@ -11,10 +13,17 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+const passwordIndex = 2
+
+// customFieldsFrom is the index of the first custom field input, the ones before are fixed
+const customFieldsFrom = 5
+
 type Model struct {
 	app    *app.Ctx
 	inputs []textinput.Model
 	focus  int
+	// hint describes the last generated password
+	hint string
 }
 
 func NewPage(app *app.Ctx) tea.Model {
@@ -34,8 +43,18 @@ func NewPage(app *app.Ctx) tea.Model {
 	password.Prompt = "password: "
 	password.CharLimit = 512
 
+	totpSecret := textinput.New()
+	totpSecret.Placeholder = "base32 secret or otpauth:// URI (optional)"
+	totpSecret.Prompt = "TOTP: "
+	totpSecret.CharLimit = 1024
+
+	expires := textinput.New()
+	expires.Placeholder = "YYYY-MM-DD (optional)"
+	expires.Prompt = "Expires: "
+	expires.CharLimit = 10
+
 	return &Model{
-		inputs: []textinput.Model{serviceName, username, password},
+		inputs: []textinput.Model{serviceName, username, password, totpSecret, expires},
 		focus:  0,
 		app:    app,
 	}
@@ -49,9 +68,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	submitIndex := len(m.inputs)
 
 	switch msg := msg.(type) {
+	case passgen.GeneratedMsg:
+		if msg.Err != nil {
+			m.hint = "generate failed: " + msg.Err.Error()
+			return m, nil
+		}
+		m.inputs[passwordIndex].SetValue(msg.Password.Password)
+		m.hint = msg.Password.Describe()
+		return m, nil
+
 	case tea.KeyMsg:
 		switch msg.String() {
 
+		case "ctrl+g":
+			return m, passgen.GenerateCmd(m.app, passgen.ModeRandom)
+
+		case "ctrl+p":
+			return m, passgen.GenerateCmd(m.app, passgen.ModePassphrase)
+
 		case "up", "k":
 			if m.focus > 0 {
 				m.setFocus(m.focus - 1)
@@ -64,9 +98,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case "ctrl+n":
+			m.inputs = append(m.inputs, newFieldInput())
+			m.setFocus(len(m.inputs) - 1)
+			return m, nil
+
 		case "enter":
 			if m.focus == submitIndex {
-				if err := CreateAccountObj(m.app, m.inputs[0].Value(), m.inputs[1].Value(), m.inputs[2].Value()); err != nil {
+				fields, err := m.customFields()
+				if err != nil {
+					return m, nav.NextPageCmd(errorPage.New(err))
+				}
+
+				if err := CreateAccountObj(m.app, m.inputs[0].Value(), m.inputs[1].Value(), m.inputs[2].Value(), m.inputs[3].Value(), m.inputs[4].Value(), fields); err != nil {
 					return m, nav.NextPageCmd(errorPage.New(err))
 				}
 
@@ -103,6 +147,24 @@ func (m *Model) setFocus(idx int) {
 	}
 }
 
+// newFieldInput is the input of one custom field, see custom_field.Parse
+func newFieldInput() textinput.Model {
+	field := textinput.New()
+	field.Placeholder = custom_field.Placeholder
+	field.Prompt = "Field: "
+	field.CharLimit = 1024
+	return field
+}
+
+// customFields parses the inputs added with ctrl+n
+func (m Model) customFields() ([]custom_field.Field, error) {
+	lines := make([]string, 0, len(m.inputs)-customFieldsFrom)
+	for _, in := range m.inputs[customFieldsFrom:] {
+		lines = append(lines, in.Value())
+	}
+	return custom_field.ParseAll(lines)
+}
+
 func (m *Model) blurAll() {
 	for i := range m.inputs {
 		m.inputs[i].Blur()
@@ -117,6 +179,9 @@ func (m Model) View() string {
 	for i := range m.inputs {
 		b.WriteString(m.inputs[i].View())
 		b.WriteString("\n")
+		if i == passwordIndex && m.hint != "" {
+			b.WriteString("  " + m.hint + "\n")
+		}
 	}
 
 	b.WriteString("\n")
@@ -128,6 +193,6 @@ func (m Model) View() string {
 		b.WriteString("  [ Submit ]\n")
 	}
 
-	b.WriteString("\n[↑/↓] переключение   [Enter] выбрать   [b] назад)\n")
+	b.WriteString("\n[↑/↓] переключение   [Enter] выбрать   [ctrl+g] пароль   [ctrl+p] фраза   [ctrl+n] поле   [b] назад)\n")
 	return b.String()
 }
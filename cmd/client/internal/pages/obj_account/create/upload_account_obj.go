@@ -2,20 +2,28 @@ package create
 
 import (
 	"client/internal/app"
+	"client/internal/domain/custom_field"
+	"client/internal/domain/expiry"
 	"client/pkg/http_request_sender"
 	"context"
 	"errors"
 	"net/http"
+	"time"
 )
 
 type createAccountRequest struct {
 	ServiceName string `json:"service_name"`
 	UserName    string `json:"user_name"`
 	Password    string `json:"password"`
+	TOTP        string `json:"totp"`
+
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	CustomFields []custom_field.Field `json:"custom_fields,omitempty"`
 }
 
 // CreateAccountObj create new User, get tokens
-func CreateAccountObj(app *app.Ctx, serviceName, userName, password string) error {
+func CreateAccountObj(app *app.Ctx, serviceName, userName, password, totp, expires string, fields []custom_field.Field) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -24,6 +32,14 @@ func CreateAccountObj(app *app.Ctx, serviceName, userName, password string) erro
 	reqData.UserName = userName
 	reqData.Password = password
 	reqData.ServiceName = serviceName
+	reqData.TOTP = totp
+	reqData.CustomFields = fields
+
+	expiresAt, err := expiry.Parse(expires)
+	if err != nil {
+		return err
+	}
+	reqData.ExpiresAt = expiresAt
 
 	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.POST, http_request_sender.SendDataCmd{
 		URL:    "http://127.0.0.1:8080/account/create",
@@ -2,17 +2,40 @@ package get
 
 import (
 	"client/internal/app"
+	"client/internal/domain/custom_field"
+	"client/internal/pages/shares"
 	"client/pkg/http_request_sender"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 type Account struct {
 	ServiceName string `json:"service_name"`
 	Username    string `json:"username"`
 	Password    string `json:"password"`
+	TOTP        string `json:"totp"`
+	Compromised bool   `json:"compromised"`
+
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	CustomFields []custom_field.Field `json:"custom_fields,omitempty"`
+
+	Shared *shares.Shared `json:"shared,omitempty"`
+}
+
+// SecretText is the account as a secret link carries it
+func (a *Account) SecretText() string {
+	return fmt.Sprintf("Service: %s\nUsername: %s\nPassword: %s\n", a.ServiceName, a.Username, a.Password) +
+		custom_field.Plain(a.CustomFields)
+}
+
+type TOTPCode struct {
+	Code             string `json:"code"`
+	Period           int64  `json:"period"`
+	RemainingSeconds int64  `json:"remaining_seconds"`
 }
 
 // GetAccountByID gets single text object by id
@@ -49,3 +72,38 @@ func GetAccountByID(ctx context.Context, app *app.Ctx, id int64) (*Account, erro
 
 	return &respData, nil
 }
+
+// GetTOTPCode gets the current one-time code of the account
+func GetTOTPCode(ctx context.Context, app *app.Ctx, id int64) (*TOTPCode, error) {
+	var respData TOTPCode
+
+	url := fmt.Sprintf("http://127.0.0.1:8080/account/totp/%d", id)
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.GET,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"GET %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return &respData, nil
+}
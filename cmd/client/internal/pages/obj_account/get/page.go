@@ -2,9 +2,16 @@ package get
 
 import (
 	"client/internal/app"
+	"client/internal/domain/custom_field"
+	"client/internal/domain/expiry"
 	nav "client/internal/navigator"
+	"client/internal/pages/attachments"
+	"client/internal/pages/obj_account/update"
+	"client/internal/pages/secret_links"
+	"client/internal/pages/shares"
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	errorPage "client/internal/pages/error"
@@ -17,12 +24,27 @@ type textLoadedMsg struct {
 	err  error
 }
 
+type totpLoadedMsg struct {
+	code *TOTPCode
+	err  error
+}
+
+type totpTickMsg struct{}
+
 type Model struct {
 	app     *app.Ctx
 	id      int64
 	loading bool
 	item    *Account
 	err     error
+
+	totp    *TOTPCode
+	totpErr error
+
+	// reveal shows the values of hidden custom fields
+	reveal bool
+
+	attachments []attachments.Attachment
 }
 
 func NewPage(app *app.Ctx, id int64) tea.Model {
@@ -34,7 +56,10 @@ func NewPage(app *app.Ctx, id int64) tea.Model {
 }
 
 func (m Model) Init() tea.Cmd {
-	return fetchTextCmd(m.app, m.id)
+	return tea.Batch(
+		fetchTextCmd(m.app, m.id),
+		attachments.LoadCmd(m.app, attachments.ItemAccount, m.id),
+	)
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -46,6 +71,31 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nav.NextPageCmd(errorPage.New(x.err))
 		}
 		m.item = x.item
+		if m.item.TOTP != "" {
+			return m, fetchTOTPCmd(m.app, m.id)
+		}
+		return m, nil
+
+	case totpLoadedMsg:
+		m.totp, m.totpErr = x.code, x.err
+		if x.err != nil {
+			return m, nil
+		}
+		return m, totpTickCmd()
+
+	case totpTickMsg:
+		if m.totp == nil {
+			return m, nil
+		}
+		m.totp.RemainingSeconds--
+		if m.totp.RemainingSeconds <= 0 {
+			return m, fetchTOTPCmd(m.app, m.id)
+		}
+		return m, totpTickCmd()
+
+	case attachments.LoadedMsg:
+		// the section is optional, a failed load just leaves it empty
+		m.attachments = x.Items
 		return m, nil
 
 	case tea.KeyMsg:
@@ -54,6 +104,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		case "esc":
 			return m, nav.PreviousPageCmd()
+		case "e":
+			if m.item == nil {
+				return m, nil
+			}
+			return m, nav.NextPageCmd(update.NewPage(m.app, m.id, update.Account{
+				ServiceName: m.item.ServiceName,
+				UserName:    m.item.Username,
+				Password:    m.item.Password,
+				TOTP:        m.item.TOTP,
+				ExpiresAt:   m.item.ExpiresAt,
+
+				CustomFields: m.item.CustomFields,
+			}))
+		case "h":
+			m.reveal = !m.reveal
+			return m, nil
+		case "f":
+			return m, nav.NextPageCmd(attachments.NewPage(m.app, attachments.ItemAccount, m.id))
+		case "s":
+			// only the owner manages shares
+			if m.item == nil || m.item.Shared != nil {
+				return m, nil
+			}
+			return m, nav.NextPageCmd(shares.NewPage(m.app, shares.ItemAccount, m.id))
+		case "l":
+			// a one-time link for someone outside the system
+			if m.item == nil {
+				return m, nil
+			}
+			return m, nav.NextPageCmd(secret_links.NewCreatePage(m.app, m.item.ServiceName, m.item.SecretText()))
 		}
 	}
 
@@ -69,16 +149,45 @@ func (m Model) View() string {
 		return "Account\n\nNot found\n"
 	}
 
-	return fmt.Sprintf(
+	var b strings.Builder
+
+	fmt.Fprintf(&b,
 		"Account\n\n"+
 			"Service name: %s\n\n"+
 			"Username: %s\n\n"+
-			"Password: %s\n\n"+
-			"esc назад\n",
+			"Password: %s\n\n",
 		m.item.ServiceName,
 		m.item.Username,
 		m.item.Password,
 	)
+
+	b.WriteString(shares.Render(m.item.Shared))
+
+	if m.item.ExpiresAt != nil {
+		fmt.Fprintf(&b, "Expiry: %s\n\n", expiry.Describe(*m.item.ExpiresAt, time.Now()))
+	}
+
+	if m.item.Compromised {
+		b.WriteString("! Password was found in known data breaches, change it\n\n")
+	}
+
+	if m.item.TOTP != "" {
+		switch {
+		case m.totpErr != nil:
+			fmt.Fprintf(&b, "TOTP: error: %v\n\n", m.totpErr)
+		case m.totp == nil:
+			b.WriteString("TOTP: ...\n\n")
+		default:
+			fmt.Fprintf(&b, "TOTP: %s  (%ds)\n\n", m.totp.Code, m.totp.RemainingSeconds)
+		}
+	}
+
+	b.WriteString(custom_field.RenderAll(m.item.CustomFields, m.reveal))
+
+	b.WriteString(attachments.Render(m.attachments))
+
+	b.WriteString("e изменить   h показать/скрыть поля   f вложения   s доступ   l ссылка   esc назад\n")
+	return b.String()
 }
 
 func fetchTextCmd(app *app.Ctx, id int64) tea.Cmd {
@@ -93,3 +202,23 @@ func fetchTextCmd(app *app.Ctx, id int64) tea.Cmd {
 		}
 	}
 }
+
+func fetchTOTPCmd(app *app.Ctx, id int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		code, err := GetTOTPCode(ctx, app, id)
+		return totpLoadedMsg{
+			code: code,
+			err:  err,
+		}
+	}
+}
+
+// totpTickCmd drives the countdown, a new code is fetched once the current one expires
+func totpTickCmd() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return totpTickMsg{}
+	})
+}
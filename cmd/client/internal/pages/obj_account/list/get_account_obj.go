@@ -13,6 +13,7 @@ type Account struct {
 	AccountID   int64  `json:"account_id"`
 	ServiceName string `json:"service_name"`
 	Username    string `json:"username"`
+	Compromised bool   `json:"compromised"`
 }
 
 // GetAccountList gets list of text objects
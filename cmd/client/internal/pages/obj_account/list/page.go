@@ -112,7 +112,11 @@ func (m Model) View() string {
 		if i == m.cursor {
 			prefix = "> "
 		}
-		b.WriteString(fmt.Sprintf("%s%s [%s]\n", prefix, it.ServiceName, it.Username))
+		mark := ""
+		if it.Compromised {
+			mark = "  ! breached"
+		}
+		b.WriteString(fmt.Sprintf("%s%s [%s]%s\n", prefix, it.ServiceName, it.Username, mark))
 	}
 
 	b.WriteString("\n[↑/↓] переключение   [enter] открыть   [r]   обновить   [b] назад\n")
@@ -0,0 +1,226 @@
+package update
+
+import (
+	"client/internal/app"
+	"client/internal/domain/custom_field"
+	"client/internal/domain/expiry"
+	nav "client/internal/navigator"
+	"client/internal/services/passgen"
+	"strings"
+
+	errorPage "client/internal/pages/error"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const passwordIndex = 2
+
+// customFieldsFrom is the index of the first custom field input, the ones before are fixed
+const customFieldsFrom = 5
+
+type Model struct {
+	app    *app.Ctx
+	id     int64
+	inputs []textinput.Model
+	focus  int
+	// hint describes the last generated password
+	hint string
+}
+
+func NewPage(app *app.Ctx, id int64, account Account) tea.Model {
+	serviceName := textinput.New()
+	serviceName.Placeholder = "Service name"
+	serviceName.Prompt = "Service name: "
+	serviceName.CharLimit = 64
+	serviceName.SetValue(account.ServiceName)
+	serviceName.Focus()
+
+	username := textinput.New()
+	username.Placeholder = "Username"
+	username.Prompt = "Username: "
+	username.CharLimit = 512
+	username.SetValue(account.UserName)
+
+	password := textinput.New()
+	password.Placeholder = "Password"
+	password.Prompt = "password: "
+	password.CharLimit = 512
+	password.SetValue(account.Password)
+
+	totpSecret := textinput.New()
+	totpSecret.Placeholder = "base32 secret or otpauth:// URI (optional)"
+	totpSecret.Prompt = "TOTP: "
+	totpSecret.CharLimit = 1024
+	totpSecret.SetValue(account.TOTP)
+
+	expires := textinput.New()
+	expires.Placeholder = "YYYY-MM-DD (empty: never)"
+	expires.Prompt = "Expires: "
+	expires.CharLimit = 10
+	if account.ExpiresAt != nil {
+		expires.SetValue(account.ExpiresAt.Local().Format(expiry.DateLayout))
+	}
+
+	inputs := []textinput.Model{serviceName, username, password, totpSecret, expires}
+	for _, f := range account.CustomFields {
+		field := newFieldInput()
+		field.SetValue(custom_field.Format(f))
+		inputs = append(inputs, field)
+	}
+
+	return &Model{
+		app:    app,
+		id:     id,
+		inputs: inputs,
+		focus:  0,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	submitIndex := len(m.inputs)
+
+	switch msg := msg.(type) {
+	case passgen.GeneratedMsg:
+		if msg.Err != nil {
+			m.hint = "generate failed: " + msg.Err.Error()
+			return m, nil
+		}
+		m.inputs[passwordIndex].SetValue(msg.Password.Password)
+		m.hint = msg.Password.Describe()
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+
+		case "ctrl+g":
+			return m, passgen.GenerateCmd(m.app, passgen.ModeRandom)
+
+		case "ctrl+p":
+			return m, passgen.GenerateCmd(m.app, passgen.ModePassphrase)
+
+		case "up", "k":
+			if m.focus > 0 {
+				m.setFocus(m.focus - 1)
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.focus < submitIndex {
+				m.setFocus(m.focus + 1)
+			}
+			return m, nil
+
+		case "ctrl+n":
+			m.inputs = append(m.inputs, newFieldInput())
+			m.setFocus(len(m.inputs) - 1)
+			return m, nil
+
+		case "enter":
+			if m.focus == submitIndex {
+				account := Account{
+					ServiceName: m.inputs[0].Value(),
+					UserName:    m.inputs[1].Value(),
+					Password:    m.inputs[2].Value(),
+					TOTP:        m.inputs[3].Value(),
+				}
+				expiresAt, err := expiry.Parse(m.inputs[4].Value())
+				if err != nil {
+					return m, nav.NextPageCmd(errorPage.New(err))
+				}
+				account.ExpiresAt = expiresAt
+
+				if account.CustomFields, err = m.customFields(); err != nil {
+					return m, nav.NextPageCmd(errorPage.New(err))
+				}
+
+				if err := UpdateAccountObj(m.app, m.id, account); err != nil {
+					return m, nav.NextPageCmd(errorPage.New(err))
+				}
+
+				return m, nav.PreviousPageCmd()
+			}
+
+			if m.focus < submitIndex {
+				m.setFocus(m.focus + 1)
+			}
+			return m, nil
+
+		case "esc":
+			return m, nav.PreviousPageCmd()
+
+		case "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+
+	if m.focus < len(m.inputs) {
+		var cmd tea.Cmd
+		m.inputs[m.focus], cmd = m.inputs[m.focus].Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m *Model) setFocus(idx int) {
+	m.blurAll()
+	m.focus = idx
+	if m.focus < len(m.inputs) {
+		m.inputs[m.focus].Focus()
+	}
+}
+
+// newFieldInput is the input of one custom field, see custom_field.Parse
+func newFieldInput() textinput.Model {
+	field := textinput.New()
+	field.Placeholder = custom_field.Placeholder
+	field.Prompt = "Field: "
+	field.CharLimit = 1024
+	return field
+}
+
+// customFields parses the inputs added with ctrl+n
+func (m Model) customFields() ([]custom_field.Field, error) {
+	lines := make([]string, 0, len(m.inputs)-customFieldsFrom)
+	for _, in := range m.inputs[customFieldsFrom:] {
+		lines = append(lines, in.Value())
+	}
+	return custom_field.ParseAll(lines)
+}
+
+func (m *Model) blurAll() {
+	for i := range m.inputs {
+		m.inputs[i].Blur()
+	}
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString("Update account\n\n")
+
+	// поля
+	for i := range m.inputs {
+		b.WriteString(m.inputs[i].View())
+		b.WriteString("\n")
+		if i == passwordIndex && m.hint != "" {
+			b.WriteString("  " + m.hint + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+
+	// submit “кнопка”
+	if m.focus == len(m.inputs) {
+		b.WriteString("> [ Save ]\n")
+	} else {
+		b.WriteString("  [ Save ]\n")
+	}
+
+	b.WriteString("\n[↑/↓] переключение   [Enter] выбрать   [ctrl+g] пароль   [ctrl+p] фраза   [ctrl+n] поле   [esc] назад\n")
+	return b.String()
+}
@@ -0,0 +1,46 @@
+package update
+
+import (
+	"client/internal/app"
+	"client/internal/domain/custom_field"
+	"client/pkg/http_request_sender"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Account struct {
+	ServiceName string `json:"service_name"`
+	UserName    string `json:"user_name"`
+	Password    string `json:"password"`
+	TOTP        string `json:"totp"`
+
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	CustomFields []custom_field.Field `json:"custom_fields,omitempty"`
+}
+
+// UpdateAccountObj replaces all fields of the account
+func UpdateAccountObj(app *app.Ctx, id int64, account Account) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.PUT, http_request_sender.SendDataCmd{
+		URL:    fmt.Sprintf("http://127.0.0.1:8080/account/update/%d", id),
+		Data:   account,
+		Client: app.HTTP,
+		JWT:    app.GetToken(),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return errors.New(string(response.Body()))
+	}
+
+	return nil
+}
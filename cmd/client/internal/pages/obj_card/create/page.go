@@ -2,6 +2,7 @@ package create
 
 import (
 	"client/internal/app"
+	"client/internal/domain/custom_field"
 	nav "client/internal/navigator"
 	"strings"
 
@@ -11,6 +12,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// customFieldsFrom is the index of the first custom field input, the ones before are fixed
+const customFieldsFrom = 7
+
 type Model struct {
 	app    *app.Ctx
 	inputs []textinput.Model
@@ -18,19 +22,46 @@ type Model struct {
 }
 
 func NewPage(app *app.Ctx) tea.Model {
-	username := textinput.New()
-	username.Placeholder = "Bank"
-	username.Prompt = "Bank: "
-	username.CharLimit = 64
-	username.Focus()
-
-	password := textinput.New()
-	password.Placeholder = "Pid"
-	password.Prompt = "Pid: "
-	password.CharLimit = 512
+	bank := textinput.New()
+	bank.Placeholder = "Bank"
+	bank.Prompt = "Bank: "
+	bank.CharLimit = 64
+	bank.Focus()
+
+	number := textinput.New()
+	number.Placeholder = "4242 4242 4242 4242"
+	number.Prompt = "Number: "
+	number.CharLimit = 23
+
+	holder := textinput.New()
+	holder.Placeholder = "NAME SURNAME"
+	holder.Prompt = "Holder: "
+	holder.CharLimit = 128
+
+	expiry := textinput.New()
+	expiry.Placeholder = "MM/YY"
+	expiry.Prompt = "Expiry: "
+	expiry.CharLimit = 7
+
+	cvv := textinput.New()
+	cvv.Placeholder = "CVV"
+	cvv.Prompt = "CVV: "
+	cvv.CharLimit = 4
+	cvv.EchoMode = textinput.EchoPassword
+
+	pin := textinput.New()
+	pin.Placeholder = "PIN"
+	pin.Prompt = "PIN: "
+	pin.CharLimit = 12
+	pin.EchoMode = textinput.EchoPassword
+
+	notes := textinput.New()
+	notes.Placeholder = "Billing notes"
+	notes.Prompt = "Notes: "
+	notes.CharLimit = 512
 
 	return &Model{
-		inputs: []textinput.Model{username, password},
+		inputs: []textinput.Model{bank, number, holder, expiry, cvv, pin, notes},
 		focus:  0,
 		app:    app,
 	}
@@ -59,9 +90,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case "ctrl+n":
+			m.inputs = append(m.inputs, newFieldInput())
+			m.setFocus(len(m.inputs) - 1)
+			return m, nil
+
 		case "enter":
 			if m.focus == submitIndex {
-				if err := CreateBankCardObj(m.app, m.inputs[0].Value(), m.inputs[1].Value()); err != nil {
+				month, year, err := ParseExpiry(m.inputs[3].Value())
+				if err != nil {
+					return m, nav.NextPageCmd(errorPage.New(err))
+				}
+
+				card := Card{
+					BankName:    m.inputs[0].Value(),
+					Number:      m.inputs[1].Value(),
+					HolderName:  m.inputs[2].Value(),
+					ExpiryMonth: month,
+					ExpiryYear:  year,
+					CVV:         m.inputs[4].Value(),
+					PIN:         m.inputs[5].Value(),
+					Notes:       m.inputs[6].Value(),
+				}
+
+				if card.CustomFields, err = m.customFields(); err != nil {
+					return m, nav.NextPageCmd(errorPage.New(err))
+				}
+
+				if err := CreateBankCardObj(m.app, card); err != nil {
 					return m, nav.NextPageCmd(errorPage.New(err))
 				}
 
@@ -98,6 +154,24 @@ func (m *Model) setFocus(idx int) {
 	}
 }
 
+// newFieldInput is the input of one custom field, see custom_field.Parse
+func newFieldInput() textinput.Model {
+	field := textinput.New()
+	field.Placeholder = custom_field.Placeholder
+	field.Prompt = "Field: "
+	field.CharLimit = 1024
+	return field
+}
+
+// customFields parses the inputs added with ctrl+n
+func (m Model) customFields() ([]custom_field.Field, error) {
+	lines := make([]string, 0, len(m.inputs)-customFieldsFrom)
+	for _, in := range m.inputs[customFieldsFrom:] {
+		lines = append(lines, in.Value())
+	}
+	return custom_field.ParseAll(lines)
+}
+
 func (m *Model) blurAll() {
 	for i := range m.inputs {
 		m.inputs[i].Blur()
@@ -123,6 +197,6 @@ func (m Model) View() string {
 		b.WriteString("  [ Submit ]\n")
 	}
 
-	b.WriteString("\n[↑/↓] переключение   [Enter] выбрать   [b] назад)\n")
+	b.WriteString("\n[↑/↓] переключение   [Enter] выбрать   [ctrl+n] поле   [b] назад)\n")
 	return b.String()
 }
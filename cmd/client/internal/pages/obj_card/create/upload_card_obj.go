@@ -2,34 +2,44 @@ package create
 
 import (
 	"client/internal/app"
+	"client/internal/domain/custom_field"
 	"client/pkg/http_request_sender"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
-type createBankCardRequest struct {
-	BankName string `json:"bank_name"`
-	Pid      string `json:"pid"`
+// Card is the create request body
+type Card struct {
+	BankName    string `json:"bank_name"`
+	Number      string `json:"number"`
+	HolderName  string `json:"holder_name"`
+	ExpiryMonth int    `json:"expiry_month"`
+	ExpiryYear  int    `json:"expiry_year"`
+	CVV         string `json:"cvv"`
+	PIN         string `json:"pin"`
+	Notes       string `json:"notes"`
+
+	CustomFields []custom_field.Field `json:"custom_fields,omitempty"`
 }
 
 type createBankCardResponse struct {
 	BankCardID int64 `json:"card_id"`
 }
 
-// CreateBankCardObj create new User, get tokens
-func CreateBankCardObj(app *app.Ctx, bankName, pid string) error {
+// CreateBankCardObj sends new bank card to the server
+func CreateBankCardObj(app *app.Ctx, card Card) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	var (
-		reqData  = new(createBankCardRequest)
+		reqData  = &card
 		respData = new(createBankCardResponse)
 	)
-	reqData.BankName = bankName
-	reqData.Pid = pid
 
 	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.POST, http_request_sender.SendDataCmd{
 		URL:    "http://127.0.0.1:8080/card/create",
@@ -53,3 +63,23 @@ func CreateBankCardObj(app *app.Ctx, bankName, pid string) error {
 
 	return nil
 }
+
+// ParseExpiry reads "MM/YY" or "MM/YYYY"
+func ParseExpiry(s string) (month, year int, err error) {
+	mm, yy, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("expiry must be MM/YY, got %q", s)
+	}
+
+	month, err = strconv.Atoi(strings.TrimSpace(mm))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid expiry month %q", mm)
+	}
+
+	year, err = strconv.Atoi(strings.TrimSpace(yy))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid expiry year %q", yy)
+	}
+
+	return month, year, nil
+}
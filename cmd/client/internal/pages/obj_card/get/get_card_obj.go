@@ -2,6 +2,8 @@ package get
 
 import (
 	"client/internal/app"
+	"client/internal/domain/custom_field"
+	"client/internal/pages/shares"
 	"client/pkg/http_request_sender"
 	"context"
 	"encoding/json"
@@ -10,8 +12,29 @@ import (
 )
 
 type Card struct {
-	BankName string `json:"bank_name"`
-	PID      string `json:"pid"`
+	BankName    string `json:"bank_name"`
+	Brand       string `json:"brand"`
+	Number      string `json:"number"`
+	HolderName  string `json:"holder_name"`
+	ExpiryMonth int    `json:"expiry_month"`
+	ExpiryYear  int    `json:"expiry_year"`
+	CVV         string `json:"cvv"`
+	PIN         string `json:"pin"`
+	Notes       string `json:"notes"`
+
+	CustomFields []custom_field.Field `json:"custom_fields,omitempty"`
+
+	Shared *shares.Shared `json:"shared,omitempty"`
+}
+
+// SecretText is the card as a secret link carries it
+func (c *Card) SecretText() string {
+	text := fmt.Sprintf("Bank: %s\nNumber: %s\nHolder: %s\nExpiry: %02d/%d\nCVV: %s\n",
+		c.BankName, c.Number, c.HolderName, c.ExpiryMonth, c.ExpiryYear, c.CVV)
+	if c.PIN != "" {
+		text += fmt.Sprintf("PIN: %s\n", c.PIN)
+	}
+	return text + custom_field.Plain(c.CustomFields)
 }
 
 // GetTextByID gets single text object by id
@@ -2,7 +2,11 @@ package get
 
 import (
 	"client/internal/app"
+	"client/internal/domain/custom_field"
 	nav "client/internal/navigator"
+	"client/internal/pages/attachments"
+	"client/internal/pages/secret_links"
+	"client/internal/pages/shares"
 	"context"
 	"fmt"
 	"time"
@@ -23,6 +27,10 @@ type Model struct {
 	loading bool
 	item    *Card
 	err     error
+	// reveal shows the values of hidden custom fields
+	reveal bool
+
+	attachments []attachments.Attachment
 }
 
 func NewPage(app *app.Ctx, id int64) tea.Model {
@@ -34,7 +42,10 @@ func NewPage(app *app.Ctx, id int64) tea.Model {
 }
 
 func (m Model) Init() tea.Cmd {
-	return fetchTextCmd(m.app, m.id)
+	return tea.Batch(
+		fetchTextCmd(m.app, m.id),
+		attachments.LoadCmd(m.app, attachments.ItemCard, m.id),
+	)
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -48,12 +59,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.item = x.item
 		return m, nil
 
+	case attachments.LoadedMsg:
+		// the section is optional, a failed load just leaves it empty
+		m.attachments = x.Items
+		return m, nil
+
 	case tea.KeyMsg:
 		switch x.String() {
 		case "q", "ctrl+c":
 			return m, tea.Quit
 		case "esc":
 			return m, nav.PreviousPageCmd()
+		case "h":
+			m.reveal = !m.reveal
+			return m, nil
+		case "f":
+			return m, nav.NextPageCmd(attachments.NewPage(m.app, attachments.ItemCard, m.id))
+		case "s":
+			// only the owner manages shares
+			if m.item == nil || m.item.Shared != nil {
+				return m, nil
+			}
+			return m, nav.NextPageCmd(shares.NewPage(m.app, shares.ItemCard, m.id))
+		case "l":
+			// a one-time link for someone outside the system
+			if m.item == nil {
+				return m, nil
+			}
+			return m, nav.NextPageCmd(secret_links.NewCreatePage(m.app, m.item.BankName, m.item.SecretText()))
 		}
 	}
 
@@ -71,11 +104,29 @@ func (m Model) View() string {
 
 	return fmt.Sprintf(
 		"Bank\n\n"+
+			"%s"+
 			"Bank name: %s\n\n"+
-			"PID: %s\n\n"+
-			"esc назад\n",
+			"Brand: %s\n\n"+
+			"Number: %s\n\n"+
+			"Holder: %s\n\n"+
+			"Expiry: %02d/%d\n\n"+
+			"CVV: %s\n\n"+
+			"PIN: %s\n\n"+
+			"Notes: %s\n\n"+
+			"%s"+
+			"%s"+
+			"h показать/скрыть поля   f вложения   s доступ   l ссылка   esc назад\n",
+		shares.Render(m.item.Shared),
 		m.item.BankName,
-		m.item.PID,
+		m.item.Brand,
+		m.item.Number,
+		m.item.HolderName,
+		m.item.ExpiryMonth, m.item.ExpiryYear,
+		m.item.CVV,
+		m.item.PIN,
+		m.item.Notes,
+		custom_field.RenderAll(m.item.CustomFields, m.reveal),
+		attachments.Render(m.attachments),
 	)
 }
 
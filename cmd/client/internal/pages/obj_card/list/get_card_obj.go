@@ -10,8 +10,12 @@ import (
 )
 
 type Card struct {
-	CardID   int64  `json:"card_id"`
-	BankName string `json:"bank_name"`
+	CardID      int64  `json:"card_id"`
+	BankName    string `json:"bank_name"`
+	Brand       string `json:"brand"`
+	Number      string `json:"number"`
+	ExpiryMonth int    `json:"expiry_month"`
+	ExpiryYear  int    `json:"expiry_year"`
 }
 
 // GetCardList gets list of text objects
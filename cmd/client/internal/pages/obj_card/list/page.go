@@ -112,7 +112,8 @@ func (m Model) View() string {
 		if i == m.cursor {
 			prefix = "> "
 		}
-		b.WriteString(fmt.Sprintf("%s%s\n", prefix, it.BankName))
+		b.WriteString(fmt.Sprintf("%s%s  %s %s  %02d/%02d\n",
+			prefix, it.BankName, it.Brand, it.Number, it.ExpiryMonth, it.ExpiryYear%100))
 	}
 
 	b.WriteString("\n[↑/↓] переключение   [enter] открыть   [r]   обновить   [b] назад\n")
@@ -0,0 +1,58 @@
+package create
+
+import (
+	"client/internal/app"
+	"client/pkg/http_request_sender"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+type importCertRequest struct {
+	Title       string `json:"title"`
+	Certificate string `json:"certificate"`
+	PrivateKey  string `json:"private_key,omitempty"`
+}
+
+// ImportCert reads a PEM bundle and an optional key file and stores them on the server
+func ImportCert(app *app.Ctx, title, certPath, keyPath string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bundle, err := os.ReadFile(strings.TrimSpace(certPath))
+	if err != nil {
+		return fmt.Errorf("read certificate file: %w", err)
+	}
+
+	reqData := &importCertRequest{
+		Title:       title,
+		Certificate: string(bundle),
+	}
+
+	if keyPath = strings.TrimSpace(keyPath); keyPath != "" {
+		key, err := os.ReadFile(keyPath)
+		if err != nil {
+			return fmt.Errorf("read key file: %w", err)
+		}
+		reqData.PrivateKey = string(key)
+	}
+
+	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.POST, http_request_sender.SendDataCmd{
+		URL:    "http://127.0.0.1:8080/cert/import",
+		Data:   reqData,
+		Client: app.HTTP,
+		JWT:    app.GetToken(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return errors.New(string(response.Body()))
+	}
+
+	return nil
+}
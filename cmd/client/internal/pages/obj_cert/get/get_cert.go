@@ -0,0 +1,60 @@
+package get
+
+import (
+	"client/internal/app"
+	"client/pkg/http_request_sender"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Certificate struct {
+	ID           int64     `json:"cert_id"`
+	Title        string    `json:"title"`
+	Subject      string    `json:"subject"`
+	Issuer       string    `json:"issuer"`
+	SANs         []string  `json:"sans"`
+	SerialNumber string    `json:"serial_number"`
+	Fingerprint  string    `json:"fingerprint"`
+	NotBefore    time.Time `json:"not_before"`
+	NotAfter     time.Time `json:"not_after"`
+	Certificate  string    `json:"certificate"`
+	PrivateKey   string    `json:"private_key"`
+}
+
+// GetCertByID gets single certificate with its PEM chain and private key
+func GetCertByID(ctx context.Context, app *app.Ctx, id int64) (*Certificate, error) {
+	var respData Certificate
+
+	url := fmt.Sprintf("http://127.0.0.1:8080/cert/list/%d", id)
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.GET,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"GET %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return &respData, nil
+}
@@ -0,0 +1,128 @@
+package get
+
+import (
+	"client/internal/app"
+	"client/internal/domain/expiry"
+	nav "client/internal/navigator"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type certLoadedMsg struct {
+	item *Certificate
+	err  error
+}
+
+type Model struct {
+	app     *app.Ctx
+	id      int64
+	loading bool
+	item    *Certificate
+
+	// reveal shows the PEM chain and the private key
+	reveal bool
+}
+
+func NewPage(app *app.Ctx, id int64) tea.Model {
+	return &Model{
+		app:     app,
+		id:      id,
+		loading: true,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return fetchCertCmd(m.app, m.id)
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case certLoadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.item = x.item
+		return m, nil
+
+	case tea.KeyMsg:
+		switch x.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			return m, nav.PreviousPageCmd()
+		case "h":
+			m.reveal = !m.reveal
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	if m.loading {
+		return "Certificate\n\nLoading...\n"
+	}
+
+	if m.item == nil {
+		return "Certificate\n\nNot found\n"
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b,
+		"Certificate\n\n"+
+			"Title: %s\n\n"+
+			"Subject: %s\n\n"+
+			"Issuer: %s\n\n"+
+			"SANs: %s\n\n"+
+			"Serial: %s\n\n"+
+			"Fingerprint: %s\n\n"+
+			"Valid from: %s\n\n"+
+			"Expiry: %s\n\n",
+		m.item.Title,
+		m.item.Subject,
+		m.item.Issuer,
+		strings.Join(m.item.SANs, ", "),
+		m.item.SerialNumber,
+		m.item.Fingerprint,
+		m.item.NotBefore.Local().Format(expiry.DateLayout),
+		expiry.Describe(m.item.NotAfter, time.Now()),
+	)
+
+	switch {
+	case m.reveal:
+		fmt.Fprintf(&b, "%s\n", m.item.Certificate)
+		if m.item.PrivateKey != "" {
+			fmt.Fprintf(&b, "%s\n", m.item.PrivateKey)
+		}
+	case m.item.PrivateKey != "":
+		b.WriteString("Private key: ********\n\n")
+	default:
+		b.WriteString("Private key: (none)\n\n")
+	}
+
+	b.WriteString("h показать/скрыть PEM   esc назад\n")
+	return b.String()
+}
+
+func fetchCertCmd(app *app.Ctx, id int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		item, err := GetCertByID(ctx, app, id)
+		return certLoadedMsg{
+			item: item,
+			err:  err,
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package list
+
+import (
+	"client/internal/app"
+	"client/pkg/http_request_sender"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// expiringDays is the window of the "expiring soon" view
+const expiringDays = 30
+
+type Certificate struct {
+	ID       int64     `json:"cert_id"`
+	Title    string    `json:"title"`
+	Subject  string    `json:"subject"`
+	NotAfter time.Time `json:"not_after"`
+}
+
+// GetCertList gets certificates of the user, search filters by subject, issuer and SANs
+func GetCertList(ctx context.Context, app *app.Ctx, search string) ([]Certificate, error) {
+	u := "http://127.0.0.1:8080/cert/list"
+	if search != "" {
+		u += "?q=" + url.QueryEscape(search)
+	}
+	return getCerts(ctx, app, u)
+}
+
+// GetExpiringCerts gets certificates that expire within expiringDays, expired ones included
+func GetExpiringCerts(ctx context.Context, app *app.Ctx) ([]Certificate, error) {
+	return getCerts(ctx, app, fmt.Sprintf("http://127.0.0.1:8080/cert/expiring?days=%d", expiringDays))
+}
+
+func getCerts(ctx context.Context, app *app.Ctx, url string) ([]Certificate, error) {
+	var respData []Certificate
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.GET,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// nothing found
+	if response.StatusCode() == http.StatusNoContent {
+		return nil, nil
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"GET %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return respData, nil
+}
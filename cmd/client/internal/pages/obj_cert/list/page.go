@@ -0,0 +1,189 @@
+package list
+
+import (
+	"client/internal/app"
+	"client/internal/domain/expiry"
+	nav "client/internal/navigator"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+
+	get_obj "client/internal/pages/obj_cert/get"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type Model struct {
+	app     *app.Ctx
+	loading bool
+	items   []Certificate
+	cursor  int
+
+	// expiring shows only certificates that expire soon
+	expiring bool
+	// searching is true while the search input has focus
+	searching bool
+	search    textinput.Model
+}
+
+func NewPage(app *app.Ctx) tea.Model {
+	search := textinput.New()
+	search.Prompt = "Search: "
+	search.Placeholder = "subject, issuer or SAN"
+	search.CharLimit = 128
+
+	return &Model{
+		app:     app,
+		loading: true,
+		cursor:  0,
+		search:  search,
+	}
+}
+
+type listLoadedMsg struct {
+	items []Certificate
+	err   error
+}
+
+func (m Model) Init() tea.Cmd {
+	if !m.loading {
+		return nil
+	}
+	return m.fetch()
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case listLoadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.items = x.items
+		if m.cursor >= len(m.items) {
+			m.cursor = 0
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.searching {
+			switch x.String() {
+			case "enter":
+				m.searching = false
+				m.search.Blur()
+				m.loading = true
+				return m, m.fetch()
+			case "esc":
+				m.searching = false
+				m.search.Blur()
+				return m, nil
+			}
+
+			var cmd tea.Cmd
+			m.search, cmd = m.search.Update(msg)
+			return m, cmd
+		}
+
+		switch x.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+
+		case "r":
+			m.loading = true
+			return m, m.fetch()
+
+		case "/":
+			m.expiring = false
+			m.searching = true
+			return m, m.search.Focus()
+
+		case "e":
+			m.expiring = !m.expiring
+			m.loading = true
+			return m, m.fetch()
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case "enter":
+			if len(m.items) == 0 {
+				return m, nil
+			}
+			selected := m.items[m.cursor]
+			return m, nav.NextPageCmd(get_obj.NewPage(m.app, selected.ID))
+		case "tab":
+			return m, nav.PreviousPageCmd()
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	if m.expiring {
+		fmt.Fprintf(&b, "Certificates expiring within %d days\n\n", expiringDays)
+	} else {
+		b.WriteString("Certificates\n\n")
+	}
+
+	if m.searching || m.search.Value() != "" {
+		b.WriteString(m.search.View() + "\n\n")
+	}
+
+	if m.loading {
+		b.WriteString("Loading...\n\n")
+		b.WriteString("[r] refresh   [q] quit\n")
+		return b.String()
+	}
+
+	if len(m.items) == 0 {
+		b.WriteString("(empty)\n\n")
+	}
+
+	now := time.Now()
+	for i, it := range m.items {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		b.WriteString(fmt.Sprintf("%s%s  %s  %s\n", prefix, it.Title, it.Subject, expiry.Describe(it.NotAfter, now)))
+	}
+
+	b.WriteString("\n[↑/↓] переключение   [enter] открыть   [/] поиск   [e] истекающие   [tab] назад\n")
+	return b.String()
+}
+
+func (m Model) fetch() tea.Cmd {
+	expiring, search := m.expiring, strings.TrimSpace(m.search.Value())
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var (
+			items []Certificate
+			err   error
+		)
+		if expiring {
+			items, err = GetExpiringCerts(ctx, m.app)
+		} else {
+			items, err = GetCertList(ctx, m.app, search)
+		}
+		return listLoadedMsg{items: items, err: err}
+	}
+}
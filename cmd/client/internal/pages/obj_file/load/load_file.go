@@ -20,8 +20,12 @@ func DownloadFileByID(app *app.Ctx, id int64) (string, error) {
 		return "", fmt.Errorf("invalid id: %d", id)
 	}
 
-	url := fmt.Sprintf("http://127.0.0.1:8080/file/download/%d", id)
+	return Download(app, fmt.Sprintf("http://127.0.0.1:8080/file/download/%d", id), "file-"+strconv.FormatInt(id, 10))
+}
 
+// Download saves the body of url into the download directory, fallback names the file
+// when the server sends no Content-Disposition
+func Download(app *app.Ctx, url, fallback string) (string, error) {
 	req := app.HTTP.R().
 		SetDoNotParseResponse(true) // do not store file in RAM
 
@@ -50,7 +54,7 @@ func DownloadFileByID(app *app.Ctx, id int64) (string, error) {
 	// get file name from content disposition
 	filename := filenameFromContentDisposition(resp.Header().Get("Content-Disposition"))
 	if filename == "" {
-		filename = "file-" + strconv.FormatInt(id, 10)
+		filename = fallback
 	}
 
 	// create download directory
@@ -29,9 +29,14 @@ func NewPage(app *app.Ctx) tea.Model {
 	path.CharLimit = 512
 	path.Focus()
 
+	expires := textinput.New()
+	expires.Placeholder = "YYYY-MM-DD (optional)"
+	expires.Prompt = "Expires: "
+	expires.CharLimit = 10
+
 	return &Model{
 		app:    app,
-		inputs: []textinput.Model{path},
+		inputs: []textinput.Model{path, expires},
 		focus:  0,
 	}
 }
@@ -91,8 +96,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.uploading = true
 
 				filePath := strings.TrimSpace(m.inputs[0].Value())
+				expires := strings.TrimSpace(m.inputs[1].Value())
 
-				err := UploadFileObj(m.app, filePath)
+				err := UploadFileObj(m.app, filePath, expires)
 				if err != nil {
 					return m, nav.NextPageCmd(errorPage.New(err))
 				}
@@ -2,18 +2,31 @@ package upload
 
 import (
 	"client/internal/app"
+	"client/internal/domain/expiry"
 	"client/pkg/http_request_sender"
 	"errors"
 	"net/http"
+	"time"
 )
 
 // UploadFileObj create new User, get tokens
-func UploadFileObj(app *app.Ctx, path string) error {
+func UploadFileObj(app *app.Ctx, path, expires string) error {
+	expiresAt, err := expiry.Parse(expires)
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]string{}
+	if expiresAt != nil {
+		fields["expires_at"] = expiresAt.Format(time.RFC3339)
+	}
+
 	response, err := http_request_sender.SendFormDataRequest(http_request_sender.SendFileCmd{
 		Client:   app.HTTP,
 		FilePath: path,
 		JWT:      app.GetToken(),
 		URL:      "http://127.0.0.1:8080/file/upload",
+		Fields:   fields,
 	})
 
 	if err != nil {
@@ -0,0 +1,82 @@
+package create
+
+import (
+	"client/internal/app"
+	"client/pkg/http_request_sender"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type importSSHKeyRequest struct {
+	Title      string `json:"title"`
+	PrivateKey string `json:"private_key"`
+	Passphrase string `json:"passphrase"`
+	Comment    string `json:"comment"`
+}
+
+type generateSSHKeyRequest struct {
+	Title   string `json:"title"`
+	Type    string `json:"type"`
+	Bits    int    `json:"bits"`
+	Comment string `json:"comment"`
+}
+
+// ImportSSHKey reads a PEM private key from path and stores it on the server
+func ImportSSHKey(app *app.Ctx, title, path, passphrase, comment string) error {
+	pem, err := os.ReadFile(strings.TrimSpace(path))
+	if err != nil {
+		return fmt.Errorf("read key file: %w", err)
+	}
+
+	return send(app, "http://127.0.0.1:8080/ssh/import", &importSSHKeyRequest{
+		Title:      title,
+		PrivateKey: string(pem),
+		Passphrase: passphrase,
+		Comment:    comment,
+	})
+}
+
+// GenerateSSHKey asks the server to generate a new key pair, empty type and bits use the server defaults
+func GenerateSSHKey(app *app.Ctx, title, kind, bits, comment string) error {
+	reqData := &generateSSHKeyRequest{
+		Title:   title,
+		Type:    strings.TrimSpace(kind),
+		Comment: comment,
+	}
+
+	if bits = strings.TrimSpace(bits); bits != "" {
+		n, err := strconv.Atoi(bits)
+		if err != nil {
+			return fmt.Errorf("invalid bits %q", bits)
+		}
+		reqData.Bits = n
+	}
+
+	return send(app, "http://127.0.0.1:8080/ssh/generate", reqData)
+}
+
+func send(app *app.Ctx, url string, data any) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.POST, http_request_sender.SendDataCmd{
+		URL:    url,
+		Data:   data,
+		Client: app.HTTP,
+		JWT:    app.GetToken(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return errors.New(string(response.Body()))
+	}
+
+	return nil
+}
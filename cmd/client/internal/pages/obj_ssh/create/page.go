@@ -0,0 +1,164 @@
+package create
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"strings"
+
+	errorPage "client/internal/pages/error"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	titleInput = iota
+	fileInput
+	passphraseInput
+	typeInput
+	bitsInput
+	commentInput
+)
+
+type Model struct {
+	app    *app.Ctx
+	inputs []textinput.Model
+	focus  int
+}
+
+func NewPage(app *app.Ctx) tea.Model {
+	title := textinput.New()
+	title.Placeholder = "Title"
+	title.Prompt = "Title: "
+	title.CharLimit = 64
+	title.Focus()
+
+	file := textinput.New()
+	file.Placeholder = "/home/user/.ssh/id_ed25519 (empty to generate)"
+	file.Prompt = "Key file: "
+	file.CharLimit = 512
+
+	passphrase := textinput.New()
+	passphrase.Placeholder = "only for encrypted keys"
+	passphrase.Prompt = "Passphrase: "
+	passphrase.EchoMode = textinput.EchoPassword
+	passphrase.CharLimit = 256
+
+	kind := textinput.New()
+	kind.Placeholder = "ed25519 | rsa"
+	kind.Prompt = "Type: "
+	kind.CharLimit = 16
+
+	bits := textinput.New()
+	bits.Placeholder = "4096 (rsa only)"
+	bits.Prompt = "Bits: "
+	bits.CharLimit = 5
+
+	comment := textinput.New()
+	comment.Placeholder = "user@host (optional)"
+	comment.Prompt = "Comment: "
+	comment.CharLimit = 128
+
+	return &Model{
+		inputs: []textinput.Model{title, file, passphrase, kind, bits, comment},
+		focus:  0,
+		app:    app,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	submitIndex := len(m.inputs)
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+
+		case "up":
+			if m.focus > 0 {
+				m.setFocus(m.focus - 1)
+			}
+			return m, nil
+
+		case "down":
+			if m.focus < submitIndex {
+				m.setFocus(m.focus + 1)
+			}
+			return m, nil
+
+		case "enter":
+			if m.focus == submitIndex {
+				if err := m.submit(); err != nil {
+					return m, nav.NextPageCmd(errorPage.New(err))
+				}
+				return m, nav.PreviousPageCmd()
+			}
+
+			m.setFocus(m.focus + 1)
+			return m, nil
+
+		case "tab":
+			return m, nav.PreviousPageCmd()
+
+		case "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+
+	if m.focus < len(m.inputs) {
+		var cmd tea.Cmd
+		m.inputs[m.focus], cmd = m.inputs[m.focus].Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// submit imports the key file when one is given, otherwise generates a new key
+func (m Model) submit() error {
+	value := func(i int) string { return m.inputs[i].Value() }
+
+	if strings.TrimSpace(value(fileInput)) != "" {
+		return ImportSSHKey(m.app, value(titleInput), value(fileInput), value(passphraseInput), value(commentInput))
+	}
+	return GenerateSSHKey(m.app, value(titleInput), value(typeInput), value(bitsInput), value(commentInput))
+}
+
+func (m *Model) setFocus(idx int) {
+	m.blurAll()
+	m.focus = idx
+	if m.focus < len(m.inputs) {
+		m.inputs[m.focus].Focus()
+	}
+}
+
+func (m *Model) blurAll() {
+	for i := range m.inputs {
+		m.inputs[i].Blur()
+	}
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString("Add SSH key\n\n")
+	b.WriteString("Import: fill key file (and passphrase). Generate: leave key file empty, set type/bits.\n\n")
+
+	for i := range m.inputs {
+		b.WriteString(m.inputs[i].View())
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+
+	if m.focus == len(m.inputs) {
+		b.WriteString("> [ Submit ]\n")
+	} else {
+		b.WriteString("  [ Submit ]\n")
+	}
+
+	b.WriteString("\n[↑/↓] переключение   [Enter] выбрать   [tab] назад\n")
+	return b.String()
+}
@@ -0,0 +1,57 @@
+package get
+
+import (
+	"client/internal/app"
+	"client/pkg/http_request_sender"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type SSHKey struct {
+	ID          int64     `json:"key_id"`
+	Title       string    `json:"title"`
+	Type        string    `json:"type"`
+	PublicKey   string    `json:"public_key"`
+	PrivateKey  string    `json:"private_key"`
+	Fingerprint string    `json:"fingerprint"`
+	Comment     string    `json:"comment,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// GetSSHKeyByID gets single ssh key with its private part
+func GetSSHKeyByID(ctx context.Context, app *app.Ctx, id int64) (*SSHKey, error) {
+	var respData SSHKey
+
+	url := fmt.Sprintf("http://127.0.0.1:8080/ssh/list/%d", id)
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.GET,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"GET %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return &respData, nil
+}
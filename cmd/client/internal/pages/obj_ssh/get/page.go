@@ -0,0 +1,113 @@
+package get
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type keyLoadedMsg struct {
+	item *SSHKey
+	err  error
+}
+
+type Model struct {
+	app     *app.Ctx
+	id      int64
+	loading bool
+	item    *SSHKey
+
+	// reveal shows the private key
+	reveal bool
+}
+
+func NewPage(app *app.Ctx, id int64) tea.Model {
+	return &Model{
+		app:     app,
+		id:      id,
+		loading: true,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return fetchKeyCmd(m.app, m.id)
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case keyLoadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.item = x.item
+		return m, nil
+
+	case tea.KeyMsg:
+		switch x.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			return m, nav.PreviousPageCmd()
+		case "h":
+			m.reveal = !m.reveal
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	if m.loading {
+		return "SSH key\n\nLoading...\n"
+	}
+
+	if m.item == nil {
+		return "SSH key\n\nNot found\n"
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b,
+		"SSH key\n\n"+
+			"Title: %s\n\n"+
+			"Type: %s\n\n"+
+			"Fingerprint: %s\n\n"+
+			"Public key:\n%s\n\n",
+		m.item.Title,
+		m.item.Type,
+		m.item.Fingerprint,
+		m.item.PublicKey,
+	)
+
+	if m.reveal {
+		fmt.Fprintf(&b, "Private key:\n%s\n", m.item.PrivateKey)
+	} else {
+		b.WriteString("Private key: ********\n\n")
+	}
+
+	b.WriteString("h показать/скрыть приватный ключ   esc назад\n")
+	return b.String()
+}
+
+func fetchKeyCmd(app *app.Ctx, id int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		item, err := GetSSHKeyByID(ctx, app, id)
+		return keyLoadedMsg{
+			item: item,
+			err:  err,
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package list
+
+import (
+	"client/internal/app"
+	"client/pkg/http_request_sender"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type SSHKey struct {
+	ID          int64  `json:"key_id"`
+	Title       string `json:"title"`
+	Type        string `json:"type"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// GetSSHKeyList gets list of ssh keys, private keys are not part of it
+func GetSSHKeyList(ctx context.Context, app *app.Ctx) ([]SSHKey, error) {
+	var respData []SSHKey
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.GET,
+		http_request_sender.SendDataCmd{
+			URL:    "http://127.0.0.1:8080/ssh/list",
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// no keys yet
+	if response.StatusCode() == http.StatusNoContent {
+		return nil, nil
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"GET /ssh/list failed: status=%d body=%s",
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return respData, nil
+}
@@ -2,6 +2,7 @@ package create
 
 import (
 	"client/internal/app"
+	"client/internal/domain/custom_field"
 	nav "client/internal/navigator"
 	"strings"
 
@@ -11,6 +12,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// customFieldsFrom is the index of the first custom field input, the ones before are fixed
+const customFieldsFrom = 3
+
 type Model struct {
 	app    *app.Ctx
 	inputs []textinput.Model
@@ -29,8 +33,13 @@ func NewPage(app *app.Ctx) tea.Model {
 	password.Prompt = "Text: "
 	password.CharLimit = 512
 
+	expires := textinput.New()
+	expires.Placeholder = "YYYY-MM-DD (optional)"
+	expires.Prompt = "Expires: "
+	expires.CharLimit = 10
+
 	return &Model{
-		inputs: []textinput.Model{username, password},
+		inputs: []textinput.Model{username, password, expires},
 		focus:  0,
 		app:    app,
 	}
@@ -59,9 +68,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case "ctrl+n":
+			m.inputs = append(m.inputs, newFieldInput())
+			m.setFocus(len(m.inputs) - 1)
+			return m, nil
+
 		case "enter":
 			if m.focus == submitIndex {
-				if err := CreateTextObj(m.app, m.inputs[0].Value(), m.inputs[1].Value()); err != nil {
+				fields, err := m.customFields()
+				if err != nil {
+					return m, nav.NextPageCmd(errorPage.New(err))
+				}
+
+				if err := CreateTextObj(m.app, m.inputs[0].Value(), m.inputs[1].Value(), m.inputs[2].Value(), fields); err != nil {
 					return m, nav.NextPageCmd(errorPage.New(err))
 				}
 
@@ -98,6 +117,24 @@ func (m *Model) setFocus(idx int) {
 	}
 }
 
+// newFieldInput is the input of one custom field, see custom_field.Parse
+func newFieldInput() textinput.Model {
+	field := textinput.New()
+	field.Placeholder = custom_field.Placeholder
+	field.Prompt = "Field: "
+	field.CharLimit = 1024
+	return field
+}
+
+// customFields parses the inputs added with ctrl+n
+func (m Model) customFields() ([]custom_field.Field, error) {
+	lines := make([]string, 0, len(m.inputs)-customFieldsFrom)
+	for _, in := range m.inputs[customFieldsFrom:] {
+		lines = append(lines, in.Value())
+	}
+	return custom_field.ParseAll(lines)
+}
+
 func (m *Model) blurAll() {
 	for i := range m.inputs {
 		m.inputs[i].Blur()
@@ -123,6 +160,6 @@ func (m Model) View() string {
 		b.WriteString("  [ Submit ]\n")
 	}
 
-	b.WriteString("\n[↑/↓] переключение   [Enter] выбрать   [tab] назад)\n")
+	b.WriteString("\n[↑/↓] переключение   [Enter] выбрать   [ctrl+n] поле   [tab] назад)\n")
 	return b.String()
 }
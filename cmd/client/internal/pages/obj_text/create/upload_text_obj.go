@@ -2,17 +2,23 @@ package create
 
 import (
 	"client/internal/app"
+	"client/internal/domain/custom_field"
+	"client/internal/domain/expiry"
 	"client/pkg/http_request_sender"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 type createTextObjRequest struct {
-	Title string `json:"title"`
-	Text  string `json:"text"`
+	Title     string     `json:"title"`
+	Text      string     `json:"text"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	CustomFields []custom_field.Field `json:"custom_fields,omitempty"`
 }
 
 type createTextObjResponse struct {
@@ -20,7 +26,7 @@ type createTextObjResponse struct {
 }
 
 // CreateTextObj create new User, get tokens
-func CreateTextObj(app *app.Ctx, title, text string) error {
+func CreateTextObj(app *app.Ctx, title, text, expires string, fields []custom_field.Field) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -30,6 +36,13 @@ func CreateTextObj(app *app.Ctx, title, text string) error {
 	)
 	reqData.Title = title
 	reqData.Text = text
+	reqData.CustomFields = fields
+
+	expiresAt, err := expiry.Parse(expires)
+	if err != nil {
+		return err
+	}
+	reqData.ExpiresAt = expiresAt
 
 	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.POST, http_request_sender.SendDataCmd{
 		URL:    "http://127.0.0.1:8080/text/create",
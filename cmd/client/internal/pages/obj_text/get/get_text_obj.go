@@ -2,17 +2,31 @@ package get
 
 import (
 	"client/internal/app"
+	"client/internal/domain/custom_field"
+	"client/internal/pages/shares"
 	"client/pkg/http_request_sender"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 type Text struct {
 	ID    int64  `json:"id"`
 	Title string `json:"title"`
 	Text  string `json:"text"`
+
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	CustomFields []custom_field.Field `json:"custom_fields,omitempty"`
+
+	Shared *shares.Shared `json:"shared,omitempty"`
+}
+
+// SecretText is the text as a secret link carries it
+func (t *Text) SecretText() string {
+	return t.Text + "\n\n" + custom_field.Plain(t.CustomFields)
 }
 
 // GetTextByID gets single text object by id
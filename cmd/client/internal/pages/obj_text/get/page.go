@@ -2,7 +2,12 @@ package get
 
 import (
 	"client/internal/app"
+	"client/internal/domain/custom_field"
+	"client/internal/domain/expiry"
 	nav "client/internal/navigator"
+	"client/internal/pages/attachments"
+	"client/internal/pages/secret_links"
+	"client/internal/pages/shares"
 	"context"
 	"fmt"
 	"time"
@@ -23,6 +28,10 @@ type Model struct {
 	loading bool
 	item    *Text
 	err     error
+	// reveal shows the values of hidden custom fields
+	reveal bool
+
+	attachments []attachments.Attachment
 }
 
 func NewPage(app *app.Ctx, id int64) tea.Model {
@@ -34,7 +43,10 @@ func NewPage(app *app.Ctx, id int64) tea.Model {
 }
 
 func (m Model) Init() tea.Cmd {
-	return fetchTextCmd(m.app, m.id)
+	return tea.Batch(
+		fetchTextCmd(m.app, m.id),
+		attachments.LoadCmd(m.app, attachments.ItemText, m.id),
+	)
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -48,12 +60,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.item = x.item
 		return m, nil
 
+	case attachments.LoadedMsg:
+		// the section is optional, a failed load just leaves it empty
+		m.attachments = x.Items
+		return m, nil
+
 	case tea.KeyMsg:
 		switch x.String() {
 		case "q", "ctrl+c":
 			return m, tea.Quit
 		case "tab":
 			return m, nav.PreviousPageCmd()
+		case "h":
+			m.reveal = !m.reveal
+			return m, nil
+		case "f":
+			return m, nav.NextPageCmd(attachments.NewPage(m.app, attachments.ItemText, m.id))
+		case "s":
+			// only the owner manages shares
+			if m.item == nil || m.item.Shared != nil {
+				return m, nil
+			}
+			return m, nav.NextPageCmd(shares.NewPage(m.app, shares.ItemText, m.id))
+		case "l":
+			// a one-time link for someone outside the system
+			if m.item == nil {
+				return m, nil
+			}
+			return m, nav.NextPageCmd(secret_links.NewCreatePage(m.app, m.item.Title, m.item.SecretText()))
 		}
 	}
 
@@ -69,15 +103,28 @@ func (m Model) View() string {
 		return "Text\n\nNot found\n"
 	}
 
+	expires := "never expires"
+	if m.item.ExpiresAt != nil {
+		expires = expiry.Describe(*m.item.ExpiresAt, time.Now())
+	}
+
 	return fmt.Sprintf(
 		"Text\n\n"+
 			"ID: %d\n"+
-			"Title: %s\n\n"+
+			"Title: %s\n"+
+			"Expiry: %s\n\n"+
+			"%s"+
 			"%s\n\n"+
-			"tab назад\n",
+			"%s"+
+			"%s"+
+			"h показать/скрыть поля   f вложения   s доступ   l ссылка   tab назад\n",
 		m.item.ID,
 		m.item.Title,
+		expires,
+		shares.Render(m.item.Shared),
 		m.item.Text,
+		custom_field.RenderAll(m.item.CustomFields, m.reveal),
+		attachments.Render(m.attachments),
 	)
 }
 
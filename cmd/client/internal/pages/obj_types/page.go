@@ -12,8 +12,12 @@ import (
 	account_list "client/internal/pages/obj_account/list"
 	card_create "client/internal/pages/obj_card/create"
 	card_list "client/internal/pages/obj_card/list"
+	cert_create "client/internal/pages/obj_cert/create"
+	cert_list "client/internal/pages/obj_cert/list"
 	file_list "client/internal/pages/obj_file/list"
 	file_upload "client/internal/pages/obj_file/upload"
+	ssh_create "client/internal/pages/obj_ssh/create"
+	ssh_list "client/internal/pages/obj_ssh/list"
 	text_create "client/internal/pages/obj_text/create"
 	text_list "client/internal/pages/obj_text/list"
 
@@ -32,7 +36,7 @@ func NewPage(app *app.Ctx, mode constants.Mode) tea.Model {
 		app:    app,
 		mode:   mode,
 		cursor: 0,
-		items:  []constants.ObjType{constants.Text, constants.Account, constants.File, constants.Bank},
+		items:  []constants.ObjType{constants.Text, constants.Account, constants.File, constants.Bank, constants.SSH, constants.Cert},
 	}
 }
 
@@ -83,6 +87,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case constants.Bank:
 					// return m, nav.NextPageCmd(create_bank.NewPage(m.app))
 					return m, nav.NextPageCmd(card_create.NewPage(m.app))
+				case constants.SSH:
+					return m, nav.NextPageCmd(ssh_create.NewPage(m.app))
+				case constants.Cert:
+					return m, nav.NextPageCmd(cert_create.NewPage(m.app))
 				default:
 					return m, nil
 				}
@@ -100,6 +108,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case constants.Bank:
 					// return m, nav.NextPageCmd(bank_list.NewPage(m.app))
 					return m, nav.NextPageCmd(card_list.NewPage(m.app))
+				case constants.SSH:
+					return m, nav.NextPageCmd(ssh_list.NewPage(m.app))
+				case constants.Cert:
+					return m, nav.NextPageCmd(cert_list.NewPage(m.app))
 				default:
 					return m, nil
 				}
@@ -0,0 +1,150 @@
+package orgs
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+	get_account "client/internal/pages/obj_account/get"
+	get_card "client/internal/pages/obj_card/get"
+	get_text "client/internal/pages/obj_text/get"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type itemsLoadedMsg struct {
+	items []Item
+	err   error
+}
+
+// ItemsModel lists the items owned by an organization
+type ItemsModel struct {
+	app *app.Ctx
+	org Org
+
+	loading bool
+	items   []Item
+	cursor  int
+}
+
+func NewItemsPage(app *app.Ctx, org Org) tea.Model {
+	return &ItemsModel{
+		app:     app,
+		org:     org,
+		loading: true,
+	}
+}
+
+func (m ItemsModel) Init() tea.Cmd {
+	return m.fetch()
+}
+
+func (m ItemsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case itemsLoadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.items = x.items
+		m.cursor = min(m.cursor, max(len(m.items)-1, 0))
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading {
+			if x.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch x.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case "enter":
+			if len(m.items) == 0 {
+				return m, nil
+			}
+			if page := itemPage(m.app, m.items[m.cursor]); page != nil {
+				return m, nav.NextPageCmd(page)
+			}
+			return m, nil
+
+		case "r":
+			m.loading = true
+			return m, m.fetch()
+
+		case "esc", "b":
+			return m, nav.PreviousPageCmd()
+		}
+	}
+
+	return m, nil
+}
+
+func (m ItemsModel) View() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s (%s)\n\n", m.org.Name, m.org.Role)
+
+	if m.loading {
+		b.WriteString("Loading...\n")
+		return b.String()
+	}
+
+	if len(m.items) == 0 {
+		b.WriteString("(no items)\n")
+	}
+
+	for i, it := range m.items {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		fmt.Fprintf(&b, "%s[%s] %s\n", prefix, it.Type, it.Title)
+	}
+
+	b.WriteString("\n[↑/↓] переключение   [enter] открыть   [r] обновить   [esc] назад\n")
+	return b.String()
+}
+
+func (m ItemsModel) fetch() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		items, err := Items(ctx, m.app, m.org.ID)
+		return itemsLoadedMsg{items: items, err: err}
+	}
+}
+
+// itemPage is the get page of an organization item
+func itemPage(app *app.Ctx, it Item) tea.Model {
+	switch it.Type {
+	case "account":
+		return get_account.NewPage(app, it.ID)
+	case "card":
+		return get_card.NewPage(app, it.ID)
+	case "text":
+		return get_text.NewPage(app, it.ID)
+	}
+	return nil
+}
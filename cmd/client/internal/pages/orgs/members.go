@@ -0,0 +1,232 @@
+package orgs
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type membersLoadedMsg struct {
+	members []Member
+	err     error
+}
+
+// MembersModel manages members of an organization, "n" asks for a username
+// and tab cycles the role before adding
+type MembersModel struct {
+	app *app.Ctx
+	org Org
+
+	loading bool
+	members []Member
+	cursor  int
+
+	adding bool
+	input  textinput.Model
+	role   string
+
+	status string
+}
+
+func NewMembersPage(app *app.Ctx, org Org) tea.Model {
+	input := textinput.New()
+	input.Prompt = "Username: "
+	input.CharLimit = 64
+
+	return &MembersModel{
+		app:     app,
+		org:     org,
+		loading: true,
+		input:   input,
+		role:    "viewer",
+	}
+}
+
+func (m MembersModel) Init() tea.Cmd {
+	return m.fetch()
+}
+
+func (m MembersModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case membersLoadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.members = x.members
+		m.cursor = min(m.cursor, max(len(m.members)-1, 0))
+		return m, nil
+
+	case doneMsg:
+		if x.err != nil {
+			m.loading = false
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.status = x.status
+		return m, m.fetch()
+
+	case tea.KeyMsg:
+		if m.adding {
+			switch x.String() {
+			case "enter":
+				username := strings.TrimSpace(m.input.Value())
+				m.adding = false
+				m.input.Blur()
+				if username == "" {
+					return m, nil
+				}
+				m.loading = true
+				return m, m.addCmd(username, m.role)
+			case "tab":
+				m.role = NextRole(m.role)
+				return m, nil
+			case "esc":
+				m.adding = false
+				m.input.Blur()
+				return m, nil
+			}
+
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+		}
+
+		if m.loading {
+			if x.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch x.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.cursor < len(m.members)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case "n":
+			m.adding, m.role = true, "viewer"
+			m.input.SetValue("")
+			return m, m.input.Focus()
+
+		case "r":
+			// moves the selected member to the next role
+			if len(m.members) == 0 {
+				return m, nil
+			}
+			mb := m.members[m.cursor]
+			m.loading = true
+			return m, m.roleCmd(mb, NextRole(mb.Role))
+
+		case "x":
+			if len(m.members) == 0 {
+				return m, nil
+			}
+			m.loading = true
+			return m, m.removeCmd(m.members[m.cursor])
+
+		case "esc", "b":
+			return m, nav.PreviousPageCmd()
+		}
+	}
+
+	return m, nil
+}
+
+func (m MembersModel) View() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Members of %s\n\n", m.org.Name)
+
+	if m.adding {
+		b.WriteString(m.input.View() + "\n")
+		fmt.Fprintf(&b, "Role: %s\n\n", m.role)
+		b.WriteString("[enter] добавить   [tab] сменить роль   [esc] отмена\n")
+		return b.String()
+	}
+
+	if m.loading {
+		b.WriteString("Loading...\n")
+		return b.String()
+	}
+
+	for i, mb := range m.members {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		fmt.Fprintf(&b, "%s%s (%s)\n", prefix, mb.Username, mb.Role)
+	}
+
+	if m.status != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.status)
+	}
+
+	b.WriteString("\n[↑/↓] переключение   [n] добавить   [r] сменить роль   [x] удалить   [esc] назад\n")
+	return b.String()
+}
+
+func (m MembersModel) fetch() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		members, err := Members(ctx, m.app, m.org.ID)
+		return membersLoadedMsg{members: members, err: err}
+	}
+}
+
+func (m MembersModel) addCmd(username, role string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := AddMember(ctx, m.app, m.org.ID, username, role); err != nil {
+			return doneMsg{err: err}
+		}
+		return doneMsg{status: fmt.Sprintf("%s added as %s", username, role)}
+	}
+}
+
+func (m MembersModel) roleCmd(mb Member, role string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := ChangeRole(ctx, m.app, m.org.ID, mb.UserID, role); err != nil {
+			return doneMsg{err: err}
+		}
+		return doneMsg{status: fmt.Sprintf("%s is now %s", mb.Username, role)}
+	}
+}
+
+func (m MembersModel) removeCmd(mb Member) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := RemoveMember(ctx, m.app, m.org.ID, mb.UserID); err != nil {
+			return doneMsg{err: err}
+		}
+		return doneMsg{status: fmt.Sprintf("%s removed", mb.Username)}
+	}
+}
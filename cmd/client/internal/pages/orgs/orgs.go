@@ -0,0 +1,139 @@
+package orgs
+
+import (
+	"client/internal/app"
+	"client/pkg/http_request_sender"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// member roles from the most to the least privileged
+var Roles = []string{"owner", "admin", "editor", "viewer"}
+
+type Org struct {
+	ID        int64     `json:"org_id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Member struct {
+	UserID    int64     `json:"user_id"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Item struct {
+	Type  string `json:"type"`
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+// List returns organizations of the current user
+func List(ctx context.Context, app *app.Ctx) ([]Org, error) {
+	var out []Org
+	return out, get(ctx, app, "http://127.0.0.1:8080/org/", &out)
+}
+
+func Members(ctx context.Context, app *app.Ctx, orgID int64) ([]Member, error) {
+	var out []Member
+	return out, get(ctx, app, fmt.Sprintf("http://127.0.0.1:8080/org/%d/members", orgID), &out)
+}
+
+// Items lists accounts, cards and texts owned by the organization
+func Items(ctx context.Context, app *app.Ctx, orgID int64) ([]Item, error) {
+	var out []Item
+	return out, get(ctx, app, fmt.Sprintf("http://127.0.0.1:8080/org/%d/items", orgID), &out)
+}
+
+func Create(ctx context.Context, app *app.Ctx, name string) error {
+	return send(ctx, app, http_request_sender.POST, "http://127.0.0.1:8080/org/", map[string]string{"name": name}, http.StatusOK)
+}
+
+// Delete removes the organization with all of its items
+func Delete(ctx context.Context, app *app.Ctx, orgID int64) error {
+	url := fmt.Sprintf("http://127.0.0.1:8080/org/%d", orgID)
+	return send(ctx, app, http_request_sender.DELETE, url, nil, http.StatusNoContent)
+}
+
+func AddMember(ctx context.Context, app *app.Ctx, orgID int64, username, role string) error {
+	url := fmt.Sprintf("http://127.0.0.1:8080/org/%d/members", orgID)
+	return send(ctx, app, http_request_sender.POST, url, map[string]string{"username": username, "role": role}, http.StatusNoContent)
+}
+
+func ChangeRole(ctx context.Context, app *app.Ctx, orgID, userID int64, role string) error {
+	url := fmt.Sprintf("http://127.0.0.1:8080/org/%d/members/%d", orgID, userID)
+	return send(ctx, app, http_request_sender.PUT, url, map[string]string{"role": role}, http.StatusNoContent)
+}
+
+// RemoveMember removes a member, removing yourself leaves the organization
+func RemoveMember(ctx context.Context, app *app.Ctx, orgID, userID int64) error {
+	url := fmt.Sprintf("http://127.0.0.1:8080/org/%d/members/%d", orgID, userID)
+	return send(ctx, app, http_request_sender.DELETE, url, nil, http.StatusNoContent)
+}
+
+// NextRole cycles through Roles, used to pick a role with a single key
+func NextRole(role string) string {
+	for i, r := range Roles {
+		if r == role {
+			return Roles[(i+1)%len(Roles)]
+		}
+	}
+	return Roles[len(Roles)-1]
+}
+
+// help func
+
+func get(ctx context.Context, app *app.Ctx, url string, out any) error {
+	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.GET, http_request_sender.SendDataCmd{
+		URL:    url,
+		Client: app.HTTP,
+		JWT:    app.GetToken(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// empty list
+	if response.StatusCode() == http.StatusNoContent {
+		return nil
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return fmt.Errorf(
+			"GET %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), out); err != nil {
+		return fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+func send(ctx context.Context, app *app.Ctx, method http_request_sender.Method, url string, data any, want int) error {
+	response, err := http_request_sender.SendJSONRequest(ctx, method, http_request_sender.SendDataCmd{
+		URL:    url,
+		Data:   data,
+		Client: app.HTTP,
+		JWT:    app.GetToken(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != want {
+		return errors.New(string(response.Body()))
+	}
+
+	return nil
+}
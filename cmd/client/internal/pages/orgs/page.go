@@ -0,0 +1,224 @@
+package orgs
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type loadedMsg struct {
+	orgs []Org
+	err  error
+}
+
+// doneMsg reports a finished create or delete
+type doneMsg struct {
+	status string
+	err    error
+}
+
+// Model lists organizations of the user, "n" asks for the name of a new one
+type Model struct {
+	app *app.Ctx
+
+	loading bool
+	orgs    []Org
+	cursor  int
+
+	creating bool
+	input    textinput.Model
+
+	status string
+}
+
+func NewPage(app *app.Ctx) tea.Model {
+	input := textinput.New()
+	input.Prompt = "Name: "
+	input.CharLimit = 128
+
+	return &Model{
+		app:     app,
+		loading: true,
+		input:   input,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.fetch()
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case loadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.orgs = x.orgs
+		m.cursor = min(m.cursor, max(len(m.orgs)-1, 0))
+		return m, nil
+
+	case doneMsg:
+		if x.err != nil {
+			m.loading = false
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.status = x.status
+		return m, m.fetch()
+
+	case tea.KeyMsg:
+		if m.creating {
+			switch x.String() {
+			case "enter":
+				name := strings.TrimSpace(m.input.Value())
+				m.creating = false
+				m.input.Blur()
+				if name == "" {
+					return m, nil
+				}
+				m.loading = true
+				return m, m.createCmd(name)
+			case "esc":
+				m.creating = false
+				m.input.Blur()
+				return m, nil
+			}
+
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+		}
+
+		if m.loading {
+			if x.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch x.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.cursor < len(m.orgs)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case "n":
+			m.creating = true
+			m.input.SetValue("")
+			return m, m.input.Focus()
+
+		case "enter":
+			if len(m.orgs) == 0 {
+				return m, nil
+			}
+			return m, nav.NextPageCmd(NewItemsPage(m.app, m.orgs[m.cursor]))
+
+		case "m":
+			if len(m.orgs) == 0 {
+				return m, nil
+			}
+			return m, nav.NextPageCmd(NewMembersPage(m.app, m.orgs[m.cursor]))
+
+		case "d":
+			// the server refuses unless the user is an owner
+			if len(m.orgs) == 0 {
+				return m, nil
+			}
+			m.loading = true
+			return m, m.deleteCmd(m.orgs[m.cursor])
+
+		case "esc", "b":
+			return m, nav.PreviousPageCmd()
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString("Organizations\n\n")
+
+	if m.creating {
+		b.WriteString(m.input.View() + "\n\n")
+		b.WriteString("[enter] создать   [esc] отмена\n")
+		return b.String()
+	}
+
+	if m.loading {
+		b.WriteString("Loading...\n")
+		return b.String()
+	}
+
+	if len(m.orgs) == 0 {
+		b.WriteString("(no organizations)\n")
+	}
+
+	for i, o := range m.orgs {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		fmt.Fprintf(&b, "%s%s (%s)\n", prefix, o.Name, o.Role)
+	}
+
+	if m.status != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.status)
+	}
+
+	b.WriteString("\n[↑/↓] переключение   [enter] объекты   [m] участники   [n] создать   [d] удалить   [esc] назад\n")
+	return b.String()
+}
+
+func (m Model) fetch() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		orgs, err := List(ctx, m.app)
+		return loadedMsg{orgs: orgs, err: err}
+	}
+}
+
+func (m Model) createCmd(name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := Create(ctx, m.app, name); err != nil {
+			return doneMsg{err: err}
+		}
+		return doneMsg{status: fmt.Sprintf("Organization %s created", name)}
+	}
+}
+
+func (m Model) deleteCmd(o Org) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := Delete(ctx, m.app, o.ID); err != nil {
+			return doneMsg{err: err}
+		}
+		return doneMsg{status: fmt.Sprintf("Organization %s deleted", o.Name)}
+	}
+}
@@ -8,13 +8,15 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
 
 	"github.com/go-resty/resty/v2"
 )
 
 type registrationRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+	DeviceName string `json:"device_name"`
 }
 
 type registrationResponse struct {
@@ -33,6 +35,8 @@ func Registration(client *resty.Client, username, password string) (*domain.Toke
 	)
 	reqData.Username = username
 	reqData.Password = password
+	// the server lists sessions by device, the host name tells them apart
+	reqData.DeviceName, _ = os.Hostname()
 
 	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.POST, http_request_sender.SendDataCmd{
 		URL:    "http://127.0.0.1:8080/user/auth/register", // fixme: create path from config
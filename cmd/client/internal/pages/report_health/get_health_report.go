@@ -0,0 +1,65 @@
+package report_health
+
+import (
+	"client/internal/app"
+	"client/pkg/http_request_sender"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type Item struct {
+	AccountID       int64    `json:"account_id"`
+	ServiceName     string   `json:"service_name"`
+	Username        string   `json:"username"`
+	Issues          []string `json:"issues"`
+	EntropyBits     float64  `json:"entropy_bits"`
+	PasswordAgeDays int      `json:"password_age_days"`
+	ReuseGroup      int      `json:"reuse_group"`
+}
+
+type Report struct {
+	Score    int    `json:"score"`
+	Total    int    `json:"total"`
+	Weak     int    `json:"weak"`
+	Reused   int    `json:"reused"`
+	Old      int    `json:"old"`
+	Breached int    `json:"breached"`
+	Items    []Item `json:"items"`
+}
+
+// GetHealthReport gets the password health summary of the vault
+func GetHealthReport(ctx context.Context, app *app.Ctx) (*Report, error) {
+	var respData Report
+
+	const url = "http://127.0.0.1:8080/report/health"
+
+	response, err := http_request_sender.SendJSONRequest(
+		ctx,
+		http_request_sender.GET,
+		http_request_sender.SendDataCmd{
+			URL:    url,
+			Client: app.HTTP,
+			JWT:    app.GetToken(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"GET %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return &respData, nil
+}
@@ -0,0 +1,144 @@
+package report_health
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+	get_obj "client/internal/pages/obj_account/get"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type reportLoadedMsg struct {
+	report *Report
+	err    error
+}
+
+type Model struct {
+	app     *app.Ctx
+	loading bool
+	report  *Report
+	cursor  int
+}
+
+func NewPage(app *app.Ctx) tea.Model {
+	return &Model{
+		app:     app,
+		loading: true,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return fetchReportCmd(m.app)
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case reportLoadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.report = x.report
+		m.cursor = 0
+		return m, nil
+
+	case tea.KeyMsg:
+		switch x.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.report != nil && m.cursor < len(m.report.Items)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case "enter":
+			if m.report == nil || len(m.report.Items) == 0 {
+				return m, nil
+			}
+			return m, nav.NextPageCmd(get_obj.NewPage(m.app, m.report.Items[m.cursor].AccountID))
+
+		case "r":
+			m.loading = true
+			return m, fetchReportCmd(m.app)
+
+		case "b":
+			return m, nav.PreviousPageCmd()
+
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString("Vault health\n\n")
+
+	if m.loading {
+		b.WriteString("Loading...\n")
+		return b.String()
+	}
+
+	r := m.report
+	fmt.Fprintf(&b, "Score: %d/100   accounts: %d\n", r.Score, r.Total)
+	fmt.Fprintf(&b, "weak: %d   reused: %d   old: %d   breached: %d\n\n", r.Weak, r.Reused, r.Old, r.Breached)
+
+	if len(r.Items) == 0 {
+		b.WriteString("No issues found\n")
+	}
+
+	for i, it := range r.Items {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		fmt.Fprintf(&b, "%s%s [%s]  %s\n", prefix, it.ServiceName, it.Username, describe(it))
+	}
+
+	b.WriteString("\n[↑/↓] переключение   [enter] открыть   [r] обновить   [b] назад\n")
+	return b.String()
+}
+
+func describe(it Item) string {
+	parts := make([]string, 0, len(it.Issues))
+	for _, issue := range it.Issues {
+		switch issue {
+		case "weak":
+			parts = append(parts, fmt.Sprintf("weak (~%.0f bits)", it.EntropyBits))
+		case "reused":
+			parts = append(parts, fmt.Sprintf("reused (group %d)", it.ReuseGroup))
+		case "old":
+			parts = append(parts, fmt.Sprintf("old (%d days)", it.PasswordAgeDays))
+		default:
+			parts = append(parts, issue)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func fetchReportCmd(app *app.Ctx) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		report, err := GetHealthReport(ctx, app)
+		return reportLoadedMsg{
+			report: report,
+			err:    err,
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package secret_links
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"client/internal/services/secret_link"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// lifetimes and view limits offered on the create page
+var (
+	TTLs  = []time.Duration{time.Hour, 24 * time.Hour, 7 * 24 * time.Hour}
+	Views = []int{1, 3, 5, 10}
+)
+
+type createdMsg struct {
+	created *secret_link.Created
+	err     error
+}
+
+// CreateModel creates a link for an ad-hoc secret or, when secret is given, for the
+// contents of an item, tab cycles the lifetime and ctrl+v the number of views
+type CreateModel struct {
+	app *app.Ctx
+
+	label  textinput.Model
+	secret textinput.Model
+	// item is the preset secret of an item, the secret input is not shown then
+	item string
+
+	ttl   int
+	views int
+
+	loading bool
+	created *secret_link.Created
+	status  string
+}
+
+func NewCreatePage(app *app.Ctx, label, item string) tea.Model {
+	labelInput := textinput.New()
+	labelInput.Prompt = "Label: "
+	labelInput.CharLimit = 128
+	labelInput.SetValue(label)
+
+	secretInput := textinput.New()
+	secretInput.Prompt = "Secret: "
+	secretInput.EchoMode = textinput.EchoPassword
+	secretInput.CharLimit = 4096
+
+	m := &CreateModel{
+		app:    app,
+		label:  labelInput,
+		secret: secretInput,
+		item:   item,
+		ttl:    1,
+	}
+	if item == "" {
+		m.secret.Focus()
+	} else {
+		m.label.Focus()
+	}
+	return m
+}
+
+func (m CreateModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m CreateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case createdMsg:
+		m.loading = false
+		if x.err != nil {
+			m.status = x.err.Error()
+			return m, nil
+		}
+		m.created = x.created
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading {
+			if x.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		// the link is shown once, any key leaves the page
+		if m.created != nil {
+			return m, nav.PreviousPageCmd()
+		}
+
+		switch x.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+
+		case "esc":
+			return m, nav.PreviousPageCmd()
+
+		case "tab":
+			m.ttl = (m.ttl + 1) % len(TTLs)
+			return m, nil
+
+		case "ctrl+v":
+			m.views = (m.views + 1) % len(Views)
+			return m, nil
+
+		case "up", "down":
+			if m.item != "" {
+				return m, nil
+			}
+			if m.label.Focused() {
+				m.label.Blur()
+				return m, m.secret.Focus()
+			}
+			m.secret.Blur()
+			return m, m.label.Focus()
+
+		case "enter":
+			secret := m.item
+			if secret == "" {
+				secret = m.secret.Value()
+			}
+			if strings.TrimSpace(secret) == "" {
+				m.status = "secret is empty"
+				return m, nil
+			}
+			m.loading, m.status = true, ""
+			return m, m.createCmd(strings.TrimSpace(m.label.Value()), secret)
+		}
+
+		var cmd tea.Cmd
+		if m.label.Focused() {
+			m.label, cmd = m.label.Update(msg)
+		} else {
+			m.secret, cmd = m.secret.Update(msg)
+		}
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m CreateModel) View() string {
+	var b strings.Builder
+
+	b.WriteString("New secret link\n\n")
+
+	if m.created != nil {
+		b.WriteString("Send this link, it is shown only once:\n\n")
+		fmt.Fprintf(&b, "%s\n\n", m.created.URL)
+		fmt.Fprintf(&b, "Views: %d   Expires: %s\n\n",
+			m.created.Link.MaxViews, m.created.Link.ExpiresAt.Local().Format("2006-01-02 15:04"))
+		b.WriteString("[любая клавиша] назад\n")
+		return b.String()
+	}
+
+	if m.loading {
+		b.WriteString("Encrypting...\n")
+		return b.String()
+	}
+
+	b.WriteString(m.label.View() + "\n")
+	if m.item == "" {
+		b.WriteString(m.secret.View() + "\n")
+	} else {
+		b.WriteString("Secret: contents of the item\n")
+	}
+	fmt.Fprintf(&b, "Lifetime: %s   Views: %d\n\n", TTLs[m.ttl], Views[m.views])
+
+	if m.status != "" {
+		fmt.Fprintf(&b, "%s\n\n", m.status)
+	}
+
+	b.WriteString("[enter] создать   [↑/↓] поле   [tab] срок   [ctrl+v] просмотры   [esc] отмена\n")
+	return b.String()
+}
+
+func (m CreateModel) createCmd(label, secret string) tea.Cmd {
+	ttl, views := TTLs[m.ttl], Views[m.views]
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		created, err := secret_link.Create(ctx, m.app, label, secret, ttl, views)
+		return createdMsg{created: created, err: err}
+	}
+}
@@ -0,0 +1,134 @@
+package secret_links
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"client/internal/services/secret_link"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type openedMsg struct {
+	opened *secret_link.Opened
+	err    error
+}
+
+// OpenModel reads a secret link in the terminal, it uses up a view like the browser does
+type OpenModel struct {
+	app *app.Ctx
+
+	input   textinput.Model
+	loading bool
+	opened  *secret_link.Opened
+	status  string
+}
+
+func NewOpenPage(app *app.Ctx) tea.Model {
+	input := textinput.New()
+	input.Prompt = "Link: "
+	input.CharLimit = 512
+	input.Focus()
+
+	return &OpenModel{
+		app:   app,
+		input: input,
+	}
+}
+
+func (m OpenModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m OpenModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case openedMsg:
+		m.loading = false
+		if x.err != nil {
+			m.status = x.err.Error()
+			return m, nil
+		}
+		m.opened = x.opened
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading {
+			if x.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		if m.opened != nil {
+			return m, nav.PreviousPageCmd()
+		}
+
+		switch x.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+
+		case "esc":
+			return m, nav.PreviousPageCmd()
+
+		case "enter":
+			link := strings.TrimSpace(m.input.Value())
+			if link == "" {
+				return m, nil
+			}
+			m.loading, m.status = true, ""
+			return m, m.openCmd(link)
+		}
+
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m OpenModel) View() string {
+	var b strings.Builder
+
+	b.WriteString("Open secret link\n\n")
+
+	if m.opened != nil {
+		fmt.Fprintf(&b, "%s\n\n", m.opened.Secret)
+		if m.opened.ViewsLeft > 0 {
+			fmt.Fprintf(&b, "The link can be opened %d more time(s).\n\n", m.opened.ViewsLeft)
+		} else {
+			b.WriteString("The secret is burned, the link no longer works.\n\n")
+		}
+		b.WriteString("[любая клавиша] назад\n")
+		return b.String()
+	}
+
+	if m.loading {
+		b.WriteString("Opening...\n")
+		return b.String()
+	}
+
+	b.WriteString(m.input.View() + "\n\n")
+
+	if m.status != "" {
+		fmt.Fprintf(&b, "%s\n\n", m.status)
+	}
+
+	b.WriteString("[enter] открыть   [esc] назад\n")
+	return b.String()
+}
+
+func (m OpenModel) openCmd(link string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		opened, err := secret_link.Open(ctx, m.app, link)
+		return openedMsg{opened: opened, err: err}
+	}
+}
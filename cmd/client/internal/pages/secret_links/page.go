@@ -0,0 +1,173 @@
+package secret_links
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"client/internal/services/secret_link"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type loadedMsg struct {
+	links []secret_link.Link
+	err   error
+}
+
+type deletedMsg struct {
+	label string
+	err   error
+}
+
+// Model lists the secret links of the user that were not read yet
+type Model struct {
+	app *app.Ctx
+
+	loading bool
+	links   []secret_link.Link
+	cursor  int
+
+	status string
+}
+
+func NewPage(app *app.Ctx) tea.Model {
+	return &Model{
+		app:     app,
+		loading: true,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.fetch()
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case loadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.links = x.links
+		m.cursor = min(m.cursor, max(len(m.links)-1, 0))
+		return m, nil
+
+	case deletedMsg:
+		if x.err != nil {
+			m.loading = false
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.status = fmt.Sprintf("link %s deleted", x.label)
+		return m, m.fetch()
+
+	case tea.KeyMsg:
+		if m.loading {
+			if x.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch x.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.cursor < len(m.links)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case "n":
+			return m, nav.NextPageCmd(NewCreatePage(m.app, "", ""))
+
+		case "o":
+			return m, nav.NextPageCmd(NewOpenPage(m.app))
+
+		case "x":
+			if len(m.links) == 0 {
+				return m, nil
+			}
+			m.loading = true
+			return m, m.deleteCmd(m.links[m.cursor])
+
+		case "r":
+			m.loading = true
+			return m, m.fetch()
+
+		case "esc", "b":
+			return m, nav.PreviousPageCmd()
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString("Secret links\n\n")
+
+	if m.loading {
+		b.WriteString("Loading...\n")
+		return b.String()
+	}
+
+	if len(m.links) == 0 {
+		b.WriteString("(no links)\n")
+	}
+
+	for i, l := range m.links {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		fmt.Fprintf(&b, "%s%s - %d/%d views left, expires %s\n",
+			prefix, labelOf(l), l.ViewsLeft, l.MaxViews, l.ExpiresAt.Local().Format("2006-01-02 15:04"))
+	}
+
+	if m.status != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.status)
+	}
+
+	b.WriteString("\n[↑/↓] переключение   [n] новая ссылка   [o] открыть ссылку   [x] удалить   [r] обновить   [esc] назад\n")
+	return b.String()
+}
+
+func (m Model) fetch() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		links, err := secret_link.List(ctx, m.app)
+		return loadedMsg{links: links, err: err}
+	}
+}
+
+func (m Model) deleteCmd(l secret_link.Link) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		return deletedMsg{label: labelOf(l), err: secret_link.Delete(ctx, m.app, l.ID)}
+	}
+}
+
+func labelOf(l secret_link.Link) string {
+	if l.Label != "" {
+		return l.Label
+	}
+	return l.ID
+}
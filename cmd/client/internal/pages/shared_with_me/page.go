@@ -0,0 +1,171 @@
+package shared_with_me
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+	get_account "client/internal/pages/obj_account/get"
+	get_card "client/internal/pages/obj_card/get"
+	get_text "client/internal/pages/obj_text/get"
+	"client/internal/pages/shares"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type loadedMsg struct {
+	items []shares.Share
+	err   error
+}
+
+type revokedMsg struct {
+	err error
+}
+
+// Model lists the items other users shared with the current user
+type Model struct {
+	app     *app.Ctx
+	loading bool
+	items   []shares.Share
+	cursor  int
+}
+
+func NewPage(app *app.Ctx) tea.Model {
+	return &Model{
+		app:     app,
+		loading: true,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return fetchCmd(m.app)
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case loadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.items = x.items
+		m.cursor = min(m.cursor, max(len(m.items)-1, 0))
+		return m, nil
+
+	case revokedMsg:
+		if x.err != nil {
+			m.loading = false
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		return m, fetchCmd(m.app)
+
+	case tea.KeyMsg:
+		if m.loading {
+			if x.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch x.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case "enter":
+			if len(m.items) == 0 {
+				return m, nil
+			}
+			if page := itemPage(m.app, m.items[m.cursor]); page != nil {
+				return m, nav.NextPageCmd(page)
+			}
+			return m, nil
+
+		case "x":
+			// drops the share, the owner keeps the item
+			if len(m.items) == 0 {
+				return m, nil
+			}
+			m.loading = true
+			return m, revokeCmd(m.app, m.items[m.cursor].ID)
+
+		case "esc", "b":
+			return m, nav.PreviousPageCmd()
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString("Shared with me\n\n")
+
+	if m.loading {
+		b.WriteString("Loading...\n")
+		return b.String()
+	}
+
+	if len(m.items) == 0 {
+		b.WriteString("(nothing shared)\n")
+	}
+
+	for i, it := range m.items {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		fmt.Fprintf(&b, "%s[%s] %s — %s (%s)\n", prefix, it.Type, it.Title, it.Owner, it.Permission)
+	}
+
+	b.WriteString("\n[↑/↓] переключение   [enter] открыть   [x] отказаться   [esc] назад\n")
+	return b.String()
+}
+
+// itemPage is the get page of a shared item
+func itemPage(app *app.Ctx, it shares.Share) tea.Model {
+	switch it.Type {
+	case shares.ItemAccount:
+		return get_account.NewPage(app, it.ItemID)
+	case shares.ItemCard:
+		return get_card.NewPage(app, it.ItemID)
+	case shares.ItemText:
+		return get_text.NewPage(app, it.ItemID)
+	}
+	return nil
+}
+
+func fetchCmd(app *app.Ctx) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		items, err := shares.Incoming(ctx, app)
+		return loadedMsg{items: items, err: err}
+	}
+}
+
+func revokeCmd(app *app.Ctx, shareID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		return revokedMsg{err: shares.Revoke(ctx, app, shareID)}
+	}
+}
@@ -0,0 +1,239 @@
+package shares
+
+import (
+	"client/internal/app"
+	nav "client/internal/navigator"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	errorPage "client/internal/pages/error"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type loadedMsg struct {
+	items []Share
+	err   error
+}
+
+// doneMsg reports a finished share or revoke
+type doneMsg struct {
+	status string
+	err    error
+}
+
+// Model lists the users an item is shared with, "n" asks for a username
+// and tab switches the permission before sharing
+type Model struct {
+	app      *app.Ctx
+	itemType string
+	itemID   int64
+
+	loading bool
+	items   []Share
+	cursor  int
+
+	adding     bool
+	input      textinput.Model
+	permission string
+
+	status string
+}
+
+func NewPage(app *app.Ctx, itemType string, itemID int64) tea.Model {
+	input := textinput.New()
+	input.Prompt = "Username: "
+	input.CharLimit = 64
+
+	return &Model{
+		app:        app,
+		itemType:   itemType,
+		itemID:     itemID,
+		loading:    true,
+		input:      input,
+		permission: PermRead,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.fetch()
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch x := msg.(type) {
+
+	case loadedMsg:
+		m.loading = false
+		if x.err != nil {
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.items = x.items
+		m.cursor = min(m.cursor, max(len(m.items)-1, 0))
+		return m, nil
+
+	case doneMsg:
+		if x.err != nil {
+			m.loading = false
+			return m, nav.NextPageCmd(errorPage.New(x.err))
+		}
+		m.status = x.status
+		return m, m.fetch()
+
+	case tea.KeyMsg:
+		if m.adding {
+			switch x.String() {
+			case "enter":
+				username := strings.TrimSpace(m.input.Value())
+				m.adding = false
+				m.input.Blur()
+				if username == "" {
+					return m, nil
+				}
+				m.loading = true
+				return m, m.shareCmd(username, m.permission)
+			case "tab":
+				m.permission = togglePermission(m.permission)
+				return m, nil
+			case "esc":
+				m.adding = false
+				m.input.Blur()
+				return m, nil
+			}
+
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+		}
+
+		if m.loading {
+			if x.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch x.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case "n":
+			m.adding, m.permission = true, PermRead
+			m.input.SetValue("")
+			return m, m.input.Focus()
+
+		case "w":
+			// flips the permission of the selected user
+			if len(m.items) == 0 {
+				return m, nil
+			}
+			it := m.items[m.cursor]
+			m.loading = true
+			return m, m.shareCmd(it.Grantee, togglePermission(it.Permission))
+
+		case "x":
+			if len(m.items) == 0 {
+				return m, nil
+			}
+			m.loading = true
+			return m, m.revokeCmd(m.items[m.cursor].ID)
+
+		case "esc", "tab":
+			return m, nav.PreviousPageCmd()
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Shares of %s #%d\n\n", m.itemType, m.itemID)
+
+	if m.adding {
+		b.WriteString(m.input.View() + "\n")
+		fmt.Fprintf(&b, "Permission: %s\n\n", m.permission)
+		b.WriteString("[enter] поделиться   [tab] чтение/запись   [esc] отмена\n")
+		return b.String()
+	}
+
+	if m.loading {
+		b.WriteString("Loading...\n")
+		return b.String()
+	}
+
+	if len(m.items) == 0 {
+		b.WriteString("(not shared)\n")
+	}
+
+	for i, it := range m.items {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		fmt.Fprintf(&b, "%s%s (%s)\n", prefix, it.Grantee, it.Permission)
+	}
+
+	if m.status != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.status)
+	}
+
+	b.WriteString("\n[↑/↓] переключение   [n] поделиться   [w] чтение/запись   [x] отозвать   [esc] назад\n")
+	return b.String()
+}
+
+func (m Model) fetch() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		items, err := ListForItem(ctx, m.app, m.itemType, m.itemID)
+		return loadedMsg{items: items, err: err}
+	}
+}
+
+func (m Model) shareCmd(username, permission string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := ShareItem(ctx, m.app, m.itemType, m.itemID, username, permission); err != nil {
+			return doneMsg{err: err}
+		}
+		return doneMsg{status: fmt.Sprintf("Shared with %s (%s)", username, permission)}
+	}
+}
+
+func (m Model) revokeCmd(shareID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := Revoke(ctx, m.app, shareID); err != nil {
+			return doneMsg{err: err}
+		}
+		return doneMsg{status: "Access revoked"}
+	}
+}
+
+func togglePermission(p string) string {
+	if p == PermWrite {
+		return PermRead
+	}
+	return PermWrite
+}
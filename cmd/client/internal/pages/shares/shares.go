@@ -0,0 +1,131 @@
+package shares
+
+import (
+	"client/internal/app"
+	"client/pkg/http_request_sender"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// item types that can be shared
+const (
+	ItemAccount = "account"
+	ItemCard    = "card"
+	ItemText    = "text"
+)
+
+const (
+	PermRead  = "read"
+	PermWrite = "write"
+)
+
+// Shared is set in get responses when the item belongs to another user
+type Shared struct {
+	Owner      string `json:"owner"`
+	Permission string `json:"permission"`
+}
+
+type Share struct {
+	ID         int64     `json:"share_id"`
+	Type       string    `json:"type"`
+	ItemID     int64     `json:"item_id"`
+	Title      string    `json:"title"`
+	Owner      string    `json:"owner"`
+	Grantee    string    `json:"grantee"`
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Incoming lists items of other users shared with the current user
+func Incoming(ctx context.Context, app *app.Ctx) ([]Share, error) {
+	return list(ctx, app, "http://127.0.0.1:8080/share/incoming")
+}
+
+// ListForItem lists who an item of the current user is shared with
+func ListForItem(ctx context.Context, app *app.Ctx, itemType string, itemID int64) ([]Share, error) {
+	return list(ctx, app, fmt.Sprintf("http://127.0.0.1:8080/share/%s/%d", itemType, itemID))
+}
+
+// ShareItem gives username access to an item, sharing again changes the permission
+func ShareItem(ctx context.Context, app *app.Ctx, itemType string, itemID int64, username, permission string) error {
+	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.POST, http_request_sender.SendDataCmd{
+		URL:    fmt.Sprintf("http://127.0.0.1:8080/share/%s/%d", itemType, itemID),
+		Data:   map[string]string{"username": username, "permission": permission},
+		Client: app.HTTP,
+		JWT:    app.GetToken(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return errors.New(string(response.Body()))
+	}
+
+	return nil
+}
+
+// Revoke removes a share, works for the owner and for the grantee
+func Revoke(ctx context.Context, app *app.Ctx, shareID int64) error {
+	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.DELETE, http_request_sender.SendDataCmd{
+		URL:    fmt.Sprintf("http://127.0.0.1:8080/share/%d", shareID),
+		Client: app.HTTP,
+		JWT:    app.GetToken(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != http.StatusNoContent {
+		return errors.New(string(response.Body()))
+	}
+
+	return nil
+}
+
+// Render is the owner line of a get page, empty for own items
+func Render(s *Shared) string {
+	if s == nil {
+		return ""
+	}
+	return fmt.Sprintf("Shared by %s (%s)\n\n", s.Owner, s.Permission)
+}
+
+// help func
+
+func list(ctx context.Context, app *app.Ctx, url string) ([]Share, error) {
+	var respData []Share
+
+	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.GET, http_request_sender.SendDataCmd{
+		URL:    url,
+		Client: app.HTTP,
+		JWT:    app.GetToken(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// nothing shared
+	if response.StatusCode() == http.StatusNoContent {
+		return nil, nil
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"GET %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return respData, nil
+}
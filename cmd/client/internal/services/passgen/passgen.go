@@ -0,0 +1,85 @@
+package passgen
+
+import (
+	"client/internal/app"
+	"client/pkg/http_request_sender"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	ModeRandom     = "random"
+	ModePassphrase = "passphrase"
+)
+
+type generateRequest struct {
+	Mode             string `json:"mode"`
+	ExcludeAmbiguous bool   `json:"exclude_ambiguous"`
+}
+
+type Password struct {
+	Password    string  `json:"password"`
+	Mode        string  `json:"mode"`
+	EntropyBits float64 `json:"entropy_bits"`
+}
+
+// GeneratedMsg is delivered to the page that asked for a password
+type GeneratedMsg struct {
+	Password *Password
+	Err      error
+}
+
+// GenerateCmd asks the server for a password with default options of the mode
+func GenerateCmd(app *app.Ctx, mode string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		password, err := Generate(ctx, app, mode)
+		return GeneratedMsg{
+			Password: password,
+			Err:      err,
+		}
+	}
+}
+
+func Generate(ctx context.Context, app *app.Ctx, mode string) (*Password, error) {
+	var respData Password
+
+	const url = "http://127.0.0.1:8080/tools/password"
+
+	response, err := http_request_sender.SendJSONRequest(ctx, http_request_sender.POST, http_request_sender.SendDataCmd{
+		URL:    url,
+		Data:   generateRequest{Mode: mode, ExcludeAmbiguous: mode == ModeRandom},
+		Client: app.HTTP,
+		JWT:    app.GetToken(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf(
+			"POST %s failed: status=%d body=%s",
+			url,
+			response.StatusCode(),
+			string(response.Body()),
+		)
+	}
+
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		return nil, fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return &respData, nil
+}
+
+// Describe is the hint shown under the password field
+func (p *Password) Describe() string {
+	return fmt.Sprintf("generated %s password, ~%.0f bits of entropy", p.Mode, p.EntropyBits)
+}
@@ -0,0 +1,211 @@
+package secret_link
+
+import (
+	"client/internal/app"
+	"client/pkg/http_request_sender"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const serverURL = "http://127.0.0.1:8080"
+
+// keySize is an AES-256 key, it only ever lives in the fragment of the link
+const keySize = 32
+
+type Link struct {
+	ID        string    `json:"link_id"`
+	Path      string    `json:"path"`
+	Label     string    `json:"label,omitempty"`
+	MaxViews  int       `json:"max_views"`
+	ViewsLeft int       `json:"views_left"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Created is a new link with the full URL, the key is not stored anywhere
+// so the URL can not be shown again later
+type Created struct {
+	Link Link
+	URL  string
+}
+
+// Opened is a decrypted secret
+type Opened struct {
+	Secret    string
+	ViewsLeft int
+}
+
+type createRequest struct {
+	Label      string `json:"label"`
+	Ciphertext []byte `json:"ciphertext"`
+	TTLMinutes int64  `json:"ttl_minutes"`
+	MaxViews   int    `json:"max_views"`
+}
+
+type openResponse struct {
+	Ciphertext []byte `json:"ciphertext"`
+	ViewsLeft  int    `json:"views_left"`
+}
+
+// Create encrypts the secret locally and uploads only the ciphertext
+func Create(ctx context.Context, app *app.Ctx, label, secret string, ttl time.Duration, maxViews int) (*Created, error) {
+	ciphertext, key, err := Seal([]byte(secret))
+	if err != nil {
+		return nil, fmt.Errorf("encrypt secret: %w", err)
+	}
+
+	var link Link
+	err = send(ctx, app, http_request_sender.POST, serverURL+"/secret/", createRequest{
+		Label:      label,
+		Ciphertext: ciphertext,
+		TTLMinutes: int64(ttl / time.Minute),
+		MaxViews:   maxViews,
+	}, http.StatusOK, &link)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Created{Link: link, URL: serverURL + link.Path + "#" + key}, nil
+}
+
+// List returns links of the current user that can still be opened
+func List(ctx context.Context, app *app.Ctx) ([]Link, error) {
+	var out []Link
+	return out, send(ctx, app, http_request_sender.GET, serverURL+"/secret/", nil, http.StatusOK, &out)
+}
+
+// Delete revokes a link before it is read
+func Delete(ctx context.Context, app *app.Ctx, id string) error {
+	return send(ctx, app, http_request_sender.DELETE, serverURL+"/secret/"+id, nil, http.StatusNoContent, nil)
+}
+
+// Open reads a link the way an outside reader does, it uses up one view
+func Open(ctx context.Context, app *app.Ctx, link string) (*Opened, error) {
+	u, key, err := Parse(link)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp openResponse
+	if err := send(ctx, app, http_request_sender.POST, u+"/open", nil, http.StatusOK, &resp); err != nil {
+		return nil, err
+	}
+
+	plaintext, err := Unseal(resp.Ciphertext, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Opened{Secret: string(plaintext), ViewsLeft: resp.ViewsLeft}, nil
+}
+
+// Seal encrypts with a fresh AES-GCM key, the nonce is prepended to the ciphertext
+// the same way the browser viewer expects it
+func Seal(plaintext []byte) ([]byte, string, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, "", err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, "", err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), base64.RawURLEncoding.EncodeToString(key), nil
+}
+
+func Unseal(ciphertext []byte, key string) ([]byte, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(key)
+	if err != nil || len(raw) != keySize {
+		return nil, errors.New("the link has an invalid key")
+	}
+
+	gcm, err := newGCM(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+
+	plaintext, err := gcm.Open(nil, ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.New("the secret can not be decrypted with the key of the link")
+	}
+
+	return plaintext, nil
+}
+
+// Parse splits a link into the URL of the secret and the key from its fragment
+func Parse(link string) (string, string, error) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || u.Scheme == "" || u.Host == "" || !strings.HasPrefix(u.Path, "/s/") {
+		return "", "", errors.New("not a secret link")
+	}
+
+	if u.Fragment == "" {
+		return "", "", errors.New("the link has no key")
+	}
+
+	key := u.Fragment
+	u.Fragment, u.RawQuery = "", ""
+	return u.String(), key, nil
+}
+
+// help func
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func send(ctx context.Context, app *app.Ctx, method http_request_sender.Method, url string, data any, want int, out any) error {
+	response, err := http_request_sender.SendJSONRequest(ctx, method, http_request_sender.SendDataCmd{
+		URL:    url,
+		Data:   data,
+		Client: app.HTTP,
+		JWT:    app.GetToken(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// empty list
+	if method == http_request_sender.GET && response.StatusCode() == http.StatusNoContent {
+		return nil
+	}
+
+	if response.StatusCode() != want {
+		return errors.New(string(response.Body()))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(response.Body(), out); err != nil {
+		return fmt.Errorf("json unmarshal response: %w", err)
+	}
+
+	return nil
+}
@@ -18,6 +18,8 @@ type (
 		FilePath string
 		JWT      string
 		URL      string
+		// Fields are extra form values sent along with the file
+		Fields map[string]string
 	}
 
 	SendDataCmd struct {
@@ -32,6 +34,8 @@ type (
 const (
 	POST Method = iota
 	GET
+	PUT
+	DELETE
 )
 
 func SendJSONRequest(c context.Context, method Method, cmd SendDataCmd) (*resty.Response, error) {
@@ -58,6 +62,10 @@ func SendJSONRequest(c context.Context, method Method, cmd SendDataCmd) (*resty.
 		return req.Post(cmd.URL)
 	case GET:
 		return req.Get(cmd.URL)
+	case PUT:
+		return req.Put(cmd.URL)
+	case DELETE:
+		return req.Delete(cmd.URL)
 	default:
 		return nil, errors.New("invalid method")
 	}
@@ -82,6 +90,9 @@ func SendFormDataRequest(cmd SendFileCmd) (*resty.Response, error) {
 	}
 
 	req := cmd.Client.R().SetFile("file", cmd.FilePath)
+	if len(cmd.Fields) > 0 {
+		req.SetFormData(cmd.Fields)
+	}
 
 	if cmd.JWT != "" {
 		cmd.Client.SetHeader("Authorization", cmd.JWT)
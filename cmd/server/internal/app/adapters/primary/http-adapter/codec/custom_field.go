@@ -0,0 +1,55 @@
+package codec
+
+import "server/internal/app/domain/custom_field"
+
+// CustomField is the JSON form of a custom field, type is "text", "hidden" or "url"
+type CustomField struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// CustomFieldsToDomain converts the fields of a request, the order is kept
+func CustomFieldsToDomain(fields []CustomField) []custom_field.Field {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	out := make([]custom_field.Field, 0, len(fields))
+	for _, f := range fields {
+		out = append(out, custom_field.Field{Name: f.Name, Kind: custom_field.Kind(f.Type), Value: f.Value})
+	}
+	return out
+}
+
+// CustomFieldsFromDomain converts the fields of a response, nil is omitted from the JSON
+func CustomFieldsFromDomain(fields []custom_field.Field) []CustomField {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	out := make([]CustomField, 0, len(fields))
+	for _, f := range fields {
+		out = append(out, CustomField{Name: f.Name, Type: string(f.Kind), Value: f.Value})
+	}
+	return out
+}
+
+// CustomFieldName is a custom field without its value, lists use it so hidden values only leave through a single item
+type CustomFieldName struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// CustomFieldNamesFromDomain converts the fields of a list item, values are dropped
+func CustomFieldNamesFromDomain(fields []custom_field.Field) []CustomFieldName {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	out := make([]CustomFieldName, 0, len(fields))
+	for _, f := range fields {
+		out = append(out, CustomFieldName{Name: f.Name, Type: string(f.Kind)})
+	}
+	return out
+}
@@ -0,0 +1,17 @@
+package codec
+
+import "server/internal/app/domain/share"
+
+// Shared tells the grantee whose item it is and what may be done with it
+type Shared struct {
+	Owner      string `json:"owner"`
+	Permission string `json:"permission"`
+}
+
+// SharedFromDomain is nil for own items, nil is omitted from the JSON
+func SharedFromDomain(a *share.Access) *Shared {
+	if a == nil {
+		return nil
+	}
+	return &Shared{Owner: a.OwnerName, Permission: a.Permission}
+}
@@ -0,0 +1,19 @@
+package codec
+
+import "time"
+
+// OptionalTime is used for optional timestamps in responses, a zero time is omitted
+func OptionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// TimeOrZero turns an optional timestamp of a request into a zero time when it is missing
+func TimeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
@@ -1,3 +1,9 @@
 package constants
 
 const UserIDKey = "UserID"
+
+// SessionIDKey is the session of the JWT, it tells the current device apart from the others
+const SessionIDKey = "SessionID"
+
+// ClaimsKey holds the verified claims of the JWT, logout revokes the token by them
+const ClaimsKey = "Claims"
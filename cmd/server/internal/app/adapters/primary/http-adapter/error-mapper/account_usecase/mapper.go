@@ -4,6 +4,8 @@ import (
 	"errors"
 	"net/http"
 	domain "server/internal/app/domain/account_obj"
+	"server/internal/app/domain/org"
+	"server/internal/app/domain/share"
 )
 
 // Process return httpStatus (200, 400 ...) and ErrMsg according to custom error type.
@@ -13,18 +15,31 @@ func Process(err error) (int, string) {
 	case errors.Is(err, domain.ErrEmptyAccountsList):
 		return http.StatusNoContent, err.Error()
 
-	case errors.Is(err, domain.ErrAccountNotFound):
+	case errors.Is(err, org.ErrOrgNotFound),
+		errors.Is(err, domain.ErrAccountNotFound),
+		errors.Is(err, domain.ErrTOTPNotConfigured):
 		return http.StatusNotFound, err.Error()
 
 	case errors.Is(err, domain.ErrInvalidUserID),
 		errors.Is(err, domain.ErrInvalidAccountID),
-		errors.Is(err, domain.ErrEmptyServiceName):
+		errors.Is(err, domain.ErrEmptyServiceName),
+		errors.Is(err, domain.ErrInvalidTOTPSecret),
+		errors.Is(err, domain.ErrInvalidCustomFields):
 		return http.StatusBadRequest, err.Error()
 
 	case errors.Is(err, domain.ErrFailedCreateAccount),
-		errors.Is(err, domain.ErrFailedUpdateAccount):
+		errors.Is(err, domain.ErrFailedUpdateAccount),
+		errors.Is(err, domain.ErrFailedDeleteAccount):
 		return http.StatusInternalServerError, err.Error()
 
+	case errors.Is(err, domain.ErrBreachCheckFailed):
+		return http.StatusInternalServerError, domain.ErrBreachCheckFailed.Error()
+
+	case errors.Is(err, share.ErrReadOnly),
+		errors.Is(err, share.ErrNotOwner),
+		errors.Is(err, org.ErrForbidden):
+		return http.StatusForbidden, err.Error()
+
 	default:
 		return http.StatusInternalServerError, "internal error"
 	}
@@ -2,10 +2,13 @@ package account_usecase
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 
 	domain "server/internal/app/domain/account_obj"
+	"server/internal/app/domain/org"
+	"server/internal/app/domain/share"
 )
 
 func TestProcess(t *testing.T) {
@@ -45,6 +48,30 @@ func TestProcess(t *testing.T) {
 			wantStatusCode: http.StatusBadRequest,
 			wantMessage:    domain.ErrEmptyServiceName.Error(),
 		},
+		{
+			name:           "ErrInvalidTOTPSecret -> 400",
+			err:            domain.ErrInvalidTOTPSecret,
+			wantStatusCode: http.StatusBadRequest,
+			wantMessage:    domain.ErrInvalidTOTPSecret.Error(),
+		},
+		{
+			name:           "ErrInvalidCustomFields -> 400",
+			err:            domain.ErrInvalidCustomFields,
+			wantStatusCode: http.StatusBadRequest,
+			wantMessage:    domain.ErrInvalidCustomFields.Error(),
+		},
+		{
+			name:           "ErrTOTPNotConfigured -> 404",
+			err:            domain.ErrTOTPNotConfigured,
+			wantStatusCode: http.StatusNotFound,
+			wantMessage:    domain.ErrTOTPNotConfigured.Error(),
+		},
+		{
+			name:           "wrapped ErrBreachCheckFailed -> 500 without details",
+			err:            fmt.Errorf("%w: read /data/pwned.txt: input/output error", domain.ErrBreachCheckFailed),
+			wantStatusCode: http.StatusInternalServerError,
+			wantMessage:    domain.ErrBreachCheckFailed.Error(),
+		},
 		{
 			name:           "ErrFailedCreateAccount -> 500",
 			err:            domain.ErrFailedCreateAccount,
@@ -57,6 +84,24 @@ func TestProcess(t *testing.T) {
 			wantStatusCode: http.StatusInternalServerError,
 			wantMessage:    domain.ErrFailedUpdateAccount.Error(),
 		},
+		{
+			name:           "org.ErrForbidden -> 403",
+			err:            org.ErrForbidden,
+			wantStatusCode: http.StatusForbidden,
+			wantMessage:    org.ErrForbidden.Error(),
+		},
+		{
+			name:           "org.ErrOrgNotFound -> 404",
+			err:            org.ErrOrgNotFound,
+			wantStatusCode: http.StatusNotFound,
+			wantMessage:    org.ErrOrgNotFound.Error(),
+		},
+		{
+			name:           "share.ErrReadOnly -> 403",
+			err:            share.ErrReadOnly,
+			wantStatusCode: http.StatusForbidden,
+			wantMessage:    share.ErrReadOnly.Error(),
+		},
 		{
 			name:           "unknown error -> 500 internal error",
 			err:            errors.New("some random error"),
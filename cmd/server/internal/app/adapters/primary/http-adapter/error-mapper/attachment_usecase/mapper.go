@@ -0,0 +1,38 @@
+package attachment_usecase
+
+import (
+	"errors"
+	"net/http"
+	domain "server/internal/app/domain/attachment"
+	"server/internal/app/domain/org"
+	"server/internal/app/domain/share"
+)
+
+// Process return httpStatus (200, 400 ...) and ErrMsg according to custom error type.
+func Process(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrEmptyAttachmentsList):
+		return http.StatusNoContent, err.Error()
+
+	case errors.Is(err, domain.ErrItemNotFound),
+		errors.Is(err, domain.ErrFileNotFound),
+		errors.Is(err, domain.ErrAttachmentNotFound):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, share.ErrReadOnly),
+		errors.Is(err, org.ErrForbidden):
+		return http.StatusForbidden, err.Error()
+
+	case errors.Is(err, domain.ErrAlreadyAttached):
+		return http.StatusConflict, err.Error()
+
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrInvalidItem),
+		errors.Is(err, domain.ErrInvalidItemID),
+		errors.Is(err, domain.ErrInvalidFileID):
+		return http.StatusBadRequest, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal error"
+	}
+}
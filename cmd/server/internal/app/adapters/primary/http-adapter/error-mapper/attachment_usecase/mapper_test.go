@@ -0,0 +1,89 @@
+package attachment_usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	domain "server/internal/app/domain/attachment"
+	"server/internal/app/domain/org"
+	"server/internal/app/domain/share"
+)
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantMsg    string
+	}{
+		{
+			name:       "ErrEmptyAttachmentsList -> 204",
+			err:        domain.ErrEmptyAttachmentsList,
+			wantStatus: http.StatusNoContent,
+			wantMsg:    domain.ErrEmptyAttachmentsList.Error(),
+		},
+		{
+			name:       "ErrItemNotFound -> 404",
+			err:        domain.ErrItemNotFound,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    domain.ErrItemNotFound.Error(),
+		},
+		{
+			name:       "share.ErrReadOnly -> 403",
+			err:        share.ErrReadOnly,
+			wantStatus: http.StatusForbidden,
+			wantMsg:    share.ErrReadOnly.Error(),
+		},
+		{
+			name:       "org.ErrForbidden -> 403",
+			err:        org.ErrForbidden,
+			wantStatus: http.StatusForbidden,
+			wantMsg:    org.ErrForbidden.Error(),
+		},
+		{
+			name:       "ErrFileNotFound -> 404",
+			err:        domain.ErrFileNotFound,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    domain.ErrFileNotFound.Error(),
+		},
+		{
+			name:       "ErrAttachmentNotFound -> 404",
+			err:        domain.ErrAttachmentNotFound,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    domain.ErrAttachmentNotFound.Error(),
+		},
+		{
+			name:       "ErrAlreadyAttached -> 409",
+			err:        domain.ErrAlreadyAttached,
+			wantStatus: http.StatusConflict,
+			wantMsg:    domain.ErrAlreadyAttached.Error(),
+		},
+		{
+			name:       "ErrInvalidItem -> 400",
+			err:        domain.ErrInvalidItem,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidItem.Error(),
+		},
+		{
+			name:       "wrapped repo error -> 500 internal error",
+			err:        fmt.Errorf("get text id=3: %w", errors.New("db down")),
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, msg := Process(tt.err)
+
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, status)
+			}
+			if msg != tt.wantMsg {
+				t.Fatalf("expected message %q, got %q", tt.wantMsg, msg)
+			}
+		})
+	}
+}
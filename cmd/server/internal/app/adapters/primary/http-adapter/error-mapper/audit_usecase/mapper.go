@@ -0,0 +1,27 @@
+package audit_usecase
+
+import (
+	"errors"
+	"net/http"
+	domain "server/internal/app/domain/audit"
+)
+
+// Process return httpStatus (200, 400 ...) and ErrMsg according to custom error type.
+func Process(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrEmptyLog):
+		return http.StatusNoContent, err.Error()
+
+	case errors.Is(err, domain.ErrNotVerified):
+		return http.StatusServiceUnavailable, err.Error()
+
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrInvalidAction),
+		errors.Is(err, domain.ErrInvalidRange),
+		errors.Is(err, domain.ErrInvalidLimit):
+		return http.StatusBadRequest, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal error"
+	}
+}
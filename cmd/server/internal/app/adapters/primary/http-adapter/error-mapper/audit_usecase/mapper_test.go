@@ -0,0 +1,75 @@
+package audit_usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	domain "server/internal/app/domain/audit"
+)
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantMsg    string
+	}{
+		{
+			name:       "ErrEmptyLog -> 204",
+			err:        domain.ErrEmptyLog,
+			wantStatus: http.StatusNoContent,
+			wantMsg:    domain.ErrEmptyLog.Error(),
+		},
+		{
+			name:       "ErrNotVerified -> 503",
+			err:        domain.ErrNotVerified,
+			wantStatus: http.StatusServiceUnavailable,
+			wantMsg:    domain.ErrNotVerified.Error(),
+		},
+		{
+			name:       "ErrInvalidUserID -> 400",
+			err:        domain.ErrInvalidUserID,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidUserID.Error(),
+		},
+		{
+			name:       "ErrInvalidAction -> 400",
+			err:        domain.ErrInvalidAction,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidAction.Error(),
+		},
+		{
+			name:       "ErrInvalidRange -> 400",
+			err:        domain.ErrInvalidRange,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidRange.Error(),
+		},
+		{
+			name:       "ErrInvalidLimit -> 400",
+			err:        domain.ErrInvalidLimit,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidLimit.Error(),
+		},
+		{
+			name:       "wrapped db error -> 500 internal error",
+			err:        fmt.Errorf("search audit events of user id=1: %w", errors.New("db down")),
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, msg := Process(tt.err)
+
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, status)
+			}
+			if msg != tt.wantMsg {
+				t.Fatalf("expected message %q, got %q", tt.wantMsg, msg)
+			}
+		})
+	}
+}
@@ -4,22 +4,39 @@ import (
 	"errors"
 	"net/http"
 	domain "server/internal/app/domain/bank_card_obj"
+	"server/internal/app/domain/org"
+	"server/internal/app/domain/share"
 )
 
 // Process return httpStatus (200, 400 ...) and ErrMsg according to custom error type.
 func Process(err error) (int, string) {
 	switch {
 
+	case errors.Is(err, org.ErrOrgNotFound):
+		return http.StatusNotFound, err.Error()
+
 	case errors.Is(err, domain.ErrInvalidUserID),
 		errors.Is(err, domain.ErrInvalidCardID),
 		errors.Is(err, domain.ErrEmptyBankName),
-		errors.Is(err, domain.ErrEmptyPID):
+		errors.Is(err, domain.ErrEmptyCardNumber),
+		errors.Is(err, domain.ErrInvalidCardNumber),
+		errors.Is(err, domain.ErrInvalidExpiry),
+		errors.Is(err, domain.ErrCardExpired),
+		errors.Is(err, domain.ErrInvalidCVV),
+		errors.Is(err, domain.ErrInvalidPIN),
+		errors.Is(err, domain.ErrInvalidCustomFields):
 		return http.StatusBadRequest, err.Error()
 
 	case errors.Is(err, domain.ErrFaildeCreateBankCardObject),
-		errors.Is(err, domain.ErrFailedUpdateBankCard):
+		errors.Is(err, domain.ErrFailedUpdateBankCard),
+		errors.Is(err, domain.ErrFailedDeleteBankCard):
 		return http.StatusInternalServerError, err.Error()
 
+	case errors.Is(err, share.ErrReadOnly),
+		errors.Is(err, share.ErrNotOwner),
+		errors.Is(err, org.ErrForbidden):
+		return http.StatusForbidden, err.Error()
+
 	default:
 		return http.StatusInternalServerError, "internal error"
 	}
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	domain "server/internal/app/domain/bank_card_obj"
+	"server/internal/app/domain/org"
+	"server/internal/app/domain/share"
 )
 
 func TestProcess(t *testing.T) {
@@ -34,10 +36,46 @@ func TestProcess(t *testing.T) {
 			wantMsg:    domain.ErrEmptyBankName.Error(),
 		},
 		{
-			name:       "ErrEmptyPID -> 400",
-			err:        domain.ErrEmptyPID,
+			name:       "ErrEmptyCardNumber -> 400",
+			err:        domain.ErrEmptyCardNumber,
 			wantStatus: http.StatusBadRequest,
-			wantMsg:    domain.ErrEmptyPID.Error(),
+			wantMsg:    domain.ErrEmptyCardNumber.Error(),
+		},
+		{
+			name:       "ErrInvalidCardNumber -> 400",
+			err:        domain.ErrInvalidCardNumber,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidCardNumber.Error(),
+		},
+		{
+			name:       "ErrInvalidExpiry -> 400",
+			err:        domain.ErrInvalidExpiry,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidExpiry.Error(),
+		},
+		{
+			name:       "ErrCardExpired -> 400",
+			err:        domain.ErrCardExpired,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrCardExpired.Error(),
+		},
+		{
+			name:       "ErrInvalidCVV -> 400",
+			err:        domain.ErrInvalidCVV,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidCVV.Error(),
+		},
+		{
+			name:       "ErrInvalidPIN -> 400",
+			err:        domain.ErrInvalidPIN,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidPIN.Error(),
+		},
+		{
+			name:       "ErrInvalidCustomFields -> 400",
+			err:        domain.ErrInvalidCustomFields,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidCustomFields.Error(),
 		},
 		{
 			name:       "ErrFaildeCreateBankCardObject -> 500",
@@ -51,6 +89,24 @@ func TestProcess(t *testing.T) {
 			wantStatus: http.StatusInternalServerError,
 			wantMsg:    domain.ErrFailedUpdateBankCard.Error(),
 		},
+		{
+			name:       "org.ErrForbidden -> 403",
+			err:        org.ErrForbidden,
+			wantStatus: http.StatusForbidden,
+			wantMsg:    org.ErrForbidden.Error(),
+		},
+		{
+			name:       "org.ErrOrgNotFound -> 404",
+			err:        org.ErrOrgNotFound,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    org.ErrOrgNotFound.Error(),
+		},
+		{
+			name:       "share.ErrReadOnly -> 403",
+			err:        share.ErrReadOnly,
+			wantStatus: http.StatusForbidden,
+			wantMsg:    share.ErrReadOnly.Error(),
+		},
 		{
 			name:       "unknown error -> 500 internal error",
 			err:        errors.New("boom"),
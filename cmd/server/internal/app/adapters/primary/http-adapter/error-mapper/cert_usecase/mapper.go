@@ -0,0 +1,33 @@
+package cert_usecase
+
+import (
+	"errors"
+	"net/http"
+	domain "server/internal/app/domain/cert_obj"
+)
+
+// Process return httpStatus (200, 400 ...) and ErrMsg according to custom error type.
+func Process(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrEmptyCertificatesList):
+		return http.StatusNoContent, err.Error()
+
+	case errors.Is(err, domain.ErrCertificateNotFound):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrInvalidCertificateID),
+		errors.Is(err, domain.ErrEmptyTitle),
+		errors.Is(err, domain.ErrInvalidCertificate),
+		errors.Is(err, domain.ErrInvalidPrivateKey),
+		errors.Is(err, domain.ErrKeyMismatch),
+		errors.Is(err, domain.ErrInvalidExpiryWindow):
+		return http.StatusBadRequest, err.Error()
+
+	case errors.Is(err, domain.ErrFailedCreateCertificate):
+		return http.StatusInternalServerError, domain.ErrFailedCreateCertificate.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal error"
+	}
+}
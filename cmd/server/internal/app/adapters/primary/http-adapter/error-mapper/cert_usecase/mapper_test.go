@@ -0,0 +1,75 @@
+package cert_usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	domain "server/internal/app/domain/cert_obj"
+)
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantMsg    string
+	}{
+		{
+			name:       "ErrEmptyCertificatesList -> 204",
+			err:        domain.ErrEmptyCertificatesList,
+			wantStatus: http.StatusNoContent,
+			wantMsg:    domain.ErrEmptyCertificatesList.Error(),
+		},
+		{
+			name:       "ErrCertificateNotFound -> 404",
+			err:        domain.ErrCertificateNotFound,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    domain.ErrCertificateNotFound.Error(),
+		},
+		{
+			name:       "ErrKeyMismatch -> 400",
+			err:        domain.ErrKeyMismatch,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrKeyMismatch.Error(),
+		},
+		{
+			name:       "ErrInvalidExpiryWindow -> 400",
+			err:        domain.ErrInvalidExpiryWindow,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidExpiryWindow.Error(),
+		},
+		{
+			name:       "wrapped ErrInvalidCertificate -> 400 with details",
+			err:        fmt.Errorf("%w: %w", domain.ErrInvalidCertificate, errors.New("no certificate found in PEM data")),
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "invalid certificate: no certificate found in PEM data",
+		},
+		{
+			name:       "wrapped ErrFailedCreateCertificate -> 500 without details",
+			err:        fmt.Errorf("%w: %w", domain.ErrFailedCreateCertificate, errors.New("db down")),
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    domain.ErrFailedCreateCertificate.Error(),
+		},
+		{
+			name:       "unknown error -> 500 internal error",
+			err:        errors.New("something bad happened"),
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, msg := Process(tt.err)
+
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, status)
+			}
+			if msg != tt.wantMsg {
+				t.Fatalf("expected message %q, got %q", tt.wantMsg, msg)
+			}
+		})
+	}
+}
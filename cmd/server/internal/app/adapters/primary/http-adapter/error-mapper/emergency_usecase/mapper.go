@@ -0,0 +1,39 @@
+package emergency_usecase
+
+import (
+	"errors"
+	"net/http"
+	domain "server/internal/app/domain/emergency"
+)
+
+// Process return httpStatus (200, 400 ...) and ErrMsg according to custom error type.
+func Process(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrEmptyContacts),
+		errors.Is(err, domain.ErrEmptyVault):
+		return http.StatusNoContent, err.Error()
+
+	case errors.Is(err, domain.ErrUserNotFound),
+		errors.Is(err, domain.ErrContactNotFound):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, domain.ErrAccessNotGranted):
+		return http.StatusForbidden, err.Error()
+
+	case errors.Is(err, domain.ErrAlreadyContact),
+		errors.Is(err, domain.ErrAlreadyRequested),
+		errors.Is(err, domain.ErrNotRequested):
+		return http.StatusConflict, err.Error()
+
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrInvalidContactID),
+		errors.Is(err, domain.ErrInvalidUsername),
+		errors.Is(err, domain.ErrInvalidAccessType),
+		errors.Is(err, domain.ErrInvalidWait),
+		errors.Is(err, domain.ErrSelfContact):
+		return http.StatusBadRequest, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal error"
+	}
+}
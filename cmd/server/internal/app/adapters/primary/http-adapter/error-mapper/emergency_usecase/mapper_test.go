@@ -0,0 +1,93 @@
+package emergency_usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	domain "server/internal/app/domain/emergency"
+)
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantMsg    string
+	}{
+		{
+			name:       "ErrEmptyContacts -> 204",
+			err:        domain.ErrEmptyContacts,
+			wantStatus: http.StatusNoContent,
+			wantMsg:    domain.ErrEmptyContacts.Error(),
+		},
+		{
+			name:       "ErrEmptyVault -> 204",
+			err:        domain.ErrEmptyVault,
+			wantStatus: http.StatusNoContent,
+			wantMsg:    domain.ErrEmptyVault.Error(),
+		},
+		{
+			name:       "ErrContactNotFound -> 404",
+			err:        domain.ErrContactNotFound,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    domain.ErrContactNotFound.Error(),
+		},
+		{
+			name:       "ErrUserNotFound -> 404",
+			err:        domain.ErrUserNotFound,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    domain.ErrUserNotFound.Error(),
+		},
+		{
+			name:       "ErrAccessNotGranted -> 403",
+			err:        domain.ErrAccessNotGranted,
+			wantStatus: http.StatusForbidden,
+			wantMsg:    domain.ErrAccessNotGranted.Error(),
+		},
+		{
+			name:       "ErrAlreadyRequested -> 409",
+			err:        domain.ErrAlreadyRequested,
+			wantStatus: http.StatusConflict,
+			wantMsg:    domain.ErrAlreadyRequested.Error(),
+		},
+		{
+			name:       "ErrNotRequested -> 409",
+			err:        domain.ErrNotRequested,
+			wantStatus: http.StatusConflict,
+			wantMsg:    domain.ErrNotRequested.Error(),
+		},
+		{
+			name:       "ErrInvalidWait -> 400",
+			err:        domain.ErrInvalidWait,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidWait.Error(),
+		},
+		{
+			name:       "ErrSelfContact -> 400",
+			err:        domain.ErrSelfContact,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrSelfContact.Error(),
+		},
+		{
+			name:       "wrapped db error -> 500 internal error",
+			err:        fmt.Errorf("list contacts of user id=1: %w", errors.New("db down")),
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, msg := Process(tt.err)
+
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, status)
+			}
+			if msg != tt.wantMsg {
+				t.Fatalf("expected message %q, got %q", tt.wantMsg, msg)
+			}
+		})
+	}
+}
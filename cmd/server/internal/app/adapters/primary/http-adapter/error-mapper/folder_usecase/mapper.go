@@ -0,0 +1,37 @@
+package folder_usecase
+
+import (
+	"errors"
+	"net/http"
+	domain "server/internal/app/domain/folder"
+	"server/internal/app/domain/org"
+)
+
+// Process return httpStatus (200, 400 ...) and ErrMsg according to custom error type.
+func Process(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrEmptyFolders):
+		return http.StatusNoContent, err.Error()
+
+	case errors.Is(err, domain.ErrFolderNotFound),
+		errors.Is(err, domain.ErrItemNotFound):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, org.ErrForbidden):
+		return http.StatusForbidden, err.Error()
+
+	case errors.Is(err, domain.ErrFolderExists),
+		errors.Is(err, domain.ErrFolderCycle):
+		return http.StatusConflict, err.Error()
+
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrInvalidFolderID),
+		errors.Is(err, domain.ErrInvalidItem),
+		errors.Is(err, domain.ErrInvalidItemID),
+		errors.Is(err, domain.ErrEmptyName):
+		return http.StatusBadRequest, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal error"
+	}
+}
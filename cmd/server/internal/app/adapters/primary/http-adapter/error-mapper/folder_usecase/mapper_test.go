@@ -0,0 +1,88 @@
+package folder_usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	domain "server/internal/app/domain/folder"
+	"server/internal/app/domain/org"
+)
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantMsg    string
+	}{
+		{
+			name:       "ErrEmptyFolders -> 204",
+			err:        domain.ErrEmptyFolders,
+			wantStatus: http.StatusNoContent,
+			wantMsg:    domain.ErrEmptyFolders.Error(),
+		},
+		{
+			name:       "ErrFolderNotFound -> 404",
+			err:        domain.ErrFolderNotFound,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    domain.ErrFolderNotFound.Error(),
+		},
+		{
+			name:       "ErrItemNotFound -> 404",
+			err:        domain.ErrItemNotFound,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    domain.ErrItemNotFound.Error(),
+		},
+		{
+			name:       "org.ErrForbidden -> 403",
+			err:        org.ErrForbidden,
+			wantStatus: http.StatusForbidden,
+			wantMsg:    org.ErrForbidden.Error(),
+		},
+		{
+			name:       "ErrFolderExists -> 409",
+			err:        domain.ErrFolderExists,
+			wantStatus: http.StatusConflict,
+			wantMsg:    domain.ErrFolderExists.Error(),
+		},
+		{
+			name:       "ErrFolderCycle -> 409",
+			err:        domain.ErrFolderCycle,
+			wantStatus: http.StatusConflict,
+			wantMsg:    domain.ErrFolderCycle.Error(),
+		},
+		{
+			name:       "ErrEmptyName -> 400",
+			err:        domain.ErrEmptyName,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrEmptyName.Error(),
+		},
+		{
+			name:       "ErrInvalidItem -> 400",
+			err:        domain.ErrInvalidItem,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidItem.Error(),
+		},
+		{
+			name:       "wrapped db error -> 500 internal error",
+			err:        fmt.Errorf("list folders: %w", errors.New("db down")),
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, msg := Process(tt.err)
+
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, status)
+			}
+			if msg != tt.wantMsg {
+				t.Fatalf("expected message %q, got %q", tt.wantMsg, msg)
+			}
+		})
+	}
+}
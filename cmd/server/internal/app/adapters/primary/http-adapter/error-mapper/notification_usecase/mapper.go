@@ -0,0 +1,27 @@
+package notification_usecase
+
+import (
+	"errors"
+	"net/http"
+	domain "server/internal/app/domain/notification"
+)
+
+// Process return httpStatus (200, 400 ...) and ErrMsg according to custom error type.
+func Process(err error) (int, string) {
+	switch {
+
+	case errors.Is(err, domain.ErrNotificationNotFound):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrInvalidNotificationID):
+		return http.StatusBadRequest, err.Error()
+
+	case errors.Is(err, domain.ErrFailedGetNotifications),
+		errors.Is(err, domain.ErrFailedMarkRead):
+		return http.StatusInternalServerError, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal error"
+	}
+}
@@ -0,0 +1,68 @@
+package notification_usecase
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	domain "server/internal/app/domain/notification"
+)
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantMsg    string
+	}{
+		{
+			name:       "ErrNotificationNotFound -> 404",
+			err:        domain.ErrNotificationNotFound,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    domain.ErrNotificationNotFound.Error(),
+		},
+		{
+			name:       "ErrInvalidUserID -> 400",
+			err:        domain.ErrInvalidUserID,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidUserID.Error(),
+		},
+		{
+			name:       "ErrInvalidNotificationID -> 400",
+			err:        domain.ErrInvalidNotificationID,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidNotificationID.Error(),
+		},
+		{
+			name:       "ErrFailedGetNotifications -> 500",
+			err:        domain.ErrFailedGetNotifications,
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    domain.ErrFailedGetNotifications.Error(),
+		},
+		{
+			name:       "ErrFailedMarkRead -> 500",
+			err:        domain.ErrFailedMarkRead,
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    domain.ErrFailedMarkRead.Error(),
+		},
+		{
+			name:       "unknown error -> 500 internal error",
+			err:        errors.New("something bad happened"),
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, msg := Process(tt.err)
+
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, status)
+			}
+			if msg != tt.wantMsg {
+				t.Fatalf("expected message %q, got %q", tt.wantMsg, msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package org_usecase
+
+import (
+	"errors"
+	"net/http"
+	domain "server/internal/app/domain/org"
+)
+
+// Process return httpStatus (200, 400 ...) and ErrMsg according to custom error type.
+func Process(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrEmptyOrgs),
+		errors.Is(err, domain.ErrEmptyOrgItems):
+		return http.StatusNoContent, err.Error()
+
+	case errors.Is(err, domain.ErrOrgNotFound),
+		errors.Is(err, domain.ErrUserNotFound),
+		errors.Is(err, domain.ErrNotMember):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden, err.Error()
+
+	case errors.Is(err, domain.ErrAlreadyMember),
+		errors.Is(err, domain.ErrLastOwner):
+		return http.StatusConflict, err.Error()
+
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrInvalidOrgID),
+		errors.Is(err, domain.ErrEmptyName),
+		errors.Is(err, domain.ErrInvalidRole),
+		errors.Is(err, domain.ErrInvalidUsername):
+		return http.StatusBadRequest, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal error"
+	}
+}
@@ -0,0 +1,81 @@
+package org_usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	domain "server/internal/app/domain/org"
+)
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantMsg    string
+	}{
+		{
+			name:       "ErrEmptyOrgs -> 204",
+			err:        domain.ErrEmptyOrgs,
+			wantStatus: http.StatusNoContent,
+			wantMsg:    domain.ErrEmptyOrgs.Error(),
+		},
+		{
+			name:       "ErrOrgNotFound -> 404",
+			err:        domain.ErrOrgNotFound,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    domain.ErrOrgNotFound.Error(),
+		},
+		{
+			name:       "ErrNotMember -> 404",
+			err:        domain.ErrNotMember,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    domain.ErrNotMember.Error(),
+		},
+		{
+			name:       "ErrForbidden -> 403",
+			err:        domain.ErrForbidden,
+			wantStatus: http.StatusForbidden,
+			wantMsg:    domain.ErrForbidden.Error(),
+		},
+		{
+			name:       "ErrAlreadyMember -> 409",
+			err:        domain.ErrAlreadyMember,
+			wantStatus: http.StatusConflict,
+			wantMsg:    domain.ErrAlreadyMember.Error(),
+		},
+		{
+			name:       "ErrLastOwner -> 409",
+			err:        domain.ErrLastOwner,
+			wantStatus: http.StatusConflict,
+			wantMsg:    domain.ErrLastOwner.Error(),
+		},
+		{
+			name:       "ErrInvalidRole -> 400",
+			err:        domain.ErrInvalidRole,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidRole.Error(),
+		},
+		{
+			name:       "wrapped db error -> 500 internal error",
+			err:        fmt.Errorf("list members of org id=1: %w", errors.New("db down")),
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, msg := Process(tt.err)
+
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, status)
+			}
+			if msg != tt.wantMsg {
+				t.Fatalf("expected message %q, got %q", tt.wantMsg, msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package secret_link_usecase
+
+import (
+	"errors"
+	"net/http"
+	domain "server/internal/app/domain/secret_link"
+)
+
+// Process return httpStatus (200, 400 ...) and ErrMsg according to custom error type.
+func Process(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrEmptyLinks):
+		return http.StatusNoContent, err.Error()
+
+	case errors.Is(err, domain.ErrLinkNotFound):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, domain.ErrSecretTooLarge):
+		return http.StatusRequestEntityTooLarge, err.Error()
+
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrEmptySecret),
+		errors.Is(err, domain.ErrInvalidTTL),
+		errors.Is(err, domain.ErrInvalidViews),
+		errors.Is(err, domain.ErrLabelTooLong):
+		return http.StatusBadRequest, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal error"
+	}
+}
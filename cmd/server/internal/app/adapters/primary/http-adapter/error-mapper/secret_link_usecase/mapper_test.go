@@ -0,0 +1,69 @@
+package secret_link_usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	domain "server/internal/app/domain/secret_link"
+)
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantMsg    string
+	}{
+		{
+			name:       "ErrEmptyLinks -> 204",
+			err:        domain.ErrEmptyLinks,
+			wantStatus: http.StatusNoContent,
+			wantMsg:    domain.ErrEmptyLinks.Error(),
+		},
+		{
+			name:       "ErrLinkNotFound -> 404",
+			err:        domain.ErrLinkNotFound,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    domain.ErrLinkNotFound.Error(),
+		},
+		{
+			name:       "ErrSecretTooLarge -> 413",
+			err:        domain.ErrSecretTooLarge,
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantMsg:    domain.ErrSecretTooLarge.Error(),
+		},
+		{
+			name:       "ErrInvalidTTL -> 400",
+			err:        domain.ErrInvalidTTL,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidTTL.Error(),
+		},
+		{
+			name:       "ErrEmptySecret -> 400",
+			err:        domain.ErrEmptySecret,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrEmptySecret.Error(),
+		},
+		{
+			name:       "ErrFailedCreateLink -> 500 internal error",
+			err:        fmt.Errorf("%w: %w", domain.ErrFailedCreateLink, errors.New("db down")),
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, msg := Process(tt.err)
+
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, status)
+			}
+			if msg != tt.wantMsg {
+				t.Fatalf("expected message %q, got %q", tt.wantMsg, msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package share_usecase
+
+import (
+	"errors"
+	"net/http"
+	domain "server/internal/app/domain/share"
+)
+
+// Process return httpStatus (200, 400 ...) and ErrMsg according to custom error type.
+func Process(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrEmptyShares):
+		return http.StatusNoContent, err.Error()
+
+	case errors.Is(err, domain.ErrItemNotFound),
+		errors.Is(err, domain.ErrUserNotFound),
+		errors.Is(err, domain.ErrShareNotFound):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrInvalidItem),
+		errors.Is(err, domain.ErrInvalidItemID),
+		errors.Is(err, domain.ErrInvalidShareID),
+		errors.Is(err, domain.ErrInvalidPermission),
+		errors.Is(err, domain.ErrShareWithSelf):
+		return http.StatusBadRequest, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal error"
+	}
+}
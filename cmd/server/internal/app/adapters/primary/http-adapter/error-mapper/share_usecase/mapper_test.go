@@ -0,0 +1,69 @@
+package share_usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	domain "server/internal/app/domain/share"
+)
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantMsg    string
+	}{
+		{
+			name:       "ErrEmptyShares -> 204",
+			err:        domain.ErrEmptyShares,
+			wantStatus: http.StatusNoContent,
+			wantMsg:    domain.ErrEmptyShares.Error(),
+		},
+		{
+			name:       "ErrUserNotFound -> 404",
+			err:        domain.ErrUserNotFound,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    domain.ErrUserNotFound.Error(),
+		},
+		{
+			name:       "ErrShareNotFound -> 404",
+			err:        domain.ErrShareNotFound,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    domain.ErrShareNotFound.Error(),
+		},
+		{
+			name:       "ErrInvalidPermission -> 400",
+			err:        domain.ErrInvalidPermission,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidPermission.Error(),
+		},
+		{
+			name:       "ErrShareWithSelf -> 400",
+			err:        domain.ErrShareWithSelf,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrShareWithSelf.Error(),
+		},
+		{
+			name:       "wrapped db error -> 500 internal error",
+			err:        fmt.Errorf("share account id=1: %w", errors.New("db down")),
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, msg := Process(tt.err)
+
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, status)
+			}
+			if msg != tt.wantMsg {
+				t.Fatalf("expected message %q, got %q", tt.wantMsg, msg)
+			}
+		})
+	}
+}
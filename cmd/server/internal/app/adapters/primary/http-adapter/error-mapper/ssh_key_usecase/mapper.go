@@ -0,0 +1,33 @@
+package ssh_key_usecase
+
+import (
+	"errors"
+	"net/http"
+	domain "server/internal/app/domain/ssh_key_obj"
+)
+
+// Process return httpStatus (200, 400 ...) and ErrMsg according to custom error type.
+func Process(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrEmptySSHKeysList):
+		return http.StatusNoContent, err.Error()
+
+	case errors.Is(err, domain.ErrSSHKeyNotFound):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrInvalidSSHKeyID),
+		errors.Is(err, domain.ErrEmptyTitle),
+		errors.Is(err, domain.ErrInvalidPrivateKey),
+		errors.Is(err, domain.ErrPassphraseRequired),
+		errors.Is(err, domain.ErrWrongPassphrase),
+		errors.Is(err, domain.ErrUnsupportedKeyType):
+		return http.StatusBadRequest, err.Error()
+
+	case errors.Is(err, domain.ErrFailedCreateSSHKey):
+		return http.StatusInternalServerError, domain.ErrFailedCreateSSHKey.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal error"
+	}
+}
@@ -0,0 +1,81 @@
+package ssh_key_usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	domain "server/internal/app/domain/ssh_key_obj"
+)
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantMsg    string
+	}{
+		{
+			name:       "ErrEmptySSHKeysList -> 204",
+			err:        domain.ErrEmptySSHKeysList,
+			wantStatus: http.StatusNoContent,
+			wantMsg:    domain.ErrEmptySSHKeysList.Error(),
+		},
+		{
+			name:       "ErrSSHKeyNotFound -> 404",
+			err:        domain.ErrSSHKeyNotFound,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    domain.ErrSSHKeyNotFound.Error(),
+		},
+		{
+			name:       "ErrEmptyTitle -> 400",
+			err:        domain.ErrEmptyTitle,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrEmptyTitle.Error(),
+		},
+		{
+			name:       "ErrPassphraseRequired -> 400",
+			err:        domain.ErrPassphraseRequired,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrPassphraseRequired.Error(),
+		},
+		{
+			name:       "ErrWrongPassphrase -> 400",
+			err:        domain.ErrWrongPassphrase,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrWrongPassphrase.Error(),
+		},
+		{
+			name:       "wrapped ErrInvalidPrivateKey -> 400 with details",
+			err:        fmt.Errorf("%w: %w", domain.ErrInvalidPrivateKey, errors.New("ssh: no key found")),
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "invalid ssh private key: ssh: no key found",
+		},
+		{
+			name:       "wrapped ErrFailedCreateSSHKey -> 500 without details",
+			err:        fmt.Errorf("%w: %w", domain.ErrFailedCreateSSHKey, errors.New("db down")),
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    domain.ErrFailedCreateSSHKey.Error(),
+		},
+		{
+			name:       "unknown error -> 500 internal error",
+			err:        errors.New("something bad happened"),
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, msg := Process(tt.err)
+
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, status)
+			}
+			if msg != tt.wantMsg {
+				t.Fatalf("expected message %q, got %q", tt.wantMsg, msg)
+			}
+		})
+	}
+}
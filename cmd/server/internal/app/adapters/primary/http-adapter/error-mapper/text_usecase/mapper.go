@@ -3,6 +3,8 @@ package text_usecase
 import (
 	"errors"
 	"net/http"
+	"server/internal/app/domain/org"
+	"server/internal/app/domain/share"
 	domain "server/internal/app/domain/text_obj"
 )
 
@@ -13,19 +15,27 @@ func Process(err error) (int, string) {
 	case errors.Is(err, domain.ErrEmptyTextsList):
 		return http.StatusNoContent, err.Error()
 
-	case errors.Is(err, domain.ErrTextNotFound):
+	case errors.Is(err, org.ErrOrgNotFound),
+		errors.Is(err, domain.ErrTextNotFound):
 		return http.StatusNotFound, err.Error()
 
 	case errors.Is(err, domain.ErrInvalidUserID),
 		errors.Is(err, domain.ErrInvalidTextID),
 		errors.Is(err, domain.ErrEmptyTitle),
-		errors.Is(err, domain.ErrEmptyText):
+		errors.Is(err, domain.ErrEmptyText),
+		errors.Is(err, domain.ErrInvalidCustomFields):
 		return http.StatusBadRequest, err.Error()
 
 	case errors.Is(err, domain.ErrFailedCreateText),
-		errors.Is(err, domain.ErrFailedUpdateText):
+		errors.Is(err, domain.ErrFailedUpdateText),
+		errors.Is(err, domain.ErrFailedDeleteText):
 		return http.StatusInternalServerError, err.Error()
 
+	case errors.Is(err, share.ErrReadOnly),
+		errors.Is(err, share.ErrNotOwner),
+		errors.Is(err, org.ErrForbidden):
+		return http.StatusForbidden, err.Error()
+
 	default:
 		return http.StatusInternalServerError, "internal error"
 	}
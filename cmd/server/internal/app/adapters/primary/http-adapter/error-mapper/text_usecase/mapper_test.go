@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"testing"
 
+	"server/internal/app/domain/org"
+	"server/internal/app/domain/share"
 	domain "server/internal/app/domain/text_obj"
 )
 
@@ -51,6 +53,12 @@ func TestProcess(t *testing.T) {
 			wantStatus: http.StatusBadRequest,
 			wantMsg:    domain.ErrEmptyText.Error(),
 		},
+		{
+			name:       "ErrInvalidCustomFields -> 400",
+			err:        domain.ErrInvalidCustomFields,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidCustomFields.Error(),
+		},
 		{
 			name:       "ErrFailedCreateText -> 500",
 			err:        domain.ErrFailedCreateText,
@@ -63,6 +71,24 @@ func TestProcess(t *testing.T) {
 			wantStatus: http.StatusInternalServerError,
 			wantMsg:    domain.ErrFailedUpdateText.Error(),
 		},
+		{
+			name:       "org.ErrForbidden -> 403",
+			err:        org.ErrForbidden,
+			wantStatus: http.StatusForbidden,
+			wantMsg:    org.ErrForbidden.Error(),
+		},
+		{
+			name:       "org.ErrOrgNotFound -> 404",
+			err:        org.ErrOrgNotFound,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    org.ErrOrgNotFound.Error(),
+		},
+		{
+			name:       "share.ErrReadOnly -> 403",
+			err:        share.ErrReadOnly,
+			wantStatus: http.StatusForbidden,
+			wantMsg:    share.ErrReadOnly.Error(),
+		},
 		{
 			name:       "unknown error -> 500 internal error",
 			err:        errors.New("something bad happened"),
@@ -0,0 +1,23 @@
+package tools_usecase
+
+import (
+	"errors"
+	"net/http"
+	domain "server/internal/app/domain/tools"
+)
+
+// Process return httpStatus (200, 400 ...) and ErrMsg according to custom error type.
+func Process(err error) (int, string) {
+	switch {
+
+	case errors.Is(err, domain.ErrUnknownPasswordMode),
+		errors.Is(err, domain.ErrInvalidPasswordOptions):
+		return http.StatusBadRequest, err.Error()
+
+	case errors.Is(err, domain.ErrFailedGeneratePassword):
+		return http.StatusInternalServerError, domain.ErrFailedGeneratePassword.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal error"
+	}
+}
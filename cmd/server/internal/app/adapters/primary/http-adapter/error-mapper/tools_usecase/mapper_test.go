@@ -0,0 +1,57 @@
+package tools_usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	domain "server/internal/app/domain/tools"
+)
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantMsg    string
+	}{
+		{
+			name:       "ErrUnknownPasswordMode -> 400",
+			err:        domain.ErrUnknownPasswordMode,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrUnknownPasswordMode.Error(),
+		},
+		{
+			name:       "ErrInvalidPasswordOptions -> 400",
+			err:        domain.ErrInvalidPasswordOptions,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidPasswordOptions.Error(),
+		},
+		{
+			name:       "wrapped ErrFailedGeneratePassword -> 500 without details",
+			err:        fmt.Errorf("%w: read random: eof", domain.ErrFailedGeneratePassword),
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    domain.ErrFailedGeneratePassword.Error(),
+		},
+		{
+			name:       "unknown error -> 500 internal error",
+			err:        errors.New("something bad happened"),
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, msg := Process(tt.err)
+
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, status)
+			}
+			if msg != tt.wantMsg {
+				t.Fatalf("expected message %q, got %q", tt.wantMsg, msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package two_factor_usecase
+
+import (
+	"errors"
+	"net/http"
+	domain "server/internal/app/domain/two_factor"
+)
+
+// Process return httpStatus (200, 400 ...) and ErrMsg according to custom error type.
+func Process(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCode),
+		errors.Is(err, domain.ErrChallengeNotFound):
+		return http.StatusUnauthorized, err.Error()
+
+	case errors.Is(err, domain.ErrLocked):
+		return http.StatusTooManyRequests, err.Error()
+
+	case errors.Is(err, domain.ErrNotEnrolled):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, domain.ErrAlreadyEnabled):
+		return http.StatusConflict, err.Error()
+
+	case errors.Is(err, domain.ErrInvalidUserID):
+		return http.StatusBadRequest, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal error"
+	}
+}
@@ -0,0 +1,69 @@
+package two_factor_usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	domain "server/internal/app/domain/two_factor"
+)
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantMsg    string
+	}{
+		{
+			name:       "ErrInvalidCode -> 401",
+			err:        domain.ErrInvalidCode,
+			wantStatus: http.StatusUnauthorized,
+			wantMsg:    domain.ErrInvalidCode.Error(),
+		},
+		{
+			name:       "ErrLocked -> 429",
+			err:        domain.ErrLocked,
+			wantStatus: http.StatusTooManyRequests,
+			wantMsg:    domain.ErrLocked.Error(),
+		},
+		{
+			name:       "ErrNotEnrolled -> 404",
+			err:        domain.ErrNotEnrolled,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    domain.ErrNotEnrolled.Error(),
+		},
+		{
+			name:       "ErrAlreadyEnabled -> 409",
+			err:        domain.ErrAlreadyEnabled,
+			wantStatus: http.StatusConflict,
+			wantMsg:    domain.ErrAlreadyEnabled.Error(),
+		},
+		{
+			name:       "ErrInvalidUserID -> 400",
+			err:        domain.ErrInvalidUserID,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrInvalidUserID.Error(),
+		},
+		{
+			name:       "unknown -> 500 internal error",
+			err:        fmt.Errorf("failed to get two-factor: %w", errors.New("db down")),
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, msg := Process(tt.err)
+
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, status)
+			}
+			if msg != tt.wantMsg {
+				t.Fatalf("expected message %q, got %q", tt.wantMsg, msg)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package user_usecase
 import (
 	"errors"
 	"net/http"
+	twoFactor "server/internal/app/domain/two_factor"
 	domain "server/internal/app/domain/user"
 )
 
@@ -14,7 +15,8 @@ func Process(err error) (int, string) {
 	)
 
 	switch {
-	case errors.Is(err, domain.ErrUsernameAlreadyExists):
+	case errors.Is(err, domain.ErrUsernameAlreadyExists),
+		errors.Is(err, domain.ErrLastOrgOwner):
 		httpStatus = http.StatusConflict
 		responseMessage = err.Error()
 	case errors.Is(err, domain.ErrPasswordMismatch):
@@ -23,15 +25,31 @@ func Process(err error) (int, string) {
 	case errors.Is(err, domain.ErrTokenNotValid):
 		httpStatus = http.StatusUnauthorized
 		responseMessage = err.Error()
-	case errors.Is(err, domain.ErrInvalidRefreshToken):
+	case errors.Is(err, domain.ErrInvalidRefreshToken),
+		errors.Is(err, domain.ErrRefreshTokenReused),
+		errors.Is(err, domain.ErrRefreshTokenExpired):
 		httpStatus = http.StatusUnauthorized
 		responseMessage = err.Error()
+	case errors.Is(err, twoFactor.ErrInvalidCode),
+		errors.Is(err, twoFactor.ErrChallengeNotFound):
+		httpStatus = http.StatusUnauthorized
+		responseMessage = err.Error()
+	case errors.Is(err, twoFactor.ErrLocked):
+		httpStatus = http.StatusTooManyRequests
+		responseMessage = err.Error()
 	case errors.Is(err, domain.ErrRefreshTokenNotFound):
 		httpStatus = http.StatusBadRequest
 		responseMessage = err.Error()
 	case errors.Is(err, domain.ErrTokenRevoked):
 		httpStatus = http.StatusForbidden
 		responseMessage = err.Error()
+	case errors.Is(err, domain.ErrEmptyUsername),
+		errors.Is(err, domain.ErrEmptyPassword):
+		httpStatus = http.StatusBadRequest
+		responseMessage = err.Error()
+	case errors.Is(err, domain.ErrSessionNotFound):
+		httpStatus = http.StatusNotFound
+		responseMessage = err.Error()
 	case errors.Is(err, domain.ErrUserNotFound):
 		httpStatus = http.StatusForbidden
 		responseMessage = err.Error()
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"testing"
 
+	twoFactor "server/internal/app/domain/two_factor"
 	domain "server/internal/app/domain/user"
 )
 
@@ -21,6 +22,12 @@ func TestProcess(t *testing.T) {
 			wantStatus: http.StatusConflict,
 			wantMsg:    domain.ErrUsernameAlreadyExists.Error(),
 		},
+		{
+			name:       "ErrLastOrgOwner -> 409",
+			err:        domain.ErrLastOrgOwner,
+			wantStatus: http.StatusConflict,
+			wantMsg:    domain.ErrLastOrgOwner.Error(),
+		},
 		{
 			name:       "ErrPasswordMismatch -> 401",
 			err:        domain.ErrPasswordMismatch,
@@ -39,18 +46,66 @@ func TestProcess(t *testing.T) {
 			wantStatus: http.StatusUnauthorized,
 			wantMsg:    domain.ErrInvalidRefreshToken.Error(),
 		},
+		{
+			name:       "ErrRefreshTokenReused -> 401",
+			err:        domain.ErrRefreshTokenReused,
+			wantStatus: http.StatusUnauthorized,
+			wantMsg:    domain.ErrRefreshTokenReused.Error(),
+		},
+		{
+			name:       "ErrRefreshTokenExpired -> 401",
+			err:        domain.ErrRefreshTokenExpired,
+			wantStatus: http.StatusUnauthorized,
+			wantMsg:    domain.ErrRefreshTokenExpired.Error(),
+		},
 		{
 			name:       "ErrRefreshTokenNotFound -> 400",
 			err:        domain.ErrRefreshTokenNotFound,
 			wantStatus: http.StatusBadRequest,
 			wantMsg:    domain.ErrRefreshTokenNotFound.Error(),
 		},
+		{
+			name:       "ErrInvalidCode -> 401",
+			err:        twoFactor.ErrInvalidCode,
+			wantStatus: http.StatusUnauthorized,
+			wantMsg:    twoFactor.ErrInvalidCode.Error(),
+		},
+		{
+			name:       "ErrChallengeNotFound -> 401",
+			err:        twoFactor.ErrChallengeNotFound,
+			wantStatus: http.StatusUnauthorized,
+			wantMsg:    twoFactor.ErrChallengeNotFound.Error(),
+		},
+		{
+			name:       "ErrLocked -> 429",
+			err:        twoFactor.ErrLocked,
+			wantStatus: http.StatusTooManyRequests,
+			wantMsg:    twoFactor.ErrLocked.Error(),
+		},
 		{
 			name:       "ErrTokenRevoked -> 403",
 			err:        domain.ErrTokenRevoked,
 			wantStatus: http.StatusForbidden,
 			wantMsg:    domain.ErrTokenRevoked.Error(),
 		},
+		{
+			name:       "ErrEmptyUsername -> 400",
+			err:        domain.ErrEmptyUsername,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrEmptyUsername.Error(),
+		},
+		{
+			name:       "ErrEmptyPassword -> 400",
+			err:        domain.ErrEmptyPassword,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    domain.ErrEmptyPassword.Error(),
+		},
+		{
+			name:       "ErrSessionNotFound -> 404",
+			err:        domain.ErrSessionNotFound,
+			wantStatus: http.StatusNotFound,
+			wantMsg:    domain.ErrSessionNotFound.Error(),
+		},
 		{
 			name:       "ErrUserNotFound -> 403",
 			err:        domain.ErrUserNotFound,
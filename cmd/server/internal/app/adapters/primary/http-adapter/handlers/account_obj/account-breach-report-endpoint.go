@@ -0,0 +1,60 @@
+package account_obj
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/account_usecase"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type BreachedAccount struct {
+	AccountID   int64  `json:"account_id"`
+	ServiceName string `json:"service_name"`
+	Username    string `json:"username"`
+	BreachCount int    `json:"breach_count"`
+}
+
+type BreachReportResponse struct {
+	Enabled     bool              `json:"enabled"`
+	Checked     int               `json:"checked"`
+	Compromised []BreachedAccount `json:"compromised"`
+}
+
+func (h *HttpHandler) GetBreachReport(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "GetBreachReport"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	report, err := h.service.BreachReport(r.Context(), userId)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	resp := BreachReportResponse{
+		Enabled:     report.Enabled,
+		Checked:     report.Checked,
+		Compromised: make([]BreachedAccount, 0, len(report.Compromised)),
+	}
+
+	for _, item := range report.Compromised {
+		resp.Compromised = append(resp.Compromised, BreachedAccount{
+			AccountID:   item.AccountId,
+			ServiceName: item.ServiceName,
+			Username:    item.UserName,
+			BreachCount: item.BreachCount,
+		})
+	}
+
+	codec.WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,89 @@
+package account_obj
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	domain "server/internal/app/domain/account_obj"
+	"strings"
+	"testing"
+)
+
+func TestHttpHandler_GetBreachReport(t *testing.T) {
+	t.Run("missing userID in context -> 422", func(t *testing.T) {
+		h := New(&serviceMock{
+			breachReportFn: func(ctx context.Context, userId int64) (*domain.BreachReport, error) {
+				t.Fatalf("service must NOT be called without user")
+				return nil, nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/report/breaches", nil)
+		rr := httptest.NewRecorder()
+
+		h.GetBreachReport(rr, req)
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("dataset failure -> 500", func(t *testing.T) {
+		h := New(&serviceMock{
+			breachReportFn: func(ctx context.Context, userId int64) (*domain.BreachReport, error) {
+				return nil, domain.ErrBreachCheckFailed
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/report/breaches", nil)
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(1)))
+		rr := httptest.NewRecorder()
+
+		h.GetBreachReport(rr, req)
+
+		if rr.Code != http.StatusInternalServerError {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusInternalServerError, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 200 + compromised accounts", func(t *testing.T) {
+		svc := &serviceMock{
+			breachReportFn: func(ctx context.Context, userId int64) (*domain.BreachReport, error) {
+				return &domain.BreachReport{
+					Enabled: true,
+					Checked: 3,
+					Compromised: []*domain.Account{
+						{AccountId: 4, ServiceName: "github", UserName: "stas", Password: "password", BreachCount: 3861493},
+					},
+				}, nil
+			},
+		}
+		h := New(svc)
+
+		req := httptest.NewRequest(http.MethodGet, "/report/breaches", nil)
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(9)))
+		rr := httptest.NewRecorder()
+
+		h.GetBreachReport(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		if svc.lastUserID != 9 {
+			t.Fatalf("expected userId=9, got %d", svc.lastUserID)
+		}
+
+		var resp BreachReportResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid json: %v body=%s", err, rr.Body.String())
+		}
+		if !resp.Enabled || resp.Checked != 3 || len(resp.Compromised) != 1 || resp.Compromised[0].BreachCount != 3861493 {
+			t.Fatalf("unexpected resp: %+v", resp)
+		}
+		if strings.Contains(rr.Body.String(), `"password"`) {
+			t.Fatalf("report must not expose passwords: %s", rr.Body.String())
+		}
+	})
+}
@@ -8,6 +8,7 @@ import (
 	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/account_usecase"
 	domain "server/internal/app/domain/account_obj"
 	"server/internal/pkg/logger"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -16,6 +17,12 @@ type CreateAccountRequest struct {
 	ServiceName string `json:"service_name"`
 	UserName    string `json:"user_name"`
 	Password    string `json:"password"`
+	TOTP        string `json:"totp"`
+
+	ExpiresAt    *time.Time          `json:"expires_at,omitempty"`
+	CustomFields []codec.CustomField `json:"custom_fields,omitempty"`
+	// OrgID creates the item in an organization instead of the personal vault
+	OrgID int64 `json:"org_id,omitempty"`
 }
 
 type CreateAccountResponse struct {
@@ -63,7 +70,12 @@ func (h *HttpHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 
 func (req CreateAccountRequest) toDomain() *domain.Account {
 	return &domain.Account{
-		ServiceName: req.ServiceName,
-		UserName:    req.UserName,
-		Password:    req.Password}
+		ServiceName:  req.ServiceName,
+		UserName:     req.UserName,
+		Password:     req.Password,
+		TOTP:         req.TOTP,
+		ExpiresAt:    codec.TimeOrZero(req.ExpiresAt),
+		CustomFields: codec.CustomFieldsToDomain(req.CustomFields),
+		OrgID:        req.OrgID,
+	}
 }
@@ -27,6 +27,9 @@ type serviceMock struct {
 	getAccountFn      func(ctx context.Context, accountId int64) (*domain.Account, error)
 	createFn          func(ctx context.Context, account *domain.Account) (int64, error)
 	updateFn          func(ctx context.Context, account *domain.Account) error
+	deleteFn          func(ctx context.Context, userId, accountId int64) error
+	getTOTPCodeFn     func(ctx context.Context, accountId int64) (*domain.TOTPCode, error)
+	breachReportFn    func(ctx context.Context, userId int64) (*domain.BreachReport, error)
 
 	getAccountsListCalled int
 	getAccountCalled      int
@@ -44,7 +47,7 @@ func (m *serviceMock) GetAccountsList(ctx context.Context, userId int64) ([]*dom
 	return m.getAccountsListFn(ctx, userId)
 }
 
-func (m *serviceMock) GetAccount(ctx context.Context, accountId int64) (*domain.Account, error) {
+func (m *serviceMock) GetAccount(ctx context.Context, userId, accountId int64) (*domain.Account, error) {
 	m.getAccountCalled++
 	m.lastAccountID = accountId
 	return m.getAccountFn(ctx, accountId)
@@ -62,6 +65,21 @@ func (m *serviceMock) UpdateAccount(ctx context.Context, account *domain.Account
 	return m.updateFn(ctx, account)
 }
 
+func (m *serviceMock) DeleteAccount(ctx context.Context, userId, accountId int64) error {
+	m.lastAccountID = accountId
+	return m.deleteFn(ctx, userId, accountId)
+}
+
+func (m *serviceMock) BreachReport(ctx context.Context, userId int64) (*domain.BreachReport, error) {
+	m.lastUserID = userId
+	return m.breachReportFn(ctx, userId)
+}
+
+func (m *serviceMock) GetTOTPCode(ctx context.Context, userId, accountId int64) (*domain.TOTPCode, error) {
+	m.lastAccountID = accountId
+	return m.getTOTPCodeFn(ctx, accountId)
+}
+
 func TestHttpHandler_CreateAccount(t *testing.T) {
 	t.Run("bad json -> 422 and service not called", func(t *testing.T) {
 		svc := &serviceMock{
@@ -0,0 +1,69 @@
+package account_obj
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	"server/internal/app/domain/org"
+	"testing"
+)
+
+func TestHttpHandler_DeleteAccountObj(t *testing.T) {
+	t.Run("invalid id -> 400", func(t *testing.T) {
+		h := New(&serviceMock{})
+
+		req := newChiReq(http.MethodDelete, "/delete/abc", "/delete/{id}", "id", "abc")
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(1)))
+		rr := httptest.NewRecorder()
+
+		h.DeleteAccountObj(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("role without delete -> 403", func(t *testing.T) {
+		svc := &serviceMock{
+			deleteFn: func(ctx context.Context, userId, accountId int64) error {
+				return org.ErrForbidden
+			},
+		}
+		h := New(svc)
+
+		req := newChiReq(http.MethodDelete, "/delete/5", "/delete/{id}", "id", "5")
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(3)))
+		rr := httptest.NewRecorder()
+
+		h.DeleteAccountObj(rr, req)
+
+		if rr.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d; body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 204", func(t *testing.T) {
+		var gotUser int64
+		svc := &serviceMock{
+			deleteFn: func(ctx context.Context, userId, accountId int64) error {
+				gotUser = userId
+				return nil
+			},
+		}
+		h := New(svc)
+
+		req := newChiReq(http.MethodDelete, "/delete/5", "/delete/{id}", "id", "5")
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(1)))
+		rr := httptest.NewRecorder()
+
+		h.DeleteAccountObj(rr, req)
+
+		if rr.Code != http.StatusNoContent {
+			t.Fatalf("expected 204, got %d; body=%s", rr.Code, rr.Body.String())
+		}
+		if gotUser != 1 || svc.lastAccountID != 5 {
+			t.Fatalf("unexpected call: user=%d account=%d", gotUser, svc.lastAccountID)
+		}
+	})
+}
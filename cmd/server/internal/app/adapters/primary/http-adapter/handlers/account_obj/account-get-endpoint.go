@@ -3,9 +3,11 @@ package account_obj
 import (
 	"net/http"
 	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
 	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/account_usecase"
 	"server/internal/pkg/logger"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
@@ -15,6 +17,13 @@ type AccountResponse struct {
 	ServiceName string `json:"service_name"`
 	Username    string `json:"username"`
 	Password    string `json:"password"`
+	TOTP        string `json:"totp,omitempty"`
+	Compromised bool   `json:"compromised"`
+
+	ExpiresAt    *time.Time          `json:"expires_at,omitempty"`
+	CustomFields []codec.CustomField `json:"custom_fields,omitempty"`
+	OrgID        int64               `json:"org_id,omitempty"`
+	Shared       *codec.Shared       `json:"shared,omitempty"`
 }
 
 func (h *HttpHandler) GetAccountObj(w http.ResponseWriter, r *http.Request) {
@@ -22,6 +31,12 @@ func (h *HttpHandler) GetAccountObj(w http.ResponseWriter, r *http.Request) {
 
 	var resp = new(AccountResponse)
 
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
 	urlId := chi.URLParam(r, "id")
 
 	id, err := strconv.ParseInt(urlId, 10, 64)
@@ -30,7 +45,7 @@ func (h *HttpHandler) GetAccountObj(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	account, err := h.service.GetAccount(r.Context(), id)
+	account, err := h.service.GetAccount(r.Context(), userId, id)
 	if err != nil {
 		logger.Log.Error(HandlerName, zap.Error(err))
 
@@ -42,6 +57,12 @@ func (h *HttpHandler) GetAccountObj(w http.ResponseWriter, r *http.Request) {
 	resp.ServiceName = account.ServiceName
 	resp.Username = account.UserName
 	resp.Password = account.Password
+	resp.TOTP = account.TOTP
+	resp.Compromised = account.Compromised()
+	resp.ExpiresAt = codec.OptionalTime(account.ExpiresAt)
+	resp.CustomFields = codec.CustomFieldsFromDomain(account.CustomFields)
+	resp.OrgID = account.OrgID
+	resp.Shared = codec.SharedFromDomain(account.Shared)
 
 	codec.WriteJSON(w, http.StatusOK, resp)
 	return
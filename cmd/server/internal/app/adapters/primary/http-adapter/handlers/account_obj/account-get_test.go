@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"server/internal/app/adapters/primary/http-adapter/constants"
 	domain "server/internal/app/domain/account_obj"
 	"server/internal/pkg/logger"
 
@@ -22,7 +23,7 @@ type mockService struct {
 func (m *mockService) GetAccountsList(ctx context.Context, userId int64) ([]*domain.Account, error) {
 	panic("not used")
 }
-func (m *mockService) GetAccount(ctx context.Context, accountId int64) (*domain.Account, error) {
+func (m *mockService) GetAccount(ctx context.Context, userId, accountId int64) (*domain.Account, error) {
 	if m.getAccountFn == nil {
 		panic("getAccountFn is nil")
 	}
@@ -34,6 +35,15 @@ func (m *mockService) CreateNewAccountObj(ctx context.Context, account *domain.A
 func (m *mockService) UpdateAccount(ctx context.Context, account *domain.Account) error {
 	panic("not used")
 }
+func (m *mockService) DeleteAccount(ctx context.Context, userId, accountId int64) error {
+	panic("not used")
+}
+func (m *mockService) BreachReport(ctx context.Context, userId int64) (*domain.BreachReport, error) {
+	panic("not used")
+}
+func (m *mockService) GetTOTPCode(ctx context.Context, userId, accountId int64) (*domain.TOTPCode, error) {
+	panic("not used")
+}
 
 func newChiReq(method, path, routePattern, paramKey, paramValue string) *http.Request {
 	req := httptest.NewRequest(method, path, nil)
@@ -58,6 +68,7 @@ func TestHttpHandler_GetAccountObj(t *testing.T) {
 		h := New(svc)
 
 		req := newChiReq(http.MethodGet, "/list/abc", "/list/{id}", "id", "abc")
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(1)))
 		rr := httptest.NewRecorder()
 
 		h.GetAccountObj(rr, req)
@@ -86,6 +97,7 @@ func TestHttpHandler_GetAccountObj(t *testing.T) {
 		h := New(svc)
 
 		req := newChiReq(http.MethodGet, "/list/10", "/list/{id}", "id", "10")
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(1)))
 		rr := httptest.NewRecorder()
 
 		h.GetAccountObj(rr, req)
@@ -119,6 +131,7 @@ func TestHttpHandler_GetAccountObj(t *testing.T) {
 		h := New(svc)
 
 		req := newChiReq(http.MethodGet, "/list/7", "/list/{id}", "id", "7")
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(1)))
 		rr := httptest.NewRecorder()
 
 		h.GetAccountObj(rr, req)
@@ -148,6 +161,7 @@ func TestHttpHandler_GetAccountObj(t *testing.T) {
 		h := New(svc)
 
 		req := newChiReq(http.MethodGet, "/list/5", "/list/{id}", "id", "5")
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(1)))
 		rr := httptest.NewRecorder()
 
 		h.GetAccountObj(rr, req)
@@ -6,6 +6,7 @@ import (
 	"server/internal/app/adapters/primary/http-adapter/constants"
 	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/account_usecase"
 	"server/internal/pkg/logger"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -14,6 +15,10 @@ type Account struct {
 	AccountID   int64  `json:"account_id"`
 	ServiceName string `json:"service_name"`
 	Username    string `json:"username"`
+	Compromised bool   `json:"compromised"`
+
+	ExpiresAt    *time.Time              `json:"expires_at,omitempty"`
+	CustomFields []codec.CustomFieldName `json:"custom_fields,omitempty"`
 }
 
 func (h *HttpHandler) GetAccountList(w http.ResponseWriter, r *http.Request) {
@@ -38,9 +43,12 @@ func (h *HttpHandler) GetAccountList(w http.ResponseWriter, r *http.Request) {
 
 	for _, item := range list {
 		c := Account{
-			AccountID:   item.AccountId,
-			ServiceName: item.ServiceName,
-			Username:    item.UserName,
+			AccountID:    item.AccountId,
+			ServiceName:  item.ServiceName,
+			Username:     item.UserName,
+			Compromised:  item.Compromised(),
+			ExpiresAt:    codec.OptionalTime(item.ExpiresAt),
+			CustomFields: codec.CustomFieldNamesFromDomain(item.CustomFields),
 		}
 		resp = append(resp, c)
 	}
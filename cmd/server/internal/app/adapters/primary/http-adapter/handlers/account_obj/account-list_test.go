@@ -8,9 +8,11 @@ import (
 	"net/http/httptest"
 	"server/internal/app/adapters/primary/http-adapter/constants"
 	"server/internal/app/adapters/primary/http-adapter/handlers/account_obj"
+	"strings"
 	"testing"
 
 	domain "server/internal/app/domain/account_obj"
+	"server/internal/app/domain/custom_field"
 	"server/internal/pkg/logger"
 
 	"go.uber.org/zap"
@@ -28,7 +30,7 @@ func (m *mockAccountService) GetAccountsList(ctx context.Context, userId int64)
 }
 
 // Остальные методы интерфейса handler.service — не используются в этих тестах, но нужны чтобы мок компилился.
-func (m *mockAccountService) GetAccount(ctx context.Context, accountId int64) (*domain.Account, error) {
+func (m *mockAccountService) GetAccount(ctx context.Context, userId, accountId int64) (*domain.Account, error) {
 	return nil, errors.New("not implemented")
 }
 func (m *mockAccountService) CreateNewAccountObj(ctx context.Context, account *domain.Account) (int64, error) {
@@ -37,6 +39,15 @@ func (m *mockAccountService) CreateNewAccountObj(ctx context.Context, account *d
 func (m *mockAccountService) UpdateAccount(ctx context.Context, account *domain.Account) error {
 	return errors.New("not implemented")
 }
+func (m *mockAccountService) DeleteAccount(ctx context.Context, userId, accountId int64) error {
+	return errors.New("not implemented")
+}
+func (m *mockAccountService) BreachReport(ctx context.Context, userId int64) (*domain.BreachReport, error) {
+	return nil, errors.New("not implemented")
+}
+func (m *mockAccountService) GetTOTPCode(ctx context.Context, userId, accountId int64) (*domain.TOTPCode, error) {
+	return nil, errors.New("not implemented")
+}
 
 func TestHttpHandler_GetAccountList(t *testing.T) {
 	// иначе будет panic на logger.Log.Error(...)
@@ -161,4 +172,40 @@ func TestHttpHandler_GetAccountList(t *testing.T) {
 			t.Fatalf("unexpected item1: %+v", resp[1])
 		}
 	})
+
+	t.Run("hidden custom field -> name only, value not in json", func(t *testing.T) {
+		svc := &mockAccountService{
+			getAccountsListFn: func(ctx context.Context, userId int64) ([]*domain.Account, error) {
+				return []*domain.Account{{
+					AccountId:   10,
+					ServiceName: "github",
+					CustomFields: []custom_field.Field{
+						{Name: "PIN", Kind: custom_field.KindHidden, Value: "s3cr3t-pin"},
+					},
+				}}, nil
+			},
+		}
+		h := account_obj.New(svc)
+
+		req := httptest.NewRequest(http.MethodGet, "/list", nil).
+			WithContext(context.WithValue(context.Background(), constants.UserIDKey, int64(5)))
+
+		rr := httptest.NewRecorder()
+		h.GetAccountList(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		if strings.Contains(rr.Body.String(), "s3cr3t-pin") {
+			t.Fatalf("hidden value leaked into the list: %s", rr.Body.String())
+		}
+
+		var resp []account_obj.Account
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid json: %v body=%s", err, rr.Body.String())
+		}
+		if len(resp) != 1 || len(resp[0].CustomFields) != 1 || resp[0].CustomFields[0].Name != "PIN" || resp[0].CustomFields[0].Type != "hidden" {
+			t.Fatalf("unexpected custom fields: %+v", resp)
+		}
+	})
 }
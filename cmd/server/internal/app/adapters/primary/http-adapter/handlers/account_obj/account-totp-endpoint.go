@@ -0,0 +1,52 @@
+package account_obj
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/account_usecase"
+	"server/internal/pkg/logger"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type TOTPResponse struct {
+	Code             string `json:"code"`
+	Period           int64  `json:"period"`
+	RemainingSeconds int64  `json:"remaining_seconds"`
+}
+
+func (h *HttpHandler) GetAccountTOTP(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "GetAccountTOTP"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	urlId := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseInt(urlId, 10, 64)
+	if err != nil {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	code, err := h.service.GetTOTPCode(r.Context(), userId, id)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, TOTPResponse{
+		Code:             code.Code,
+		Period:           int64(code.Period.Seconds()),
+		RemainingSeconds: int64(code.Remaining.Seconds()),
+	})
+}
@@ -0,0 +1,82 @@
+package account_obj
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	domain "server/internal/app/domain/account_obj"
+)
+
+func TestHttpHandler_GetAccountTOTP(t *testing.T) {
+	t.Run("invalid id -> 400", func(t *testing.T) {
+		h := New(&serviceMock{
+			getTOTPCodeFn: func(ctx context.Context, accountId int64) (*domain.TOTPCode, error) {
+				t.Fatalf("service must NOT be called on invalid id")
+				return nil, nil
+			},
+		})
+
+		req := newChiReq(http.MethodGet, "/totp/abc", "/totp/{id}", "id", "abc")
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(1)))
+		rr := httptest.NewRecorder()
+
+		h.GetAccountTOTP(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("not configured -> 404", func(t *testing.T) {
+		h := New(&serviceMock{
+			getTOTPCodeFn: func(ctx context.Context, accountId int64) (*domain.TOTPCode, error) {
+				return nil, domain.ErrTOTPNotConfigured
+			},
+		})
+
+		req := newChiReq(http.MethodGet, "/totp/3", "/totp/{id}", "id", "3")
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(1)))
+		rr := httptest.NewRecorder()
+
+		h.GetAccountTOTP(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusNotFound, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 200 + code and remaining seconds", func(t *testing.T) {
+		svc := &serviceMock{
+			getTOTPCodeFn: func(ctx context.Context, accountId int64) (*domain.TOTPCode, error) {
+				return &domain.TOTPCode{Code: "287082", Period: 30 * time.Second, Remaining: 12 * time.Second}, nil
+			},
+		}
+		h := New(svc)
+
+		req := newChiReq(http.MethodGet, "/totp/7", "/totp/{id}", "id", "7")
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(1)))
+		rr := httptest.NewRecorder()
+
+		h.GetAccountTOTP(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		if svc.lastAccountID != 7 {
+			t.Fatalf("expected id=7, got %d", svc.lastAccountID)
+		}
+
+		var resp TOTPResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid json: %v body=%s", err, rr.Body.String())
+		}
+		if resp.Code != "287082" || resp.Period != 30 || resp.RemainingSeconds != 12 {
+			t.Fatalf("unexpected resp: %+v", resp)
+		}
+	})
+}
@@ -9,6 +9,7 @@ import (
 	domain "server/internal/app/domain/account_obj"
 	"server/internal/pkg/logger"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
@@ -18,7 +19,11 @@ type UpdateAccountRequest struct {
 	ServiceName string `json:"service_name"`
 	UserName    string `json:"user_name"`
 	Password    string `json:"password"`
+	TOTP        string `json:"totp"`
 	AccountId   int64  `json:"account_id"`
+
+	ExpiresAt    *time.Time          `json:"expires_at,omitempty"`
+	CustomFields []codec.CustomField `json:"custom_fields,omitempty"`
 }
 
 func (h *HttpHandler) UpdateAccountObj(w http.ResponseWriter, r *http.Request) {
@@ -67,9 +72,12 @@ func (h *HttpHandler) UpdateAccountObj(w http.ResponseWriter, r *http.Request) {
 
 func (u *UpdateAccountRequest) toDomain() *domain.Account {
 	return &domain.Account{
-		ServiceName: u.ServiceName,
-		UserName:    u.UserName,
-		Password:    u.Password,
-		AccountId:   u.AccountId,
+		ServiceName:  u.ServiceName,
+		UserName:     u.UserName,
+		Password:     u.Password,
+		TOTP:         u.TOTP,
+		AccountId:    u.AccountId,
+		ExpiresAt:    codec.TimeOrZero(u.ExpiresAt),
+		CustomFields: codec.CustomFieldsToDomain(u.CustomFields),
 	}
 }
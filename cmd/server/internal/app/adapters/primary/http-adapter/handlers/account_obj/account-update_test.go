@@ -29,16 +29,26 @@ func (m *mockAccountServiceS) UpdateAccount(ctx context.Context, account *domain
 	return m.updateAccountFn(ctx, account)
 }
 
+func (m *mockAccountServiceS) DeleteAccount(ctx context.Context, userId, accountId int64) error {
+	return errors.New("not implemented")
+}
+
 // Остальные методы интерфейса handler.service (не используются тут)
 func (m *mockAccountServiceS) GetAccountsList(ctx context.Context, userId int64) ([]*domain.Account, error) {
 	return nil, errors.New("not implemented")
 }
-func (m *mockAccountServiceS) GetAccount(ctx context.Context, accountId int64) (*domain.Account, error) {
+func (m *mockAccountServiceS) GetAccount(ctx context.Context, userId, accountId int64) (*domain.Account, error) {
 	return nil, errors.New("not implemented")
 }
 func (m *mockAccountServiceS) CreateNewAccountObj(ctx context.Context, account *domain.Account) (int64, error) {
 	return 0, errors.New("not implemented")
 }
+func (m *mockAccountServiceS) BreachReport(ctx context.Context, userId int64) (*domain.BreachReport, error) {
+	return nil, errors.New("not implemented")
+}
+func (m *mockAccountServiceS) GetTOTPCode(ctx context.Context, userId, accountId int64) (*domain.TOTPCode, error) {
+	return nil, errors.New("not implemented")
+}
 
 func withChiURLParam(req *http.Request, key, value string) *http.Request {
 	rctx := chi.NewRouteContext()
@@ -9,9 +9,12 @@ import (
 
 type service interface {
 	GetAccountsList(ctx context.Context, userId int64) ([]*domain.Account, error)
-	GetAccount(ctx context.Context, accountId int64) (*domain.Account, error)
+	GetAccount(ctx context.Context, userId, accountId int64) (*domain.Account, error)
 	CreateNewAccountObj(ctx context.Context, account *domain.Account) (int64, error)
 	UpdateAccount(ctx context.Context, account *domain.Account) error
+	DeleteAccount(ctx context.Context, userId, accountId int64) error
+	GetTOTPCode(ctx context.Context, userId, accountId int64) (*domain.TOTPCode, error)
+	BreachReport(ctx context.Context, userId int64) (*domain.BreachReport, error)
 }
 
 type HttpHandler struct {
@@ -31,6 +34,9 @@ func (h *HttpHandler) Routes() *chi.Mux {
 	router.Get("/list/{id}", h.GetAccountObj)
 	router.Post("/create", h.CreateAccount)
 	router.Put("/update/{id}", h.UpdateAccountObj)
+	router.Delete("/delete/{id}", h.DeleteAccountObj)
+	router.Get("/totp/{id}", h.GetAccountTOTP)
+	router.Get("/report/breaches", h.GetBreachReport)
 
 	return router
 }
@@ -0,0 +1,87 @@
+package attachment
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/attachment_usecase"
+	"server/internal/pkg/logger"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type AttachRequest struct {
+	FileID int64 `json:"file_id"`
+}
+
+type AttachResponse struct {
+	AttachmentID int64 `json:"attachment_id"`
+}
+
+func (h *HttpHandler) Attach(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "Attach"
+
+	req := new(AttachRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "json decode error")
+		return
+	}
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	item, ok := itemFromURL(r)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid item id")
+		return
+	}
+
+	id, err := h.service.Attach(r.Context(), userId, item, req.FileID)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, AttachResponse{AttachmentID: id})
+}
+
+func (h *HttpHandler) Detach(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "Detach"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	item, ok := itemFromURL(r)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid item id")
+		return
+	}
+
+	fileID, err := strconv.ParseInt(chi.URLParam(r, "file_id"), 10, 64)
+	if err != nil {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid file id")
+		return
+	}
+
+	if err := h.service.Detach(r.Context(), userId, item, fileID); err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
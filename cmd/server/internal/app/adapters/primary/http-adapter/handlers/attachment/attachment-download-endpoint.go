@@ -0,0 +1,68 @@
+package attachment
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/attachment_usecase"
+	"server/internal/pkg/logger"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// Download streams an attached file to anyone who can read the item
+func (h *HttpHandler) Download(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "DownloadAttachment"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	item, ok := itemFromURL(r)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid item id")
+		return
+	}
+
+	fileID, err := strconv.ParseInt(chi.URLParam(r, "file_id"), 10, 64)
+	if err != nil {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid file id")
+		return
+	}
+
+	meta, reader, err := h.service.Download(r.Context(), userId, item, fileID)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+	defer reader.Close()
+
+	if meta.SizeBytes > 0 {
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", meta.SizeBytes))
+	}
+
+	if meta.ETag != "" {
+		w.Header().Set("ETag", meta.ETag)
+	}
+
+	filename := meta.Title
+	if filename == "" {
+		filename = fmt.Sprintf("file-%d", fileID)
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, reader); err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+	}
+}
@@ -0,0 +1,74 @@
+package attachment
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/attachment_usecase"
+	domain "server/internal/app/domain/attachment"
+	"server/internal/pkg/logger"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// Attachment is a file of an item, the file itself is downloaded with /file/download/{file_id}
+type Attachment struct {
+	AttachmentID int64     `json:"attachment_id"`
+	FileID       int64     `json:"file_id"`
+	Title        string    `json:"title"`
+	SizeBytes    int64     `json:"size_bytes"`
+	ContentType  string    `json:"content_type,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (h *HttpHandler) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "ListAttachments"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	item, ok := itemFromURL(r)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid item id")
+		return
+	}
+
+	list, err := h.service.ListAttachments(r.Context(), userId, item)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	resp := make([]Attachment, 0, len(list))
+	for _, a := range list {
+		resp = append(resp, Attachment{
+			AttachmentID: a.ID,
+			FileID:       a.FileID,
+			Title:        a.Title,
+			SizeBytes:    a.SizeBytes,
+			ContentType:  a.ContentType,
+			CreatedAt:    a.CreatedAt,
+		})
+	}
+
+	codec.WriteJSON(w, http.StatusOK, resp)
+}
+
+// itemFromURL reads {type} and {id}, the type is validated by the use case
+func itemFromURL(r *http.Request) (domain.Item, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		return domain.Item{}, false
+	}
+
+	return domain.Item{Type: chi.URLParam(r, "type"), ID: id}, true
+}
@@ -0,0 +1,270 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	domain "server/internal/app/domain/attachment"
+	file "server/internal/app/domain/file_obj"
+	"server/internal/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type serviceMock struct {
+	listFn   func(ctx context.Context, userID int64, item domain.Item) ([]*domain.Attachment, error)
+	attachFn func(ctx context.Context, userID int64, item domain.Item, fileID int64) (int64, error)
+	detachFn func(ctx context.Context, userID int64, item domain.Item, fileID int64) error
+	openFn   func(ctx context.Context, userID int64, item domain.Item, fileID int64) (*file.File, io.ReadCloser, error)
+}
+
+func (m *serviceMock) ListAttachments(ctx context.Context, userID int64, item domain.Item) ([]*domain.Attachment, error) {
+	if m.listFn == nil {
+		return nil, errors.New("ListAttachments not stubbed")
+	}
+	return m.listFn(ctx, userID, item)
+}
+
+func (m *serviceMock) Attach(ctx context.Context, userID int64, item domain.Item, fileID int64) (int64, error) {
+	if m.attachFn == nil {
+		return 0, errors.New("Attach not stubbed")
+	}
+	return m.attachFn(ctx, userID, item, fileID)
+}
+
+func (m *serviceMock) Detach(ctx context.Context, userID int64, item domain.Item, fileID int64) error {
+	if m.detachFn == nil {
+		return errors.New("Detach not stubbed")
+	}
+	return m.detachFn(ctx, userID, item, fileID)
+}
+
+func (m *serviceMock) Download(ctx context.Context, userID int64, item domain.Item, fileID int64) (*file.File, io.ReadCloser, error) {
+	if m.openFn == nil {
+		return nil, nil, errors.New("Download not stubbed")
+	}
+	return m.openFn(ctx, userID, item, fileID)
+}
+
+func withUser(r *http.Request, id int64) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), constants.UserIDKey, id))
+}
+
+// newChiReq builds a request with URL params given as key, value pairs
+func newChiReq(method, path string, body io.Reader, params ...string) *http.Request {
+	req := httptest.NewRequest(method, path, body)
+
+	rctx := chi.NewRouteContext()
+	for i := 0; i+1 < len(params); i += 2 {
+		rctx.URLParams.Add(params[i], params[i+1])
+	}
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHttpHandler_ListAttachments(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("invalid id -> 400", func(t *testing.T) {
+		h := New(&serviceMock{})
+
+		rr := httptest.NewRecorder()
+		h.ListAttachments(rr, withUser(newChiReq(http.MethodGet, "/card/x", nil, "type", "card", "id", "x"), 7))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+	})
+
+	t.Run("ok -> item passed to service", func(t *testing.T) {
+		var got domain.Item
+		h := New(&serviceMock{
+			listFn: func(ctx context.Context, userID int64, item domain.Item) ([]*domain.Attachment, error) {
+				got = item
+				return []*domain.Attachment{{ID: 1, FileID: 5, Title: "contract.pdf"}}, nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.ListAttachments(rr, withUser(newChiReq(http.MethodGet, "/card/3", nil, "type", "card", "id", "3"), 7))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		if got != (domain.Item{Type: domain.ItemBankCard, ID: 3}) {
+			t.Fatalf("unexpected item: %+v", got)
+		}
+
+		var resp []Attachment
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(resp) != 1 || resp[0].FileID != 5 || resp[0].Title != "contract.pdf" {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+	})
+
+	t.Run("empty -> 204", func(t *testing.T) {
+		h := New(&serviceMock{
+			listFn: func(ctx context.Context, userID int64, item domain.Item) ([]*domain.Attachment, error) {
+				return nil, domain.ErrEmptyAttachmentsList
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.ListAttachments(rr, withUser(newChiReq(http.MethodGet, "/text/3", nil, "type", "text", "id", "3"), 7))
+
+		if rr.Code != http.StatusNoContent {
+			t.Fatalf("expected %d, got %d", http.StatusNoContent, rr.Code)
+		}
+	})
+}
+
+func TestHttpHandler_Attach(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("bad json -> 422", func(t *testing.T) {
+		h := New(&serviceMock{})
+
+		rr := httptest.NewRecorder()
+		h.Attach(rr, withUser(newChiReq(http.MethodPost, "/account/3", bytes.NewReader([]byte("{")), "type", "account", "id", "3"), 7))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d", http.StatusUnprocessableEntity, rr.Code)
+		}
+	})
+
+	t.Run("already attached -> 409", func(t *testing.T) {
+		var gotFile int64
+		h := New(&serviceMock{
+			attachFn: func(ctx context.Context, userID int64, item domain.Item, fileID int64) (int64, error) {
+				gotFile = fileID
+				return 0, domain.ErrAlreadyAttached
+			},
+		})
+
+		body := bytes.NewReader([]byte(`{"file_id":5}`))
+		rr := httptest.NewRecorder()
+		h.Attach(rr, withUser(newChiReq(http.MethodPost, "/account/3", body, "type", "account", "id", "3"), 7))
+
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusConflict, rr.Code, rr.Body.String())
+		}
+		if gotFile != 5 {
+			t.Fatalf("expected file 5, got %d", gotFile)
+		}
+	})
+
+	t.Run("ok -> 200 with id", func(t *testing.T) {
+		h := New(&serviceMock{
+			attachFn: func(ctx context.Context, userID int64, item domain.Item, fileID int64) (int64, error) {
+				return 11, nil
+			},
+		})
+
+		body := bytes.NewReader([]byte(`{"file_id":5}`))
+		rr := httptest.NewRecorder()
+		h.Attach(rr, withUser(newChiReq(http.MethodPost, "/account/3", body, "type", "account", "id", "3"), 7))
+
+		var resp AttachResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil || rr.Code != http.StatusOK || resp.AttachmentID != 11 {
+			t.Fatalf("unexpected response: %d %s", rr.Code, rr.Body.String())
+		}
+	})
+}
+
+func TestHttpHandler_Detach(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("invalid file id -> 400", func(t *testing.T) {
+		h := New(&serviceMock{})
+
+		rr := httptest.NewRecorder()
+		h.Detach(rr, withUser(newChiReq(http.MethodDelete, "/text/3/x", nil, "type", "text", "id", "3", "file_id", "x"), 7))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+	})
+
+	t.Run("not attached -> 404", func(t *testing.T) {
+		h := New(&serviceMock{
+			detachFn: func(ctx context.Context, userID int64, item domain.Item, fileID int64) error {
+				return domain.ErrAttachmentNotFound
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.Detach(rr, withUser(newChiReq(http.MethodDelete, "/text/3/5", nil, "type", "text", "id", "3", "file_id", "5"), 7))
+
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+
+	t.Run("ok -> 204", func(t *testing.T) {
+		h := New(&serviceMock{
+			detachFn: func(ctx context.Context, userID int64, item domain.Item, fileID int64) error {
+				return nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.Detach(rr, withUser(newChiReq(http.MethodDelete, "/text/3/5", nil, "type", "text", "id", "3", "file_id", "5"), 7))
+
+		if rr.Code != http.StatusNoContent {
+			t.Fatalf("expected %d, got %d", http.StatusNoContent, rr.Code)
+		}
+	})
+}
+
+func TestHttpHandler_Download(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("no access -> 404", func(t *testing.T) {
+		h := New(&serviceMock{
+			openFn: func(ctx context.Context, userID int64, item domain.Item, fileID int64) (*file.File, io.ReadCloser, error) {
+				return nil, nil, domain.ErrItemNotFound
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.Download(rr, withUser(newChiReq(http.MethodGet, "/text/3/5", nil, "type", "text", "id", "3", "file_id", "5"), 7))
+
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+
+	t.Run("ok -> 200 + file body", func(t *testing.T) {
+		var gotItem domain.Item
+		h := New(&serviceMock{
+			openFn: func(ctx context.Context, userID int64, item domain.Item, fileID int64) (*file.File, io.ReadCloser, error) {
+				gotItem = item
+				return &file.File{ID: fileID, Title: "scan.pdf", SizeBytes: 3}, io.NopCloser(strings.NewReader("pdf")), nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.Download(rr, withUser(newChiReq(http.MethodGet, "/text/3/5", nil, "type", "text", "id", "3", "file_id", "5"), 7))
+
+		if rr.Code != http.StatusOK || rr.Body.String() != "pdf" {
+			t.Fatalf("unexpected response %d: %s", rr.Code, rr.Body.String())
+		}
+		if gotItem != (domain.Item{Type: domain.ItemText, ID: 3}) {
+			t.Fatalf("unexpected item: %+v", gotItem)
+		}
+		if !strings.Contains(rr.Header().Get("Content-Disposition"), "scan.pdf") {
+			t.Fatalf("unexpected Content-Disposition: %q", rr.Header().Get("Content-Disposition"))
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package attachment
+
+import (
+	"context"
+	"io"
+	domain "server/internal/app/domain/attachment"
+	file "server/internal/app/domain/file_obj"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type service interface {
+	ListAttachments(ctx context.Context, userID int64, item domain.Item) ([]*domain.Attachment, error)
+	Attach(ctx context.Context, userID int64, item domain.Item, fileID int64) (int64, error)
+	Detach(ctx context.Context, userID int64, item domain.Item, fileID int64) error
+	Download(ctx context.Context, userID int64, item domain.Item, fileID int64) (*file.File, io.ReadCloser, error)
+}
+
+type HttpHandler struct {
+	service service
+}
+
+func New(service service) *HttpHandler {
+	return &HttpHandler{
+		service: service,
+	}
+}
+
+// Routes of the attachments of an item, {type} is account, card or text
+func (h *HttpHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Get("/{type}/{id}", h.ListAttachments)
+	router.Post("/{type}/{id}", h.Attach)
+	router.Get("/{type}/{id}/{file_id}", h.Download)
+	router.Delete("/{type}/{id}/{file_id}", h.Detach)
+
+	return router
+}
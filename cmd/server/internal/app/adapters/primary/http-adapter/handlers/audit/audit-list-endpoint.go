@@ -0,0 +1,135 @@
+package audit
+
+import (
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/audit_usecase"
+	domain "server/internal/app/domain/audit"
+	"server/internal/pkg/logger"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Event struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	OwnerID   int64     `json:"owner_id"`
+	Action    string    `json:"action"`
+	ItemType  string    `json:"item_type,omitempty"`
+	ItemID    int64     `json:"item_id,omitempty"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	Details   string    `json:"details,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Hash      string    `json:"hash"`
+}
+
+// GetEvents lists events of the user and events on the user's items, newest first.
+// Filters: ?action, ?item_type, ?item_id, ?from and ?to (RFC 3339), ?before (event id), ?limit
+func (h *HttpHandler) GetEvents(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "GetEvents"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	filter, msg := parseFilter(r.URL.Query())
+	if msg != "" {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, msg)
+		return
+	}
+	filter.UserID = userId
+
+	list, err := h.service.Search(r.Context(), filter)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	resp := make([]Event, 0, len(list))
+	for _, e := range list {
+		resp = append(resp, fromDomain(e))
+	}
+
+	codec.WriteJSON(w, http.StatusOK, resp)
+}
+
+// help func
+
+// parseFilter reads the query filters, the message is set for a malformed value
+func parseFilter(q url.Values) (domain.Filter, string) {
+	f := domain.Filter{
+		Action:   q.Get("action"),
+		ItemType: q.Get("item_type"),
+	}
+
+	ints := []struct {
+		key string
+		dst *int64
+	}{
+		{"item_id", &f.ItemID},
+		{"before", &f.BeforeID},
+	}
+	for _, p := range ints {
+		if v := q.Get(p.key); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || n <= 0 {
+				return f, "invalid " + p.key
+			}
+			*p.dst = n
+		}
+	}
+
+	times := []struct {
+		key string
+		dst *time.Time
+	}{
+		{"from", &f.From},
+		{"to", &f.To},
+	}
+	for _, p := range times {
+		if v := q.Get(p.key); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return f, "invalid " + p.key + ", RFC 3339 expected"
+			}
+			*p.dst = t
+		}
+	}
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return f, "invalid limit"
+		}
+		f.Limit = n
+	}
+
+	return f, ""
+}
+
+func fromDomain(e *domain.Event) Event {
+	return Event{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		OwnerID:   e.OwnerID,
+		Action:    e.Action,
+		ItemType:  e.ItemType,
+		ItemID:    e.ItemID,
+		IP:        e.IP,
+		UserAgent: e.UserAgent,
+		Details:   e.Details,
+		CreatedAt: e.CreatedAt,
+		Hash:      hex.EncodeToString(e.Hash),
+	}
+}
@@ -0,0 +1,37 @@
+package audit
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/audit_usecase"
+	"server/internal/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// VerifyResponse leaves out event ids and counts, they describe the log of every user
+type VerifyResponse struct {
+	Valid      bool      `json:"valid"`
+	VerifiedAt time.Time `json:"verified_at"`
+}
+
+// Verify returns the result of the latest check of the hash chain, a broken chain means an earlier entry
+// was changed or removed
+func (h *HttpHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "VerifyAudit"
+
+	res, err := h.service.LastVerification(r.Context())
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, VerifyResponse{
+		Valid:      res.Valid,
+		VerifiedAt: res.VerifiedAt,
+	})
+}
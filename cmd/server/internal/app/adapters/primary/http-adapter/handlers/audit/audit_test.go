@@ -0,0 +1,194 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	domain "server/internal/app/domain/audit"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type serviceMock struct {
+	searchFn func(ctx context.Context, f domain.Filter) ([]*domain.Event, error)
+	verifyFn func(ctx context.Context) (*domain.Verification, error)
+}
+
+func (m *serviceMock) Search(ctx context.Context, f domain.Filter) ([]*domain.Event, error) {
+	if m.searchFn == nil {
+		return nil, errors.New("Search not stubbed")
+	}
+	return m.searchFn(ctx, f)
+}
+
+func (m *serviceMock) LastVerification(ctx context.Context) (*domain.Verification, error) {
+	if m.verifyFn == nil {
+		return nil, errors.New("LastVerification not stubbed")
+	}
+	return m.verifyFn(ctx)
+}
+
+func withUser(r *http.Request, id int64) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), constants.UserIDKey, id))
+}
+
+func TestHttpHandler_GetEvents(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("missing userID in context -> 422", func(t *testing.T) {
+		h := New(&serviceMock{})
+
+		rr := httptest.NewRecorder()
+		h.GetEvents(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+		}
+	})
+
+	for _, q := range []string{"item_id=x", "before=-1", "from=yesterday", "to=2026-05-01", "limit=many"} {
+		t.Run("malformed "+q+" -> 400", func(t *testing.T) {
+			h := New(&serviceMock{})
+
+			rr := httptest.NewRecorder()
+			h.GetEvents(rr, withUser(httptest.NewRequest(http.MethodGet, "/?"+q, nil), 1))
+
+			if rr.Code != http.StatusBadRequest {
+				t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+			}
+		})
+	}
+
+	t.Run("service error -> mapped", func(t *testing.T) {
+		h := New(&serviceMock{
+			searchFn: func(ctx context.Context, f domain.Filter) ([]*domain.Event, error) {
+				return nil, domain.ErrInvalidAction
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.GetEvents(rr, withUser(httptest.NewRequest(http.MethodGet, "/?action=peek", nil), 1))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 200 + filters passed", func(t *testing.T) {
+		createdAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+		var got domain.Filter
+		h := New(&serviceMock{
+			searchFn: func(ctx context.Context, f domain.Filter) ([]*domain.Event, error) {
+				got = f
+				return []*domain.Event{
+					{ID: 9, UserID: 2, OwnerID: 1, Action: domain.ActionItemRead, ItemType: domain.ItemAccount, ItemID: 5,
+						IP: "10.0.0.2", UserAgent: "tui", CreatedAt: createdAt, Hash: []byte{0xab, 0xcd}},
+				}, nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet,
+			"/?action=item_read&item_type=account&item_id=5&from=2026-05-01T00:00:00Z&to=2026-05-02T00:00:00Z&before=10&limit=20", nil)
+		h.GetEvents(rr, withUser(req, 1))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+
+		want := domain.Filter{
+			UserID:   1,
+			Action:   domain.ActionItemRead,
+			ItemType: domain.ItemAccount,
+			ItemID:   5,
+			From:     time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+			To:       time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC),
+			BeforeID: 10,
+			Limit:    20,
+		}
+		if got.UserID != want.UserID || got.Action != want.Action || got.ItemType != want.ItemType || got.ItemID != want.ItemID ||
+			!got.From.Equal(want.From) || !got.To.Equal(want.To) || got.BeforeID != want.BeforeID || got.Limit != want.Limit {
+			t.Fatalf("expected filter %+v, got %+v", want, got)
+		}
+
+		var resp []Event
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		if len(resp) != 1 || resp[0].ID != 9 || resp[0].OwnerID != 1 || resp[0].Hash != "abcd" {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+	})
+}
+
+func TestHttpHandler_Verify(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("service error -> 500", func(t *testing.T) {
+		h := New(&serviceMock{
+			verifyFn: func(ctx context.Context) (*domain.Verification, error) {
+				return nil, errors.New("db down")
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.Verify(rr, withUser(httptest.NewRequest(http.MethodGet, "/verify", nil), 1))
+
+		if rr.Code != http.StatusInternalServerError {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusInternalServerError, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("not verified yet -> 503", func(t *testing.T) {
+		h := New(&serviceMock{
+			verifyFn: func(ctx context.Context) (*domain.Verification, error) {
+				return nil, domain.ErrNotVerified
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.Verify(rr, withUser(httptest.NewRequest(http.MethodGet, "/verify", nil), 1))
+
+		if rr.Code != http.StatusServiceUnavailable {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusServiceUnavailable, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("broken chain -> 200 without event ids and counts", func(t *testing.T) {
+		at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+		h := New(&serviceMock{
+			verifyFn: func(ctx context.Context) (*domain.Verification, error) {
+				return &domain.Verification{Checked: 41, Valid: false, BrokenAt: 42, VerifiedAt: at}, nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.Verify(rr, withUser(httptest.NewRequest(http.MethodGet, "/verify", nil), 1))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+
+		var resp map[string]any
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		if resp["valid"] != false || resp["verified_at"] != "2026-05-01T12:00:00Z" {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+		if _, ok := resp["broken_at"]; ok {
+			t.Fatalf("event id leaked: %+v", resp)
+		}
+		if _, ok := resp["checked"]; ok {
+			t.Fatalf("event count leaked: %+v", resp)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package audit
+
+import (
+	"context"
+	domain "server/internal/app/domain/audit"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type service interface {
+	Search(ctx context.Context, f domain.Filter) ([]*domain.Event, error)
+	LastVerification(ctx context.Context) (*domain.Verification, error)
+}
+
+type HttpHandler struct {
+	service service
+}
+
+func New(service service) *HttpHandler {
+	return &HttpHandler{
+		service: service,
+	}
+}
+
+func (h *HttpHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Get("/", h.GetEvents)
+	router.Get("/verify", h.Verify)
+
+	return router
+}
@@ -8,13 +8,25 @@ import (
 	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/bank_card_usecase"
 	domain "server/internal/app/domain/bank_card_obj"
 	"server/internal/pkg/logger"
+	"time"
 
 	"go.uber.org/zap"
 )
 
 type CreateBankCardRequest struct {
-	BankName string `json:"bank_name"`
-	PID      string `json:"pid"`
+	BankName    string `json:"bank_name"`
+	Number      string `json:"number"`
+	HolderName  string `json:"holder_name"`
+	ExpiryMonth int    `json:"expiry_month"`
+	ExpiryYear  int    `json:"expiry_year"`
+	CVV         string `json:"cvv"`
+	PIN         string `json:"pin"`
+	Notes       string `json:"notes"`
+
+	ExpiresAt    *time.Time          `json:"expires_at,omitempty"`
+	CustomFields []codec.CustomField `json:"custom_fields,omitempty"`
+	// OrgID creates the item in an organization instead of the personal vault
+	OrgID int64 `json:"org_id,omitempty"`
 }
 
 type CreateBankCardResponse struct {
@@ -62,7 +74,16 @@ func (h *HttpHandler) CreateBankCard(w http.ResponseWriter, r *http.Request) {
 
 func (req CreateBankCardRequest) toDomain() *domain.BankCard {
 	return &domain.BankCard{
-		Bank: req.BankName,
-		Pid:  req.PID,
+		Bank:         req.BankName,
+		Number:       req.Number,
+		HolderName:   req.HolderName,
+		ExpiryMonth:  req.ExpiryMonth,
+		ExpiryYear:   req.ExpiryYear,
+		CVV:          req.CVV,
+		PIN:          req.PIN,
+		Notes:        req.Notes,
+		ExpiresAt:    codec.TimeOrZero(req.ExpiresAt),
+		CustomFields: codec.CustomFieldsToDomain(req.CustomFields),
+		OrgID:        req.OrgID,
 	}
 }
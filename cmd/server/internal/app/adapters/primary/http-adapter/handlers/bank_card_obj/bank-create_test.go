@@ -22,13 +22,14 @@ type mockService struct {
 	listFn       func(ctx context.Context, userId int64) ([]*domain.BankCard, error)
 	createFn     func(ctx context.Context, card *domain.BankCard) (int64, error)
 	updateFn     func(ctx context.Context, card *domain.BankCard) error
+	deleteFn     func(ctx context.Context, userId, cardId int64) error
 	calledGet    bool
 	calledList   bool
 	calledCreate bool
 	calledUpdate bool
 }
 
-func (m *mockService) GetBankCard(ctx context.Context, cardId int64) (*domain.BankCard, error) {
+func (m *mockService) GetBankCard(ctx context.Context, userId, cardId int64) (*domain.BankCard, error) {
 	m.calledGet = true
 	if m.getFn == nil {
 		return nil, errors.New("getFn is nil")
@@ -60,6 +61,13 @@ func (m *mockService) UpdateBankCard(ctx context.Context, card *domain.BankCard)
 	return m.updateFn(ctx, card)
 }
 
+func (m *mockService) DeleteBankCard(ctx context.Context, userId, cardId int64) error {
+	if m.deleteFn == nil {
+		return errors.New("deleteFn is nil")
+	}
+	return m.deleteFn(ctx, userId, cardId)
+}
+
 func TestHttpHandler_CreateBankCard(t *testing.T) {
 	old := logger.Log
 	logger.Log = zap.NewNop()
@@ -96,7 +104,7 @@ func TestHttpHandler_CreateBankCard(t *testing.T) {
 
 		body, _ := json.Marshal(map[string]any{
 			"bank_name": "MAIB",
-			"pid":       "A123",
+			"number":    "4242424242424242",
 		})
 
 		req := httptest.NewRequest(http.MethodPost, "/create", bytes.NewReader(body))
@@ -125,7 +133,7 @@ func TestHttpHandler_CreateBankCard(t *testing.T) {
 
 		body, _ := json.Marshal(map[string]any{
 			"bank_name": "MAIB",
-			"pid":       "A123",
+			"number":    "4242424242424242",
 		})
 
 		req := httptest.NewRequest(http.MethodPost, "/create", bytes.NewReader(body))
@@ -158,7 +166,7 @@ func TestHttpHandler_CreateBankCard(t *testing.T) {
 
 		body, _ := json.Marshal(map[string]any{
 			"bank_name": "MAIB",
-			"pid":       "A123",
+			"number":    "4242424242424242",
 		})
 
 		req := httptest.NewRequest(http.MethodPost, "/create", bytes.NewReader(body))
@@ -188,7 +196,7 @@ func TestHttpHandler_CreateBankCard(t *testing.T) {
 		if got.UserId != 777 {
 			t.Fatalf("expected domain.UserId=777, got %d", got.UserId)
 		}
-		if got.Bank != "MAIB" || got.Pid != "A123" {
+		if got.Bank != "MAIB" || got.Number != "4242424242424242" {
 			t.Fatalf("unexpected domain: %+v", got)
 		}
 	})
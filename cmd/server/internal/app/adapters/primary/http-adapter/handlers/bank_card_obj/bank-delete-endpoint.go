@@ -0,0 +1,40 @@
+package bank_card_obj
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/bank_card_usecase"
+	"server/internal/pkg/logger"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// DeleteBankCardObj removes the card, items of an organization need a role allowed to delete
+func (h *HttpHandler) DeleteBankCardObj(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "DeleteBankCardObj"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	cardID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid card id")
+		return
+	}
+
+	if err := h.service.DeleteBankCard(r.Context(), userId, cardID); err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,74 @@
+package bank_card_obj
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	domain "server/internal/app/domain/bank_card_obj"
+	"server/internal/app/domain/share"
+	"server/internal/pkg/logger"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestHttpHandler_DeleteBankCardObj(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("service error -> mapped error, not 204", func(t *testing.T) {
+		svc := &mockService{
+			deleteFn: func(ctx context.Context, userId, cardId int64) error {
+				return domain.ErrBankCardNotFound
+			},
+		}
+
+		req := newReqWithChiID(t, http.MethodDelete, "/delete/9", "9", nil)
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(1)))
+		rr := httptest.NewRecorder()
+
+		New(svc).DeleteBankCardObj(rr, req)
+
+		if rr.Code == http.StatusNoContent {
+			t.Fatalf("expected error status, got %d", rr.Code)
+		}
+	})
+
+	t.Run("grantee -> 403", func(t *testing.T) {
+		svc := &mockService{
+			deleteFn: func(ctx context.Context, userId, cardId int64) error {
+				return share.ErrNotOwner
+			},
+		}
+
+		req := newReqWithChiID(t, http.MethodDelete, "/delete/9", "9", nil)
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(2)))
+		rr := httptest.NewRecorder()
+
+		New(svc).DeleteBankCardObj(rr, req)
+
+		if rr.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d", rr.Code)
+		}
+	})
+
+	t.Run("ok -> 204", func(t *testing.T) {
+		var gotID int64
+		svc := &mockService{
+			deleteFn: func(ctx context.Context, userId, cardId int64) error {
+				gotID = cardId
+				return nil
+			},
+		}
+
+		req := newReqWithChiID(t, http.MethodDelete, "/delete/9", "9", nil)
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(1)))
+		rr := httptest.NewRecorder()
+
+		New(svc).DeleteBankCardObj(rr, req)
+
+		if rr.Code != http.StatusNoContent || gotID != 9 {
+			t.Fatalf("unexpected result: code=%d card=%d", rr.Code, gotID)
+		}
+	})
+}
@@ -3,17 +3,31 @@ package bank_card_obj
 import (
 	"net/http"
 	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
 	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/bank_card_usecase"
 	"server/internal/pkg/logger"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 )
 
 type BankCardResponse struct {
-	BankName string `json:"bank_name"`
-	PID      string `json:"pid"`
+	BankName    string `json:"bank_name"`
+	Brand       string `json:"brand"`
+	Number      string `json:"number"`
+	HolderName  string `json:"holder_name"`
+	ExpiryMonth int    `json:"expiry_month"`
+	ExpiryYear  int    `json:"expiry_year"`
+	CVV         string `json:"cvv"`
+	PIN         string `json:"pin"`
+	Notes       string `json:"notes"`
+
+	ExpiresAt    *time.Time          `json:"expires_at,omitempty"`
+	CustomFields []codec.CustomField `json:"custom_fields,omitempty"`
+	OrgID        int64               `json:"org_id,omitempty"`
+	Shared       *codec.Shared       `json:"shared,omitempty"`
 }
 
 func (h *HttpHandler) GetBankCardObj(w http.ResponseWriter, r *http.Request) {
@@ -21,6 +35,12 @@ func (h *HttpHandler) GetBankCardObj(w http.ResponseWriter, r *http.Request) {
 
 	var resp = new(BankCardResponse)
 
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
 	urlId := chi.URLParam(r, "id")
 
 	id, err := strconv.ParseInt(urlId, 10, 64)
@@ -29,7 +49,7 @@ func (h *HttpHandler) GetBankCardObj(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	card, err := h.service.GetBankCard(r.Context(), id)
+	card, err := h.service.GetBankCard(r.Context(), userId, id)
 	if err != nil {
 		logger.Log.Error(HandlerName, zap.Error(err))
 
@@ -38,8 +58,19 @@ func (h *HttpHandler) GetBankCardObj(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp.PID = card.Pid
 	resp.BankName = card.Bank
+	resp.Brand = card.Brand()
+	resp.Number = card.Number
+	resp.HolderName = card.HolderName
+	resp.ExpiryMonth = card.ExpiryMonth
+	resp.ExpiryYear = card.ExpiryYear
+	resp.CVV = card.CVV
+	resp.PIN = card.PIN
+	resp.Notes = card.Notes
+	resp.ExpiresAt = codec.OptionalTime(card.ExpiresAt)
+	resp.CustomFields = codec.CustomFieldsFromDomain(card.CustomFields)
+	resp.OrgID = card.OrgID
+	resp.Shared = codec.SharedFromDomain(card.Shared)
 
 	codec.WriteJSON(w, http.StatusOK, resp)
 }
@@ -9,6 +9,7 @@ import (
 	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/bank_card_usecase"
 	"testing"
 
+	"server/internal/app/adapters/primary/http-adapter/constants"
 	domain "server/internal/app/domain/bank_card_obj"
 	"server/internal/pkg/logger"
 
@@ -26,7 +27,7 @@ type mockServiceS struct {
 	calledGet bool
 }
 
-func (m *mockServiceS) GetBankCard(ctx context.Context, cardId int64) (*domain.BankCard, error) {
+func (m *mockServiceS) GetBankCard(ctx context.Context, userId, cardId int64) (*domain.BankCard, error) {
 	m.calledGet = true
 	return m.getFn(ctx, cardId)
 }
@@ -40,6 +41,10 @@ func (m *mockServiceS) UpdateBankCard(ctx context.Context, card *domain.BankCard
 	return nil
 }
 
+func (m *mockServiceS) DeleteBankCard(ctx context.Context, userId, cardId int64) error {
+	return nil
+}
+
 /* ---------- tests ---------- */
 
 func TestHttpHandler_GetBankCardObj(t *testing.T) {
@@ -62,6 +67,7 @@ func TestHttpHandler_GetBankCardObj(t *testing.T) {
 		rctx := chi.NewRouteContext()
 		rctx.URLParams.Add("id", "abc")
 		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(1)))
 
 		h.GetBankCardObj(rr, req)
 
@@ -93,6 +99,7 @@ func TestHttpHandler_GetBankCardObj(t *testing.T) {
 		rctx := chi.NewRouteContext()
 		rctx.URLParams.Add("id", "10")
 		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(1)))
 
 		h.GetBankCardObj(rr, req)
 
@@ -111,8 +118,11 @@ func TestHttpHandler_GetBankCardObj(t *testing.T) {
 		ms := &mockServiceS{
 			getFn: func(ctx context.Context, cardId int64) (*domain.BankCard, error) {
 				return &domain.BankCard{
-					Bank: "MAIB",
-					Pid:  "A123",
+					Bank:        "MAIB",
+					Number:      "4242424242424242",
+					ExpiryMonth: 12,
+					ExpiryYear:  2030,
+					CVV:         "123",
 				}, nil
 			},
 		}
@@ -124,6 +134,7 @@ func TestHttpHandler_GetBankCardObj(t *testing.T) {
 		rctx := chi.NewRouteContext()
 		rctx.URLParams.Add("id", "7")
 		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(1)))
 
 		h.GetBankCardObj(rr, req)
 
@@ -136,7 +147,10 @@ func TestHttpHandler_GetBankCardObj(t *testing.T) {
 			t.Fatalf("decode response: %v", err)
 		}
 
-		if resp.BankName != "MAIB" || resp.PID != "A123" {
+		if resp.BankName != "MAIB" || resp.Number != "4242424242424242" || resp.CVV != "123" {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+		if resp.Brand != domain.BrandVisa || resp.ExpiryMonth != 12 || resp.ExpiryYear != 2030 {
 			t.Fatalf("unexpected response: %+v", resp)
 		}
 	})
@@ -6,13 +6,21 @@ import (
 	"server/internal/app/adapters/primary/http-adapter/constants"
 	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/bank_card_usecase"
 	"server/internal/pkg/logger"
+	"time"
 
 	"go.uber.org/zap"
 )
 
 type Card struct {
-	CardID   int64  `json:"card_id"`
-	BankName string `json:"bank_name"`
+	CardID      int64  `json:"card_id"`
+	BankName    string `json:"bank_name"`
+	Brand       string `json:"brand"`
+	Number      string `json:"number"`
+	ExpiryMonth int    `json:"expiry_month"`
+	ExpiryYear  int    `json:"expiry_year"`
+
+	ExpiresAt    *time.Time              `json:"expires_at,omitempty"`
+	CustomFields []codec.CustomFieldName `json:"custom_fields,omitempty"`
 }
 
 func (h *HttpHandler) GetBankCardList(w http.ResponseWriter, r *http.Request) {
@@ -36,8 +44,14 @@ func (h *HttpHandler) GetBankCardList(w http.ResponseWriter, r *http.Request) {
 
 	for _, item := range list {
 		c := Card{
-			CardID:   item.CardId,
-			BankName: item.Bank,
+			CardID:       item.CardId,
+			BankName:     item.Bank,
+			Brand:        item.Brand(),
+			Number:       item.Masked(),
+			ExpiryMonth:  item.ExpiryMonth,
+			ExpiryYear:   item.ExpiryYear,
+			ExpiresAt:    codec.OptionalTime(item.ExpiresAt),
+			CustomFields: codec.CustomFieldNamesFromDomain(item.CustomFields),
 		}
 		resp = append(resp, c)
 	}
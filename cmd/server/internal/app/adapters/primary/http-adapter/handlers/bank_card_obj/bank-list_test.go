@@ -25,7 +25,7 @@ type mockServiceSS struct {
 	calledList bool
 }
 
-func (m *mockServiceSS) GetBankCard(ctx context.Context, cardId int64) (*domain.BankCard, error) {
+func (m *mockServiceSS) GetBankCard(ctx context.Context, userId, cardId int64) (*domain.BankCard, error) {
 	return nil, nil
 }
 
@@ -42,6 +42,10 @@ func (m *mockServiceSS) UpdateBankCard(ctx context.Context, card *domain.BankCar
 	return nil
 }
 
+func (m *mockServiceSS) DeleteBankCard(ctx context.Context, userId, cardId int64) error {
+	return nil
+}
+
 func TestHttpHandler_GetBankCardList(t *testing.T) {
 
 	old := logger.Log
@@ -105,8 +109,8 @@ func TestHttpHandler_GetBankCardList(t *testing.T) {
 		ms := &mockServiceSS{
 			listFn: func(ctx context.Context, userId int64) ([]*domain.BankCard, error) {
 				return []*domain.BankCard{
-					{CardId: 1, Bank: "MAIB"},
-					{CardId: 2, Bank: "Moldindconbank"},
+					{CardId: 1, Bank: "MAIB", Number: "4242424242424242"},
+					{CardId: 2, Bank: "Moldindconbank", Number: "5555555555554444"},
 				}, nil
 			},
 		}
@@ -127,13 +131,16 @@ func TestHttpHandler_GetBankCardList(t *testing.T) {
 			t.Fatalf("decode response: %v", err)
 		}
 
+		if bytes.Contains(rr.Body.Bytes(), []byte("4242424242424242")) {
+			t.Fatalf("full card number must not be listed, body=%s", rr.Body.String())
+		}
 		if len(got) != 2 {
 			t.Fatalf("expected 2 items, got %d: %+v", len(got), got)
 		}
-		if got[0].CardID != 1 || got[0].BankName != "MAIB" {
+		if got[0].CardID != 1 || got[0].BankName != "MAIB" || got[0].Number != "**** 4242" || got[0].Brand != domain.BrandVisa {
 			t.Fatalf("unexpected item[0]: %+v", got[0])
 		}
-		if got[1].CardID != 2 || got[1].BankName != "Moldindconbank" {
+		if got[1].CardID != 2 || got[1].BankName != "Moldindconbank" || got[1].Number != "**** 4444" {
 			t.Fatalf("unexpected item[1]: %+v", got[1])
 		}
 	})
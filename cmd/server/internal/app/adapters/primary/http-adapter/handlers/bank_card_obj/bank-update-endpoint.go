@@ -9,14 +9,24 @@ import (
 	domain "server/internal/app/domain/bank_card_obj"
 	"server/internal/pkg/logger"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 )
 
 type UpdateBankCardRequest struct {
-	Pid      string `json:"pid"`
-	BankName string `json:"bank_name"`
+	BankName    string `json:"bank_name"`
+	Number      string `json:"number"`
+	HolderName  string `json:"holder_name"`
+	ExpiryMonth int    `json:"expiry_month"`
+	ExpiryYear  int    `json:"expiry_year"`
+	CVV         string `json:"cvv"`
+	PIN         string `json:"pin"`
+	Notes       string `json:"notes"`
+
+	ExpiresAt    *time.Time          `json:"expires_at,omitempty"`
+	CustomFields []codec.CustomField `json:"custom_fields,omitempty"`
 }
 
 func (h *HttpHandler) UpdateBankCardObj(w http.ResponseWriter, r *http.Request) {
@@ -63,7 +73,15 @@ func (h *HttpHandler) UpdateBankCardObj(w http.ResponseWriter, r *http.Request)
 
 func (u *UpdateBankCardRequest) toDomain() *domain.BankCard {
 	return &domain.BankCard{
-		Pid:  u.Pid,
-		Bank: u.BankName,
+		Bank:         u.BankName,
+		Number:       u.Number,
+		HolderName:   u.HolderName,
+		ExpiryMonth:  u.ExpiryMonth,
+		ExpiryYear:   u.ExpiryYear,
+		CVV:          u.CVV,
+		PIN:          u.PIN,
+		Notes:        u.Notes,
+		ExpiresAt:    codec.TimeOrZero(u.ExpiresAt),
+		CustomFields: codec.CustomFieldsToDomain(u.CustomFields),
 	}
 }
@@ -26,7 +26,7 @@ type mockServicE struct {
 	lastCard     *domain.BankCard
 }
 
-func (m *mockServicE) GetBankCard(ctx context.Context, cardId int64) (*domain.BankCard, error) {
+func (m *mockServicE) GetBankCard(ctx context.Context, userId, cardId int64) (*domain.BankCard, error) {
 	return nil, nil
 }
 func (m *mockServicE) GetBankCardList(ctx context.Context, userId int64) ([]*domain.BankCard, error) {
@@ -44,6 +44,10 @@ func (m *mockServicE) UpdateBankCard(ctx context.Context, card *domain.BankCard)
 	return m.updateFn(ctx, card)
 }
 
+func (m *mockServicE) DeleteBankCard(ctx context.Context, userId, cardId int64) error {
+	return nil
+}
+
 /* ---------- helpers ---------- */
 
 func newReqWithChiID(t *testing.T, method, path, id string, body []byte) *http.Request {
@@ -97,7 +101,7 @@ func TestHttpHandler_UpdateBankCardObj(t *testing.T) {
 		}
 		h := New(ms)
 
-		body, _ := json.Marshal(UpdateBankCardRequest{Pid: "123", BankName: "MAIB"})
+		body, _ := json.Marshal(UpdateBankCardRequest{Number: "4242424242424242", BankName: "MAIB"})
 		req := newReqWithChiID(t, http.MethodPut, "/update/nope", "nope", body)
 		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(7)))
 
@@ -121,7 +125,7 @@ func TestHttpHandler_UpdateBankCardObj(t *testing.T) {
 		}
 		h := New(ms)
 
-		body, _ := json.Marshal(UpdateBankCardRequest{Pid: "123", BankName: "MAIB"})
+		body, _ := json.Marshal(UpdateBankCardRequest{Number: "4242424242424242", BankName: "MAIB"})
 		req := newReqWithChiID(t, http.MethodPut, "/update/10", "10", body) // no userID
 
 		rr := httptest.NewRecorder()
@@ -146,7 +150,7 @@ func TestHttpHandler_UpdateBankCardObj(t *testing.T) {
 		}
 		h := New(ms)
 
-		body, _ := json.Marshal(UpdateBankCardRequest{Pid: "123", BankName: "MAIB"})
+		body, _ := json.Marshal(UpdateBankCardRequest{Number: "4242424242424242", BankName: "MAIB"})
 		req := newReqWithChiID(t, http.MethodPut, "/update/10", "10", body)
 		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(7)))
 
@@ -172,7 +176,7 @@ func TestHttpHandler_UpdateBankCardObj(t *testing.T) {
 		}
 		h := New(ms)
 
-		body, _ := json.Marshal(UpdateBankCardRequest{Pid: "PID-999", BankName: "MICB"})
+		body, _ := json.Marshal(UpdateBankCardRequest{Number: "5555555555554444", BankName: "MICB", ExpiryMonth: 1, ExpiryYear: 2031})
 		req := newReqWithChiID(t, http.MethodPut, "/update/55", "55", body)
 		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(777)))
 
@@ -194,8 +198,8 @@ func TestHttpHandler_UpdateBankCardObj(t *testing.T) {
 		if ms.lastCard.UserId != 777 {
 			t.Fatalf("expected UserId=777, got %d", ms.lastCard.UserId)
 		}
-		if ms.lastCard.Pid != "PID-999" {
-			t.Fatalf("expected Pid=PID-999, got %q", ms.lastCard.Pid)
+		if ms.lastCard.Number != "5555555555554444" || ms.lastCard.ExpiryMonth != 1 || ms.lastCard.ExpiryYear != 2031 {
+			t.Fatalf("unexpected card fields: %+v", ms.lastCard)
 		}
 		if ms.lastCard.Bank != "MICB" {
 			t.Fatalf("expected Bank=MICB, got %q", ms.lastCard.Bank)
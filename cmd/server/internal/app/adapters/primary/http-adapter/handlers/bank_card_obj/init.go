@@ -8,10 +8,11 @@ import (
 )
 
 type service interface {
-	GetBankCard(ctx context.Context, cardId int64) (*domain.BankCard, error)
+	GetBankCard(ctx context.Context, userId, cardId int64) (*domain.BankCard, error)
 	GetBankCardList(ctx context.Context, userId int64) ([]*domain.BankCard, error)
 	CreateNewBankCardObj(ctx context.Context, card *domain.BankCard) (int64, error)
 	UpdateBankCard(ctx context.Context, card *domain.BankCard) error
+	DeleteBankCard(ctx context.Context, userId, cardId int64) error
 }
 
 type HttpHandler struct {
@@ -29,6 +30,7 @@ func (h *HttpHandler) Routes() *chi.Mux {
 	router.Get("/list/{id}", h.GetBankCardObj)
 	router.Post("/create", h.CreateBankCard)
 	router.Put("/update/{id}", h.UpdateBankCardObj)
+	router.Delete("/delete/{id}", h.DeleteBankCardObj)
 
 	return router
 }
@@ -0,0 +1,50 @@
+package cert_obj
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/cert_usecase"
+	"server/internal/pkg/logger"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type CertificateResponse struct {
+	Certificate
+	CertificatePEM string `json:"certificate"`
+	PrivateKey     string `json:"private_key,omitempty"`
+}
+
+func (h *HttpHandler) GetCertificate(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "GetCertificate"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid certificate id")
+		return
+	}
+
+	cert, err := h.service.GetCertificate(r.Context(), userId, id)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, CertificateResponse{
+		Certificate:    fromDomain(cert),
+		CertificatePEM: cert.Certificate,
+		PrivateKey:     cert.PrivateKey,
+	})
+}
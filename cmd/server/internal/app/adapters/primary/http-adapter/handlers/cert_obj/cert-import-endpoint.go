@@ -0,0 +1,53 @@
+package cert_obj
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/cert_usecase"
+	domain "server/internal/app/domain/cert_obj"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// ImportCertificateRequest is a PEM bundle, the private key may be a part of it or sent separately
+type ImportCertificateRequest struct {
+	Title       string `json:"title"`
+	Certificate string `json:"certificate"`
+	PrivateKey  string `json:"private_key"`
+}
+
+// ImportCertificate parses and stores a PEM bundle and responds with the extracted fields
+func (h *HttpHandler) ImportCertificate(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "ImportCertificate"
+
+	req := new(ImportCertificateRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "json decode error")
+		return
+	}
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	cert, err := h.service.ImportCertificate(r.Context(), domain.ImportRequest{
+		UserId:     userId,
+		Title:      req.Title,
+		Bundle:     req.Certificate,
+		PrivateKey: req.PrivateKey,
+	})
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, fromDomain(cert))
+}
@@ -0,0 +1,118 @@
+package cert_obj
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "server/internal/app/domain/cert_obj"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+func TestHttpHandler_ImportCertificate(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("bad json -> 422", func(t *testing.T) {
+		h := New(&serviceMock{})
+
+		rr := httptest.NewRecorder()
+		h.ImportCertificate(rr, withUser(httptest.NewRequest(http.MethodPost, "/import", bytes.NewReader([]byte("{"))), 7))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d", http.StatusUnprocessableEntity, rr.Code)
+		}
+	})
+
+	t.Run("key mismatch -> 400", func(t *testing.T) {
+		var got domain.ImportRequest
+		h := New(&serviceMock{
+			importFn: func(ctx context.Context, req domain.ImportRequest) (*domain.Certificate, error) {
+				got = req
+				return nil, domain.ErrKeyMismatch
+			},
+		})
+
+		body := []byte(`{"title":"web","certificate":"CERT","private_key":"KEY"}`)
+		rr := httptest.NewRecorder()
+		h.ImportCertificate(rr, withUser(httptest.NewRequest(http.MethodPost, "/import", bytes.NewReader(body)), 7))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+		}
+		want := domain.ImportRequest{UserId: 7, Title: "web", Bundle: "CERT", PrivateKey: "KEY"}
+		if got != want {
+			t.Fatalf("expected %+v, got %+v", want, got)
+		}
+	})
+
+	t.Run("ok -> 200 with extracted fields", func(t *testing.T) {
+		h := New(&serviceMock{
+			importFn: func(ctx context.Context, req domain.ImportRequest) (*domain.Certificate, error) {
+				return &domain.Certificate{CertId: 4, Title: req.Title, Subject: "CN=example.com", SANs: []string{"example.com"}}, nil
+			},
+		})
+
+		body := []byte(`{"title":"web","certificate":"CERT"}`)
+		rr := httptest.NewRecorder()
+		h.ImportCertificate(rr, withUser(httptest.NewRequest(http.MethodPost, "/import", bytes.NewReader(body)), 7))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+
+		var resp Certificate
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if resp.CertID != 4 || resp.Subject != "CN=example.com" || len(resp.SANs) != 1 || resp.HasPrivateKey {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+	})
+}
+
+func TestHttpHandler_GetCertificate(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("not found -> 404", func(t *testing.T) {
+		h := New(&serviceMock{
+			getFn: func(ctx context.Context, userId, certId int64) (*domain.Certificate, error) {
+				return nil, domain.ErrCertificateNotFound
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.GetCertificate(rr, withUser(newChiReq(http.MethodGet, "/list/3", "/list/{id}", "id", "3"), 7))
+
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+
+	t.Run("ok -> PEM and key returned", func(t *testing.T) {
+		h := New(&serviceMock{
+			getFn: func(ctx context.Context, userId, certId int64) (*domain.Certificate, error) {
+				return &domain.Certificate{CertId: certId, UserId: userId, Certificate: "CERT", PrivateKey: "KEY"}, nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.GetCertificate(rr, withUser(newChiReq(http.MethodGet, "/list/3", "/list/{id}", "id", "3"), 7))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+
+		var resp CertificateResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if resp.CertificatePEM != "CERT" || resp.PrivateKey != "KEY" || resp.CertID != 3 {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+	})
+}
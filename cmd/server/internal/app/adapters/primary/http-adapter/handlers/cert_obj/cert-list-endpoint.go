@@ -0,0 +1,110 @@
+package cert_obj
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/cert_usecase"
+	domain "server/internal/app/domain/cert_obj"
+	"server/internal/pkg/logger"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// defaultExpiringDays is the window of the expiring list when ?days is not set
+const defaultExpiringDays = 30
+
+// Certificate is the public part of a certificate, the PEM data is only returned by the get endpoint
+type Certificate struct {
+	CertID        int64     `json:"cert_id"`
+	Title         string    `json:"title"`
+	Subject       string    `json:"subject"`
+	Issuer        string    `json:"issuer"`
+	SANs          []string  `json:"sans,omitempty"`
+	SerialNumber  string    `json:"serial_number"`
+	Fingerprint   string    `json:"fingerprint"`
+	NotBefore     time.Time `json:"not_before"`
+	NotAfter      time.Time `json:"not_after"`
+	HasPrivateKey bool      `json:"has_private_key"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// GetCertificateList lists certificates of the user, ?q filters by subject, issuer and SANs
+func (h *HttpHandler) GetCertificateList(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "GetCertificateList"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	list, err := h.service.GetCertificateList(r.Context(), userId, r.URL.Query().Get("q"))
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, fromDomainList(list))
+}
+
+// GetExpiringCertificates lists certificates that expire within ?days (30 by default), expired ones included
+func (h *HttpHandler) GetExpiringCertificates(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "GetExpiringCertificates"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	days := defaultExpiringDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid days")
+			return
+		}
+		days = n
+	}
+
+	list, err := h.service.GetExpiringCertificates(r.Context(), userId, time.Duration(days)*24*time.Hour)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, fromDomainList(list))
+}
+
+func fromDomainList(list []*domain.Certificate) []Certificate {
+	resp := make([]Certificate, 0, len(list))
+	for _, item := range list {
+		resp = append(resp, fromDomain(item))
+	}
+	return resp
+}
+
+func fromDomain(cert *domain.Certificate) Certificate {
+	return Certificate{
+		CertID:        cert.CertId,
+		Title:         cert.Title,
+		Subject:       cert.Subject,
+		Issuer:        cert.Issuer,
+		SANs:          cert.SANs,
+		SerialNumber:  cert.SerialNumber,
+		Fingerprint:   cert.Fingerprint,
+		NotBefore:     cert.NotBefore,
+		NotAfter:      cert.NotAfter,
+		HasPrivateKey: cert.PrivateKey != "",
+		CreatedAt:     cert.CreatedAt,
+	}
+}
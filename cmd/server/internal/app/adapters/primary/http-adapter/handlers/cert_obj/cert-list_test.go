@@ -0,0 +1,154 @@
+package cert_obj
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	domain "server/internal/app/domain/cert_obj"
+	"server/internal/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type serviceMock struct {
+	getListFn     func(ctx context.Context, userId int64, search string) ([]*domain.Certificate, error)
+	getExpiringFn func(ctx context.Context, userId int64, within time.Duration) ([]*domain.Certificate, error)
+	getFn         func(ctx context.Context, userId, certId int64) (*domain.Certificate, error)
+	importFn      func(ctx context.Context, req domain.ImportRequest) (*domain.Certificate, error)
+}
+
+func (m *serviceMock) GetCertificateList(ctx context.Context, userId int64, search string) ([]*domain.Certificate, error) {
+	if m.getListFn == nil {
+		return nil, errors.New("GetCertificateList not stubbed")
+	}
+	return m.getListFn(ctx, userId, search)
+}
+
+func (m *serviceMock) GetExpiringCertificates(ctx context.Context, userId int64, within time.Duration) ([]*domain.Certificate, error) {
+	if m.getExpiringFn == nil {
+		return nil, errors.New("GetExpiringCertificates not stubbed")
+	}
+	return m.getExpiringFn(ctx, userId, within)
+}
+
+func (m *serviceMock) GetCertificate(ctx context.Context, userId, certId int64) (*domain.Certificate, error) {
+	if m.getFn == nil {
+		return nil, errors.New("GetCertificate not stubbed")
+	}
+	return m.getFn(ctx, userId, certId)
+}
+
+func (m *serviceMock) ImportCertificate(ctx context.Context, req domain.ImportRequest) (*domain.Certificate, error) {
+	if m.importFn == nil {
+		return nil, errors.New("ImportCertificate not stubbed")
+	}
+	return m.importFn(ctx, req)
+}
+
+func withUser(r *http.Request, id int64) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), constants.UserIDKey, id))
+}
+
+func newChiReq(method, path, routePattern, paramKey, paramValue string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+
+	rctx := chi.NewRouteContext()
+	rctx.RoutePatterns = append(rctx.RoutePatterns, routePattern)
+	rctx.URLParams.Add(paramKey, paramValue)
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHttpHandler_GetCertificateList(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("search passed to service, no PEM in response", func(t *testing.T) {
+		var gotSearch string
+		h := New(&serviceMock{
+			getListFn: func(ctx context.Context, userId int64, search string) ([]*domain.Certificate, error) {
+				gotSearch = search
+				return []*domain.Certificate{{CertId: 1, Subject: "CN=example.com", Certificate: "PEM", PrivateKey: "KEY"}}, nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.GetCertificateList(rr, withUser(httptest.NewRequest(http.MethodGet, "/list?q=example", nil), 7))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		if gotSearch != "example" {
+			t.Fatalf("expected search %q, got %q", "example", gotSearch)
+		}
+
+		var resp []map[string]any
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(resp) != 1 || resp[0]["has_private_key"] != true {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+		if _, ok := resp[0]["private_key"]; ok {
+			t.Fatalf("list must not expose private keys: %+v", resp[0])
+		}
+	})
+
+	t.Run("empty -> 204", func(t *testing.T) {
+		h := New(&serviceMock{
+			getListFn: func(ctx context.Context, userId int64, search string) ([]*domain.Certificate, error) {
+				return nil, domain.ErrEmptyCertificatesList
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.GetCertificateList(rr, withUser(httptest.NewRequest(http.MethodGet, "/list", nil), 7))
+
+		if rr.Code != http.StatusNoContent {
+			t.Fatalf("expected %d, got %d", http.StatusNoContent, rr.Code)
+		}
+	})
+}
+
+func TestHttpHandler_GetExpiringCertificates(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantWithin time.Duration
+	}{
+		{name: "default window -> 30 days", path: "/expiring", wantStatus: http.StatusOK, wantWithin: 30 * 24 * time.Hour},
+		{name: "days param", path: "/expiring?days=7", wantStatus: http.StatusOK, wantWithin: 7 * 24 * time.Hour},
+		{name: "bad days -> 400", path: "/expiring?days=week", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotWithin time.Duration
+			h := New(&serviceMock{
+				getExpiringFn: func(ctx context.Context, userId int64, within time.Duration) ([]*domain.Certificate, error) {
+					gotWithin = within
+					return []*domain.Certificate{{CertId: 1}}, nil
+				},
+			})
+
+			rr := httptest.NewRecorder()
+			h.GetExpiringCertificates(rr, withUser(httptest.NewRequest(http.MethodGet, tt.path, nil), 7))
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d, body=%s", tt.wantStatus, rr.Code, rr.Body.String())
+			}
+			if gotWithin != tt.wantWithin {
+				t.Fatalf("expected window %v, got %v", tt.wantWithin, gotWithin)
+			}
+		})
+	}
+}
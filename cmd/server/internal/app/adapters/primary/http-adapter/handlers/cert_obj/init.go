@@ -0,0 +1,37 @@
+package cert_obj
+
+import (
+	"context"
+	domain "server/internal/app/domain/cert_obj"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type service interface {
+	GetCertificateList(ctx context.Context, userId int64, search string) ([]*domain.Certificate, error)
+	GetExpiringCertificates(ctx context.Context, userId int64, within time.Duration) ([]*domain.Certificate, error)
+	GetCertificate(ctx context.Context, userId, certId int64) (*domain.Certificate, error)
+	ImportCertificate(ctx context.Context, req domain.ImportRequest) (*domain.Certificate, error)
+}
+
+type HttpHandler struct {
+	service service
+}
+
+func New(service service) *HttpHandler {
+	return &HttpHandler{
+		service: service,
+	}
+}
+
+func (h *HttpHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Get("/list", h.GetCertificateList)
+	router.Get("/list/{id}", h.GetCertificate)
+	router.Get("/expiring", h.GetExpiringCertificates)
+	router.Post("/import", h.ImportCertificate)
+
+	return router
+}
@@ -0,0 +1,76 @@
+package emergency
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/emergency_usecase"
+	"server/internal/pkg/logger"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// AddContactRequest adds a trusted contact, zero wait_hours uses the server default
+type AddContactRequest struct {
+	Username   string `json:"username"`
+	AccessType string `json:"access_type"`
+	WaitHours  int64  `json:"wait_hours"`
+}
+
+func (h *HttpHandler) AddContact(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "AddContact"
+
+	req := new(AddContactRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "json decode error")
+		return
+	}
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	c, err := h.service.AddContact(r.Context(), userId, req.Username, req.AccessType, time.Duration(req.WaitHours)*time.Hour)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, fromDomain(c, time.Now()))
+}
+
+// RemoveContact is used by grantors to revoke a contact and by contacts to step down
+func (h *HttpHandler) RemoveContact(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "RemoveContact"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	contactId, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid contact id")
+		return
+	}
+
+	if err := h.service.Remove(r.Context(), userId, contactId); err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
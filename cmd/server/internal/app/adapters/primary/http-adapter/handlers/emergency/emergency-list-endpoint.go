@@ -0,0 +1,129 @@
+package emergency
+
+import (
+	"context"
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/emergency_usecase"
+	domain "server/internal/app/domain/emergency"
+	"server/internal/pkg/logger"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type Contact struct {
+	ContactID   int64      `json:"contact_id"`
+	Grantor     string     `json:"grantor"`
+	Grantee     string     `json:"grantee"`
+	AccessType  string     `json:"access_type"`
+	WaitHours   int64      `json:"wait_hours"`
+	Status      string     `json:"status"`
+	RequestedAt *time.Time `json:"requested_at,omitempty"`
+	AccessAt    *time.Time `json:"access_at,omitempty"`
+	Granted     bool       `json:"granted"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type Item struct {
+	Type  string `json:"type"`
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+// Trusted lists the emergency contacts of the caller
+func (h *HttpHandler) Trusted(w http.ResponseWriter, r *http.Request) {
+	h.listContacts(w, r, "Trusted", h.service.Trusted)
+}
+
+// TrustedBy lists the users who made the caller their emergency contact
+func (h *HttpHandler) TrustedBy(w http.ResponseWriter, r *http.Request) {
+	h.listContacts(w, r, "TrustedBy", h.service.TrustedBy)
+}
+
+// Vault lists the items of the grantor once the waiting period is over or the request is approved
+func (h *HttpHandler) Vault(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "Vault"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	contactId, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid contact id")
+		return
+	}
+
+	list, err := h.service.Vault(r.Context(), userId, contactId)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	out := make([]Item, 0, len(list))
+	for _, it := range list {
+		out = append(out, Item{Type: it.Type, ID: it.ID, Title: it.Title})
+	}
+
+	codec.WriteJSON(w, http.StatusOK, out)
+}
+
+// help func
+
+func (h *HttpHandler) listContacts(w http.ResponseWriter, r *http.Request, handlerName string,
+	list func(ctx context.Context, userID int64) ([]*domain.Contact, error)) {
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	contacts, err := list(r.Context(), userId)
+	if err != nil {
+		logger.Log.Error(handlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	now := time.Now()
+	out := make([]Contact, 0, len(contacts))
+	for _, c := range contacts {
+		out = append(out, fromDomain(c, now))
+	}
+
+	codec.WriteJSON(w, http.StatusOK, out)
+}
+
+func fromDomain(c *domain.Contact, now time.Time) Contact {
+	out := Contact{
+		ContactID:  c.ID,
+		Grantor:    c.GrantorName,
+		Grantee:    c.GranteeName,
+		AccessType: c.AccessType,
+		WaitHours:  int64(c.Wait / time.Hour),
+		Status:     c.Status,
+		Granted:    c.Granted(now),
+		CreatedAt:  c.CreatedAt,
+	}
+
+	if !c.RequestedAt.IsZero() {
+		requestedAt := c.RequestedAt
+		out.RequestedAt = &requestedAt
+	}
+	if accessAt := c.AccessAt(); !accessAt.IsZero() {
+		out.AccessAt = &accessAt
+	}
+
+	return out
+}
@@ -0,0 +1,59 @@
+package emergency
+
+import (
+	"context"
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/emergency_usecase"
+	domain "server/internal/app/domain/emergency"
+	"server/internal/pkg/logger"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// RequestAccess starts the waiting period of the contact
+func (h *HttpHandler) RequestAccess(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, "RequestAccess", h.service.RequestAccess)
+}
+
+// Approve lets the grantor skip the rest of the waiting period
+func (h *HttpHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, "Approve", h.service.Approve)
+}
+
+// Reject lets the grantor deny a request or revoke access already given
+func (h *HttpHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, "Reject", h.service.Reject)
+}
+
+// help func
+
+func (h *HttpHandler) transition(w http.ResponseWriter, r *http.Request, handlerName string,
+	do func(ctx context.Context, userID, contactID int64) (*domain.Contact, error)) {
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	contactId, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid contact id")
+		return
+	}
+
+	c, err := do(r.Context(), userId, contactId)
+	if err != nil {
+		logger.Log.Error(handlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, fromDomain(c, time.Now()))
+}
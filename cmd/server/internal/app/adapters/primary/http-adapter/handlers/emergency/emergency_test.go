@@ -0,0 +1,318 @@
+package emergency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	domain "server/internal/app/domain/emergency"
+	"server/internal/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type serviceMock struct {
+	addFn       func(ctx context.Context, grantorID int64, username, accessType string, wait time.Duration) (*domain.Contact, error)
+	trustedFn   func(ctx context.Context, grantorID int64) ([]*domain.Contact, error)
+	trustedByFn func(ctx context.Context, granteeID int64) ([]*domain.Contact, error)
+	removeFn    func(ctx context.Context, userID, contactID int64) error
+	requestFn   func(ctx context.Context, granteeID, contactID int64) (*domain.Contact, error)
+	approveFn   func(ctx context.Context, grantorID, contactID int64) (*domain.Contact, error)
+	rejectFn    func(ctx context.Context, grantorID, contactID int64) (*domain.Contact, error)
+	vaultFn     func(ctx context.Context, granteeID, contactID int64) ([]*domain.Item, error)
+}
+
+func (m *serviceMock) AddContact(ctx context.Context, grantorID int64, username, accessType string, wait time.Duration) (*domain.Contact, error) {
+	if m.addFn == nil {
+		return nil, errors.New("AddContact not stubbed")
+	}
+	return m.addFn(ctx, grantorID, username, accessType, wait)
+}
+
+func (m *serviceMock) Trusted(ctx context.Context, grantorID int64) ([]*domain.Contact, error) {
+	if m.trustedFn == nil {
+		return nil, errors.New("Trusted not stubbed")
+	}
+	return m.trustedFn(ctx, grantorID)
+}
+
+func (m *serviceMock) TrustedBy(ctx context.Context, granteeID int64) ([]*domain.Contact, error) {
+	if m.trustedByFn == nil {
+		return nil, errors.New("TrustedBy not stubbed")
+	}
+	return m.trustedByFn(ctx, granteeID)
+}
+
+func (m *serviceMock) Remove(ctx context.Context, userID, contactID int64) error {
+	if m.removeFn == nil {
+		return errors.New("Remove not stubbed")
+	}
+	return m.removeFn(ctx, userID, contactID)
+}
+
+func (m *serviceMock) RequestAccess(ctx context.Context, granteeID, contactID int64) (*domain.Contact, error) {
+	if m.requestFn == nil {
+		return nil, errors.New("RequestAccess not stubbed")
+	}
+	return m.requestFn(ctx, granteeID, contactID)
+}
+
+func (m *serviceMock) Approve(ctx context.Context, grantorID, contactID int64) (*domain.Contact, error) {
+	if m.approveFn == nil {
+		return nil, errors.New("Approve not stubbed")
+	}
+	return m.approveFn(ctx, grantorID, contactID)
+}
+
+func (m *serviceMock) Reject(ctx context.Context, grantorID, contactID int64) (*domain.Contact, error) {
+	if m.rejectFn == nil {
+		return nil, errors.New("Reject not stubbed")
+	}
+	return m.rejectFn(ctx, grantorID, contactID)
+}
+
+func (m *serviceMock) Vault(ctx context.Context, granteeID, contactID int64) ([]*domain.Item, error) {
+	if m.vaultFn == nil {
+		return nil, errors.New("Vault not stubbed")
+	}
+	return m.vaultFn(ctx, granteeID, contactID)
+}
+
+func withUser(r *http.Request, id int64) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), constants.UserIDKey, id))
+}
+
+// newChiReq builds a request with URL params given as key, value pairs
+func newChiReq(method, path string, body io.Reader, params ...string) *http.Request {
+	req := httptest.NewRequest(method, path, body)
+
+	rctx := chi.NewRouteContext()
+	for i := 0; i+1 < len(params); i += 2 {
+		rctx.URLParams.Add(params[i], params[i+1])
+	}
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHttpHandler_AddContact(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("bad json -> 422", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		New(&serviceMock{}).AddContact(rr, withUser(httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{")), 1))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d", http.StatusUnprocessableEntity, rr.Code)
+		}
+	})
+
+	t.Run("missing user -> 422", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		New(&serviceMock{}).AddContact(rr, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"username":"bob"}`)))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d", http.StatusUnprocessableEntity, rr.Code)
+		}
+	})
+
+	t.Run("wait out of range -> 400", func(t *testing.T) {
+		h := New(&serviceMock{addFn: func(ctx context.Context, grantorID int64, username, accessType string, wait time.Duration) (*domain.Contact, error) {
+			return nil, domain.ErrInvalidWait
+		}})
+
+		rr := httptest.NewRecorder()
+		h.AddContact(rr, withUser(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"username":"bob","wait_hours":10000}`)), 1))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+	})
+
+	t.Run("ok -> 200 with hours converted", func(t *testing.T) {
+		var gotWait time.Duration
+		h := New(&serviceMock{addFn: func(ctx context.Context, grantorID int64, username, accessType string, wait time.Duration) (*domain.Contact, error) {
+			gotWait = wait
+			return &domain.Contact{ID: 4, GrantorName: "alice", GranteeName: username, AccessType: accessType, Wait: wait, Status: domain.StatusIdle}, nil
+		}})
+
+		rr := httptest.NewRecorder()
+		h.AddContact(rr, withUser(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"username":"bob","access_type":"view","wait_hours":48}`)), 1))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		if gotWait != 48*time.Hour {
+			t.Fatalf("expected wait 48h, got %s", gotWait)
+		}
+
+		var out Contact
+		if err := json.Unmarshal(rr.Body.Bytes(), &out); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if out.ContactID != 4 || out.Grantee != "bob" || out.WaitHours != 48 || out.Granted || out.AccessAt != nil {
+			t.Fatalf("unexpected contact: %+v", out)
+		}
+	})
+}
+
+func TestHttpHandler_TrustedBy(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("empty -> 204", func(t *testing.T) {
+		h := New(&serviceMock{trustedByFn: func(ctx context.Context, granteeID int64) ([]*domain.Contact, error) {
+			return nil, domain.ErrEmptyContacts
+		}})
+
+		rr := httptest.NewRecorder()
+		h.TrustedBy(rr, withUser(httptest.NewRequest(http.MethodGet, "/granted", nil), 2))
+
+		if rr.Code != http.StatusNoContent {
+			t.Fatalf("expected %d, got %d", http.StatusNoContent, rr.Code)
+		}
+	})
+
+	t.Run("ok -> granted after the waiting period", func(t *testing.T) {
+		requestedAt := time.Now().Add(-2 * time.Hour)
+		h := New(&serviceMock{trustedByFn: func(ctx context.Context, granteeID int64) ([]*domain.Contact, error) {
+			return []*domain.Contact{
+				{ID: 1, GrantorName: "alice", Wait: time.Hour, Status: domain.StatusRequested, RequestedAt: requestedAt},
+				{ID: 2, GrantorName: "carol", Wait: 24 * time.Hour, Status: domain.StatusRequested, RequestedAt: requestedAt},
+			}, nil
+		}})
+
+		rr := httptest.NewRecorder()
+		h.TrustedBy(rr, withUser(httptest.NewRequest(http.MethodGet, "/granted", nil), 2))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d", http.StatusOK, rr.Code)
+		}
+
+		var out []Contact
+		if err := json.Unmarshal(rr.Body.Bytes(), &out); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(out) != 2 || !out[0].Granted || out[1].Granted || out[1].AccessAt == nil {
+			t.Fatalf("unexpected contacts: %+v", out)
+		}
+	})
+}
+
+func TestHttpHandler_RequestAccess(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("invalid id -> 400", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		New(&serviceMock{}).RequestAccess(rr, withUser(newChiReq(http.MethodPost, "/x/request", nil, "id", "x"), 2))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+	})
+
+	t.Run("already requested -> 409", func(t *testing.T) {
+		h := New(&serviceMock{requestFn: func(ctx context.Context, granteeID, contactID int64) (*domain.Contact, error) {
+			return nil, domain.ErrAlreadyRequested
+		}})
+
+		rr := httptest.NewRecorder()
+		h.RequestAccess(rr, withUser(newChiReq(http.MethodPost, "/3/request", nil, "id", "3"), 2))
+
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("expected %d, got %d", http.StatusConflict, rr.Code)
+		}
+	})
+
+	t.Run("ok -> 200", func(t *testing.T) {
+		var gotGrantee, gotContact int64
+		h := New(&serviceMock{requestFn: func(ctx context.Context, granteeID, contactID int64) (*domain.Contact, error) {
+			gotGrantee, gotContact = granteeID, contactID
+			return &domain.Contact{ID: contactID, Wait: time.Hour, Status: domain.StatusRequested, RequestedAt: time.Now()}, nil
+		}})
+
+		rr := httptest.NewRecorder()
+		h.RequestAccess(rr, withUser(newChiReq(http.MethodPost, "/3/request", nil, "id", "3"), 2))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d", http.StatusOK, rr.Code)
+		}
+		if gotGrantee != 2 || gotContact != 3 {
+			t.Fatalf("unexpected args: grantee=%d contact=%d", gotGrantee, gotContact)
+		}
+	})
+}
+
+func TestHttpHandler_Reject(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	h := New(&serviceMock{rejectFn: func(ctx context.Context, grantorID, contactID int64) (*domain.Contact, error) {
+		return nil, domain.ErrNotRequested
+	}})
+
+	rr := httptest.NewRecorder()
+	h.Reject(rr, withUser(newChiReq(http.MethodPost, "/3/reject", nil, "id", "3"), 1))
+
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected %d, got %d", http.StatusConflict, rr.Code)
+	}
+}
+
+func TestHttpHandler_RemoveContact(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	h := New(&serviceMock{removeFn: func(ctx context.Context, userID, contactID int64) error {
+		return nil
+	}})
+
+	rr := httptest.NewRecorder()
+	h.RemoveContact(rr, withUser(newChiReq(http.MethodDelete, "/3", nil, "id", "3"), 1))
+
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected %d, got %d", http.StatusNoContent, rr.Code)
+	}
+}
+
+func TestHttpHandler_Vault(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("waiting period running -> 403", func(t *testing.T) {
+		h := New(&serviceMock{vaultFn: func(ctx context.Context, granteeID, contactID int64) ([]*domain.Item, error) {
+			return nil, domain.ErrAccessNotGranted
+		}})
+
+		rr := httptest.NewRecorder()
+		h.Vault(rr, withUser(newChiReq(http.MethodGet, "/3/vault", nil, "id", "3"), 2))
+
+		if rr.Code != http.StatusForbidden {
+			t.Fatalf("expected %d, got %d", http.StatusForbidden, rr.Code)
+		}
+	})
+
+	t.Run("ok -> 200", func(t *testing.T) {
+		h := New(&serviceMock{vaultFn: func(ctx context.Context, granteeID, contactID int64) ([]*domain.Item, error) {
+			return []*domain.Item{{Type: "account", ID: 4, Title: "github"}}, nil
+		}})
+
+		rr := httptest.NewRecorder()
+		h.Vault(rr, withUser(newChiReq(http.MethodGet, "/3/vault", nil, "id", "3"), 2))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d", http.StatusOK, rr.Code)
+		}
+
+		var out []Item
+		if err := json.Unmarshal(rr.Body.Bytes(), &out); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(out) != 1 || out[0].Title != "github" {
+			t.Fatalf("unexpected items: %+v", out)
+		}
+	})
+}
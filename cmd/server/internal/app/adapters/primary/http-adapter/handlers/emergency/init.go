@@ -0,0 +1,46 @@
+package emergency
+
+import (
+	"context"
+	domain "server/internal/app/domain/emergency"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type service interface {
+	AddContact(ctx context.Context, grantorID int64, username, accessType string, wait time.Duration) (*domain.Contact, error)
+	Trusted(ctx context.Context, grantorID int64) ([]*domain.Contact, error)
+	TrustedBy(ctx context.Context, granteeID int64) ([]*domain.Contact, error)
+	Remove(ctx context.Context, userID, contactID int64) error
+	RequestAccess(ctx context.Context, granteeID, contactID int64) (*domain.Contact, error)
+	Approve(ctx context.Context, grantorID, contactID int64) (*domain.Contact, error)
+	Reject(ctx context.Context, grantorID, contactID int64) (*domain.Contact, error)
+	Vault(ctx context.Context, granteeID, contactID int64) ([]*domain.Item, error)
+}
+
+type HttpHandler struct {
+	service service
+}
+
+func New(service service) *HttpHandler {
+	return &HttpHandler{
+		service: service,
+	}
+}
+
+// Routes of emergency access, /trusted are contacts of the caller, /granted are users who trust the caller
+func (h *HttpHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Get("/trusted", h.Trusted)
+	router.Get("/granted", h.TrustedBy)
+	router.Post("/", h.AddContact)
+	router.Delete("/{id}", h.RemoveContact)
+	router.Post("/{id}/request", h.RequestAccess)
+	router.Post("/{id}/approve", h.Approve)
+	router.Post("/{id}/reject", h.Reject)
+	router.Get("/{id}/vault", h.Vault)
+
+	return router
+}
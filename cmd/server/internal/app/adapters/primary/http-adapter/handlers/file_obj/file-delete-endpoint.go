@@ -0,0 +1,46 @@
+package file_obj
+
+import (
+	"errors"
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	"strconv"
+
+	domain "server/internal/app/domain/file_obj"
+	"server/internal/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+func (h *FileHandler) DeleteByID(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "DeleteFileByID"
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	if err := h.uc.DeleteFile(r.Context(), userId, id); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrFileNotFound), errors.Is(err, domain.ErrInvalidUserID):
+			codec.WriteErrorJSON(w, http.StatusNotFound, "file not found")
+		case errors.Is(err, domain.ErrInvalidFileID):
+			codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid id")
+		default:
+			logger.Log.Error(HandlerName, zap.Error(err))
+			codec.WriteErrorJSON(w, http.StatusInternalServerError, "internal error")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
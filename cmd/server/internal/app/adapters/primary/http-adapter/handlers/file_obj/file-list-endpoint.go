@@ -6,11 +6,14 @@ import (
 	"server/internal/app/adapters/primary/http-adapter/codec"
 	"server/internal/app/adapters/primary/http-adapter/constants"
 	domain "server/internal/app/domain/file_obj"
+	"time"
 )
 
 type fileResponse struct {
 	ID    int64  `json:"id"`
 	Title string `json:"title"`
+
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 }
 
 func (h *FileHandler) ListByUserID(w http.ResponseWriter, r *http.Request) {
@@ -39,6 +42,8 @@ func (h *FileHandler) ListByUserID(w http.ResponseWriter, r *http.Request) {
 			fileResponse{
 				ID:    f.ID,
 				Title: f.Title,
+
+				ExpiresAt: codec.OptionalTime(f.ExpiresAt),
 			})
 	}
 
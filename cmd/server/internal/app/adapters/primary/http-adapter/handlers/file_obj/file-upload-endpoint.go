@@ -7,6 +7,7 @@ import (
 	"server/internal/app/adapters/primary/http-adapter/constants"
 	"server/internal/app/config"
 	domain "server/internal/app/domain/file_obj"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -37,6 +38,14 @@ func (h *FileHandler) Create(w http.ResponseWriter, r *http.Request) {
 	title := r.FormValue("title")
 	objectKey := uuid.New().String()
 
+	var expiresAt time.Time
+	if v := r.FormValue("expires_at"); v != "" {
+		if expiresAt, err = time.Parse(time.RFC3339, v); err != nil {
+			codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid expires_at, RFC 3339 expected")
+			return
+		}
+	}
+
 	if title == "" {
 		title = fileHeader.Filename
 	}
@@ -82,6 +91,7 @@ func (h *FileHandler) Create(w http.ResponseWriter, r *http.Request) {
 		codec.WriteErrorJSON(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	f.ExpiresAt = expiresAt
 
 	fileBytes, err := io.ReadAll(file)
 	if err != nil {
@@ -14,6 +14,7 @@ type Service interface {
 	GetFileList(ctx context.Context, userID int64) ([]*domain.File, error)
 	UploadAndCreate(ctx context.Context, file *domain.File, data []byte) (int64, error)
 	GetByID(ctx context.Context, fileID int64) (*domain.File, error)
+	DeleteFile(ctx context.Context, userID, fileID int64) error
 }
 
 type FileHandler struct {
@@ -30,6 +31,7 @@ func (h *FileHandler) Routes() http.Handler {
 	r.Get("/download/{id}", h.DownloadByID)
 	r.Get("/list/", h.ListByUserID)
 	r.Post("/upload", h.Create)
+	r.Delete("/delete/{id}", h.DeleteByID)
 
 	return r
 }
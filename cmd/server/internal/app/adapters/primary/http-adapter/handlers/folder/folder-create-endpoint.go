@@ -0,0 +1,44 @@
+package folder
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/folder_usecase"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type CreateFolderRequest struct {
+	Name     string `json:"name"`
+	ParentID *int64 `json:"parent_id"`
+}
+
+func (h *HttpHandler) CreateFolder(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "CreateFolder"
+
+	req := new(CreateFolderRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "json decode error")
+		return
+	}
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	f, err := h.service.CreateFolder(r.Context(), userId, req.ParentID, req.Name)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, fromDomain(f))
+}
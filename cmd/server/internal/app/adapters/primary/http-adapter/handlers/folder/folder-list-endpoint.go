@@ -0,0 +1,124 @@
+package folder
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/folder_usecase"
+	domain "server/internal/app/domain/folder"
+	"server/internal/pkg/logger"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type Folder struct {
+	ID        int64     `json:"folder_id"`
+	ParentID  *int64    `json:"parent_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Item struct {
+	Type  string `json:"type"`
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+// ContentsResponse is a folder listing, Folder is null for the root
+type ContentsResponse struct {
+	Folder  *Folder  `json:"folder"`
+	Folders []Folder `json:"folders"`
+	Items   []Item   `json:"items"`
+}
+
+func (h *HttpHandler) ListFolders(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "ListFolders"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	list, err := h.service.ListFolders(r.Context(), userId)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, fromDomainList(list))
+}
+
+func (h *HttpHandler) GetContents(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "GetContents"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	// /root has no id
+	var folderID *int64
+	if raw := chi.URLParam(r, "id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid folder id")
+			return
+		}
+		folderID = &id
+	}
+
+	c, err := h.service.GetContents(r.Context(), userId, folderID)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	resp := ContentsResponse{
+		Folders: fromDomainList(c.Folders),
+		Items:   make([]Item, 0, len(c.Items)),
+	}
+	if c.Folder != nil {
+		f := fromDomain(c.Folder)
+		resp.Folder = &f
+	}
+	for _, it := range c.Items {
+		resp.Items = append(resp.Items, Item{Type: it.Type, ID: it.ID, Title: it.Title})
+	}
+
+	codec.WriteJSON(w, http.StatusOK, resp)
+}
+
+// help func
+
+func fromDomain(f *domain.Folder) Folder {
+	return Folder{
+		ID:        f.ID,
+		ParentID:  f.ParentID,
+		Name:      f.Name,
+		CreatedAt: f.CreatedAt,
+	}
+}
+
+func fromDomainList(list []*domain.Folder) []Folder {
+	out := make([]Folder, 0, len(list))
+	for _, f := range list {
+		out = append(out, fromDomain(f))
+	}
+	return out
+}
+
+func idFromURL(r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	return id, err == nil
+}
@@ -0,0 +1,52 @@
+package folder
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/folder_usecase"
+	domain "server/internal/app/domain/folder"
+	"server/internal/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type MoveItemRequest struct {
+	FolderID *int64 `json:"folder_id"`
+}
+
+// MoveItem places an object of any type into a folder, null folder_id moves it to the root
+func (h *HttpHandler) MoveItem(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "MoveItem"
+
+	req := new(MoveItemRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "json decode error")
+		return
+	}
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	id, ok := idFromURL(r)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid item id")
+		return
+	}
+
+	item := domain.Item{Type: chi.URLParam(r, "type"), ID: id}
+	if err := h.service.MoveItem(r.Context(), userId, item, req.FolderID); err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
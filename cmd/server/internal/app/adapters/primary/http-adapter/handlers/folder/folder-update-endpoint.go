@@ -0,0 +1,112 @@
+package folder
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/folder_usecase"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type RenameFolderRequest struct {
+	Name string `json:"name"`
+}
+
+type MoveFolderRequest struct {
+	ParentID *int64 `json:"parent_id"`
+}
+
+func (h *HttpHandler) RenameFolder(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "RenameFolder"
+
+	req := new(RenameFolderRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "json decode error")
+		return
+	}
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	id, ok := idFromURL(r)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid folder id")
+		return
+	}
+
+	f, err := h.service.RenameFolder(r.Context(), userId, id, req.Name)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, fromDomain(f))
+}
+
+func (h *HttpHandler) MoveFolder(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "MoveFolder"
+
+	req := new(MoveFolderRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "json decode error")
+		return
+	}
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	id, ok := idFromURL(r)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid folder id")
+		return
+	}
+
+	f, err := h.service.MoveFolder(r.Context(), userId, id, req.ParentID)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, fromDomain(f))
+}
+
+func (h *HttpHandler) DeleteFolder(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "DeleteFolder"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	id, ok := idFromURL(r)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid folder id")
+		return
+	}
+
+	if err := h.service.DeleteFolder(r.Context(), userId, id); err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,293 @@
+package folder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	domain "server/internal/app/domain/folder"
+	"server/internal/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type serviceMock struct {
+	listFn     func(ctx context.Context, userID int64) ([]*domain.Folder, error)
+	contentsFn func(ctx context.Context, userID int64, folderID *int64) (*domain.Contents, error)
+	createFn   func(ctx context.Context, userID int64, parentID *int64, name string) (*domain.Folder, error)
+	renameFn   func(ctx context.Context, userID, folderID int64, name string) (*domain.Folder, error)
+	moveFn     func(ctx context.Context, userID, folderID int64, parentID *int64) (*domain.Folder, error)
+	deleteFn   func(ctx context.Context, userID, folderID int64) error
+	moveItemFn func(ctx context.Context, userID int64, item domain.Item, folderID *int64) error
+}
+
+func (m *serviceMock) ListFolders(ctx context.Context, userID int64) ([]*domain.Folder, error) {
+	if m.listFn == nil {
+		return nil, errors.New("ListFolders not stubbed")
+	}
+	return m.listFn(ctx, userID)
+}
+
+func (m *serviceMock) GetContents(ctx context.Context, userID int64, folderID *int64) (*domain.Contents, error) {
+	if m.contentsFn == nil {
+		return nil, errors.New("GetContents not stubbed")
+	}
+	return m.contentsFn(ctx, userID, folderID)
+}
+
+func (m *serviceMock) CreateFolder(ctx context.Context, userID int64, parentID *int64, name string) (*domain.Folder, error) {
+	if m.createFn == nil {
+		return nil, errors.New("CreateFolder not stubbed")
+	}
+	return m.createFn(ctx, userID, parentID, name)
+}
+
+func (m *serviceMock) RenameFolder(ctx context.Context, userID, folderID int64, name string) (*domain.Folder, error) {
+	if m.renameFn == nil {
+		return nil, errors.New("RenameFolder not stubbed")
+	}
+	return m.renameFn(ctx, userID, folderID, name)
+}
+
+func (m *serviceMock) MoveFolder(ctx context.Context, userID, folderID int64, parentID *int64) (*domain.Folder, error) {
+	if m.moveFn == nil {
+		return nil, errors.New("MoveFolder not stubbed")
+	}
+	return m.moveFn(ctx, userID, folderID, parentID)
+}
+
+func (m *serviceMock) DeleteFolder(ctx context.Context, userID, folderID int64) error {
+	if m.deleteFn == nil {
+		return errors.New("DeleteFolder not stubbed")
+	}
+	return m.deleteFn(ctx, userID, folderID)
+}
+
+func (m *serviceMock) MoveItem(ctx context.Context, userID int64, item domain.Item, folderID *int64) error {
+	if m.moveItemFn == nil {
+		return errors.New("MoveItem not stubbed")
+	}
+	return m.moveItemFn(ctx, userID, item, folderID)
+}
+
+func withUser(r *http.Request, id int64) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), constants.UserIDKey, id))
+}
+
+// newChiReq builds a request with URL params given as key, value pairs
+func newChiReq(method, path string, body io.Reader, params ...string) *http.Request {
+	req := httptest.NewRequest(method, path, body)
+
+	rctx := chi.NewRouteContext()
+	for i := 0; i+1 < len(params); i += 2 {
+		rctx.URLParams.Add(params[i], params[i+1])
+	}
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHttpHandler_ListFolders(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("no user -> 422", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		New(&serviceMock{}).ListFolders(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d", http.StatusUnprocessableEntity, rr.Code)
+		}
+	})
+
+	t.Run("empty -> 204", func(t *testing.T) {
+		h := New(&serviceMock{listFn: func(ctx context.Context, userID int64) ([]*domain.Folder, error) {
+			return nil, domain.ErrEmptyFolders
+		}})
+
+		rr := httptest.NewRecorder()
+		h.ListFolders(rr, withUser(httptest.NewRequest(http.MethodGet, "/", nil), 7))
+
+		if rr.Code != http.StatusNoContent {
+			t.Fatalf("expected %d, got %d", http.StatusNoContent, rr.Code)
+		}
+	})
+
+	t.Run("ok -> folders with parents", func(t *testing.T) {
+		parent := int64(1)
+		h := New(&serviceMock{listFn: func(ctx context.Context, userID int64) ([]*domain.Folder, error) {
+			return []*domain.Folder{{ID: 1, Name: "work"}, {ID: 2, ParentID: &parent, Name: "servers"}}, nil
+		}})
+
+		rr := httptest.NewRecorder()
+		h.ListFolders(rr, withUser(httptest.NewRequest(http.MethodGet, "/", nil), 7))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d", http.StatusOK, rr.Code)
+		}
+
+		var resp []Folder
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(resp) != 2 || resp[0].ParentID != nil || resp[1].ParentID == nil || *resp[1].ParentID != 1 {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+	})
+}
+
+func TestHttpHandler_GetContents(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("root -> nil folder id", func(t *testing.T) {
+		called := false
+		h := New(&serviceMock{contentsFn: func(ctx context.Context, userID int64, folderID *int64) (*domain.Contents, error) {
+			called = true
+			if folderID != nil {
+				t.Fatalf("expected root, got folder %d", *folderID)
+			}
+			return &domain.Contents{Items: []domain.Item{{Type: domain.ItemCert, ID: 3, Title: "api"}}}, nil
+		}})
+
+		rr := httptest.NewRecorder()
+		h.GetContents(rr, withUser(newChiReq(http.MethodGet, "/root", nil), 7))
+
+		if rr.Code != http.StatusOK || !called {
+			t.Fatalf("expected %d, got %d", http.StatusOK, rr.Code)
+		}
+		if !strings.Contains(rr.Body.String(), `"folder":null`) || !strings.Contains(rr.Body.String(), `"type":"cert"`) {
+			t.Fatalf("unexpected body: %s", rr.Body.String())
+		}
+	})
+
+	t.Run("invalid id -> 400", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		New(&serviceMock{}).GetContents(rr, withUser(newChiReq(http.MethodGet, "/x", nil, "id", "x"), 7))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+	})
+
+	t.Run("foreign folder -> 404", func(t *testing.T) {
+		h := New(&serviceMock{contentsFn: func(ctx context.Context, userID int64, folderID *int64) (*domain.Contents, error) {
+			return nil, domain.ErrFolderNotFound
+		}})
+
+		rr := httptest.NewRecorder()
+		h.GetContents(rr, withUser(newChiReq(http.MethodGet, "/9", nil, "id", "9"), 7))
+
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+}
+
+func TestHttpHandler_CreateFolder(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("bad json -> 422", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		New(&serviceMock{}).CreateFolder(rr, withUser(httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{")), 7))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d", http.StatusUnprocessableEntity, rr.Code)
+		}
+	})
+
+	t.Run("duplicate -> 409", func(t *testing.T) {
+		var gotParent *int64
+		h := New(&serviceMock{createFn: func(ctx context.Context, userID int64, parentID *int64, name string) (*domain.Folder, error) {
+			gotParent = parentID
+			return nil, domain.ErrFolderExists
+		}})
+
+		body, _ := json.Marshal(CreateFolderRequest{Name: "servers", ParentID: func() *int64 { v := int64(1); return &v }()})
+
+		rr := httptest.NewRecorder()
+		h.CreateFolder(rr, withUser(httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)), 7))
+
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("expected %d, got %d", http.StatusConflict, rr.Code)
+		}
+		if gotParent == nil || *gotParent != 1 {
+			t.Fatalf("parent not passed to service: %v", gotParent)
+		}
+	})
+}
+
+func TestHttpHandler_MoveFolder(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	h := New(&serviceMock{moveFn: func(ctx context.Context, userID, folderID int64, parentID *int64) (*domain.Folder, error) {
+		return nil, domain.ErrFolderCycle
+	}})
+
+	rr := httptest.NewRecorder()
+	h.MoveFolder(rr, withUser(newChiReq(http.MethodPut, "/1/move", strings.NewReader(`{"parent_id":3}`), "id", "1"), 7))
+
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected %d, got %d", http.StatusConflict, rr.Code)
+	}
+}
+
+func TestHttpHandler_DeleteFolder(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	var deleted int64
+	h := New(&serviceMock{deleteFn: func(ctx context.Context, userID, folderID int64) error {
+		deleted = folderID
+		return nil
+	}})
+
+	rr := httptest.NewRecorder()
+	h.DeleteFolder(rr, withUser(newChiReq(http.MethodDelete, "/2", nil, "id", "2"), 7))
+
+	if rr.Code != http.StatusNoContent || deleted != 2 {
+		t.Fatalf("unexpected result: code=%d deleted=%d", rr.Code, deleted)
+	}
+}
+
+func TestHttpHandler_MoveItem(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("null folder -> moved to root", func(t *testing.T) {
+		var (
+			gotItem   domain.Item
+			gotFolder = new(int64)
+		)
+		h := New(&serviceMock{moveItemFn: func(ctx context.Context, userID int64, item domain.Item, folderID *int64) error {
+			gotItem, gotFolder = item, folderID
+			return nil
+		}})
+
+		rr := httptest.NewRecorder()
+		h.MoveItem(rr, withUser(newChiReq(http.MethodPut, "/item/ssh/4", strings.NewReader(`{"folder_id":null}`), "type", "ssh", "id", "4"), 7))
+
+		if rr.Code != http.StatusNoContent {
+			t.Fatalf("expected %d, got %d", http.StatusNoContent, rr.Code)
+		}
+		if gotItem != (domain.Item{Type: domain.ItemSSHKey, ID: 4}) || gotFolder != nil {
+			t.Fatalf("unexpected call: %+v, %v", gotItem, gotFolder)
+		}
+	})
+
+	t.Run("unknown type -> 400", func(t *testing.T) {
+		h := New(&serviceMock{moveItemFn: func(ctx context.Context, userID int64, item domain.Item, folderID *int64) error {
+			return domain.ErrInvalidItem
+		}})
+
+		rr := httptest.NewRecorder()
+		h.MoveItem(rr, withUser(newChiReq(http.MethodPut, "/item/users/4", strings.NewReader(`{"folder_id":1}`), "type", "users", "id", "4"), 7))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+	})
+}
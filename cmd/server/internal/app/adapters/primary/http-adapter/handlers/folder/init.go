@@ -0,0 +1,47 @@
+package folder
+
+import (
+	"context"
+	domain "server/internal/app/domain/folder"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type service interface {
+	ListFolders(ctx context.Context, userID int64) ([]*domain.Folder, error)
+	GetContents(ctx context.Context, userID int64, folderID *int64) (*domain.Contents, error)
+	CreateFolder(ctx context.Context, userID int64, parentID *int64, name string) (*domain.Folder, error)
+	RenameFolder(ctx context.Context, userID, folderID int64, name string) (*domain.Folder, error)
+	MoveFolder(ctx context.Context, userID, folderID int64, parentID *int64) (*domain.Folder, error)
+	DeleteFolder(ctx context.Context, userID, folderID int64) error
+	MoveItem(ctx context.Context, userID int64, item domain.Item, folderID *int64) error
+}
+
+type HttpHandler struct {
+	service service
+}
+
+func New(service service) *HttpHandler {
+	return &HttpHandler{
+		service: service,
+	}
+}
+
+// Routes of user folders, /root and /{id} list a folder with objects of all types,
+// null parent_id and folder_id point to the root
+func (h *HttpHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Get("/", h.ListFolders)
+	router.Post("/", h.CreateFolder)
+
+	router.Get("/root", h.GetContents)
+	router.Get("/{id}", h.GetContents)
+	router.Put("/{id}", h.RenameFolder)
+	router.Put("/{id}/move", h.MoveFolder)
+	router.Delete("/{id}", h.DeleteFolder)
+
+	router.Put("/item/{type}/{id}", h.MoveItem)
+
+	return router
+}
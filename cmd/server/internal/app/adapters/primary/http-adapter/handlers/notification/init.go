@@ -0,0 +1,36 @@
+package notification
+
+import (
+	"context"
+	domain "server/internal/app/domain/notification"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type service interface {
+	GetNotifications(ctx context.Context, userId int64, unreadOnly bool) ([]*domain.Notification, error)
+	CountUnread(ctx context.Context, userId int64) (int, error)
+	MarkRead(ctx context.Context, userId, notificationId int64) error
+	MarkAllRead(ctx context.Context, userId int64) (int, error)
+}
+
+type HttpHandler struct {
+	service service
+}
+
+func New(service service) *HttpHandler {
+	return &HttpHandler{
+		service: service,
+	}
+}
+
+func (h *HttpHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Get("/", h.GetNotifications)
+	router.Get("/unread", h.CountUnread)
+	router.Post("/read/{id}", h.MarkRead)
+	router.Post("/read", h.MarkAllRead)
+
+	return router
+}
@@ -0,0 +1,36 @@
+package notification
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/notification_usecase"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type UnreadCountResponse struct {
+	Unread int `json:"unread"`
+}
+
+func (h *HttpHandler) CountUnread(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "CountUnreadNotifications"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	count, err := h.service.CountUnread(r.Context(), userId)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, UnreadCountResponse{Unread: count})
+}
@@ -0,0 +1,62 @@
+package notification
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/notification_usecase"
+	"server/internal/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Notification struct {
+	ID         int64     `json:"id"`
+	ObjectType string    `json:"object_type"`
+	ObjectID   int64     `json:"object_id"`
+	Title      string    `json:"title"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Expired    bool      `json:"expired"`
+	Read       bool      `json:"read"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// GetNotifications lists the reminders of the user, ?unread=true hides the read ones
+func (h *HttpHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "GetNotifications"
+	resp := make([]Notification, 0)
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	list, err := h.service.GetNotifications(r.Context(), userId, unreadOnly)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	now := time.Now()
+	for _, item := range list {
+		resp = append(resp, Notification{
+			ID:         item.ID,
+			ObjectType: item.ObjectType,
+			ObjectID:   item.ObjectID,
+			Title:      item.Title,
+			ExpiresAt:  item.ExpiresAt,
+			Expired:    item.Expired(now),
+			Read:       item.Read(),
+			CreatedAt:  item.CreatedAt,
+		})
+	}
+
+	codec.WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,183 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	domain "server/internal/app/domain/notification"
+	"server/internal/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type serviceMock struct {
+	getNotificationsFn func(ctx context.Context, userId int64, unreadOnly bool) ([]*domain.Notification, error)
+	countUnreadFn      func(ctx context.Context, userId int64) (int, error)
+	markReadFn         func(ctx context.Context, userId, notificationId int64) error
+	markAllReadFn      func(ctx context.Context, userId int64) (int, error)
+}
+
+func (m *serviceMock) GetNotifications(ctx context.Context, userId int64, unreadOnly bool) ([]*domain.Notification, error) {
+	if m.getNotificationsFn == nil {
+		return nil, errors.New("GetNotifications not stubbed")
+	}
+	return m.getNotificationsFn(ctx, userId, unreadOnly)
+}
+
+func (m *serviceMock) CountUnread(ctx context.Context, userId int64) (int, error) {
+	if m.countUnreadFn == nil {
+		return 0, errors.New("CountUnread not stubbed")
+	}
+	return m.countUnreadFn(ctx, userId)
+}
+
+func (m *serviceMock) MarkRead(ctx context.Context, userId, notificationId int64) error {
+	if m.markReadFn == nil {
+		return errors.New("MarkRead not stubbed")
+	}
+	return m.markReadFn(ctx, userId, notificationId)
+}
+
+func (m *serviceMock) MarkAllRead(ctx context.Context, userId int64) (int, error) {
+	if m.markAllReadFn == nil {
+		return 0, errors.New("MarkAllRead not stubbed")
+	}
+	return m.markAllReadFn(ctx, userId)
+}
+
+func withUser(r *http.Request, id int64) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), constants.UserIDKey, id))
+}
+
+func newChiReq(method, path, routePattern, paramKey, paramValue string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+
+	rctx := chi.NewRouteContext()
+	rctx.RoutePatterns = append(rctx.RoutePatterns, routePattern)
+	rctx.URLParams.Add(paramKey, paramValue)
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHttpHandler_GetNotifications(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("missing userID in context -> 422", func(t *testing.T) {
+		h := New(&serviceMock{})
+
+		rr := httptest.NewRecorder()
+		h.GetNotifications(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("service error -> mapped", func(t *testing.T) {
+		h := New(&serviceMock{
+			getNotificationsFn: func(ctx context.Context, userId int64, unreadOnly bool) ([]*domain.Notification, error) {
+				return nil, domain.ErrFailedGetNotifications
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.GetNotifications(rr, withUser(httptest.NewRequest(http.MethodGet, "/", nil), 1))
+
+		if rr.Code != http.StatusInternalServerError {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusInternalServerError, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("empty inbox -> 200 + []", func(t *testing.T) {
+		h := New(&serviceMock{
+			getNotificationsFn: func(ctx context.Context, userId int64, unreadOnly bool) ([]*domain.Notification, error) {
+				return nil, nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.GetNotifications(rr, withUser(httptest.NewRequest(http.MethodGet, "/", nil), 1))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+		if body := rr.Body.String(); body != "[]\n" {
+			t.Fatalf("expected empty json array, got %q", body)
+		}
+	})
+
+	t.Run("ok -> 200 + unread filter + expired flag", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour)
+		future := time.Now().Add(48 * time.Hour)
+
+		var gotUnread bool
+		h := New(&serviceMock{
+			getNotificationsFn: func(ctx context.Context, userId int64, unreadOnly bool) ([]*domain.Notification, error) {
+				if userId != 3 {
+					t.Fatalf("expected userId=3, got %d", userId)
+				}
+				gotUnread = unreadOnly
+				return []*domain.Notification{
+					{ID: 1, ObjectType: domain.ObjectBankCard, ObjectID: 5, Title: "maib", ExpiresAt: past},
+					{ID: 2, ObjectType: domain.ObjectText, ObjectID: 6, Title: "token", ExpiresAt: future, ReadAt: past},
+				}, nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.GetNotifications(rr, withUser(httptest.NewRequest(http.MethodGet, "/?unread=true", nil), 3))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+		if !gotUnread {
+			t.Fatalf("expected unread filter to be passed")
+		}
+
+		var resp []Notification
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		if len(resp) != 2 {
+			t.Fatalf("expected 2 items, got %d", len(resp))
+		}
+		if resp[0].ObjectType != "card" || resp[0].ObjectID != 5 || !resp[0].Expired || resp[0].Read {
+			t.Fatalf("unexpected item[0]: %+v", resp[0])
+		}
+		if resp[1].Expired || !resp[1].Read {
+			t.Fatalf("unexpected item[1]: %+v", resp[1])
+		}
+	})
+}
+
+func TestHttpHandler_CountUnread(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	h := New(&serviceMock{
+		countUnreadFn: func(ctx context.Context, userId int64) (int, error) {
+			return 4, nil
+		},
+	})
+
+	rr := httptest.NewRecorder()
+	h.CountUnread(rr, withUser(httptest.NewRequest(http.MethodGet, "/unread", nil), 1))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d, body=%s", rr.Code, rr.Body.String())
+	}
+
+	var resp UnreadCountResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if resp.Unread != 4 {
+		t.Fatalf("expected unread=4, got %d", resp.Unread)
+	}
+}
@@ -0,0 +1,107 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "server/internal/app/domain/notification"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+func TestHttpHandler_MarkRead(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("invalid id -> 400", func(t *testing.T) {
+		h := New(&serviceMock{
+			markReadFn: func(ctx context.Context, userId, notificationId int64) error {
+				t.Fatalf("service must NOT be called on invalid id")
+				return nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.MarkRead(rr, withUser(newChiReq(http.MethodPost, "/read/abc", "/read/{id}", "id", "abc"), 1))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("not found -> 404", func(t *testing.T) {
+		h := New(&serviceMock{
+			markReadFn: func(ctx context.Context, userId, notificationId int64) error {
+				return domain.ErrNotificationNotFound
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.MarkRead(rr, withUser(newChiReq(http.MethodPost, "/read/9", "/read/{id}", "id", "9"), 1))
+
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusNotFound, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 200, user and id passed", func(t *testing.T) {
+		var gotUser, gotID int64
+		h := New(&serviceMock{
+			markReadFn: func(ctx context.Context, userId, notificationId int64) error {
+				gotUser, gotID = userId, notificationId
+				return nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.MarkRead(rr, withUser(newChiReq(http.MethodPost, "/read/9", "/read/{id}", "id", "9"), 2))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+		if gotUser != 2 || gotID != 9 {
+			t.Fatalf("unexpected args: user=%d id=%d", gotUser, gotID)
+		}
+	})
+}
+
+func TestHttpHandler_MarkAllRead(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("missing userID in context -> 422", func(t *testing.T) {
+		h := New(&serviceMock{})
+
+		rr := httptest.NewRecorder()
+		h.MarkAllRead(rr, httptest.NewRequest(http.MethodPost, "/read", nil))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 200 + marked count", func(t *testing.T) {
+		h := New(&serviceMock{
+			markAllReadFn: func(ctx context.Context, userId int64) (int, error) {
+				return 3, nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.MarkAllRead(rr, withUser(httptest.NewRequest(http.MethodPost, "/read", nil), 1))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+
+		var resp MarkReadResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		if resp.Marked != 3 {
+			t.Fatalf("expected marked=3, got %d", resp.Marked)
+		}
+	})
+}
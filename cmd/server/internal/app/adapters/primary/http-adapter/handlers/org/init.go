@@ -0,0 +1,45 @@
+package org
+
+import (
+	"context"
+	domain "server/internal/app/domain/org"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type service interface {
+	CreateOrg(ctx context.Context, userID int64, name string) (*domain.Org, error)
+	ListOrgs(ctx context.Context, userID int64) ([]*domain.Org, error)
+	DeleteOrg(ctx context.Context, userID, orgID int64) error
+	Members(ctx context.Context, userID, orgID int64) ([]*domain.Member, error)
+	Items(ctx context.Context, userID, orgID int64) ([]*domain.Item, error)
+	AddMember(ctx context.Context, userID, orgID int64, username, role string) error
+	ChangeRole(ctx context.Context, userID, orgID, memberID int64, role string) error
+	RemoveMember(ctx context.Context, userID, orgID, memberID int64) error
+}
+
+type HttpHandler struct {
+	service service
+}
+
+func New(service service) *HttpHandler {
+	return &HttpHandler{
+		service: service,
+	}
+}
+
+// Routes of organizations, members are managed under /{id}/members
+func (h *HttpHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Get("/", h.ListOrgs)
+	router.Post("/", h.CreateOrg)
+	router.Delete("/{id}", h.DeleteOrg)
+	router.Get("/{id}/items", h.Items)
+	router.Get("/{id}/members", h.Members)
+	router.Post("/{id}/members", h.AddMember)
+	router.Put("/{id}/members/{user_id}", h.ChangeRole)
+	router.Delete("/{id}/members/{user_id}", h.RemoveMember)
+
+	return router
+}
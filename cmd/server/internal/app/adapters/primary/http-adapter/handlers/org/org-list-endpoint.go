@@ -0,0 +1,138 @@
+package org
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/org_usecase"
+	domain "server/internal/app/domain/org"
+	"server/internal/pkg/logger"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type Org struct {
+	OrgID     int64     `json:"org_id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Member struct {
+	UserID    int64     `json:"user_id"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Item struct {
+	Type  string `json:"type"`
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+// ListOrgs lists organizations of the caller with the role the caller has in each
+func (h *HttpHandler) ListOrgs(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "ListOrgs"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	list, err := h.service.ListOrgs(r.Context(), userId)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	out := make([]Org, 0, len(list))
+	for _, o := range list {
+		out = append(out, fromDomain(o))
+	}
+
+	codec.WriteJSON(w, http.StatusOK, out)
+}
+
+func (h *HttpHandler) Members(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "Members"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	orgId, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid organization id")
+		return
+	}
+
+	list, err := h.service.Members(r.Context(), userId, orgId)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	out := make([]Member, 0, len(list))
+	for _, m := range list {
+		out = append(out, Member{UserID: m.UserID, Username: m.Username, Role: m.Role, CreatedAt: m.CreatedAt})
+	}
+
+	codec.WriteJSON(w, http.StatusOK, out)
+}
+
+// Items lists accounts, cards and texts owned by the organization
+func (h *HttpHandler) Items(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "Items"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	orgId, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid organization id")
+		return
+	}
+
+	list, err := h.service.Items(r.Context(), userId, orgId)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	out := make([]Item, 0, len(list))
+	for _, it := range list {
+		out = append(out, Item{Type: it.Type, ID: it.ID, Title: it.Title})
+	}
+
+	codec.WriteJSON(w, http.StatusOK, out)
+}
+
+// help func
+
+func fromDomain(o *domain.Org) Org {
+	return Org{
+		OrgID:     o.ID,
+		Name:      o.Name,
+		Role:      o.Role,
+		CreatedAt: o.CreatedAt,
+	}
+}
@@ -0,0 +1,280 @@
+package org
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	domain "server/internal/app/domain/org"
+	"server/internal/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type serviceMock struct {
+	createFn  func(ctx context.Context, userID int64, name string) (*domain.Org, error)
+	listFn    func(ctx context.Context, userID int64) ([]*domain.Org, error)
+	deleteFn  func(ctx context.Context, userID, orgID int64) error
+	membersFn func(ctx context.Context, userID, orgID int64) ([]*domain.Member, error)
+	itemsFn   func(ctx context.Context, userID, orgID int64) ([]*domain.Item, error)
+	addFn     func(ctx context.Context, userID, orgID int64, username, role string) error
+	roleFn    func(ctx context.Context, userID, orgID, memberID int64, role string) error
+	removeFn  func(ctx context.Context, userID, orgID, memberID int64) error
+}
+
+func (m *serviceMock) CreateOrg(ctx context.Context, userID int64, name string) (*domain.Org, error) {
+	if m.createFn == nil {
+		return nil, errors.New("CreateOrg not stubbed")
+	}
+	return m.createFn(ctx, userID, name)
+}
+
+func (m *serviceMock) ListOrgs(ctx context.Context, userID int64) ([]*domain.Org, error) {
+	if m.listFn == nil {
+		return nil, errors.New("ListOrgs not stubbed")
+	}
+	return m.listFn(ctx, userID)
+}
+
+func (m *serviceMock) DeleteOrg(ctx context.Context, userID, orgID int64) error {
+	if m.deleteFn == nil {
+		return errors.New("DeleteOrg not stubbed")
+	}
+	return m.deleteFn(ctx, userID, orgID)
+}
+
+func (m *serviceMock) Members(ctx context.Context, userID, orgID int64) ([]*domain.Member, error) {
+	if m.membersFn == nil {
+		return nil, errors.New("Members not stubbed")
+	}
+	return m.membersFn(ctx, userID, orgID)
+}
+
+func (m *serviceMock) Items(ctx context.Context, userID, orgID int64) ([]*domain.Item, error) {
+	if m.itemsFn == nil {
+		return nil, errors.New("Items not stubbed")
+	}
+	return m.itemsFn(ctx, userID, orgID)
+}
+
+func (m *serviceMock) AddMember(ctx context.Context, userID, orgID int64, username, role string) error {
+	if m.addFn == nil {
+		return errors.New("AddMember not stubbed")
+	}
+	return m.addFn(ctx, userID, orgID, username, role)
+}
+
+func (m *serviceMock) ChangeRole(ctx context.Context, userID, orgID, memberID int64, role string) error {
+	if m.roleFn == nil {
+		return errors.New("ChangeRole not stubbed")
+	}
+	return m.roleFn(ctx, userID, orgID, memberID, role)
+}
+
+func (m *serviceMock) RemoveMember(ctx context.Context, userID, orgID, memberID int64) error {
+	if m.removeFn == nil {
+		return errors.New("RemoveMember not stubbed")
+	}
+	return m.removeFn(ctx, userID, orgID, memberID)
+}
+
+func withUser(r *http.Request, id int64) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), constants.UserIDKey, id))
+}
+
+// newChiReq builds a request with URL params given as key, value pairs
+func newChiReq(method, path string, body io.Reader, params ...string) *http.Request {
+	req := httptest.NewRequest(method, path, body)
+
+	rctx := chi.NewRouteContext()
+	for i := 0; i+1 < len(params); i += 2 {
+		rctx.URLParams.Add(params[i], params[i+1])
+	}
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHttpHandler_CreateOrg(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("bad json -> 422", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		New(&serviceMock{}).CreateOrg(rr, withUser(httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{")), 1))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d", http.StatusUnprocessableEntity, rr.Code)
+		}
+	})
+
+	t.Run("empty name -> 400", func(t *testing.T) {
+		h := New(&serviceMock{createFn: func(ctx context.Context, userID int64, name string) (*domain.Org, error) {
+			return nil, domain.ErrEmptyName
+		}})
+
+		rr := httptest.NewRecorder()
+		h.CreateOrg(rr, withUser(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":""}`)), 1))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+	})
+
+	t.Run("ok -> org with owner role", func(t *testing.T) {
+		h := New(&serviceMock{createFn: func(ctx context.Context, userID int64, name string) (*domain.Org, error) {
+			return &domain.Org{ID: 3, Name: name, Role: domain.RoleOwner}, nil
+		}})
+
+		rr := httptest.NewRecorder()
+		h.CreateOrg(rr, withUser(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"acme"}`)), 1))
+
+		var resp Org
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if rr.Code != http.StatusOK || resp.OrgID != 3 || resp.Name != "acme" || resp.Role != domain.RoleOwner {
+			t.Fatalf("unexpected response: code=%d %+v", rr.Code, resp)
+		}
+	})
+}
+
+func TestHttpHandler_ListOrgs(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("empty -> 204", func(t *testing.T) {
+		h := New(&serviceMock{listFn: func(ctx context.Context, userID int64) ([]*domain.Org, error) {
+			return nil, domain.ErrEmptyOrgs
+		}})
+
+		rr := httptest.NewRecorder()
+		h.ListOrgs(rr, withUser(httptest.NewRequest(http.MethodGet, "/", nil), 1))
+
+		if rr.Code != http.StatusNoContent {
+			t.Fatalf("expected %d, got %d", http.StatusNoContent, rr.Code)
+		}
+	})
+
+	t.Run("no user -> 422", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		New(&serviceMock{}).ListOrgs(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d", http.StatusUnprocessableEntity, rr.Code)
+		}
+	})
+}
+
+func TestHttpHandler_Items(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("not a member -> 404", func(t *testing.T) {
+		h := New(&serviceMock{itemsFn: func(ctx context.Context, userID, orgID int64) ([]*domain.Item, error) {
+			return nil, domain.ErrOrgNotFound
+		}})
+
+		rr := httptest.NewRecorder()
+		h.Items(rr, withUser(newChiReq(http.MethodGet, "/2/items", nil, "id", "2"), 9))
+
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+
+	t.Run("ok -> items", func(t *testing.T) {
+		h := New(&serviceMock{itemsFn: func(ctx context.Context, userID, orgID int64) ([]*domain.Item, error) {
+			return []*domain.Item{{Type: domain.ItemText, ID: 4, Title: "wifi"}}, nil
+		}})
+
+		rr := httptest.NewRecorder()
+		h.Items(rr, withUser(newChiReq(http.MethodGet, "/2/items", nil, "id", "2"), 1))
+
+		var resp []Item
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(resp) != 1 || resp[0].Type != "text" || resp[0].ID != 4 {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+	})
+}
+
+func TestHttpHandler_AddMember(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("viewer -> 403", func(t *testing.T) {
+		h := New(&serviceMock{addFn: func(ctx context.Context, userID, orgID int64, username, role string) error {
+			return domain.ErrForbidden
+		}})
+
+		rr := httptest.NewRecorder()
+		h.AddMember(rr, withUser(newChiReq(http.MethodPost, "/2/members", strings.NewReader(`{"username":"bob","role":"editor"}`), "id", "2"), 4))
+
+		if rr.Code != http.StatusForbidden {
+			t.Fatalf("expected %d, got %d", http.StatusForbidden, rr.Code)
+		}
+	})
+
+	t.Run("ok -> 204", func(t *testing.T) {
+		var gotUser, gotRole string
+		h := New(&serviceMock{addFn: func(ctx context.Context, userID, orgID int64, username, role string) error {
+			gotUser, gotRole = username, role
+			return nil
+		}})
+
+		rr := httptest.NewRecorder()
+		h.AddMember(rr, withUser(newChiReq(http.MethodPost, "/2/members", strings.NewReader(`{"username":"bob","role":"editor"}`), "id", "2"), 1))
+
+		if rr.Code != http.StatusNoContent || gotUser != "bob" || gotRole != domain.RoleEditor {
+			t.Fatalf("unexpected result: code=%d user=%q role=%q", rr.Code, gotUser, gotRole)
+		}
+	})
+}
+
+func TestHttpHandler_ChangeRole(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("invalid member id -> 400", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		New(&serviceMock{}).ChangeRole(rr, withUser(newChiReq(http.MethodPut, "/2/members/x", strings.NewReader(`{"role":"admin"}`), "id", "2", "user_id", "x"), 1))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+	})
+
+	t.Run("last owner -> 409", func(t *testing.T) {
+		h := New(&serviceMock{roleFn: func(ctx context.Context, userID, orgID, memberID int64, role string) error {
+			return domain.ErrLastOwner
+		}})
+
+		rr := httptest.NewRecorder()
+		h.ChangeRole(rr, withUser(newChiReq(http.MethodPut, "/2/members/1", strings.NewReader(`{"role":"viewer"}`), "id", "2", "user_id", "1"), 1))
+
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("expected %d, got %d", http.StatusConflict, rr.Code)
+		}
+	})
+}
+
+func TestHttpHandler_RemoveMember(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	var gotOrg, gotMember int64
+	h := New(&serviceMock{removeFn: func(ctx context.Context, userID, orgID, memberID int64) error {
+		gotOrg, gotMember = orgID, memberID
+		return nil
+	}})
+
+	rr := httptest.NewRecorder()
+	h.RemoveMember(rr, withUser(newChiReq(http.MethodDelete, "/2/members/5", nil, "id", "2", "user_id", "5"), 1))
+
+	if rr.Code != http.StatusNoContent || gotOrg != 2 || gotMember != 5 {
+		t.Fatalf("unexpected result: code=%d org=%d member=%d", rr.Code, gotOrg, gotMember)
+	}
+}
@@ -0,0 +1,30 @@
+package report
+
+import (
+	"context"
+	domain "server/internal/app/domain/account_obj"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type service interface {
+	HealthReport(ctx context.Context, userId int64, opts domain.HealthOptions) (*domain.HealthReport, error)
+}
+
+type HttpHandler struct {
+	service service
+}
+
+func New(service service) *HttpHandler {
+	return &HttpHandler{
+		service: service,
+	}
+}
+
+func (h *HttpHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Get("/health", h.GetHealthReport)
+
+	return router
+}
@@ -0,0 +1,80 @@
+package report
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/account_usecase"
+	"server/internal/app/config"
+	domain "server/internal/app/domain/account_obj"
+	"server/internal/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type HealthItem struct {
+	AccountID       int64    `json:"account_id"`
+	ServiceName     string   `json:"service_name"`
+	Username        string   `json:"username"`
+	Issues          []string `json:"issues"`
+	EntropyBits     float64  `json:"entropy_bits"`
+	PasswordAgeDays int      `json:"password_age_days"`
+	ReuseGroup      int      `json:"reuse_group,omitempty"`
+}
+
+type HealthReportResponse struct {
+	Score    int          `json:"score"`
+	Total    int          `json:"total"`
+	Weak     int          `json:"weak"`
+	Reused   int          `json:"reused"`
+	Old      int          `json:"old"`
+	Breached int          `json:"breached"`
+	Items    []HealthItem `json:"items"`
+}
+
+func (h *HttpHandler) GetHealthReport(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "GetHealthReport"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	report, err := h.service.HealthReport(r.Context(), userId, domain.HealthOptions{
+		WeakEntropyBits: config.App.GetHealthWeakEntropyBits(),
+		MaxPasswordAge:  config.App.GetHealthMaxPasswordAge(),
+	})
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	resp := HealthReportResponse{
+		Score:    report.Score,
+		Total:    report.Total,
+		Weak:     report.Weak,
+		Reused:   report.Reused,
+		Old:      report.Old,
+		Breached: report.Breached,
+		Items:    make([]HealthItem, 0, len(report.Items)),
+	}
+
+	for _, item := range report.Items {
+		resp.Items = append(resp.Items, HealthItem{
+			AccountID:       item.AccountId,
+			ServiceName:     item.ServiceName,
+			Username:        item.UserName,
+			Issues:          item.Issues,
+			EntropyBits:     item.EntropyBits,
+			PasswordAgeDays: int(item.PasswordAge / (24 * time.Hour)),
+			ReuseGroup:      item.ReuseGroup,
+		})
+	}
+
+	codec.WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,103 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	"server/internal/app/config"
+	domain "server/internal/app/domain/account_obj"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type serviceMock struct {
+	healthReportFn func(ctx context.Context, userId int64, opts domain.HealthOptions) (*domain.HealthReport, error)
+}
+
+func (m *serviceMock) HealthReport(ctx context.Context, userId int64, opts domain.HealthOptions) (*domain.HealthReport, error) {
+	return m.healthReportFn(ctx, userId, opts)
+}
+
+func TestHttpHandler_GetHealthReport(t *testing.T) {
+	logger.Log = zap.NewNop()
+	config.InitTestConfig()
+
+	withUser := func(r *http.Request, id int64) *http.Request {
+		return r.WithContext(context.WithValue(r.Context(), constants.UserIDKey, id))
+	}
+
+	t.Run("missing userID in context -> 422", func(t *testing.T) {
+		h := New(&serviceMock{
+			healthReportFn: func(ctx context.Context, userId int64, opts domain.HealthOptions) (*domain.HealthReport, error) {
+				t.Fatalf("service must NOT be called without user")
+				return nil, nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.GetHealthReport(rr, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("service error -> mapped", func(t *testing.T) {
+		h := New(&serviceMock{
+			healthReportFn: func(ctx context.Context, userId int64, opts domain.HealthOptions) (*domain.HealthReport, error) {
+				return nil, domain.ErrAccountNotFound
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.GetHealthReport(rr, withUser(httptest.NewRequest(http.MethodGet, "/health", nil), 1))
+
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusNotFound, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 200 + summary and items", func(t *testing.T) {
+		h := New(&serviceMock{
+			healthReportFn: func(ctx context.Context, userId int64, opts domain.HealthOptions) (*domain.HealthReport, error) {
+				if userId != 3 {
+					t.Fatalf("expected userId=3, got %d", userId)
+				}
+				return &domain.HealthReport{
+					Score: 50, Total: 2, Weak: 1,
+					Items: []*domain.HealthItem{{
+						AccountId:   8,
+						ServiceName: "mail",
+						Issues:      []string{domain.IssueWeak},
+						EntropyBits: 19.9,
+						PasswordAge: 49 * time.Hour,
+					}},
+				}, nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.GetHealthReport(rr, withUser(httptest.NewRequest(http.MethodGet, "/health", nil), 3))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+
+		var resp HealthReportResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid json: %v body=%s", err, rr.Body.String())
+		}
+		if resp.Score != 50 || resp.Weak != 1 || len(resp.Items) != 1 {
+			t.Fatalf("unexpected resp: %+v", resp)
+		}
+		if item := resp.Items[0]; item.AccountID != 8 || item.PasswordAgeDays != 2 || item.Issues[0] != domain.IssueWeak {
+			t.Fatalf("unexpected item: %+v", item)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package secret_link
+
+import (
+	"context"
+	domain "server/internal/app/domain/secret_link"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type service interface {
+	CreateLink(ctx context.Context, userID int64, label string, ciphertext []byte, ttl time.Duration, maxViews int) (*domain.Link, error)
+	ListLinks(ctx context.Context, userID int64) ([]*domain.Link, error)
+	DeleteLink(ctx context.Context, userID int64, linkID string) error
+	Open(ctx context.Context, linkID string) (*domain.Secret, error)
+}
+
+type HttpHandler struct {
+	service service
+}
+
+func New(service service) *HttpHandler {
+	return &HttpHandler{
+		service: service,
+	}
+}
+
+// Routes manage links of the authenticated user
+func (h *HttpHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Get("/", h.ListLinks)
+	router.Post("/", h.CreateLink)
+	router.Delete("/{id}", h.DeleteLink)
+
+	return router
+}
+
+// PublicRoutes are opened by people without an account, GET only serves the viewer page
+// so link previews can not burn a secret, a view is counted by POST /{id}/open
+func (h *HttpHandler) PublicRoutes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Get("/{id}", h.Viewer)
+	router.Post("/{id}/open", h.OpenLink)
+
+	return router
+}
@@ -0,0 +1,121 @@
+package secret_link
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/secret_link_usecase"
+	domain "server/internal/app/domain/secret_link"
+	"server/internal/pkg/logger"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// CreateLinkRequest carries a ciphertext sealed by the client, zero ttl_minutes and max_views use the defaults
+type CreateLinkRequest struct {
+	Label      string `json:"label"`
+	Ciphertext []byte `json:"ciphertext"`
+	TTLMinutes int64  `json:"ttl_minutes"`
+	MaxViews   int    `json:"max_views"`
+}
+
+type Link struct {
+	LinkID    string    `json:"link_id"`
+	Path      string    `json:"path"`
+	Label     string    `json:"label,omitempty"`
+	MaxViews  int       `json:"max_views"`
+	ViewsLeft int       `json:"views_left"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (h *HttpHandler) CreateLink(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "CreateLink"
+
+	req := new(CreateLinkRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "json decode error")
+		return
+	}
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	link, err := h.service.CreateLink(r.Context(), userId, req.Label, req.Ciphertext, time.Duration(req.TTLMinutes)*time.Minute, req.MaxViews)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, fromDomain(link))
+}
+
+// ListLinks lists links of the caller that can still be opened
+func (h *HttpHandler) ListLinks(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "ListLinks"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	list, err := h.service.ListLinks(r.Context(), userId)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	out := make([]Link, 0, len(list))
+	for _, link := range list {
+		out = append(out, fromDomain(link))
+	}
+
+	codec.WriteJSON(w, http.StatusOK, out)
+}
+
+func (h *HttpHandler) DeleteLink(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "DeleteLink"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	if err := h.service.DeleteLink(r.Context(), userId, chi.URLParam(r, "id")); err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// help func
+
+func fromDomain(link *domain.Link) Link {
+	return Link{
+		LinkID:    link.ID,
+		Path:      "/s/" + link.ID,
+		Label:     link.Label,
+		MaxViews:  link.MaxViews,
+		ViewsLeft: link.ViewsLeft(),
+		ExpiresAt: link.ExpiresAt,
+		CreatedAt: link.CreatedAt,
+	}
+}
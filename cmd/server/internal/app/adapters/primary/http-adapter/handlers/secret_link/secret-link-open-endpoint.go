@@ -0,0 +1,99 @@
+package secret_link
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/secret_link_usecase"
+	"server/internal/pkg/logger"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type OpenLinkResponse struct {
+	Ciphertext []byte    `json:"ciphertext"`
+	ViewsLeft  int       `json:"views_left"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// viewerPage decrypts the secret in the browser with the key from the URL fragment,
+// WebCrypto needs a secure context so outside of localhost the server must be behind https
+const viewerPage = `<!doctype html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="referrer" content="no-referrer">
+<title>Secret</title>
+</head>
+<body>
+<p id="status">Someone shared a secret with you. It can be opened a limited number of times.</p>
+<button id="open">Reveal</button>
+<pre id="secret"></pre>
+<script>
+const decode = s => Uint8Array.from(atob(s.replace(/-/g, '+').replace(/_/g, '/')), c => c.charCodeAt(0));
+document.getElementById('open').onclick = async () => {
+  const status = document.getElementById('status');
+  try {
+    const rawKey = location.hash.slice(1);
+    if (!rawKey) throw new Error('The link has no key.');
+    const resp = await fetch(location.pathname + '/open', {method: 'POST'});
+    if (!resp.ok) throw new Error('The secret does not exist, has expired or was already read.');
+    const data = await resp.json();
+    const sealed = decode(data.ciphertext);
+    const key = await crypto.subtle.importKey('raw', decode(rawKey), 'AES-GCM', false, ['decrypt']);
+    const plain = await crypto.subtle.decrypt({name: 'AES-GCM', iv: sealed.slice(0, 12)}, key, sealed.slice(12));
+    document.getElementById('secret').textContent = new TextDecoder().decode(plain);
+    document.getElementById('open').remove();
+    status.textContent = data.views_left > 0
+      ? 'The link can be opened ' + data.views_left + ' more time(s).'
+      : 'The secret is burned, the link no longer works.';
+  } catch (e) {
+    status.textContent = e.message;
+  }
+};
+</script>
+</body>
+</html>
+`
+
+// Viewer serves the page that opens the link, it does not count a view
+func (h *HttpHandler) Viewer(w http.ResponseWriter, r *http.Request) {
+	noStore(w)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write([]byte(viewerPage)); err != nil {
+		logger.Log.Error("Viewer", zap.Error(err))
+	}
+}
+
+// OpenLink counts a view and returns the ciphertext, the link is burned after its last view
+func (h *HttpHandler) OpenLink(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "OpenLink"
+
+	noStore(w)
+
+	secret, err := h.service.Open(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, OpenLinkResponse{
+		Ciphertext: secret.Ciphertext,
+		ViewsLeft:  secret.ViewsLeft,
+		ExpiresAt:  secret.ExpiresAt,
+	})
+}
+
+// help func
+
+// noStore keeps secrets out of caches and the id out of referrers
+func noStore(w http.ResponseWriter) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+}
@@ -0,0 +1,230 @@
+package secret_link
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	domain "server/internal/app/domain/secret_link"
+	"server/internal/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type serviceMock struct {
+	createFn func(ctx context.Context, userID int64, label string, ciphertext []byte, ttl time.Duration, maxViews int) (*domain.Link, error)
+	listFn   func(ctx context.Context, userID int64) ([]*domain.Link, error)
+	deleteFn func(ctx context.Context, userID int64, linkID string) error
+	openFn   func(ctx context.Context, linkID string) (*domain.Secret, error)
+}
+
+func (m *serviceMock) CreateLink(ctx context.Context, userID int64, label string, ciphertext []byte, ttl time.Duration, maxViews int) (*domain.Link, error) {
+	if m.createFn == nil {
+		return nil, errors.New("CreateLink not stubbed")
+	}
+	return m.createFn(ctx, userID, label, ciphertext, ttl, maxViews)
+}
+
+func (m *serviceMock) ListLinks(ctx context.Context, userID int64) ([]*domain.Link, error) {
+	if m.listFn == nil {
+		return nil, errors.New("ListLinks not stubbed")
+	}
+	return m.listFn(ctx, userID)
+}
+
+func (m *serviceMock) DeleteLink(ctx context.Context, userID int64, linkID string) error {
+	if m.deleteFn == nil {
+		return errors.New("DeleteLink not stubbed")
+	}
+	return m.deleteFn(ctx, userID, linkID)
+}
+
+func (m *serviceMock) Open(ctx context.Context, linkID string) (*domain.Secret, error) {
+	if m.openFn == nil {
+		return nil, errors.New("Open not stubbed")
+	}
+	return m.openFn(ctx, linkID)
+}
+
+func withUser(r *http.Request, id int64) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), constants.UserIDKey, id))
+}
+
+// newChiReq builds a request with URL params given as key, value pairs
+func newChiReq(method, path string, body io.Reader, params ...string) *http.Request {
+	req := httptest.NewRequest(method, path, body)
+
+	rctx := chi.NewRouteContext()
+	for i := 0; i+1 < len(params); i += 2 {
+		rctx.URLParams.Add(params[i], params[i+1])
+	}
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHttpHandler_CreateLink(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("bad json -> 422", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		New(&serviceMock{}).CreateLink(rr, withUser(httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{")), 1))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d", http.StatusUnprocessableEntity, rr.Code)
+		}
+	})
+
+	t.Run("missing user -> 422", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		New(&serviceMock{}).CreateLink(rr, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"ciphertext":"c2VhbGVk"}`)))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d", http.StatusUnprocessableEntity, rr.Code)
+		}
+	})
+
+	t.Run("too large -> 413", func(t *testing.T) {
+		h := New(&serviceMock{createFn: func(ctx context.Context, userID int64, label string, ciphertext []byte, ttl time.Duration, maxViews int) (*domain.Link, error) {
+			return nil, domain.ErrSecretTooLarge
+		}})
+
+		rr := httptest.NewRecorder()
+		h.CreateLink(rr, withUser(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"ciphertext":"c2VhbGVk"}`)), 1))
+
+		if rr.Code != http.StatusRequestEntityTooLarge {
+			t.Fatalf("expected %d, got %d", http.StatusRequestEntityTooLarge, rr.Code)
+		}
+	})
+
+	t.Run("ok -> 200 with base64 ciphertext decoded", func(t *testing.T) {
+		var (
+			gotCiphertext []byte
+			gotTTL        time.Duration
+			gotViews      int
+		)
+		h := New(&serviceMock{createFn: func(ctx context.Context, userID int64, label string, ciphertext []byte, ttl time.Duration, maxViews int) (*domain.Link, error) {
+			gotCiphertext, gotTTL, gotViews = ciphertext, ttl, maxViews
+			return &domain.Link{ID: "abc", Label: label, MaxViews: maxViews}, nil
+		}})
+
+		body := `{"label":"wifi","ciphertext":"c2VhbGVk","ttl_minutes":90,"max_views":3}`
+		rr := httptest.NewRecorder()
+		h.CreateLink(rr, withUser(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)), 1))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		if string(gotCiphertext) != "sealed" || gotTTL != 90*time.Minute || gotViews != 3 {
+			t.Fatalf("unexpected args: %q %s %d", gotCiphertext, gotTTL, gotViews)
+		}
+
+		var out Link
+		if err := json.Unmarshal(rr.Body.Bytes(), &out); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if out.Path != "/s/abc" || out.ViewsLeft != 3 {
+			t.Fatalf("unexpected link: %+v", out)
+		}
+	})
+}
+
+func TestHttpHandler_ListLinks(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	h := New(&serviceMock{listFn: func(ctx context.Context, userID int64) ([]*domain.Link, error) {
+		return nil, domain.ErrEmptyLinks
+	}})
+
+	rr := httptest.NewRecorder()
+	h.ListLinks(rr, withUser(httptest.NewRequest(http.MethodGet, "/", nil), 1))
+
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected %d, got %d", http.StatusNoContent, rr.Code)
+	}
+}
+
+func TestHttpHandler_DeleteLink(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	var gotID string
+	h := New(&serviceMock{deleteFn: func(ctx context.Context, userID int64, linkID string) error {
+		gotID = linkID
+		return nil
+	}})
+
+	rr := httptest.NewRecorder()
+	h.DeleteLink(rr, withUser(newChiReq(http.MethodDelete, "/abc", nil, "id", "abc"), 1))
+
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected %d, got %d", http.StatusNoContent, rr.Code)
+	}
+	if gotID != "abc" {
+		t.Fatalf("expected id abc, got %q", gotID)
+	}
+}
+
+func TestHttpHandler_Viewer(t *testing.T) {
+	h := New(&serviceMock{openFn: func(ctx context.Context, linkID string) (*domain.Secret, error) {
+		t.Fatalf("the viewer page must not open the link")
+		return nil, nil
+	}})
+
+	rr := httptest.NewRecorder()
+	h.Viewer(rr, newChiReq(http.MethodGet, "/abc", nil, "id", "abc"))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d", http.StatusOK, rr.Code)
+	}
+	if rr.Header().Get("Cache-Control") != "no-store" || !strings.HasPrefix(rr.Header().Get("Content-Type"), "text/html") {
+		t.Fatalf("unexpected headers: %v", rr.Header())
+	}
+}
+
+func TestHttpHandler_OpenLink(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("gone -> 404", func(t *testing.T) {
+		h := New(&serviceMock{openFn: func(ctx context.Context, linkID string) (*domain.Secret, error) {
+			return nil, domain.ErrLinkNotFound
+		}})
+
+		rr := httptest.NewRecorder()
+		h.OpenLink(rr, newChiReq(http.MethodPost, "/abc/open", nil, "id", "abc"))
+
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+
+	t.Run("ok -> 200 without a user", func(t *testing.T) {
+		h := New(&serviceMock{openFn: func(ctx context.Context, linkID string) (*domain.Secret, error) {
+			return &domain.Secret{Ciphertext: []byte("sealed"), ViewsLeft: 0}, nil
+		}})
+
+		rr := httptest.NewRecorder()
+		h.OpenLink(rr, newChiReq(http.MethodPost, "/abc/open", nil, "id", "abc"))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d", http.StatusOK, rr.Code)
+		}
+		if rr.Header().Get("Cache-Control") != "no-store" {
+			t.Fatalf("expected no-store, got %q", rr.Header().Get("Cache-Control"))
+		}
+
+		var out OpenLinkResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &out); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if string(out.Ciphertext) != "sealed" || out.ViewsLeft != 0 {
+			t.Fatalf("unexpected response: %+v", out)
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package share
+
+import (
+	"context"
+	domain "server/internal/app/domain/share"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type service interface {
+	ShareItem(ctx context.Context, ownerID int64, item domain.Item, username, permission string) (*domain.Share, error)
+	ListItemShares(ctx context.Context, ownerID int64, item domain.Item) ([]*domain.Share, error)
+	SharedWithMe(ctx context.Context, userID int64) ([]*domain.Share, error)
+	Revoke(ctx context.Context, userID, shareID int64) error
+}
+
+type HttpHandler struct {
+	service service
+}
+
+func New(service service) *HttpHandler {
+	return &HttpHandler{
+		service: service,
+	}
+}
+
+// Routes of item shares, {type} is account, card or text
+func (h *HttpHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Get("/incoming", h.SharedWithMe)
+	router.Get("/{type}/{id}", h.ListItemShares)
+	router.Post("/{type}/{id}", h.ShareItem)
+	router.Delete("/{id}", h.Revoke)
+
+	return router
+}
@@ -0,0 +1,107 @@
+package share
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/share_usecase"
+	domain "server/internal/app/domain/share"
+	"server/internal/pkg/logger"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type Share struct {
+	ShareID    int64     `json:"share_id"`
+	Type       string    `json:"type"`
+	ItemID     int64     `json:"item_id"`
+	Title      string    `json:"title,omitempty"`
+	Owner      string    `json:"owner,omitempty"`
+	Grantee    string    `json:"grantee,omitempty"`
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// SharedWithMe lists items of other users the caller has access to
+func (h *HttpHandler) SharedWithMe(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "SharedWithMe"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	list, err := h.service.SharedWithMe(r.Context(), userId)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, fromDomainList(list))
+}
+
+// ListItemShares lists who an item of the caller is shared with
+func (h *HttpHandler) ListItemShares(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "ListItemShares"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	item, ok := itemFromURL(r)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid item id")
+		return
+	}
+
+	list, err := h.service.ListItemShares(r.Context(), userId, item)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, fromDomainList(list))
+}
+
+// help func
+
+func fromDomain(s *domain.Share) Share {
+	return Share{
+		ShareID:    s.ID,
+		Type:       s.Item.Type,
+		ItemID:     s.Item.ID,
+		Title:      s.Title,
+		Owner:      s.OwnerName,
+		Grantee:    s.GranteeName,
+		Permission: s.Permission,
+		CreatedAt:  s.CreatedAt,
+	}
+}
+
+func fromDomainList(list []*domain.Share) []Share {
+	out := make([]Share, 0, len(list))
+	for _, s := range list {
+		out = append(out, fromDomain(s))
+	}
+	return out
+}
+
+func itemFromURL(r *http.Request) (domain.Item, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		return domain.Item{}, false
+	}
+	return domain.Item{Type: chi.URLParam(r, "type"), ID: id}, true
+}
@@ -0,0 +1,188 @@
+package share
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	domain "server/internal/app/domain/share"
+	"server/internal/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type serviceMock struct {
+	shareFn    func(ctx context.Context, ownerID int64, item domain.Item, username, permission string) (*domain.Share, error)
+	listItemFn func(ctx context.Context, ownerID int64, item domain.Item) ([]*domain.Share, error)
+	incomingFn func(ctx context.Context, userID int64) ([]*domain.Share, error)
+	revokeFn   func(ctx context.Context, userID, shareID int64) error
+}
+
+func (m *serviceMock) ShareItem(ctx context.Context, ownerID int64, item domain.Item, username, permission string) (*domain.Share, error) {
+	if m.shareFn == nil {
+		return nil, errors.New("ShareItem not stubbed")
+	}
+	return m.shareFn(ctx, ownerID, item, username, permission)
+}
+
+func (m *serviceMock) ListItemShares(ctx context.Context, ownerID int64, item domain.Item) ([]*domain.Share, error) {
+	if m.listItemFn == nil {
+		return nil, errors.New("ListItemShares not stubbed")
+	}
+	return m.listItemFn(ctx, ownerID, item)
+}
+
+func (m *serviceMock) SharedWithMe(ctx context.Context, userID int64) ([]*domain.Share, error) {
+	if m.incomingFn == nil {
+		return nil, errors.New("SharedWithMe not stubbed")
+	}
+	return m.incomingFn(ctx, userID)
+}
+
+func (m *serviceMock) Revoke(ctx context.Context, userID, shareID int64) error {
+	if m.revokeFn == nil {
+		return errors.New("Revoke not stubbed")
+	}
+	return m.revokeFn(ctx, userID, shareID)
+}
+
+func withUser(r *http.Request, id int64) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), constants.UserIDKey, id))
+}
+
+// newChiReq builds a request with URL params given as key, value pairs
+func newChiReq(method, path string, body io.Reader, params ...string) *http.Request {
+	req := httptest.NewRequest(method, path, body)
+
+	rctx := chi.NewRouteContext()
+	for i := 0; i+1 < len(params); i += 2 {
+		rctx.URLParams.Add(params[i], params[i+1])
+	}
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHttpHandler_ShareItem(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("bad json -> 422", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		New(&serviceMock{}).ShareItem(rr, withUser(newChiReq(http.MethodPost, "/account/3", strings.NewReader("{"), "type", "account", "id", "3"), 7))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d", http.StatusUnprocessableEntity, rr.Code)
+		}
+	})
+
+	t.Run("unknown user -> 404", func(t *testing.T) {
+		h := New(&serviceMock{shareFn: func(ctx context.Context, ownerID int64, item domain.Item, username, permission string) (*domain.Share, error) {
+			return nil, domain.ErrUserNotFound
+		}})
+
+		rr := httptest.NewRecorder()
+		h.ShareItem(rr, withUser(newChiReq(http.MethodPost, "/account/3", strings.NewReader(`{"username":"nobody","permission":"read"}`), "type", "account", "id", "3"), 7))
+
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+
+	t.Run("ok -> share", func(t *testing.T) {
+		var (
+			gotItem domain.Item
+			gotUser string
+			gotPerm string
+		)
+		h := New(&serviceMock{shareFn: func(ctx context.Context, ownerID int64, item domain.Item, username, permission string) (*domain.Share, error) {
+			gotItem, gotUser, gotPerm = item, username, permission
+			return &domain.Share{ID: 5, Item: item, GranteeName: username, Permission: permission}, nil
+		}})
+
+		rr := httptest.NewRecorder()
+		h.ShareItem(rr, withUser(newChiReq(http.MethodPost, "/card/3", strings.NewReader(`{"username":"bob","permission":"write"}`), "type", "card", "id", "3"), 7))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d", http.StatusOK, rr.Code)
+		}
+		if gotItem != (domain.Item{Type: domain.ItemBankCard, ID: 3}) || gotUser != "bob" || gotPerm != domain.PermWrite {
+			t.Fatalf("unexpected call: %+v %q %q", gotItem, gotUser, gotPerm)
+		}
+
+		var resp Share
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if resp.ShareID != 5 || resp.Grantee != "bob" {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+	})
+}
+
+func TestHttpHandler_SharedWithMe(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("empty -> 204", func(t *testing.T) {
+		h := New(&serviceMock{incomingFn: func(ctx context.Context, userID int64) ([]*domain.Share, error) {
+			return nil, domain.ErrEmptyShares
+		}})
+
+		rr := httptest.NewRecorder()
+		h.SharedWithMe(rr, withUser(httptest.NewRequest(http.MethodGet, "/incoming", nil), 8))
+
+		if rr.Code != http.StatusNoContent {
+			t.Fatalf("expected %d, got %d", http.StatusNoContent, rr.Code)
+		}
+	})
+
+	t.Run("ok -> items with owners", func(t *testing.T) {
+		h := New(&serviceMock{incomingFn: func(ctx context.Context, userID int64) ([]*domain.Share, error) {
+			return []*domain.Share{{ID: 1, Item: domain.Item{Type: domain.ItemText, ID: 2}, Title: "wifi", OwnerName: "alice", Permission: domain.PermRead}}, nil
+		}})
+
+		rr := httptest.NewRecorder()
+		h.SharedWithMe(rr, withUser(httptest.NewRequest(http.MethodGet, "/incoming", nil), 8))
+
+		var resp []Share
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(resp) != 1 || resp[0].Owner != "alice" || resp[0].Type != "text" || resp[0].ItemID != 2 {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+	})
+}
+
+func TestHttpHandler_Revoke(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("invalid id -> 400", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		New(&serviceMock{}).Revoke(rr, withUser(newChiReq(http.MethodDelete, "/x", nil, "id", "x"), 7))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+	})
+
+	t.Run("ok -> 204", func(t *testing.T) {
+		var got int64
+		h := New(&serviceMock{revokeFn: func(ctx context.Context, userID, shareID int64) error {
+			got = shareID
+			return nil
+		}})
+
+		rr := httptest.NewRecorder()
+		h.Revoke(rr, withUser(newChiReq(http.MethodDelete, "/4", nil, "id", "4"), 7))
+
+		if rr.Code != http.StatusNoContent || got != 4 {
+			t.Fatalf("unexpected result: code=%d share=%d", rr.Code, got)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package ssh_key_obj
+
+import (
+	"context"
+	domain "server/internal/app/domain/ssh_key_obj"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type service interface {
+	GetSSHKeyList(ctx context.Context, userId int64) ([]*domain.SSHKey, error)
+	GetSSHKey(ctx context.Context, userId, keyId int64) (*domain.SSHKey, error)
+	ImportSSHKey(ctx context.Context, req domain.ImportRequest) (*domain.SSHKey, error)
+	GenerateSSHKey(ctx context.Context, req domain.GenerateRequest) (*domain.SSHKey, error)
+}
+
+type HttpHandler struct {
+	service service
+}
+
+func New(service service) *HttpHandler {
+	return &HttpHandler{
+		service: service,
+	}
+}
+
+func (h *HttpHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Get("/list", h.GetSSHKeyList)
+	router.Get("/list/{id}", h.GetSSHKey)
+	router.Post("/import", h.ImportSSHKey)
+	router.Post("/generate", h.GenerateSSHKey)
+
+	return router
+}
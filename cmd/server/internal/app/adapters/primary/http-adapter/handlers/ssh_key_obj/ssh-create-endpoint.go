@@ -0,0 +1,95 @@
+package ssh_key_obj
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/ssh_key_usecase"
+	domain "server/internal/app/domain/ssh_key_obj"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type ImportSSHKeyRequest struct {
+	Title      string `json:"title"`
+	PrivateKey string `json:"private_key"`
+	Passphrase string `json:"passphrase"`
+	Comment    string `json:"comment"`
+}
+
+type GenerateSSHKeyRequest struct {
+	Title   string `json:"title"`
+	Type    string `json:"type"`
+	Bits    int    `json:"bits"`
+	Comment string `json:"comment"`
+}
+
+// ImportSSHKey stores an existing private key and responds with its public part
+func (h *HttpHandler) ImportSSHKey(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "ImportSSHKey"
+
+	req := new(ImportSSHKeyRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "json decode error")
+		return
+	}
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	key, err := h.service.ImportSSHKey(r.Context(), domain.ImportRequest{
+		UserId:     userId,
+		Title:      req.Title,
+		PrivateKey: req.PrivateKey,
+		Passphrase: req.Passphrase,
+		Comment:    req.Comment,
+	})
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, fromDomain(key))
+}
+
+// GenerateSSHKey creates a new key pair, the public part is returned right away
+func (h *HttpHandler) GenerateSSHKey(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "GenerateSSHKey"
+
+	req := new(GenerateSSHKeyRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "json decode error")
+		return
+	}
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	key, err := h.service.GenerateSSHKey(r.Context(), domain.GenerateRequest{
+		UserId:  userId,
+		Title:   req.Title,
+		Type:    req.Type,
+		Bits:    req.Bits,
+		Comment: req.Comment,
+	})
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, fromDomain(key))
+}
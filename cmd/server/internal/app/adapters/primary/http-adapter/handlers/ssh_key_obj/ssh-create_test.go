@@ -0,0 +1,88 @@
+package ssh_key_obj
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "server/internal/app/domain/ssh_key_obj"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+func TestHttpHandler_ImportSSHKey(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("bad json -> 422", func(t *testing.T) {
+		h := New(&serviceMock{})
+
+		rr := httptest.NewRecorder()
+		h.ImportSSHKey(rr, withUser(httptest.NewRequest(http.MethodPost, "/import", bytes.NewReader([]byte("{"))), 7))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d", http.StatusUnprocessableEntity, rr.Code)
+		}
+	})
+
+	t.Run("passphrase required -> 400", func(t *testing.T) {
+		var got domain.ImportRequest
+		h := New(&serviceMock{
+			importFn: func(ctx context.Context, req domain.ImportRequest) (*domain.SSHKey, error) {
+				got = req
+				return nil, domain.ErrPassphraseRequired
+			},
+		})
+
+		body := []byte(`{"title":"laptop","private_key":"PEM","passphrase":""}`)
+		rr := httptest.NewRecorder()
+		h.ImportSSHKey(rr, withUser(httptest.NewRequest(http.MethodPost, "/import", bytes.NewReader(body)), 7))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+		}
+		if got.UserId != 7 || got.Title != "laptop" || got.PrivateKey != "PEM" {
+			t.Fatalf("unexpected service request: %+v", got)
+		}
+	})
+}
+
+func TestHttpHandler_GenerateSSHKey(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("missing userID in context -> 422", func(t *testing.T) {
+		h := New(&serviceMock{})
+
+		body := []byte(`{"title":"deploy","type":"ed25519"}`)
+		rr := httptest.NewRecorder()
+		h.GenerateSSHKey(rr, httptest.NewRequest(http.MethodPost, "/generate", bytes.NewReader(body)))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d", http.StatusUnprocessableEntity, rr.Code)
+		}
+	})
+
+	t.Run("ok -> 200, request passed to service", func(t *testing.T) {
+		var got domain.GenerateRequest
+		h := New(&serviceMock{
+			generateFn: func(ctx context.Context, req domain.GenerateRequest) (*domain.SSHKey, error) {
+				got = req
+				return &domain.SSHKey{KeyId: 5, Title: req.Title, Type: "ssh-rsa", Fingerprint: "SHA256:x"}, nil
+			},
+		})
+
+		body := []byte(`{"title":"deploy","type":"rsa","bits":3072,"comment":"ci"}`)
+		rr := httptest.NewRecorder()
+		h.GenerateSSHKey(rr, withUser(httptest.NewRequest(http.MethodPost, "/generate", bytes.NewReader(body)), 7))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		want := domain.GenerateRequest{UserId: 7, Title: "deploy", Type: "rsa", Bits: 3072, Comment: "ci"}
+		if got != want {
+			t.Fatalf("expected %+v, got %+v", want, got)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package ssh_key_obj
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/ssh_key_usecase"
+	"server/internal/pkg/logger"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type SSHKeyResponse struct {
+	SSHKey
+	PrivateKey string `json:"private_key"`
+}
+
+func (h *HttpHandler) GetSSHKey(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "GetSSHKey"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid ssh key id")
+		return
+	}
+
+	key, err := h.service.GetSSHKey(r.Context(), userId, id)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, SSHKeyResponse{
+		SSHKey:     fromDomain(key),
+		PrivateKey: key.PrivateKey,
+	})
+}
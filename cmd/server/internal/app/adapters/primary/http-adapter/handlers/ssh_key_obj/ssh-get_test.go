@@ -0,0 +1,169 @@
+package ssh_key_obj
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	domain "server/internal/app/domain/ssh_key_obj"
+	"server/internal/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type serviceMock struct {
+	getListFn  func(ctx context.Context, userId int64) ([]*domain.SSHKey, error)
+	getFn      func(ctx context.Context, userId, keyId int64) (*domain.SSHKey, error)
+	importFn   func(ctx context.Context, req domain.ImportRequest) (*domain.SSHKey, error)
+	generateFn func(ctx context.Context, req domain.GenerateRequest) (*domain.SSHKey, error)
+}
+
+func (m *serviceMock) GetSSHKeyList(ctx context.Context, userId int64) ([]*domain.SSHKey, error) {
+	if m.getListFn == nil {
+		return nil, errors.New("GetSSHKeyList not stubbed")
+	}
+	return m.getListFn(ctx, userId)
+}
+
+func (m *serviceMock) GetSSHKey(ctx context.Context, userId, keyId int64) (*domain.SSHKey, error) {
+	if m.getFn == nil {
+		return nil, errors.New("GetSSHKey not stubbed")
+	}
+	return m.getFn(ctx, userId, keyId)
+}
+
+func (m *serviceMock) ImportSSHKey(ctx context.Context, req domain.ImportRequest) (*domain.SSHKey, error) {
+	if m.importFn == nil {
+		return nil, errors.New("ImportSSHKey not stubbed")
+	}
+	return m.importFn(ctx, req)
+}
+
+func (m *serviceMock) GenerateSSHKey(ctx context.Context, req domain.GenerateRequest) (*domain.SSHKey, error) {
+	if m.generateFn == nil {
+		return nil, errors.New("GenerateSSHKey not stubbed")
+	}
+	return m.generateFn(ctx, req)
+}
+
+func withUser(r *http.Request, id int64) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), constants.UserIDKey, id))
+}
+
+func newChiReq(method, path, routePattern, paramKey, paramValue string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+
+	rctx := chi.NewRouteContext()
+	rctx.RoutePatterns = append(rctx.RoutePatterns, routePattern)
+	rctx.URLParams.Add(paramKey, paramValue)
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHttpHandler_GetSSHKey(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("invalid id -> 400", func(t *testing.T) {
+		h := New(&serviceMock{})
+
+		rr := httptest.NewRecorder()
+		h.GetSSHKey(rr, withUser(newChiReq(http.MethodGet, "/list/x", "/list/{id}", "id", "x"), 7))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("not found -> 404", func(t *testing.T) {
+		h := New(&serviceMock{
+			getFn: func(ctx context.Context, userId, keyId int64) (*domain.SSHKey, error) {
+				return nil, domain.ErrSSHKeyNotFound
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.GetSSHKey(rr, withUser(newChiReq(http.MethodGet, "/list/3", "/list/{id}", "id", "3"), 7))
+
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusNotFound, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 200 with private key, user passed to service", func(t *testing.T) {
+		var gotUser, gotKey int64
+		h := New(&serviceMock{
+			getFn: func(ctx context.Context, userId, keyId int64) (*domain.SSHKey, error) {
+				gotUser, gotKey = userId, keyId
+				return &domain.SSHKey{KeyId: keyId, Title: "deploy", Type: "ssh-ed25519", PrivateKey: "PEM", Fingerprint: "SHA256:abc"}, nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.GetSSHKey(rr, withUser(newChiReq(http.MethodGet, "/list/3", "/list/{id}", "id", "3"), 7))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		if gotUser != 7 || gotKey != 3 {
+			t.Fatalf("unexpected service args: user=%d key=%d", gotUser, gotKey)
+		}
+
+		var resp SSHKeyResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if resp.PrivateKey != "PEM" || resp.Fingerprint != "SHA256:abc" || resp.KeyID != 3 {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+	})
+}
+
+func TestHttpHandler_GetSSHKeyList(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("ok -> public parts only", func(t *testing.T) {
+		h := New(&serviceMock{
+			getListFn: func(ctx context.Context, userId int64) ([]*domain.SSHKey, error) {
+				return []*domain.SSHKey{{KeyId: 1, Title: "a", PrivateKey: "PEM", PublicKey: "ssh-ed25519 AAAA", Fingerprint: "SHA256:1"}}, nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.GetSSHKeyList(rr, withUser(httptest.NewRequest(http.MethodGet, "/list", nil), 7))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+
+		var resp []map[string]any
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(resp) != 1 || resp[0]["fingerprint"] != "SHA256:1" || resp[0]["public_key"] != "ssh-ed25519 AAAA" {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+		if _, ok := resp[0]["private_key"]; ok {
+			t.Fatalf("list must not expose private keys: %+v", resp[0])
+		}
+	})
+
+	t.Run("empty -> 204", func(t *testing.T) {
+		h := New(&serviceMock{
+			getListFn: func(ctx context.Context, userId int64) ([]*domain.SSHKey, error) {
+				return nil, domain.ErrEmptySSHKeysList
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.GetSSHKeyList(rr, withUser(httptest.NewRequest(http.MethodGet, "/list", nil), 7))
+
+		if rr.Code != http.StatusNoContent {
+			t.Fatalf("expected %d, got %d", http.StatusNoContent, rr.Code)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package ssh_key_obj
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/ssh_key_usecase"
+	domain "server/internal/app/domain/ssh_key_obj"
+	"server/internal/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SSHKey is the public part of a key, the private key is only returned by the get endpoint
+type SSHKey struct {
+	KeyID       int64     `json:"key_id"`
+	Title       string    `json:"title"`
+	Type        string    `json:"type"`
+	PublicKey   string    `json:"public_key"`
+	Fingerprint string    `json:"fingerprint"`
+	Comment     string    `json:"comment,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (h *HttpHandler) GetSSHKeyList(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "GetSSHKeyList"
+	resp := make([]SSHKey, 0)
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	list, err := h.service.GetSSHKeyList(r.Context(), userId)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	for _, item := range list {
+		resp = append(resp, fromDomain(item))
+	}
+
+	codec.WriteJSON(w, http.StatusOK, resp)
+}
+
+func fromDomain(key *domain.SSHKey) SSHKey {
+	return SSHKey{
+		KeyID:       key.KeyId,
+		Title:       key.Title,
+		Type:        key.Type,
+		PublicKey:   key.PublicKey,
+		Fingerprint: key.Fingerprint,
+		Comment:     key.Comment,
+		CreatedAt:   key.CreatedAt,
+	}
+}
@@ -8,10 +8,11 @@ import (
 )
 
 type service interface {
-	GetText(ctx context.Context, cardId int64) (*domain.Text, error)
+	GetText(ctx context.Context, userId, textId int64) (*domain.Text, error)
 	GetTextList(ctx context.Context, userId int64) ([]*domain.Text, error)
 	CreateNewTextObj(ctx context.Context, card *domain.Text) (int64, error)
 	UpdateText(ctx context.Context, card *domain.Text) error
+	DeleteText(ctx context.Context, userId, textId int64) error
 }
 
 type HttpHandler struct {
@@ -29,6 +30,7 @@ func (h *HttpHandler) Routes() *chi.Mux {
 	router.Get("/list/{id}", h.GetTextObj)
 	router.Post("/create", h.CreateText)
 	router.Put("/update/{id}", h.UpdateTextObj)
+	router.Delete("/delete/{id}", h.DeleteTextObj)
 
 	return router
 }
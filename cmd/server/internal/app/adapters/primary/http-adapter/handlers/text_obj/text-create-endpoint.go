@@ -8,6 +8,7 @@ import (
 	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/text_usecase"
 	domain "server/internal/app/domain/text_obj"
 	"server/internal/pkg/logger"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -15,6 +16,11 @@ import (
 type CreateTextRequest struct {
 	Title string `json:"title"`
 	Text  string `json:"text"`
+
+	ExpiresAt    *time.Time          `json:"expires_at,omitempty"`
+	CustomFields []codec.CustomField `json:"custom_fields,omitempty"`
+	// OrgID creates the item in an organization instead of the personal vault
+	OrgID int64 `json:"org_id,omitempty"`
 }
 
 type CreateTextResponse struct {
@@ -64,5 +70,9 @@ func (req CreateTextRequest) toDomain() *domain.Text {
 	return &domain.Text{
 		Title: req.Title,
 		Text:  req.Text,
+
+		ExpiresAt:    codec.TimeOrZero(req.ExpiresAt),
+		CustomFields: codec.CustomFieldsToDomain(req.CustomFields),
+		OrgID:        req.OrgID,
 	}
 }
@@ -7,9 +7,12 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"server/internal/app/adapters/primary/http-adapter/constants"
 	"testing"
+	"time"
 
+	"server/internal/app/domain/custom_field"
 	domain "server/internal/app/domain/text_obj"
 	"server/internal/pkg/logger"
 
@@ -33,9 +36,10 @@ type mockService struct {
 	getTextListFn func(ctx context.Context, userID int64) ([]*domain.Text, error)
 	createFn      func(ctx context.Context, t *domain.Text) (int64, error)
 	updateFn      func(ctx context.Context, t *domain.Text) error
+	deleteFn      func(ctx context.Context, userId, textId int64) error
 }
 
-func (m *mockService) GetText(ctx context.Context, cardId int64) (*domain.Text, error) {
+func (m *mockService) GetText(ctx context.Context, userId, cardId int64) (*domain.Text, error) {
 	m.getTextCalls++
 	if m.getTextFn == nil {
 		return nil, errors.New("GetText not stubbed")
@@ -73,6 +77,13 @@ func (m *mockService) UpdateText(ctx context.Context, card *domain.Text) error {
 	return m.updateFn(ctx, card)
 }
 
+func (m *mockService) DeleteText(ctx context.Context, userId, textId int64) error {
+	if m.deleteFn == nil {
+		return errors.New("DeleteText not stubbed")
+	}
+	return m.deleteFn(ctx, userId, textId)
+}
+
 func TestHttpHandler_CreateText(t *testing.T) {
 	logger.Log = zap.NewNop()
 
@@ -185,4 +196,59 @@ func TestHttpHandler_CreateText(t *testing.T) {
 			t.Fatalf("expected text_id=123, got %d", resp.TextID)
 		}
 	})
+
+	t.Run("expires_at -> passed into domain", func(t *testing.T) {
+		svc := &mockService{
+			createFn: func(ctx context.Context, t *domain.Text) (int64, error) {
+				return 1, nil
+			},
+		}
+		h := New(svc)
+
+		body := []byte(`{"title":"token","text":"secret","expires_at":"2026-12-31T00:00:00Z"}`)
+		req := httptest.NewRequest(http.MethodPost, "/create", bytes.NewReader(body))
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(42)))
+
+		rr := httptest.NewRecorder()
+		h.CreateText(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d; body=%s", rr.Code, rr.Body.String())
+		}
+
+		want := time.Date(2026, time.December, 31, 0, 0, 0, 0, time.UTC)
+		if svc.lastCreateArg == nil || !svc.lastCreateArg.ExpiresAt.Equal(want) {
+			t.Fatalf("expected ExpiresAt=%v, got %+v", want, svc.lastCreateArg)
+		}
+	})
+
+	t.Run("custom_fields -> passed into domain in order", func(t *testing.T) {
+		svc := &mockService{
+			createFn: func(ctx context.Context, t *domain.Text) (int64, error) {
+				return 1, nil
+			},
+		}
+		h := New(svc)
+
+		body := []byte(`{"title":"token","text":"secret","custom_fields":[` +
+			`{"name":"url","type":"url","value":"https://example.com"},` +
+			`{"name":"answer","type":"hidden","value":"rex"}]}`)
+		req := httptest.NewRequest(http.MethodPost, "/create", bytes.NewReader(body))
+		req = req.WithContext(context.WithValue(req.Context(), constants.UserIDKey, int64(42)))
+
+		rr := httptest.NewRecorder()
+		h.CreateText(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d; body=%s", rr.Code, rr.Body.String())
+		}
+
+		want := []custom_field.Field{
+			{Name: "url", Kind: custom_field.KindURL, Value: "https://example.com"},
+			{Name: "answer", Kind: custom_field.KindHidden, Value: "rex"},
+		}
+		if svc.lastCreateArg == nil || !reflect.DeepEqual(svc.lastCreateArg.CustomFields, want) {
+			t.Fatalf("expected CustomFields=%+v, got %+v", want, svc.lastCreateArg)
+		}
+	})
 }
@@ -0,0 +1,61 @@
+package text_obj
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	"testing"
+
+	domain "server/internal/app/domain/text_obj"
+	"server/internal/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+func TestHttpHandler_DeleteTextObj(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	newReq := func(id string, userID int64) *http.Request {
+		req := httptest.NewRequest(http.MethodDelete, "/delete/"+id, nil)
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+
+		ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+		return req.WithContext(context.WithValue(ctx, constants.UserIDKey, userID))
+	}
+
+	t.Run("not found -> 404", func(t *testing.T) {
+		svc := &mockService{
+			deleteFn: func(ctx context.Context, userId, textId int64) error {
+				return domain.ErrTextNotFound
+			},
+		}
+
+		rr := httptest.NewRecorder()
+		New(svc).DeleteTextObj(rr, newReq("4", 1))
+
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d", rr.Code)
+		}
+	})
+
+	t.Run("ok -> 204", func(t *testing.T) {
+		var gotID int64
+		svc := &mockService{
+			deleteFn: func(ctx context.Context, userId, textId int64) error {
+				gotID = textId
+				return nil
+			},
+		}
+
+		rr := httptest.NewRecorder()
+		New(svc).DeleteTextObj(rr, newReq("4", 1))
+
+		if rr.Code != http.StatusNoContent || gotID != 4 {
+			t.Fatalf("unexpected result: code=%d text=%d", rr.Code, gotID)
+		}
+	})
+}
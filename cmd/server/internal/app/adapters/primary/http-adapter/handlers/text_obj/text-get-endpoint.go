@@ -3,9 +3,11 @@ package text_obj
 import (
 	"net/http"
 	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
 	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/text_usecase"
 	"server/internal/pkg/logger"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
@@ -14,6 +16,11 @@ import (
 type TextResponse struct {
 	Title string `json:"title"`
 	Text  string `json:"text"`
+
+	ExpiresAt    *time.Time          `json:"expires_at,omitempty"`
+	CustomFields []codec.CustomField `json:"custom_fields,omitempty"`
+	OrgID        int64               `json:"org_id,omitempty"`
+	Shared       *codec.Shared       `json:"shared,omitempty"`
 }
 
 func (h *HttpHandler) GetTextObj(w http.ResponseWriter, r *http.Request) {
@@ -21,6 +28,12 @@ func (h *HttpHandler) GetTextObj(w http.ResponseWriter, r *http.Request) {
 
 	var resp = new(TextResponse)
 
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
 	urlId := chi.URLParam(r, "id")
 
 	id, err := strconv.ParseInt(urlId, 10, 64)
@@ -29,7 +42,7 @@ func (h *HttpHandler) GetTextObj(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	card, err := h.service.GetText(r.Context(), id)
+	card, err := h.service.GetText(r.Context(), userId, id)
 	if err != nil {
 		logger.Log.Error(HandlerName, zap.Error(err))
 
@@ -40,6 +53,10 @@ func (h *HttpHandler) GetTextObj(w http.ResponseWriter, r *http.Request) {
 
 	resp.Title = card.Title
 	resp.Text = card.Text
+	resp.ExpiresAt = codec.OptionalTime(card.ExpiresAt)
+	resp.CustomFields = codec.CustomFieldsFromDomain(card.CustomFields)
+	resp.OrgID = card.OrgID
+	resp.Shared = codec.SharedFromDomain(card.Shared)
 
 	codec.WriteJSON(w, http.StatusOK, resp)
 }
@@ -6,6 +6,7 @@ import (
 	"server/internal/app/adapters/primary/http-adapter/constants"
 	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/bank_card_usecase"
 	"server/internal/pkg/logger"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -13,6 +14,9 @@ import (
 type Text struct {
 	TextID int64  `json:"text_id"`
 	Title  string `json:"title"`
+
+	ExpiresAt    *time.Time              `json:"expires_at,omitempty"`
+	CustomFields []codec.CustomFieldName `json:"custom_fields,omitempty"`
 }
 
 func (h *HttpHandler) GetTextList(w http.ResponseWriter, r *http.Request) {
@@ -37,6 +41,9 @@ func (h *HttpHandler) GetTextList(w http.ResponseWriter, r *http.Request) {
 		c := Text{
 			Title:  item.Title,
 			TextID: item.TextId,
+
+			ExpiresAt:    codec.OptionalTime(item.ExpiresAt),
+			CustomFields: codec.CustomFieldNamesFromDomain(item.CustomFields),
 		}
 		resp = append(resp, c)
 	}
@@ -18,7 +18,7 @@ type mockServices struct {
 	lastUID   int64
 }
 
-func (m *mockServices) GetText(ctx context.Context, cardId int64) (*textDomain.Text, error) {
+func (m *mockServices) GetText(ctx context.Context, userId, cardId int64) (*textDomain.Text, error) {
 	panic("not used in these tests")
 }
 func (m *mockServices) CreateNewTextObj(ctx context.Context, card *textDomain.Text) (int64, error) {
@@ -28,6 +28,10 @@ func (m *mockServices) UpdateText(ctx context.Context, card *textDomain.Text) er
 	panic("not used in these tests")
 }
 
+func (m *mockServices) DeleteText(ctx context.Context, userId, textId int64) error {
+	panic("not used in these tests")
+}
+
 func (m *mockServices) GetTextList(ctx context.Context, userId int64) ([]*textDomain.Text, error) {
 	m.calls++
 	m.lastUID = userId
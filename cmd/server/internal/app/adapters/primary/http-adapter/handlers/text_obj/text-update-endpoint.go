@@ -9,6 +9,7 @@ import (
 	domain "server/internal/app/domain/text_obj"
 	"server/internal/pkg/logger"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
@@ -17,6 +18,9 @@ import (
 type UpdateTextRequest struct {
 	Title string `json:"title"`
 	Text  string `json:"Text"`
+
+	ExpiresAt    *time.Time          `json:"expires_at,omitempty"`
+	CustomFields []codec.CustomField `json:"custom_fields,omitempty"`
 }
 
 func (h *HttpHandler) UpdateTextObj(w http.ResponseWriter, r *http.Request) {
@@ -64,5 +68,8 @@ func (u *UpdateTextRequest) toDomain() *domain.Text {
 	return &domain.Text{
 		Title: u.Title,
 		Text:  u.Text,
+
+		ExpiresAt:    codec.TimeOrZero(u.ExpiresAt),
+		CustomFields: codec.CustomFieldsToDomain(u.CustomFields),
 	}
 }
@@ -0,0 +1,30 @@
+package tools
+
+import (
+	"context"
+	domain "server/internal/app/domain/tools"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type service interface {
+	GeneratePassword(ctx context.Context, opts domain.PasswordOptions) (*domain.GeneratedPassword, error)
+}
+
+type HttpHandler struct {
+	service service
+}
+
+func New(service service) *HttpHandler {
+	return &HttpHandler{
+		service: service,
+	}
+}
+
+func (h *HttpHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Post("/password", h.GeneratePassword)
+
+	return router
+}
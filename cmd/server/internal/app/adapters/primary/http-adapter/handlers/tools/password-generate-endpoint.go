@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/tools_usecase"
+	domain "server/internal/app/domain/tools"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type GeneratePasswordRequest struct {
+	Mode string `json:"mode"`
+
+	Length           int  `json:"length"`
+	Lower            bool `json:"lower"`
+	Upper            bool `json:"upper"`
+	Digits           bool `json:"digits"`
+	Symbols          bool `json:"symbols"`
+	ExcludeAmbiguous bool `json:"exclude_ambiguous"`
+
+	Words      int    `json:"words"`
+	Separator  string `json:"separator"`
+	Capitalize bool   `json:"capitalize"`
+}
+
+type GeneratePasswordResponse struct {
+	Password    string  `json:"password"`
+	Mode        string  `json:"mode"`
+	EntropyBits float64 `json:"entropy_bits"`
+}
+
+func (h *HttpHandler) GeneratePassword(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "GeneratePassword"
+
+	var req = new(GeneratePasswordRequest)
+
+	// an empty body asks for a password with default options
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "json decode error")
+			return
+		}
+	}
+
+	password, err := h.service.GeneratePassword(r.Context(), req.toDomain())
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, GeneratePasswordResponse{
+		Password:    password.Password,
+		Mode:        password.Mode,
+		EntropyBits: password.Entropy,
+	})
+}
+
+func (req GeneratePasswordRequest) toDomain() domain.PasswordOptions {
+	return domain.PasswordOptions{
+		Mode:             req.Mode,
+		Length:           req.Length,
+		Lower:            req.Lower,
+		Upper:            req.Upper,
+		Digits:           req.Digits,
+		Symbols:          req.Symbols,
+		ExcludeAmbiguous: req.ExcludeAmbiguous,
+		Words:            req.Words,
+		Separator:        req.Separator,
+		Capitalize:       req.Capitalize,
+	}
+}
@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "server/internal/app/domain/tools"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type serviceMock struct {
+	generateFn func(ctx context.Context, opts domain.PasswordOptions) (*domain.GeneratedPassword, error)
+}
+
+func (m *serviceMock) GeneratePassword(ctx context.Context, opts domain.PasswordOptions) (*domain.GeneratedPassword, error) {
+	return m.generateFn(ctx, opts)
+}
+
+func TestHttpHandler_GeneratePassword(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("bad json -> 422", func(t *testing.T) {
+		h := New(&serviceMock{
+			generateFn: func(ctx context.Context, opts domain.PasswordOptions) (*domain.GeneratedPassword, error) {
+				t.Fatalf("service must NOT be called on bad json")
+				return nil, nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/password", bytes.NewBufferString("{bad"))
+		rr := httptest.NewRecorder()
+
+		h.GeneratePassword(rr, req)
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("invalid options -> 400", func(t *testing.T) {
+		h := New(&serviceMock{
+			generateFn: func(ctx context.Context, opts domain.PasswordOptions) (*domain.GeneratedPassword, error) {
+				return nil, domain.ErrInvalidPasswordOptions
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/password", bytes.NewBufferString(`{"length":2}`))
+		rr := httptest.NewRecorder()
+
+		h.GeneratePassword(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("empty body -> defaults", func(t *testing.T) {
+		h := New(&serviceMock{
+			generateFn: func(ctx context.Context, opts domain.PasswordOptions) (*domain.GeneratedPassword, error) {
+				if opts != (domain.PasswordOptions{}) {
+					t.Fatalf("expected zero options, got %+v", opts)
+				}
+				return &domain.GeneratedPassword{Password: "x", Mode: domain.PasswordModeRandom, Entropy: 1}, nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/password", nil)
+		rr := httptest.NewRecorder()
+
+		h.GeneratePassword(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 200 + password and entropy", func(t *testing.T) {
+		h := New(&serviceMock{
+			generateFn: func(ctx context.Context, opts domain.PasswordOptions) (*domain.GeneratedPassword, error) {
+				if opts.Mode != domain.PasswordModePassphrase || opts.Words != 5 || opts.Separator != " " {
+					t.Fatalf("unexpected options: %+v", opts)
+				}
+				return &domain.GeneratedPassword{Password: "a b c d e", Mode: opts.Mode, Entropy: 64.6}, nil
+			},
+		})
+
+		body := `{"mode":"passphrase","words":5,"separator":" "}`
+		req := httptest.NewRequest(http.MethodPost, "/password", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		h.GeneratePassword(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+
+		var resp GeneratePasswordResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid json: %v body=%s", err, rr.Body.String())
+		}
+		if resp.Password != "a b c d e" || resp.EntropyBits != 64.6 {
+			t.Fatalf("unexpected resp: %+v", resp)
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package two_factor
+
+import (
+	"context"
+	domain "server/internal/app/domain/two_factor"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type service interface {
+	Setup(ctx context.Context, userId int64) (*domain.Enrollment, error)
+	Enable(ctx context.Context, userId int64, code string) ([]string, error)
+	Disable(ctx context.Context, userId int64, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userId int64, code string) ([]string, error)
+	Status(ctx context.Context, userId int64) (*domain.Status, error)
+}
+
+type HttpHandler struct {
+	service service
+}
+
+func New(service service) *HttpHandler {
+	return &HttpHandler{
+		service: service,
+	}
+}
+
+// Routes manage the second factor of the authenticated user, changes of an enabled one need a code
+func (h *HttpHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Get("/", h.Status)
+	router.Post("/setup", h.Setup)
+	router.Post("/enable", h.Enable)
+	router.Post("/disable", h.Disable)
+	router.Post("/recovery-codes", h.RegenerateRecoveryCodes)
+
+	return router
+}
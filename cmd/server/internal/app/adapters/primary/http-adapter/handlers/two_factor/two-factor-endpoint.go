@@ -0,0 +1,157 @@
+package two_factor
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/two_factor_usecase"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// CodeRequest carries a TOTP code, recovery codes are accepted where 2FA is already on
+type CodeRequest struct {
+	Code string `json:"code"`
+}
+
+type StatusResponse struct {
+	Enabled           bool `json:"enabled"`
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+}
+
+// SetupResponse is shown once, the secret is for manual entry and the uri for the QR code
+type SetupResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// RecoveryCodesResponse is the only time the plain recovery codes leave the server
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (h *HttpHandler) Status(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "TwoFactorStatus"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	status, err := h.service.Status(r.Context(), userId)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, StatusResponse{Enabled: status.Enabled, RecoveryCodesLeft: status.RecoveryCodesLeft})
+}
+
+// Setup starts an enrollment, 2FA is off until a code is confirmed by Enable
+func (h *HttpHandler) Setup(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "TwoFactorSetup"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	e, err := h.service.Setup(r.Context(), userId)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, SetupResponse{Secret: e.Secret, URI: e.URI})
+}
+
+func (h *HttpHandler) Enable(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "TwoFactorEnable"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	req := new(CodeRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "json decode error")
+		return
+	}
+
+	codes, err := h.service.Enable(r.Context(), userId, req.Code)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+func (h *HttpHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "TwoFactorDisable"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	req := new(CodeRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "json decode error")
+		return
+	}
+
+	if err := h.service.Disable(r.Context(), userId, req.Code); err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodes replaces every recovery code, the old ones stop working
+func (h *HttpHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "RegenerateRecoveryCodes"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	req := new(CodeRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "json decode error")
+		return
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodes(r.Context(), userId, req.Code)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, RecoveryCodesResponse{RecoveryCodes: codes})
+}
@@ -0,0 +1,240 @@
+package two_factor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	domain "server/internal/app/domain/two_factor"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type serviceMock struct {
+	setupFn      func(ctx context.Context, userId int64) (*domain.Enrollment, error)
+	enableFn     func(ctx context.Context, userId int64, code string) ([]string, error)
+	disableFn    func(ctx context.Context, userId int64, code string) error
+	regenerateFn func(ctx context.Context, userId int64, code string) ([]string, error)
+	statusFn     func(ctx context.Context, userId int64) (*domain.Status, error)
+}
+
+func (m *serviceMock) Setup(ctx context.Context, userId int64) (*domain.Enrollment, error) {
+	if m.setupFn == nil {
+		return nil, errors.New("Setup not stubbed")
+	}
+	return m.setupFn(ctx, userId)
+}
+
+func (m *serviceMock) Enable(ctx context.Context, userId int64, code string) ([]string, error) {
+	if m.enableFn == nil {
+		return nil, errors.New("Enable not stubbed")
+	}
+	return m.enableFn(ctx, userId, code)
+}
+
+func (m *serviceMock) Disable(ctx context.Context, userId int64, code string) error {
+	if m.disableFn == nil {
+		return errors.New("Disable not stubbed")
+	}
+	return m.disableFn(ctx, userId, code)
+}
+
+func (m *serviceMock) RegenerateRecoveryCodes(ctx context.Context, userId int64, code string) ([]string, error) {
+	if m.regenerateFn == nil {
+		return nil, errors.New("RegenerateRecoveryCodes not stubbed")
+	}
+	return m.regenerateFn(ctx, userId, code)
+}
+
+func (m *serviceMock) Status(ctx context.Context, userId int64) (*domain.Status, error) {
+	if m.statusFn == nil {
+		return nil, errors.New("Status not stubbed")
+	}
+	return m.statusFn(ctx, userId)
+}
+
+func withUser(r *http.Request, id int64) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), constants.UserIDKey, id))
+}
+
+func TestHttpHandler_Status(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("missing userID in context -> 422", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		New(&serviceMock{}).Status(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected 422, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 200", func(t *testing.T) {
+		h := New(&serviceMock{
+			statusFn: func(ctx context.Context, userId int64) (*domain.Status, error) {
+				return &domain.Status{Enabled: true, RecoveryCodesLeft: 9}, nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.Status(rr, withUser(httptest.NewRequest(http.MethodGet, "/", nil), 7))
+
+		var got StatusResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		if rr.Code != http.StatusOK || !got.Enabled || got.RecoveryCodesLeft != 9 {
+			t.Fatalf("unexpected response %d: %+v", rr.Code, got)
+		}
+	})
+}
+
+func TestHttpHandler_Setup(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("already enabled -> 409", func(t *testing.T) {
+		h := New(&serviceMock{
+			setupFn: func(ctx context.Context, userId int64) (*domain.Enrollment, error) {
+				return nil, domain.ErrAlreadyEnabled
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.Setup(rr, withUser(httptest.NewRequest(http.MethodPost, "/setup", nil), 7))
+
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("expected 409, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 200 + uri", func(t *testing.T) {
+		h := New(&serviceMock{
+			setupFn: func(ctx context.Context, userId int64) (*domain.Enrollment, error) {
+				return &domain.Enrollment{Secret: "ABC", URI: "otpauth://totp/Vault:alice?secret=ABC"}, nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.Setup(rr, withUser(httptest.NewRequest(http.MethodPost, "/setup", nil), 7))
+
+		var got SetupResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		if rr.Code != http.StatusOK || got.Secret != "ABC" || !strings.HasPrefix(got.URI, "otpauth://") {
+			t.Fatalf("unexpected response %d: %+v", rr.Code, got)
+		}
+	})
+}
+
+func TestHttpHandler_Enable(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("bad json -> 422", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		New(&serviceMock{}).Enable(rr, withUser(httptest.NewRequest(http.MethodPost, "/enable", strings.NewReader("{")), 7))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected 422, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("wrong code -> 401", func(t *testing.T) {
+		h := New(&serviceMock{
+			enableFn: func(ctx context.Context, userId int64, code string) ([]string, error) {
+				return nil, domain.ErrInvalidCode
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.Enable(rr, withUser(httptest.NewRequest(http.MethodPost, "/enable", strings.NewReader(`{"code":"000000"}`)), 7))
+
+		if rr.Code != http.StatusUnauthorized {
+			t.Fatalf("expected 401, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 200 + recovery codes", func(t *testing.T) {
+		var gotCode string
+		h := New(&serviceMock{
+			enableFn: func(ctx context.Context, userId int64, code string) ([]string, error) {
+				gotCode = code
+				return []string{"aaaa-bbbb-cccc-dddd"}, nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.Enable(rr, withUser(httptest.NewRequest(http.MethodPost, "/enable", strings.NewReader(`{"code":"123456"}`)), 7))
+
+		var got RecoveryCodesResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		if rr.Code != http.StatusOK || gotCode != "123456" || len(got.RecoveryCodes) != 1 {
+			t.Fatalf("unexpected response %d (%q): %+v", rr.Code, gotCode, got)
+		}
+	})
+}
+
+func TestHttpHandler_Disable(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("not enrolled -> 404", func(t *testing.T) {
+		h := New(&serviceMock{
+			disableFn: func(ctx context.Context, userId int64, code string) error {
+				return domain.ErrNotEnrolled
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.Disable(rr, withUser(httptest.NewRequest(http.MethodPost, "/disable", strings.NewReader(`{"code":"123456"}`)), 7))
+
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 204", func(t *testing.T) {
+		var gotID int64
+		h := New(&serviceMock{
+			disableFn: func(ctx context.Context, userId int64, code string) error {
+				gotID = userId
+				return nil
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		h.Disable(rr, withUser(httptest.NewRequest(http.MethodPost, "/disable", strings.NewReader(`{"code":"123456"}`)), 7))
+
+		if rr.Code != http.StatusNoContent || gotID != 7 {
+			t.Fatalf("expected 204 for user 7, got %d (%d), body=%s", rr.Code, gotID, rr.Body.String())
+		}
+	})
+}
+
+func TestHttpHandler_RegenerateRecoveryCodes(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	h := New(&serviceMock{
+		regenerateFn: func(ctx context.Context, userId int64, code string) ([]string, error) {
+			return []string{"a", "b"}, nil
+		},
+	})
+
+	rr := httptest.NewRecorder()
+	h.RegenerateRecoveryCodes(rr, withUser(httptest.NewRequest(http.MethodPost, "/recovery-codes", strings.NewReader(`{"code":"123456"}`)), 7))
+
+	var got RecoveryCodesResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if rr.Code != http.StatusOK || len(got.RecoveryCodes) != 2 {
+		t.Fatalf("unexpected response %d: %+v", rr.Code, got)
+	}
+}
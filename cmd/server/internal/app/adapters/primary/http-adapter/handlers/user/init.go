@@ -3,16 +3,26 @@ package user_obj
 import (
 	"context"
 	"server/internal/app/adapters/primary/http-adapter/middlewares"
+	domain "server/internal/app/domain/user"
 	"server/internal/pkg/token"
 
 	"github.com/go-chi/chi/v5"
 )
 
 type service interface {
-	RegisterNewUser(ctx context.Context, username, password string) (*token.Tokens, error)
-	Login(ctx context.Context, username, password string) (*token.Tokens, error)
-	Authenticate(token string) (int64, error)
-	RefreshJWTToken(ctx context.Context, jwt, refreshToken string) (*token.Tokens, error)
+	RegisterNewUser(ctx context.Context, username, password, device string) (*token.Tokens, error)
+	Login(ctx context.Context, username, password, device string) (*token.Tokens, error)
+	LoginTwoFactor(ctx context.Context, challenge, code string) (*token.Tokens, error)
+	Authenticate(token string) (*token.Claims, error)
+	RefreshJWTToken(ctx context.Context, refreshToken string) (*token.Tokens, error)
+	ChangePassword(ctx context.Context, userId, sessionId int64, oldPassword, newPassword string) (*token.Tokens, error)
+	ChangeUsername(ctx context.Context, userId int64, password, username string) error
+	DeleteAccount(ctx context.Context, userId int64, password string) error
+	Sessions(ctx context.Context, userId, currentSessionId int64) ([]*domain.Session, error)
+	RevokeSession(ctx context.Context, userId, sessionId int64) error
+	Revoked(ctx context.Context, claims *token.Claims) bool
+	Logout(ctx context.Context, claims *token.Claims) error
+	LogoutEverywhere(ctx context.Context, userId int64) error
 }
 
 type HttpHandler struct {
@@ -30,7 +40,17 @@ func (h *HttpHandler) Routes(service service) *chi.Mux {
 
 	r.Post("/auth/register", h.RegistrationHandler)
 	r.Post("/auth/login", h.LoginHandler)
-	r.With(middlewares.JWTMiddleware(service)).Post("/auth/refresh", h.RefreshTokenHandler)
+	r.Post("/auth/login/2fa", h.LoginTwoFactorHandler)
+	r.Post("/auth/refresh", h.RefreshTokenHandler)
+	r.With(middlewares.JWTMiddleware(service)).Post("/auth/logout", h.LogoutHandler)
+	r.With(middlewares.JWTMiddleware(service)).Post("/auth/logout/all", h.LogoutEverywhereHandler)
+
+	r.With(middlewares.JWTMiddleware(service)).Post("/account/password", h.ChangePasswordHandler)
+	r.With(middlewares.JWTMiddleware(service)).Post("/account/username", h.ChangeUsernameHandler)
+	r.With(middlewares.JWTMiddleware(service)).Delete("/account", h.DeleteAccountHandler)
+
+	r.With(middlewares.JWTMiddleware(service)).Get("/sessions", h.ListSessionsHandler)
+	r.With(middlewares.JWTMiddleware(service)).Delete("/sessions/{id}", h.RevokeSessionHandler)
 
 	return r
 }
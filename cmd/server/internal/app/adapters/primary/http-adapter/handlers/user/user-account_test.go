@@ -0,0 +1,259 @@
+package user_obj
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	domain "server/internal/app/domain/user"
+	"server/internal/pkg/logger"
+	"server/internal/pkg/token"
+
+	"go.uber.org/zap"
+)
+
+type mockServiceAccount struct {
+	changePasswordFn   func(ctx context.Context, userId, sessionId int64, oldPassword, newPassword string) (*token.Tokens, error)
+	changeUsernameFn   func(ctx context.Context, userId int64, password, username string) error
+	deleteAccountFn    func(ctx context.Context, userId int64, password string) error
+	sessionsFn         func(ctx context.Context, userId, currentSessionId int64) ([]*domain.Session, error)
+	revokeSessionFn    func(ctx context.Context, userId, sessionId int64) error
+	logoutFn           func(ctx context.Context, claims *token.Claims) error
+	logoutEverywhereFn func(ctx context.Context, userId int64) error
+	loginTwoFactorFn   func(ctx context.Context, challenge, code string) (*token.Tokens, error)
+}
+
+func (m *mockServiceAccount) RegisterNewUser(ctx context.Context, username, password, device string) (*token.Tokens, error) {
+	panic("not used")
+}
+func (m *mockServiceAccount) Login(ctx context.Context, username, password, device string) (*token.Tokens, error) {
+	panic("not used")
+}
+func (m *mockServiceAccount) LoginTwoFactor(ctx context.Context, challenge, code string) (*token.Tokens, error) {
+	if m.loginTwoFactorFn == nil {
+		return nil, errors.New("LoginTwoFactor not stubbed")
+	}
+	return m.loginTwoFactorFn(ctx, challenge, code)
+}
+func (m *mockServiceAccount) Authenticate(tk string) (*token.Claims, error) { panic("not used") }
+func (m *mockServiceAccount) RefreshJWTToken(ctx context.Context, refreshToken string) (*token.Tokens, error) {
+	panic("not used")
+}
+func (m *mockServiceAccount) ChangePassword(ctx context.Context, userId, sessionId int64, oldPassword, newPassword string) (*token.Tokens, error) {
+	if m.changePasswordFn == nil {
+		return nil, errors.New("ChangePassword not stubbed")
+	}
+	return m.changePasswordFn(ctx, userId, sessionId, oldPassword, newPassword)
+}
+func (m *mockServiceAccount) ChangeUsername(ctx context.Context, userId int64, password, username string) error {
+	if m.changeUsernameFn == nil {
+		return errors.New("ChangeUsername not stubbed")
+	}
+	return m.changeUsernameFn(ctx, userId, password, username)
+}
+func (m *mockServiceAccount) DeleteAccount(ctx context.Context, userId int64, password string) error {
+	if m.deleteAccountFn == nil {
+		return errors.New("DeleteAccount not stubbed")
+	}
+	return m.deleteAccountFn(ctx, userId, password)
+}
+
+func (m *mockServiceAccount) Sessions(ctx context.Context, userId, currentSessionId int64) ([]*domain.Session, error) {
+	if m.sessionsFn == nil {
+		return nil, errors.New("Sessions not stubbed")
+	}
+	return m.sessionsFn(ctx, userId, currentSessionId)
+}
+func (m *mockServiceAccount) RevokeSession(ctx context.Context, userId, sessionId int64) error {
+	if m.revokeSessionFn == nil {
+		return errors.New("RevokeSession not stubbed")
+	}
+	return m.revokeSessionFn(ctx, userId, sessionId)
+}
+
+func (m *mockServiceAccount) Revoked(ctx context.Context, claims *token.Claims) bool {
+	return false
+}
+func (m *mockServiceAccount) Logout(ctx context.Context, claims *token.Claims) error {
+	if m.logoutFn == nil {
+		return errors.New("Logout not stubbed")
+	}
+	return m.logoutFn(ctx, claims)
+}
+func (m *mockServiceAccount) LogoutEverywhere(ctx context.Context, userId int64) error {
+	if m.logoutEverywhereFn == nil {
+		return errors.New("LogoutEverywhere not stubbed")
+	}
+	return m.logoutEverywhereFn(ctx, userId)
+}
+
+func withUser(r *http.Request, id int64) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), constants.UserIDKey, id))
+}
+
+func withSession(r *http.Request, id int64) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), constants.SessionIDKey, id))
+}
+
+func jsonBody(t *testing.T, v any) *bytes.Reader {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	return bytes.NewReader(b)
+}
+
+func TestHttpHandler_ChangePasswordHandler(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("missing userID in context -> 422", func(t *testing.T) {
+		h := &HttpHandler{service: &mockServiceAccount{}}
+
+		rr := httptest.NewRecorder()
+		h.ChangePasswordHandler(rr, httptest.NewRequest(http.MethodPost, "/account/password", jsonBody(t, ChangePasswordRequest{})))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected 422, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("bad json -> 422", func(t *testing.T) {
+		h := &HttpHandler{service: &mockServiceAccount{}}
+
+		rr := httptest.NewRecorder()
+		h.ChangePasswordHandler(rr, withUser(httptest.NewRequest(http.MethodPost, "/account/password", bytes.NewBufferString("{bad")), 7))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected 422, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("wrong old password -> 401", func(t *testing.T) {
+		h := &HttpHandler{service: &mockServiceAccount{
+			changePasswordFn: func(ctx context.Context, userId, sessionId int64, oldPassword, newPassword string) (*token.Tokens, error) {
+				return nil, domain.ErrPasswordMismatch
+			},
+		}}
+
+		rr := httptest.NewRecorder()
+		body := jsonBody(t, ChangePasswordRequest{OldPassword: "bad", NewPassword: "new"})
+		h.ChangePasswordHandler(rr, withUser(httptest.NewRequest(http.MethodPost, "/account/password", body), 7))
+
+		if rr.Code != http.StatusUnauthorized {
+			t.Fatalf("expected 401, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 200 + new tokens", func(t *testing.T) {
+		var gotID, gotSession int64
+		var gotOld, gotNew string
+		h := &HttpHandler{service: &mockServiceAccount{
+			changePasswordFn: func(ctx context.Context, userId, sessionId int64, oldPassword, newPassword string) (*token.Tokens, error) {
+				gotID, gotSession, gotOld, gotNew = userId, sessionId, oldPassword, newPassword
+				return &token.Tokens{JWTToken: "jwt", RefreshToken: "rt"}, nil
+			},
+		}}
+
+		rr := httptest.NewRecorder()
+		body := jsonBody(t, ChangePasswordRequest{OldPassword: "old", NewPassword: "new"})
+		h.ChangePasswordHandler(rr, withSession(withUser(httptest.NewRequest(http.MethodPost, "/account/password", body), 7), 3))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+		if gotID != 7 || gotSession != 3 || gotOld != "old" || gotNew != "new" {
+			t.Fatalf("unexpected args: id=%d session=%d old=%q new=%q", gotID, gotSession, gotOld, gotNew)
+		}
+
+		var resp ChangePasswordResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		if resp.Token != "jwt" || resp.RefreshToken != "rt" {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+	})
+}
+
+func TestHttpHandler_ChangeUsernameHandler(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("taken -> 409", func(t *testing.T) {
+		h := &HttpHandler{service: &mockServiceAccount{
+			changeUsernameFn: func(ctx context.Context, userId int64, password, username string) error {
+				return domain.ErrUsernameAlreadyExists
+			},
+		}}
+
+		rr := httptest.NewRecorder()
+		body := jsonBody(t, ChangeUsernameRequest{Password: "secret", Username: "bob"})
+		h.ChangeUsernameHandler(rr, withUser(httptest.NewRequest(http.MethodPost, "/account/username", body), 7))
+
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("expected 409, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 200", func(t *testing.T) {
+		var gotName string
+		h := &HttpHandler{service: &mockServiceAccount{
+			changeUsernameFn: func(ctx context.Context, userId int64, password, username string) error {
+				gotName = username
+				return nil
+			},
+		}}
+
+		rr := httptest.NewRecorder()
+		body := jsonBody(t, ChangeUsernameRequest{Password: "secret", Username: "bob"})
+		h.ChangeUsernameHandler(rr, withUser(httptest.NewRequest(http.MethodPost, "/account/username", body), 7))
+
+		if rr.Code != http.StatusOK || gotName != "bob" {
+			t.Fatalf("expected 200 for bob, got %d (%q), body=%s", rr.Code, gotName, rr.Body.String())
+		}
+	})
+}
+
+func TestHttpHandler_DeleteAccountHandler(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("wrong password -> 401", func(t *testing.T) {
+		h := &HttpHandler{service: &mockServiceAccount{
+			deleteAccountFn: func(ctx context.Context, userId int64, password string) error {
+				return domain.ErrPasswordMismatch
+			},
+		}}
+
+		rr := httptest.NewRecorder()
+		body := jsonBody(t, DeleteAccountRequest{Password: "bad"})
+		h.DeleteAccountHandler(rr, withUser(httptest.NewRequest(http.MethodDelete, "/account", body), 7))
+
+		if rr.Code != http.StatusUnauthorized {
+			t.Fatalf("expected 401, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 204", func(t *testing.T) {
+		var gotID int64
+		h := &HttpHandler{service: &mockServiceAccount{
+			deleteAccountFn: func(ctx context.Context, userId int64, password string) error {
+				gotID = userId
+				return nil
+			},
+		}}
+
+		rr := httptest.NewRecorder()
+		body := jsonBody(t, DeleteAccountRequest{Password: "secret"})
+		h.DeleteAccountHandler(rr, withUser(httptest.NewRequest(http.MethodDelete, "/account", body), 7))
+
+		if rr.Code != http.StatusNoContent || gotID != 7 {
+			t.Fatalf("expected 204 for user 7, got %d (%d), body=%s", rr.Code, gotID, rr.Body.String())
+		}
+	})
+}
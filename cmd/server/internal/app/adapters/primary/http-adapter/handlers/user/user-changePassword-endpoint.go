@@ -0,0 +1,61 @@
+package user_obj
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/user_usecase"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+type ChangePasswordResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// ChangePasswordHandler replaces the password, other sessions are signed out and the caller gets new tokens
+func (h *HttpHandler) ChangePasswordHandler(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "ChangePasswordHandler"
+
+	var (
+		req  = new(ChangePasswordRequest)
+		resp = new(ChangePasswordResponse)
+	)
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	// the session of the request stays signed in
+	sessionId, _ := r.Context().Value(constants.SessionIDKey).(int64)
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "json decode error")
+		return
+	}
+
+	tokens, err := h.service.ChangePassword(r.Context(), userId, sessionId, req.OldPassword, req.NewPassword)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	resp.Token = tokens.JWTToken
+	resp.RefreshToken = tokens.RefreshToken
+
+	codec.WriteJSON(w, http.StatusOK, resp)
+}
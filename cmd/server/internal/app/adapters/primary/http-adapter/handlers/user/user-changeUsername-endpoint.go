@@ -0,0 +1,48 @@
+package user_obj
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/user_usecase"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type ChangeUsernameRequest struct {
+	Password string `json:"password"`
+	Username string `json:"username"`
+}
+
+func (h *HttpHandler) ChangeUsernameHandler(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "ChangeUsernameHandler"
+
+	var (
+		req = new(ChangeUsernameRequest)
+	)
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "json decode error")
+		return
+	}
+
+	err = h.service.ChangeUsername(r.Context(), userId, req.Password, req.Username)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	codec.WriteJSON(w, http.StatusOK, "updated username successfully")
+}
@@ -6,6 +6,7 @@ import (
 	"server/internal/app/adapters/primary/http-adapter/codec"
 	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/user_usecase"
 	"server/internal/pkg/logger"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -13,12 +14,18 @@ import (
 type LoginRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	// DeviceName labels the session, the user agent is used when empty
+	DeviceName string `json:"device_name"`
 }
 
 type LoginResponse struct {
 	Token        string `json:"token"`
 	RefreshToken string `json:"refresh_token"`
-	Error        string `json:"error"`
+	// TwoFactorRequired comes without tokens, the challenge is sent with a code to /auth/login/2fa
+	TwoFactorRequired  bool       `json:"two_factor_required,omitempty"`
+	Challenge          string     `json:"challenge,omitempty"`
+	ChallengeExpiresAt *time.Time `json:"challenge_expires_at,omitempty"`
+	Error              string     `json:"error"`
 }
 
 func (h *HttpHandler) LoginHandler(w http.ResponseWriter, r *http.Request) {
@@ -35,7 +42,7 @@ func (h *HttpHandler) LoginHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tokens, err := h.service.Login(r.Context(), req.Username, req.Password)
+	tokens, err := h.service.Login(r.Context(), req.Username, req.Password, req.DeviceName)
 	if err != nil {
 		logger.Log.Error(HandlerName, zap.Error(err))
 
@@ -44,6 +51,15 @@ func (h *HttpHandler) LoginHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if tokens.Challenge != "" {
+		resp.TwoFactorRequired = true
+		resp.Challenge = tokens.Challenge
+		resp.ChallengeExpiresAt = &tokens.ChallengeExpAt
+
+		codec.WriteJSON(w, http.StatusOK, resp)
+		return
+	}
+
 	resp.Token = tokens.JWTToken
 	resp.RefreshToken = tokens.RefreshToken
 
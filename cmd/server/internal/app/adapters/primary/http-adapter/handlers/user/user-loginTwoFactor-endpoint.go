@@ -0,0 +1,47 @@
+package user_obj
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/user_usecase"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type LoginTwoFactorRequest struct {
+	Challenge string `json:"challenge"`
+	// Code is the current TOTP code or an unused recovery code
+	Code string `json:"code"`
+}
+
+// LoginTwoFactorHandler finishes a login of a user with 2FA, the response is the one of a login without it
+func (h *HttpHandler) LoginTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "LoginTwoFactorHandler"
+
+	var (
+		req  = new(LoginTwoFactorRequest)
+		resp = new(LoginResponse)
+	)
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "json decode error")
+		return
+	}
+
+	tokens, err := h.service.LoginTwoFactor(r.Context(), req.Challenge, req.Code)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	resp.Token = tokens.JWTToken
+	resp.RefreshToken = tokens.RefreshToken
+
+	codec.WriteJSON(w, http.StatusOK, resp)
+}
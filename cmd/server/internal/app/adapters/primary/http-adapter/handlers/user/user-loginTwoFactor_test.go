@@ -0,0 +1,107 @@
+package user_obj
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	twoFactor "server/internal/app/domain/two_factor"
+	"server/internal/pkg/logger"
+	"server/internal/pkg/token"
+
+	"go.uber.org/zap"
+)
+
+func TestHttpHandler_LoginHandler_TwoFactor(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	expiresAt := time.Date(2026, 5, 1, 12, 5, 0, 0, time.UTC)
+	ms := &mockService{
+		loginFn: func(ctx context.Context, username, password string) (*token.Tokens, error) {
+			return &token.Tokens{UserId: 7, Challenge: "ch-1", ChallengeExpAt: expiresAt}, nil
+		},
+	}
+	h := &HttpHandler{service: ms}
+
+	rr := httptest.NewRecorder()
+	h.LoginHandler(rr, httptest.NewRequest(http.MethodPost, "/auth/login", jsonBody(t, LoginRequest{Username: "john", Password: "pass"})))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d, body=%s", rr.Code, rr.Body.String())
+	}
+
+	var got LoginResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if !got.TwoFactorRequired || got.Challenge != "ch-1" || got.Token != "" || got.RefreshToken != "" {
+		t.Fatalf("unexpected response: %+v", got)
+	}
+	if got.ChallengeExpiresAt == nil || !got.ChallengeExpiresAt.Equal(expiresAt) {
+		t.Fatalf("unexpected challenge expiry: %v", got.ChallengeExpiresAt)
+	}
+}
+
+func TestHttpHandler_LoginTwoFactorHandler(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("bad json -> 422", func(t *testing.T) {
+		h := &HttpHandler{service: &mockServiceAccount{}}
+
+		rr := httptest.NewRecorder()
+		h.LoginTwoFactorHandler(rr, httptest.NewRequest(http.MethodPost, "/auth/login/2fa", bytes.NewBufferString("{bad")))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected 422, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("wrong code -> 401", func(t *testing.T) {
+		h := &HttpHandler{service: &mockServiceAccount{
+			loginTwoFactorFn: func(ctx context.Context, challenge, code string) (*token.Tokens, error) {
+				return nil, twoFactor.ErrInvalidCode
+			},
+		}}
+
+		rr := httptest.NewRecorder()
+		body := jsonBody(t, LoginTwoFactorRequest{Challenge: "ch-1", Code: "000000"})
+		h.LoginTwoFactorHandler(rr, httptest.NewRequest(http.MethodPost, "/auth/login/2fa", body))
+
+		if rr.Code != http.StatusUnauthorized {
+			t.Fatalf("expected 401, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 200 + tokens", func(t *testing.T) {
+		var gotChallenge, gotCode string
+		h := &HttpHandler{service: &mockServiceAccount{
+			loginTwoFactorFn: func(ctx context.Context, challenge, code string) (*token.Tokens, error) {
+				gotChallenge, gotCode = challenge, code
+				return &token.Tokens{JWTToken: "jwt", RefreshToken: "rt"}, nil
+			},
+		}}
+
+		rr := httptest.NewRecorder()
+		body := jsonBody(t, LoginTwoFactorRequest{Challenge: "ch-1", Code: "123456"})
+		h.LoginTwoFactorHandler(rr, httptest.NewRequest(http.MethodPost, "/auth/login/2fa", body))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+		if gotChallenge != "ch-1" || gotCode != "123456" {
+			t.Fatalf("unexpected args: %q %q", gotChallenge, gotCode)
+		}
+
+		var resp LoginResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		if resp.Token != "jwt" || resp.RefreshToken != "rt" || resp.TwoFactorRequired {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+	})
+}
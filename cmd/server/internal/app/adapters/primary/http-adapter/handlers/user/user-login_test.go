@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	domain "server/internal/app/domain/user"
 	"server/internal/pkg/logger"
 	"server/internal/pkg/token"
 
@@ -23,17 +24,44 @@ type mockService struct {
 	lastP      string
 }
 
-func (m *mockService) RegisterNewUser(ctx context.Context, username, password string) (*token.Tokens, error) {
+func (m *mockService) RegisterNewUser(ctx context.Context, username, password, device string) (*token.Tokens, error) {
 	panic("not used")
 }
-func (m *mockService) Login(ctx context.Context, username, password string) (*token.Tokens, error) {
+func (m *mockService) Login(ctx context.Context, username, password, device string) (*token.Tokens, error) {
 	m.loginCalls++
 	m.lastU = username
 	m.lastP = password
 	return m.loginFn(ctx, username, password)
 }
-func (m *mockService) Authenticate(tk string) (int64, error) { panic("not used") }
-func (m *mockService) RefreshJWTToken(ctx context.Context, jwt, refreshToken string) (*token.Tokens, error) {
+func (m *mockService) LoginTwoFactor(ctx context.Context, challenge, code string) (*token.Tokens, error) {
+	panic("not used")
+}
+func (m *mockService) Authenticate(tk string) (*token.Claims, error) { panic("not used") }
+func (m *mockService) RefreshJWTToken(ctx context.Context, refreshToken string) (*token.Tokens, error) {
+	panic("not used")
+}
+func (m *mockService) ChangePassword(ctx context.Context, userId, sessionId int64, oldPassword, newPassword string) (*token.Tokens, error) {
+	panic("not used")
+}
+func (m *mockService) ChangeUsername(ctx context.Context, userId int64, password, username string) error {
+	panic("not used")
+}
+func (m *mockService) Sessions(ctx context.Context, userId, currentSessionId int64) ([]*domain.Session, error) {
+	panic("not used")
+}
+func (m *mockService) RevokeSession(ctx context.Context, userId, sessionId int64) error {
+	panic("not used")
+}
+func (m *mockService) Revoked(ctx context.Context, claims *token.Claims) bool {
+	panic("not used")
+}
+func (m *mockService) Logout(ctx context.Context, claims *token.Claims) error {
+	panic("not used")
+}
+func (m *mockService) LogoutEverywhere(ctx context.Context, userId int64) error {
+	panic("not used")
+}
+func (m *mockService) DeleteAccount(ctx context.Context, userId int64, password string) error {
 	panic("not used")
 }
 
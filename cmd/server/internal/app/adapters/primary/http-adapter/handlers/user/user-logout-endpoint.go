@@ -0,0 +1,54 @@
+package user_obj
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/user_usecase"
+	"server/internal/pkg/logger"
+	"server/internal/pkg/token"
+
+	"go.uber.org/zap"
+)
+
+// LogoutHandler signs the current device out, the JWT of the request stops working at once
+func (h *HttpHandler) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "LogoutHandler"
+
+	claims, ok := r.Context().Value(constants.ClaimsKey).(*token.Claims)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "token claims not found in context")
+		return
+	}
+
+	if err := h.service.Logout(r.Context(), claims); err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// LogoutEverywhereHandler signs every device of the user out, this one included
+func (h *HttpHandler) LogoutEverywhereHandler(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "LogoutEverywhereHandler"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	if err := h.service.LogoutEverywhere(r.Context(), userId); err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,99 @@
+package user_obj
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	"server/internal/pkg/logger"
+	"server/internal/pkg/token"
+
+	"go.uber.org/zap"
+)
+
+func withClaims(r *http.Request, c *token.Claims) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), constants.ClaimsKey, c))
+}
+
+func TestHttpHandler_LogoutHandler(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("missing claims in context -> 422", func(t *testing.T) {
+		h := &HttpHandler{service: &mockServiceAccount{}}
+
+		rr := httptest.NewRecorder()
+		h.LogoutHandler(rr, httptest.NewRequest(http.MethodPost, "/auth/logout", nil))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected 422, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("service error -> 500", func(t *testing.T) {
+		h := &HttpHandler{service: &mockServiceAccount{
+			logoutFn: func(ctx context.Context, claims *token.Claims) error {
+				return errors.New("db down")
+			},
+		}}
+
+		rr := httptest.NewRecorder()
+		h.LogoutHandler(rr, withClaims(httptest.NewRequest(http.MethodPost, "/auth/logout", nil), &token.Claims{UserID: 7}))
+
+		if rr.Code != http.StatusInternalServerError {
+			t.Fatalf("expected 500, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 204", func(t *testing.T) {
+		var got *token.Claims
+		h := &HttpHandler{service: &mockServiceAccount{
+			logoutFn: func(ctx context.Context, claims *token.Claims) error {
+				got = claims
+				return nil
+			},
+		}}
+
+		claims := &token.Claims{UserID: 7, SessionID: 3}
+		rr := httptest.NewRecorder()
+		h.LogoutHandler(rr, withClaims(httptest.NewRequest(http.MethodPost, "/auth/logout", nil), claims))
+
+		if rr.Code != http.StatusNoContent || got != claims {
+			t.Fatalf("expected 204 with the claims of the request, got %d (%+v), body=%s", rr.Code, got, rr.Body.String())
+		}
+	})
+}
+
+func TestHttpHandler_LogoutEverywhereHandler(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("missing userID in context -> 422", func(t *testing.T) {
+		h := &HttpHandler{service: &mockServiceAccount{}}
+
+		rr := httptest.NewRecorder()
+		h.LogoutEverywhereHandler(rr, httptest.NewRequest(http.MethodPost, "/auth/logout/all", nil))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected 422, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 204", func(t *testing.T) {
+		var gotID int64
+		h := &HttpHandler{service: &mockServiceAccount{
+			logoutEverywhereFn: func(ctx context.Context, userId int64) error {
+				gotID = userId
+				return nil
+			},
+		}}
+
+		rr := httptest.NewRecorder()
+		h.LogoutEverywhereHandler(rr, withUser(httptest.NewRequest(http.MethodPost, "/auth/logout/all", nil), 7))
+
+		if rr.Code != http.StatusNoContent || gotID != 7 {
+			t.Fatalf("expected 204 for user 7, got %d (%d), body=%s", rr.Code, gotID, rr.Body.String())
+		}
+	})
+}
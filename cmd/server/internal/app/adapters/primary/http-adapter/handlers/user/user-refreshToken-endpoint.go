@@ -10,9 +10,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// RefreshTokenRequest carries the refresh token alone, the endpoint works after the JWT has expired
 type RefreshTokenRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 type RefreshTokenResponse struct {
@@ -34,7 +34,7 @@ func (h *HttpHandler) RefreshTokenHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	tokens, err := h.service.RefreshJWTToken(r.Context(), req.Username, req.Password)
+	tokens, err := h.service.RefreshJWTToken(r.Context(), req.RefreshToken)
 	if err != nil {
 		logger.Log.Error(HandlerName, zap.Error(err))
 
@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	domain "server/internal/app/domain/user"
 	"server/internal/pkg/logger"
 	"server/internal/pkg/token"
 
@@ -16,27 +17,52 @@ import (
 )
 
 type mockServiceRefresh struct {
-	refreshFn func(ctx context.Context, jwt, refresh string) (*token.Tokens, error)
+	refreshFn func(ctx context.Context, refresh string) (*token.Tokens, error)
 
 	refreshCalls int
-	lastJWT      string
 	lastRT       string
 }
 
-func (m *mockServiceRefresh) RegisterNewUser(ctx context.Context, username, password string) (*token.Tokens, error) {
+func (m *mockServiceRefresh) RegisterNewUser(ctx context.Context, username, password, device string) (*token.Tokens, error) {
 	panic("not used")
 }
-func (m *mockServiceRefresh) Login(ctx context.Context, username, password string) (*token.Tokens, error) {
+func (m *mockServiceRefresh) Login(ctx context.Context, username, password, device string) (*token.Tokens, error) {
 	panic("not used")
 }
-func (m *mockServiceRefresh) Authenticate(tk string) (int64, error) {
+func (m *mockServiceRefresh) LoginTwoFactor(ctx context.Context, challenge, code string) (*token.Tokens, error) {
 	panic("not used")
 }
-func (m *mockServiceRefresh) RefreshJWTToken(ctx context.Context, jwt, refreshToken string) (*token.Tokens, error) {
+func (m *mockServiceRefresh) Authenticate(tk string) (*token.Claims, error) {
+	panic("not used")
+}
+func (m *mockServiceRefresh) RefreshJWTToken(ctx context.Context, refreshToken string) (*token.Tokens, error) {
 	m.refreshCalls++
-	m.lastJWT = jwt
 	m.lastRT = refreshToken
-	return m.refreshFn(ctx, jwt, refreshToken)
+	return m.refreshFn(ctx, refreshToken)
+}
+func (m *mockServiceRefresh) ChangePassword(ctx context.Context, userId, sessionId int64, oldPassword, newPassword string) (*token.Tokens, error) {
+	panic("not used")
+}
+func (m *mockServiceRefresh) ChangeUsername(ctx context.Context, userId int64, password, username string) error {
+	panic("not used")
+}
+func (m *mockServiceRefresh) Sessions(ctx context.Context, userId, currentSessionId int64) ([]*domain.Session, error) {
+	panic("not used")
+}
+func (m *mockServiceRefresh) RevokeSession(ctx context.Context, userId, sessionId int64) error {
+	panic("not used")
+}
+func (m *mockServiceRefresh) Revoked(ctx context.Context, claims *token.Claims) bool {
+	panic("not used")
+}
+func (m *mockServiceRefresh) Logout(ctx context.Context, claims *token.Claims) error {
+	panic("not used")
+}
+func (m *mockServiceRefresh) LogoutEverywhere(ctx context.Context, userId int64) error {
+	panic("not used")
+}
+func (m *mockServiceRefresh) DeleteAccount(ctx context.Context, userId int64, password string) error {
+	panic("not used")
 }
 
 func TestHttpHandler_RefreshTokenHandler(t *testing.T) {
@@ -45,7 +71,7 @@ func TestHttpHandler_RefreshTokenHandler(t *testing.T) {
 
 	t.Run("bad json -> 422 and service NOT called", func(t *testing.T) {
 		ms := &mockServiceRefresh{
-			refreshFn: func(ctx context.Context, jwt, refresh string) (*token.Tokens, error) {
+			refreshFn: func(ctx context.Context, refresh string) (*token.Tokens, error) {
 				return &token.Tokens{}, nil
 			},
 		}
@@ -66,15 +92,14 @@ func TestHttpHandler_RefreshTokenHandler(t *testing.T) {
 
 	t.Run("service returns error -> status != 200 and error response", func(t *testing.T) {
 		ms := &mockServiceRefresh{
-			refreshFn: func(ctx context.Context, jwt, refresh string) (*token.Tokens, error) {
+			refreshFn: func(ctx context.Context, refresh string) (*token.Tokens, error) {
 				return nil, errors.New("refresh failed")
 			},
 		}
 		h := &HttpHandler{service: ms}
 
 		body, _ := json.Marshal(RefreshTokenRequest{
-			Username: "jwt-123",
-			Password: "rt-456",
+			RefreshToken: "sel.rt-456",
 		})
 		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", bytes.NewReader(body))
 		rr := httptest.NewRecorder()
@@ -84,8 +109,8 @@ func TestHttpHandler_RefreshTokenHandler(t *testing.T) {
 		if ms.refreshCalls != 1 {
 			t.Fatalf("expected service called once, got %d", ms.refreshCalls)
 		}
-		if ms.lastJWT != "jwt-123" || ms.lastRT != "rt-456" {
-			t.Fatalf("unexpected args: jwt=%q rt=%q", ms.lastJWT, ms.lastRT)
+		if ms.lastRT != "sel.rt-456" {
+			t.Fatalf("unexpected refresh token: %q", ms.lastRT)
 		}
 
 		if rr.Code == http.StatusOK {
@@ -96,9 +121,27 @@ func TestHttpHandler_RefreshTokenHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("expired refresh token -> 401", func(t *testing.T) {
+		ms := &mockServiceRefresh{
+			refreshFn: func(ctx context.Context, refresh string) (*token.Tokens, error) {
+				return nil, domain.ErrRefreshTokenExpired
+			},
+		}
+		h := &HttpHandler{service: ms}
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", bytes.NewBufferString(`{"refresh_token":"sel.old-rt"}`))
+		rr := httptest.NewRecorder()
+
+		h.RefreshTokenHandler(rr, req)
+
+		if rr.Code != http.StatusUnauthorized {
+			t.Fatalf("expected 401, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
 	t.Run("ok -> 200 and returns tokens", func(t *testing.T) {
 		ms := &mockServiceRefresh{
-			refreshFn: func(ctx context.Context, jwt, refresh string) (*token.Tokens, error) {
+			refreshFn: func(ctx context.Context, refresh string) (*token.Tokens, error) {
 				return &token.Tokens{
 					JWTToken:     "new-jwt",
 					RefreshToken: "new-rt",
@@ -108,8 +151,7 @@ func TestHttpHandler_RefreshTokenHandler(t *testing.T) {
 		h := &HttpHandler{service: ms}
 
 		body, _ := json.Marshal(RefreshTokenRequest{
-			Username: "old-jwt",
-			Password: "old-rt",
+			RefreshToken: "sel.old-rt",
 		})
 		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", bytes.NewReader(body))
 		rr := httptest.NewRecorder()
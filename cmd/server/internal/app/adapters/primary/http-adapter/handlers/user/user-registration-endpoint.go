@@ -13,6 +13,8 @@ import (
 type RegisterNewUserRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	// DeviceName labels the session, the user agent is used when empty
+	DeviceName string `json:"device_name"`
 }
 
 type RegisterNewUserResponse struct {
@@ -33,7 +35,7 @@ func (h *HttpHandler) RegistrationHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	tokens, err := h.service.RegisterNewUser(r.Context(), req.Username, req.Password)
+	tokens, err := h.service.RegisterNewUser(r.Context(), req.Username, req.Password, req.DeviceName)
 	if err != nil {
 		logger.Log.Error(HandlerName, zap.Error(err))
 
@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	domain "server/internal/app/domain/user"
 	"server/internal/pkg/logger"
 	"server/internal/pkg/token"
 
@@ -23,20 +24,47 @@ type mockServiceRegister struct {
 	lastPassword  string
 }
 
-func (m *mockServiceRegister) RegisterNewUser(ctx context.Context, username, password string) (*token.Tokens, error) {
+func (m *mockServiceRegister) RegisterNewUser(ctx context.Context, username, password, device string) (*token.Tokens, error) {
 	m.registerCalls++
 	m.lastUsername = username
 	m.lastPassword = password
 	return m.registerFn(ctx, username, password)
 }
 
-func (m *mockServiceRegister) Login(ctx context.Context, username, password string) (*token.Tokens, error) {
+func (m *mockServiceRegister) Login(ctx context.Context, username, password, device string) (*token.Tokens, error) {
 	panic("not used")
 }
-func (m *mockServiceRegister) Authenticate(tk string) (int64, error) {
+func (m *mockServiceRegister) LoginTwoFactor(ctx context.Context, challenge, code string) (*token.Tokens, error) {
 	panic("not used")
 }
-func (m *mockServiceRegister) RefreshJWTToken(ctx context.Context, jwt, refreshToken string) (*token.Tokens, error) {
+func (m *mockServiceRegister) Authenticate(tk string) (*token.Claims, error) {
+	panic("not used")
+}
+func (m *mockServiceRegister) RefreshJWTToken(ctx context.Context, refreshToken string) (*token.Tokens, error) {
+	panic("not used")
+}
+func (m *mockServiceRegister) ChangePassword(ctx context.Context, userId, sessionId int64, oldPassword, newPassword string) (*token.Tokens, error) {
+	panic("not used")
+}
+func (m *mockServiceRegister) ChangeUsername(ctx context.Context, userId int64, password, username string) error {
+	panic("not used")
+}
+func (m *mockServiceRegister) Sessions(ctx context.Context, userId, currentSessionId int64) ([]*domain.Session, error) {
+	panic("not used")
+}
+func (m *mockServiceRegister) RevokeSession(ctx context.Context, userId, sessionId int64) error {
+	panic("not used")
+}
+func (m *mockServiceRegister) Revoked(ctx context.Context, claims *token.Claims) bool {
+	panic("not used")
+}
+func (m *mockServiceRegister) Logout(ctx context.Context, claims *token.Claims) error {
+	panic("not used")
+}
+func (m *mockServiceRegister) LogoutEverywhere(ctx context.Context, userId int64) error {
+	panic("not used")
+}
+func (m *mockServiceRegister) DeleteAccount(ctx context.Context, userId int64, password string) error {
 	panic("not used")
 }
 
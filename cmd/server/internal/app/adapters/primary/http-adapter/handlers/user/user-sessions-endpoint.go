@@ -0,0 +1,91 @@
+package user_obj
+
+import (
+	"net/http"
+	"server/internal/app/adapters/primary/http-adapter/codec"
+	"server/internal/app/adapters/primary/http-adapter/constants"
+	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/user_usecase"
+	domain "server/internal/app/domain/user"
+	"server/internal/pkg/logger"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type Session struct {
+	ID         int64     `json:"id"`
+	DeviceName string    `json:"device_name"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Current    bool      `json:"current"`
+}
+
+// ListSessionsHandler lists the signed-in devices of the user
+func (h *HttpHandler) ListSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "ListSessionsHandler"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	sessionId, _ := r.Context().Value(constants.SessionIDKey).(int64)
+
+	list, err := h.service.Sessions(r.Context(), userId, sessionId)
+	if err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	resp := make([]Session, 0, len(list))
+	for _, s := range list {
+		resp = append(resp, fromDomain(s))
+	}
+
+	codec.WriteJSON(w, http.StatusOK, resp)
+}
+
+// RevokeSessionHandler signs a device out, the refresh token of the session stops working
+func (h *HttpHandler) RevokeSessionHandler(w http.ResponseWriter, r *http.Request) {
+	const HandlerName = "RevokeSessionHandler"
+
+	userId, ok := r.Context().Value(constants.UserIDKey).(int64)
+	if !ok {
+		codec.WriteErrorJSON(w, http.StatusUnprocessableEntity, "user ID not found in context")
+		return
+	}
+
+	sessionId, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		codec.WriteErrorJSON(w, http.StatusBadRequest, "invalid session id")
+		return
+	}
+
+	if err := h.service.RevokeSession(r.Context(), userId, sessionId); err != nil {
+		logger.Log.Error(HandlerName, zap.Error(err))
+
+		s, m := errorMapper.Process(err)
+		codec.WriteErrorJSON(w, s, m)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func fromDomain(s *domain.Session) Session {
+	return Session{
+		ID:         s.ID,
+		DeviceName: s.DeviceName,
+		IP:         s.IP,
+		CreatedAt:  s.CreatedAt,
+		LastUsedAt: s.LastUsedAt,
+		Current:    s.Current,
+	}
+}
@@ -0,0 +1,120 @@
+package user_obj
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "server/internal/app/domain/user"
+	"server/internal/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+func withSessionParam(r *http.Request, id string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHttpHandler_ListSessionsHandler(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("missing userID in context -> 422", func(t *testing.T) {
+		h := &HttpHandler{service: &mockServiceAccount{}}
+
+		rr := httptest.NewRecorder()
+		h.ListSessionsHandler(rr, httptest.NewRequest(http.MethodGet, "/sessions", nil))
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected 422, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 200 + sessions with current one marked", func(t *testing.T) {
+		var gotCurrent int64
+		h := &HttpHandler{service: &mockServiceAccount{
+			sessionsFn: func(ctx context.Context, userId, currentSessionId int64) ([]*domain.Session, error) {
+				gotCurrent = currentSessionId
+				return []*domain.Session{
+					{ID: 3, DeviceName: "laptop", IP: "10.0.0.1", Current: true},
+					{ID: 4, DeviceName: "phone"},
+				}, nil
+			},
+		}}
+
+		rr := httptest.NewRecorder()
+		h.ListSessionsHandler(rr, withSession(withUser(httptest.NewRequest(http.MethodGet, "/sessions", nil), 7), 3))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+		if gotCurrent != 3 {
+			t.Fatalf("expected current session 3, got %d", gotCurrent)
+		}
+
+		var resp []Session
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		if len(resp) != 2 || !resp[0].Current || resp[0].DeviceName != "laptop" || resp[1].Current {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+	})
+}
+
+func TestHttpHandler_RevokeSessionHandler(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	t.Run("invalid id -> 400", func(t *testing.T) {
+		h := &HttpHandler{service: &mockServiceAccount{}}
+
+		rr := httptest.NewRecorder()
+		req := withSessionParam(withUser(httptest.NewRequest(http.MethodDelete, "/sessions/x", nil), 7), "x")
+		h.RevokeSessionHandler(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("not found -> 404", func(t *testing.T) {
+		h := &HttpHandler{service: &mockServiceAccount{
+			revokeSessionFn: func(ctx context.Context, userId, sessionId int64) error {
+				return domain.ErrSessionNotFound
+			},
+		}}
+
+		rr := httptest.NewRecorder()
+		req := withSessionParam(withUser(httptest.NewRequest(http.MethodDelete, "/sessions/9", nil), 7), "9")
+		h.RevokeSessionHandler(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok -> 204", func(t *testing.T) {
+		var gotUser, gotSession int64
+		h := &HttpHandler{service: &mockServiceAccount{
+			revokeSessionFn: func(ctx context.Context, userId, sessionId int64) error {
+				gotUser, gotSession = userId, sessionId
+				return nil
+			},
+		}}
+
+		rr := httptest.NewRecorder()
+		req := withSessionParam(withUser(httptest.NewRequest(http.MethodDelete, "/sessions/4", nil), 7), "4")
+		h.RevokeSessionHandler(rr, req)
+
+		if rr.Code != http.StatusNoContent {
+			t.Fatalf("expected 204, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+		if gotUser != 7 || gotSession != 4 {
+			t.Fatalf("unexpected args: user=%d session=%d", gotUser, gotSession)
+		}
+	})
+}
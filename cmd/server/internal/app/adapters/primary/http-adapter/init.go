@@ -3,15 +3,40 @@ package http_adapter
 import (
 	"context"
 	account_router "server/internal/app/adapters/primary/http-adapter/handlers/account_obj"
+	attachment_router "server/internal/app/adapters/primary/http-adapter/handlers/attachment"
+	audit_router "server/internal/app/adapters/primary/http-adapter/handlers/audit"
 	bankCard_router "server/internal/app/adapters/primary/http-adapter/handlers/bank_card_obj"
+	cert_router "server/internal/app/adapters/primary/http-adapter/handlers/cert_obj"
+	emergency_router "server/internal/app/adapters/primary/http-adapter/handlers/emergency"
 	file_router "server/internal/app/adapters/primary/http-adapter/handlers/file_obj"
+	folder_router "server/internal/app/adapters/primary/http-adapter/handlers/folder"
+	notification_router "server/internal/app/adapters/primary/http-adapter/handlers/notification"
+	org_router "server/internal/app/adapters/primary/http-adapter/handlers/org"
+	report_router "server/internal/app/adapters/primary/http-adapter/handlers/report"
+	secret_link_router "server/internal/app/adapters/primary/http-adapter/handlers/secret_link"
+	share_router "server/internal/app/adapters/primary/http-adapter/handlers/share"
+	ssh_key_router "server/internal/app/adapters/primary/http-adapter/handlers/ssh_key_obj"
 	text_router "server/internal/app/adapters/primary/http-adapter/handlers/text_obj"
+	tools_router "server/internal/app/adapters/primary/http-adapter/handlers/tools"
+	two_factor_router "server/internal/app/adapters/primary/http-adapter/handlers/two_factor"
 	user_router "server/internal/app/adapters/primary/http-adapter/handlers/user"
 	"server/internal/app/adapters/primary/http-adapter/middlewares"
 	account "server/internal/app/usecases/account_obj"
+	"server/internal/app/usecases/attachment"
+	"server/internal/app/usecases/audit"
 	bankCard "server/internal/app/usecases/bank_card_obj"
+	cert "server/internal/app/usecases/cert_obj"
+	"server/internal/app/usecases/emergency"
 	file "server/internal/app/usecases/file_obj"
+	"server/internal/app/usecases/folder"
+	"server/internal/app/usecases/notification"
+	"server/internal/app/usecases/org"
+	secretLink "server/internal/app/usecases/secret_link"
+	"server/internal/app/usecases/share"
+	sshKey "server/internal/app/usecases/ssh_key_obj"
 	text "server/internal/app/usecases/text_obj"
+	"server/internal/app/usecases/tools"
+	twoFactor "server/internal/app/usecases/two_factor"
 	"server/internal/app/usecases/user"
 	http_server "server/internal/pkg/http-server"
 
@@ -23,11 +48,23 @@ type HttpAdapter struct {
 }
 
 type Srv struct {
-	UserUseCase        *user.User
-	AccountObjUseCase  *account.AccountObj
-	BankCardObjUseCase *bankCard.BankCardObj
-	TextObjUseCase     *text.TextObj
-	FileObjUseCase     *file.FileObj
+	UserUseCase         *user.User
+	AccountObjUseCase   *account.AccountObj
+	BankCardObjUseCase  *bankCard.BankCardObj
+	TextObjUseCase      *text.TextObj
+	FileObjUseCase      *file.FileObj
+	SSHKeyObjUseCase    *sshKey.SSHKeyObj
+	CertObjUseCase      *cert.CertObj
+	AttachmentUseCase   *attachment.Attachments
+	FolderUseCase       *folder.Folders
+	ShareUseCase        *share.Shares
+	OrgUseCase          *org.Orgs
+	EmergencyUseCase    *emergency.Emergency
+	SecretLinkUseCase   *secretLink.Links
+	ToolsUseCase        *tools.Tools
+	NotificationUseCase *notification.Notifications
+	AuditUseCase        *audit.Audit
+	TwoFactorUseCase    *twoFactor.TwoFactor
 }
 
 func New(svc *Srv) *HttpAdapter {
@@ -56,17 +93,75 @@ func newRouter(srv *Srv) *chi.Mux {
 	// file handler
 	fileRouter := file_router.New(srv.FileObjUseCase)
 
+	// ssh key handler
+	sshKeyRouter := ssh_key_router.New(srv.SSHKeyObjUseCase)
+
+	// certificate handler
+	certRouter := cert_router.New(srv.CertObjUseCase)
+
+	// attachment handler
+	attachmentRouter := attachment_router.New(srv.AttachmentUseCase)
+
+	// folder handler
+	folderRouter := folder_router.New(srv.FolderUseCase)
+
+	// share handler
+	shareRouter := share_router.New(srv.ShareUseCase)
+
+	// org handler
+	orgRouter := org_router.New(srv.OrgUseCase)
+
+	// emergency handler
+	emergencyRouter := emergency_router.New(srv.EmergencyUseCase)
+
+	// secret link handler
+	secretLinkRouter := secret_link_router.New(srv.SecretLinkUseCase)
+
+	// report handler
+	reportRouter := report_router.New(srv.AccountObjUseCase)
+
+	// tools handler
+	toolsRouter := tools_router.New(srv.ToolsUseCase)
+
+	// notification handler
+	notificationRouter := notification_router.New(srv.NotificationUseCase)
+
+	// audit handler
+	auditRouter := audit_router.New(srv.AuditUseCase)
+
+	// two-factor handler
+	twoFactorRouter := two_factor_router.New(srv.TwoFactorUseCase)
+
 	// create router
 	r := chi.NewRouter()
 
+	// client address and user agent for the audit log
+	r.Use(middlewares.ClientMiddleware)
+
 	// mount user router
 	r.Mount("/user", userRouter.Routes(srv.UserUseCase))
 
+	// secret links are opened by people without an account
+	r.Mount("/s", secretLinkRouter.PublicRoutes())
+
 	// mount account object router with jwt authentification middleware
 	r.With(middlewares.JWTMiddleware(srv.UserUseCase)).Mount("/account", accountRouter.Routes())
 	r.With(middlewares.JWTMiddleware(srv.UserUseCase)).Mount("/card", bankCardRouter.Routes())
 	r.With(middlewares.JWTMiddleware(srv.UserUseCase)).Mount("/text", textRouter.Routes())
 	r.With(middlewares.JWTMiddleware(srv.UserUseCase)).Mount("/file", fileRouter.Routes())
+	r.With(middlewares.JWTMiddleware(srv.UserUseCase)).Mount("/ssh", sshKeyRouter.Routes())
+	r.With(middlewares.JWTMiddleware(srv.UserUseCase)).Mount("/cert", certRouter.Routes())
+	r.With(middlewares.JWTMiddleware(srv.UserUseCase)).Mount("/attachment", attachmentRouter.Routes())
+	r.With(middlewares.JWTMiddleware(srv.UserUseCase)).Mount("/folder", folderRouter.Routes())
+	r.With(middlewares.JWTMiddleware(srv.UserUseCase)).Mount("/share", shareRouter.Routes())
+	r.With(middlewares.JWTMiddleware(srv.UserUseCase)).Mount("/org", orgRouter.Routes())
+	r.With(middlewares.JWTMiddleware(srv.UserUseCase)).Mount("/emergency", emergencyRouter.Routes())
+	r.With(middlewares.JWTMiddleware(srv.UserUseCase)).Mount("/secret", secretLinkRouter.Routes())
+	r.With(middlewares.JWTMiddleware(srv.UserUseCase)).Mount("/tools", toolsRouter.Routes())
+	r.With(middlewares.JWTMiddleware(srv.UserUseCase)).Mount("/report", reportRouter.Routes())
+	r.With(middlewares.JWTMiddleware(srv.UserUseCase)).Mount("/notifications", notificationRouter.Routes())
+	r.With(middlewares.JWTMiddleware(srv.UserUseCase)).Mount("/audit", auditRouter.Routes())
+	r.With(middlewares.JWTMiddleware(srv.UserUseCase)).Mount("/2fa", twoFactorRouter.Routes())
 
 	return r
 }
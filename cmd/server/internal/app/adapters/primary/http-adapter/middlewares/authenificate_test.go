@@ -1,10 +1,12 @@
 package middlewares
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"server/internal/app/adapters/primary/http-adapter/constants"
+	"server/internal/pkg/token"
 	"strings"
 	"testing"
 )
@@ -12,23 +14,28 @@ import (
 // ---- mock auth service ----
 
 type mockAuthService struct {
-	authenticateFn func(token string) (int64, error)
+	authenticateFn func(tk string) (*token.Claims, error)
+	revoked        bool
 
 	calls     int
 	lastToken string
 }
 
-func (m *mockAuthService) Authenticate(token string) (int64, error) {
+func (m *mockAuthService) Authenticate(tk string) (*token.Claims, error) {
 	m.calls++
-	m.lastToken = token
-	return m.authenticateFn(token)
+	m.lastToken = tk
+	return m.authenticateFn(tk)
+}
+
+func (m *mockAuthService) Revoked(ctx context.Context, claims *token.Claims) bool {
+	return m.revoked
 }
 
 func TestJWTMiddleware(t *testing.T) {
 	t.Run("missing Authorization -> 401 and service NOT called", func(t *testing.T) {
 		svc := &mockAuthService{
-			authenticateFn: func(token string) (int64, error) {
-				return 0, nil
+			authenticateFn: func(tk string) (*token.Claims, error) {
+				return nil, nil
 			},
 		}
 
@@ -61,8 +68,8 @@ func TestJWTMiddleware(t *testing.T) {
 
 	t.Run("Authenticate returns error -> status != 200 and next NOT called", func(t *testing.T) {
 		svc := &mockAuthService{
-			authenticateFn: func(token string) (int64, error) {
-				return 0, errors.New("invalid token")
+			authenticateFn: func(tk string) (*token.Claims, error) {
+				return nil, errors.New("invalid token")
 			},
 		}
 
@@ -100,13 +107,13 @@ func TestJWTMiddleware(t *testing.T) {
 		}
 	})
 
-	t.Run("ok -> calls next and puts userID in context", func(t *testing.T) {
+	t.Run("ok -> calls next and puts userID and sessionID in context", func(t *testing.T) {
 		svc := &mockAuthService{
-			authenticateFn: func(token string) (int64, error) {
-				if token != "Bearer good" {
-					return 0, errors.New("unexpected token")
+			authenticateFn: func(tk string) (*token.Claims, error) {
+				if tk != "Bearer good" {
+					return nil, errors.New("unexpected token")
 				}
-				return int64(77), nil
+				return &token.Claims{UserID: 77, SessionID: 5}, nil
 			},
 		}
 
@@ -123,6 +130,13 @@ func TestJWTMiddleware(t *testing.T) {
 				t.Fatalf("expected userID=77, got %d", id)
 			}
 
+			if sid, _ := r.Context().Value(constants.SessionIDKey).(int64); sid != 5 {
+				t.Fatalf("expected sessionID=5, got %v", r.Context().Value(constants.SessionIDKey))
+			}
+			if c, _ := r.Context().Value(constants.ClaimsKey).(*token.Claims); c == nil || c.UserID != 77 {
+				t.Fatalf("expected claims in context, got %v", r.Context().Value(constants.ClaimsKey))
+			}
+
 			w.WriteHeader(http.StatusOK)
 			_, _ = w.Write([]byte("ok"))
 		})
@@ -148,4 +162,32 @@ func TestJWTMiddleware(t *testing.T) {
 			t.Fatalf("expected body %q, got %q", "ok", rr.Body.String())
 		}
 	})
+	t.Run("revoked token -> 403 and next NOT called", func(t *testing.T) {
+		svc := &mockAuthService{
+			authenticateFn: func(tk string) (*token.Claims, error) {
+				return &token.Claims{UserID: 77, SessionID: 5}, nil
+			},
+			revoked: true,
+		}
+
+		nextCalled := 0
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			nextCalled++
+		})
+
+		h := JWTMiddleware(svc)(next)
+
+		req := httptest.NewRequest(http.MethodGet, "/any", nil)
+		req.Header.Set("Authorization", "Bearer good")
+		rr := httptest.NewRecorder()
+
+		h.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d, body=%s", rr.Code, rr.Body.String())
+		}
+		if nextCalled != 0 {
+			t.Fatalf("next must NOT be called, nextCalled=%d", nextCalled)
+		}
+	})
 }
@@ -6,10 +6,14 @@ import (
 	"server/internal/app/adapters/primary/http-adapter/codec"
 	"server/internal/app/adapters/primary/http-adapter/constants"
 	errorMapper "server/internal/app/adapters/primary/http-adapter/error-mapper/user_usecase"
+	domain "server/internal/app/domain/user"
+	"server/internal/pkg/token"
 )
 
 type authService interface {
-	Authenticate(token string) (int64, error)
+	Authenticate(token string) (*token.Claims, error)
+	// Revoked tells if the token was taken out of use by a logout before it expired
+	Revoked(ctx context.Context, claims *token.Claims) bool
 }
 
 func JWTMiddleware(service authService) func(next http.Handler) http.Handler {
@@ -20,13 +24,20 @@ func JWTMiddleware(service authService) func(next http.Handler) http.Handler {
 				http.Error(w, "JWT token not found", http.StatusUnauthorized)
 				return
 			}
-			userID, err := service.Authenticate(jwt)
+			claims, err := service.Authenticate(jwt)
 			if err != nil {
 				status, message := errorMapper.Process(err)
 				codec.WriteJSON(w, status, message)
 				return
 			}
-			ctx := context.WithValue(r.Context(), constants.UserIDKey, userID)
+			if service.Revoked(r.Context(), claims) {
+				status, message := errorMapper.Process(domain.ErrTokenRevoked)
+				codec.WriteJSON(w, status, message)
+				return
+			}
+			ctx := context.WithValue(r.Context(), constants.UserIDKey, claims.UserID)
+			ctx = context.WithValue(ctx, constants.SessionIDKey, claims.SessionID)
+			ctx = context.WithValue(ctx, constants.ClaimsKey, claims)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"net"
+	"net/http"
+	"server/internal/app/domain/audit"
+)
+
+// ClientMiddleware puts the remote address and user agent into the context, the audit log reads them from there
+func ClientMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+
+		ctx := audit.WithClient(r.Context(), audit.Client{
+			IP:        ip,
+			UserAgent: r.UserAgent(),
+		})
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"server/internal/app/domain/audit"
+	"testing"
+)
+
+func TestClientMiddleware(t *testing.T) {
+	t.Run("host and user agent are put into context", func(t *testing.T) {
+		var got audit.Client
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = audit.ClientFrom(r.Context())
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/any", nil)
+		req.RemoteAddr = "10.0.0.7:51234"
+		req.Header.Set("User-Agent", "gophkeeper-tui")
+
+		ClientMiddleware(next).ServeHTTP(httptest.NewRecorder(), req)
+
+		if got.IP != "10.0.0.7" {
+			t.Fatalf("expected ip %q, got %q", "10.0.0.7", got.IP)
+		}
+		if got.UserAgent != "gophkeeper-tui" {
+			t.Fatalf("expected user agent %q, got %q", "gophkeeper-tui", got.UserAgent)
+		}
+	})
+
+	t.Run("remote address without port is kept as is", func(t *testing.T) {
+		var got audit.Client
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = audit.ClientFrom(r.Context())
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/any", nil)
+		req.RemoteAddr = "unix"
+
+		ClientMiddleware(next).ServeHTTP(httptest.NewRecorder(), req)
+
+		if got.IP != "unix" {
+			t.Fatalf("expected ip %q, got %q", "unix", got.IP)
+		}
+	})
+}
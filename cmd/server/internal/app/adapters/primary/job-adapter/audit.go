@@ -0,0 +1,32 @@
+package job_adapter
+
+import (
+	"context"
+	"server/internal/app/domain/audit"
+	"server/internal/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type auditVerifier interface {
+	Verify(ctx context.Context) (*audit.Verification, error)
+}
+
+// NewAuditVerifyJob walks the hash chain of the audit log, users only read the latest result
+func NewAuditVerifyJob(uc auditVerifier, interval time.Duration) Job {
+	return Job{
+		Name:     "audit-verify",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			res, err := uc.Verify(ctx)
+			if err != nil {
+				return err
+			}
+			if !res.Valid {
+				logger.Log.Error("audit: hash chain is broken", zap.Int64("broken_at", res.BrokenAt), zap.Int64("checked", res.Checked))
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package job_adapter
+
+import (
+	"context"
+	"server/internal/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// Job is a background task started every Interval until the application stops
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+type JobAdapter struct {
+	jobs []Job
+}
+
+func New(jobs ...Job) *JobAdapter {
+	return &JobAdapter{jobs: jobs}
+}
+
+func (a *JobAdapter) Start(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+
+	for _, job := range a.jobs {
+		if job.Interval <= 0 {
+			logger.Log.Warn("job disabled: interval is not set", zap.String("job", job.Name))
+			continue
+		}
+
+		g.Go(func() error {
+			ticker := time.NewTicker(job.Interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+					// job errors are logged only, one failed run must not stop the application
+					if err := job.Run(ctx); err != nil {
+						logger.Log.Error("job failed", zap.String("job", job.Name), zap.Error(err))
+					}
+				}
+			}
+		})
+	}
+
+	return g.Wait()
+}
@@ -0,0 +1,29 @@
+package job_adapter
+
+import (
+	"context"
+	domain "server/internal/app/domain/file_obj"
+	"server/internal/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type outboxProcessor interface {
+	ProcessOperations(ctx context.Context, opts domain.OutboxOptions) (int, error)
+}
+
+// NewOutboxJob finishes or compensates file operations interrupted between Postgres and object storage
+func NewOutboxJob(uc outboxProcessor, interval time.Duration, opts domain.OutboxOptions) Job {
+	return Job{
+		Name:     "file-outbox",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			done, err := uc.ProcessOperations(ctx, opts)
+			if done > 0 {
+				logger.Log.Info("outbox: file operations completed", zap.Int("count", done))
+			}
+			return err
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package job_adapter
+
+import (
+	"context"
+	domain "server/internal/app/domain/file_obj"
+	"server/internal/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type reconciler interface {
+	Reconcile(ctx context.Context, opts domain.ReconcileOptions) (*domain.ReconcileReport, error)
+}
+
+// NewReconcileJob compares file_data with the object storage and logs what is out of sync
+func NewReconcileJob(uc reconciler, interval time.Duration, opts domain.ReconcileOptions) Job {
+	return Job{
+		Name:     "file-reconcile",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			report, err := uc.Reconcile(ctx, opts)
+			if report != nil {
+				LogReconcileReport(report)
+			}
+			return err
+		},
+	}
+}
+
+func LogReconcileReport(report *domain.ReconcileReport) {
+	fields := []zap.Field{
+		zap.String("bucket", report.Bucket),
+		zap.Int("objects", report.ObjectsTotal),
+		zap.Int("files", report.FilesTotal),
+		zap.Int("orphan_objects", len(report.OrphanObjects)),
+		zap.Int("dangling_files", len(report.DanglingFiles)),
+		zap.Int("mismatches", len(report.Mismatches)),
+		zap.Int("removed_objects", report.RemovedObjects),
+		zap.Int("removed_files", report.RemovedFiles),
+	}
+
+	if report.Clean() {
+		logger.Log.Info("reconcile: storage is consistent", fields...)
+		return
+	}
+
+	logger.Log.Warn("reconcile: storage is out of sync", fields...)
+
+	for _, obj := range report.OrphanObjects {
+		logger.Log.Warn("reconcile: orphan object",
+			zap.String("key", obj.Key),
+			zap.Int64("size", obj.SizeBytes),
+			zap.Time("last_modified", obj.LastModified))
+	}
+	for _, f := range report.DanglingFiles {
+		logger.Log.Warn("reconcile: dangling file metadata",
+			zap.Int64("file_id", f.ID),
+			zap.Int64("user_id", f.UserID),
+			zap.String("key", f.Storage.ObjectKey))
+	}
+	for _, m := range report.Mismatches {
+		logger.Log.Warn("reconcile: size or etag mismatch",
+			zap.Int64("file_id", m.File.ID),
+			zap.String("key", m.File.Storage.ObjectKey),
+			zap.Int64("meta_size", m.File.SizeBytes),
+			zap.Int64("object_size", m.Object.SizeBytes),
+			zap.String("meta_etag", m.File.ETag),
+			zap.String("object_etag", m.Object.ETag))
+	}
+}
@@ -0,0 +1,29 @@
+package job_adapter
+
+import (
+	"context"
+	domain "server/internal/app/domain/notification"
+	"server/internal/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type reminderGenerator interface {
+	GenerateReminders(ctx context.Context, opts domain.ReminderOptions) (int, error)
+}
+
+// NewReminderJob puts reminders about objects expiring within the lead time into the users inboxes
+func NewReminderJob(uc reminderGenerator, interval time.Duration, opts domain.ReminderOptions) Job {
+	return Job{
+		Name:     "expiry-reminders",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			created, err := uc.GenerateReminders(ctx, opts)
+			if created > 0 {
+				logger.Log.Info("reminders: notifications created", zap.Int("count", created))
+			}
+			return err
+		},
+	}
+}
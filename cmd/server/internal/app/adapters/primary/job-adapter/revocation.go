@@ -0,0 +1,28 @@
+package job_adapter
+
+import (
+	"context"
+	"server/internal/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type revocationPruner interface {
+	Prune(ctx context.Context) (int64, error)
+}
+
+// NewRevocationPruneJob drops revoked access tokens that have expired anyway
+func NewRevocationPruneJob(list revocationPruner, interval time.Duration) Job {
+	return Job{
+		Name:     "revocation-prune",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			removed, err := list.Prune(ctx)
+			if removed > 0 {
+				logger.Log.Info("revocations: expired entries removed", zap.Int64("count", removed))
+			}
+			return err
+		},
+	}
+}
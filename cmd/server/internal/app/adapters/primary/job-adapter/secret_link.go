@@ -0,0 +1,28 @@
+package job_adapter
+
+import (
+	"context"
+	"server/internal/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type secretLinkPurger interface {
+	PurgeDead(ctx context.Context) (int64, error)
+}
+
+// NewSecretLinkPurgeJob removes the ciphertext of expired and burned secret links
+func NewSecretLinkPurgeJob(uc secretLinkPurger, interval time.Duration) Job {
+	return Job{
+		Name:     "secret-link-purge",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			removed, err := uc.PurgeDead(ctx)
+			if removed > 0 {
+				logger.Log.Info("secret links: dead links removed", zap.Int64("count", removed))
+			}
+			return err
+		},
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	domain "server/internal/app/domain/file_obj"
 
 	"github.com/minio/minio-go/v7"
 )
@@ -60,3 +61,39 @@ func (r *Repository) GetObjectReader(
 
 	return obj, nil
 }
+
+func (r *Repository) ListObjects(ctx context.Context, bucket string) ([]domain.ObjectInfo, error) {
+	var out []domain.ObjectInfo
+
+	for obj := range r.mc.ListObjects(ctx, bucket, minio.ListObjectsOptions{Recursive: true}) {
+		if obj.Err != nil {
+			return nil, fmt.Errorf("minio list objects bucket=%s: %w", bucket, obj.Err)
+		}
+
+		out = append(out, domain.ObjectInfo{
+			Key:          obj.Key,
+			SizeBytes:    obj.Size,
+			ETag:         obj.ETag,
+			LastModified: obj.LastModified,
+		})
+	}
+
+	return out, nil
+}
+
+func (r *Repository) StatObject(ctx context.Context, bucket, key string) (domain.ObjectInfo, error) {
+	info, err := r.mc.StatObject(ctx, bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return domain.ObjectInfo{}, domain.ErrObjectNotFound
+		}
+		return domain.ObjectInfo{}, fmt.Errorf("minio stat object bucket=%s key=%s: %w", bucket, key, err)
+	}
+
+	return domain.ObjectInfo{
+		Key:          info.Key,
+		SizeBytes:    info.Size,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+	}, nil
+}
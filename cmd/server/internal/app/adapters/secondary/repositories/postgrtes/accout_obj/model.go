@@ -11,6 +11,13 @@ type Account struct {
 	ServiceName sql.NullString
 	UserName    sql.NullString
 	Password    sql.NullString
+	TOTP        sql.NullString
+	BreachCount sql.NullInt64
+	ChangedAt   sql.NullTime
+	ExpiresAt   sql.NullTime
+	// CustomFields is the raw JSONB column, decoded by the repository
+	CustomFields sql.NullString
+	OrgID        sql.NullInt64
 }
 
 func (u *Account) ToDomain() *domain.Account {
@@ -20,5 +27,15 @@ func (u *Account) ToDomain() *domain.Account {
 		ServiceName: u.ServiceName.String,
 		UserName:    u.UserName.String,
 		Password:    u.Password.String,
+		TOTP:        u.TOTP.String,
+		BreachCount: int(u.BreachCount.Int64),
+
+		PasswordChangedAt: u.ChangedAt.Time,
+		ExpiresAt:         u.ExpiresAt.Time,
+		OrgID:             u.OrgID.Int64,
 	}
 }
+
+func (u *Account) scanFields() []any {
+	return []any{&u.ID, &u.ServiceName, &u.UserName, &u.UserId, &u.Password, &u.TOTP, &u.BreachCount, &u.ChangedAt, &u.ExpiresAt, &u.CustomFields, &u.OrgID}
+}
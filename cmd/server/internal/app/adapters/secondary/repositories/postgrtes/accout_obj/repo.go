@@ -5,19 +5,21 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"server/internal/app/adapters/secondary/repositories/postgrtes/custom_field"
 	"server/internal/app/config"
 	domain "server/internal/app/domain/account_obj"
 	"server/internal/pkg/encryption/aes"
 	"server/internal/pkg/logger"
+	"time"
 
 	"go.uber.org/zap"
 )
 
 func (u *Repository) GetByUserID(ctx context.Context, userId int64) ([]*domain.Account, error) {
 	query := `
-		SELECT id, service_name, username, user_id, password
+		SELECT id, service_name, username, user_id, password, totp_secret, breach_count, password_changed_at, expires_at, custom_fields, org_id
 		FROM account_data
-		WHERE user_id = $1`
+		WHERE user_id = $1 AND org_id IS NULL`
 
 	var accounts []*domain.Account
 
@@ -34,63 +36,57 @@ func (u *Repository) GetByUserID(ctx context.Context, userId int64) ([]*domain.A
 	for rows.Next() {
 		obj := new(Account)
 
-		if err := rows.Scan(&obj.ID, &obj.ServiceName, &obj.UserName, &obj.UserId, &obj.Password); err != nil {
+		if err := rows.Scan(obj.scanFields()...); err != nil {
 			return nil, err
 		}
 
-		if obj.Password.Valid {
-			decrypted, err := aes.DecryptAES([]byte(obj.Password.String), []byte(config.App.GetAccountObjEncryptionKey()))
-			if err != nil {
-				return nil, fmt.Errorf("failed decrypt account data: %w", err)
-			}
-			obj.Password.String = string(decrypted)
+		account, err := toDomain(obj)
+		if err != nil {
+			return nil, err
 		}
 
-		accounts = append(accounts, obj.ToDomain())
+		accounts = append(accounts, account)
 	}
 	return accounts, nil
 }
 
 func (u *Repository) GetByID(ctx context.Context, accountId int64) (*domain.Account, error) {
 	query := `
-		SELECT id, service_name, username, user_id, password
+		SELECT id, service_name, username, user_id, password, totp_secret, breach_count, password_changed_at, expires_at, custom_fields, org_id
 		FROM account_data
 		WHERE id = $1`
 
 	obj := new(Account)
 
-	if err := u.db.QueryRowContext(ctx, query, accountId).Scan(&obj.ID, &obj.ServiceName, &obj.UserName, &obj.UserId, &obj.Password); err != nil {
+	if err := u.db.QueryRowContext(ctx, query, accountId).Scan(obj.scanFields()...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrAccountInformationNotFound
 		}
 		return nil, err
 	}
 
-	if obj.Password.Valid {
-		decrypted, err := aes.DecryptAES([]byte(obj.Password.String), []byte(config.App.GetAccountObjEncryptionKey()))
-		if err != nil {
-			return nil, fmt.Errorf("failed decrypt account data: %w", err)
-		}
-		obj.Password.String = string(decrypted)
-	}
-
-	return obj.ToDomain(), nil
+	return toDomain(obj)
 }
 
 func (u *Repository) Create(ctx context.Context, account *domain.Account) (int64, error) {
 	query := `
-		INSERT INTO account_data (user_id, service_name, username, password)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO account_data (user_id, service_name, username, password, totp_secret, breach_count, password_changed_at, expires_at, custom_fields, org_id)
+		VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7, now()), $8, $9, $10)
 		RETURNING id`
 
-	encryptedPassword, err := aes.EncryptAES([]byte(account.Password), []byte(config.App.GetAccountObjEncryptionKey()))
+	encryptedPassword, encryptedTOTP, err := encryptAccount(account)
+	if err != nil {
+		return 0, err
+	}
+
+	fields, err := custom_field.Encode(account.CustomFields)
 	if err != nil {
-		return 0, fmt.Errorf("failed to encrypt password: %w", err)
+		return 0, err
 	}
 
 	var id sql.NullInt64
 
-	if err := u.db.QueryRowContext(ctx, query, account.UserId, account.ServiceName, account.UserName, encryptedPassword).Scan(&id); err != nil {
+	if err := u.db.QueryRowContext(ctx, query, account.UserId, account.ServiceName, account.UserName, encryptedPassword, encryptedTOTP, account.BreachCount, nullIfZero(account.PasswordChangedAt), nullIfZero(account.ExpiresAt), fields, nullIfZeroID(account.OrgID)).Scan(&id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, domain.ErrFaildeCreateAccountObject
 		}
@@ -107,16 +103,110 @@ func (u *Repository) Create(ctx context.Context, account *domain.Account) (int64
 func (u *Repository) Update(ctx context.Context, account *domain.Account) error {
 	query := `
 		UPDATE account_data SET
-		service_name = $1, username = $2, password = $3
-		WHERE id = $4`
+		service_name = $1, username = $2, password = $3, totp_secret = $4, breach_count = $5,
+		password_changed_at = COALESCE($6, password_changed_at), expires_at = $7, custom_fields = $8
+		WHERE id = $9`
+
+	encryptedPassword, encryptedTOTP, err := encryptAccount(account)
+	if err != nil {
+		return err
+	}
 
-	encryptedPassword, err := aes.EncryptAES([]byte(account.Password), []byte(config.App.GetAccountObjEncryptionKey()))
+	fields, err := custom_field.Encode(account.CustomFields)
 	if err != nil {
-		return fmt.Errorf("failed to encrypt password: %w", err)
+		return err
 	}
 
-	if _, err := u.db.ExecContext(ctx, query, account.ServiceName, account.UserName, encryptedPassword, account.AccountId); err != nil {
+	if _, err := u.db.ExecContext(ctx, query, account.ServiceName, account.UserName, encryptedPassword, encryptedTOTP, account.BreachCount, nullIfZero(account.PasswordChangedAt), nullIfZero(account.ExpiresAt), fields, account.AccountId); err != nil {
 		return err
 	}
 	return nil
 }
+
+func (u *Repository) SetBreachCount(ctx context.Context, accountId int64, count int) error {
+	query := `
+		UPDATE account_data SET breach_count = $1
+		WHERE id = $2`
+
+	if _, err := u.db.ExecContext(ctx, query, count, accountId); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *Repository) Delete(ctx context.Context, accountId int64) error {
+	query := `DELETE FROM account_data WHERE id = $1`
+
+	if _, err := u.db.ExecContext(ctx, query, accountId); err != nil {
+		return err
+	}
+	return nil
+}
+
+// help func
+
+// encryptAccount encrypts the password and the TOTP secret, a missing TOTP secret is stored as NULL
+func encryptAccount(account *domain.Account) (password, totp []byte, err error) {
+	key := []byte(config.App.GetAccountObjEncryptionKey())
+
+	password, err = aes.EncryptAES([]byte(account.Password), key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encrypt password: %w", err)
+	}
+
+	if account.TOTP != "" {
+		if totp, err = aes.EncryptAES([]byte(account.TOTP), key); err != nil {
+			return nil, nil, fmt.Errorf("failed to encrypt totp secret: %w", err)
+		}
+	}
+
+	return password, totp, nil
+}
+
+func decryptAccount(obj *Account) error {
+	key := []byte(config.App.GetAccountObjEncryptionKey())
+
+	for _, field := range []*sql.NullString{&obj.Password, &obj.TOTP} {
+		if !field.Valid {
+			continue
+		}
+
+		decrypted, err := aes.DecryptAES([]byte(field.String), key)
+		if err != nil {
+			return fmt.Errorf("failed decrypt account data: %w", err)
+		}
+		field.String = string(decrypted)
+	}
+
+	return nil
+}
+
+// toDomain decrypts the secrets and decodes the custom fields of a row
+func toDomain(obj *Account) (*domain.Account, error) {
+	if err := decryptAccount(obj); err != nil {
+		return nil, err
+	}
+
+	fields, err := custom_field.Decode(obj.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+
+	account := obj.ToDomain()
+	account.CustomFields = fields
+	return account, nil
+}
+
+func nullIfZero(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func nullIfZeroID(id int64) any {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"server/internal/app/config"
 	domain "server/internal/app/domain/account_obj"
@@ -56,13 +57,21 @@ func TestRepository_GetByID_OK_DecryptsPassword(t *testing.T) {
 	}
 	encStr := string(enc)
 
+	changedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	expiresAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	encTOTP, err := aes.EncryptAES([]byte("GEZDGNBVGY3TQOJQ"), []byte(key))
+	if err != nil {
+		t.Fatalf("EncryptAES error: %v", err)
+	}
+
 	const q = `
-		SELECT id, service_name, username, user_id, password
+		SELECT id, service_name, username, user_id, password, totp_secret, breach_count, password_changed_at, expires_at, custom_fields, org_id
 		FROM account_data
 		WHERE id = $1`
 
-	rows := sqlmock.NewRows([]string{"id", "service_name", "username", "user_id", "password"}).
-		AddRow(int64(10), "telegram", "stas", int64(7), encStr)
+	rows := sqlmock.NewRows([]string{"id", "service_name", "username", "user_id", "password", "totp_secret", "breach_count", "password_changed_at", "expires_at", "custom_fields", "org_id"}).
+		AddRow(int64(10), "telegram", "stas", int64(7), encStr, string(encTOTP), 0, changedAt, expiresAt, "[]", nil)
 
 	mock.ExpectQuery(sqlRe(q)).
 		WithArgs(int64(10)).
@@ -81,6 +90,15 @@ func TestRepository_GetByID_OK_DecryptsPassword(t *testing.T) {
 	if got.Password != plaintext {
 		t.Fatalf("expected decrypted password=%q, got %q", plaintext, got.Password)
 	}
+	if !got.PasswordChangedAt.Equal(changedAt) {
+		t.Fatalf("expected password_changed_at=%v, got %v", changedAt, got.PasswordChangedAt)
+	}
+	if !got.ExpiresAt.Equal(expiresAt) {
+		t.Fatalf("expected expires_at=%v, got %v", expiresAt, got.ExpiresAt)
+	}
+	if got.TOTP != "GEZDGNBVGY3TQOJQ" {
+		t.Fatalf("expected decrypted totp secret, got %q", got.TOTP)
+	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Fatalf("sql expectations: %v", err)
@@ -99,7 +117,7 @@ func TestRepository_GetByID_NoRows_ReturnsDomainNotFound(t *testing.T) {
 	repo := &Repository{db: db}
 
 	const q = `
-		SELECT id, service_name, username, user_id, password
+		SELECT id, service_name, username, user_id, password, totp_secret, breach_count, password_changed_at, expires_at, custom_fields, org_id
 		FROM account_data
 		WHERE id = $1`
 
@@ -129,12 +147,12 @@ func TestRepository_Create_OK_ReturnsID(t *testing.T) {
 	repo := &Repository{db: db}
 
 	const q = `
-		INSERT INTO account_data (user_id, service_name, username, password)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO account_data (user_id, service_name, username, password, totp_secret, breach_count, password_changed_at, expires_at, custom_fields, org_id)
+		VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7, now()), $8, $9, $10)
 		RETURNING id`
 
 	mock.ExpectQuery(sqlRe(q)).
-		WithArgs(int64(7), "telegram", "stas", sqlmock.AnyArg()).
+		WithArgs(int64(7), "telegram", "stas", sqlmock.AnyArg(), sqlmock.AnyArg(), 0, nil, nil, "[]", nil).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(123)))
 
 	id, err := repo.Create(context.Background(), &domain.Account{
@@ -142,6 +160,7 @@ func TestRepository_Create_OK_ReturnsID(t *testing.T) {
 		ServiceName: "telegram",
 		UserName:    "stas",
 		Password:    "my-pass",
+		TOTP:        "GEZDGNBVGY3TQOJQ",
 	})
 	if err != nil {
 		t.Fatalf("Create error: %v", err)
@@ -169,12 +188,12 @@ func TestRepository_Create_InvalidID_ReturnsDomainFailed(t *testing.T) {
 	repo := &Repository{db: db}
 
 	const q = `
-		INSERT INTO account_data (user_id, service_name, username, password)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO account_data (user_id, service_name, username, password, totp_secret, breach_count, password_changed_at, expires_at, custom_fields, org_id)
+		VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7, now()), $8, $9, $10)
 		RETURNING id`
 
 	mock.ExpectQuery(sqlRe(q)).
-		WithArgs(int64(7), "telegram", "stas", sqlmock.AnyArg()).
+		WithArgs(int64(7), "telegram", "stas", sqlmock.AnyArg(), []byte(nil), 0, nil, nil, "[]", nil).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(nil))
 
 	_, err = repo.Create(context.Background(), &domain.Account{
@@ -207,11 +226,14 @@ func TestRepository_Update_OK(t *testing.T) {
 
 	const q = `
 		UPDATE account_data SET
-		service_name = $1, username = $2, password = $3
-		WHERE id = $4`
+		service_name = $1, username = $2, password = $3, totp_secret = $4, breach_count = $5,
+		password_changed_at = COALESCE($6, password_changed_at), expires_at = $7, custom_fields = $8
+		WHERE id = $9`
+
+	changedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
 
 	mock.ExpectExec(sqlRe(q)).
-		WithArgs("telegram", "stas", sqlmock.AnyArg(), int64(55)).
+		WithArgs("telegram", "stas", sqlmock.AnyArg(), []byte(nil), 12, changedAt, nil, sqlmock.AnyArg(), int64(55)).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	err = repo.Update(context.Background(), &domain.Account{
@@ -219,6 +241,9 @@ func TestRepository_Update_OK(t *testing.T) {
 		ServiceName: "telegram",
 		UserName:    "stas",
 		Password:    "new-pass",
+		BreachCount: 12,
+
+		PasswordChangedAt: changedAt,
 	})
 	if err != nil {
 		t.Fatalf("Update error: %v", err)
@@ -252,13 +277,13 @@ func TestRepository_GetByUserID_OK_ListDecryptsPasswords(t *testing.T) {
 	}
 
 	const q = `
-		SELECT id, service_name, username, user_id, password
+		SELECT id, service_name, username, user_id, password, totp_secret, breach_count, password_changed_at, expires_at, custom_fields, org_id
 		FROM account_data
-		WHERE user_id = $1`
+		WHERE user_id = $1 AND org_id IS NULL`
 
-	rows := sqlmock.NewRows([]string{"id", "service_name", "username", "user_id", "password"}).
-		AddRow(int64(1), "telegram", "u1", int64(7), string(enc1)).
-		AddRow(int64(2), "shopify", "u2", int64(7), string(enc2))
+	rows := sqlmock.NewRows([]string{"id", "service_name", "username", "user_id", "password", "totp_secret", "breach_count", "password_changed_at", "expires_at", "custom_fields", "org_id"}).
+		AddRow(int64(1), "telegram", "u1", int64(7), string(enc1), nil, 0, nil, nil, "[]", nil).
+		AddRow(int64(2), "shopify", "u2", int64(7), string(enc2), nil, 17, nil, nil, nil, nil)
 
 	mock.ExpectQuery(sqlRe(q)).
 		WithArgs(int64(7)).
@@ -277,6 +302,37 @@ func TestRepository_GetByUserID_OK_ListDecryptsPasswords(t *testing.T) {
 	if list[1].Password != "p2" {
 		t.Fatalf("expected decrypted p2, got %q", list[1].Password)
 	}
+	if list[0].Compromised() || list[1].BreachCount != 17 {
+		t.Fatalf("unexpected breach counts: %d, %d", list[0].BreachCount, list[1].BreachCount)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_SetBreachCount_OK(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db: db}
+
+	const q = `
+		UPDATE account_data SET breach_count = $1
+		WHERE id = $2`
+
+	mock.ExpectExec(sqlRe(q)).
+		WithArgs(3, int64(55)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.SetBreachCount(context.Background(), 55, 3); err != nil {
+		t.Fatalf("SetBreachCount error: %v", err)
+	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Fatalf("sql expectations: %v", err)
@@ -295,3 +351,27 @@ func sqlRe(q string) string {
 	s = strings.ReplaceAll(s, `\ `, `\s+`)
 	return s
 }
+
+func TestRepository_Delete(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db: db}
+
+	mock.ExpectExec(sqlRe(`DELETE FROM account_data WHERE id = $1`)).
+		WithArgs(int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.Delete(context.Background(), 5); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
@@ -0,0 +1,11 @@
+package attachment
+
+import "database/sql"
+
+type Repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
@@ -0,0 +1,153 @@
+package attachment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"server/internal/pkg/logger"
+
+	domain "server/internal/app/domain/attachment"
+
+	"go.uber.org/zap"
+)
+
+func (r *Repository) ItemOwner(ctx context.Context, item domain.Item) (domain.Owner, error) {
+	table, _, err := itemColumns(item)
+	if err != nil {
+		return domain.Owner{}, err
+	}
+
+	query := fmt.Sprintf(`SELECT user_id, COALESCE(org_id, 0) FROM %s WHERE id = $1`, table)
+
+	var owner domain.Owner
+	if err := r.db.QueryRowContext(ctx, query, item.ID).Scan(&owner.UserID, &owner.OrgID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Owner{}, domain.ErrItemNotFound
+		}
+		return domain.Owner{}, err
+	}
+
+	return owner, nil
+}
+
+// FileOwner only sees uploaded files, pending ones can not be attached
+func (r *Repository) FileOwner(ctx context.Context, fileID int64) (int64, error) {
+	query := `SELECT user_id FROM file_data WHERE id = $1 AND status = 'ready'`
+
+	var userID int64
+	if err := r.db.QueryRowContext(ctx, query, fileID).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrFileNotFound
+		}
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+func (r *Repository) ListByItem(ctx context.Context, item domain.Item) ([]*domain.Attachment, error) {
+	_, column, err := itemColumns(item)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT a.id, a.user_id, a.file_id, f.title, f.size_bytes, f.content_type, a.created_at
+		FROM attachments a
+		JOIN file_data f ON f.id = a.file_id
+		WHERE a.%s = $1 AND f.status = 'ready'
+		ORDER BY a.id`, column)
+
+	rows, err := r.db.QueryContext(ctx, query, item.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
+
+	var out []*domain.Attachment
+	for rows.Next() {
+		var (
+			a           = &domain.Attachment{Item: item}
+			title       sql.NullString
+			contentType sql.NullString
+		)
+
+		if err := rows.Scan(&a.ID, &a.UserID, &a.FileID, &title, &a.SizeBytes, &contentType, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		a.Title, a.ContentType = title.String, contentType.String
+
+		out = append(out, a)
+	}
+
+	return out, rows.Err()
+}
+
+// Attach relies on the unique indexes of the item columns, a second link of the same file is reported as ErrAlreadyAttached
+func (r *Repository) Attach(ctx context.Context, userID int64, item domain.Item, fileID int64) (int64, error) {
+	_, column, err := itemColumns(item)
+	if err != nil {
+		return 0, err
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO attachments (user_id, file_id, %s)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+		RETURNING id`, column)
+
+	var id int64
+	if err := r.db.QueryRowContext(ctx, query, userID, fileID, item.ID).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrAlreadyAttached
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *Repository) Detach(ctx context.Context, item domain.Item, fileID int64) error {
+	_, column, err := itemColumns(item)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`DELETE FROM attachments WHERE %s = $1 AND file_id = $2`, column)
+
+	res, err := r.db.ExecContext(ctx, query, item.ID, fileID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrAttachmentNotFound
+	}
+
+	return nil
+}
+
+// help func
+
+// itemColumns maps an item type to its table and the link column of attachments
+func itemColumns(item domain.Item) (table, column string, err error) {
+	switch item.Type {
+	case domain.ItemAccount:
+		return "account_data", "account_id", nil
+	case domain.ItemBankCard:
+		return "bank_data", "bank_id", nil
+	case domain.ItemText:
+		return "text_data", "text_id", nil
+	default:
+		return "", "", domain.ErrInvalidItem
+	}
+}
@@ -0,0 +1,223 @@
+package attachment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/app/config"
+	domain "server/internal/app/domain/attachment"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func init() {
+	config.InitTestConfig()
+}
+
+func TestRepository_ItemOwner(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		item  domain.Item
+		query string
+	}{
+		{name: "account", item: domain.Item{Type: domain.ItemAccount, ID: 3}, query: `SELECT user_id, COALESCE(org_id, 0) FROM account_data WHERE id = $1`},
+		{name: "card", item: domain.Item{Type: domain.ItemBankCard, ID: 3}, query: `SELECT user_id, COALESCE(org_id, 0) FROM bank_data WHERE id = $1`},
+		{name: "text", item: domain.Item{Type: domain.ItemText, ID: 3}, query: `SELECT user_id, COALESCE(org_id, 0) FROM text_data WHERE id = $1`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New error: %v", err)
+			}
+			defer db.Close()
+
+			repo := &Repository{db: db}
+
+			mock.ExpectQuery(sqlRe(tc.query)).
+				WithArgs(int64(3)).
+				WillReturnRows(sqlmock.NewRows([]string{"user_id", "org_id"}).AddRow(int64(7), int64(2)))
+
+			owner, err := repo.ItemOwner(context.Background(), tc.item)
+			if err != nil || owner != (domain.Owner{UserID: 7, OrgID: 2}) {
+				t.Fatalf("unexpected result: %+v, %v", owner, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("sql expectations: %v", err)
+			}
+		})
+	}
+
+	t.Run("no rows -> ErrItemNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New error: %v", err)
+		}
+		defer db.Close()
+
+		repo := &Repository{db: db}
+
+		mock.ExpectQuery(sqlRe(`SELECT user_id, COALESCE(org_id, 0) FROM text_data WHERE id = $1`)).
+			WithArgs(int64(9)).
+			WillReturnError(sql.ErrNoRows)
+
+		if _, err := repo.ItemOwner(context.Background(), domain.Item{Type: domain.ItemText, ID: 9}); !errors.Is(err, domain.ErrItemNotFound) {
+			t.Fatalf("expected ErrItemNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("unknown type -> ErrInvalidItem", func(t *testing.T) {
+		t.Parallel()
+
+		repo := &Repository{}
+		if _, err := repo.ItemOwner(context.Background(), domain.Item{Type: "users", ID: 1}); !errors.Is(err, domain.ErrInvalidItem) {
+			t.Fatalf("expected ErrInvalidItem, got: %v", err)
+		}
+	})
+}
+
+func TestRepository_ListByItem(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db: db}
+
+	const q = `
+		SELECT a.id, a.user_id, a.file_id, f.title, f.size_bytes, f.content_type, a.created_at
+		FROM attachments a
+		JOIN file_data f ON f.id = a.file_id
+		WHERE a.bank_id = $1 AND f.status = 'ready'
+		ORDER BY a.id`
+
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	mock.ExpectQuery(sqlRe(q)).
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "file_id", "title", "size_bytes", "content_type", "created_at"}).
+			AddRow(int64(1), int64(7), int64(5), "contract.pdf", int64(1024), "application/pdf", createdAt).
+			AddRow(int64(2), int64(7), int64(6), nil, int64(0), nil, createdAt))
+
+	item := domain.Item{Type: domain.ItemBankCard, ID: 3}
+	list, err := repo.ListByItem(context.Background(), item)
+	if err != nil {
+		t.Fatalf("ListByItem error: %v", err)
+	}
+	if len(list) != 2 || list[0].Title != "contract.pdf" || list[0].Item != item || list[1].ContentType != "" {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_Attach(t *testing.T) {
+	t.Parallel()
+
+	const q = `
+		INSERT INTO attachments (user_id, file_id, account_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+		RETURNING id`
+
+	t.Run("ok -> id", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New error: %v", err)
+		}
+		defer db.Close()
+
+		repo := &Repository{db: db}
+
+		mock.ExpectQuery(sqlRe(q)).
+			WithArgs(int64(7), int64(5), int64(3)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(11)))
+
+		id, err := repo.Attach(context.Background(), 7, domain.Item{Type: domain.ItemAccount, ID: 3}, 5)
+		if err != nil || id != 11 {
+			t.Fatalf("unexpected result: %d, %v", id, err)
+		}
+	})
+
+	t.Run("conflict -> ErrAlreadyAttached", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New error: %v", err)
+		}
+		defer db.Close()
+
+		repo := &Repository{db: db}
+
+		mock.ExpectQuery(sqlRe(q)).
+			WithArgs(int64(7), int64(5), int64(3)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err = repo.Attach(context.Background(), 7, domain.Item{Type: domain.ItemAccount, ID: 3}, 5)
+		if !errors.Is(err, domain.ErrAlreadyAttached) {
+			t.Fatalf("expected ErrAlreadyAttached, got: %v", err)
+		}
+	})
+}
+
+func TestRepository_Detach(t *testing.T) {
+	t.Parallel()
+
+	const q = `DELETE FROM attachments WHERE text_id = $1 AND file_id = $2`
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db: db}
+
+	mock.ExpectExec(sqlRe(q)).
+		WithArgs(int64(3), int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(sqlRe(q)).
+		WithArgs(int64(3), int64(6)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	item := domain.Item{Type: domain.ItemText, ID: 3}
+	if err := repo.Detach(context.Background(), item, 5); err != nil {
+		t.Fatalf("Detach error: %v", err)
+	}
+	if err := repo.Detach(context.Background(), item, 6); !errors.Is(err, domain.ErrAttachmentNotFound) {
+		t.Fatalf("expected ErrAttachmentNotFound, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func sqlRe(q string) string {
+	s := strings.TrimSpace(q)
+	s = strings.Join(strings.Fields(s), " ")
+	s = regexp.QuoteMeta(s)
+	s = strings.ReplaceAll(s, `\ `, `\s+`)
+	return s
+}
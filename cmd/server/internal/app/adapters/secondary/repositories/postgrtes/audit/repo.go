@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"server/internal/pkg/logger"
+	postgres "server/internal/pkg/postgres"
+	"strings"
+
+	domain "server/internal/app/domain/audit"
+
+	"go.uber.org/zap"
+)
+
+// appendLockKey is the advisory lock that serializes appends, two writers reading the
+// same last hash would fork the chain
+const appendLockKey = 0x617564697400
+
+const selectEvent = `
+	SELECT id, user_id, owner_id, action, item_type, item_id, ip, user_agent, details, created_at, prev_hash, hash
+	FROM audit_log`
+
+const insertQuery = `
+	INSERT INTO audit_log (user_id, owner_id, action, item_type, item_id, ip, user_agent, details, created_at, prev_hash, hash)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	RETURNING id`
+
+func (r *Repository) Append(ctx context.Context, e *domain.Event) error {
+	return postgres.WithTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, appendLockKey); err != nil {
+			return fmt.Errorf("lock audit_log: %w", err)
+		}
+
+		var prev []byte
+		err := tx.QueryRowContext(ctx, `SELECT hash FROM audit_log ORDER BY id DESC LIMIT 1`).Scan(&prev)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("read last audit hash: %w", err)
+		}
+
+		e.Seal(prev)
+
+		return tx.QueryRowContext(ctx, insertQuery,
+			e.UserID, e.OwnerID, e.Action, e.ItemType, e.ItemID,
+			e.IP, e.UserAgent, e.Details, e.CreatedAt, e.PrevHash, e.Hash,
+		).Scan(&e.ID)
+	})
+}
+
+func (r *Repository) Search(ctx context.Context, f domain.Filter) ([]*domain.Event, error) {
+	where := []string{"(user_id = $1 OR owner_id = $1)"}
+	args := []any{f.UserID}
+
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.Action != "" {
+		add("action = $%d", f.Action)
+	}
+	if f.ItemType != "" {
+		add("item_type = $%d", f.ItemType)
+	}
+	if f.ItemID != 0 {
+		add("item_id = $%d", f.ItemID)
+	}
+	if !f.From.IsZero() {
+		add("created_at >= $%d", f.From)
+	}
+	if !f.To.IsZero() {
+		add("created_at < $%d", f.To)
+	}
+	if f.BeforeID != 0 {
+		add("id < $%d", f.BeforeID)
+	}
+
+	args = append(args, f.Limit)
+	query := fmt.Sprintf("%s WHERE %s ORDER BY id DESC LIMIT $%d", selectEvent, strings.Join(where, " AND "), len(args))
+
+	return r.list(ctx, query, args...)
+}
+
+func (r *Repository) Chain(ctx context.Context, afterID int64, limit int) ([]*domain.Event, error) {
+	return r.list(ctx, selectEvent+` WHERE id > $1 ORDER BY id LIMIT $2`, afterID, limit)
+}
+
+// help func
+
+func (r *Repository) list(ctx context.Context, query string, args ...any) ([]*domain.Event, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
+
+	var out []*domain.Event
+	for rows.Next() {
+		e := new(domain.Event)
+		if err := rows.Scan(&e.ID, &e.UserID, &e.OwnerID, &e.Action, &e.ItemType, &e.ItemID,
+			&e.IP, &e.UserAgent, &e.Details, &e.CreatedAt, &e.PrevHash, &e.Hash); err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+
+	return out, rows.Err()
+}
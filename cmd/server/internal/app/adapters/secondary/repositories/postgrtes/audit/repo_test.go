@@ -0,0 +1,191 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/app/config"
+	domain "server/internal/app/domain/audit"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func init() {
+	config.InitTestConfig()
+}
+
+func newRepo(t *testing.T) (*Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return &Repository{db: db}, mock
+}
+
+var eventColumns = []string{"id", "user_id", "owner_id", "action", "item_type", "item_id", "ip", "user_agent", "details", "created_at", "prev_hash", "hash"}
+
+func TestRepository_Append(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	createdAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	prev := []byte("previous")
+	e := &domain.Event{UserID: 1, OwnerID: 1, Action: domain.ActionItemRead, ItemType: domain.ItemAccount, ItemID: 5, IP: "10.0.0.1", UserAgent: "tui", CreatedAt: createdAt}
+	want := e.Digest(prev)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(sqlRe(`SELECT pg_advisory_xact_lock($1)`)).
+		WithArgs(appendLockKey).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(sqlRe(`SELECT hash FROM audit_log ORDER BY id DESC LIMIT 1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"hash"}).AddRow(prev))
+	mock.ExpectQuery(sqlRe(`INSERT INTO audit_log`)).
+		WithArgs(int64(1), int64(1), domain.ActionItemRead, domain.ItemAccount, int64(5), "10.0.0.1", "tui", "", createdAt, prev, want).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(42)))
+	mock.ExpectCommit()
+
+	if err := repo.Append(context.Background(), e); err != nil {
+		t.Fatalf("Append error: %v", err)
+	}
+	if e.ID != 42 {
+		t.Fatalf("expected id 42, got %d", e.ID)
+	}
+	if !e.Follows(prev) {
+		t.Fatalf("expected event to follow the last hash")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_Append_FirstEvent(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	e := &domain.Event{UserID: 1, Action: domain.ActionLogin, CreatedAt: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(sqlRe(`SELECT pg_advisory_xact_lock($1)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(sqlRe(`SELECT hash FROM audit_log ORDER BY id DESC LIMIT 1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"hash"}))
+	mock.ExpectQuery(sqlRe(`INSERT INTO audit_log`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectCommit()
+
+	if err := repo.Append(context.Background(), e); err != nil {
+		t.Fatalf("Append error: %v", err)
+	}
+	if e.PrevHash != nil {
+		t.Fatalf("expected no previous hash, got %x", e.PrevHash)
+	}
+	if !e.Follows(nil) {
+		t.Fatalf("expected first event to be sealed")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_Append_InsertError(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(sqlRe(`SELECT pg_advisory_xact_lock($1)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(sqlRe(`SELECT hash FROM audit_log ORDER BY id DESC LIMIT 1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"hash"}))
+	mock.ExpectQuery(sqlRe(`INSERT INTO audit_log`)).
+		WillReturnError(errors.New("db down"))
+	mock.ExpectRollback()
+
+	err := repo.Append(context.Background(), &domain.Event{UserID: 1, Action: domain.ActionLogin})
+	if err == nil || !strings.Contains(err.Error(), "db down") {
+		t.Fatalf("expected db error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_Search(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(sqlRe(`FROM audit_log WHERE (user_id = $1 OR owner_id = $1) AND action = $2 AND item_type = $3 AND created_at >= $4 AND id < $5 ORDER BY id DESC LIMIT $6`)).
+		WithArgs(int64(1), domain.ActionItemRead, domain.ItemAccount, from, int64(90), 10).
+		WillReturnRows(sqlmock.NewRows(eventColumns).
+			AddRow(int64(80), int64(2), int64(1), domain.ActionItemRead, domain.ItemAccount, int64(5), "10.0.0.2", "tui", "", createdAt, []byte("p"), []byte("h")))
+
+	events, err := repo.Search(context.Background(), domain.Filter{
+		UserID:   1,
+		Action:   domain.ActionItemRead,
+		ItemType: domain.ItemAccount,
+		From:     from,
+		BeforeID: 90,
+		Limit:    10,
+	})
+	if err != nil {
+		t.Fatalf("Search error: %v", err)
+	}
+	if len(events) != 1 || events[0].ID != 80 || events[0].UserID != 2 || events[0].OwnerID != 1 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_Chain(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	createdAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(sqlRe(`FROM audit_log WHERE id > $1 ORDER BY id LIMIT $2`)).
+		WithArgs(int64(10), 1000).
+		WillReturnRows(sqlmock.NewRows(eventColumns).
+			AddRow(int64(11), int64(1), int64(0), domain.ActionLogin, "", int64(0), "", "", "", createdAt, []byte("p"), []byte("h")).
+			AddRow(int64(12), int64(1), int64(0), domain.ActionRefresh, "", int64(0), "", "", "", createdAt, []byte("h"), []byte("h2")))
+
+	events, err := repo.Chain(context.Background(), 10, 1000)
+	if err != nil {
+		t.Fatalf("Chain error: %v", err)
+	}
+	if len(events) != 2 || events[1].ID != 12 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func sqlRe(q string) string {
+	s := strings.TrimSpace(q)
+	s = strings.Join(strings.Fields(s), " ")
+	s = regexp.QuoteMeta(s)
+	s = strings.ReplaceAll(s, `\ `, `\s+`)
+	return s
+}
@@ -6,17 +6,43 @@ import (
 )
 
 type Card struct {
-	ID     sql.NullInt64
-	UserId sql.NullInt64
-	Bank   sql.NullString
-	PID    []byte
+	ID          sql.NullInt64
+	UserId      sql.NullInt64
+	Bank        sql.NullString
+	Number      []byte
+	HolderName  sql.NullString
+	ExpiryMonth sql.NullInt16
+	ExpiryYear  sql.NullInt16
+	CVV         []byte
+	PIN         []byte
+	Notes       sql.NullString
+	ExpiresAt   sql.NullTime
+	// CustomFields is the raw JSONB column, decoded by the repository
+	CustomFields sql.NullString
+	OrgID        sql.NullInt64
 }
 
 func (c *Card) ToDomain() *domain.BankCard {
 	return &domain.BankCard{
-		CardId: c.ID.Int64,
-		UserId: c.UserId.Int64,
-		Bank:   c.Bank.String,
-		Pid:    string(c.PID),
+		CardId:      c.ID.Int64,
+		UserId:      c.UserId.Int64,
+		Bank:        c.Bank.String,
+		Number:      string(c.Number),
+		HolderName:  c.HolderName.String,
+		ExpiryMonth: int(c.ExpiryMonth.Int16),
+		ExpiryYear:  int(c.ExpiryYear.Int16),
+		CVV:         string(c.CVV),
+		PIN:         string(c.PIN),
+		Notes:       c.Notes.String,
+		ExpiresAt:   c.ExpiresAt.Time,
+		OrgID:       c.OrgID.Int64,
+	}
+}
+
+func (c *Card) scanFields() []any {
+	return []any{
+		&c.ID, &c.UserId, &c.Bank, &c.Number,
+		&c.HolderName, &c.ExpiryMonth, &c.ExpiryYear,
+		&c.CVV, &c.PIN, &c.Notes, &c.ExpiresAt, &c.CustomFields, &c.OrgID,
 	}
 }
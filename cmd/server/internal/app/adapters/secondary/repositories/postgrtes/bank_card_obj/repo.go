@@ -5,19 +5,23 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"server/internal/app/adapters/secondary/repositories/postgrtes/custom_field"
 	"server/internal/app/config"
 	domain "server/internal/app/domain/bank_card_obj"
 	"server/internal/pkg/encryption/aes"
 	"server/internal/pkg/logger"
+	"time"
 
 	"go.uber.org/zap"
 )
 
 func (u *Repository) GetByUserID(ctx context.Context, userId int64) ([]*domain.BankCard, error) {
 	query := `
-		SELECT id, user_id, bank_name, pid
+		SELECT id, user_id, bank_name, number,
+		       holder_name, expiry_month, expiry_year,
+		       cvv, pin, notes, expires_at, custom_fields, org_id
 		FROM bank_data
-		WHERE user_id = $1`
+		WHERE user_id = $1 AND org_id IS NULL`
 
 	var cards []*domain.BankCard
 
@@ -34,61 +38,69 @@ func (u *Repository) GetByUserID(ctx context.Context, userId int64) ([]*domain.B
 	for rows.Next() {
 		obj := new(Card)
 
-		if err := rows.Scan(&obj.ID, &obj.UserId, &obj.Bank, &obj.PID); err != nil {
+		if err := rows.Scan(obj.scanFields()...); err != nil {
 			return nil, err
 		}
 
-		if len(obj.PID) > 0 {
-			decrypted, err := aes.DecryptAES(obj.PID, []byte(config.App.GetBankCardObjEncryptionKey()))
-			if err != nil {
-				return nil, fmt.Errorf("failed decrypt bank card: %w", err)
-			}
-			obj.PID = decrypted
+		card, err := toDomain(obj)
+		if err != nil {
+			return nil, err
 		}
 
-		cards = append(cards, obj.ToDomain())
+		cards = append(cards, card)
 	}
 	return cards, nil
 }
 
 func (u *Repository) GetByID(ctx context.Context, cardId int64) (*domain.BankCard, error) {
 	query := `
-		SELECT id, user_id, bank_name, pid
+		SELECT id, user_id, bank_name, number,
+		       holder_name, expiry_month, expiry_year,
+		       cvv, pin, notes, expires_at, custom_fields, org_id
 		FROM bank_data
 		WHERE id = $1`
 
 	obj := new(Card)
 
-	if err := u.db.QueryRowContext(ctx, query, cardId).Scan(&obj.ID, &obj.UserId, &obj.Bank, &obj.PID); err != nil {
+	if err := u.db.QueryRowContext(ctx, query, cardId).Scan(obj.scanFields()...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrBankCardNotFound
 		}
 		return nil, err
 	}
 
-	decrypted, err := aes.DecryptAES(obj.PID, []byte(config.App.GetBankCardObjEncryptionKey()))
-	if err != nil {
-		return nil, fmt.Errorf("failed decrypt bank card: %w", err)
-	}
-	obj.PID = decrypted
-
-	return obj.ToDomain(), nil
+	return toDomain(obj)
 }
 
 func (u *Repository) Create(ctx context.Context, card *domain.BankCard) (int64, error) {
 	query := `
-		INSERT INTO bank_data (user_id, bank_name, pid)
-		VALUES ($1, $2, $3)
+		INSERT INTO bank_data (
+			user_id, bank_name, number,
+			holder_name, expiry_month, expiry_year,
+			cvv, pin, notes, expires_at, custom_fields, org_id
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING id`
 
-	encryptedPid, err := aes.EncryptAES([]byte(card.Pid), []byte(config.App.GetBankCardObjEncryptionKey()))
+	number, cvv, pin, err := encryptCard(card)
+	if err != nil {
+		return 0, err
+	}
+
+	fields, err := custom_field.Encode(card.CustomFields)
 	if err != nil {
-		return 0, fmt.Errorf("failed to encrypt PID: %w", err)
+		return 0, err
 	}
 
 	var id sql.NullInt64
 
-	if err := u.db.QueryRowContext(ctx, query, card.UserId, card.Bank, encryptedPid).Scan(&id); err != nil {
+	err = u.db.QueryRowContext(ctx, query,
+		card.UserId, card.Bank, number,
+		nullIfEmpty(card.HolderName), card.ExpiryMonth, card.ExpiryYear,
+		cvv, pin, nullIfEmpty(card.Notes), nullIfZero(card.ExpiresAt), fields,
+		nullIfZeroID(card.OrgID),
+	).Scan(&id)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, domain.ErrFaildeCreateBankCardObject
 		}
@@ -105,16 +117,119 @@ func (u *Repository) Create(ctx context.Context, card *domain.BankCard) (int64,
 func (u *Repository) Update(ctx context.Context, card *domain.BankCard) error {
 	query := `
 		UPDATE bank_data SET
-		bank_name = $1, pid = $2
-		WHERE id = $3`
+		bank_name = $1, number = $2,
+		holder_name = $3, expiry_month = $4, expiry_year = $5,
+		cvv = $6, pin = $7, notes = $8, expires_at = $9, custom_fields = $10
+		WHERE id = $11`
+
+	number, cvv, pin, err := encryptCard(card)
+	if err != nil {
+		return err
+	}
 
-	encryptedPassword, err := aes.EncryptAES([]byte(card.Pid), []byte(config.App.GetBankCardObjEncryptionKey()))
+	fields, err := custom_field.Encode(card.CustomFields)
 	if err != nil {
-		return fmt.Errorf("failed to encrypt PID: %w", err)
+		return err
 	}
 
-	if _, err := u.db.ExecContext(ctx, query, card.Bank, encryptedPassword, card.CardId); err != nil {
+	_, err = u.db.ExecContext(ctx, query,
+		card.Bank, number,
+		nullIfEmpty(card.HolderName), card.ExpiryMonth, card.ExpiryYear,
+		cvv, pin, nullIfEmpty(card.Notes), nullIfZero(card.ExpiresAt), fields,
+		card.CardId,
+	)
+	if err != nil {
 		return err
 	}
 	return nil
 }
+
+func (u *Repository) Delete(ctx context.Context, cardId int64) error {
+	query := `DELETE FROM bank_data WHERE id = $1`
+
+	if _, err := u.db.ExecContext(ctx, query, cardId); err != nil {
+		return err
+	}
+	return nil
+}
+
+// help func
+
+// encryptCard encrypts the number, CVV and PIN, empty optional parts are stored as NULL
+func encryptCard(card *domain.BankCard) (number, cvv, pin []byte, err error) {
+	key := []byte(config.App.GetBankCardObjEncryptionKey())
+
+	number, err = aes.EncryptAES([]byte(card.Number), key)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to encrypt card number: %w", err)
+	}
+
+	if card.CVV != "" {
+		if cvv, err = aes.EncryptAES([]byte(card.CVV), key); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to encrypt CVV: %w", err)
+		}
+	}
+
+	if card.PIN != "" {
+		if pin, err = aes.EncryptAES([]byte(card.PIN), key); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to encrypt PIN: %w", err)
+		}
+	}
+
+	return number, cvv, pin, nil
+}
+
+func decryptCard(obj *Card) error {
+	key := []byte(config.App.GetBankCardObjEncryptionKey())
+
+	for _, field := range []*[]byte{&obj.Number, &obj.CVV, &obj.PIN} {
+		if len(*field) == 0 {
+			continue
+		}
+
+		decrypted, err := aes.DecryptAES(*field, key)
+		if err != nil {
+			return fmt.Errorf("failed decrypt bank card: %w", err)
+		}
+		*field = decrypted
+	}
+
+	return nil
+}
+
+// toDomain decrypts the secrets and decodes the custom fields of a row
+func toDomain(obj *Card) (*domain.BankCard, error) {
+	if err := decryptCard(obj); err != nil {
+		return nil, err
+	}
+
+	fields, err := custom_field.Decode(obj.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+
+	card := obj.ToDomain()
+	card.CustomFields = fields
+	return card, nil
+}
+
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func nullIfZero(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func nullIfZeroID(id int64) any {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"server/internal/app/config"
 	domain "server/internal/app/domain/bank_card_obj"
@@ -36,7 +37,7 @@ func requireBankCardEncKey(t *testing.T) string {
 	return key
 }
 
-func TestRepository_GetByID_OK_DecryptsPID(t *testing.T) {
+func TestRepository_GetByID_OK_DecryptsSensitiveFields(t *testing.T) {
 	t.Parallel()
 
 	key := requireBankCardEncKey(t)
@@ -49,19 +50,31 @@ func TestRepository_GetByID_OK_DecryptsPID(t *testing.T) {
 
 	repo := &Repository{db: db}
 
-	plainPID := "PID-123"
-	enc, err := aes.EncryptAES([]byte(plainPID), []byte(key))
+	plainNumber := "4242424242424242"
+	enc, err := aes.EncryptAES([]byte(plainNumber), []byte(key))
 	if err != nil {
 		t.Fatalf("EncryptAES error: %v", err)
 	}
+	encCVV, err := aes.EncryptAES([]byte("123"), []byte(key))
+	if err != nil {
+		t.Fatalf("EncryptAES error: %v", err)
+	}
+
+	expiresAt := time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)
 
 	const q = `
-		SELECT id, user_id, bank_name, pid
+		SELECT id, user_id, bank_name, number,
+		       holder_name, expiry_month, expiry_year,
+		       cvv, pin, notes, expires_at, custom_fields, org_id
 		FROM bank_data
 		WHERE id = $1`
 
-	rows := sqlmock.NewRows([]string{"id", "user_id", "bank_name", "pid"}).
-		AddRow(int64(10), int64(7), "maib", enc)
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "bank_name", "number",
+		"holder_name", "expiry_month", "expiry_year",
+		"cvv", "pin", "notes", "expires_at", "custom_fields", "org_id",
+	}).
+		AddRow(int64(10), int64(7), "maib", enc, "JOHN DOE", int16(12), int16(2030), encCVV, nil, nil, expiresAt, `[{"name":"account no","type":"text","value":"2259"}]`, nil)
 
 	mock.ExpectQuery(sqlRe(q)).
 		WithArgs(int64(10)).
@@ -77,8 +90,20 @@ func TestRepository_GetByID_OK_DecryptsPID(t *testing.T) {
 	if got.CardId != 10 || got.UserId != 7 {
 		t.Fatalf("unexpected ids: %+v", got)
 	}
-	if got.Pid != plainPID {
-		t.Fatalf("expected decrypted pid=%q, got %q", plainPID, got.Pid)
+	if got.Number != plainNumber {
+		t.Fatalf("expected decrypted number=%q, got %q", plainNumber, got.Number)
+	}
+	if got.CVV != "123" || got.PIN != "" {
+		t.Fatalf("expected decrypted cvv and empty pin, got cvv=%q pin=%q", got.CVV, got.PIN)
+	}
+	if got.HolderName != "JOHN DOE" || got.ExpiryMonth != 12 || got.ExpiryYear != 2030 {
+		t.Fatalf("unexpected card fields: %+v", got)
+	}
+	if !got.ExpiresAt.Equal(expiresAt) {
+		t.Fatalf("expected expires_at=%v, got %v", expiresAt, got.ExpiresAt)
+	}
+	if len(got.CustomFields) != 1 || got.CustomFields[0].Name != "account no" || got.CustomFields[0].Value != "2259" {
+		t.Fatalf("unexpected custom fields: %+v", got.CustomFields)
 	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
@@ -98,7 +123,9 @@ func TestRepository_GetByID_NoRows_ReturnsNotFound(t *testing.T) {
 	repo := &Repository{db: db}
 
 	const q = `
-		SELECT id, user_id, bank_name, pid
+		SELECT id, user_id, bank_name, number,
+		       holder_name, expiry_month, expiry_year,
+		       cvv, pin, notes, expires_at, custom_fields, org_id
 		FROM bank_data
 		WHERE id = $1`
 
@@ -130,18 +157,31 @@ func TestRepository_Create_OK_ReturnsID(t *testing.T) {
 	repo := &Repository{db: db}
 
 	const q = `
-		INSERT INTO bank_data (user_id, bank_name, pid)
-		VALUES ($1, $2, $3)
+		INSERT INTO bank_data (
+			user_id, bank_name, number,
+			holder_name, expiry_month, expiry_year,
+			cvv, pin, notes, expires_at, custom_fields, org_id
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING id`
 
 	mock.ExpectQuery(sqlRe(q)).
-		WithArgs(int64(7), "maib", sqlmock.AnyArg()).
+		WithArgs(
+			int64(7), "maib", sqlmock.AnyArg(),
+			"JOHN DOE", 12, 2030,
+			sqlmock.AnyArg(), []byte(nil), nil, nil, "[]",
+			nil,
+		).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(123)))
 
 	id, err := repo.Create(context.Background(), &domain.BankCard{
-		UserId: 7,
-		Bank:   "maib",
-		Pid:    "PID-123",
+		UserId:      7,
+		Bank:        "maib",
+		Number:      "4242424242424242",
+		HolderName:  "JOHN DOE",
+		ExpiryMonth: 12,
+		ExpiryYear:  2030,
+		CVV:         "123",
 	})
 	if err != nil {
 		t.Fatalf("Create error: %v", err)
@@ -167,19 +207,33 @@ func TestRepository_Update_OK(t *testing.T) {
 
 	repo := &Repository{db: db}
 
+	expiresAt := time.Date(2031, 2, 1, 0, 0, 0, 0, time.UTC)
+
 	const q = `
 		UPDATE bank_data SET
-		bank_name = $1, pid = $2
-		WHERE id = $3`
+		bank_name = $1, number = $2,
+		holder_name = $3, expiry_month = $4, expiry_year = $5,
+		cvv = $6, pin = $7, notes = $8, expires_at = $9, custom_fields = $10
+		WHERE id = $11`
 
 	mock.ExpectExec(sqlRe(q)).
-		WithArgs("maib", sqlmock.AnyArg(), int64(55)).
+		WithArgs(
+			"maib", sqlmock.AnyArg(),
+			nil, 1, 2031,
+			[]byte(nil), sqlmock.AnyArg(), "billing address", expiresAt, sqlmock.AnyArg(),
+			int64(55),
+		).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	err = repo.Update(context.Background(), &domain.BankCard{
-		CardId: 55,
-		Bank:   "maib",
-		Pid:    "PID-NEW",
+		CardId:      55,
+		Bank:        "maib",
+		Number:      "5555555555554444",
+		ExpiryMonth: 1,
+		ExpiryYear:  2031,
+		PIN:         "0000",
+		Notes:       "billing address",
+		ExpiresAt:   expiresAt,
 	})
 	if err != nil {
 		t.Fatalf("Update error: %v", err)
@@ -190,7 +244,7 @@ func TestRepository_Update_OK(t *testing.T) {
 	}
 }
 
-func TestRepository_GetByUserID_OK_ListDecryptsPID(t *testing.T) {
+func TestRepository_GetByUserID_OK_ListDecryptsNumber(t *testing.T) {
 	t.Parallel()
 
 	key := requireBankCardEncKey(t)
@@ -203,23 +257,29 @@ func TestRepository_GetByUserID_OK_ListDecryptsPID(t *testing.T) {
 
 	repo := &Repository{db: db}
 
-	enc1, err := aes.EncryptAES([]byte("PID1"), []byte(key))
+	enc1, err := aes.EncryptAES([]byte("4242424242424242"), []byte(key))
 	if err != nil {
 		t.Fatalf("EncryptAES error: %v", err)
 	}
-	enc2, err := aes.EncryptAES([]byte("PID2"), []byte(key))
+	enc2, err := aes.EncryptAES([]byte("5555555555554444"), []byte(key))
 	if err != nil {
 		t.Fatalf("EncryptAES error: %v", err)
 	}
 
 	const q = `
-		SELECT id, user_id, bank_name, pid
+		SELECT id, user_id, bank_name, number,
+		       holder_name, expiry_month, expiry_year,
+		       cvv, pin, notes, expires_at, custom_fields, org_id
 		FROM bank_data
-		WHERE user_id = $1`
+		WHERE user_id = $1 AND org_id IS NULL`
 
-	rows := sqlmock.NewRows([]string{"id", "user_id", "bank_name", "pid"}).
-		AddRow(int64(1), int64(7), "maib", enc1).
-		AddRow(int64(2), int64(7), "victoriabank", enc2)
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "bank_name", "number",
+		"holder_name", "expiry_month", "expiry_year",
+		"cvv", "pin", "notes", "expires_at", "custom_fields", "org_id",
+	}).
+		AddRow(int64(1), int64(7), "maib", enc1, nil, int16(1), int16(2030), nil, nil, nil, nil, "[]", nil).
+		AddRow(int64(2), int64(7), "victoriabank", enc2, nil, int16(2), int16(2031), nil, nil, nil, nil, nil, nil)
 
 	mock.ExpectQuery(sqlRe(q)).
 		WithArgs(int64(7)).
@@ -232,11 +292,11 @@ func TestRepository_GetByUserID_OK_ListDecryptsPID(t *testing.T) {
 	if len(list) != 2 {
 		t.Fatalf("expected 2 cards, got %d", len(list))
 	}
-	if list[0].Pid != "PID1" {
-		t.Fatalf("expected decrypted PID1, got %q", list[0].Pid)
+	if list[0].Number != "4242424242424242" {
+		t.Fatalf("expected decrypted number, got %q", list[0].Number)
 	}
-	if list[1].Pid != "PID2" {
-		t.Fatalf("expected decrypted PID2, got %q", list[1].Pid)
+	if list[1].Masked() != "**** 4444" {
+		t.Fatalf("expected masked number, got %q", list[1].Masked())
 	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
@@ -256,3 +316,27 @@ func sqlRe(q string) string {
 	s = strings.ReplaceAll(s, `\ `, `\s+`)
 	return s
 }
+
+func TestRepository_Delete(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db: db}
+
+	mock.ExpectExec(sqlRe(`DELETE FROM bank_data WHERE id = $1`)).
+		WithArgs(int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.Delete(context.Background(), 5); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package cert_obj
+
+import (
+	"database/sql"
+	domain "server/internal/app/domain/cert_obj"
+	"strings"
+)
+
+type Certificate struct {
+	ID           sql.NullInt64
+	UserId       sql.NullInt64
+	Title        sql.NullString
+	Certificate  sql.NullString
+	PrivateKey   []byte
+	Subject      sql.NullString
+	Issuer       sql.NullString
+	SANs         sql.NullString
+	SerialNumber sql.NullString
+	Fingerprint  sql.NullString
+	NotBefore    sql.NullTime
+	NotAfter     sql.NullTime
+	CreatedAt    sql.NullTime
+}
+
+func (c *Certificate) ToDomain() *domain.Certificate {
+	return &domain.Certificate{
+		CertId:       c.ID.Int64,
+		UserId:       c.UserId.Int64,
+		Title:        c.Title.String,
+		Certificate:  c.Certificate.String,
+		PrivateKey:   string(c.PrivateKey),
+		Subject:      c.Subject.String,
+		Issuer:       c.Issuer.String,
+		SANs:         splitSANs(c.SANs.String),
+		SerialNumber: c.SerialNumber.String,
+		Fingerprint:  c.Fingerprint.String,
+		NotBefore:    c.NotBefore.Time,
+		NotAfter:     c.NotAfter.Time,
+		CreatedAt:    c.CreatedAt.Time,
+	}
+}
+
+func (c *Certificate) scanFields() []any {
+	return []any{
+		&c.ID, &c.UserId, &c.Title, &c.Certificate, &c.PrivateKey,
+		&c.Subject, &c.Issuer, &c.SANs, &c.SerialNumber, &c.Fingerprint,
+		&c.NotBefore, &c.NotAfter, &c.CreatedAt,
+	}
+}
+
+// SANs are stored newline separated, see the cert_data migration
+func joinSANs(sans []string) string {
+	return strings.Join(sans, "\n")
+}
+
+func splitSANs(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
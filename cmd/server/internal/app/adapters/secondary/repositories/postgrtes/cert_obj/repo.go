@@ -0,0 +1,143 @@
+package cert_obj
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"server/internal/app/config"
+	domain "server/internal/app/domain/cert_obj"
+	"server/internal/pkg/encryption/aes"
+	"server/internal/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const selectColumns = `
+		SELECT id, user_id, title, certificate, private_key,
+		       subject, issuer, sans, serial_number, fingerprint,
+		       not_before, not_after, created_at
+		FROM cert_data`
+
+// GetByUserID lists certificates of the user, a non-empty search is matched case-insensitively
+// against subject, issuer and SANs
+func (u *Repository) GetByUserID(ctx context.Context, userId int64, search string) ([]*domain.Certificate, error) {
+	query := selectColumns + `
+		WHERE user_id = $1
+		  AND ($2 = '' OR position(lower($2) in lower(subject || ' ' || issuer || ' ' || sans)) > 0)
+		ORDER BY not_after, id`
+
+	return u.list(ctx, query, userId, search)
+}
+
+// GetExpiring lists certificates of the user that expire before until, soonest first
+func (u *Repository) GetExpiring(ctx context.Context, userId int64, until time.Time) ([]*domain.Certificate, error) {
+	query := selectColumns + `
+		WHERE user_id = $1 AND not_after <= $2
+		ORDER BY not_after, id`
+
+	return u.list(ctx, query, userId, until)
+}
+
+func (u *Repository) GetByID(ctx context.Context, certId int64) (*domain.Certificate, error) {
+	query := selectColumns + `
+		WHERE id = $1`
+
+	obj := new(Certificate)
+
+	if err := u.db.QueryRowContext(ctx, query, certId).Scan(obj.scanFields()...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrCertificateNotFound
+		}
+		return nil, err
+	}
+
+	if err := decryptKey(obj); err != nil {
+		return nil, err
+	}
+
+	return obj.ToDomain(), nil
+}
+
+func (u *Repository) Create(ctx context.Context, cert *domain.Certificate) (int64, error) {
+	query := `
+		INSERT INTO cert_data (user_id, title, certificate, private_key, subject, issuer, sans,
+		                       serial_number, fingerprint, not_before, not_after)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id`
+
+	var privateKey []byte
+	if cert.PrivateKey != "" {
+		encrypted, err := aes.EncryptAES([]byte(cert.PrivateKey), []byte(config.App.GetCertObjEncryptionKey()))
+		if err != nil {
+			return 0, fmt.Errorf("failed to encrypt certificate private key: %w", err)
+		}
+		privateKey = encrypted
+	}
+
+	var id sql.NullInt64
+
+	err := u.db.QueryRowContext(ctx, query,
+		cert.UserId, cert.Title, cert.Certificate, privateKey,
+		cert.Subject, cert.Issuer, joinSANs(cert.SANs),
+		cert.SerialNumber, cert.Fingerprint, cert.NotBefore, cert.NotAfter,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrFailedCreateCertificate
+		}
+		return 0, err
+	}
+
+	if !id.Valid {
+		return 0, domain.ErrFailedCreateCertificate
+	}
+
+	return id.Int64, nil
+}
+
+// help func
+
+func (u *Repository) list(ctx context.Context, query string, args ...any) ([]*domain.Certificate, error) {
+	var certs []*domain.Certificate
+
+	rows, err := u.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
+
+	for rows.Next() {
+		obj := new(Certificate)
+
+		if err := rows.Scan(obj.scanFields()...); err != nil {
+			return nil, err
+		}
+
+		if err := decryptKey(obj); err != nil {
+			return nil, err
+		}
+
+		certs = append(certs, obj.ToDomain())
+	}
+	return certs, rows.Err()
+}
+
+func decryptKey(obj *Certificate) error {
+	if len(obj.PrivateKey) == 0 {
+		return nil
+	}
+
+	decrypted, err := aes.DecryptAES(obj.PrivateKey, []byte(config.App.GetCertObjEncryptionKey()))
+	if err != nil {
+		return fmt.Errorf("failed decrypt certificate private key: %w", err)
+	}
+	obj.PrivateKey = decrypted
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package custom_field
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"server/internal/app/config"
+	domain "server/internal/app/domain/custom_field"
+	"server/internal/pkg/encryption/aes"
+)
+
+// field is the JSONB representation of a custom field, hidden values hold base64 encoded ciphertext
+type field struct {
+	Name  string `json:"name"`
+	Kind  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Encode turns the fields into the value of a custom_fields column, hidden values are encrypted
+func Encode(fields []domain.Field) (string, error) {
+	key := []byte(config.App.GetCustomFieldEncryptionKey())
+
+	out := make([]field, 0, len(fields))
+	for _, f := range fields {
+		value := f.Value
+
+		if f.Kind == domain.KindHidden {
+			encrypted, err := aes.EncryptAES([]byte(f.Value), key)
+			if err != nil {
+				return "", fmt.Errorf("failed to encrypt custom field %q: %w", f.Name, err)
+			}
+			value = base64.StdEncoding.EncodeToString(encrypted)
+		}
+
+		out = append(out, field{Name: f.Name, Kind: string(f.Kind), Value: value})
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Decode reads a custom_fields column, a NULL column means no fields
+func Decode(column sql.NullString) ([]domain.Field, error) {
+	if !column.Valid || column.String == "" {
+		return nil, nil
+	}
+
+	var stored []field
+	if err := json.Unmarshal([]byte(column.String), &stored); err != nil {
+		return nil, fmt.Errorf("failed to decode custom fields: %w", err)
+	}
+
+	if len(stored) == 0 {
+		return nil, nil
+	}
+
+	key := []byte(config.App.GetCustomFieldEncryptionKey())
+
+	fields := make([]domain.Field, 0, len(stored))
+	for _, f := range stored {
+		value := f.Value
+
+		if domain.Kind(f.Kind) == domain.KindHidden {
+			encrypted, err := base64.StdEncoding.DecodeString(f.Value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode custom field %q: %w", f.Name, err)
+			}
+
+			decrypted, err := aes.DecryptAES(encrypted, key)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt custom field %q: %w", f.Name, err)
+			}
+			value = string(decrypted)
+		}
+
+		fields = append(fields, domain.Field{Name: f.Name, Kind: domain.Kind(f.Kind), Value: value})
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,80 @@
+package custom_field
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"server/internal/app/config"
+	domain "server/internal/app/domain/custom_field"
+)
+
+func init() {
+	config.InitTestConfig()
+}
+
+func TestEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	fields := []domain.Field{
+		{Name: "Security question", Kind: domain.KindText, Value: "first pet"},
+		{Name: "Answer", Kind: domain.KindHidden, Value: "rex"},
+		{Name: "Login page", Kind: domain.KindURL, Value: "https://example.com/login"},
+	}
+
+	encoded, err := Encode(fields)
+	if err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+
+	t.Run("hidden value -> encrypted at rest", func(t *testing.T) {
+		var stored []field
+		if err := json.Unmarshal([]byte(encoded), &stored); err != nil {
+			t.Fatalf("stored value is not json: %v", err)
+		}
+		if len(stored) != 3 {
+			t.Fatalf("expected 3 stored fields, got %d", len(stored))
+		}
+		if stored[1].Value == "rex" || strings.Contains(encoded, `"rex"`) {
+			t.Fatalf("hidden value stored in plain text: %s", encoded)
+		}
+		if stored[0].Value != "first pet" || stored[2].Value != "https://example.com/login" {
+			t.Fatalf("non hidden values must be stored as is: %s", encoded)
+		}
+	})
+
+	t.Run("round trip -> same fields in same order", func(t *testing.T) {
+		got, err := Decode(sql.NullString{String: encoded, Valid: true})
+		if err != nil {
+			t.Fatalf("Decode error: %v", err)
+		}
+		if !reflect.DeepEqual(got, fields) {
+			t.Fatalf("expected %+v, got %+v", fields, got)
+		}
+	})
+}
+
+func TestDecode_Empty(t *testing.T) {
+	t.Parallel()
+
+	for _, column := range []sql.NullString{{}, {String: "[]", Valid: true}} {
+		got, err := Decode(column)
+		if err != nil {
+			t.Fatalf("Decode(%+v) error: %v", column, err)
+		}
+		if got != nil {
+			t.Fatalf("Decode(%+v) expected nil, got %+v", column, got)
+		}
+	}
+}
+
+func TestDecode_BrokenCiphertext(t *testing.T) {
+	t.Parallel()
+
+	_, err := Decode(sql.NullString{String: `[{"name":"pin","type":"hidden","value":"not base64!"}]`, Valid: true})
+	if err == nil {
+		t.Fatal("expected error for broken hidden value")
+	}
+}
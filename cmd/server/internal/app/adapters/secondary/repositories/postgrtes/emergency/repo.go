@@ -0,0 +1,231 @@
+package emergency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"server/internal/pkg/logger"
+	postgres "server/internal/pkg/postgres"
+	"time"
+
+	domain "server/internal/app/domain/emergency"
+	"server/internal/app/domain/share"
+
+	"go.uber.org/zap"
+)
+
+// selectContact reads a contact with the names of both users, callers add the WHERE clause
+const selectContact = `
+	SELECT c.id, c.grantor_id, g.username, c.grantee_id, e.username,
+	       c.access_type, c.wait_seconds, c.status, c.requested_at, c.created_at
+	FROM emergency_contacts c
+	JOIN users g ON g.id = c.grantor_id
+	JOIN users e ON e.id = c.grantee_id`
+
+// vaultItemsQuery resolves personal accounts, cards and texts of a user to a common shape
+const vaultItemsQuery = `
+	SELECT 'account', id, service_name FROM account_data WHERE user_id = $1 AND org_id IS NULL
+	UNION ALL
+	SELECT 'card', id, bank_name FROM bank_data WHERE user_id = $1 AND org_id IS NULL
+	UNION ALL
+	SELECT 'text', id, title FROM text_data WHERE user_id = $1 AND org_id IS NULL
+	ORDER BY 1, 3, 2`
+
+func (r *Repository) UserIDByName(ctx context.Context, username string) (int64, error) {
+	query := `SELECT id FROM users WHERE username = $1`
+
+	var id int64
+	if err := r.db.QueryRowContext(ctx, query, username).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrUserNotFound
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *Repository) Create(ctx context.Context, c *domain.Contact) (int64, error) {
+	query := `
+		INSERT INTO emergency_contacts (grantor_id, grantee_id, access_type, wait_seconds, status)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	var id int64
+	err := r.db.QueryRowContext(ctx, query, c.GrantorID, c.GranteeID, c.AccessType, int64(c.Wait/time.Second), c.Status).Scan(&id)
+	if err != nil {
+		if postgres.IsUniqueViolation(err, "uq_emergency_contacts") {
+			return 0, domain.ErrAlreadyContact
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *Repository) GetByID(ctx context.Context, contactID int64) (*domain.Contact, error) {
+	c, err := scanContact(r.db.QueryRowContext(ctx, selectContact+` WHERE c.id = $1`, contactID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrContactNotFound
+		}
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (r *Repository) ListByGrantor(ctx context.Context, grantorID int64) ([]*domain.Contact, error) {
+	return r.list(ctx, selectContact+` WHERE c.grantor_id = $1 ORDER BY lower(e.username), c.id`, grantorID)
+}
+
+func (r *Repository) ListByGrantee(ctx context.Context, granteeID int64) ([]*domain.Contact, error) {
+	return r.list(ctx, selectContact+` WHERE c.grantee_id = $1 ORDER BY lower(g.username), c.id`, granteeID)
+}
+
+func (r *Repository) SetStatus(ctx context.Context, contactID int64, status string, requestedAt time.Time) error {
+	query := `UPDATE emergency_contacts SET status = $1, requested_at = $2 WHERE id = $3`
+
+	res, err := r.db.ExecContext(ctx, query, status, nullIfZero(requestedAt), contactID)
+	if err != nil {
+		return err
+	}
+
+	return expectOne(res, domain.ErrContactNotFound)
+}
+
+func (r *Repository) Delete(ctx context.Context, contactID int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM emergency_contacts WHERE id = $1`, contactID)
+	if err != nil {
+		return err
+	}
+
+	return expectOne(res, domain.ErrContactNotFound)
+}
+
+func (r *Repository) ForItem(ctx context.Context, granteeID int64, item share.Item) (*domain.Contact, error) {
+	table, err := itemTable(item.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(selectContact+`
+		JOIN %s i ON i.user_id = c.grantor_id
+		WHERE i.id = $1 AND i.org_id IS NULL AND c.grantee_id = $2`, table)
+
+	c, err := scanContact(r.db.QueryRowContext(ctx, query, item.ID, granteeID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrContactNotFound
+		}
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (r *Repository) VaultItems(ctx context.Context, grantorID int64) ([]*domain.Item, error) {
+	rows, err := r.db.QueryContext(ctx, vaultItemsQuery, grantorID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
+
+	var out []*domain.Item
+	for rows.Next() {
+		it := new(domain.Item)
+		if err := rows.Scan(&it.Type, &it.ID, &it.Title); err != nil {
+			return nil, err
+		}
+		out = append(out, it)
+	}
+
+	return out, rows.Err()
+}
+
+// help func
+
+func (r *Repository) list(ctx context.Context, query string, userID int64) ([]*domain.Contact, error) {
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
+
+	var out []*domain.Contact
+	for rows.Next() {
+		c, err := scanContact(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+
+	return out, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanContact(row scanner) (*domain.Contact, error) {
+	var (
+		c           domain.Contact
+		waitSeconds int64
+		requestedAt sql.NullTime
+	)
+
+	if err := row.Scan(&c.ID, &c.GrantorID, &c.GrantorName, &c.GranteeID, &c.GranteeName,
+		&c.AccessType, &waitSeconds, &c.Status, &requestedAt, &c.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	c.Wait = time.Duration(waitSeconds) * time.Second
+	if requestedAt.Valid {
+		c.RequestedAt = requestedAt.Time
+	}
+
+	return &c, nil
+}
+
+// itemTable maps an item type to the table of its rows
+func itemTable(itemType string) (string, error) {
+	switch itemType {
+	case share.ItemAccount:
+		return "account_data", nil
+	case share.ItemBankCard:
+		return "bank_data", nil
+	case share.ItemText:
+		return "text_data", nil
+	default:
+		return "", share.ErrInvalidItem
+	}
+}
+
+func nullIfZero(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func expectOne(res sql.Result, notFound error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return notFound
+	}
+
+	return nil
+}
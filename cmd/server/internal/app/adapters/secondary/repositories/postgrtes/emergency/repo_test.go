@@ -0,0 +1,215 @@
+package emergency
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/app/config"
+	domain "server/internal/app/domain/emergency"
+	"server/internal/app/domain/share"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func init() {
+	config.InitTestConfig()
+}
+
+var contactColumns = []string{
+	"id", "grantor_id", "grantor", "grantee_id", "grantee",
+	"access_type", "wait_seconds", "status", "requested_at", "created_at",
+}
+
+func newRepo(t *testing.T) (*Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return &Repository{db: db}, mock
+}
+
+func TestRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	const q = `INSERT INTO emergency_contacts (grantor_id, grantee_id, access_type, wait_seconds, status)`
+
+	repo, mock := newRepo(t)
+
+	c := &domain.Contact{GrantorID: 1, GranteeID: 2, AccessType: domain.AccessView, Wait: 48 * time.Hour, Status: domain.StatusIdle}
+
+	mock.ExpectQuery(sqlRe(q)).
+		WithArgs(int64(1), int64(2), domain.AccessView, int64(172800), domain.StatusIdle).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(5)))
+	mock.ExpectQuery(sqlRe(q)).
+		WithArgs(int64(1), int64(2), domain.AccessView, int64(172800), domain.StatusIdle).
+		WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "uq_emergency_contacts"})
+
+	id, err := repo.Create(context.Background(), c)
+	if err != nil || id != 5 {
+		t.Fatalf("Create = %d, %v", id, err)
+	}
+	if _, err := repo.Create(context.Background(), c); !errors.Is(err, domain.ErrAlreadyContact) {
+		t.Fatalf("expected ErrAlreadyContact, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_GetByID(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	requestedAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(sqlRe(selectContact + ` WHERE c.id = $1`)).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows(contactColumns).
+			AddRow(int64(5), int64(1), "alice", int64(2), "bob", domain.AccessTakeover, int64(3600), domain.StatusRequested, requestedAt, createdAt))
+	mock.ExpectQuery(sqlRe(selectContact + ` WHERE c.id = $1`)).
+		WithArgs(int64(6)).
+		WillReturnRows(sqlmock.NewRows(contactColumns))
+
+	c, err := repo.GetByID(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("GetByID error: %v", err)
+	}
+	if c.GrantorName != "alice" || c.GranteeName != "bob" || c.Wait != time.Hour || !c.RequestedAt.Equal(requestedAt) {
+		t.Fatalf("unexpected contact: %+v", c)
+	}
+
+	if _, err := repo.GetByID(context.Background(), 6); !errors.Is(err, domain.ErrContactNotFound) {
+		t.Fatalf("expected ErrContactNotFound, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_ListByGrantee(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	createdAt := time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(sqlRe(selectContact + ` WHERE c.grantee_id = $1`)).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows(contactColumns).
+			AddRow(int64(5), int64(1), "alice", int64(2), "bob", domain.AccessView, int64(3600), domain.StatusIdle, nil, createdAt))
+
+	list, err := repo.ListByGrantee(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("ListByGrantee error: %v", err)
+	}
+	if len(list) != 1 || list[0].GrantorName != "alice" || !list[0].RequestedAt.IsZero() {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_SetStatus(t *testing.T) {
+	t.Parallel()
+
+	const q = `UPDATE emergency_contacts SET status = $1, requested_at = $2 WHERE id = $3`
+
+	repo, mock := newRepo(t)
+
+	requestedAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(sqlRe(q)).
+		WithArgs(domain.StatusRequested, requestedAt, int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(sqlRe(q)).
+		WithArgs(domain.StatusRejected, driver.Value(nil), int64(9)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.SetStatus(context.Background(), 5, domain.StatusRequested, requestedAt); err != nil {
+		t.Fatalf("SetStatus error: %v", err)
+	}
+	if err := repo.SetStatus(context.Background(), 9, domain.StatusRejected, time.Time{}); !errors.Is(err, domain.ErrContactNotFound) {
+		t.Fatalf("expected ErrContactNotFound, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_ForItem(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	createdAt := time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(sqlRe(`JOIN bank_data i ON i.user_id = c.grantor_id WHERE i.id = $1 AND i.org_id IS NULL AND c.grantee_id = $2`)).
+		WithArgs(int64(4), int64(2)).
+		WillReturnRows(sqlmock.NewRows(contactColumns).
+			AddRow(int64(5), int64(1), "alice", int64(2), "bob", domain.AccessView, int64(3600), domain.StatusApproved, createdAt, createdAt))
+
+	c, err := repo.ForItem(context.Background(), 2, share.Item{Type: share.ItemBankCard, ID: 4})
+	if err != nil {
+		t.Fatalf("ForItem error: %v", err)
+	}
+	if c.ID != 5 || c.Status != domain.StatusApproved {
+		t.Fatalf("unexpected contact: %+v", c)
+	}
+
+	if _, err := repo.ForItem(context.Background(), 2, share.Item{Type: "ssh", ID: 4}); !errors.Is(err, share.ErrInvalidItem) {
+		t.Fatalf("expected ErrInvalidItem, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_VaultItems(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	mock.ExpectQuery(sqlRe(vaultItemsQuery)).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"type", "id", "title"}).
+			AddRow("account", int64(4), "github").
+			AddRow("text", int64(2), "wifi"))
+
+	list, err := repo.VaultItems(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("VaultItems error: %v", err)
+	}
+	if len(list) != 2 || list[0].Type != share.ItemAccount || list[1].Title != "wifi" {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func sqlRe(q string) string {
+	s := strings.TrimSpace(q)
+	s = strings.Join(strings.Fields(s), " ")
+	s = regexp.QuoteMeta(s)
+	s = strings.ReplaceAll(s, `\ `, `\s+`)
+	return s
+}
@@ -0,0 +1,188 @@
+package file_obj
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"server/internal/pkg/logger"
+	postgres "server/internal/pkg/postgres"
+	"time"
+
+	domain "server/internal/app/domain/file_obj"
+
+	"go.uber.org/zap"
+)
+
+// MarkUploaded makes a pending file visible and drops its upload operation
+func (r *Repository) MarkUploaded(ctx context.Context, fileID int64, etag string) error {
+	if fileID <= 0 {
+		return domain.ErrInvalidFileID
+	}
+
+	query := `
+		UPDATE file_data
+		SET status = $2, etag = COALESCE($3, etag)
+		WHERE id = $1
+	`
+
+	opQuery := `DELETE FROM file_operations WHERE operation = $1 AND file_id = $2`
+
+	return postgres.WithTx(ctx, r.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query, fileID, domain.StatusReady, nullIfEmpty(etag))
+		if err != nil {
+			return fmt.Errorf("mark uploaded file_data id=%d: %w", fileID, err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("mark uploaded file_data id=%d: rows affected: %w", fileID, err)
+		}
+		if affected == 0 {
+			return domain.ErrFileNotFound
+		}
+
+		if _, err := tx.ExecContext(ctx, opQuery, domain.OperationUpload, fileID); err != nil {
+			return fmt.Errorf("mark uploaded file_data id=%d: delete file_operations: %w", fileID, err)
+		}
+		return nil
+	})
+}
+
+// AbortUpload removes a pending file together with its upload operation
+func (r *Repository) AbortUpload(ctx context.Context, fileID int64) error {
+	if fileID <= 0 {
+		return domain.ErrInvalidFileID
+	}
+
+	query := `DELETE FROM file_data WHERE id = $1 AND status = $2`
+
+	opQuery := `DELETE FROM file_operations WHERE operation = $1 AND file_id = $2`
+
+	return postgres.WithTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, query, fileID, domain.StatusPending); err != nil {
+			return fmt.Errorf("abort upload file_data id=%d: %w", fileID, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, opQuery, domain.OperationUpload, fileID); err != nil {
+			return fmt.Errorf("abort upload file_data id=%d: delete file_operations: %w", fileID, err)
+		}
+		return nil
+	})
+}
+
+// ClaimOperations returns operations due before staleBefore and hides them from other workers for lease
+func (r *Repository) ClaimOperations(ctx context.Context, staleBefore time.Time, lease time.Duration, limit int) ([]*domain.Operation, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	query := `
+		UPDATE file_operations
+		SET attempts = attempts + 1,
+		    next_attempt_at = now() + $3 * INTERVAL '1 millisecond'
+		WHERE id IN (
+			SELECT id
+			FROM file_operations
+			WHERE next_attempt_at <= now() AND created_at <= $1
+			ORDER BY next_attempt_at, id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING
+			id, operation, file_id,
+			bucket_name, object_key, size_bytes,
+			attempts, last_error, created_at, next_attempt_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, staleBefore, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("claim file_operations: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
+
+	var out []*domain.Operation
+	for rows.Next() {
+		op, err := scanOperation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan file_operations row: %w", err)
+		}
+		out = append(out, op)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
+	}
+
+	return out, nil
+}
+
+// CompleteOperation removes an operation once storage reflects it
+func (r *Repository) CompleteOperation(ctx context.Context, op *domain.Operation) error {
+	if op == nil {
+		return fmt.Errorf("operation is nil")
+	}
+
+	query := `
+		DELETE FROM file_operations
+		WHERE operation = $1 AND bucket_name = $2 AND object_key = $3
+	`
+
+	_, err := r.db.ExecContext(ctx, query, op.Type, op.Storage.BucketName, op.Storage.ObjectKey)
+	if err != nil {
+		return fmt.Errorf("complete file_operations id=%d: %w", op.ID, err)
+	}
+	return nil
+}
+
+// RetryOperation records the failure and schedules the next attempt
+func (r *Repository) RetryOperation(ctx context.Context, op *domain.Operation, lastErr string, next time.Time) error {
+	if op == nil {
+		return fmt.Errorf("operation is nil")
+	}
+
+	query := `
+		UPDATE file_operations
+		SET last_error = $2, next_attempt_at = $3
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, op.ID, nullIfEmpty(lastErr), next)
+	if err != nil {
+		return fmt.Errorf("retry file_operations id=%d: %w", op.ID, err)
+	}
+	return nil
+}
+
+func scanOperation(s scanner) (*domain.Operation, error) {
+	var (
+		op         domain.Operation
+		fileID     sql.NullInt64
+		bucketName string
+		objectKey  string
+		lastError  sql.NullString
+	)
+
+	err := s.Scan(
+		&op.ID, &op.Type, &fileID,
+		&bucketName, &objectKey, &op.SizeBytes,
+		&op.Attempts, &lastError, &op.CreatedAt, &op.NextAttemptAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := domain.NewStorageRef(bucketName, objectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	op.Storage = ref
+	op.FileID = fileID.Int64
+	op.LastError = nullStringToString(lastError)
+
+	return &op, nil
+}
@@ -0,0 +1,169 @@
+package file_obj
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "server/internal/app/domain/file_obj"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestRepository_MarkUploaded(t *testing.T) {
+	t.Parallel()
+
+	const (
+		q = `
+			UPDATE file_data
+			SET status = $2, etag = COALESCE($3, etag)
+			WHERE id = $1
+		`
+		opQ = `DELETE FROM file_operations WHERE operation = $1 AND file_id = $2`
+	)
+
+	t.Run("invalid id -> ErrInvalidFileID", func(t *testing.T) {
+		t.Parallel()
+
+		db, _, _ := sqlmock.New()
+		defer db.Close()
+
+		r := &Repository{db: db}
+		if err := r.MarkUploaded(context.Background(), 0, ""); !errors.Is(err, domain.ErrInvalidFileID) {
+			t.Fatalf("expected ErrInvalidFileID, got: %v", err)
+		}
+	})
+
+	t.Run("affected=0 -> ErrFileNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		r := &Repository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlRe(q)).
+			WithArgs(int64(5), domain.StatusReady, "etag").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := r.MarkUploaded(context.Background(), 5, "etag")
+		if !errors.Is(err, domain.ErrFileNotFound) {
+			t.Fatalf("expected ErrFileNotFound, got: %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("expectations: %v", err)
+		}
+	})
+
+	t.Run("ok -> marks ready and drops upload operation", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		r := &Repository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlRe(q)).
+			WithArgs(int64(5), domain.StatusReady, "etag").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlRe(opQ)).
+			WithArgs(domain.OperationUpload, int64(5)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := r.MarkUploaded(context.Background(), 5, "etag"); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("expectations: %v", err)
+		}
+	})
+}
+
+func TestRepository_AbortUpload(t *testing.T) {
+	t.Parallel()
+
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	r := &Repository{db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(sqlRe(`DELETE FROM file_data WHERE id = $1 AND status = $2`)).
+		WithArgs(int64(5), domain.StatusPending).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(sqlRe(`DELETE FROM file_operations WHERE operation = $1 AND file_id = $2`)).
+		WithArgs(domain.OperationUpload, int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := r.AbortUpload(context.Background(), 5); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestRepository_ClaimOperations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zero limit -> nothing claimed", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		r := &Repository{db: db}
+		ops, err := r.ClaimOperations(context.Background(), time.Now(), time.Minute, 0)
+		if err != nil || ops != nil {
+			t.Fatalf("expected nil, nil; got %v, %v", ops, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("expectations: %v", err)
+		}
+	})
+
+	t.Run("ok -> returns claimed operations", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		r := &Repository{db: db}
+		now := time.Now()
+
+		rows := sqlmock.NewRows([]string{
+			"id", "operation", "file_id",
+			"bucket_name", "object_key", "size_bytes",
+			"attempts", "last_error", "created_at", "next_attempt_at",
+		}).
+			AddRow(int64(1), "upload", int64(5), "b", "k1", int64(10), 1, nil, now, now).
+			AddRow(int64(2), "delete", nil, "b", "k2", int64(0), 3, "timeout", now, now)
+
+		mock.ExpectQuery(`UPDATE\s+file_operations\s+SET\s+attempts = attempts \+ 1`).
+			WithArgs(now, 10, int64(60000)).
+			WillReturnRows(rows)
+
+		ops, err := r.ClaimOperations(context.Background(), now, time.Minute, 10)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if len(ops) != 2 {
+			t.Fatalf("expected 2 operations, got %d", len(ops))
+		}
+		if ops[0].Type != domain.OperationUpload || ops[0].FileID != 5 || ops[0].Storage.ObjectKey != "k1" {
+			t.Fatalf("unexpected first op: %+v", ops[0])
+		}
+		if ops[1].FileID != 0 || ops[1].LastError != "timeout" || ops[1].Attempts != 3 {
+			t.Fatalf("unexpected second op: %+v", ops[1])
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("expectations: %v", err)
+		}
+	})
+}
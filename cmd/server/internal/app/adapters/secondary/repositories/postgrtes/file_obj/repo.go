@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"server/internal/pkg/logger"
+	postgres "server/internal/pkg/postgres"
+	"time"
 
 	domain "server/internal/app/domain/file_obj"
 
@@ -13,6 +15,8 @@ import (
 	"go.uber.org/zap"
 )
 
+// Create stores file metadata as pending together with an upload operation.
+// The file becomes visible after MarkUploaded.
 func (r *Repository) Create(ctx context.Context, f *domain.File) (int64, error) {
 	if f == nil {
 		return 0, fmt.Errorf("file is nil")
@@ -22,29 +26,54 @@ func (r *Repository) Create(ctx context.Context, f *domain.File) (int64, error)
 		INSERT INTO file_data (
 			user_id, title,
 			bucket_name, object_key,
-			size_bytes, content_type, etag
+			size_bytes, content_type, etag,
+			status, expires_at
 		)
-		VALUES ($1,$2,$3,$4,$5,$6,$7)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
 		RETURNING id, created_at
 	`
 
+	opQuery := `
+		INSERT INTO file_operations (operation, file_id, bucket_name, object_key, size_bytes)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
 	var (
 		id        int64
 		createdAt = f.CreatedAt
 	)
 
-	err := r.db.QueryRowContext(ctx, query,
-		f.UserID,
-		nullIfEmpty(f.Title),
-		f.Storage.BucketName,
-		f.Storage.ObjectKey,
-		f.SizeBytes,
-		nullIfEmpty(f.ContentType),
-		nullIfEmpty(f.ETag),
-	).Scan(&id, &createdAt)
+	err := postgres.WithTx(ctx, r.db, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, query,
+			f.UserID,
+			nullIfEmpty(f.Title),
+			f.Storage.BucketName,
+			f.Storage.ObjectKey,
+			f.SizeBytes,
+			nullIfEmpty(f.ContentType),
+			nullIfEmpty(f.ETag),
+			domain.StatusPending,
+			nullIfZero(f.ExpiresAt),
+		).Scan(&id, &createdAt)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, opQuery,
+			domain.OperationUpload,
+			id,
+			f.Storage.BucketName,
+			f.Storage.ObjectKey,
+			f.SizeBytes,
+		)
+		if err != nil {
+			return fmt.Errorf("insert file_operations: %w", err)
+		}
+		return nil
+	})
 
 	if err != nil {
-		if isUniqueViolation(err, "uq_file_object") {
+		if postgres.IsUniqueViolation(err, "uq_file_object") {
 			return 0, fmt.Errorf(
 				"file already exists in storage (bucket=%s key=%s): %w",
 				f.Storage.BucketName, f.Storage.ObjectKey, err,
@@ -57,6 +86,7 @@ func (r *Repository) Create(ctx context.Context, f *domain.File) (int64, error)
 	}
 
 	f.ID = id
+	f.Status = domain.StatusPending
 	f.CreatedAt = createdAt
 
 	return f.ID, nil
@@ -72,9 +102,9 @@ func (r *Repository) GetByID(ctx context.Context, id int64) (*domain.File, error
 			id, user_id, title,
 			bucket_name, object_key,
 			size_bytes, content_type, etag,
-			created_at
+			status, created_at, expires_at
 		FROM file_data
-		WHERE id = $1
+		WHERE id = $1 AND status = 'ready'
 	`
 
 	row := r.db.QueryRowContext(ctx, q, id)
@@ -100,9 +130,9 @@ func (r *Repository) ListByUserID(ctx context.Context, userID int64) ([]*domain.
 			id, user_id, title,
 			bucket_name, object_key,
 			size_bytes, content_type, etag,
-			created_at
+			status, created_at, expires_at
 		FROM file_data
-		WHERE user_id = $1
+		WHERE user_id = $1 AND status = 'ready'
 		ORDER BY created_at DESC, id DESC
 	`
 
@@ -132,28 +162,78 @@ func (r *Repository) ListByUserID(ctx context.Context, userID int64) ([]*domain.
 	return out, nil
 }
 
-func (r *Repository) Delete(ctx context.Context, id int64) error {
-	if id <= 0 {
-		return domain.ErrInvalidFileID
+func (r *Repository) ListByBucket(ctx context.Context, bucket string) ([]*domain.File, error) {
+	if bucket == "" {
+		return nil, domain.ErrEmptyBucketName
 	}
 
-	query := `DELETE FROM file_data WHERE id = $1`
+	query := `
+		SELECT
+			id, user_id, title,
+			bucket_name, object_key,
+			size_bytes, content_type, etag,
+			status, created_at, expires_at
+		FROM file_data
+		WHERE bucket_name = $1
+		ORDER BY id
+	`
 
-	res, err := r.db.ExecContext(ctx, query, int64(id))
+	rows, err := r.db.QueryContext(ctx, query, bucket)
 	if err != nil {
-		return fmt.Errorf("delete file_data id=%d: %w", id, err)
+		return nil, fmt.Errorf("list file_data by bucket=%s: %w", bucket, err)
 	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
 
-	affected, err := res.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("delete file_data id=%d: rows affected: %w", id, err)
+	var out []*domain.File
+	for rows.Next() {
+		f, err := scanFile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan file_data row: %w", err)
+		}
+		out = append(out, f)
 	}
 
-	if affected == 0 {
-		return domain.ErrFileNotFound
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
 	}
 
-	return nil
+	return out, nil
+}
+
+// Delete removes file metadata and records a delete operation for its object in one transaction
+func (r *Repository) Delete(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domain.ErrInvalidFileID
+	}
+
+	query := `DELETE FROM file_data WHERE id = $1 RETURNING bucket_name, object_key`
+
+	opQuery := `
+		INSERT INTO file_operations (operation, file_id, bucket_name, object_key)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (operation, bucket_name, object_key) DO NOTHING
+	`
+
+	return postgres.WithTx(ctx, r.db, func(tx *sql.Tx) error {
+		var bucket, key string
+
+		err := tx.QueryRowContext(ctx, query, id).Scan(&bucket, &key)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.ErrFileNotFound
+			}
+			return fmt.Errorf("delete file_data id=%d: %w", id, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, opQuery, domain.OperationDelete, id, bucket, key); err != nil {
+			return fmt.Errorf("delete file_data id=%d: insert file_operations: %w", id, err)
+		}
+		return nil
+	})
 }
 
 // help func
@@ -172,14 +252,16 @@ func scanFile(s scanner) (*domain.File, error) {
 		sizeBytes  int64
 		ct         sql.NullString
 		etag       sql.NullString
+		status     string
 		createdAt  sql.NullTime
+		expiresAt  sql.NullTime
 	)
 
 	err := s.Scan(
 		&id, &userID, &title,
 		&bucketName, &objectKey,
 		&sizeBytes, &ct, &etag,
-		&createdAt,
+		&status, &createdAt, &expiresAt,
 	)
 	if err != nil {
 		return nil, err
@@ -198,11 +280,15 @@ func scanFile(s scanner) (*domain.File, error) {
 		SizeBytes:   sizeBytes,
 		ContentType: nullStringToString(ct),
 		ETag:        nullStringToString(etag),
+		Status:      status,
 	}
 
 	if createdAt.Valid {
 		f.CreatedAt = createdAt.Time
 	}
+	if expiresAt.Valid {
+		f.ExpiresAt = expiresAt.Time
+	}
 
 	return f, nil
 }
@@ -214,6 +300,13 @@ func nullIfEmpty(s string) any {
 	return s
 }
 
+func nullIfZero(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
 func nullStringToString(ns sql.NullString) string {
 	if !ns.Valid {
 		return ""
@@ -221,15 +314,6 @@ func nullStringToString(ns sql.NullString) string {
 	return ns.String
 }
 
-func isUniqueViolation(err error, constraint string) bool {
-	var pgErr *pgconn.PgError
-	if errors.As(err, &pgErr) {
-		// 23505 = unique_violation
-		return pgErr.Code == "23505" && pgErr.ConstraintName == constraint
-	}
-	return false
-}
-
 func isFKViolation(err error, constraint string) bool {
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
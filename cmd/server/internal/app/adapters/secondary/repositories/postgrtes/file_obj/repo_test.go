@@ -68,12 +68,19 @@ func TestRepository_Create(t *testing.T) {
 			INSERT INTO file_data (
 				user_id, title,
 				bucket_name, object_key,
-				size_bytes, content_type, etag
+				size_bytes, content_type, etag,
+				status, expires_at
 			)
-			VALUES ($1,$2,$3,$4,$5,$6,$7)
+			VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
 			RETURNING id, created_at
 		`
 
+		const opQ = `
+			INSERT INTO file_operations (operation, file_id, bucket_name, object_key, size_bytes)
+			VALUES ($1, $2, $3, $4, $5)
+		`
+
+		mock.ExpectBegin()
 		mock.ExpectQuery(sqlRe(q)).
 			WithArgs(
 				int64(7),
@@ -83,8 +90,14 @@ func TestRepository_Create(t *testing.T) {
 				int64(10),
 				"text/plain",
 				"etag",
+				domain.StatusPending,
+				nil,
 			).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(int64(123), now))
+		mock.ExpectExec(sqlRe(opQ)).
+			WithArgs(domain.OperationUpload, int64(123), "bucket", "key", int64(10)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
 
 		id, err := r.Create(context.Background(), f)
 		if err != nil {
@@ -99,6 +112,9 @@ func TestRepository_Create(t *testing.T) {
 		if f.CreatedAt.IsZero() {
 			t.Fatalf("expected CreatedAt to be set")
 		}
+		if f.Status != domain.StatusPending {
+			t.Fatalf("expected pending status, got %q", f.Status)
+		}
 
 		if err := mock.ExpectationsWereMet(); err != nil {
 			t.Fatalf("expectations: %v", err)
@@ -132,14 +148,17 @@ func TestRepository_Create(t *testing.T) {
 			INSERT INTO file_data (
 				user_id, title,
 				bucket_name, object_key,
-				size_bytes, content_type, etag
+				size_bytes, content_type, etag,
+				status, expires_at
 			)
-			VALUES ($1,$2,$3,$4,$5,$6,$7)
+			VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
 			RETURNING id, created_at
 		`
 
+		mock.ExpectBegin()
 		mock.ExpectQuery(sqlRe(q)).
 			WillReturnError(pgErr)
+		mock.ExpectRollback()
 
 		_, err = r.Create(context.Background(), f)
 		if err == nil {
@@ -184,14 +203,17 @@ func TestRepository_Create(t *testing.T) {
 			INSERT INTO file_data (
 				user_id, title,
 				bucket_name, object_key,
-				size_bytes, content_type, etag
+				size_bytes, content_type, etag,
+				status, expires_at
 			)
-			VALUES ($1,$2,$3,$4,$5,$6,$7)
+			VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
 			RETURNING id, created_at
 		`
 
+		mock.ExpectBegin()
 		mock.ExpectQuery(sqlRe(q)).
 			WillReturnError(pgErr)
+		mock.ExpectRollback()
 
 		_, err = r.Create(context.Background(), f)
 		if err == nil {
@@ -233,13 +255,16 @@ func TestRepository_Create(t *testing.T) {
 			INSERT INTO file_data (
 				user_id, title,
 				bucket_name, object_key,
-				size_bytes, content_type, etag
+				size_bytes, content_type, etag,
+				status, expires_at
 			)
-			VALUES ($1,$2,$3,$4,$5,$6,$7)
+			VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
 			RETURNING id, created_at
 		`
 
+		mock.ExpectBegin()
 		mock.ExpectQuery(sqlRe(q)).WillReturnError(dbErr)
+		mock.ExpectRollback()
 
 		_, err = r.Create(context.Background(), f)
 		if err == nil {
@@ -287,9 +312,9 @@ func TestRepository_GetByID(t *testing.T) {
 				id, user_id, title,
 				bucket_name, object_key,
 				size_bytes, content_type, etag,
-				created_at
+				status, created_at, expires_at
 			FROM file_data
-			WHERE id = $1
+			WHERE id = $1 AND status = 'ready'
 		`
 
 		mock.ExpectQuery(sqlRe(q)).
@@ -317,9 +342,9 @@ func TestRepository_GetByID(t *testing.T) {
 				id, user_id, title,
 				bucket_name, object_key,
 				size_bytes, content_type, etag,
-				created_at
+				status, created_at, expires_at
 			FROM file_data
-			WHERE id = $1
+			WHERE id = $1 AND status = 'ready'
 		`
 
 		mock.ExpectQuery(sqlRe(q)).
@@ -353,21 +378,21 @@ func TestRepository_GetByID(t *testing.T) {
 				id, user_id, title,
 				bucket_name, object_key,
 				size_bytes, content_type, etag,
-				created_at
+				status, created_at, expires_at
 			FROM file_data
-			WHERE id = $1
+			WHERE id = $1 AND status = 'ready'
 		`
 
 		rows := sqlmock.NewRows([]string{
 			"id", "user_id", "title",
 			"bucket_name", "object_key",
 			"size_bytes", "content_type", "etag",
-			"created_at",
+			"status", "created_at", "expires_at",
 		}).AddRow(
 			int64(1), int64(7), "title",
 			"b", "k",
 			int64(100), "text/plain", "etag",
-			now,
+			"ready", now, nil,
 		)
 
 		mock.ExpectQuery(sqlRe(q)).
@@ -417,9 +442,9 @@ func TestRepository_ListByUserID(t *testing.T) {
 				id, user_id, title,
 				bucket_name, object_key,
 				size_bytes, content_type, etag,
-				created_at
+				status, created_at, expires_at
 			FROM file_data
-			WHERE user_id = $1
+			WHERE user_id = $1 AND status = 'ready'
 			ORDER BY created_at DESC, id DESC
 		`
 
@@ -452,9 +477,9 @@ func TestRepository_ListByUserID(t *testing.T) {
 				id, user_id, title,
 				bucket_name, object_key,
 				size_bytes, content_type, etag,
-				created_at
+				status, created_at, expires_at
 			FROM file_data
-			WHERE user_id = $1
+			WHERE user_id = $1 AND status = 'ready'
 			ORDER BY created_at DESC, id DESC
 		`
 
@@ -487,9 +512,9 @@ func TestRepository_ListByUserID(t *testing.T) {
 				id, user_id, title,
 				bucket_name, object_key,
 				size_bytes, content_type, etag,
-				created_at
+				status, created_at, expires_at
 			FROM file_data
-			WHERE user_id = $1
+			WHERE user_id = $1 AND status = 'ready'
 			ORDER BY created_at DESC, id DESC
 		`
 
@@ -499,12 +524,12 @@ func TestRepository_ListByUserID(t *testing.T) {
 			"id", "user_id", "title",
 			"bucket_name", "object_key",
 			"size_bytes", "content_type", "etag",
-			"created_at",
+			"status", "created_at", "expires_at",
 		}).AddRow(
 			int64(1), int64(7), "t",
 			"b", "k",
 			int64(1), "ct", "etag",
-			time.Now(),
+			"ready", time.Now(), nil,
 		).RowError(0, rowErr)
 
 		mock.ExpectQuery(sqlRe(q)).
@@ -535,9 +560,9 @@ func TestRepository_ListByUserID(t *testing.T) {
 				id, user_id, title,
 				bucket_name, object_key,
 				size_bytes, content_type, etag,
-				created_at
+				status, created_at, expires_at
 			FROM file_data
-			WHERE user_id = $1
+			WHERE user_id = $1 AND status = 'ready'
 			ORDER BY created_at DESC, id DESC
 		`
 
@@ -545,10 +570,10 @@ func TestRepository_ListByUserID(t *testing.T) {
 			"id", "user_id", "title",
 			"bucket_name", "object_key",
 			"size_bytes", "content_type", "etag",
-			"created_at",
+			"status", "created_at", "expires_at",
 		}).
-			AddRow(int64(2), int64(7), "t2", "b", "k2", int64(2), "ct", "e2", now).
-			AddRow(int64(1), int64(7), "t1", "b", "k1", int64(1), "ct", "e1", now.Add(-time.Minute))
+			AddRow(int64(2), int64(7), "t2", "b", "k2", int64(2), "ct", "e2", "ready", now, nil).
+			AddRow(int64(1), int64(7), "t1", "b", "k1", int64(1), "ct", "e1", "ready", now.Add(-time.Minute), now.Add(time.Hour))
 
 		mock.ExpectQuery(sqlRe(q)).
 			WithArgs(int64(7)).
@@ -567,6 +592,72 @@ func TestRepository_ListByUserID(t *testing.T) {
 	})
 }
 
+func TestRepository_ListByBucket(t *testing.T) {
+	t.Parallel()
+
+	const q = `
+		SELECT
+			id, user_id, title,
+			bucket_name, object_key,
+			size_bytes, content_type, etag,
+			status, created_at, expires_at
+		FROM file_data
+		WHERE bucket_name = $1
+		ORDER BY id
+	`
+
+	t.Run("empty bucket -> ErrEmptyBucketName", func(t *testing.T) {
+		t.Parallel()
+
+		db, _, _ := sqlmock.New()
+		defer db.Close()
+
+		r := &Repository{db: db}
+		_, err := r.ListByBucket(context.Background(), "")
+		if !errors.Is(err, domain.ErrEmptyBucketName) {
+			t.Fatalf("expected ErrEmptyBucketName, got: %v", err)
+		}
+	})
+
+	t.Run("ok -> returns rows of the bucket", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		r := &Repository{db: db}
+		now := time.Now()
+
+		rows := sqlmock.NewRows([]string{
+			"id", "user_id", "title",
+			"bucket_name", "object_key",
+			"size_bytes", "content_type", "etag",
+			"status", "created_at", "expires_at",
+		}).
+			AddRow(int64(1), int64(7), "a", "user-files", "k1", int64(10), "text/plain", "e1", "ready", now, nil).
+			AddRow(int64(2), int64(8), nil, "user-files", "k2", int64(0), nil, nil, "pending", now, nil)
+
+		mock.ExpectQuery(sqlRe(q)).
+			WithArgs("user-files").
+			WillReturnRows(rows)
+
+		list, err := r.ListByBucket(context.Background(), "user-files")
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if len(list) != 2 {
+			t.Fatalf("expected 2 files, got %d", len(list))
+		}
+		if list[0].Storage.ObjectKey != "k1" || list[1].UserID != 8 {
+			t.Fatalf("unexpected files: %+v %+v", list[0], list[1])
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("sql expectations: %v", err)
+		}
+	})
+}
+
 func TestRepository_Delete(t *testing.T) {
 	t.Parallel()
 
@@ -583,7 +674,16 @@ func TestRepository_Delete(t *testing.T) {
 		}
 	})
 
-	t.Run("exec error -> wrapped", func(t *testing.T) {
+	const (
+		q   = `DELETE FROM file_data WHERE id = $1 RETURNING bucket_name, object_key`
+		opQ = `
+			INSERT INTO file_operations (operation, file_id, bucket_name, object_key)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (operation, bucket_name, object_key) DO NOTHING
+		`
+	)
+
+	t.Run("query error -> wrapped", func(t *testing.T) {
 		t.Parallel()
 
 		db, mock, _ := sqlmock.New()
@@ -592,9 +692,11 @@ func TestRepository_Delete(t *testing.T) {
 		r := &Repository{db: db}
 		dbErr := errors.New("db down")
 
-		mock.ExpectExec(sqlRe(`DELETE FROM file_data WHERE id = $1`)).
+		mock.ExpectBegin()
+		mock.ExpectQuery(sqlRe(q)).
 			WithArgs(int64(10)).
 			WillReturnError(dbErr)
+		mock.ExpectRollback()
 
 		err := r.Delete(context.Background(), 10)
 		if err == nil {
@@ -606,9 +708,12 @@ func TestRepository_Delete(t *testing.T) {
 		if !strings.Contains(err.Error(), "delete file_data id=10") {
 			t.Fatalf("expected context, got: %v", err)
 		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("expectations: %v", err)
+		}
 	})
 
-	t.Run("rows affected error -> wrapped", func(t *testing.T) {
+	t.Run("no rows -> ErrFileNotFound", func(t *testing.T) {
 		t.Parallel()
 
 		db, mock, _ := sqlmock.New()
@@ -616,39 +721,49 @@ func TestRepository_Delete(t *testing.T) {
 
 		r := &Repository{db: db}
 
-		// sqlmock умеет имитировать ошибку RowsAffected через ResultError
-		mock.ExpectExec(sqlRe(`DELETE FROM file_data WHERE id = $1`)).
+		mock.ExpectBegin()
+		mock.ExpectQuery(sqlRe(q)).
 			WithArgs(int64(10)).
-			WillReturnResult(sqlmock.NewErrorResult(errors.New("rows affected fail")))
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
 
 		err := r.Delete(context.Background(), 10)
-		if err == nil {
-			t.Fatalf("expected error")
-		}
-		if !strings.Contains(err.Error(), "rows affected") {
-			t.Fatalf("expected rows affected context, got: %v", err)
+		if !errors.Is(err, domain.ErrFileNotFound) {
+			t.Fatalf("expected ErrFileNotFound, got: %v", err)
 		}
 	})
 
-	t.Run("affected=0 -> ErrFileNotFound", func(t *testing.T) {
+	t.Run("operation insert error -> rollback", func(t *testing.T) {
 		t.Parallel()
 
 		db, mock, _ := sqlmock.New()
 		defer db.Close()
 
 		r := &Repository{db: db}
+		dbErr := errors.New("insert fail")
 
-		mock.ExpectExec(sqlRe(`DELETE FROM file_data WHERE id = $1`)).
+		mock.ExpectBegin()
+		mock.ExpectQuery(sqlRe(q)).
 			WithArgs(int64(10)).
-			WillReturnResult(sqlmock.NewResult(0, 0))
+			WillReturnRows(sqlmock.NewRows([]string{"bucket_name", "object_key"}).AddRow("b", "k"))
+		mock.ExpectExec(sqlRe(opQ)).
+			WithArgs(domain.OperationDelete, int64(10), "b", "k").
+			WillReturnError(dbErr)
+		mock.ExpectRollback()
 
 		err := r.Delete(context.Background(), 10)
-		if !errors.Is(err, domain.ErrFileNotFound) {
-			t.Fatalf("expected ErrFileNotFound, got: %v", err)
+		if !errors.Is(err, dbErr) {
+			t.Fatalf("expected wrapped dbErr, got: %v", err)
+		}
+		if !strings.Contains(err.Error(), "insert file_operations") {
+			t.Fatalf("expected context, got: %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("expectations: %v", err)
 		}
 	})
 
-	t.Run("ok -> nil", func(t *testing.T) {
+	t.Run("ok -> deletes row and enqueues delete operation", func(t *testing.T) {
 		t.Parallel()
 
 		db, mock, _ := sqlmock.New()
@@ -656,14 +771,22 @@ func TestRepository_Delete(t *testing.T) {
 
 		r := &Repository{db: db}
 
-		mock.ExpectExec(sqlRe(`DELETE FROM file_data WHERE id = $1`)).
+		mock.ExpectBegin()
+		mock.ExpectQuery(sqlRe(q)).
 			WithArgs(int64(10)).
+			WillReturnRows(sqlmock.NewRows([]string{"bucket_name", "object_key"}).AddRow("b", "k"))
+		mock.ExpectExec(sqlRe(opQ)).
+			WithArgs(domain.OperationDelete, int64(10), "b", "k").
 			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
 
 		err := r.Delete(context.Background(), 10)
 		if err != nil {
 			t.Fatalf("expected nil, got: %v", err)
 		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("expectations: %v", err)
+		}
 	})
 }
 
@@ -0,0 +1,260 @@
+package folder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"server/internal/pkg/logger"
+	postgres "server/internal/pkg/postgres"
+
+	domain "server/internal/app/domain/folder"
+
+	"go.uber.org/zap"
+)
+
+// listItemsQuery collects objects of every type placed in a folder, $2 is NULL for the root.
+// Organization items are listed to current members only, one filed into a folder of another member
+// is shown in the root.
+const listItemsQuery = `
+	SELECT type, id, title FROM (
+		SELECT 'account' AS type, id, COALESCE(service_name, '') AS title FROM account_data
+		WHERE (user_id = $1 AND org_id IS NULL OR org_id IN (SELECT org_id FROM org_members WHERE user_id = $1))
+			AND (CASE WHEN folder_id IN (SELECT id FROM folders WHERE user_id = $1) THEN folder_id END) IS NOT DISTINCT FROM $2::BIGINT
+		UNION ALL
+		SELECT 'card', id, COALESCE(bank_name, '') FROM bank_data
+		WHERE (user_id = $1 AND org_id IS NULL OR org_id IN (SELECT org_id FROM org_members WHERE user_id = $1))
+			AND (CASE WHEN folder_id IN (SELECT id FROM folders WHERE user_id = $1) THEN folder_id END) IS NOT DISTINCT FROM $2::BIGINT
+		UNION ALL
+		SELECT 'text', id, COALESCE(title, '') FROM text_data
+		WHERE (user_id = $1 AND org_id IS NULL OR org_id IN (SELECT org_id FROM org_members WHERE user_id = $1))
+			AND (CASE WHEN folder_id IN (SELECT id FROM folders WHERE user_id = $1) THEN folder_id END) IS NOT DISTINCT FROM $2::BIGINT
+		UNION ALL
+		SELECT 'file', id, COALESCE(title, '') FROM file_data WHERE user_id = $1 AND folder_id IS NOT DISTINCT FROM $2::BIGINT AND status = 'ready'
+		UNION ALL
+		SELECT 'ssh', id, title FROM ssh_key_data WHERE user_id = $1 AND folder_id IS NOT DISTINCT FROM $2::BIGINT
+		UNION ALL
+		SELECT 'cert', id, title FROM cert_data WHERE user_id = $1 AND folder_id IS NOT DISTINCT FROM $2::BIGINT
+	) items
+	ORDER BY type, lower(title), id`
+
+func (r *Repository) GetByUserID(ctx context.Context, userID int64) ([]*domain.Folder, error) {
+	query := `
+		SELECT id, user_id, parent_id, name, created_at
+		FROM folders
+		WHERE user_id = $1
+		ORDER BY lower(name), id`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
+
+	var out []*domain.Folder
+	for rows.Next() {
+		f, err := scanFolder(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, f)
+	}
+
+	return out, rows.Err()
+}
+
+func (r *Repository) GetByID(ctx context.Context, folderID int64) (*domain.Folder, error) {
+	query := `
+		SELECT id, user_id, parent_id, name, created_at
+		FROM folders
+		WHERE id = $1`
+
+	f, err := scanFolder(r.db.QueryRowContext(ctx, query, folderID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrFolderNotFound
+		}
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (r *Repository) Create(ctx context.Context, f *domain.Folder) (int64, error) {
+	query := `
+		INSERT INTO folders (user_id, parent_id, name)
+		VALUES ($1, $2, $3)
+		RETURNING id`
+
+	var id int64
+	if err := r.db.QueryRowContext(ctx, query, f.UserID, nullID(f.ParentID), f.Name).Scan(&id); err != nil {
+		if postgres.IsUniqueViolation(err, "uq_folders_name") {
+			return 0, domain.ErrFolderExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *Repository) Update(ctx context.Context, f *domain.Folder) error {
+	query := `UPDATE folders SET parent_id = $2, name = $3 WHERE id = $1`
+
+	res, err := r.db.ExecContext(ctx, query, f.ID, nullID(f.ParentID), f.Name)
+	if err != nil {
+		if postgres.IsUniqueViolation(err, "uq_folders_name") {
+			return domain.ErrFolderExists
+		}
+		return err
+	}
+
+	return expectOne(res, domain.ErrFolderNotFound)
+}
+
+// Delete relies on the foreign keys, subfolders are removed and objects fall back to the root
+func (r *Repository) Delete(ctx context.Context, folderID int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM folders WHERE id = $1`, folderID)
+	if err != nil {
+		return err
+	}
+
+	return expectOne(res, domain.ErrFolderNotFound)
+}
+
+func (r *Repository) ListItems(ctx context.Context, userID int64, folderID *int64) ([]domain.Item, error) {
+	rows, err := r.db.QueryContext(ctx, listItemsQuery, userID, nullID(folderID))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
+
+	var out []domain.Item
+	for rows.Next() {
+		var it domain.Item
+		if err := rows.Scan(&it.Type, &it.ID, &it.Title); err != nil {
+			return nil, err
+		}
+		out = append(out, it)
+	}
+
+	return out, rows.Err()
+}
+
+func (r *Repository) ItemOwner(ctx context.Context, itemType string, itemID int64) (domain.Owner, error) {
+	table, err := itemTable(itemType)
+	if err != nil {
+		return domain.Owner{}, err
+	}
+
+	orgColumn := "0"
+	if hasOrg(table) {
+		orgColumn = "COALESCE(org_id, 0)"
+	}
+
+	query := fmt.Sprintf(`SELECT user_id, %s FROM %s WHERE id = $1`, orgColumn, table)
+
+	var owner domain.Owner
+	if err := r.db.QueryRowContext(ctx, query, itemID).Scan(&owner.UserID, &owner.OrgID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Owner{}, domain.ErrItemNotFound
+		}
+		return domain.Owner{}, err
+	}
+
+	return owner, nil
+}
+
+func (r *Repository) MoveItem(ctx context.Context, itemType string, itemID int64, folderID *int64) error {
+	table, err := itemTable(itemType)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET folder_id = $2 WHERE id = $1`, table)
+
+	res, err := r.db.ExecContext(ctx, query, itemID, nullID(folderID))
+	if err != nil {
+		return err
+	}
+
+	return expectOne(res, domain.ErrItemNotFound)
+}
+
+// help func
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanFolder(row rowScanner) (*domain.Folder, error) {
+	var (
+		f        domain.Folder
+		parentID sql.NullInt64
+	)
+
+	if err := row.Scan(&f.ID, &f.UserID, &parentID, &f.Name, &f.CreatedAt); err != nil {
+		return nil, err
+	}
+	if parentID.Valid {
+		f.ParentID = &parentID.Int64
+	}
+
+	return &f, nil
+}
+
+// itemTable maps an object type to its table
+func itemTable(itemType string) (string, error) {
+	switch itemType {
+	case domain.ItemAccount:
+		return "account_data", nil
+	case domain.ItemBankCard:
+		return "bank_data", nil
+	case domain.ItemText:
+		return "text_data", nil
+	case domain.ItemFile:
+		return "file_data", nil
+	case domain.ItemSSHKey:
+		return "ssh_key_data", nil
+	case domain.ItemCert:
+		return "cert_data", nil
+	default:
+		return "", domain.ErrInvalidItem
+	}
+}
+
+// hasOrg reports whether objects of the table can belong to an organization
+func hasOrg(table string) bool {
+	switch table {
+	case "account_data", "bank_data", "text_data":
+		return true
+	default:
+		return false
+	}
+}
+
+func nullID(id *int64) sql.NullInt64 {
+	if id == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *id, Valid: true}
+}
+
+func expectOne(res sql.Result, notFound error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return notFound
+	}
+
+	return nil
+}
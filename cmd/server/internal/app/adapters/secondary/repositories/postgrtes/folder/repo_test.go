@@ -0,0 +1,293 @@
+package folder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/app/config"
+	domain "server/internal/app/domain/folder"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func init() {
+	config.InitTestConfig()
+}
+
+func newRepo(t *testing.T) (*Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return &Repository{db: db}, mock
+}
+
+func TestRepository_GetByID(t *testing.T) {
+	t.Parallel()
+
+	const q = `
+		SELECT id, user_id, parent_id, name, created_at
+		FROM folders
+		WHERE id = $1`
+
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("nested folder", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepo(t)
+		mock.ExpectQuery(sqlRe(q)).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "parent_id", "name", "created_at"}).
+				AddRow(int64(2), int64(7), int64(1), "servers", createdAt))
+
+		f, err := repo.GetByID(context.Background(), 2)
+		if err != nil {
+			t.Fatalf("GetByID error: %v", err)
+		}
+		if f.ParentID == nil || *f.ParentID != 1 || f.Name != "servers" || !f.CreatedAt.Equal(createdAt) {
+			t.Fatalf("unexpected folder: %+v", f)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("sql expectations: %v", err)
+		}
+	})
+
+	t.Run("root folder -> nil parent", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepo(t)
+		mock.ExpectQuery(sqlRe(q)).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "parent_id", "name", "created_at"}).
+				AddRow(int64(1), int64(7), nil, "work", createdAt))
+
+		f, err := repo.GetByID(context.Background(), 1)
+		if err != nil || f.ParentID != nil {
+			t.Fatalf("unexpected result: %+v, %v", f, err)
+		}
+	})
+
+	t.Run("no rows -> ErrFolderNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepo(t)
+		mock.ExpectQuery(sqlRe(q)).WithArgs(int64(5)).WillReturnError(sql.ErrNoRows)
+
+		if _, err := repo.GetByID(context.Background(), 5); !errors.Is(err, domain.ErrFolderNotFound) {
+			t.Fatalf("expected ErrFolderNotFound, got: %v", err)
+		}
+	})
+}
+
+func TestRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	const q = `
+		INSERT INTO folders (user_id, parent_id, name)
+		VALUES ($1, $2, $3)
+		RETURNING id`
+
+	t.Run("root folder -> NULL parent", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepo(t)
+		mock.ExpectQuery(sqlRe(q)).
+			WithArgs(int64(7), nil, "work").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(4)))
+
+		id, err := repo.Create(context.Background(), &domain.Folder{UserID: 7, Name: "work"})
+		if err != nil || id != 4 {
+			t.Fatalf("unexpected result: %d, %v", id, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("sql expectations: %v", err)
+		}
+	})
+
+	t.Run("sibling with same name -> ErrFolderExists", func(t *testing.T) {
+		t.Parallel()
+
+		parent := int64(1)
+		repo, mock := newRepo(t)
+		mock.ExpectQuery(sqlRe(q)).
+			WithArgs(int64(7), int64(1), "servers").
+			WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "uq_folders_name"})
+
+		_, err := repo.Create(context.Background(), &domain.Folder{UserID: 7, ParentID: &parent, Name: "servers"})
+		if !errors.Is(err, domain.ErrFolderExists) {
+			t.Fatalf("expected ErrFolderExists, got: %v", err)
+		}
+	})
+}
+
+func TestRepository_Update(t *testing.T) {
+	t.Parallel()
+
+	const q = `UPDATE folders SET parent_id = $2, name = $3 WHERE id = $1`
+
+	repo, mock := newRepo(t)
+	mock.ExpectExec(sqlRe(q)).
+		WithArgs(int64(3), nil, "prod").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.Update(context.Background(), &domain.Folder{ID: 3, Name: "prod"}); !errors.Is(err, domain.ErrFolderNotFound) {
+		t.Fatalf("expected ErrFolderNotFound, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_ListItems(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		folderID *int64
+		arg      any
+	}{
+		{name: "root", folderID: nil, arg: nil},
+		{name: "folder", folderID: func() *int64 { v := int64(3); return &v }(), arg: int64(3)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo, mock := newRepo(t)
+			mock.ExpectQuery(sqlRe(listItemsQuery)).
+				WithArgs(int64(7), tc.arg).
+				WillReturnRows(sqlmock.NewRows([]string{"type", "id", "title"}).
+					AddRow("account", int64(1), "github").
+					AddRow("file", int64(5), "scan.pdf"))
+
+			items, err := repo.ListItems(context.Background(), 7, tc.folderID)
+			if err != nil {
+				t.Fatalf("ListItems error: %v", err)
+			}
+			if len(items) != 2 || items[1] != (domain.Item{Type: domain.ItemFile, ID: 5, Title: "scan.pdf"}) {
+				t.Fatalf("unexpected items: %+v", items)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("sql expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestRepository_MoveItem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		itemType string
+		query    string
+	}{
+		{itemType: domain.ItemAccount, query: `UPDATE account_data SET folder_id = $2 WHERE id = $1`},
+		{itemType: domain.ItemBankCard, query: `UPDATE bank_data SET folder_id = $2 WHERE id = $1`},
+		{itemType: domain.ItemText, query: `UPDATE text_data SET folder_id = $2 WHERE id = $1`},
+		{itemType: domain.ItemFile, query: `UPDATE file_data SET folder_id = $2 WHERE id = $1`},
+		{itemType: domain.ItemSSHKey, query: `UPDATE ssh_key_data SET folder_id = $2 WHERE id = $1`},
+		{itemType: domain.ItemCert, query: `UPDATE cert_data SET folder_id = $2 WHERE id = $1`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.itemType, func(t *testing.T) {
+			t.Parallel()
+
+			folderID := int64(3)
+			repo, mock := newRepo(t)
+			mock.ExpectExec(sqlRe(tc.query)).
+				WithArgs(int64(9), int64(3)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			if err := repo.MoveItem(context.Background(), tc.itemType, 9, &folderID); err != nil {
+				t.Fatalf("MoveItem error: %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("sql expectations: %v", err)
+			}
+		})
+	}
+
+	t.Run("unknown type -> ErrInvalidItem", func(t *testing.T) {
+		t.Parallel()
+
+		repo := &Repository{}
+		if err := repo.MoveItem(context.Background(), "users", 1, nil); !errors.Is(err, domain.ErrInvalidItem) {
+			t.Fatalf("expected ErrInvalidItem, got: %v", err)
+		}
+	})
+}
+
+func TestRepository_ItemOwner(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		itemType string
+		query    string
+		orgID    int64
+	}{
+		{itemType: domain.ItemAccount, query: `SELECT user_id, COALESCE(org_id, 0) FROM account_data WHERE id = $1`, orgID: 3},
+		{itemType: domain.ItemFile, query: `SELECT user_id, 0 FROM file_data WHERE id = $1`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.itemType, func(t *testing.T) {
+			t.Parallel()
+
+			repo, mock := newRepo(t)
+			mock.ExpectQuery(sqlRe(tc.query)).
+				WithArgs(int64(9)).
+				WillReturnRows(sqlmock.NewRows([]string{"user_id", "org_id"}).AddRow(int64(7), tc.orgID))
+
+			owner, err := repo.ItemOwner(context.Background(), tc.itemType, 9)
+			if err != nil {
+				t.Fatalf("ItemOwner error: %v", err)
+			}
+			if owner != (domain.Owner{UserID: 7, OrgID: tc.orgID}) {
+				t.Fatalf("unexpected owner: %+v", owner)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("sql expectations: %v", err)
+			}
+		})
+	}
+
+	t.Run("missing -> ErrItemNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepo(t)
+		mock.ExpectQuery(sqlRe(`SELECT user_id, 0 FROM cert_data WHERE id = $1`)).
+			WithArgs(int64(9)).
+			WillReturnError(sql.ErrNoRows)
+
+		if _, err := repo.ItemOwner(context.Background(), domain.ItemCert, 9); !errors.Is(err, domain.ErrItemNotFound) {
+			t.Fatalf("expected ErrItemNotFound, got: %v", err)
+		}
+	})
+}
+
+func sqlRe(q string) string {
+	s := strings.TrimSpace(q)
+	s = strings.Join(strings.Fields(s), " ")
+	s = regexp.QuoteMeta(s)
+	s = strings.ReplaceAll(s, `\ `, `\s+`)
+	return s
+}
@@ -0,0 +1,34 @@
+package notification
+
+import (
+	"database/sql"
+	domain "server/internal/app/domain/notification"
+)
+
+type Notification struct {
+	ID         sql.NullInt64
+	UserID     sql.NullInt64
+	ObjectType sql.NullString
+	ObjectID   sql.NullInt64
+	Title      sql.NullString
+	ExpiresAt  sql.NullTime
+	CreatedAt  sql.NullTime
+	ReadAt     sql.NullTime
+}
+
+func (n *Notification) ToDomain() *domain.Notification {
+	return &domain.Notification{
+		ID:         n.ID.Int64,
+		UserID:     n.UserID.Int64,
+		ObjectType: n.ObjectType.String,
+		ObjectID:   n.ObjectID.Int64,
+		Title:      n.Title.String,
+		ExpiresAt:  n.ExpiresAt.Time,
+		CreatedAt:  n.CreatedAt.Time,
+		ReadAt:     n.ReadAt.Time,
+	}
+}
+
+func (n *Notification) scanFields() []any {
+	return []any{&n.ID, &n.UserID, &n.ObjectType, &n.ObjectID, &n.Title, &n.ExpiresAt, &n.CreatedAt, &n.ReadAt}
+}
@@ -0,0 +1,143 @@
+package notification
+
+import (
+	"context"
+	"server/internal/pkg/logger"
+	"time"
+
+	domain "server/internal/app/domain/notification"
+
+	"go.uber.org/zap"
+)
+
+// CreateReminders inserts one notification per expiring object, the unique key on
+// (object_type, object_id, expires_at) keeps repeated runs idempotent and
+// creates a new reminder when the expiry date of an object is moved
+func (r *Repository) CreateReminders(ctx context.Context, until time.Time) (int, error) {
+	query := `
+		INSERT INTO notifications (user_id, object_type, object_id, title, expires_at)
+		SELECT user_id, 'account', id, service_name, expires_at
+		FROM account_data
+		WHERE expires_at IS NOT NULL AND expires_at <= $1
+		UNION ALL
+		SELECT user_id, 'card', id, bank_name, expires_at
+		FROM bank_data
+		WHERE expires_at IS NOT NULL AND expires_at <= $1
+		UNION ALL
+		SELECT user_id, 'text', id, title, expires_at
+		FROM text_data
+		WHERE expires_at IS NOT NULL AND expires_at <= $1
+		UNION ALL
+		SELECT user_id, 'file', id, title, expires_at
+		FROM file_data
+		WHERE status = 'ready' AND expires_at IS NOT NULL AND expires_at <= $1
+		UNION ALL
+		SELECT user_id, 'cert', id, title, not_after
+		FROM cert_data
+		WHERE not_after <= $1
+		ON CONFLICT (object_type, object_id, expires_at) DO NOTHING`
+
+	res, err := r.db.ExecContext(ctx, query, until)
+	if err != nil {
+		return 0, err
+	}
+
+	created, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(created), nil
+}
+
+func (r *Repository) ListByUserID(ctx context.Context, userId int64, unreadOnly bool) ([]*domain.Notification, error) {
+	query := `
+		SELECT id, user_id, object_type, object_id, title, expires_at, created_at, read_at
+		FROM notifications
+		WHERE user_id = $1 AND ($2 = FALSE OR read_at IS NULL)
+		ORDER BY expires_at, id`
+
+	var list []*domain.Notification
+
+	rows, err := r.db.QueryContext(ctx, query, userId, unreadOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
+
+	for rows.Next() {
+		obj := new(Notification)
+
+		if err := rows.Scan(obj.scanFields()...); err != nil {
+			return nil, err
+		}
+
+		list = append(list, obj.ToDomain())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (r *Repository) CountUnread(ctx context.Context, userId int64) (int, error) {
+	query := `
+		SELECT count(*)
+		FROM notifications
+		WHERE user_id = $1 AND read_at IS NULL`
+
+	var count int
+
+	if err := r.db.QueryRowContext(ctx, query, userId).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// MarkRead keeps the first read time when the notification is already read
+func (r *Repository) MarkRead(ctx context.Context, userId, notificationId int64) error {
+	query := `
+		UPDATE notifications SET read_at = COALESCE(read_at, now())
+		WHERE id = $1 AND user_id = $2`
+
+	res, err := r.db.ExecContext(ctx, query, notificationId, userId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) MarkAllRead(ctx context.Context, userId int64) (int, error) {
+	query := `
+		UPDATE notifications SET read_at = now()
+		WHERE user_id = $1 AND read_at IS NULL`
+
+	res, err := r.db.ExecContext(ctx, query, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
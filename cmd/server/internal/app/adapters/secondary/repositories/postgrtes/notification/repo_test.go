@@ -0,0 +1,306 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	domain "server/internal/app/domain/notification"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const createRemindersQuery = `
+	INSERT INTO notifications (user_id, object_type, object_id, title, expires_at)
+	SELECT user_id, 'account', id, service_name, expires_at
+	FROM account_data
+	WHERE expires_at IS NOT NULL AND expires_at <= $1
+	UNION ALL
+	SELECT user_id, 'card', id, bank_name, expires_at
+	FROM bank_data
+	WHERE expires_at IS NOT NULL AND expires_at <= $1
+	UNION ALL
+	SELECT user_id, 'text', id, title, expires_at
+	FROM text_data
+	WHERE expires_at IS NOT NULL AND expires_at <= $1
+	UNION ALL
+	SELECT user_id, 'file', id, title, expires_at
+	FROM file_data
+	WHERE status = 'ready' AND expires_at IS NOT NULL AND expires_at <= $1
+	UNION ALL
+	SELECT user_id, 'cert', id, title, not_after
+	FROM cert_data
+	WHERE not_after <= $1
+	ON CONFLICT (object_type, object_id, expires_at) DO NOTHING`
+
+func TestRepository_CreateReminders(t *testing.T) {
+	t.Parallel()
+
+	until := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("ok -> returns created count", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New: %v", err)
+		}
+		defer db.Close()
+
+		repo := &Repository{db: db}
+
+		mock.ExpectExec(sqlRe(createRemindersQuery)).
+			WithArgs(until).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		created, err := repo.CreateReminders(context.Background(), until)
+		if err != nil {
+			t.Fatalf("CreateReminders error: %v", err)
+		}
+		if created != 3 {
+			t.Fatalf("expected 3, got %d", created)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("expectations: %v", err)
+		}
+	})
+
+	t.Run("exec error -> returned", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New: %v", err)
+		}
+		defer db.Close()
+
+		repo := &Repository{db: db}
+
+		dbErr := errors.New("db down")
+		mock.ExpectExec(sqlRe(createRemindersQuery)).
+			WithArgs(until).
+			WillReturnError(dbErr)
+
+		if _, err := repo.CreateReminders(context.Background(), until); !errors.Is(err, dbErr) {
+			t.Fatalf("expected dbErr, got: %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("expectations: %v", err)
+		}
+	})
+}
+
+func TestRepository_ListByUserID(t *testing.T) {
+	t.Parallel()
+
+	const q = `
+		SELECT id, user_id, object_type, object_id, title, expires_at, created_at, read_at
+		FROM notifications
+		WHERE user_id = $1 AND ($2 = FALSE OR read_at IS NULL)
+		ORDER BY expires_at, id`
+
+	t.Run("ok -> returns list", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New: %v", err)
+		}
+		defer db.Close()
+
+		repo := &Repository{db: db}
+
+		expiresAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		createdAt := time.Date(2026, 2, 22, 0, 0, 0, 0, time.UTC)
+
+		rows := sqlmock.NewRows([]string{"id", "user_id", "object_type", "object_id", "title", "expires_at", "created_at", "read_at"}).
+			AddRow(int64(1), int64(7), "card", int64(3), "maib", expiresAt, createdAt, nil).
+			AddRow(int64(2), int64(7), "text", int64(9), "api token", expiresAt, createdAt, createdAt)
+
+		mock.ExpectQuery(sqlRe(q)).
+			WithArgs(int64(7), false).
+			WillReturnRows(rows)
+
+		list, err := repo.ListByUserID(context.Background(), 7, false)
+		if err != nil {
+			t.Fatalf("ListByUserID error: %v", err)
+		}
+		if len(list) != 2 {
+			t.Fatalf("expected 2 items, got %d", len(list))
+		}
+		if list[0].ObjectType != domain.ObjectBankCard || list[0].ObjectID != 3 || list[0].Title != "maib" || list[0].Read() {
+			t.Fatalf("unexpected item[0]: %+v", list[0])
+		}
+		if !list[0].ExpiresAt.Equal(expiresAt) {
+			t.Fatalf("expected expires_at=%v, got %v", expiresAt, list[0].ExpiresAt)
+		}
+		if !list[1].Read() {
+			t.Fatalf("expected item[1] to be read: %+v", list[1])
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("expectations: %v", err)
+		}
+	})
+
+	t.Run("query error -> returned", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New: %v", err)
+		}
+		defer db.Close()
+
+		repo := &Repository{db: db}
+
+		dbErr := errors.New("db down")
+		mock.ExpectQuery(sqlRe(q)).
+			WithArgs(int64(7), true).
+			WillReturnError(dbErr)
+
+		if _, err := repo.ListByUserID(context.Background(), 7, true); !errors.Is(err, dbErr) {
+			t.Fatalf("expected dbErr, got: %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("expectations: %v", err)
+		}
+	})
+}
+
+func TestRepository_CountUnread(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db: db}
+
+	const q = `
+		SELECT count(*)
+		FROM notifications
+		WHERE user_id = $1 AND read_at IS NULL`
+
+	mock.ExpectQuery(sqlRe(q)).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+
+	count, err := repo.CountUnread(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("CountUnread error: %v", err)
+	}
+	if count != 5 {
+		t.Fatalf("expected 5, got %d", count)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestRepository_MarkRead(t *testing.T) {
+	t.Parallel()
+
+	const q = `
+		UPDATE notifications SET read_at = COALESCE(read_at, now())
+		WHERE id = $1 AND user_id = $2`
+
+	t.Run("ok -> nil", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New: %v", err)
+		}
+		defer db.Close()
+
+		repo := &Repository{db: db}
+
+		mock.ExpectExec(sqlRe(q)).
+			WithArgs(int64(5), int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		if err := repo.MarkRead(context.Background(), 7, 5); err != nil {
+			t.Fatalf("expected nil, got: %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("expectations: %v", err)
+		}
+	})
+
+	t.Run("foreign or missing notification -> ErrNotificationNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New: %v", err)
+		}
+		defer db.Close()
+
+		repo := &Repository{db: db}
+
+		mock.ExpectExec(sqlRe(q)).
+			WithArgs(int64(5), int64(8)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		if err := repo.MarkRead(context.Background(), 8, 5); !errors.Is(err, domain.ErrNotificationNotFound) {
+			t.Fatalf("expected ErrNotificationNotFound, got: %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("expectations: %v", err)
+		}
+	})
+}
+
+func TestRepository_MarkAllRead(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db: db}
+
+	const q = `
+		UPDATE notifications SET read_at = now()
+		WHERE user_id = $1 AND read_at IS NULL`
+
+	mock.ExpectExec(sqlRe(q)).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	count, err := repo.MarkAllRead(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("MarkAllRead error: %v", err)
+	}
+	if count != 4 {
+		t.Fatalf("expected 4, got %d", count)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func sqlRe(q string) string {
+	s := strings.TrimSpace(q)
+	s = strings.Join(strings.Fields(s), " ")
+
+	s = regexp.QuoteMeta(s)
+
+	s = strings.ReplaceAll(s, `\ `, `\s+`)
+	return s
+}
@@ -0,0 +1,225 @@
+package org
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"server/internal/pkg/logger"
+	postgres "server/internal/pkg/postgres"
+
+	domain "server/internal/app/domain/org"
+
+	"go.uber.org/zap"
+)
+
+// createQuery inserts the organization and its first owner in one statement
+const createQuery = `
+	WITH o AS (
+		INSERT INTO organizations (name) VALUES ($1)
+		RETURNING id, name, created_at
+	), m AS (
+		INSERT INTO org_members (org_id, user_id, role)
+		SELECT id, $2, 'owner' FROM o
+	)
+	SELECT id, name, created_at FROM o`
+
+// listItemsQuery resolves accounts, cards and texts of an organization to a common shape
+const listItemsQuery = `
+	SELECT 'account', id, service_name FROM account_data WHERE org_id = $1
+	UNION ALL
+	SELECT 'card', id, bank_name FROM bank_data WHERE org_id = $1
+	UNION ALL
+	SELECT 'text', id, title FROM text_data WHERE org_id = $1
+	ORDER BY 1, 3, 2`
+
+func (r *Repository) Create(ctx context.Context, name string, ownerID int64) (*domain.Org, error) {
+	org := &domain.Org{Role: domain.RoleOwner}
+	if err := r.db.QueryRowContext(ctx, createQuery, name, ownerID).Scan(&org.ID, &org.Name, &org.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+func (r *Repository) ListByUser(ctx context.Context, userID int64) ([]*domain.Org, error) {
+	query := `
+		SELECT o.id, o.name, m.role, o.created_at
+		FROM organizations o
+		JOIN org_members m ON m.org_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY lower(o.name), o.id`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
+
+	var list []*domain.Org
+	for rows.Next() {
+		org := new(domain.Org)
+		if err := rows.Scan(&org.ID, &org.Name, &org.Role, &org.CreatedAt); err != nil {
+			return nil, err
+		}
+		list = append(list, org)
+	}
+
+	return list, rows.Err()
+}
+
+// Delete removes the organization, memberships and org items go with it
+func (r *Repository) Delete(ctx context.Context, orgID int64) error {
+	query := `DELETE FROM organizations WHERE id = $1`
+
+	res, err := r.db.ExecContext(ctx, query, orgID)
+	if err != nil {
+		return err
+	}
+
+	return expectOne(res, domain.ErrOrgNotFound)
+}
+
+func (r *Repository) Role(ctx context.Context, orgID, userID int64) (string, error) {
+	query := `SELECT role FROM org_members WHERE org_id = $1 AND user_id = $2`
+
+	var role string
+	if err := r.db.QueryRowContext(ctx, query, orgID, userID).Scan(&role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrNotMember
+		}
+		return "", err
+	}
+
+	return role, nil
+}
+
+func (r *Repository) UserIDByName(ctx context.Context, username string) (int64, error) {
+	query := `SELECT id FROM users WHERE username = $1`
+
+	var id int64
+	if err := r.db.QueryRowContext(ctx, query, username).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrUserNotFound
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *Repository) Members(ctx context.Context, orgID int64) ([]*domain.Member, error) {
+	query := `
+		SELECT m.org_id, m.user_id, u.username, m.role, m.created_at
+		FROM org_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.org_id = $1
+		ORDER BY lower(u.username)`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
+
+	var list []*domain.Member
+	for rows.Next() {
+		m := new(domain.Member)
+		if err := rows.Scan(&m.OrgID, &m.UserID, &m.Username, &m.Role, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		list = append(list, m)
+	}
+
+	return list, rows.Err()
+}
+
+func (r *Repository) AddMember(ctx context.Context, orgID, userID int64, role string) error {
+	query := `INSERT INTO org_members (org_id, user_id, role) VALUES ($1, $2, $3)`
+
+	if _, err := r.db.ExecContext(ctx, query, orgID, userID, role); err != nil {
+		if postgres.IsUniqueViolation(err, "org_members_pkey") {
+			return domain.ErrAlreadyMember
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repository) SetRole(ctx context.Context, orgID, userID int64, role string) error {
+	query := `UPDATE org_members SET role = $1 WHERE org_id = $2 AND user_id = $3`
+
+	res, err := r.db.ExecContext(ctx, query, role, orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	return expectOne(res, domain.ErrNotMember)
+}
+
+func (r *Repository) RemoveMember(ctx context.Context, orgID, userID int64) error {
+	query := `DELETE FROM org_members WHERE org_id = $1 AND user_id = $2`
+
+	res, err := r.db.ExecContext(ctx, query, orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	return expectOne(res, domain.ErrNotMember)
+}
+
+func (r *Repository) CountOwners(ctx context.Context, orgID int64) (int, error) {
+	query := `SELECT count(*) FROM org_members WHERE org_id = $1 AND role = 'owner'`
+
+	var n int
+	if err := r.db.QueryRowContext(ctx, query, orgID).Scan(&n); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func (r *Repository) ListItems(ctx context.Context, orgID int64) ([]*domain.Item, error) {
+	rows, err := r.db.QueryContext(ctx, listItemsQuery, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
+
+	var list []*domain.Item
+	for rows.Next() {
+		it := new(domain.Item)
+		if err := rows.Scan(&it.Type, &it.ID, &it.Title); err != nil {
+			return nil, err
+		}
+		list = append(list, it)
+	}
+
+	return list, rows.Err()
+}
+
+// help func
+
+func expectOne(res sql.Result, notFound error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return notFound
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package org
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/app/config"
+	domain "server/internal/app/domain/org"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func init() {
+	config.InitTestConfig()
+}
+
+func TestRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db: db}
+
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	mock.ExpectQuery(sqlRe(createQuery)).
+		WithArgs("team", int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at"}).AddRow(int64(3), "team", createdAt))
+
+	org, err := repo.Create(context.Background(), "team", 7)
+	if err != nil {
+		t.Fatalf("Create error: %v", err)
+	}
+	if org.ID != 3 || org.Role != domain.RoleOwner || !org.CreatedAt.Equal(createdAt) {
+		t.Fatalf("unexpected org: %+v", org)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_Role(t *testing.T) {
+	t.Parallel()
+
+	const q = `SELECT role FROM org_members WHERE org_id = $1 AND user_id = $2`
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db: db}
+
+	mock.ExpectQuery(sqlRe(q)).
+		WithArgs(int64(1), int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow(domain.RoleEditor))
+	mock.ExpectQuery(sqlRe(q)).
+		WithArgs(int64(1), int64(8)).
+		WillReturnError(sql.ErrNoRows)
+
+	if role, err := repo.Role(context.Background(), 1, 7); err != nil || role != domain.RoleEditor {
+		t.Fatalf("unexpected result: %q, %v", role, err)
+	}
+	if _, err := repo.Role(context.Background(), 1, 8); !errors.Is(err, domain.ErrNotMember) {
+		t.Fatalf("expected ErrNotMember, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_AddMember(t *testing.T) {
+	t.Parallel()
+
+	const q = `INSERT INTO org_members (org_id, user_id, role) VALUES ($1, $2, $3)`
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db: db}
+
+	mock.ExpectExec(sqlRe(q)).
+		WithArgs(int64(1), int64(8), domain.RoleViewer).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(sqlRe(q)).
+		WithArgs(int64(1), int64(8), domain.RoleViewer).
+		WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "org_members_pkey"})
+
+	if err := repo.AddMember(context.Background(), 1, 8, domain.RoleViewer); err != nil {
+		t.Fatalf("AddMember error: %v", err)
+	}
+	if err := repo.AddMember(context.Background(), 1, 8, domain.RoleViewer); !errors.Is(err, domain.ErrAlreadyMember) {
+		t.Fatalf("expected ErrAlreadyMember, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_RemoveMember(t *testing.T) {
+	t.Parallel()
+
+	const q = `DELETE FROM org_members WHERE org_id = $1 AND user_id = $2`
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db: db}
+
+	mock.ExpectExec(sqlRe(q)).
+		WithArgs(int64(1), int64(9)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.RemoveMember(context.Background(), 1, 9); !errors.Is(err, domain.ErrNotMember) {
+		t.Fatalf("expected ErrNotMember, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_ListItems(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db: db}
+
+	mock.ExpectQuery(sqlRe(listItemsQuery)).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"type", "id", "title"}).
+			AddRow("account", int64(4), "github").
+			AddRow("text", int64(2), "wifi"))
+
+	list, err := repo.ListItems(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ListItems error: %v", err)
+	}
+	if len(list) != 2 || list[0].Type != domain.ItemAccount || list[1].Title != "wifi" {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func sqlRe(q string) string {
+	s := strings.TrimSpace(q)
+	s = strings.Join(strings.Fields(s), " ")
+	s = regexp.QuoteMeta(s)
+	s = strings.ReplaceAll(s, `\ `, `\s+`)
+	return s
+}
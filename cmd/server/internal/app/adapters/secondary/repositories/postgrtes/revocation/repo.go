@@ -0,0 +1,65 @@
+package revocation
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	domain "server/internal/app/domain/user"
+)
+
+func (r *Repository) Add(ctx context.Context, rev *domain.Revocation) (int64, error) {
+	var id int64
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO token_revocations (jti, user_id, session_id, issued_before, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`,
+		rev.JTI, rev.UserID, rev.SessionID, nullTime(rev.IssuedBefore), rev.ExpiresAt,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *Repository) Since(ctx context.Context, since, now time.Time) ([]*domain.Revocation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, jti, user_id, session_id, issued_before, expires_at, created_at
+		FROM token_revocations
+		WHERE created_at >= $1 AND expires_at > $2
+		ORDER BY created_at, id`, since, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*domain.Revocation
+	for rows.Next() {
+		var (
+			rev          domain.Revocation
+			issuedBefore sql.NullTime
+		)
+		if err := rows.Scan(&rev.ID, &rev.JTI, &rev.UserID, &rev.SessionID, &issuedBefore, &rev.ExpiresAt, &rev.CreatedAt); err != nil {
+			return nil, err
+		}
+		rev.IssuedBefore = issuedBefore.Time
+		list = append(list, &rev)
+	}
+
+	return list, rows.Err()
+}
+
+func (r *Repository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM token_revocations WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// nullTime stores the zero cutoff of jti entries as NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
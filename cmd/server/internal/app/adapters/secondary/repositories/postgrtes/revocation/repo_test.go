@@ -0,0 +1,156 @@
+package revocation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/app/config"
+	domain "server/internal/app/domain/user"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func init() {
+	config.InitTestConfig()
+}
+
+func newRepo(t *testing.T) (*Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return &Repository{db: db}, mock
+}
+
+func TestRepository_Add(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Date(2026, 5, 1, 12, 15, 0, 0, time.UTC)
+	cutoff := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("jti entry stores NULL cutoff", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		mock.ExpectQuery(sqlRe(`INSERT INTO token_revocations (jti, user_id, session_id, issued_before, expires_at)`)).
+			WithArgs("abc", int64(7), int64(0), sql.NullTime{}, expiresAt).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(3)))
+
+		id, err := repo.Add(context.Background(), &domain.Revocation{JTI: "abc", UserID: 7, ExpiresAt: expiresAt})
+		if err != nil || id != 3 {
+			t.Fatalf("Add = %d, %v", id, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unmet expectations: %v", err)
+		}
+	})
+
+	t.Run("user entry stores the cutoff", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		mock.ExpectQuery(sqlRe(`INSERT INTO token_revocations (jti, user_id, session_id, issued_before, expires_at)`)).
+			WithArgs("", int64(7), int64(0), sql.NullTime{Time: cutoff, Valid: true}, expiresAt).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(4)))
+
+		id, err := repo.Add(context.Background(), &domain.Revocation{UserID: 7, IssuedBefore: cutoff, ExpiresAt: expiresAt})
+		if err != nil || id != 4 {
+			t.Fatalf("Add = %d, %v", id, err)
+		}
+	})
+
+	t.Run("session entry stores the session", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		mock.ExpectQuery(sqlRe(`INSERT INTO token_revocations (jti, user_id, session_id, issued_before, expires_at)`)).
+			WithArgs("", int64(7), int64(31), sql.NullTime{}, expiresAt).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(5)))
+
+		id, err := repo.Add(context.Background(), &domain.Revocation{UserID: 7, SessionID: 31, ExpiresAt: expiresAt})
+		if err != nil || id != 5 {
+			t.Fatalf("Add = %d, %v", id, err)
+		}
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		mock.ExpectQuery(sqlRe(`INSERT INTO token_revocations`)).WillReturnError(errors.New("boom"))
+
+		if _, err := repo.Add(context.Background(), &domain.Revocation{JTI: "abc", ExpiresAt: expiresAt}); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestRepository_Since(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(15 * time.Minute)
+	cutoff := now.Add(-time.Minute)
+	since := now.Add(-2 * time.Minute)
+
+	mock.ExpectQuery(sqlRe(`SELECT id, jti, user_id, session_id, issued_before, expires_at, created_at FROM token_revocations WHERE created_at >= $1 AND expires_at > $2 ORDER BY created_at, id`)).
+		WithArgs(since, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "jti", "user_id", "session_id", "issued_before", "expires_at", "created_at"}).
+			AddRow(int64(3), "abc", int64(7), int64(0), nil, expiresAt, since).
+			AddRow(int64(4), "", int64(8), int64(0), cutoff, expiresAt, cutoff).
+			AddRow(int64(5), "", int64(9), int64(31), nil, expiresAt, now))
+
+	list, err := repo.Since(context.Background(), since, now)
+	if err != nil {
+		t.Fatalf("Since error: %v", err)
+	}
+	if len(list) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(list))
+	}
+	if list[0].JTI != "abc" || !list[0].IssuedBefore.IsZero() {
+		t.Fatalf("unexpected jti entry: %+v", list[0])
+	}
+	if list[1].UserID != 8 || !list[1].IssuedBefore.Equal(cutoff) {
+		t.Fatalf("unexpected user entry: %+v", list[1])
+	}
+	if list[2].SessionID != 31 || !list[2].CreatedAt.Equal(now) {
+		t.Fatalf("unexpected session entry: %+v", list[2])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_DeleteExpired(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectExec(sqlRe(`DELETE FROM token_revocations WHERE expires_at <= $1`)).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+
+	n, err := repo.DeleteExpired(context.Background(), now)
+	if err != nil || n != 5 {
+		t.Fatalf("DeleteExpired = %d, %v", n, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func sqlRe(q string) string {
+	s := strings.TrimSpace(q)
+	s = strings.Join(strings.Fields(s), " ")
+	s = regexp.QuoteMeta(s)
+	s = strings.ReplaceAll(s, `\ `, `\s+`)
+	return s
+}
@@ -0,0 +1,104 @@
+package secret_link
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"server/internal/pkg/logger"
+	"time"
+
+	domain "server/internal/app/domain/secret_link"
+
+	"go.uber.org/zap"
+)
+
+// consumeQuery counts a view in the same statement that checks it is allowed,
+// concurrent readers can not get more views than max_views
+const consumeQuery = `
+	UPDATE secret_links
+	SET views = views + 1
+	WHERE id = $1 AND expires_at > $2 AND views < max_views
+	RETURNING ciphertext, max_views - views, expires_at`
+
+func (r *Repository) Create(ctx context.Context, link *domain.Link) error {
+	query := `
+		INSERT INTO secret_links (id, user_id, label, ciphertext, max_views, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		link.ID, link.UserID, link.Label, link.Ciphertext, link.MaxViews, link.ExpiresAt, link.CreatedAt)
+
+	return err
+}
+
+func (r *Repository) ListByUser(ctx context.Context, userID int64) ([]*domain.Link, error) {
+	query := `
+		SELECT id, label, max_views, views, expires_at, created_at
+		FROM secret_links
+		WHERE user_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
+
+	var out []*domain.Link
+	for rows.Next() {
+		link := &domain.Link{UserID: userID}
+		if err := rows.Scan(&link.ID, &link.Label, &link.MaxViews, &link.Views, &link.ExpiresAt, &link.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, link)
+	}
+
+	return out, rows.Err()
+}
+
+func (r *Repository) Delete(ctx context.Context, userID int64, linkID string) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM secret_links WHERE id = $1 AND user_id = $2`, linkID, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrLinkNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) Consume(ctx context.Context, linkID string, now time.Time) (*domain.Secret, error) {
+	s := new(domain.Secret)
+	if err := r.db.QueryRowContext(ctx, consumeQuery, linkID, now).Scan(&s.Ciphertext, &s.ViewsLeft, &s.ExpiresAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrLinkNotFound
+		}
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (r *Repository) Burn(ctx context.Context, linkID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM secret_links WHERE id = $1`, linkID)
+	return err
+}
+
+func (r *Repository) DeleteDead(ctx context.Context, now time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM secret_links WHERE expires_at <= $1 OR views >= max_views`, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
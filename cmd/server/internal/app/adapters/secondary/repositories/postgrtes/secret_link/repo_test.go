@@ -0,0 +1,159 @@
+package secret_link
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/app/config"
+	domain "server/internal/app/domain/secret_link"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func init() {
+	config.InitTestConfig()
+}
+
+func newRepo(t *testing.T) (*Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return &Repository{db: db}, mock
+}
+
+func TestRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	expiresAt := time.Date(2026, 5, 2, 12, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	link := &domain.Link{ID: "abc", UserID: 1, Label: "wifi", Ciphertext: []byte("sealed"), MaxViews: 1, ExpiresAt: expiresAt, CreatedAt: createdAt}
+
+	mock.ExpectExec(sqlRe(`INSERT INTO secret_links (id, user_id, label, ciphertext, max_views, expires_at, created_at)`)).
+		WithArgs("abc", int64(1), "wifi", []byte("sealed"), 1, expiresAt, createdAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.Create(context.Background(), link); err != nil {
+		t.Fatalf("Create error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_Consume(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(time.Hour)
+
+	mock.ExpectQuery(sqlRe(consumeQuery)).
+		WithArgs("abc", now).
+		WillReturnRows(sqlmock.NewRows([]string{"ciphertext", "views_left", "expires_at"}).AddRow([]byte("sealed"), 0, expiresAt))
+	mock.ExpectQuery(sqlRe(consumeQuery)).
+		WithArgs("abc", now).
+		WillReturnRows(sqlmock.NewRows([]string{"ciphertext", "views_left", "expires_at"}))
+
+	s, err := repo.Consume(context.Background(), "abc", now)
+	if err != nil {
+		t.Fatalf("Consume error: %v", err)
+	}
+	if string(s.Ciphertext) != "sealed" || !s.Burned() || !s.ExpiresAt.Equal(expiresAt) {
+		t.Fatalf("unexpected secret: %+v", s)
+	}
+
+	if _, err := repo.Consume(context.Background(), "abc", now); !errors.Is(err, domain.ErrLinkNotFound) {
+		t.Fatalf("expected ErrLinkNotFound, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_ListByUser(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	createdAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(sqlRe(`SELECT id, label, max_views, views, expires_at, created_at FROM secret_links WHERE user_id = $1`)).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "label", "max_views", "views", "expires_at", "created_at"}).
+			AddRow("abc", "wifi", 3, 1, createdAt.Add(time.Hour), createdAt))
+
+	list, err := repo.ListByUser(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ListByUser error: %v", err)
+	}
+	if len(list) != 1 || list[0].UserID != 1 || list[0].ViewsLeft() != 2 || list[0].Ciphertext != nil {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_Delete(t *testing.T) {
+	t.Parallel()
+
+	const q = `DELETE FROM secret_links WHERE id = $1 AND user_id = $2`
+
+	repo, mock := newRepo(t)
+
+	mock.ExpectExec(sqlRe(q)).
+		WithArgs("abc", int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.Delete(context.Background(), 2, "abc"); !errors.Is(err, domain.ErrLinkNotFound) {
+		t.Fatalf("expected ErrLinkNotFound, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_DeleteDead(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(sqlRe(`DELETE FROM secret_links WHERE expires_at <= $1 OR views >= max_views`)).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	n, err := repo.DeleteDead(context.Background(), now)
+	if err != nil || n != 4 {
+		t.Fatalf("DeleteDead = %d, %v", n, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func sqlRe(q string) string {
+	s := strings.TrimSpace(q)
+	s = strings.Join(strings.Fields(s), " ")
+	s = regexp.QuoteMeta(s)
+	s = strings.ReplaceAll(s, `\ `, `\s+`)
+	return s
+}
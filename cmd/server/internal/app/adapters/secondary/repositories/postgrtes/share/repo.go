@@ -0,0 +1,234 @@
+package share
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"server/internal/pkg/logger"
+
+	domain "server/internal/app/domain/share"
+
+	"go.uber.org/zap"
+)
+
+// listByGranteeQuery resolves the item column of every share to its type, id and title
+const listByGranteeQuery = `
+	SELECT s.id, s.owner_id, o.username, s.grantee_id, s.permission, s.created_at,
+		CASE
+			WHEN s.account_id IS NOT NULL THEN 'account'
+			WHEN s.bank_id IS NOT NULL THEN 'card'
+			ELSE 'text'
+		END,
+		COALESCE(s.account_id, s.bank_id, s.text_id),
+		COALESCE(a.service_name, b.bank_name, t.title, '')
+	FROM shares s
+	JOIN users o ON o.id = s.owner_id
+	LEFT JOIN account_data a ON a.id = s.account_id
+	LEFT JOIN bank_data b ON b.id = s.bank_id
+	LEFT JOIN text_data t ON t.id = s.text_id
+	WHERE s.grantee_id = $1
+	ORDER BY lower(o.username), s.id`
+
+func (r *Repository) ItemOwner(ctx context.Context, item domain.Item) (int64, error) {
+	table, _, err := itemColumns(item)
+	if err != nil {
+		return 0, err
+	}
+
+	// items of organizations are governed by member roles and can not be shared
+	query := fmt.Sprintf(`SELECT user_id FROM %s WHERE id = $1 AND org_id IS NULL`, table)
+
+	var userID int64
+	if err := r.db.QueryRowContext(ctx, query, item.ID).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrItemNotFound
+		}
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+func (r *Repository) UserIDByName(ctx context.Context, username string) (int64, error) {
+	query := `SELECT id FROM users WHERE username = $1`
+
+	var id int64
+	if err := r.db.QueryRowContext(ctx, query, username).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrUserNotFound
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// Upsert relies on the unique indexes of the item columns, sharing again updates the permission
+func (r *Repository) Upsert(ctx context.Context, s *domain.Share) (int64, error) {
+	_, column, err := itemColumns(s.Item)
+	if err != nil {
+		return 0, err
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO shares (owner_id, grantee_id, %[1]s, permission)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (%[1]s, grantee_id) WHERE %[1]s IS NOT NULL
+		DO UPDATE SET permission = EXCLUDED.permission
+		RETURNING id`, column)
+
+	var id int64
+	if err := r.db.QueryRowContext(ctx, query, s.OwnerID, s.GranteeID, s.Item.ID, s.Permission).Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *Repository) GetByID(ctx context.Context, shareID int64) (*domain.Share, error) {
+	query := `
+		SELECT id, owner_id, grantee_id, account_id, bank_id, text_id, permission, created_at
+		FROM shares
+		WHERE id = $1`
+
+	var (
+		s                         domain.Share
+		accountID, bankID, textID sql.NullInt64
+	)
+
+	err := r.db.QueryRowContext(ctx, query, shareID).
+		Scan(&s.ID, &s.OwnerID, &s.GranteeID, &accountID, &bankID, &textID, &s.Permission, &s.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrShareNotFound
+		}
+		return nil, err
+	}
+
+	switch {
+	case accountID.Valid:
+		s.Item = domain.Item{Type: domain.ItemAccount, ID: accountID.Int64}
+	case bankID.Valid:
+		s.Item = domain.Item{Type: domain.ItemBankCard, ID: bankID.Int64}
+	case textID.Valid:
+		s.Item = domain.Item{Type: domain.ItemText, ID: textID.Int64}
+	}
+
+	return &s, nil
+}
+
+func (r *Repository) Delete(ctx context.Context, shareID int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM shares WHERE id = $1`, shareID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrShareNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) ListByItem(ctx context.Context, item domain.Item) ([]*domain.Share, error) {
+	_, column, err := itemColumns(item)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT s.id, s.owner_id, s.grantee_id, u.username, s.permission, s.created_at
+		FROM shares s
+		JOIN users u ON u.id = s.grantee_id
+		WHERE s.%s = $1
+		ORDER BY lower(u.username)`, column)
+
+	rows, err := r.db.QueryContext(ctx, query, item.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
+
+	var out []*domain.Share
+	for rows.Next() {
+		s := &domain.Share{Item: item}
+		if err := rows.Scan(&s.ID, &s.OwnerID, &s.GranteeID, &s.GranteeName, &s.Permission, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+
+	return out, rows.Err()
+}
+
+func (r *Repository) ListByGrantee(ctx context.Context, granteeID int64) ([]*domain.Share, error) {
+	rows, err := r.db.QueryContext(ctx, listByGranteeQuery, granteeID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
+
+	var out []*domain.Share
+	for rows.Next() {
+		s := new(domain.Share)
+		if err := rows.Scan(&s.ID, &s.OwnerID, &s.OwnerName, &s.GranteeID, &s.Permission, &s.CreatedAt,
+			&s.Item.Type, &s.Item.ID, &s.Title); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+
+	return out, rows.Err()
+}
+
+func (r *Repository) Access(ctx context.Context, userID int64, item domain.Item) (*domain.Access, error) {
+	_, column, err := itemColumns(item)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT s.owner_id, o.username, s.permission
+		FROM shares s
+		JOIN users o ON o.id = s.owner_id
+		WHERE s.%s = $1 AND s.grantee_id = $2`, column)
+
+	var a domain.Access
+	if err := r.db.QueryRowContext(ctx, query, item.ID, userID).Scan(&a.OwnerID, &a.OwnerName, &a.Permission); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrShareNotFound
+		}
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// help func
+
+// itemColumns maps an item type to its table and the link column of shares
+func itemColumns(item domain.Item) (table, column string, err error) {
+	switch item.Type {
+	case domain.ItemAccount:
+		return "account_data", "account_id", nil
+	case domain.ItemBankCard:
+		return "bank_data", "bank_id", nil
+	case domain.ItemText:
+		return "text_data", "text_id", nil
+	default:
+		return "", "", domain.ErrInvalidItem
+	}
+}
@@ -0,0 +1,180 @@
+package share
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/app/config"
+	domain "server/internal/app/domain/share"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func init() {
+	config.InitTestConfig()
+}
+
+func newRepo(t *testing.T) (*Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return &Repository{db: db}, mock
+}
+
+func TestRepository_Upsert(t *testing.T) {
+	t.Parallel()
+
+	const q = `
+		INSERT INTO shares (owner_id, grantee_id, bank_id, permission)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (bank_id, grantee_id) WHERE bank_id IS NOT NULL
+		DO UPDATE SET permission = EXCLUDED.permission
+		RETURNING id`
+
+	repo, mock := newRepo(t)
+	mock.ExpectQuery(sqlRe(q)).
+		WithArgs(int64(7), int64(8), int64(3), "write").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(11)))
+
+	id, err := repo.Upsert(context.Background(), &domain.Share{
+		Item:       domain.Item{Type: domain.ItemBankCard, ID: 3},
+		OwnerID:    7,
+		GranteeID:  8,
+		Permission: domain.PermWrite,
+	})
+	if err != nil || id != 11 {
+		t.Fatalf("unexpected result: %d, %v", id, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_GetByID(t *testing.T) {
+	t.Parallel()
+
+	const q = `
+		SELECT id, owner_id, grantee_id, account_id, bank_id, text_id, permission, created_at
+		FROM shares
+		WHERE id = $1`
+
+	t.Run("text share", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepo(t)
+		mock.ExpectQuery(sqlRe(q)).
+			WithArgs(int64(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "owner_id", "grantee_id", "account_id", "bank_id", "text_id", "permission", "created_at"}).
+				AddRow(int64(4), int64(7), int64(8), nil, nil, int64(2), "read", time.Now()))
+
+		s, err := repo.GetByID(context.Background(), 4)
+		if err != nil {
+			t.Fatalf("GetByID error: %v", err)
+		}
+		if s.Item != (domain.Item{Type: domain.ItemText, ID: 2}) || s.OwnerID != 7 || s.GranteeID != 8 {
+			t.Fatalf("unexpected share: %+v", s)
+		}
+	})
+
+	t.Run("no rows -> ErrShareNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepo(t)
+		mock.ExpectQuery(sqlRe(q)).WithArgs(int64(5)).WillReturnError(sql.ErrNoRows)
+
+		if _, err := repo.GetByID(context.Background(), 5); !errors.Is(err, domain.ErrShareNotFound) {
+			t.Fatalf("expected ErrShareNotFound, got: %v", err)
+		}
+	})
+}
+
+func TestRepository_ListByGrantee(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery(sqlRe(listByGranteeQuery)).
+		WithArgs(int64(8)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "owner_id", "username", "grantee_id", "permission", "created_at", "type", "item_id", "title"}).
+			AddRow(int64(1), int64(7), "alice", int64(8), "write", createdAt, "account", int64(3), "github"))
+
+	list, err := repo.ListByGrantee(context.Background(), 8)
+	if err != nil {
+		t.Fatalf("ListByGrantee error: %v", err)
+	}
+	if len(list) != 1 || list[0].OwnerName != "alice" || list[0].Item != (domain.Item{Type: domain.ItemAccount, ID: 3}) || list[0].Title != "github" {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRepository_Access(t *testing.T) {
+	t.Parallel()
+
+	const q = `
+		SELECT s.owner_id, o.username, s.permission
+		FROM shares s
+		JOIN users o ON o.id = s.owner_id
+		WHERE s.account_id = $1 AND s.grantee_id = $2`
+
+	t.Run("shared", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepo(t)
+		mock.ExpectQuery(sqlRe(q)).
+			WithArgs(int64(3), int64(8)).
+			WillReturnRows(sqlmock.NewRows([]string{"owner_id", "username", "permission"}).AddRow(int64(7), "alice", "read"))
+
+		a, err := repo.Access(context.Background(), 8, domain.Item{Type: domain.ItemAccount, ID: 3})
+		if err != nil || a.OwnerName != "alice" || a.CanWrite() {
+			t.Fatalf("unexpected result: %+v, %v", a, err)
+		}
+	})
+
+	t.Run("not shared -> ErrShareNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		repo, mock := newRepo(t)
+		mock.ExpectQuery(sqlRe(q)).WithArgs(int64(3), int64(9)).WillReturnError(sql.ErrNoRows)
+
+		if _, err := repo.Access(context.Background(), 9, domain.Item{Type: domain.ItemAccount, ID: 3}); !errors.Is(err, domain.ErrShareNotFound) {
+			t.Fatalf("expected ErrShareNotFound, got: %v", err)
+		}
+	})
+}
+
+func TestRepository_Delete(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+	mock.ExpectExec(sqlRe(`DELETE FROM shares WHERE id = $1`)).
+		WithArgs(int64(4)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.Delete(context.Background(), 4); !errors.Is(err, domain.ErrShareNotFound) {
+		t.Fatalf("expected ErrShareNotFound, got: %v", err)
+	}
+}
+
+func sqlRe(q string) string {
+	s := strings.TrimSpace(q)
+	s = strings.Join(strings.Fields(s), " ")
+	s = regexp.QuoteMeta(s)
+	s = strings.ReplaceAll(s, `\ `, `\s+`)
+	return s
+}
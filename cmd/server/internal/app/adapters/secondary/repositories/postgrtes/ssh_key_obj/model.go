@@ -0,0 +1,39 @@
+package ssh_key_obj
+
+import (
+	"database/sql"
+	domain "server/internal/app/domain/ssh_key_obj"
+)
+
+type SSHKey struct {
+	ID          sql.NullInt64
+	UserId      sql.NullInt64
+	Title       sql.NullString
+	Type        sql.NullString
+	PrivateKey  []byte
+	PublicKey   sql.NullString
+	Fingerprint sql.NullString
+	Comment     sql.NullString
+	CreatedAt   sql.NullTime
+}
+
+func (k *SSHKey) ToDomain() *domain.SSHKey {
+	return &domain.SSHKey{
+		KeyId:       k.ID.Int64,
+		UserId:      k.UserId.Int64,
+		Title:       k.Title.String,
+		Type:        k.Type.String,
+		PrivateKey:  string(k.PrivateKey),
+		PublicKey:   k.PublicKey.String,
+		Fingerprint: k.Fingerprint.String,
+		Comment:     k.Comment.String,
+		CreatedAt:   k.CreatedAt.Time,
+	}
+}
+
+func (k *SSHKey) scanFields() []any {
+	return []any{
+		&k.ID, &k.UserId, &k.Title, &k.Type, &k.PrivateKey,
+		&k.PublicKey, &k.Fingerprint, &k.Comment, &k.CreatedAt,
+	}
+}
@@ -0,0 +1,123 @@
+package ssh_key_obj
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"server/internal/app/config"
+	domain "server/internal/app/domain/ssh_key_obj"
+	"server/internal/pkg/encryption/aes"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+func (u *Repository) GetByUserID(ctx context.Context, userId int64) ([]*domain.SSHKey, error) {
+	query := `
+		SELECT id, user_id, title, key_type, private_key,
+		       public_key, fingerprint, comment, created_at
+		FROM ssh_key_data
+		WHERE user_id = $1
+		ORDER BY id`
+
+	var keys []*domain.SSHKey
+
+	rows, err := u.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
+
+	for rows.Next() {
+		obj := new(SSHKey)
+
+		if err := rows.Scan(obj.scanFields()...); err != nil {
+			return nil, err
+		}
+
+		if err := decryptKey(obj); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, obj.ToDomain())
+	}
+	return keys, rows.Err()
+}
+
+func (u *Repository) GetByID(ctx context.Context, keyId int64) (*domain.SSHKey, error) {
+	query := `
+		SELECT id, user_id, title, key_type, private_key,
+		       public_key, fingerprint, comment, created_at
+		FROM ssh_key_data
+		WHERE id = $1`
+
+	obj := new(SSHKey)
+
+	if err := u.db.QueryRowContext(ctx, query, keyId).Scan(obj.scanFields()...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSSHKeyNotFound
+		}
+		return nil, err
+	}
+
+	if err := decryptKey(obj); err != nil {
+		return nil, err
+	}
+
+	return obj.ToDomain(), nil
+}
+
+func (u *Repository) Create(ctx context.Context, key *domain.SSHKey) (int64, error) {
+	query := `
+		INSERT INTO ssh_key_data (user_id, title, key_type, private_key, public_key, fingerprint, comment)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	encrypted, err := aes.EncryptAES([]byte(key.PrivateKey), []byte(config.App.GetSSHKeyObjEncryptionKey()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to encrypt ssh private key: %w", err)
+	}
+
+	var id sql.NullInt64
+
+	err = u.db.QueryRowContext(ctx, query,
+		key.UserId, key.Title, key.Type, encrypted,
+		key.PublicKey, key.Fingerprint, nullIfEmpty(key.Comment),
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrFailedCreateSSHKey
+		}
+		return 0, err
+	}
+
+	if !id.Valid {
+		return 0, domain.ErrFailedCreateSSHKey
+	}
+
+	return id.Int64, nil
+}
+
+// help func
+
+func decryptKey(obj *SSHKey) error {
+	decrypted, err := aes.DecryptAES(obj.PrivateKey, []byte(config.App.GetSSHKeyObjEncryptionKey()))
+	if err != nil {
+		return fmt.Errorf("failed decrypt ssh private key: %w", err)
+	}
+	obj.PrivateKey = decrypted
+
+	return nil
+}
+
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
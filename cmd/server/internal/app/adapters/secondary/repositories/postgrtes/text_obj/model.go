@@ -6,10 +6,14 @@ import (
 )
 
 type Text struct {
-	Text   sql.NullString `db:"text"`
-	Title  sql.NullString `db:"title"`
-	UserID sql.NullInt64  `db:"user_id"`
-	ID     sql.NullInt64  `db:"id"`
+	Text      sql.NullString `db:"text"`
+	Title     sql.NullString `db:"title"`
+	UserID    sql.NullInt64  `db:"user_id"`
+	ID        sql.NullInt64  `db:"id"`
+	ExpiresAt sql.NullTime   `db:"expires_at"`
+	// CustomFields is the raw JSONB column, decoded by the repository
+	CustomFields sql.NullString `db:"custom_fields"`
+	OrgID        sql.NullInt64  `db:"org_id"`
 }
 
 func (t *Text) ToDomain() *domain.Text {
@@ -18,5 +22,8 @@ func (t *Text) ToDomain() *domain.Text {
 		UserId: t.UserID.Int64,
 		TextId: t.ID.Int64,
 		Title:  t.Title.String,
+
+		ExpiresAt: t.ExpiresAt.Time,
+		OrgID:     t.OrgID.Int64,
 	}
 }
@@ -4,17 +4,19 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"server/internal/app/adapters/secondary/repositories/postgrtes/custom_field"
 	domain "server/internal/app/domain/text_obj"
 	"server/internal/pkg/logger"
+	"time"
 
 	"go.uber.org/zap"
 )
 
 func (u *Repository) GetByUserID(ctx context.Context, userId int64) ([]*domain.Text, error) {
 	query := `
-		SELECT id, user_id, title, text
+		SELECT id, user_id, title, text, expires_at, custom_fields, org_id
 		FROM text_data
-		WHERE user_id = $1`
+		WHERE user_id = $1 AND org_id IS NULL`
 
 	var texts []*domain.Text
 
@@ -31,24 +33,29 @@ func (u *Repository) GetByUserID(ctx context.Context, userId int64) ([]*domain.T
 	for rows.Next() {
 		obj := new(Text)
 
-		if err := rows.Scan(&obj.ID, &obj.UserID, &obj.Title, &obj.Text); err != nil {
+		if err := rows.Scan(&obj.ID, &obj.UserID, &obj.Title, &obj.Text, &obj.ExpiresAt, &obj.CustomFields, &obj.OrgID); err != nil {
 			return nil, err
 		}
 
-		texts = append(texts, obj.ToDomain())
+		text, err := toDomain(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		texts = append(texts, text)
 	}
 	return texts, nil
 }
 
 func (u *Repository) GetByID(ctx context.Context, cardId int64) (*domain.Text, error) {
 	query := `
-		SELECT id, user_id, title, text
+		SELECT id, user_id, title, text, expires_at, custom_fields, org_id
 		FROM text_data
 		WHERE id = $1`
 
 	obj := new(Text)
 
-	if err := u.db.QueryRowContext(ctx, query, cardId).Scan(&obj.ID, &obj.UserID, &obj.Title, &obj.Text); err != nil {
+	if err := u.db.QueryRowContext(ctx, query, cardId).Scan(&obj.ID, &obj.UserID, &obj.Title, &obj.Text, &obj.ExpiresAt, &obj.CustomFields, &obj.OrgID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrTextInformationNotFound
 		}
@@ -56,18 +63,23 @@ func (u *Repository) GetByID(ctx context.Context, cardId int64) (*domain.Text, e
 		return nil, err
 	}
 
-	return obj.ToDomain(), nil
+	return toDomain(obj)
 }
 
 func (u *Repository) Create(ctx context.Context, card *domain.Text) (int64, error) {
 	query := `
-		INSERT INTO text_data (user_id, title, text)
-		VALUES ($1, $2, $3)
+		INSERT INTO text_data (user_id, title, text, expires_at, custom_fields, org_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id`
 
+	fields, err := custom_field.Encode(card.CustomFields)
+	if err != nil {
+		return 0, err
+	}
+
 	var id sql.NullInt64
 
-	if err := u.db.QueryRowContext(ctx, query, card.UserId, card.Title, card.Text).Scan(&id); err != nil {
+	if err := u.db.QueryRowContext(ctx, query, card.UserId, card.Title, card.Text, nullIfZero(card.ExpiresAt), fields, nullIfZeroID(card.OrgID)).Scan(&id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, domain.ErrFailedCreateText
 		}
@@ -84,11 +96,51 @@ func (u *Repository) Create(ctx context.Context, card *domain.Text) (int64, erro
 func (u *Repository) Update(ctx context.Context, card *domain.Text) error {
 	query := `
 		UPDATE text_data SET
-		title = $1, text = $2
-		WHERE id = $3`
+		title = $1, text = $2, expires_at = $3, custom_fields = $4
+		WHERE id = $5`
 
-	if _, err := u.db.ExecContext(ctx, query, card.Title, card.Text, card.TextId); err != nil {
+	fields, err := custom_field.Encode(card.CustomFields)
+	if err != nil {
+		return err
+	}
+
+	if _, err := u.db.ExecContext(ctx, query, card.Title, card.Text, nullIfZero(card.ExpiresAt), fields, card.TextId); err != nil {
 		return err
 	}
 	return nil
 }
+
+func (u *Repository) Delete(ctx context.Context, textId int64) error {
+	query := `DELETE FROM text_data WHERE id = $1`
+
+	if _, err := u.db.ExecContext(ctx, query, textId); err != nil {
+		return err
+	}
+	return nil
+}
+
+// toDomain decodes the custom fields, the rest of the row maps as is
+func toDomain(obj *Text) (*domain.Text, error) {
+	fields, err := custom_field.Decode(obj.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+
+	text := obj.ToDomain()
+	text.CustomFields = fields
+	return text, nil
+}
+
+func nullIfZero(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func nullIfZeroID(id int64) any {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
@@ -8,6 +8,7 @@ import (
 	"server/internal/app/config"
 	"strings"
 	"testing"
+	"time"
 
 	domain "server/internal/app/domain/text_obj"
 
@@ -33,9 +34,9 @@ func TestRepository_GetByUserID(t *testing.T) {
 		repo := &Repository{db: db}
 
 		const q = `
-			SELECT id, user_id, title, text
+			SELECT id, user_id, title, text, expires_at, custom_fields, org_id
 			FROM text_data
-			WHERE user_id = $1`
+			WHERE user_id = $1 AND org_id IS NULL`
 
 		dbErr := errors.New("db down")
 		mock.ExpectQuery(sqlRe(q)).
@@ -67,9 +68,9 @@ func TestRepository_GetByUserID(t *testing.T) {
 		repo := &Repository{db: db}
 
 		const q = `
-			SELECT id, user_id, title, text
+			SELECT id, user_id, title, text, expires_at, custom_fields, org_id
 			FROM text_data
-			WHERE user_id = $1`
+			WHERE user_id = $1 AND org_id IS NULL`
 
 		// Неправильные колонки => Scan упадёт
 		rows := sqlmock.NewRows([]string{"id"}).AddRow(int64(1))
@@ -100,13 +101,13 @@ func TestRepository_GetByUserID(t *testing.T) {
 		repo := &Repository{db: db}
 
 		const q = `
-			SELECT id, user_id, title, text
+			SELECT id, user_id, title, text, expires_at, custom_fields, org_id
 			FROM text_data
-			WHERE user_id = $1`
+			WHERE user_id = $1 AND org_id IS NULL`
 
-		rows := sqlmock.NewRows([]string{"id", "user_id", "title", "text"}).
-			AddRow(int64(1), int64(7), "t1", "body1").
-			AddRow(int64(2), int64(7), "t2", "body2")
+		rows := sqlmock.NewRows([]string{"id", "user_id", "title", "text", "expires_at", "custom_fields", "org_id"}).
+			AddRow(int64(1), int64(7), "t1", "body1", nil, "[]", nil).
+			AddRow(int64(2), int64(7), "t2", "body2", nil, "[]", nil)
 
 		mock.ExpectQuery(sqlRe(q)).
 			WithArgs(int64(7)).
@@ -147,7 +148,7 @@ func TestRepository_GetByID(t *testing.T) {
 		repo := &Repository{db: db}
 
 		const q = `
-			SELECT id, user_id, title, text
+			SELECT id, user_id, title, text, expires_at, custom_fields, org_id
 			FROM text_data
 			WHERE id = $1`
 
@@ -177,7 +178,7 @@ func TestRepository_GetByID(t *testing.T) {
 		repo := &Repository{db: db}
 
 		const q = `
-			SELECT id, user_id, title, text
+			SELECT id, user_id, title, text, expires_at, custom_fields, org_id
 			FROM text_data
 			WHERE id = $1`
 
@@ -211,12 +212,13 @@ func TestRepository_GetByID(t *testing.T) {
 		repo := &Repository{db: db}
 
 		const q = `
-			SELECT id, user_id, title, text
+			SELECT id, user_id, title, text, expires_at, custom_fields, org_id
 			FROM text_data
 			WHERE id = $1`
 
-		rows := sqlmock.NewRows([]string{"id", "user_id", "title", "text"}).
-			AddRow(int64(5), int64(7), "hello", "world")
+		expiresAt := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+		rows := sqlmock.NewRows([]string{"id", "user_id", "title", "text", "expires_at", "custom_fields", "org_id"}).
+			AddRow(int64(5), int64(7), "hello", "world", expiresAt, `[{"name":"url","type":"url","value":"https://example.com"}]`, nil)
 
 		mock.ExpectQuery(sqlRe(q)).
 			WithArgs(int64(5)).
@@ -229,6 +231,12 @@ func TestRepository_GetByID(t *testing.T) {
 		if item.TextId != 5 || item.UserId != 7 || item.Title != "hello" || item.Text != "world" {
 			t.Fatalf("unexpected item: %+v", item)
 		}
+		if !item.ExpiresAt.Equal(expiresAt) {
+			t.Fatalf("expected expires_at=%v, got %v", expiresAt, item.ExpiresAt)
+		}
+		if len(item.CustomFields) != 1 || item.CustomFields[0].Value != "https://example.com" {
+			t.Fatalf("unexpected custom fields: %+v", item.CustomFields)
+		}
 
 		if err := mock.ExpectationsWereMet(); err != nil {
 			t.Fatalf("expectations: %v", err)
@@ -251,12 +259,12 @@ func TestRepository_Create(t *testing.T) {
 		repo := &Repository{db: db}
 
 		const q = `
-			INSERT INTO text_data (user_id, title, text)
-			VALUES ($1, $2, $3)
+			INSERT INTO text_data (user_id, title, text, expires_at, custom_fields, org_id)
+			VALUES ($1, $2, $3, $4, $5, $6)
 			RETURNING id`
 
 		mock.ExpectQuery(sqlRe(q)).
-			WithArgs(int64(7), "t", "body").
+			WithArgs(int64(7), "t", "body", nil, "[]", nil).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(123)))
 
 		id, err := repo.Create(context.Background(), &domain.Text{
@@ -288,12 +296,12 @@ func TestRepository_Create(t *testing.T) {
 		repo := &Repository{db: db}
 
 		const q = `
-			INSERT INTO text_data (user_id, title, text)
-			VALUES ($1, $2, $3)
+			INSERT INTO text_data (user_id, title, text, expires_at, custom_fields, org_id)
+			VALUES ($1, $2, $3, $4, $5, $6)
 			RETURNING id`
 
 		mock.ExpectQuery(sqlRe(q)).
-			WithArgs(int64(7), "t", "body").
+			WithArgs(int64(7), "t", "body", nil, "[]", nil).
 			WillReturnError(sql.ErrNoRows)
 
 		_, err = repo.Create(context.Background(), &domain.Text{UserId: 7, Title: "t", Text: "body"})
@@ -318,12 +326,12 @@ func TestRepository_Create(t *testing.T) {
 		repo := &Repository{db: db}
 
 		const q = `
-			INSERT INTO text_data (user_id, title, text)
-			VALUES ($1, $2, $3)
+			INSERT INTO text_data (user_id, title, text, expires_at, custom_fields, org_id)
+			VALUES ($1, $2, $3, $4, $5, $6)
 			RETURNING id`
 
 		mock.ExpectQuery(sqlRe(q)).
-			WithArgs(int64(7), "t", "body").
+			WithArgs(int64(7), "t", "body", nil, "[]", nil).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(nil))
 
 		_, err = repo.Create(context.Background(), &domain.Text{UserId: 7, Title: "t", Text: "body"})
@@ -353,12 +361,12 @@ func TestRepository_Update(t *testing.T) {
 
 		const q = `
 			UPDATE text_data SET
-			title = $1, text = $2
-			WHERE id = $3`
+			title = $1, text = $2, expires_at = $3, custom_fields = $4
+			WHERE id = $5`
 
 		dbErr := errors.New("db down")
 		mock.ExpectExec(sqlRe(q)).
-			WithArgs("t", "body", int64(9)).
+			WithArgs("t", "body", nil, "[]", int64(9)).
 			WillReturnError(dbErr)
 
 		err = repo.Update(context.Background(), &domain.Text{TextId: 9, Title: "t", Text: "body"})
@@ -387,11 +395,11 @@ func TestRepository_Update(t *testing.T) {
 
 		const q = `
 			UPDATE text_data SET
-			title = $1, text = $2
-			WHERE id = $3`
+			title = $1, text = $2, expires_at = $3, custom_fields = $4
+			WHERE id = $5`
 
 		mock.ExpectExec(sqlRe(q)).
-			WithArgs("t", "body", int64(9)).
+			WithArgs("t", "body", nil, "[]", int64(9)).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		err = repo.Update(context.Background(), &domain.Text{TextId: 9, Title: "t", Text: "body"})
@@ -417,3 +425,27 @@ func sqlRe(q string) string {
 	s = strings.ReplaceAll(s, `\ `, `\s+`)
 	return s
 }
+
+func TestRepository_Delete(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db: db}
+
+	mock.ExpectExec(sqlRe(`DELETE FROM text_data WHERE id = $1`)).
+		WithArgs(int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.Delete(context.Background(), 5); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
@@ -0,0 +1,245 @@
+package two_factor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"server/internal/app/config"
+	domain "server/internal/app/domain/two_factor"
+	"server/internal/pkg/encryption/aes"
+	postgres "server/internal/pkg/postgres"
+)
+
+func (r *Repository) Get(ctx context.Context, userId int64) (*domain.TwoFactor, error) {
+	query := `
+		SELECT secret, enabled_at IS NOT NULL, last_counter
+		FROM user_two_factor
+		WHERE user_id = $1`
+
+	tf := &domain.TwoFactor{UserID: userId}
+	var secret []byte
+
+	if err := r.db.QueryRowContext(ctx, query, userId).Scan(&secret, &tf.Enabled, &tf.LastCounter); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotEnrolled
+		}
+		return nil, err
+	}
+
+	decrypted, err := aes.DecryptAES(secret, []byte(config.App.GetTwoFactorEncryptionKey()))
+	if err != nil {
+		return nil, fmt.Errorf("failed decrypt two-factor secret: %w", err)
+	}
+	tf.Secret = decrypted
+
+	return tf, nil
+}
+
+func (r *Repository) Save(ctx context.Context, userId int64, secret []byte) error {
+	// a pending secret is replaced by a new setup, an enabled one is left alone
+	query := `
+		INSERT INTO user_two_factor (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, last_counter = 0, created_at = now()
+		WHERE user_two_factor.enabled_at IS NULL`
+
+	encrypted, err := aes.EncryptAES(secret, []byte(config.App.GetTwoFactorEncryptionKey()))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt two-factor secret: %w", err)
+	}
+
+	res, err := r.db.ExecContext(ctx, query, userId, encrypted)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrAlreadyEnabled
+	}
+
+	return nil
+}
+
+func (r *Repository) Enable(ctx context.Context, userId, counter int64, codeHashes []string) error {
+	query := `
+		UPDATE user_two_factor
+		SET enabled_at = now(), last_counter = $2
+		WHERE user_id = $1 AND enabled_at IS NULL`
+
+	return postgres.WithTx(ctx, r.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query, userId, counter)
+		if err != nil {
+			return err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return domain.ErrNotEnrolled
+		}
+
+		return replaceCodes(ctx, tx, userId, codeHashes)
+	})
+}
+
+func (r *Repository) Delete(ctx context.Context, userId int64) error {
+	return postgres.WithTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userId); err != nil {
+			return fmt.Errorf("delete recovery codes of user id=%d: %w", userId, err)
+		}
+
+		res, err := tx.ExecContext(ctx, `DELETE FROM user_two_factor WHERE user_id = $1`, userId)
+		if err != nil {
+			return err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return domain.ErrNotEnrolled
+		}
+		return nil
+	})
+}
+
+func (r *Repository) UseCounter(ctx context.Context, userId, counter int64) (bool, error) {
+	query := `
+		UPDATE user_two_factor
+		SET last_counter = $2
+		WHERE user_id = $1 AND last_counter < $2`
+
+	return r.affected(ctx, query, userId, counter)
+}
+
+func (r *Repository) UseAttempt(ctx context.Context, userId int64, now time.Time, maxFailures int, lockUntil time.Time) (bool, error) {
+	// an expired lock starts the count over, the attempt reaching maxFailures sets the lock
+	query := `
+		UPDATE user_two_factor
+		SET failed_attempts = CASE WHEN locked_until <= $2 THEN 0 ELSE failed_attempts END + 1,
+		    locked_until    = CASE WHEN (CASE WHEN locked_until <= $2 THEN 0 ELSE failed_attempts END) + 1 >= $3 THEN $4 END
+		WHERE user_id = $1 AND (locked_until IS NULL OR locked_until <= $2)`
+
+	return r.affected(ctx, query, userId, now, maxFailures, lockUntil)
+}
+
+func (r *Repository) ResetAttempts(ctx context.Context, userId int64) error {
+	query := `
+		UPDATE user_two_factor
+		SET failed_attempts = 0, locked_until = NULL
+		WHERE user_id = $1`
+
+	_, err := r.db.ExecContext(ctx, query, userId)
+	return err
+}
+
+func (r *Repository) UseRecoveryCode(ctx context.Context, userId int64, codeHash string, now time.Time) (bool, error) {
+	query := `
+		UPDATE recovery_codes
+		SET used_at = $3
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`
+
+	return r.affected(ctx, query, userId, codeHash, now)
+}
+
+func (r *Repository) ReplaceRecoveryCodes(ctx context.Context, userId int64, codeHashes []string) error {
+	return postgres.WithTx(ctx, r.db, func(tx *sql.Tx) error {
+		return replaceCodes(ctx, tx, userId, codeHashes)
+	})
+}
+
+func (r *Repository) RecoveryCodesLeft(ctx context.Context, userId int64) (int, error) {
+	query := `SELECT count(*) FROM recovery_codes WHERE user_id = $1 AND used_at IS NULL`
+
+	var n int
+	if err := r.db.QueryRowContext(ctx, query, userId).Scan(&n); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func (r *Repository) CreateChallenge(ctx context.Context, c *domain.Challenge, now time.Time) error {
+	// challenges are short-lived, the expired ones of every user go with each new login
+	pruneQuery := `DELETE FROM login_challenges WHERE expires_at <= $1`
+
+	insertQuery := `
+		INSERT INTO login_challenges (user_id, token_hash, device_name, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	if _, err := r.db.ExecContext(ctx, pruneQuery, now); err != nil {
+		return fmt.Errorf("prune login challenges: %w", err)
+	}
+
+	return r.db.QueryRowContext(ctx, insertQuery, c.UserID, c.TokenHash, c.DeviceName, c.ExpiresAt).Scan(&c.ID)
+}
+
+func (r *Repository) UseChallenge(ctx context.Context, tokenHash string, now time.Time, maxAttempts int) (*domain.Challenge, error) {
+	// the attempt is counted before the code is checked so parallel guesses can't exceed the limit
+	query := `
+		UPDATE login_challenges
+		SET attempts = attempts + 1
+		WHERE token_hash = $1 AND expires_at > $2 AND attempts < $3
+		RETURNING id, user_id, device_name, attempts, expires_at`
+
+	c := &domain.Challenge{TokenHash: tokenHash}
+
+	err := r.db.QueryRowContext(ctx, query, tokenHash, now, maxAttempts).
+		Scan(&c.ID, &c.UserID, &c.DeviceName, &c.Attempts, &c.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrChallengeNotFound
+		}
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (r *Repository) DeleteChallenge(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM login_challenges WHERE id = $1`, id)
+	return err
+}
+
+// help func
+
+// affected runs an update and tells if it changed a row
+func (r *Repository) affected(ctx context.Context, query string, args ...any) (bool, error) {
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+// replaceCodes drops every recovery code of the user, spent ones too, and stores the new hashes
+func replaceCodes(ctx context.Context, tx *sql.Tx, userId int64, codeHashes []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userId); err != nil {
+		return fmt.Errorf("delete recovery codes of user id=%d: %w", userId, err)
+	}
+
+	for _, h := range codeHashes {
+		_, err := tx.ExecContext(ctx, `INSERT INTO recovery_codes (user_id, code_hash) VALUES ($1, $2)`, userId, h)
+		if err != nil {
+			return fmt.Errorf("insert recovery code of user id=%d: %w", userId, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,358 @@
+package two_factor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/app/config"
+	domain "server/internal/app/domain/two_factor"
+	"server/internal/pkg/encryption/aes"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func init() {
+	config.InitTestConfig()
+}
+
+func newRepo(t *testing.T) (*Repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return &Repository{db: db}, mock
+}
+
+func TestRepository_Get(t *testing.T) {
+	t.Parallel()
+
+	t.Run("decrypts the secret", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		encrypted, err := aes.EncryptAES([]byte("secret"), []byte(config.App.GetTwoFactorEncryptionKey()))
+		if err != nil {
+			t.Fatalf("EncryptAES error: %v", err)
+		}
+
+		mock.ExpectQuery(sqlRe(`SELECT secret, enabled_at IS NOT NULL, last_counter FROM user_two_factor WHERE user_id = $1`)).
+			WithArgs(int64(7)).
+			WillReturnRows(sqlmock.NewRows([]string{"secret", "enabled", "last_counter"}).AddRow(encrypted, true, int64(42)))
+
+		tf, err := repo.Get(context.Background(), 7)
+		if err != nil {
+			t.Fatalf("Get error: %v", err)
+		}
+		if string(tf.Secret) != "secret" || !tf.Enabled || tf.LastCounter != 42 || tf.UserID != 7 {
+			t.Fatalf("unexpected two-factor: %+v", tf)
+		}
+	})
+
+	t.Run("no rows -> ErrNotEnrolled", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		mock.ExpectQuery(sqlRe(`FROM user_two_factor`)).WithArgs(int64(7)).WillReturnError(sql.ErrNoRows)
+
+		if _, err := repo.Get(context.Background(), 7); !errors.Is(err, domain.ErrNotEnrolled) {
+			t.Fatalf("expected ErrNotEnrolled, got %v", err)
+		}
+	})
+}
+
+func TestRepository_Save(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stores the secret encrypted", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		mock.ExpectExec(sqlRe(`INSERT INTO user_two_factor (user_id, secret) VALUES ($1, $2) ON CONFLICT (user_id) DO UPDATE`)).
+			WithArgs(int64(7), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		if err := repo.Save(context.Background(), 7, []byte("secret")); err != nil {
+			t.Fatalf("Save error: %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unmet expectations: %v", err)
+		}
+	})
+
+	t.Run("enabled secret is kept -> ErrAlreadyEnabled", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		mock.ExpectExec(sqlRe(`INSERT INTO user_two_factor`)).
+			WithArgs(int64(7), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		if err := repo.Save(context.Background(), 7, []byte("secret")); !errors.Is(err, domain.ErrAlreadyEnabled) {
+			t.Fatalf("expected ErrAlreadyEnabled, got %v", err)
+		}
+	})
+}
+
+func TestRepository_Enable(t *testing.T) {
+	t.Parallel()
+
+	t.Run("enables and replaces codes in one tx", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlRe(`UPDATE user_two_factor SET enabled_at = now(), last_counter = $2 WHERE user_id = $1 AND enabled_at IS NULL`)).
+			WithArgs(int64(7), int64(100)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlRe(`DELETE FROM recovery_codes WHERE user_id = $1`)).
+			WithArgs(int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(sqlRe(`INSERT INTO recovery_codes (user_id, code_hash) VALUES ($1, $2)`)).
+			WithArgs(int64(7), "h1").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(sqlRe(`INSERT INTO recovery_codes (user_id, code_hash) VALUES ($1, $2)`)).
+			WithArgs(int64(7), "h2").
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		if err := repo.Enable(context.Background(), 7, 100, []string{"h1", "h2"}); err != nil {
+			t.Fatalf("Enable error: %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unmet expectations: %v", err)
+		}
+	})
+
+	t.Run("nothing pending -> ErrNotEnrolled and rollback", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlRe(`UPDATE user_two_factor`)).
+			WithArgs(int64(7), int64(100)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		if err := repo.Enable(context.Background(), 7, 100, []string{"h1"}); !errors.Is(err, domain.ErrNotEnrolled) {
+			t.Fatalf("expected ErrNotEnrolled, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unmet expectations: %v", err)
+		}
+	})
+}
+
+func TestRepository_Delete(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(sqlRe(`DELETE FROM recovery_codes WHERE user_id = $1`)).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(sqlRe(`DELETE FROM user_two_factor WHERE user_id = $1`)).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.Delete(context.Background(), 7); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_UseCounter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("newer step", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		mock.ExpectExec(sqlRe(`UPDATE user_two_factor SET last_counter = $2 WHERE user_id = $1 AND last_counter < $2`)).
+			WithArgs(int64(7), int64(101)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		ok, err := repo.UseCounter(context.Background(), 7, 101)
+		if err != nil || !ok {
+			t.Fatalf("UseCounter = %v, %v", ok, err)
+		}
+	})
+
+	t.Run("replayed step", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		mock.ExpectExec(sqlRe(`UPDATE user_two_factor SET last_counter = $2`)).
+			WithArgs(int64(7), int64(100)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		ok, err := repo.UseCounter(context.Background(), 7, 100)
+		if err != nil || ok {
+			t.Fatalf("UseCounter = %v, %v", ok, err)
+		}
+	})
+}
+
+func TestRepository_UseAttempt(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	lockUntil := now.Add(15 * time.Minute)
+
+	t.Run("not locked -> counted", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		mock.ExpectExec(sqlRe(`UPDATE user_two_factor SET failed_attempts = CASE WHEN locked_until <= $2 THEN 0 ELSE failed_attempts END + 1`)).
+			WithArgs(int64(7), now, 10, lockUntil).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		ok, err := repo.UseAttempt(context.Background(), 7, now, 10, lockUntil)
+		if err != nil || !ok {
+			t.Fatalf("UseAttempt = %v, %v", ok, err)
+		}
+	})
+
+	t.Run("locked -> false", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		mock.ExpectExec(sqlRe(`UPDATE user_two_factor SET failed_attempts`)).
+			WithArgs(int64(7), now, 10, lockUntil).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		ok, err := repo.UseAttempt(context.Background(), 7, now, 10, lockUntil)
+		if err != nil || ok {
+			t.Fatalf("UseAttempt = %v, %v", ok, err)
+		}
+	})
+}
+
+func TestRepository_ResetAttempts(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	mock.ExpectExec(sqlRe(`UPDATE user_two_factor SET failed_attempts = 0, locked_until = NULL WHERE user_id = $1`)).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.ResetAttempts(context.Background(), 7); err != nil {
+		t.Fatalf("ResetAttempts error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_UseRecoveryCode(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("unused code", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		mock.ExpectExec(sqlRe(`UPDATE recovery_codes SET used_at = $3 WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`)).
+			WithArgs(int64(7), "h1", now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		ok, err := repo.UseRecoveryCode(context.Background(), 7, "h1", now)
+		if err != nil || !ok {
+			t.Fatalf("UseRecoveryCode = %v, %v", ok, err)
+		}
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		mock.ExpectExec(sqlRe(`UPDATE recovery_codes`)).WillReturnError(errors.New("boom"))
+
+		if _, err := repo.UseRecoveryCode(context.Background(), 7, "h1", now); err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+}
+
+func TestRepository_RecoveryCodesLeft(t *testing.T) {
+	t.Parallel()
+
+	repo, mock := newRepo(t)
+
+	mock.ExpectQuery(sqlRe(`SELECT count(*) FROM recovery_codes WHERE user_id = $1 AND used_at IS NULL`)).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(8))
+
+	n, err := repo.RecoveryCodesLeft(context.Background(), 7)
+	if err != nil || n != 8 {
+		t.Fatalf("RecoveryCodesLeft = %d, %v", n, err)
+	}
+}
+
+func TestRepository_CreateChallenge(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	c := &domain.Challenge{UserID: 7, TokenHash: "hash", DeviceName: "laptop", ExpiresAt: now.Add(5 * time.Minute)}
+
+	repo, mock := newRepo(t)
+
+	mock.ExpectExec(sqlRe(`DELETE FROM login_challenges WHERE expires_at <= $1`)).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(sqlRe(`INSERT INTO login_challenges (user_id, token_hash, device_name, expires_at)`)).
+		WithArgs(int64(7), "hash", "laptop", c.ExpiresAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(5)))
+
+	if err := repo.CreateChallenge(context.Background(), c, now); err != nil {
+		t.Fatalf("CreateChallenge error: %v", err)
+	}
+	if c.ID != 5 {
+		t.Fatalf("expected id 5, got %d", c.ID)
+	}
+}
+
+func TestRepository_UseChallenge(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("counts the attempt", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		mock.ExpectQuery(sqlRe(`UPDATE login_challenges SET attempts = attempts + 1 WHERE token_hash = $1 AND expires_at > $2 AND attempts < $3`)).
+			WithArgs("hash", now, 5).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "device_name", "attempts", "expires_at"}).
+				AddRow(int64(5), int64(7), "laptop", 1, now.Add(time.Minute)))
+
+		c, err := repo.UseChallenge(context.Background(), "hash", now, 5)
+		if err != nil {
+			t.Fatalf("UseChallenge error: %v", err)
+		}
+		if c.ID != 5 || c.UserID != 7 || c.DeviceName != "laptop" || c.Attempts != 1 {
+			t.Fatalf("unexpected challenge: %+v", c)
+		}
+	})
+
+	t.Run("expired or out of attempts -> ErrChallengeNotFound", func(t *testing.T) {
+		repo, mock := newRepo(t)
+
+		mock.ExpectQuery(sqlRe(`UPDATE login_challenges`)).
+			WithArgs("hash", now, 5).
+			WillReturnError(sql.ErrNoRows)
+
+		if _, err := repo.UseChallenge(context.Background(), "hash", now, 5); !errors.Is(err, domain.ErrChallengeNotFound) {
+			t.Fatalf("expected ErrChallengeNotFound, got %v", err)
+		}
+	})
+}
+
+func sqlRe(q string) string {
+	s := strings.TrimSpace(q)
+	s = strings.Join(strings.Fields(s), " ")
+	s = regexp.QuoteMeta(s)
+	s = strings.ReplaceAll(s, `\ `, `\s+`)
+	return s
+}
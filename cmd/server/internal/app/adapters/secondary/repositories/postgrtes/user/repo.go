@@ -4,12 +4,20 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	file "server/internal/app/domain/file_obj"
 	"server/internal/app/domain/user"
+	"server/internal/pkg/logger"
+	postgres "server/internal/pkg/postgres"
 	"server/internal/pkg/token"
+	"time"
 
-	"github.com/jackc/pgconn"
+	"go.uber.org/zap"
 )
 
+// usernameConstraint is the name postgres gave to the UNIQUE of users.username
+const usernameConstraint = "users_username_key"
+
 func (u *Repository) GetById(ctx context.Context, id int64) (*user.User, error) {
 	query := `
 		SELECT id, username, password_hash
@@ -51,53 +59,118 @@ func (u *Repository) CreateNewUser(ctx context.Context, newUser *user.User) (int
 	var id int64
 	err := u.db.QueryRowContext(ctx, query, newUser.Username, newUser.Password).Scan(&id)
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			if pgErr.Code == "23505" { // unique_violation
-				return 0, user.ErrUsernameAlreadyExists
-			}
+		if postgres.IsUniqueViolation(err, usernameConstraint) {
+			return 0, user.ErrUsernameAlreadyExists
 		}
 		return 0, err
 	}
 	return id, nil
 }
 
-func (u *Repository) AddTokens(ctx context.Context, userId int64, token *token.Tokens) error {
+// AddTokens starts a session of the user with the hashed refresh token, the row id is the session id
+func (u *Repository) AddTokens(ctx context.Context, session *user.Session, token *token.Tokens) (int64, error) {
 	query := `
-		INSERT INTO user_tokens (user_id, refresh_token, refresh_token_expires_at, revoked_at)
-		VALUES ($1, $2, $3, $4)`
-	_, err := u.db.ExecContext(ctx, query, userId, token.RefreshToken, token.RefreshTokenExpAt, nil)
+		INSERT INTO user_tokens (user_id, refresh_token, refresh_token_expires_at, device_name, ip, selector)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	var id int64
+	err := u.db.QueryRowContext(ctx, query,
+		session.UserID, token.RefreshToken, token.RefreshTokenExpAt, session.DeviceName, session.IP, token.Selector,
+	).Scan(&id)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	return nil
+	return id, nil
 }
 
-func (u *Repository) UpdateTokens(ctx context.Context, userId int64, token *token.Tokens) error {
-	query := `
+// UpdateTokens rotates the refresh token of the session, revoked sessions are not brought back.
+// The replaced token is kept as used so a replay of it is told apart from a wrong token. With prevHash
+// the session must still hold the verified token, a concurrent refresh with it gets ErrRefreshTokenReused.
+// The selector of the session is set on the token
+func (u *Repository) UpdateTokens(ctx context.Context, sessionId int64, ip, prevHash string, token *token.Tokens) error {
+	selectQuery := `
+		SELECT refresh_token, refresh_token_expires_at, selector
+		FROM user_tokens
+		WHERE id = $1 AND revoked_at IS NULL
+		FOR UPDATE`
+
+	usedQuery := `
+		INSERT INTO used_refresh_tokens (session_id, refresh_token, expires_at)
+		VALUES ($1, $2, $3)`
+
+	// used tokens are not needed once they would have expired anyway
+	pruneQuery := `DELETE FROM used_refresh_tokens WHERE session_id = $1 AND expires_at <= now()`
+
+	updateQuery := `
 		UPDATE user_tokens
 		SET 
 			refresh_token = $2,
 			refresh_token_expires_at = $3,
-			revoked_at = $4
-		WHERE user_id = $1`
-	_, err := u.db.ExecContext(ctx, query, userId, token.RefreshToken, token.RefreshTokenExpAt, nil)
-	if err != nil {
-		return err
+			ip = $4,
+			last_used_at = now()
+		WHERE id = $1`
+
+	return postgres.WithTx(ctx, u.db, func(tx *sql.Tx) error {
+		var (
+			current   string
+			expiresAt time.Time
+		)
+		err := tx.QueryRowContext(ctx, selectQuery, sessionId).Scan(&current, &expiresAt, &token.Selector)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return user.ErrRefreshTokenNotFound
+			}
+			return err
+		}
+		if prevHash != "" && current != prevHash {
+			return user.ErrRefreshTokenReused
+		}
+
+		if _, err := tx.ExecContext(ctx, usedQuery, sessionId, current, expiresAt); err != nil {
+			return fmt.Errorf("keep used token of session id=%d: %w", sessionId, err)
+		}
+		if _, err := tx.ExecContext(ctx, pruneQuery, sessionId); err != nil {
+			return fmt.Errorf("prune used tokens of session id=%d: %w", sessionId, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, updateQuery, sessionId, token.RefreshToken, token.RefreshTokenExpAt, ip); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// RefreshTokenUsed tells if the hash is of a rotated token of the session that has not expired
+func (u *Repository) RefreshTokenUsed(ctx context.Context, sessionId int64, hash string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM used_refresh_tokens
+			WHERE session_id = $1 AND refresh_token = $2 AND expires_at > now()
+		)`
+
+	var used bool
+	if err := u.db.QueryRowContext(ctx, query, sessionId, hash).Scan(&used); err != nil {
+		return false, err
 	}
-	return nil
+	return used, nil
 }
 
-func (u *Repository) GetTokens(ctx context.Context, userId int64) (*token.Tokens, error) {
+// GetTokens finds the session of a refresh token by its selector
+func (u *Repository) GetTokens(ctx context.Context, selector string) (*token.Tokens, error) {
 	query := `
-		SELECT refresh_token, refresh_token_expires_at, revoked_at
+		SELECT id, user_id, refresh_token, refresh_token_expires_at, revoked_at
 		FROM user_tokens
-		WHERE user_id = $1`
+		WHERE selector = $1`
 
-	var revokedAt sql.NullInt64
-	newToken := token.NewTokens(userId)
+	var revokedAt sql.NullTime
+	newToken := token.NewTokens(0)
+	newToken.Selector = selector
 
-	err := u.db.QueryRowContext(ctx, query, userId).Scan(&newToken.RefreshToken, &newToken.RefreshTokenExpAt, &revokedAt)
+	err := u.db.QueryRowContext(ctx, query, selector).Scan(
+		&newToken.SessionID, &newToken.UserId, &newToken.RefreshToken, &newToken.RefreshTokenExpAt, &revokedAt,
+	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, user.ErrRefreshTokenNotFound
@@ -109,3 +182,273 @@ func (u *Repository) GetTokens(ctx context.Context, userId int64) (*token.Tokens
 	}
 	return newToken, err
 }
+
+// GetSessions returns the sessions of the user that are neither revoked nor expired
+func (u *Repository) GetSessions(ctx context.Context, userId int64) ([]*user.Session, error) {
+	query := `
+		SELECT id, device_name, ip, created_at, last_used_at
+		FROM user_tokens
+		WHERE user_id = $1 AND revoked_at IS NULL AND refresh_token_expires_at > now()
+		ORDER BY last_used_at DESC, id DESC`
+
+	rows, err := u.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.Error("rows.Close() failed", zap.Error(err))
+		}
+	}()
+
+	list := make([]*user.Session, 0)
+	for rows.Next() {
+		s := &user.Session{UserID: userId}
+		if err := rows.Scan(&s.ID, &s.DeviceName, &s.IP, &s.CreatedAt, &s.LastUsedAt); err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// RevokeSession signs out one session of the user, sessions of other users are not found
+func (u *Repository) RevokeSession(ctx context.Context, userId, sessionId int64) error {
+	query := `
+		UPDATE user_tokens
+		SET revoked_at = now()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	res, err := u.db.ExecContext(ctx, query, sessionId, userId)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return user.ErrSessionNotFound
+	}
+	return nil
+}
+
+// RevokeSessions signs out every session of the user and returns how many were active
+func (u *Repository) RevokeSessions(ctx context.Context, userId int64) (int64, error) {
+	query := `
+		UPDATE user_tokens
+		SET revoked_at = now()
+		WHERE user_id = $1 AND revoked_at IS NULL`
+
+	res, err := u.db.ExecContext(ctx, query, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// UpdatePassword stores the new hash and revokes every other session of the user in one transaction,
+// the ids of the revoked sessions are returned
+func (u *Repository) UpdatePassword(ctx context.Context, userId int64, passwordHash string, keepSessionId int64) ([]int64, error) {
+	query := `UPDATE users SET password_hash = $2 WHERE id = $1`
+
+	revokeQuery := `
+		UPDATE user_tokens
+		SET revoked_at = now()
+		WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL
+		RETURNING id`
+
+	var revoked []int64
+
+	err := postgres.WithTx(ctx, u.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query, userId, passwordHash)
+		if err != nil {
+			return fmt.Errorf("update password of user id=%d: %w", userId, err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return user.ErrUserNotFound
+		}
+
+		rows, err := tx.QueryContext(ctx, revokeQuery, userId, keepSessionId)
+		if err != nil {
+			return fmt.Errorf("revoke tokens of user id=%d: %w", userId, err)
+		}
+		defer func() {
+			if err := rows.Close(); err != nil {
+				logger.Log.Error("rows.Close() failed", zap.Error(err))
+			}
+		}()
+
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			revoked = append(revoked, id)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return revoked, nil
+}
+
+func (u *Repository) UpdateUsername(ctx context.Context, userId int64, username string) error {
+	query := `UPDATE users SET username = $2 WHERE id = $1`
+
+	res, err := u.db.ExecContext(ctx, query, userId, username)
+	if err != nil {
+		if postgres.IsUniqueViolation(err, usernameConstraint) {
+			return user.ErrUsernameAlreadyExists
+		}
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return user.ErrUserNotFound
+	}
+	return nil
+}
+
+// orgItemTables hold the items which can belong to an organization, user_id of such an item is its creator
+var orgItemTables = []struct{ table, column string }{
+	{"account_data", "account_id"},
+	{"bank_data", "bank_id"},
+	{"text_data", "text_id"},
+}
+
+// Delete removes the user, ON DELETE CASCADE takes the rest of the rows. The last owner of an organization
+// is refused with ErrLastOrgOwner, organization items of the user, their attachments and files move to
+// another owner of the organization first. The objects of the user files are queued in file_operations,
+// it has no FK so the queue outlives the rows
+func (u *Repository) Delete(ctx context.Context, userId int64) ([]*file.Operation, error) {
+	// owners of the organizations of the user are locked, so two owners can't leave one at the same time
+	lockQuery := `
+		SELECT org_id
+		FROM org_members
+		WHERE role = 'owner' AND org_id IN (SELECT org_id FROM org_members WHERE user_id = $1)
+		FOR UPDATE`
+
+	lastOwnerQuery := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM org_members m
+			WHERE m.user_id = $1 AND m.role = 'owner'
+			  AND NOT EXISTS (
+				SELECT 1 FROM org_members o
+				WHERE o.org_id = m.org_id AND o.role = 'owner' AND o.user_id <> $1))`
+
+	moveItemQuery := `
+		UPDATE %[1]s d
+		SET user_id = (
+			SELECT o.user_id
+			FROM org_members o
+			WHERE o.org_id = d.org_id AND o.role = 'owner' AND o.user_id <> $1
+			ORDER BY o.created_at, o.user_id
+			LIMIT 1)
+		WHERE d.user_id = $1 AND d.org_id IS NOT NULL`
+
+	moveAttachmentQuery := `
+		UPDATE attachments a
+		SET user_id = d.user_id
+		FROM %[1]s d
+		WHERE a.%[2]s = d.id AND a.user_id = $1 AND d.org_id IS NOT NULL`
+
+	// files attached to organization items follow the attachments, the rest stays with the user
+	moveFileQuery := `
+		UPDATE file_data f
+		SET user_id = a.user_id
+		FROM attachments a
+		WHERE a.file_id = f.id AND f.user_id = $1 AND a.user_id <> $1`
+
+	queueQuery := `
+		INSERT INTO file_operations (operation, file_id, bucket_name, object_key)
+		SELECT $2, id, bucket_name, object_key
+		FROM file_data
+		WHERE user_id = $1
+		ON CONFLICT (operation, bucket_name, object_key) DO UPDATE SET next_attempt_at = now()
+		RETURNING id, file_id, bucket_name, object_key`
+
+	query := `DELETE FROM users WHERE id = $1`
+
+	var ops []*file.Operation
+
+	err := postgres.WithTx(ctx, u.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, lockQuery, userId); err != nil {
+			return fmt.Errorf("lock organization owners of user id=%d: %w", userId, err)
+		}
+
+		var lastOwner bool
+		if err := tx.QueryRowContext(ctx, lastOwnerQuery, userId).Scan(&lastOwner); err != nil {
+			return fmt.Errorf("check organization owners of user id=%d: %w", userId, err)
+		}
+		if lastOwner {
+			return user.ErrLastOrgOwner
+		}
+
+		for _, t := range orgItemTables {
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf(moveItemQuery, t.table), userId); err != nil {
+				return fmt.Errorf("move %s of user id=%d: %w", t.table, userId, err)
+			}
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf(moveAttachmentQuery, t.table, t.column), userId); err != nil {
+				return fmt.Errorf("move %s attachments of user id=%d: %w", t.table, userId, err)
+			}
+		}
+		if _, err := tx.ExecContext(ctx, moveFileQuery, userId); err != nil {
+			return fmt.Errorf("move attached files of user id=%d: %w", userId, err)
+		}
+
+		rows, err := tx.QueryContext(ctx, queueQuery, userId, file.OperationDelete)
+		if err != nil {
+			return fmt.Errorf("queue file objects of user id=%d: %w", userId, err)
+		}
+		defer func() {
+			if err := rows.Close(); err != nil {
+				logger.Log.Error("rows.Close() failed", zap.Error(err))
+			}
+		}()
+
+		for rows.Next() {
+			op := &file.Operation{Type: file.OperationDelete}
+			if err := rows.Scan(&op.ID, &op.FileID, &op.Storage.BucketName, &op.Storage.ObjectKey); err != nil {
+				return err
+			}
+			ops = append(ops, op)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, query, userId)
+		if err != nil {
+			return fmt.Errorf("delete user id=%d: %w", userId, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return user.ErrUserNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ops, nil
+}
@@ -9,11 +9,12 @@ import (
 	"testing"
 	"time"
 
+	file "server/internal/app/domain/file_obj"
 	domain "server/internal/app/domain/user"
 	"server/internal/pkg/token"
 
 	"github.com/DATA-DOG/go-sqlmock"
-	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 func mustMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
@@ -180,7 +181,7 @@ func TestRepository_CreateNewUser(t *testing.T) {
 			RETURNING id
 		`)
 
-		pgErr := &pgconn.PgError{Code: "23505"}
+		pgErr := &pgconn.PgError{Code: "23505", ConstraintName: "users_username_key"}
 
 		mock.ExpectQuery(q).
 			WithArgs("john", "hash").
@@ -246,131 +247,255 @@ func TestRepository_CreateNewUser(t *testing.T) {
 func TestRepository_AddTokens(t *testing.T) {
 	ctx := context.Background()
 
-	t.Run("exec error -> returned", func(t *testing.T) {
+	q := sqlRe(`
+		INSERT INTO user_tokens (user_id, refresh_token, refresh_token_expires_at, device_name, ip, selector)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`)
+
+	t.Run("query error -> returned", func(t *testing.T) {
 		db, mock := mustMockDB(t)
 		repo := &Repository{db: db}
 
 		dbErr := errors.New("insert tokens failed")
 
-		q := sqlRe(`
-			INSERT INTO user_tokens (user_id, refresh_token, refresh_token_expires_at, revoked_at)
-			VALUES ($1, $2, $3, $4)
-		`)
-
 		tk := token.NewTokens(7)
 		tk.RefreshToken = "rt"
 		tk.RefreshTokenExpAt = time.Now().Add(time.Hour)
+		tk.Selector = "sel"
 
-		mock.ExpectExec(q).
-			WithArgs(int64(7), "rt", tk.RefreshTokenExpAt, nil).
+		mock.ExpectQuery(q).
+			WithArgs(int64(7), "rt", tk.RefreshTokenExpAt, "laptop", "10.0.0.1", "sel").
 			WillReturnError(dbErr)
 
-		err := repo.AddTokens(ctx, 7, tk)
+		_, err := repo.AddTokens(ctx, domain.NewSession(7, "laptop", "", "10.0.0.1"), tk)
 		if !errors.Is(err, dbErr) {
 			t.Fatalf("expected dbErr, got: %v", err)
 		}
 	})
 
-	t.Run("ok -> nil", func(t *testing.T) {
+	t.Run("ok -> returns session id", func(t *testing.T) {
 		db, mock := mustMockDB(t)
 		repo := &Repository{db: db}
 
-		q := sqlRe(`
-			INSERT INTO user_tokens (user_id, refresh_token, refresh_token_expires_at, revoked_at)
-			VALUES ($1, $2, $3, $4)
-		`)
-
 		tk := token.NewTokens(7)
 		tk.RefreshToken = "rt"
 		tk.RefreshTokenExpAt = time.Now().Add(time.Hour)
+		tk.Selector = "sel"
 
-		mock.ExpectExec(q).
-			WithArgs(int64(7), "rt", tk.RefreshTokenExpAt, nil).
-			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(q).
+			WithArgs(int64(7), "rt", tk.RefreshTokenExpAt, "laptop", "10.0.0.1", "sel").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(31)))
 
-		if err := repo.AddTokens(ctx, 7, tk); err != nil {
+		id, err := repo.AddTokens(ctx, domain.NewSession(7, "laptop", "", "10.0.0.1"), tk)
+		if err != nil {
 			t.Fatalf("unexpected err: %v", err)
 		}
+		if id != 31 {
+			t.Fatalf("expected id=31, got %d", id)
+		}
 	})
 }
 
 func TestRepository_UpdateTokens(t *testing.T) {
 	ctx := context.Background()
 
-	t.Run("exec error -> returned", func(t *testing.T) {
-		db, mock := mustMockDB(t)
-		repo := &Repository{db: db}
-
-		dbErr := errors.New("update tokens failed")
-
-		q := sqlRe(`
-			UPDATE user_tokens
-			SET 
-				refresh_token = $2,
-				refresh_token_expires_at = $3,
-				revoked_at = $4
-			WHERE user_id = $1
-		`)
-
+	selectQ := sqlRe(`
+		SELECT refresh_token, refresh_token_expires_at, selector
+		FROM user_tokens
+		WHERE id = $1 AND revoked_at IS NULL
+		FOR UPDATE
+	`)
+	usedQ := sqlRe(`INSERT INTO used_refresh_tokens (session_id, refresh_token, expires_at) VALUES ($1, $2, $3)`)
+	pruneQ := sqlRe(`DELETE FROM used_refresh_tokens WHERE session_id = $1 AND expires_at <= now()`)
+	updateQ := sqlRe(`
+		UPDATE user_tokens
+		SET 
+			refresh_token = $2,
+			refresh_token_expires_at = $3,
+			ip = $4,
+			last_used_at = now()
+		WHERE id = $1
+	`)
+
+	oldExp := time.Now().Add(time.Hour)
+
+	newTokens := func() *token.Tokens {
 		tk := token.NewTokens(7)
 		tk.RefreshToken = "rt2"
 		tk.RefreshTokenExpAt = time.Now().Add(2 * time.Hour)
+		return tk
+	}
 
-		mock.ExpectExec(q).
-			WithArgs(int64(7), "rt2", tk.RefreshTokenExpAt, nil).
+	t.Run("exec error -> returned and rollback", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		dbErr := errors.New("update tokens failed")
+		tk := newTokens()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(selectQ).
+			WithArgs(int64(31)).
+			WillReturnRows(sqlmock.NewRows([]string{"refresh_token", "refresh_token_expires_at", "selector"}).AddRow("rt1", oldExp, "sel"))
+		mock.ExpectExec(usedQ).WithArgs(int64(31), "rt1", oldExp).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(pruneQ).WithArgs(int64(31)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(updateQ).
+			WithArgs(int64(31), "rt2", tk.RefreshTokenExpAt, "10.0.0.2").
 			WillReturnError(dbErr)
+		mock.ExpectRollback()
 
-		err := repo.UpdateTokens(ctx, 7, tk)
+		err := repo.UpdateTokens(ctx, 31, "10.0.0.2", "rt1", tk)
 		if !errors.Is(err, dbErr) {
 			t.Fatalf("expected dbErr, got: %v", err)
 		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("sql expectations: %v", err)
+		}
 	})
 
-	t.Run("ok -> nil", func(t *testing.T) {
+	t.Run("revoked or missing session -> ErrRefreshTokenNotFound", func(t *testing.T) {
 		db, mock := mustMockDB(t)
 		repo := &Repository{db: db}
 
-		q := sqlRe(`
-			UPDATE user_tokens
-			SET 
-				refresh_token = $2,
-				refresh_token_expires_at = $3,
-				revoked_at = $4
-			WHERE user_id = $1
-		`)
+		mock.ExpectBegin()
+		mock.ExpectQuery(selectQ).
+			WithArgs(int64(31)).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
 
-		tk := token.NewTokens(7)
-		tk.RefreshToken = "rt2"
-		tk.RefreshTokenExpAt = time.Now().Add(2 * time.Hour)
+		err := repo.UpdateTokens(ctx, 31, "10.0.0.2", "rt1", newTokens())
+		if !errors.Is(err, domain.ErrRefreshTokenNotFound) {
+			t.Fatalf("expected ErrRefreshTokenNotFound, got: %v", err)
+		}
+	})
 
-		mock.ExpectExec(q).
-			WithArgs(int64(7), "rt2", tk.RefreshTokenExpAt, nil).
+	t.Run("rotated by another refresh -> ErrRefreshTokenReused", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(selectQ).
+			WithArgs(int64(31)).
+			WillReturnRows(sqlmock.NewRows([]string{"refresh_token", "refresh_token_expires_at", "selector"}).AddRow("rt-other", oldExp, "sel"))
+		mock.ExpectRollback()
+
+		err := repo.UpdateTokens(ctx, 31, "10.0.0.2", "rt1", newTokens())
+		if !errors.Is(err, domain.ErrRefreshTokenReused) {
+			t.Fatalf("expected ErrRefreshTokenReused, got: %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("sql expectations: %v", err)
+		}
+	})
+
+	t.Run("ok -> old token kept as used", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		tk := newTokens()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(selectQ).
+			WithArgs(int64(31)).
+			WillReturnRows(sqlmock.NewRows([]string{"refresh_token", "refresh_token_expires_at", "selector"}).AddRow("rt1", oldExp, "sel"))
+		mock.ExpectExec(usedQ).WithArgs(int64(31), "rt1", oldExp).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(pruneQ).WithArgs(int64(31)).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(updateQ).
+			WithArgs(int64(31), "rt2", tk.RefreshTokenExpAt, "10.0.0.2").
 			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
 
-		if err := repo.UpdateTokens(ctx, 7, tk); err != nil {
+		if err := repo.UpdateTokens(ctx, 31, "10.0.0.2", "rt1", tk); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if tk.Selector != "sel" {
+			t.Fatalf("expected the selector of the session, got %q", tk.Selector)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("sql expectations: %v", err)
+		}
+	})
+
+	t.Run("no previous hash -> rotated without check", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		tk := newTokens()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(selectQ).
+			WithArgs(int64(31)).
+			WillReturnRows(sqlmock.NewRows([]string{"refresh_token", "refresh_token_expires_at", "selector"}).AddRow("rt1", oldExp, "sel"))
+		mock.ExpectExec(usedQ).WithArgs(int64(31), "rt1", oldExp).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(pruneQ).WithArgs(int64(31)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(updateQ).
+			WithArgs(int64(31), "rt2", tk.RefreshTokenExpAt, "10.0.0.2").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.UpdateTokens(ctx, 31, "10.0.0.2", "", tk); err != nil {
 			t.Fatalf("unexpected err: %v", err)
 		}
 	})
 }
 
+func TestRepository_RefreshTokenUsed(t *testing.T) {
+	ctx := context.Background()
+
+	q := sqlRe(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM used_refresh_tokens
+			WHERE session_id = $1 AND refresh_token = $2 AND expires_at > now()
+		)
+	`)
+
+	t.Run("used -> true", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		mock.ExpectQuery(q).
+			WithArgs(int64(31), "hash").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		used, err := repo.RefreshTokenUsed(ctx, 31, "hash")
+		if err != nil || !used {
+			t.Fatalf("RefreshTokenUsed = %v, %v", used, err)
+		}
+	})
+
+	t.Run("db error -> err", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		dbErr := errors.New("db down")
+		mock.ExpectQuery(q).WithArgs(int64(31), "hash").WillReturnError(dbErr)
+
+		if _, err := repo.RefreshTokenUsed(ctx, 31, "hash"); !errors.Is(err, dbErr) {
+			t.Fatalf("expected dbErr, got: %v", err)
+		}
+	})
+}
+
 func TestRepository_GetTokens(t *testing.T) {
 	ctx := context.Background()
 
+	q := sqlRe(`
+		SELECT id, user_id, refresh_token, refresh_token_expires_at, revoked_at
+		FROM user_tokens
+		WHERE selector = $1
+	`)
+
 	t.Run("no_rows -> ErrRefreshTokenNotFound", func(t *testing.T) {
 		db, mock := mustMockDB(t)
 		repo := &Repository{db: db}
 
-		q := sqlRe(`
-			SELECT refresh_token, refresh_token_expires_at, revoked_at
-			FROM user_tokens
-			WHERE user_id = $1
-		`)
-
 		mock.ExpectQuery(q).
-			WithArgs(int64(7)).
+			WithArgs("sel").
 			WillReturnError(sql.ErrNoRows)
 
-		tk, err := repo.GetTokens(ctx, 7)
+		tk, err := repo.GetTokens(ctx, "sel")
 		if !errors.Is(err, domain.ErrRefreshTokenNotFound) {
 			t.Fatalf("expected ErrRefreshTokenNotFound, got: %v", err)
 		}
@@ -385,17 +510,11 @@ func TestRepository_GetTokens(t *testing.T) {
 
 		dbErr := errors.New("select failed")
 
-		q := sqlRe(`
-			SELECT refresh_token, refresh_token_expires_at, revoked_at
-			FROM user_tokens
-			WHERE user_id = $1
-		`)
-
 		mock.ExpectQuery(q).
-			WithArgs(int64(7)).
+			WithArgs("sel").
 			WillReturnError(dbErr)
 
-		_, err := repo.GetTokens(ctx, 7)
+		_, err := repo.GetTokens(ctx, "sel")
 		if !errors.Is(err, dbErr) {
 			t.Fatalf("expected dbErr, got: %v", err)
 		}
@@ -407,26 +526,23 @@ func TestRepository_GetTokens(t *testing.T) {
 
 		exp := time.Now().Add(time.Hour)
 
-		q := sqlRe(`
-			SELECT refresh_token, refresh_token_expires_at, revoked_at
-			FROM user_tokens
-			WHERE user_id = $1
-		`)
-
-		rows := sqlmock.NewRows([]string{"refresh_token", "refresh_token_expires_at", "revoked_at"}).
-			AddRow("hashed-rt", exp, sql.NullInt64{Valid: false})
+		rows := sqlmock.NewRows([]string{"id", "user_id", "refresh_token", "refresh_token_expires_at", "revoked_at"}).
+			AddRow(int64(31), int64(7), "hashed-rt", exp, nil)
 
 		mock.ExpectQuery(q).
-			WithArgs(int64(7)).
+			WithArgs("sel").
 			WillReturnRows(rows)
 
-		tk, err := repo.GetTokens(ctx, 7)
+		tk, err := repo.GetTokens(ctx, "sel")
 		if err != nil {
 			t.Fatalf("unexpected err: %v", err)
 		}
 		if tk == nil || tk.RefreshToken != "hashed-rt" || tk.RefreshTokenExpAt.IsZero() {
 			t.Fatalf("unexpected tokens: %+v", tk)
 		}
+		if tk.UserId != 7 || tk.SessionID != 31 || tk.Selector != "sel" {
+			t.Fatalf("unexpected owner: user=%d session=%d selector=%q", tk.UserId, tk.SessionID, tk.Selector)
+		}
 		if tk.Revoked {
 			t.Fatalf("expected Revoked=false, got true")
 		}
@@ -438,20 +554,14 @@ func TestRepository_GetTokens(t *testing.T) {
 
 		exp := time.Now().Add(time.Hour)
 
-		q := sqlRe(`
-			SELECT refresh_token, refresh_token_expires_at, revoked_at
-			FROM user_tokens
-			WHERE user_id = $1
-		`)
-
-		rows := sqlmock.NewRows([]string{"refresh_token", "refresh_token_expires_at", "revoked_at"}).
-			AddRow("hashed-rt", exp, sql.NullInt64{Int64: 123, Valid: true})
+		rows := sqlmock.NewRows([]string{"id", "user_id", "refresh_token", "refresh_token_expires_at", "revoked_at"}).
+			AddRow(int64(31), int64(7), "hashed-rt", exp, time.Now())
 
 		mock.ExpectQuery(q).
-			WithArgs(int64(7)).
+			WithArgs("sel").
 			WillReturnRows(rows)
 
-		tk, err := repo.GetTokens(ctx, 7)
+		tk, err := repo.GetTokens(ctx, "sel")
 		if err != nil {
 			t.Fatalf("unexpected err: %v", err)
 		}
@@ -463,3 +573,308 @@ func TestRepository_GetTokens(t *testing.T) {
 		}
 	})
 }
+
+func TestRepository_GetSessions(t *testing.T) {
+	ctx := context.Background()
+
+	q := sqlRe(`
+		SELECT id, device_name, ip, created_at, last_used_at
+		FROM user_tokens
+		WHERE user_id = $1 AND revoked_at IS NULL AND refresh_token_expires_at > now()
+		ORDER BY last_used_at DESC, id DESC
+	`)
+
+	t.Run("ok -> active sessions", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		now := time.Now()
+		rows := sqlmock.NewRows([]string{"id", "device_name", "ip", "created_at", "last_used_at"}).
+			AddRow(int64(32), "phone", "10.0.0.2", now, now).
+			AddRow(int64(31), "laptop", "10.0.0.1", now.Add(-time.Hour), now.Add(-time.Minute))
+
+		mock.ExpectQuery(q).
+			WithArgs(int64(7)).
+			WillReturnRows(rows)
+
+		list, err := repo.GetSessions(ctx, 7)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if len(list) != 2 || list[0].ID != 32 || list[1].DeviceName != "laptop" || list[1].UserID != 7 {
+			t.Fatalf("unexpected sessions: %+v", list)
+		}
+	})
+
+	t.Run("db error -> returned", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		dbErr := errors.New("select failed")
+		mock.ExpectQuery(q).
+			WithArgs(int64(7)).
+			WillReturnError(dbErr)
+
+		if _, err := repo.GetSessions(ctx, 7); !errors.Is(err, dbErr) {
+			t.Fatalf("expected dbErr, got: %v", err)
+		}
+	})
+}
+
+func TestRepository_RevokeSession(t *testing.T) {
+	ctx := context.Background()
+
+	q := sqlRe(`
+		UPDATE user_tokens
+		SET revoked_at = now()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`)
+
+	t.Run("ok -> nil", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		mock.ExpectExec(q).
+			WithArgs(int64(31), int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		if err := repo.RevokeSession(ctx, 7, 31); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	})
+
+	t.Run("other user or revoked -> ErrSessionNotFound", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		mock.ExpectExec(q).
+			WithArgs(int64(31), int64(8)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		if err := repo.RevokeSession(ctx, 8, 31); !errors.Is(err, domain.ErrSessionNotFound) {
+			t.Fatalf("expected ErrSessionNotFound, got: %v", err)
+		}
+	})
+}
+
+func TestRepository_RevokeSessions(t *testing.T) {
+	ctx := context.Background()
+
+	q := sqlRe(`
+		UPDATE user_tokens
+		SET revoked_at = now()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`)
+
+	t.Run("ok -> count", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		mock.ExpectExec(q).
+			WithArgs(int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		n, err := repo.RevokeSessions(ctx, 7)
+		if err != nil || n != 3 {
+			t.Fatalf("RevokeSessions = %d, %v", n, err)
+		}
+	})
+
+	t.Run("db error -> err", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		dbErr := errors.New("db down")
+		mock.ExpectExec(q).
+			WithArgs(int64(7)).
+			WillReturnError(dbErr)
+
+		if _, err := repo.RevokeSessions(ctx, 7); !errors.Is(err, dbErr) {
+			t.Fatalf("expected dbErr, got: %v", err)
+		}
+	})
+}
+
+func TestRepository_UpdatePassword(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ok -> password updated and other sessions revoked", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlRe(`UPDATE users SET password_hash = $2 WHERE id = $1`)).
+			WithArgs(int64(7), "new-hash").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(sqlRe(`UPDATE user_tokens SET revoked_at = now() WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL RETURNING id`)).
+			WithArgs(int64(7), int64(31)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(30)).AddRow(int64(32)))
+		mock.ExpectCommit()
+
+		revoked, err := repo.UpdatePassword(ctx, 7, "new-hash", 31)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if len(revoked) != 2 || revoked[0] != 30 || revoked[1] != 32 {
+			t.Fatalf("unexpected revoked sessions: %v", revoked)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("sql expectations: %v", err)
+		}
+	})
+
+	t.Run("no user -> ErrUserNotFound and rollback", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlRe(`UPDATE users SET password_hash = $2 WHERE id = $1`)).
+			WithArgs(int64(7), "new-hash").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		if _, err := repo.UpdatePassword(ctx, 7, "new-hash", 31); !errors.Is(err, domain.ErrUserNotFound) {
+			t.Fatalf("expected ErrUserNotFound, got: %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("sql expectations: %v", err)
+		}
+	})
+}
+
+func TestRepository_UpdateUsername(t *testing.T) {
+	ctx := context.Background()
+	q := sqlRe(`UPDATE users SET username = $2 WHERE id = $1`)
+
+	t.Run("unique violation -> ErrUsernameAlreadyExists", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		mock.ExpectExec(q).
+			WithArgs(int64(7), "bob").
+			WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "users_username_key"})
+
+		if err := repo.UpdateUsername(ctx, 7, "bob"); !errors.Is(err, domain.ErrUsernameAlreadyExists) {
+			t.Fatalf("expected ErrUsernameAlreadyExists, got: %v", err)
+		}
+	})
+
+	t.Run("no user -> ErrUserNotFound", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		mock.ExpectExec(q).
+			WithArgs(int64(7), "bob").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		if err := repo.UpdateUsername(ctx, 7, "bob"); !errors.Is(err, domain.ErrUserNotFound) {
+			t.Fatalf("expected ErrUserNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("ok -> nil", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		mock.ExpectExec(q).
+			WithArgs(int64(7), "bob").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		if err := repo.UpdateUsername(ctx, 7, "bob"); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	})
+}
+
+func TestRepository_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	lock := sqlRe(`SELECT org_id FROM org_members WHERE role = 'owner'`)
+	lastOwner := sqlRe(`SELECT EXISTS (`)
+	moveItems := func(mock sqlmock.Sqlmock) {
+		for _, t := range []string{"account_data", "bank_data", "text_data"} {
+			mock.ExpectExec(sqlRe(`UPDATE ` + t + ` d SET user_id = (`)).
+				WithArgs(int64(7)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(sqlRe(`UPDATE attachments a SET user_id = d.user_id FROM ` + t + ` d`)).
+				WithArgs(int64(7)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectExec(sqlRe(`UPDATE file_data f SET user_id = a.user_id`)).
+			WithArgs(int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	queue := sqlRe(`INSERT INTO file_operations (operation, file_id, bucket_name, object_key)`)
+	del := sqlRe(`DELETE FROM users WHERE id = $1`)
+
+	t.Run("ok -> org items moved, file objects queued and user deleted", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(lock).WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectQuery(lastOwner).WithArgs(int64(7)).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		moveItems(mock)
+		mock.ExpectQuery(queue).
+			WithArgs(int64(7), file.OperationDelete).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "file_id", "bucket_name", "object_key"}).
+				AddRow(int64(1), int64(10), "bucket", "7/a").
+				AddRow(int64(2), int64(11), "bucket", "7/b"))
+		mock.ExpectExec(del).
+			WithArgs(int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		ops, err := repo.Delete(ctx, 7)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if len(ops) != 2 || ops[1].FileID != 11 || ops[1].Storage.ObjectKey != "7/b" || ops[1].Type != file.OperationDelete {
+			t.Fatalf("unexpected operations: %+v", ops)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("sql expectations: %v", err)
+		}
+	})
+
+	t.Run("last org owner -> ErrLastOrgOwner, nothing moved or deleted", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(lock).WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(lastOwner).WithArgs(int64(7)).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+
+		if _, err := repo.Delete(ctx, 7); !errors.Is(err, domain.ErrLastOrgOwner) {
+			t.Fatalf("expected ErrLastOrgOwner, got: %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("sql expectations: %v", err)
+		}
+	})
+
+	t.Run("no user -> ErrUserNotFound and nothing queued", func(t *testing.T) {
+		db, mock := mustMockDB(t)
+		repo := &Repository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(lock).WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(lastOwner).WithArgs(int64(7)).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		moveItems(mock)
+		mock.ExpectQuery(queue).
+			WithArgs(int64(7), file.OperationDelete).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "file_id", "bucket_name", "object_key"}))
+		mock.ExpectExec(del).
+			WithArgs(int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		if _, err := repo.Delete(ctx, 7); !errors.Is(err, domain.ErrUserNotFound) {
+			t.Fatalf("expected ErrUserNotFound, got: %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("sql expectations: %v", err)
+		}
+	})
+}
@@ -4,28 +4,64 @@ import (
 	"context"
 	"fmt"
 	"server/internal/app/adapters/primary/http-adapter"
+	"server/internal/app/adapters/primary/job-adapter"
 	"server/internal/app/adapters/primary/os-signal-adapter"
 	fileMinioRepository "server/internal/app/adapters/secondary/repositories/minio/file_obj"
 	accountPostgresRepository "server/internal/app/adapters/secondary/repositories/postgrtes/accout_obj"
+	attachmentPostgresRepository "server/internal/app/adapters/secondary/repositories/postgrtes/attachment"
+	auditPostgresRepository "server/internal/app/adapters/secondary/repositories/postgrtes/audit"
 	bankCardPostgresRepository "server/internal/app/adapters/secondary/repositories/postgrtes/bank_card_obj"
+	certPostgresRepository "server/internal/app/adapters/secondary/repositories/postgrtes/cert_obj"
+	emergencyPostgresRepository "server/internal/app/adapters/secondary/repositories/postgrtes/emergency"
 	filePostgresRepository "server/internal/app/adapters/secondary/repositories/postgrtes/file_obj"
+	folderPostgresRepository "server/internal/app/adapters/secondary/repositories/postgrtes/folder"
+	notificationPostgresRepository "server/internal/app/adapters/secondary/repositories/postgrtes/notification"
+	orgPostgresRepository "server/internal/app/adapters/secondary/repositories/postgrtes/org"
+	revocationPostgresRepository "server/internal/app/adapters/secondary/repositories/postgrtes/revocation"
+	secretLinkPostgresRepository "server/internal/app/adapters/secondary/repositories/postgrtes/secret_link"
+	sharePostgresRepository "server/internal/app/adapters/secondary/repositories/postgrtes/share"
+	sshKeyPostgresRepository "server/internal/app/adapters/secondary/repositories/postgrtes/ssh_key_obj"
 	textPostgresRepository "server/internal/app/adapters/secondary/repositories/postgrtes/text_obj"
+	twoFactorPostgresRepository "server/internal/app/adapters/secondary/repositories/postgrtes/two_factor"
 	userPostgresReporitory "server/internal/app/adapters/secondary/repositories/postgrtes/user"
+	"server/internal/app/config"
+	emergencyDomain "server/internal/app/domain/emergency"
+	fileDomain "server/internal/app/domain/file_obj"
+	notificationDomain "server/internal/app/domain/notification"
+	secretLinkDomain "server/internal/app/domain/secret_link"
+	twoFactorDomain "server/internal/app/domain/two_factor"
 	accountUsecase "server/internal/app/usecases/account_obj"
+	attachmentUsecase "server/internal/app/usecases/attachment"
+	auditUsecase "server/internal/app/usecases/audit"
 	bankCardUsecase "server/internal/app/usecases/bank_card_obj"
+	certUsecase "server/internal/app/usecases/cert_obj"
+	emergencyUsecase "server/internal/app/usecases/emergency"
 	fileUsecase "server/internal/app/usecases/file_obj"
+	folderUsecase "server/internal/app/usecases/folder"
+	notificationUsecase "server/internal/app/usecases/notification"
+	orgUsecase "server/internal/app/usecases/org"
+	revocationUsecase "server/internal/app/usecases/revocation"
+	secretLinkUsecase "server/internal/app/usecases/secret_link"
+	shareUsecase "server/internal/app/usecases/share"
+	sshKeyUsecase "server/internal/app/usecases/ssh_key_obj"
 	textUsecase "server/internal/app/usecases/text_obj"
+	toolsUsecase "server/internal/app/usecases/tools"
+	twoFactorUsecase "server/internal/app/usecases/two_factor"
 	userUsecase "server/internal/app/usecases/user"
 	"server/internal/pkg/graceful"
 	"server/internal/pkg/minio"
 	postgres "server/internal/pkg/postgres"
+	"server/internal/pkg/pwned"
 )
 
 type App struct {
 	HttpAdapter     *http_adapter.HttpAdapter
 	OSSignalAdapter *os_signal_adapter.OsSignalAdapter
+	JobAdapter      *job_adapter.JobAdapter
 	PostgresAdapter *postgres.DatabaseAdapter
 	MinioAdapter    *minio.MinioAdapter
+
+	fileUseCase *fileUsecase.FileObj
 }
 
 func New() (*App, error) {
@@ -45,20 +81,98 @@ func New() (*App, error) {
 	// os signals
 	osSignalAdapter := os_signal_adapter.New()
 
+	// access to items and sign-ins are written to the hash-chained audit log
+	auditUseCase := auditUsecase.New(auditPostgresRepository.New(p.DB))
+
+	fileObjUseCase := fileUsecase.New(filePostgresRepository.New(p.DB), fileMinioRepository.New(m.CL), auditUseCase, config.App.GetUploadTimeout())
+
+	notificationUseCase := notificationUsecase.New(notificationPostgresRepository.New(p.DB))
+
+	secretLinkUseCase := secretLinkUsecase.New(secretLinkPostgresRepository.New(p.DB), secretLinkDomain.Options{
+		DefaultTTL: config.App.GetSecretLinkDefaultTTL(),
+		MaxTTL:     config.App.GetSecretLinkMaxTTL(),
+		MaxViews:   config.App.GetSecretLinkMaxViews(),
+		MaxSize:    config.App.GetSecretLinkMaxSize(),
+	})
+
+	// access tokens revoked by logouts, checked by the JWT middleware from memory
+	revocationList := revocationUsecase.New(revocationPostgresRepository.New(p.DB), config.App.GetRevocationSyncInterval())
+
+	breaches, err := breachChecker()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached passwords dataset: %v", err)
+	}
+
+	// background jobs
+	jobs := []job_adapter.Job{
+		job_adapter.NewOutboxJob(fileObjUseCase, config.App.GetOutboxInterval(), fileDomain.OutboxOptions{
+			StaleAfter: config.App.GetOutboxStaleAfter(),
+			Lease:      config.App.GetOutboxLease(),
+			BatchSize:  config.App.GetOutboxBatchSize(),
+		}),
+		job_adapter.NewReminderJob(notificationUseCase, config.App.GetRemindersInterval(), notificationDomain.ReminderOptions{
+			LeadTime: config.App.GetRemindersLeadTime(),
+		}),
+		job_adapter.NewSecretLinkPurgeJob(secretLinkUseCase, config.App.GetSecretLinkPurgeInterval()),
+		job_adapter.NewRevocationPruneJob(revocationList, config.App.GetRevocationPruneInterval()),
+		job_adapter.NewAuditVerifyJob(auditUseCase, config.App.GetAuditVerifyInterval()),
+	}
+	if config.App.GetReconcileEnabled() {
+		jobs = append(jobs, job_adapter.NewReconcileJob(fileObjUseCase, config.App.GetReconcileInterval(), reconcileOptions()))
+	}
+	jobAdapter := job_adapter.New(jobs...)
+
+	// items of other users are opened through shares
+	shareUseCase := shareUsecase.New(sharePostgresRepository.New(p.DB))
+
+	// trusted contacts open personal items after the waiting period, shares are checked first
+	emergencyUseCase := emergencyUsecase.New(emergencyPostgresRepository.New(p.DB), shareUseCase, emergencyDomain.Options{
+		DefaultWait: config.App.GetEmergencyDefaultWait(),
+		MinWait:     config.App.GetEmergencyMinWait(),
+		MaxWait:     config.App.GetEmergencyMaxWait(),
+	})
+
+	// items of organizations are opened by member roles
+	orgUseCase := orgUsecase.New(orgPostgresRepository.New(p.DB))
+
+	// optional TOTP asked after the password, logins of enrolled users go through a challenge
+	twoFactorUseCase := twoFactorUsecase.New(twoFactorPostgresRepository.New(p.DB), userPostgresReporitory.New(p.DB), twoFactorDomain.Options{
+		Issuer:        config.App.GetTwoFactorIssuer(),
+		ChallengeTTL:  config.App.GetTwoFactorChallengeTTL(),
+		MaxAttempts:   config.App.GetTwoFactorMaxAttempts(),
+		RecoveryCodes: config.App.GetTwoFactorRecoveryCodes(),
+		MaxFailures:   config.App.GetTwoFactorMaxFailures(),
+		Lockout:       config.App.GetTwoFactorLockout(),
+	}, auditUseCase)
+
 	// http
 	httpAdapter := http_adapter.New(&http_adapter.Srv{
-		UserUseCase:        userUsecase.New(userPostgresReporitory.New(p.DB)),
-		AccountObjUseCase:  accountUsecase.New(accountPostgresRepository.New(p.DB)),
-		BankCardObjUseCase: bankCardUsecase.New(bankCardPostgresRepository.New(p.DB)),
-		TextObjUseCase:     textUsecase.New(textPostgresRepository.New(p.DB)),
-		FileObjUseCase:     fileUsecase.New(filePostgresRepository.New(p.DB), fileMinioRepository.New(m.CL)),
+		UserUseCase:         userUsecase.New(userPostgresReporitory.New(p.DB), fileObjUseCase, auditUseCase, revocationList, twoFactorUseCase),
+		AccountObjUseCase:   accountUsecase.New(accountPostgresRepository.New(p.DB), breaches, emergencyUseCase, orgUseCase, auditUseCase),
+		BankCardObjUseCase:  bankCardUsecase.New(bankCardPostgresRepository.New(p.DB), emergencyUseCase, orgUseCase, auditUseCase),
+		TextObjUseCase:      textUsecase.New(textPostgresRepository.New(p.DB), emergencyUseCase, orgUseCase, auditUseCase),
+		FileObjUseCase:      fileObjUseCase,
+		SSHKeyObjUseCase:    sshKeyUsecase.New(sshKeyPostgresRepository.New(p.DB), auditUseCase),
+		CertObjUseCase:      certUsecase.New(certPostgresRepository.New(p.DB), auditUseCase),
+		AttachmentUseCase:   attachmentUsecase.New(attachmentPostgresRepository.New(p.DB), fileObjUseCase, emergencyUseCase, orgUseCase),
+		FolderUseCase:       folderUsecase.New(folderPostgresRepository.New(p.DB), orgUseCase),
+		ShareUseCase:        shareUseCase,
+		OrgUseCase:          orgUseCase,
+		EmergencyUseCase:    emergencyUseCase,
+		SecretLinkUseCase:   secretLinkUseCase,
+		ToolsUseCase:        toolsUsecase.New(),
+		NotificationUseCase: notificationUseCase,
+		AuditUseCase:        auditUseCase,
+		TwoFactorUseCase:    twoFactorUseCase,
 	})
 
 	return &App{
 		HttpAdapter:     httpAdapter,
 		OSSignalAdapter: osSignalAdapter,
+		JobAdapter:      jobAdapter,
 		PostgresAdapter: p,
 		MinioAdapter:    m,
+		fileUseCase:     fileObjUseCase,
 	}, nil
 }
 
@@ -66,6 +180,7 @@ func (a App) Start() error {
 	gr := graceful.New(
 		graceful.NewProcess(a.OSSignalAdapter),
 		graceful.NewProcess(a.HttpAdapter),
+		graceful.NewProcess(a.JobAdapter),
 		graceful.NewProcess(a.PostgresAdapter),
 		graceful.NewProcess(a.MinioAdapter),
 	)
@@ -77,3 +192,30 @@ func (a App) Start() error {
 
 	return nil
 }
+
+// Reconcile runs a single file storage reconciliation pass, used by the -reconcile admin command
+func (a App) Reconcile(ctx context.Context) (*fileDomain.ReconcileReport, error) {
+	report, err := a.fileUseCase.Reconcile(ctx, reconcileOptions())
+	if report != nil {
+		job_adapter.LogReconcileReport(report)
+	}
+	return report, err
+}
+
+func reconcileOptions() fileDomain.ReconcileOptions {
+	return fileDomain.ReconcileOptions{
+		Bucket:      config.App.GetMinioBucketName(),
+		Repair:      config.App.GetReconcileRepair(),
+		GracePeriod: config.App.GetReconcileGracePeriod(),
+	}
+}
+
+// breachChecker opens the local pwned passwords dataset, the check is off when no path is configured
+func breachChecker() (accountUsecase.BreachChecker, error) {
+	path := config.App.GetBreachesDatasetPath()
+	if path == "" {
+		return nil, nil
+	}
+
+	return pwned.Open(path)
+}
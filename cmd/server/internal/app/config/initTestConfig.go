@@ -22,6 +22,10 @@ func InitTestConfig() {
 			Encryption: Encryption{
 				AccountObjKey:  "1234567890abcdef",
 				BankCardObjKey: "1234567890abcdef",
+				SSHKeyObjKey:   "1234567890abcdef",
+				CertObjKey:     "1234567890abcdef",
+				CustomFieldKey: "1234567890abcdef",
+				TwoFactorKey:   "1234567890abcdef",
 			},
 		}
 	})
@@ -47,6 +47,8 @@ type parsedFlags struct {
 	configPath string
 	debugMode  bool
 	debugSet   bool
+	reconcile  bool
+	repair     bool
 }
 
 func parseFlags() parsedFlags {
@@ -58,11 +60,16 @@ func parseFlags() parsedFlags {
 
 	debugModeFlg := flag.Bool("t", false, "debug mode")
 
+	reconcileFlg := flag.Bool("reconcile", false, "run file storage reconciliation once and exit")
+	repairFlg := flag.Bool("repair", false, "remove orphan objects and dangling file metadata (with -reconcile)")
+
 	flag.Parse()
 
 	out.serverAddr = *serverAddrFlg
 	out.dsn = *connPathFlag
 	out.configPath = *configPath
+	out.reconcile = *reconcileFlg
+	out.repair = *repairFlg
 
 	if flag.Lookup("t") != nil {
 		out.debugSet = containsArg(os.Args, "-t") || containsArg(os.Args, "--t")
@@ -85,6 +92,10 @@ func applyFlags(cfg *AppConfig, f parsedFlags) {
 	if f.debugSet {
 		cfg.Core.DebugMode = f.debugMode
 	}
+	if f.reconcile {
+		cfg.Reconcile.RunOnce = true
+		cfg.Reconcile.Repair = f.repair
+	}
 }
 
 func containsArg(args []string, target string) bool {
@@ -108,6 +119,9 @@ func applyENV(cfg *AppConfig) {
 	if configPath, ok := os.LookupEnv("CONFIG_FILE"); ok {
 		cfg.Core.ConfigPath = configPath
 	}
+	if path, ok := os.LookupEnv("PWNED_PASSWORDS_PATH"); ok {
+		cfg.Breaches.DatasetPath = path
+	}
 	if dbg, ok := os.LookupEnv("DEBUG_MODE"); ok {
 		if dbg == "1" || dbg == "true" || dbg == "TRUE" {
 			cfg.Core.DebugMode = true
@@ -65,6 +65,21 @@ func (cfg *AppConfig) GetRefreshTokenLength() int {
 	return cfg.JWT.Refresh.Length
 }
 
+func (cfg *AppConfig) GetRefreshTokenSecret() string {
+	if cfg.JWT.Refresh.Secret == "" {
+		return cfg.JWT.Secret
+	}
+	return cfg.JWT.Refresh.Secret
+}
+
+func (cfg *AppConfig) GetRevocationSyncInterval() time.Duration {
+	return cfg.JWT.Revocation.SyncInterval
+}
+
+func (cfg *AppConfig) GetRevocationPruneInterval() time.Duration {
+	return cfg.JWT.Revocation.PruneInterval
+}
+
 // ---- MiniO ----
 
 func (cfg *AppConfig) GetMinioEndpoint() string {
@@ -103,9 +118,159 @@ func (cfg *AppConfig) GetAccountObjEncryptionKey() string {
 func (cfg *AppConfig) GetBankCardObjEncryptionKey() string {
 	return cfg.Encryption.BankCardObjKey
 }
+func (cfg *AppConfig) GetSSHKeyObjEncryptionKey() string {
+	return cfg.Encryption.SSHKeyObjKey
+}
+func (cfg *AppConfig) GetCertObjEncryptionKey() string {
+	return cfg.Encryption.CertObjKey
+}
+func (cfg *AppConfig) GetCustomFieldEncryptionKey() string {
+	return cfg.Encryption.CustomFieldKey
+}
+func (cfg *AppConfig) GetTwoFactorEncryptionKey() string {
+	return cfg.Encryption.TwoFactorKey
+}
+
+// ---- Reconcile ----
+
+func (cfg *AppConfig) GetReconcileEnabled() bool {
+	return cfg.Reconcile.Enabled
+}
+
+func (cfg *AppConfig) GetReconcileInterval() time.Duration {
+	return cfg.Reconcile.Interval
+}
+
+func (cfg *AppConfig) GetReconcileRepair() bool {
+	return cfg.Reconcile.Repair
+}
+
+func (cfg *AppConfig) GetReconcileGracePeriod() time.Duration {
+	return cfg.Reconcile.GracePeriod
+}
+
+func (cfg *AppConfig) GetReconcileRunOnce() bool {
+	return cfg.Reconcile.RunOnce
+}
+
+// ---- Outbox ----
+
+func (cfg *AppConfig) GetOutboxInterval() time.Duration {
+	return cfg.Outbox.Interval
+}
+
+func (cfg *AppConfig) GetOutboxStaleAfter() time.Duration {
+	return cfg.Outbox.StaleAfter
+}
+
+func (cfg *AppConfig) GetOutboxLease() time.Duration {
+	return cfg.Outbox.Lease
+}
+
+func (cfg *AppConfig) GetOutboxBatchSize() int {
+	return cfg.Outbox.BatchSize
+}
+
+// ---- Breaches ----
+
+func (cfg *AppConfig) GetBreachesDatasetPath() string {
+	return cfg.Breaches.DatasetPath
+}
+
+// ---- Health ----
+
+func (cfg *AppConfig) GetHealthWeakEntropyBits() float64 {
+	return cfg.Health.WeakEntropyBits
+}
+
+func (cfg *AppConfig) GetHealthMaxPasswordAge() time.Duration {
+	return cfg.Health.MaxPasswordAge
+}
+
+// ---- Audit ----
+
+func (cfg *AppConfig) GetAuditVerifyInterval() time.Duration {
+	return cfg.Audit.VerifyInterval
+}
+
+// ---- Reminders ----
+
+func (cfg *AppConfig) GetRemindersInterval() time.Duration {
+	return cfg.Reminders.Interval
+}
+
+func (cfg *AppConfig) GetRemindersLeadTime() time.Duration {
+	return cfg.Reminders.LeadTime
+}
+
+// ---- Emergency access ----
+
+func (cfg *AppConfig) GetEmergencyDefaultWait() time.Duration {
+	return cfg.Emergency.DefaultWait
+}
+
+func (cfg *AppConfig) GetEmergencyMinWait() time.Duration {
+	return cfg.Emergency.MinWait
+}
+
+func (cfg *AppConfig) GetEmergencyMaxWait() time.Duration {
+	return cfg.Emergency.MaxWait
+}
+
+// ---- Secret links ----
+
+func (cfg *AppConfig) GetSecretLinkDefaultTTL() time.Duration {
+	return cfg.SecretLink.DefaultTTL
+}
+
+func (cfg *AppConfig) GetSecretLinkMaxTTL() time.Duration {
+	return cfg.SecretLink.MaxTTL
+}
+
+func (cfg *AppConfig) GetSecretLinkMaxViews() int {
+	return cfg.SecretLink.MaxViews
+}
+
+func (cfg *AppConfig) GetSecretLinkMaxSize() int {
+	return cfg.SecretLink.MaxSize
+}
+
+func (cfg *AppConfig) GetSecretLinkPurgeInterval() time.Duration {
+	return cfg.SecretLink.PurgeInterval
+}
+
+// ---- Two-factor authentication ----
+
+func (cfg *AppConfig) GetTwoFactorIssuer() string {
+	return cfg.TwoFactor.Issuer
+}
+
+func (cfg *AppConfig) GetTwoFactorChallengeTTL() time.Duration {
+	return cfg.TwoFactor.ChallengeTTL
+}
+
+func (cfg *AppConfig) GetTwoFactorMaxAttempts() int {
+	return cfg.TwoFactor.MaxAttempts
+}
+
+func (cfg *AppConfig) GetTwoFactorRecoveryCodes() int {
+	return cfg.TwoFactor.RecoveryCodes
+}
+
+func (cfg *AppConfig) GetTwoFactorMaxFailures() int {
+	return cfg.TwoFactor.MaxFailures
+}
+
+func (cfg *AppConfig) GetTwoFactorLockout() time.Duration {
+	return cfg.TwoFactor.Lockout
+}
 
 // ---- File Types
 
+func (cfg *AppConfig) GetUploadTimeout() time.Duration {
+	return cfg.Uploads.Timeout
+}
+
 func (cfg *AppConfig) AllowedMimeSet() map[string]struct{} {
 	m := make(map[string]struct{}, len(cfg.Uploads.AllowedMimeTypes))
 	for _, t := range cfg.Uploads.AllowedMimeTypes {
@@ -11,11 +11,26 @@ type AppConfig struct {
 	Minio      Minio      `yaml:"minio"`
 	Encryption Encryption `yaml:"encryption"`
 	Uploads    Uploads    `yaml:"uploads"`
+	Reconcile  Reconcile  `yaml:"reconcile"`
+	Outbox     Outbox     `yaml:"outbox"`
+	Breaches   Breaches   `yaml:"breaches"`
+	Health     Health     `yaml:"health"`
+	Reminders  Reminders  `yaml:"reminders"`
+	Emergency  Emergency  `yaml:"emergency"`
+	SecretLink SecretLink `yaml:"secret_links"`
+	TwoFactor  TwoFactor  `yaml:"two_factor"`
+	Audit      Audit      `yaml:"audit"`
 }
 
 type Encryption struct {
 	AccountObjKey  string `yaml:"account_obj_key"`
 	BankCardObjKey string `yaml:"bank_card_obj_key"`
+	SSHKeyObjKey   string `yaml:"ssh_key_obj_key"`
+	CertObjKey     string `yaml:"cert_obj_key"`
+	// CustomFieldKey encrypts the hidden custom fields of every object type
+	CustomFieldKey string `yaml:"custom_field_key"`
+	// TwoFactorKey encrypts the TOTP secrets of the users' own second factor
+	TwoFactorKey string `yaml:"two_factor_key"`
 }
 
 type Core struct {
@@ -49,11 +64,20 @@ type JWT struct {
 	JWTLifetime time.Duration `yaml:"jwt_lifetime"`
 	Issuer      string        `yaml:"issuer"`
 	Refresh     RefreshToken  `yaml:"refresh"`
+	Revocation  Revocation    `yaml:"revocation"`
+}
+
+type Revocation struct {
+	// SyncInterval is how often the cached revocation list picks up entries of other instances
+	SyncInterval  time.Duration `yaml:"sync_interval"`
+	PruneInterval time.Duration `yaml:"prune_interval"`
 }
 
 type RefreshToken struct {
 	Lifetime time.Duration `yaml:"lifetime"`
 	Length   int           `yaml:"length"`
+	// Secret keys the HMAC of stored verifiers, the JWT secret is used when it is empty
+	Secret string `yaml:"secret"`
 }
 
 type Logger struct {
@@ -72,4 +96,71 @@ type Minio struct {
 
 type Uploads struct {
 	AllowedMimeTypes []string `yaml:"allowed_mime_types"`
+	// Timeout bounds the storage write of an upload, the outbox leaves uploads alone for that long
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+type Reconcile struct {
+	Enabled     bool          `yaml:"enabled"`
+	Interval    time.Duration `yaml:"interval"`
+	Repair      bool          `yaml:"repair"`
+	GracePeriod time.Duration `yaml:"grace_period"`
+	RunOnce     bool          `yaml:"-"`
+}
+
+type Outbox struct {
+	Interval   time.Duration `yaml:"interval"`
+	StaleAfter time.Duration `yaml:"stale_after"`
+	Lease      time.Duration `yaml:"lease"`
+	BatchSize  int           `yaml:"batch_size"`
+}
+
+type Breaches struct {
+	// DatasetPath is a local HIBP pwned passwords SHA-1 file or range directory, empty disables the check
+	DatasetPath string `yaml:"dataset_path"`
+}
+
+type Health struct {
+	WeakEntropyBits float64       `yaml:"weak_entropy_bits"`
+	MaxPasswordAge  time.Duration `yaml:"max_password_age"`
+}
+
+type Audit struct {
+	// VerifyInterval is how often the hash chain of the whole log is walked
+	VerifyInterval time.Duration `yaml:"verify_interval"`
+}
+
+type Reminders struct {
+	Interval time.Duration `yaml:"interval"`
+	// LeadTime is how long before expires_at a reminder is created
+	LeadTime time.Duration `yaml:"lead_time"`
+}
+
+type Emergency struct {
+	// DefaultWait is the waiting period of contacts added without one
+	DefaultWait time.Duration `yaml:"default_wait"`
+	MinWait     time.Duration `yaml:"min_wait"`
+	MaxWait     time.Duration `yaml:"max_wait"`
+}
+
+type SecretLink struct {
+	// DefaultTTL is the lifetime of links created without one
+	DefaultTTL time.Duration `yaml:"default_ttl"`
+	MaxTTL     time.Duration `yaml:"max_ttl"`
+	MaxViews   int           `yaml:"max_views"`
+	// MaxSize limits the ciphertext in bytes
+	MaxSize       int           `yaml:"max_size"`
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+}
+
+type TwoFactor struct {
+	// Issuer names the service in authenticator apps
+	Issuer string `yaml:"issuer"`
+	// ChallengeTTL is how long a login waits for the code after the password
+	ChallengeTTL  time.Duration `yaml:"challenge_ttl"`
+	MaxAttempts   int           `yaml:"max_attempts"`
+	RecoveryCodes int           `yaml:"recovery_codes"`
+	// MaxFailures counts wrong codes of a user across challenges and account changes, reaching it locks codes for Lockout
+	MaxFailures int           `yaml:"max_failures"`
+	Lockout     time.Duration `yaml:"lockout"`
 }
@@ -12,4 +12,9 @@ var (
 	ErrEmptyServiceName           = errors.New("service name is empty")
 	ErrFailedCreateAccount        = errors.New("failed to create account")
 	ErrFailedUpdateAccount        = errors.New("failed to update account")
+	ErrFailedDeleteAccount        = errors.New("failed to delete account")
+	ErrInvalidTOTPSecret          = errors.New("invalid totp secret")
+	ErrTOTPNotConfigured          = errors.New("totp is not configured for account")
+	ErrBreachCheckFailed          = errors.New("failed to check password against breaches")
+	ErrInvalidCustomFields        = errors.New("invalid custom fields")
 )
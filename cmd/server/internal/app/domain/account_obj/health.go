@@ -0,0 +1,40 @@
+package account_obj
+
+import "time"
+
+const (
+	IssueWeak     = "weak"
+	IssueReused   = "reused"
+	IssueOld      = "old"
+	IssueBreached = "breached"
+)
+
+type HealthOptions struct {
+	// WeakEntropyBits is the estimated strength below which a password is weak
+	WeakEntropyBits float64
+	// MaxPasswordAge is how long a password may stay unchanged, 0 disables the check
+	MaxPasswordAge time.Duration
+}
+
+// HealthItem is an account with at least one issue, the password itself is never part of it
+type HealthItem struct {
+	AccountId   int64
+	ServiceName string
+	UserName    string
+	Issues      []string
+	EntropyBits float64
+	PasswordAge time.Duration
+	// ReuseGroup is shared by the accounts with the same password, 0 when the password is unique
+	ReuseGroup int
+}
+
+type HealthReport struct {
+	// Score is the share of accounts without issues, 0..100
+	Score    int
+	Total    int
+	Weak     int
+	Reused   int
+	Old      int
+	Breached int
+	Items    []*HealthItem
+}
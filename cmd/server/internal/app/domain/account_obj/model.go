@@ -1,9 +1,48 @@
 package account_obj
 
+import (
+	"server/internal/app/domain/custom_field"
+	"server/internal/app/domain/share"
+	"time"
+)
+
 type Account struct {
 	ServiceName string
 	UserName    string
 	Password    string
-	UserId      int64
-	AccountId   int64
+	// TOTP is a base32 secret or an otpauth:// URI, empty when 2FA is not set up
+	TOTP string
+	// BreachCount is how many times the password was seen in known breaches
+	BreachCount int
+	// PasswordChangedAt is zero when unknown
+	PasswordChangedAt time.Time
+	// ExpiresAt is zero when the account does not expire
+	ExpiresAt time.Time
+	// CustomFields are extra user-defined fields in the order they were entered
+	CustomFields []custom_field.Field
+	UserId       int64
+	AccountId    int64
+	// OrgID is set for items owned by an organization, zero for personal items
+	OrgID int64
+	// Shared is set when the item belongs to another user and is opened through a share
+	Shared *share.Access
+}
+
+func (a *Account) Compromised() bool {
+	return a.BreachCount > 0
+}
+
+// BreachReport lists the accounts of a user whose passwords are known to be breached
+type BreachReport struct {
+	// Enabled is false when no breach dataset is configured, Compromised then holds the last known flags
+	Enabled     bool
+	Checked     int
+	Compromised []*Account
+}
+
+// TOTPCode is the one-time code of an account at the moment of the request
+type TOTPCode struct {
+	Code      string
+	Period    time.Duration
+	Remaining time.Duration
 }
@@ -0,0 +1,16 @@
+package attachment
+
+import "errors"
+
+var (
+	ErrInvalidUserID = errors.New("invalid user id")
+	ErrInvalidItem   = errors.New("invalid item type")
+	ErrInvalidItemID = errors.New("invalid item id")
+	ErrInvalidFileID = errors.New("invalid file id")
+
+	ErrItemNotFound         = errors.New("item not found")
+	ErrFileNotFound         = errors.New("file not found")
+	ErrAttachmentNotFound   = errors.New("attachment not found")
+	ErrEmptyAttachmentsList = errors.New("empty attachments list")
+	ErrAlreadyAttached      = errors.New("file is already attached to the item")
+)
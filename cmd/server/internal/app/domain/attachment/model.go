@@ -0,0 +1,34 @@
+package attachment
+
+import "time"
+
+// item types a file can be attached to
+const (
+	ItemAccount  = "account"
+	ItemBankCard = "card"
+	ItemText     = "text"
+)
+
+// Item points to an account, a bank card or a text
+type Item struct {
+	Type string
+	ID   int64
+}
+
+// Owner is who an item belongs to, OrgID is 0 for personal items
+type Owner struct {
+	UserID int64
+	OrgID  int64
+}
+
+// Attachment is a file linked to an item, file fields are taken from file_data
+type Attachment struct {
+	ID          int64
+	UserID      int64
+	Item        Item
+	FileID      int64
+	Title       string
+	SizeBytes   int64
+	ContentType string
+	CreatedAt   time.Time
+}
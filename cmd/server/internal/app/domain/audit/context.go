@@ -0,0 +1,21 @@
+package audit
+
+import "context"
+
+// Client is who sent the request, usecases only see it through the context
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+type clientKey struct{}
+
+func WithClient(ctx context.Context, c Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, c)
+}
+
+// ClientFrom returns the client of the request, empty outside of HTTP requests
+func ClientFrom(ctx context.Context) Client {
+	c, _ := ctx.Value(clientKey{}).(Client)
+	return c
+}
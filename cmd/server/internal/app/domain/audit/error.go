@@ -0,0 +1,13 @@
+package audit
+
+import "errors"
+
+var (
+	ErrInvalidUserID = errors.New("invalid user id")
+	ErrInvalidAction = errors.New("unknown audit action")
+	ErrInvalidRange  = errors.New("from must be before to")
+	ErrInvalidLimit  = errors.New("limit is out of the allowed range")
+
+	ErrEmptyLog    = errors.New("no audit events")
+	ErrNotVerified = errors.New("audit chain has not been verified yet")
+)
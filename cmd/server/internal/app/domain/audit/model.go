@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"strconv"
+	"time"
+)
+
+// actions written to the log
+const (
+	ActionLogin       = "login"
+	ActionLoginFailed = "login_failed"
+	ActionRefresh     = "refresh"
+	ActionItemRead    = "item_read"
+	// ActionDecrypt is a secret derived from an item without opening it, like a TOTP code
+	ActionDecrypt  = "decrypt"
+	ActionCreate   = "create"
+	ActionUpdate   = "update"
+	ActionDelete   = "delete"
+	ActionDownload = "download"
+
+	ActionPasswordChange = "password_change"
+	ActionUsernameChange = "username_change"
+	// ActionAccountDelete stays in the log after the user is gone, the log has no FK to users
+	ActionAccountDelete = "account_delete"
+	ActionSessionRevoke = "session_revoke"
+	ActionLogout        = "logout"
+	// ActionLogoutAll signs out every device and revokes the access tokens issued so far
+	ActionLogoutAll = "logout_all"
+	// ActionRefreshReuse is a replay of a rotated refresh token, the session is revoked because of it
+	ActionRefreshReuse = "refresh_reuse"
+
+	ActionTwoFactorEnable  = "two_factor_enable"
+	ActionTwoFactorDisable = "two_factor_disable"
+	// ActionRecoveryCodeUse is a login or a 2FA change confirmed by a one-time recovery code
+	ActionRecoveryCodeUse    = "recovery_code_use"
+	ActionRecoveryCodesRenew = "recovery_codes_renew"
+)
+
+// item types of events, the same names are used by shares
+const (
+	ItemAccount  = "account"
+	ItemBankCard = "card"
+	ItemText     = "text"
+	ItemFile     = "file"
+	ItemSSHKey   = "ssh"
+	ItemCert     = "cert"
+)
+
+// Event is an entry of the append-only log, every entry carries the hash of the
+// previous one so changing or removing an earlier entry breaks the chain
+type Event struct {
+	ID int64
+	// UserID is who acted, zero for failed logins of unknown users
+	UserID int64
+	// OwnerID is the owner of the item, it lets owners see reads through shares
+	OwnerID   int64
+	Action    string
+	ItemType  string
+	ItemID    int64
+	IP        string
+	UserAgent string
+	Details   string
+	CreatedAt time.Time
+
+	PrevHash []byte
+	Hash     []byte
+}
+
+// Digest hashes the event together with the hash of the previous event, fields are
+// length-prefixed so moving bytes between them changes the digest
+func (e *Event) Digest(prev []byte) []byte {
+	h := sha256.New()
+	h.Write(prev)
+
+	for _, f := range []string{
+		strconv.FormatInt(e.UserID, 10),
+		strconv.FormatInt(e.OwnerID, 10),
+		e.Action,
+		e.ItemType,
+		strconv.FormatInt(e.ItemID, 10),
+		e.IP,
+		e.UserAgent,
+		e.Details,
+		e.CreatedAt.UTC().Format(time.RFC3339Nano),
+	} {
+		var n [4]byte
+		binary.BigEndian.PutUint32(n[:], uint32(len(f)))
+		h.Write(n[:])
+		h.Write([]byte(f))
+	}
+
+	return h.Sum(nil)
+}
+
+// Seal links the event to the previous one
+func (e *Event) Seal(prev []byte) {
+	e.PrevHash = prev
+	e.Hash = e.Digest(prev)
+}
+
+// Follows reports whether the event is intact and directly follows prev
+func (e *Event) Follows(prev []byte) bool {
+	return bytes.Equal(e.PrevHash, prev) && bytes.Equal(e.Hash, e.Digest(prev))
+}
+
+// Filter of the audit query, zero fields do not filter
+type Filter struct {
+	// UserID is the caller, events where the caller acted or owns the item are returned
+	UserID   int64
+	Action   string
+	ItemType string
+	ItemID   int64
+	From     time.Time
+	To       time.Time
+	// BeforeID pages back from the newest events
+	BeforeID int64
+	Limit    int
+}
+
+// Verification is the result of walking the chain
+type Verification struct {
+	Checked int64
+	Valid   bool
+	// BrokenAt is the first event that does not follow the one before it
+	BrokenAt   int64
+	VerifiedAt time.Time
+}
+
+func ValidAction(a string) bool {
+	switch a {
+	case ActionLogin, ActionLoginFailed, ActionRefresh, ActionItemRead, ActionDecrypt,
+		ActionCreate, ActionUpdate, ActionDelete, ActionDownload,
+		ActionPasswordChange, ActionUsernameChange, ActionAccountDelete, ActionSessionRevoke,
+		ActionLogout, ActionLogoutAll, ActionRefreshReuse,
+		ActionTwoFactorEnable, ActionTwoFactorDisable, ActionRecoveryCodeUse, ActionRecoveryCodesRenew:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package bank_card_obj
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	BrandVisa       = "visa"
+	BrandMastercard = "mastercard"
+	BrandAmex       = "amex"
+	BrandDiscover   = "discover"
+	BrandJCB        = "jcb"
+	BrandDiners     = "diners"
+	BrandUnionPay   = "unionpay"
+	BrandMaestro    = "maestro"
+	BrandMir        = "mir"
+	BrandUnknown    = "unknown"
+)
+
+// NormalizeNumber drops spaces and dashes users type between digit groups
+func NormalizeNumber(number string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, number)
+}
+
+// IsDigits reports whether s is a non-empty string of ASCII digits
+func IsDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidLuhn checks the card number checksum
+func ValidLuhn(number string) bool {
+	if !IsDigits(number) {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		d := int(number[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+// Expired reports whether the card is past the last day of its expiry month
+func Expired(month, year int, now time.Time) bool {
+	return !now.Before(ExpiryEnd(month, year, now.Location()))
+}
+
+// ExpiryEnd is the first moment of the month after expiry
+func ExpiryEnd(month, year int, loc *time.Location) time.Time {
+	return time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, loc)
+}
+
+// NormalizeExpiryYear turns two digit years into full ones ("27" -> 2027)
+func NormalizeExpiryYear(year int) int {
+	if year >= 0 && year < 100 {
+		return 2000 + year
+	}
+	return year
+}
+
+// DetectBrand guesses the payment network from the IIN prefix
+func DetectBrand(number string) string {
+	n := NormalizeNumber(number)
+	if !IsDigits(n) {
+		return BrandUnknown
+	}
+
+	prefix := func(l int) int {
+		if len(n) < l {
+			return -1
+		}
+		v, _ := strconv.Atoi(n[:l])
+		return v
+	}
+
+	switch p2, p3, p4 := prefix(2), prefix(3), prefix(4); {
+	case p2 == 34 || p2 == 37:
+		return BrandAmex
+	case p4 >= 2200 && p4 <= 2204:
+		return BrandMir
+	case (p2 >= 51 && p2 <= 55) || (p4 >= 2221 && p4 <= 2720):
+		return BrandMastercard
+	case p4 == 6011 || p2 == 65 || (p3 >= 644 && p3 <= 649):
+		return BrandDiscover
+	case p4 >= 3528 && p4 <= 3589:
+		return BrandJCB
+	case p2 == 36 || p2 == 38 || (p3 >= 300 && p3 <= 305):
+		return BrandDiners
+	case p2 == 62:
+		return BrandUnionPay
+	case p2 == 50 || (p2 >= 56 && p2 <= 58) || p2 == 67:
+		return BrandMaestro
+	case n[0] == '4':
+		return BrandVisa
+	default:
+		return BrandUnknown
+	}
+}
+
+// MaskNumber keeps only the last four digits of the number
+func MaskNumber(number string) string {
+	n := NormalizeNumber(number)
+	if len(n) < 4 {
+		return "****"
+	}
+	return "**** " + n[len(n)-4:]
+}
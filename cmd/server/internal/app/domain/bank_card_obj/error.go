@@ -5,11 +5,18 @@ import "errors"
 var (
 	ErrFaildeCreateBankCardObject = errors.New("fail create card object")
 
-	ErrInvalidUserID = errors.New("invalid user id")
-	ErrInvalidCardID = errors.New("invalid card id")
-	ErrEmptyBankName = errors.New("bank name is empty")
-	ErrEmptyPID      = errors.New("pid is empty")
+	ErrInvalidUserID       = errors.New("invalid user id")
+	ErrInvalidCardID       = errors.New("invalid card id")
+	ErrEmptyBankName       = errors.New("bank name is empty")
+	ErrEmptyCardNumber     = errors.New("card number is empty")
+	ErrInvalidCardNumber   = errors.New("invalid card number")
+	ErrInvalidExpiry       = errors.New("invalid card expiry date")
+	ErrCardExpired         = errors.New("card is expired")
+	ErrInvalidCVV          = errors.New("invalid cvv")
+	ErrInvalidPIN          = errors.New("invalid pin")
+	ErrInvalidCustomFields = errors.New("invalid custom fields")
 
 	ErrFailedUpdateBankCard = errors.New("failed to update card object")
+	ErrFailedDeleteBankCard = errors.New("failed to delete card object")
 	ErrBankCardNotFound     = errors.New("bank card not found")
 )
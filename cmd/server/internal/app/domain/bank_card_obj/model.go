@@ -1,8 +1,38 @@
 package bank_card_obj
 
+import (
+	"server/internal/app/domain/custom_field"
+	"server/internal/app/domain/share"
+	"time"
+)
+
 type BankCard struct {
-	Bank   string
-	Pid    string
-	UserId int64
-	CardId int64
+	Bank        string
+	Number      string
+	HolderName  string
+	ExpiryMonth int
+	ExpiryYear  int
+	CVV         string
+	PIN         string
+	Notes       string
+	// ExpiresAt defaults to the end of the expiry month
+	ExpiresAt time.Time
+	// CustomFields are extra user-defined fields in the order they were entered
+	CustomFields []custom_field.Field
+	UserId       int64
+	CardId       int64
+	// OrgID is set for items owned by an organization, zero for personal items
+	OrgID int64
+	// Shared is set when the item belongs to another user and is opened through a share
+	Shared *share.Access
+}
+
+// Brand is detected from the card number prefix
+func (c *BankCard) Brand() string {
+	return DetectBrand(c.Number)
+}
+
+// Masked hides everything except the last four digits, e.g. "**** 4242"
+func (c *BankCard) Masked() string {
+	return MaskNumber(c.Number)
 }
@@ -0,0 +1,16 @@
+package cert_obj
+
+import "errors"
+
+var (
+	ErrCertificateNotFound     = errors.New("certificate not found")
+	ErrEmptyCertificatesList   = errors.New("empty certificates list")
+	ErrInvalidUserID           = errors.New("invalid user id")
+	ErrInvalidCertificateID    = errors.New("invalid certificate id")
+	ErrEmptyTitle              = errors.New("title is empty")
+	ErrInvalidCertificate      = errors.New("invalid certificate")
+	ErrInvalidPrivateKey       = errors.New("invalid private key")
+	ErrKeyMismatch             = errors.New("private key does not match the certificate")
+	ErrInvalidExpiryWindow     = errors.New("invalid expiry window")
+	ErrFailedCreateCertificate = errors.New("failed to create certificate")
+)
@@ -0,0 +1,34 @@
+package cert_obj
+
+import "time"
+
+type Certificate struct {
+	Title string
+	// Certificate is the PEM chain, leaf first
+	Certificate string
+	// PrivateKey is the PKCS #8 PEM key of the leaf, empty when it was not uploaded
+	PrivateKey string
+
+	Subject      string
+	Issuer       string
+	SANs         []string
+	SerialNumber string
+	Fingerprint  string
+	NotBefore    time.Time
+	NotAfter     time.Time
+
+	CreatedAt time.Time
+	UserId    int64
+	CertId    int64
+}
+
+// ImportRequest is an uploaded PEM bundle, PrivateKey may be empty or a part of Bundle
+type ImportRequest struct {
+	UserId     int64
+	Title      string
+	Bundle     string
+	PrivateKey string
+}
+
+// MaxExpiryWindow bounds the window of the soon-to-expire list
+const MaxExpiryWindow = 5 * 365 * 24 * time.Hour
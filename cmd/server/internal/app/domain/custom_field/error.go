@@ -0,0 +1,12 @@
+package custom_field
+
+import "errors"
+
+var (
+	ErrTooManyFields      = errors.New("too many custom fields")
+	ErrInvalidFieldName   = errors.New("custom field name is empty or too long")
+	ErrDuplicateFieldName = errors.New("duplicate custom field name")
+	ErrInvalidFieldKind   = errors.New("invalid custom field type")
+	ErrFieldValueTooLong  = errors.New("custom field value is too long")
+	ErrInvalidFieldURL    = errors.New("invalid custom field url")
+)
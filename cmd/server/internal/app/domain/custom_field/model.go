@@ -0,0 +1,89 @@
+package custom_field
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Kind tells how the value of a field is stored and shown
+type Kind string
+
+const (
+	KindText Kind = "text"
+	// KindHidden values are encrypted at rest and masked by clients
+	KindHidden Kind = "hidden"
+	KindURL    Kind = "url"
+)
+
+const (
+	MaxFields   = 32
+	MaxNameLen  = 64
+	MaxValueLen = 4096
+)
+
+// Field is a user-defined key/value pair attached to an item, the order of fields is kept
+type Field struct {
+	Name  string
+	Kind  Kind
+	Value string
+}
+
+func (k Kind) Valid() bool {
+	switch k {
+	case KindText, KindHidden, KindURL:
+		return true
+	default:
+		return false
+	}
+}
+
+// Normalize trims field names, defaults an empty kind to text and validates the list
+func Normalize(fields []Field) error {
+	if len(fields) > MaxFields {
+		return fmt.Errorf("%w: %d fields, max %d", ErrTooManyFields, len(fields), MaxFields)
+	}
+
+	seen := make(map[string]struct{}, len(fields))
+
+	for i := range fields {
+		f := &fields[i]
+
+		f.Name = strings.TrimSpace(f.Name)
+		if f.Kind == "" {
+			f.Kind = KindText
+		}
+
+		if f.Name == "" || len(f.Name) > MaxNameLen {
+			return fmt.Errorf("%w: field #%d", ErrInvalidFieldName, i+1)
+		}
+
+		if _, ok := seen[f.Name]; ok {
+			return fmt.Errorf("%w: %q", ErrDuplicateFieldName, f.Name)
+		}
+		seen[f.Name] = struct{}{}
+
+		if !f.Kind.Valid() {
+			return fmt.Errorf("%w: %q", ErrInvalidFieldKind, f.Kind)
+		}
+
+		if len(f.Value) > MaxValueLen {
+			return fmt.Errorf("%w: %q", ErrFieldValueTooLong, f.Name)
+		}
+
+		if f.Kind == KindURL && !validURL(f.Value) {
+			return fmt.Errorf("%w: %q", ErrInvalidFieldURL, f.Name)
+		}
+	}
+
+	return nil
+}
+
+// validURL accepts absolute URLs with a host, e.g. https://example.com/login
+func validURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	return u.Scheme != "" && u.Host != ""
+}
@@ -0,0 +1,23 @@
+package emergency
+
+import "errors"
+
+var (
+	ErrInvalidUserID     = errors.New("invalid user id")
+	ErrInvalidContactID  = errors.New("invalid contact id")
+	ErrInvalidUsername   = errors.New("username is empty")
+	ErrInvalidAccessType = errors.New("access type must be view or takeover")
+	ErrInvalidWait       = errors.New("waiting period is out of the allowed range")
+	ErrSelfContact       = errors.New("user can not be their own emergency contact")
+
+	ErrUserNotFound    = errors.New("user not found")
+	ErrContactNotFound = errors.New("emergency contact not found")
+	ErrAlreadyContact  = errors.New("user is already an emergency contact")
+	ErrEmptyContacts   = errors.New("empty emergency contacts list")
+	ErrEmptyVault      = errors.New("vault has no items")
+
+	ErrAlreadyRequested = errors.New("access is already requested")
+	ErrNotRequested     = errors.New("access is not requested")
+	// ErrAccessNotGranted is returned while the waiting period is running or after a rejection
+	ErrAccessNotGranted = errors.New("emergency access is not granted")
+)
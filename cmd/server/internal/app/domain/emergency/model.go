@@ -0,0 +1,83 @@
+package emergency
+
+import (
+	"time"
+
+	"server/internal/app/domain/share"
+)
+
+// access types, takeover also lets the contact change items
+const (
+	AccessView     = "view"
+	AccessTakeover = "takeover"
+)
+
+// statuses of a trusted contact, a new contact is idle until it requests access
+const (
+	StatusIdle      = "idle"
+	StatusRequested = "requested"
+	StatusApproved  = "approved"
+	StatusRejected  = "rejected"
+)
+
+// Contact is a user the grantor trusts to open the vault after a waiting period
+type Contact struct {
+	ID          int64
+	GrantorID   int64
+	GrantorName string
+	GranteeID   int64
+	GranteeName string
+	AccessType  string
+	Wait        time.Duration
+	Status      string
+	// RequestedAt is zero until the contact requests access
+	RequestedAt time.Time
+	CreatedAt   time.Time
+}
+
+// AccessAt is when a pending request turns into access, zero without a request
+func (c *Contact) AccessAt() time.Time {
+	if c.Status != StatusRequested && c.Status != StatusApproved {
+		return time.Time{}
+	}
+	return c.RequestedAt.Add(c.Wait)
+}
+
+// Granted reports whether the contact may open the vault: the grantor approved
+// the request or did not reject it during the waiting period
+func (c *Contact) Granted(now time.Time) bool {
+	switch c.Status {
+	case StatusApproved:
+		return true
+	case StatusRequested:
+		return !now.Before(c.AccessAt())
+	default:
+		return false
+	}
+}
+
+// Permission is the share permission the access type maps to
+func (c *Contact) Permission() string {
+	if c.AccessType == AccessTakeover {
+		return share.PermWrite
+	}
+	return share.PermRead
+}
+
+// Item is a personal item of the grantor listed to a contact with access
+type Item struct {
+	Type  string
+	ID    int64
+	Title string
+}
+
+type Options struct {
+	// DefaultWait is used when a contact is added without a waiting period
+	DefaultWait time.Duration
+	MinWait     time.Duration
+	MaxWait     time.Duration
+}
+
+func ValidAccessType(t string) bool {
+	return t == AccessView || t == AccessTakeover
+}
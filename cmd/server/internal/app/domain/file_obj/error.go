@@ -10,4 +10,5 @@ var (
 	ErrNegativeSizeBytes = errors.New("size_bytes must be >= 0")
 	ErrFileNotFound      = errors.New("file not found")
 	ErrEmptyFilesList    = errors.New("empty files list")
+	ErrObjectNotFound    = errors.New("object not found in storage")
 )
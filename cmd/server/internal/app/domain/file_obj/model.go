@@ -27,6 +27,12 @@ func NewStorageRef(bucketName, objectKey string) (StorageRef, error) {
 	}, nil
 }
 
+const (
+	// StatusPending file_data row is written, object upload is not confirmed yet
+	StatusPending = "pending"
+	StatusReady   = "ready"
+)
+
 type File struct {
 	ID          int64
 	UserID      int64
@@ -35,7 +41,10 @@ type File struct {
 	SizeBytes   int64
 	ContentType string
 	ETag        string
+	Status      string
 	CreatedAt   time.Time
+	// ExpiresAt is zero when the file does not expire
+	ExpiresAt time.Time
 }
 
 func NewFile(
@@ -60,6 +69,7 @@ func NewFile(
 		SizeBytes:   sizeBytes,
 		ContentType: strings.TrimSpace(contentType),
 		ETag:        "",
+		Status:      StatusPending,
 		CreatedAt:   time.Time{},
 	}, nil
 }
@@ -0,0 +1,50 @@
+package file_obj
+
+import "time"
+
+const (
+	OperationUpload = "upload"
+	OperationDelete = "delete"
+)
+
+// Operation is a pending object storage change recorded in the same transaction as file_data.
+// It is removed once storage and metadata agree again.
+type Operation struct {
+	ID            int64
+	Type          string
+	FileID        int64
+	Storage       StorageRef
+	SizeBytes     int64
+	Attempts      int
+	LastError     string
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+}
+
+type OutboxOptions struct {
+	// StaleAfter leaves fresh operations to the request that created them,
+	// uploads are never claimed before their upload timeout and lease ran out
+	StaleAfter time.Duration
+	// Lease hides claimed operations from other workers while they are processed
+	Lease     time.Duration
+	BatchSize int
+}
+
+// RetryDelay is an exponential backoff capped at one hour
+func (o *Operation) RetryDelay() time.Duration {
+	const maxDelay = time.Hour
+
+	attempts := o.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	if attempts > 12 {
+		return maxDelay
+	}
+
+	delay := time.Second << attempts
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
@@ -0,0 +1,52 @@
+package file_obj
+
+import (
+	"strings"
+	"time"
+)
+
+// ObjectInfo describes an object as it is stored in the object storage
+type ObjectInfo struct {
+	Key          string
+	SizeBytes    int64
+	ETag         string
+	LastModified time.Time
+}
+
+// Mismatch is a file_data row whose object exists but differs in size or ETag
+type Mismatch struct {
+	File   *File
+	Object ObjectInfo
+}
+
+type ReconcileOptions struct {
+	Bucket string
+	// Repair removes orphan objects and dangling file_data rows
+	Repair bool
+	// GracePeriod skips objects and rows younger than this, their operations may still be in flight
+	GracePeriod time.Duration
+}
+
+type ReconcileReport struct {
+	Bucket        string
+	StartedAt     time.Time
+	FinishedAt    time.Time
+	ObjectsTotal  int
+	FilesTotal    int
+	OrphanObjects []ObjectInfo
+	DanglingFiles []*File
+	Mismatches    []Mismatch
+
+	RemovedObjects int
+	RemovedFiles   int
+}
+
+// Clean reports whether storage and metadata are consistent
+func (r *ReconcileReport) Clean() bool {
+	return len(r.OrphanObjects) == 0 && len(r.DanglingFiles) == 0 && len(r.Mismatches) == 0
+}
+
+// SameETag compares ETags ignoring quotes and case, S3 compatible storages are not consistent about both
+func SameETag(a, b string) bool {
+	return strings.EqualFold(strings.Trim(a, `"`), strings.Trim(b, `"`))
+}
@@ -0,0 +1,17 @@
+package folder
+
+import "errors"
+
+var (
+	ErrInvalidUserID   = errors.New("invalid user id")
+	ErrInvalidFolderID = errors.New("invalid folder id")
+	ErrInvalidItem     = errors.New("invalid item type")
+	ErrInvalidItemID   = errors.New("invalid item id")
+	ErrEmptyName       = errors.New("empty folder name")
+
+	ErrFolderNotFound = errors.New("folder not found")
+	ErrItemNotFound   = errors.New("item not found")
+	ErrFolderExists   = errors.New("folder with this name already exists")
+	ErrFolderCycle    = errors.New("folder can not be moved into itself or its subfolder")
+	ErrEmptyFolders   = errors.New("empty folders list")
+)
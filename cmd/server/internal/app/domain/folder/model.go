@@ -0,0 +1,52 @@
+package folder
+
+import "time"
+
+// object types a folder can hold
+const (
+	ItemAccount  = "account"
+	ItemBankCard = "card"
+	ItemText     = "text"
+	ItemFile     = "file"
+	ItemSSHKey   = "ssh"
+	ItemCert     = "cert"
+)
+
+// Folder is a user folder, ParentID is nil for folders in the root
+type Folder struct {
+	ID        int64
+	UserID    int64
+	ParentID  *int64
+	Name      string
+	CreatedAt time.Time
+}
+
+// Item is an object of any type placed in a folder
+type Item struct {
+	Type  string
+	ID    int64
+	Title string
+}
+
+// Owner is who an object belongs to, OrgID is 0 for personal objects
+type Owner struct {
+	UserID int64
+	OrgID  int64
+}
+
+// Contents is what a folder holds, Folder is nil for the root
+type Contents struct {
+	Folder  *Folder
+	Folders []*Folder
+	Items   []Item
+}
+
+// ValidItemType reports whether objects of the type can be placed in folders
+func ValidItemType(t string) bool {
+	switch t {
+	case ItemAccount, ItemBankCard, ItemText, ItemFile, ItemSSHKey, ItemCert:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,15 @@
+package notification
+
+import "errors"
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+
+	ErrInvalidUserID         = errors.New("invalid user id")
+	ErrInvalidNotificationID = errors.New("invalid notification id")
+	ErrInvalidLeadTime       = errors.New("reminder lead time must be positive")
+
+	ErrFailedGenerateReminders = errors.New("failed to generate reminders")
+	ErrFailedGetNotifications  = errors.New("failed to get notifications")
+	ErrFailedMarkRead          = errors.New("failed to mark notifications as read")
+)
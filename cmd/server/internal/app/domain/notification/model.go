@@ -0,0 +1,40 @@
+package notification
+
+import "time"
+
+// object types a reminder can point to
+const (
+	ObjectAccount  = "account"
+	ObjectBankCard = "card"
+	ObjectText     = "text"
+	ObjectFile     = "file"
+	ObjectCert     = "cert"
+)
+
+// Notification reminds the user about an object that expires soon or has already expired
+type Notification struct {
+	ID         int64
+	UserID     int64
+	ObjectType string
+	ObjectID   int64
+	// Title is the service, bank or file name of the object at the moment the reminder was created
+	Title     string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	// ReadAt is zero while the notification is unread
+	ReadAt time.Time
+}
+
+func (n *Notification) Read() bool {
+	return !n.ReadAt.IsZero()
+}
+
+// Expired reports whether the object is past its expiry date
+func (n *Notification) Expired(now time.Time) bool {
+	return !now.Before(n.ExpiresAt)
+}
+
+type ReminderOptions struct {
+	// LeadTime is how long before expiry a reminder is created
+	LeadTime time.Duration
+}
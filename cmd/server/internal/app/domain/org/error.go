@@ -0,0 +1,23 @@
+package org
+
+import "errors"
+
+var (
+	ErrInvalidUserID   = errors.New("invalid user id")
+	ErrInvalidOrgID    = errors.New("invalid organization id")
+	ErrEmptyName       = errors.New("organization name is empty")
+	ErrInvalidRole     = errors.New("role must be owner, admin, editor or viewer")
+	ErrInvalidUsername = errors.New("username is empty")
+
+	ErrOrgNotFound    = errors.New("organization not found")
+	ErrUserNotFound   = errors.New("user not found")
+	ErrNotMember      = errors.New("user is not a member of the organization")
+	ErrAlreadyMember  = errors.New("user is already a member of the organization")
+	ErrEmptyOrgs      = errors.New("empty organizations list")
+	ErrEmptyOrgItems  = errors.New("organization has no items")
+	ErrLastOwner      = errors.New("organization must keep at least one owner")
+	ErrFailedCreation = errors.New("failed to create organization")
+
+	// ErrForbidden is returned when the role of the member does not allow the action
+	ErrForbidden = errors.New("role does not allow this action")
+)
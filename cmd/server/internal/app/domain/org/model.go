@@ -0,0 +1,95 @@
+package org
+
+import "time"
+
+// member roles, from the most to the least privileged
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+// actions checked against the role of a member
+const (
+	ActionRead          = "read"
+	ActionCreate        = "create"
+	ActionUpdate        = "update"
+	ActionDelete        = "delete"
+	ActionManageMembers = "manage_members"
+	ActionDeleteOrg     = "delete_org"
+)
+
+// item types an organization can own
+const (
+	ItemAccount  = "account"
+	ItemBankCard = "card"
+	ItemText     = "text"
+)
+
+// permissions of every role, a role has all the actions listed for it
+var permissions = map[string]map[string]bool{
+	RoleOwner: {
+		ActionRead: true, ActionCreate: true, ActionUpdate: true, ActionDelete: true,
+		ActionManageMembers: true, ActionDeleteOrg: true,
+	},
+	RoleAdmin: {
+		ActionRead: true, ActionCreate: true, ActionUpdate: true, ActionDelete: true,
+		ActionManageMembers: true,
+	},
+	RoleEditor: {
+		ActionRead: true, ActionCreate: true, ActionUpdate: true,
+	},
+	RoleViewer: {
+		ActionRead: true,
+	},
+}
+
+var rank = map[string]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+	RoleOwner:  4,
+}
+
+// Org is an organization as seen by one of its members
+type Org struct {
+	ID        int64
+	Name      string
+	Role      string
+	CreatedAt time.Time
+}
+
+type Member struct {
+	OrgID     int64
+	UserID    int64
+	Username  string
+	Role      string
+	CreatedAt time.Time
+}
+
+// Item is an object owned by an organization
+type Item struct {
+	Type  string
+	ID    int64
+	Title string
+}
+
+func ValidRole(role string) bool {
+	_, ok := rank[role]
+	return ok
+}
+
+// Allowed reports whether a member with the role may perform the action
+func Allowed(role, action string) bool {
+	return permissions[role][action]
+}
+
+// CanAssign reports whether actor may give a member the role or change a member
+// who has it. Owners manage everyone, admins only roles below their own.
+func CanAssign(actor, role string) bool {
+	if !Allowed(actor, ActionManageMembers) {
+		return false
+	}
+	return actor == RoleOwner || rank[actor] > rank[role]
+}
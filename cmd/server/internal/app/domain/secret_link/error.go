@@ -0,0 +1,17 @@
+package secret_link
+
+import "errors"
+
+var (
+	ErrInvalidUserID  = errors.New("invalid user id")
+	ErrEmptySecret    = errors.New("secret is empty")
+	ErrSecretTooLarge = errors.New("secret is too large")
+	ErrInvalidTTL     = errors.New("lifetime of the link is out of the allowed range")
+	ErrInvalidViews   = errors.New("number of views is out of the allowed range")
+	ErrLabelTooLong   = errors.New("label is too long")
+
+	// ErrLinkNotFound is also returned for expired and burned links so a reader can not tell them apart
+	ErrLinkNotFound     = errors.New("link not found")
+	ErrEmptyLinks       = errors.New("empty links list")
+	ErrFailedCreateLink = errors.New("failed to create link")
+)
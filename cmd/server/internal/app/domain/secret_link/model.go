@@ -0,0 +1,43 @@
+package secret_link
+
+import "time"
+
+// Link is a one-time secret, the ciphertext is encrypted by the client with a key
+// that travels only in the URL fragment so the server can not read it
+type Link struct {
+	// ID is a random URL-safe token, it is the only part of the link the server sees
+	ID         string
+	UserID     int64
+	Label      string
+	Ciphertext []byte
+	MaxViews   int
+	Views      int
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+}
+
+// ViewsLeft is how many times the link can still be opened
+func (l *Link) ViewsLeft() int {
+	return max(l.MaxViews-l.Views, 0)
+}
+
+// Secret is what an anonymous reader of a link gets
+type Secret struct {
+	Ciphertext []byte
+	ViewsLeft  int
+	ExpiresAt  time.Time
+}
+
+// Burned reports that this was the last view of the link
+func (s *Secret) Burned() bool {
+	return s.ViewsLeft <= 0
+}
+
+type Options struct {
+	// DefaultTTL is used when a link is created without a lifetime
+	DefaultTTL time.Duration
+	MaxTTL     time.Duration
+	MaxViews   int
+	// MaxSize limits the ciphertext in bytes, zero means no limit
+	MaxSize int
+}
@@ -0,0 +1,22 @@
+package share
+
+import "errors"
+
+var (
+	ErrInvalidUserID     = errors.New("invalid user id")
+	ErrInvalidItem       = errors.New("invalid item type")
+	ErrInvalidItemID     = errors.New("invalid item id")
+	ErrInvalidShareID    = errors.New("invalid share id")
+	ErrInvalidPermission = errors.New("permission must be read or write")
+	ErrShareWithSelf     = errors.New("item can not be shared with its owner")
+
+	ErrItemNotFound  = errors.New("item not found")
+	ErrUserNotFound  = errors.New("user not found")
+	ErrShareNotFound = errors.New("share not found")
+	ErrEmptyShares   = errors.New("empty shares list")
+
+	// ErrReadOnly is returned by item usecases when a read-only share is used to change an item
+	ErrReadOnly = errors.New("item is shared read-only")
+	// ErrNotOwner is returned when a grantee tries to delete a shared item
+	ErrNotOwner = errors.New("only the owner can delete a shared item")
+)
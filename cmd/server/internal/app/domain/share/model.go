@@ -0,0 +1,60 @@
+package share
+
+import "time"
+
+// item types that can be shared
+const (
+	ItemAccount  = "account"
+	ItemBankCard = "card"
+	ItemText     = "text"
+)
+
+// permissions of a share, write includes read
+const (
+	PermRead  = "read"
+	PermWrite = "write"
+)
+
+// Item points to an account, a bank card or a text
+type Item struct {
+	Type string
+	ID   int64
+}
+
+// Share gives a registered user access to an item of its owner
+type Share struct {
+	ID          int64
+	Item        Item
+	Title       string
+	OwnerID     int64
+	OwnerName   string
+	GranteeID   int64
+	GranteeName string
+	Permission  string
+	CreatedAt   time.Time
+}
+
+// Access is what a user may do with an item of another user, item usecases attach it
+// to the items they return so clients can show the owner
+type Access struct {
+	OwnerID    int64
+	OwnerName  string
+	Permission string
+}
+
+func (a *Access) CanWrite() bool {
+	return a != nil && a.Permission == PermWrite
+}
+
+func ValidItemType(t string) bool {
+	switch t {
+	case ItemAccount, ItemBankCard, ItemText:
+		return true
+	default:
+		return false
+	}
+}
+
+func ValidPermission(p string) bool {
+	return p == PermRead || p == PermWrite
+}
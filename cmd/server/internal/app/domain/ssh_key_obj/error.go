@@ -0,0 +1,18 @@
+package ssh_key_obj
+
+import "errors"
+
+var (
+	ErrSSHKeyNotFound   = errors.New("ssh key not found")
+	ErrEmptySSHKeysList = errors.New("empty ssh keys list")
+
+	ErrInvalidUserID      = errors.New("invalid user id")
+	ErrInvalidSSHKeyID    = errors.New("invalid ssh key id")
+	ErrEmptyTitle         = errors.New("title is empty")
+	ErrInvalidPrivateKey  = errors.New("invalid ssh private key")
+	ErrPassphraseRequired = errors.New("ssh key is passphrase protected, passphrase required")
+	ErrWrongPassphrase    = errors.New("wrong ssh key passphrase")
+	ErrUnsupportedKeyType = errors.New("unsupported ssh key type")
+
+	ErrFailedCreateSSHKey = errors.New("failed to create ssh key")
+)
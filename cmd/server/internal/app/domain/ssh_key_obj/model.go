@@ -0,0 +1,35 @@
+package ssh_key_obj
+
+import "time"
+
+type SSHKey struct {
+	Title string
+	// Type is the SSH algorithm name, e.g. "ssh-ed25519"
+	Type string
+	// PrivateKey is an unencrypted OpenSSH PEM block, it is encrypted at rest
+	PrivateKey  string
+	PublicKey   string
+	Fingerprint string
+	Comment     string
+	CreatedAt   time.Time
+	UserId      int64
+	KeyId       int64
+}
+
+// ImportRequest is an existing private key, Passphrase is only needed for protected keys
+type ImportRequest struct {
+	UserId     int64
+	Title      string
+	PrivateKey string
+	Passphrase string
+	Comment    string
+}
+
+// GenerateRequest describes a new key pair, Bits is used for RSA only
+type GenerateRequest struct {
+	UserId  int64
+	Title   string
+	Type    string
+	Bits    int
+	Comment string
+}
@@ -6,12 +6,14 @@ var (
 	ErrTextNotFound   = errors.New("text not found")
 	ErrEmptyTextsList = errors.New("empty texts list")
 
-	ErrInvalidUserID = errors.New("invalid user id")
-	ErrInvalidTextID = errors.New("invalid text id")
-	ErrEmptyTitle    = errors.New("title is empty")
-	ErrEmptyText     = errors.New("text is empty")
+	ErrInvalidUserID       = errors.New("invalid user id")
+	ErrInvalidTextID       = errors.New("invalid text id")
+	ErrEmptyTitle          = errors.New("title is empty")
+	ErrEmptyText           = errors.New("text is empty")
+	ErrInvalidCustomFields = errors.New("invalid custom fields")
 
 	ErrFailedCreateText        = errors.New("failed to create text")
 	ErrFailedUpdateText        = errors.New("failed to update text")
+	ErrFailedDeleteText        = errors.New("failed to delete text")
 	ErrTextInformationNotFound = errors.New("text information not found")
 )
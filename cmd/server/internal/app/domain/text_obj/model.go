@@ -1,8 +1,22 @@
 package text_obj
 
+import (
+	"server/internal/app/domain/custom_field"
+	"server/internal/app/domain/share"
+	"time"
+)
+
 type Text struct {
-	Title  string
-	Text   string
-	UserId int64
-	TextId int64
+	Title string
+	Text  string
+	// ExpiresAt is zero when the text does not expire
+	ExpiresAt time.Time
+	// CustomFields are extra user-defined fields in the order they were entered
+	CustomFields []custom_field.Field
+	UserId       int64
+	TextId       int64
+	// OrgID is set for items owned by an organization, zero for personal items
+	OrgID int64
+	// Shared is set when the item belongs to another user and is opened through a share
+	Shared *share.Access
 }
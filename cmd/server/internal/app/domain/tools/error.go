@@ -0,0 +1,9 @@
+package tools
+
+import "errors"
+
+var (
+	ErrUnknownPasswordMode    = errors.New("unknown password mode")
+	ErrInvalidPasswordOptions = errors.New("invalid password options")
+	ErrFailedGeneratePassword = errors.New("failed to generate password")
+)
@@ -0,0 +1,32 @@
+package tools
+
+const (
+	PasswordModeRandom     = "random"
+	PasswordModePassphrase = "passphrase"
+
+	DefaultPasswordLength  = 20
+	DefaultPassphraseWords = 6
+)
+
+// PasswordOptions describe the password to generate, zero values fall back to defaults
+type PasswordOptions struct {
+	Mode string
+
+	Length           int
+	Lower            bool
+	Upper            bool
+	Digits           bool
+	Symbols          bool
+	ExcludeAmbiguous bool
+
+	Words      int
+	Separator  string
+	Capitalize bool
+}
+
+type GeneratedPassword struct {
+	Password string
+	Mode     string
+	// Entropy is the estimated strength in bits
+	Entropy float64
+}
@@ -0,0 +1,16 @@
+package two_factor
+
+import "errors"
+
+var (
+	ErrInvalidUserID = errors.New("invalid user id")
+
+	ErrNotEnrolled    = errors.New("two-factor authentication is not set up")
+	ErrAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	// ErrInvalidCode is returned for wrong, reused and already spent codes alike
+	ErrInvalidCode = errors.New("invalid two-factor code")
+	// ErrChallengeNotFound is also returned for expired challenges and ones out of attempts
+	ErrChallengeNotFound = errors.New("login challenge not found")
+	// ErrLocked refuses every code of a user who got too many wrong, for logins and account changes alike
+	ErrLocked = errors.New("too many two-factor attempts, try again later")
+)
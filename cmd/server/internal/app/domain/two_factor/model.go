@@ -0,0 +1,51 @@
+package two_factor
+
+import "time"
+
+// TwoFactor is the TOTP secret of a user, it is pending until the user confirms a code
+type TwoFactor struct {
+	UserID  int64
+	Secret  []byte
+	Enabled bool
+	// LastCounter is the time step of the last accepted code, older and equal steps are refused
+	LastCounter int64
+}
+
+// Enrollment is what the user needs to add the secret to an authenticator app
+type Enrollment struct {
+	// Secret is the base32 form for manual entry
+	Secret string
+	// URI is the otpauth:// form shown as a QR code
+	URI string
+}
+
+type Status struct {
+	Enabled           bool
+	RecoveryCodesLeft int
+}
+
+// Challenge is the first step of a login with 2FA, the password was right and a code is expected
+type Challenge struct {
+	ID     int64
+	UserID int64
+	// TokenHash is the keyed hash of the token handed to the client
+	TokenHash  string
+	DeviceName string
+	Attempts   int
+	ExpiresAt  time.Time
+}
+
+type Options struct {
+	// Issuer names the service in authenticator apps
+	Issuer string
+	// ChallengeTTL is how long the client has to enter a code after the password
+	ChallengeTTL time.Duration
+	// MaxAttempts limits the codes tried against one challenge
+	MaxAttempts int
+	// MaxFailures limits the codes a user gets wrong, at logins and account changes, before codes are refused
+	MaxFailures int
+	// Lockout is how long codes of the user are refused once MaxFailures is reached
+	Lockout time.Duration
+	// RecoveryCodes is how many one-time codes are issued on enabling
+	RecoveryCodes int
+}
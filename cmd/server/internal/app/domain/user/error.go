@@ -11,4 +11,11 @@ var (
 	ErrTokenNotValid         = errors.New("token not valid")
 	ErrPasswordMismatch      = errors.New("password mismatch")
 	ErrTokenRevoked          = errors.New("token revoked")
+	ErrEmptyUsername         = errors.New("username must not be empty")
+	ErrEmptyPassword         = errors.New("password must not be empty")
+	ErrSessionNotFound       = errors.New("session not found")
+	// ErrRefreshTokenReused is a replay of a rotated refresh token, the session is revoked because of it
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+	// ErrLastOrgOwner keeps the account while an organization would be left without an owner
+	ErrLastOrgOwner = errors.New("user is the last owner of an organization")
 )
@@ -0,0 +1,34 @@
+package user
+
+import "time"
+
+// Revocation takes access tokens out of use before they expire. An entry with a JTI revokes
+// that token only, an entry with a SessionID every token of the session, an entry with neither
+// revokes every token of the user issued before IssuedBefore
+type Revocation struct {
+	ID           int64
+	JTI          string
+	UserID       int64
+	SessionID    int64
+	IssuedBefore time.Time
+	// ExpiresAt is when the revoked tokens expire anyway, the entry is not needed after it
+	ExpiresAt time.Time
+	// CreatedAt is set by the database, other instances read the entries by it
+	CreatedAt time.Time
+}
+
+// Covers tells if the token with the jti of the user session issued at issuedAt is revoked by the entry
+func (r *Revocation) Covers(jti string, userID, sessionID int64, issuedAt time.Time) bool {
+	if r.JTI != "" {
+		return r.JTI == jti
+	}
+	if r.SessionID != 0 {
+		return r.UserID == userID && r.SessionID == sessionID
+	}
+	return r.UserID == userID && issuedAt.Before(r.IssuedBefore)
+}
+
+// Expired tells if every token the entry revokes has expired by now
+func (r *Revocation) Expired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
@@ -0,0 +1,26 @@
+package user
+
+import "time"
+
+// Session is a signed-in device of the user, each one has its own refresh token
+type Session struct {
+	ID         int64
+	UserID     int64
+	DeviceName string
+	IP         string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	// Current marks the session the request was made with
+	Current bool
+}
+
+// NewSession names the session after the device, the user agent is used when the client sends no name
+func NewSession(userID int64, deviceName, userAgent, ip string) *Session {
+	if deviceName == "" {
+		deviceName = userAgent
+	}
+	if deviceName == "" {
+		deviceName = "unknown device"
+	}
+	return &Session{UserID: userID, DeviceName: deviceName, IP: ip}
+}
@@ -0,0 +1,74 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"server/internal/app/domain/org"
+	"server/internal/app/domain/share"
+)
+
+// ErrNotFound hides an item the caller can't see, callers return their own not found error for it
+var ErrNotFound = errors.New("item not found")
+
+// Orgs checks the role of a member in an organization
+type Orgs interface {
+	Authorize(ctx context.Context, userId, orgId int64, action string) error
+}
+
+// Shares finds the share of an item granted to a user
+type Shares interface {
+	Access(ctx context.Context, userId int64, item share.Item) (*share.Access, error)
+}
+
+// Item is what the decision needs to know about a vault item
+type Item struct {
+	// OrgID is set for items of an organization, they go by the role of the caller alone
+	OrgID   int64
+	OwnerID int64
+	Share   share.Item
+}
+
+// Check decides if the caller may do the org action on the item: items of an organization by the role
+// in it, other items by ownership and then by a share. A share never deletes and writes only with
+// CanWrite. The access of the share is returned for a shared item, nil otherwise.
+// Without orgs or shares nobody is a member or a grantee
+func Check(ctx context.Context, orgs Orgs, shares Shares, userId int64, item Item, action string) (*share.Access, error) {
+	if item.OrgID != 0 {
+		if orgs == nil {
+			return nil, ErrNotFound
+		}
+		err := orgs.Authorize(ctx, userId, item.OrgID, action)
+		if errors.Is(err, org.ErrOrgNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	if item.OwnerID == userId {
+		return nil, nil
+	}
+
+	if shares == nil {
+		return nil, ErrNotFound
+	}
+
+	access, err := shares.Access(ctx, userId, item.Share)
+	if err != nil {
+		if errors.Is(err, share.ErrShareNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("check share of %s id=%d: %w", item.Share.Type, item.Share.ID, err)
+	}
+
+	if action == org.ActionDelete {
+		return nil, share.ErrNotOwner
+	}
+
+	if action != org.ActionRead && !access.CanWrite() {
+		return nil, share.ErrReadOnly
+	}
+
+	return access, nil
+}
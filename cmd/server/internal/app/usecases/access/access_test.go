@@ -0,0 +1,103 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"server/internal/app/domain/org"
+	"server/internal/app/domain/share"
+)
+
+type orgsFake struct {
+	authorize func(ctx context.Context, userId, orgId int64, action string) error
+}
+
+func (o *orgsFake) Authorize(ctx context.Context, userId, orgId int64, action string) error {
+	if o.authorize != nil {
+		return o.authorize(ctx, userId, orgId, action)
+	}
+	return nil
+}
+
+type sharesFake struct {
+	access func(ctx context.Context, userId int64, item share.Item) (*share.Access, error)
+}
+
+func (s *sharesFake) Access(ctx context.Context, userId int64, item share.Item) (*share.Access, error) {
+	if s.access != nil {
+		return s.access(ctx, userId, item)
+	}
+	return nil, share.ErrShareNotFound
+}
+
+func grant(permission string) *sharesFake {
+	return &sharesFake{access: func(ctx context.Context, userId int64, item share.Item) (*share.Access, error) {
+		return &share.Access{Permission: permission}, nil
+	}}
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	owned := Item{OwnerID: 1, Share: share.Item{Type: share.ItemText, ID: 5}}
+	inOrg := Item{OrgID: 3, OwnerID: 1, Share: share.Item{Type: share.ItemText, ID: 5}}
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name       string
+		orgs       Orgs
+		shares     Shares
+		userId     int64
+		item       Item
+		action     string
+		wantErr    error
+		wantAccess bool
+	}{
+		{name: "owner", userId: 1, item: owned, action: org.ActionDelete},
+		{name: "org member", orgs: &orgsFake{}, userId: 2, item: inOrg, action: org.ActionUpdate},
+		{name: "org item without orgs -> ErrNotFound", userId: 1, item: inOrg, action: org.ActionRead, wantErr: ErrNotFound},
+		{
+			name: "not a member -> ErrNotFound",
+			orgs: &orgsFake{authorize: func(ctx context.Context, userId, orgId int64, action string) error {
+				return org.ErrOrgNotFound
+			}},
+			userId: 2, item: inOrg, action: org.ActionRead, wantErr: ErrNotFound,
+		},
+		{
+			name: "role too low -> ErrForbidden",
+			orgs: &orgsFake{authorize: func(ctx context.Context, userId, orgId int64, action string) error {
+				return org.ErrForbidden
+			}},
+			userId: 2, item: inOrg, action: org.ActionDelete, wantErr: org.ErrForbidden,
+		},
+		{name: "stranger without shares -> ErrNotFound", userId: 2, item: owned, action: org.ActionRead, wantErr: ErrNotFound},
+		{name: "no share -> ErrNotFound", shares: &sharesFake{}, userId: 2, item: owned, action: org.ActionRead, wantErr: ErrNotFound},
+		{
+			name: "share error -> wrapped",
+			shares: &sharesFake{access: func(ctx context.Context, userId int64, item share.Item) (*share.Access, error) {
+				return nil, dbErr
+			}},
+			userId: 2, item: owned, action: org.ActionRead, wantErr: dbErr,
+		},
+		{name: "read share reads", shares: grant(share.PermRead), userId: 2, item: owned, action: org.ActionRead, wantAccess: true},
+		{name: "read share updates -> ErrReadOnly", shares: grant(share.PermRead), userId: 2, item: owned, action: org.ActionUpdate, wantErr: share.ErrReadOnly},
+		{name: "write share updates", shares: grant(share.PermWrite), userId: 2, item: owned, action: org.ActionUpdate, wantAccess: true},
+		{name: "write share deletes -> ErrNotOwner", shares: grant(share.PermWrite), userId: 2, item: owned, action: org.ActionDelete, wantErr: share.ErrNotOwner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			access, err := Check(ctx, tt.orgs, tt.shares, tt.userId, tt.item, tt.action)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got: %v", tt.wantErr, err)
+			}
+			if (access != nil) != tt.wantAccess {
+				t.Fatalf("expected access %v, got: %+v", tt.wantAccess, access)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package account_obj
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	domain "server/internal/app/domain/account_obj"
+	"server/internal/pkg/passgen"
+	"time"
+)
+
+// HealthReport finds weak, reused, old and breached passwords of the user.
+// Reuse is detected by comparing HMACs under a key that lives only for this report,
+// so neither passwords nor their hashes leave the function.
+func (a *AccountObj) HealthReport(ctx context.Context, userId int64, opts domain.HealthOptions) (*domain.HealthReport, error) {
+	if userId <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	list, err := a.repo.GetByUserID(ctx, userId)
+	if err != nil {
+		return nil, domain.ErrAccountNotFound
+	}
+
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("health report key: %w", err)
+	}
+
+	now := time.Now()
+	report := &domain.HealthReport{Total: len(list), Items: make([]*domain.HealthItem, 0)}
+
+	items := make([]*domain.HealthItem, len(list))
+	digests := make([]string, len(list))
+	uses := make(map[string]int)
+
+	for i, account := range list {
+		item := &domain.HealthItem{
+			AccountId:   account.AccountId,
+			ServiceName: account.ServiceName,
+			UserName:    account.UserName,
+			EntropyBits: passgen.EstimateEntropy(account.Password),
+		}
+		if !account.PasswordChangedAt.IsZero() {
+			item.PasswordAge = now.Sub(account.PasswordChangedAt)
+		}
+		items[i] = item
+
+		if account.Password == "" {
+			continue
+		}
+
+		if item.EntropyBits < opts.WeakEntropyBits {
+			item.Issues = append(item.Issues, domain.IssueWeak)
+			report.Weak++
+		}
+
+		if opts.MaxPasswordAge > 0 && item.PasswordAge > opts.MaxPasswordAge {
+			item.Issues = append(item.Issues, domain.IssueOld)
+			report.Old++
+		}
+
+		if account.Compromised() {
+			item.Issues = append(item.Issues, domain.IssueBreached)
+			report.Breached++
+		}
+
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(account.Password))
+		digests[i] = string(mac.Sum(nil))
+		uses[digests[i]]++
+	}
+
+	// groups are numbered in the order their first account appears
+	groups := make(map[string]int)
+	for i, item := range items {
+		if digests[i] == "" || uses[digests[i]] < 2 {
+			continue
+		}
+
+		if _, ok := groups[digests[i]]; !ok {
+			groups[digests[i]] = len(groups) + 1
+		}
+		item.ReuseGroup = groups[digests[i]]
+		item.Issues = append(item.Issues, domain.IssueReused)
+		report.Reused++
+	}
+
+	healthy := 0
+	for _, item := range items {
+		if len(item.Issues) == 0 {
+			healthy++
+			continue
+		}
+		report.Items = append(report.Items, item)
+	}
+
+	report.Score = 100
+	if report.Total > 0 {
+		report.Score = healthy * 100 / report.Total
+	}
+
+	return report, nil
+}
@@ -0,0 +1,163 @@
+package account_obj
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "server/internal/app/domain/account_obj"
+)
+
+func TestAccountObj_HealthReport(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	opts := domain.HealthOptions{WeakEntropyBits: 60, MaxPasswordAge: 180 * 24 * time.Hour}
+
+	t.Run("invalid userId -> ErrInvalidUserID", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := New(&repoFake{}, nil, nil, nil, nil).HealthReport(ctx, 0, opts); !errors.Is(err, domain.ErrInvalidUserID) {
+			t.Fatalf("expected ErrInvalidUserID, got: %v", err)
+		}
+	})
+
+	t.Run("repo error -> ErrAccountNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			getByUserID: func(ctx context.Context, userId int64) ([]*domain.Account, error) {
+				return nil, errors.New("db down")
+			},
+		}, nil, nil, nil, nil)
+
+		if _, err := uc.HealthReport(ctx, 1, opts); !errors.Is(err, domain.ErrAccountNotFound) {
+			t.Fatalf("expected ErrAccountNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("empty vault -> score 100", func(t *testing.T) {
+		t.Parallel()
+
+		report, err := New(&repoFake{}, nil, nil, nil, nil).HealthReport(ctx, 1, opts)
+		if err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+		if report.Score != 100 || report.Total != 0 || len(report.Items) != 0 {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+	})
+
+	t.Run("issues -> counted per kind and listed", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		strong := "kX9#vQ2!mZ7$pL4&"
+
+		uc := New(&repoFake{
+			getByUserID: func(ctx context.Context, userId int64) ([]*domain.Account, error) {
+				return []*domain.Account{
+					{AccountId: 1, ServiceName: "github", Password: strong, PasswordChangedAt: now},
+					{AccountId: 2, ServiceName: "gitlab", Password: strong, PasswordChangedAt: now},
+					{AccountId: 3, ServiceName: "mail", Password: "123456", BreachCount: 37359195, PasswordChangedAt: now},
+					{AccountId: 4, ServiceName: "bank", Password: "Tq8@wR5#nY2%jH6*", PasswordChangedAt: now.Add(-365 * 24 * time.Hour)},
+					{AccountId: 5, ServiceName: "shop", Password: "Vb3!cN8?xM1^sD4(", PasswordChangedAt: now},
+					{AccountId: 6, ServiceName: "notes", Password: ""},
+				}, nil
+			},
+		}, nil, nil, nil, nil)
+
+		report, err := uc.HealthReport(ctx, 1, opts)
+		if err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+
+		if report.Total != 6 || report.Weak != 1 || report.Reused != 2 || report.Old != 1 || report.Breached != 1 {
+			t.Fatalf("unexpected counters: %+v", report)
+		}
+		// shop and notes are healthy
+		if report.Score != 33 {
+			t.Fatalf("expected score 33, got %d", report.Score)
+		}
+		if len(report.Items) != 4 {
+			t.Fatalf("expected 4 items with issues, got %d", len(report.Items))
+		}
+
+		byID := map[int64]*domain.HealthItem{}
+		for _, item := range report.Items {
+			byID[item.AccountId] = item
+		}
+
+		if byID[1].ReuseGroup != 1 || byID[2].ReuseGroup != 1 {
+			t.Fatalf("expected github and gitlab in reuse group 1, got %d, %d", byID[1].ReuseGroup, byID[2].ReuseGroup)
+		}
+		if got := byID[3].Issues; len(got) != 2 || got[0] != domain.IssueWeak || got[1] != domain.IssueBreached {
+			t.Fatalf("unexpected mail issues: %v", got)
+		}
+		if got := byID[4].Issues; len(got) != 1 || got[0] != domain.IssueOld {
+			t.Fatalf("unexpected bank issues: %v", got)
+		}
+	})
+}
+
+func TestAccountObj_UpdateAccount_PasswordChangedAt(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	changedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	stored := func(ctx context.Context, accountId int64) (*domain.Account, error) {
+		return &domain.Account{AccountId: accountId, Password: "old-pass", PasswordChangedAt: changedAt}, nil
+	}
+
+	t.Run("same password -> change date kept", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			getByID: stored,
+			update: func(ctx context.Context, account *domain.Account) error {
+				if !account.PasswordChangedAt.Equal(changedAt) {
+					t.Fatalf("expected change date kept, got %v", account.PasswordChangedAt)
+				}
+				return nil
+			},
+		}, nil, nil, nil, nil)
+
+		if err := uc.UpdateAccount(ctx, &domain.Account{AccountId: 1, ServiceName: "x", Password: "old-pass"}); err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+	})
+
+	t.Run("new password -> change date moved", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			getByID: stored,
+			update: func(ctx context.Context, account *domain.Account) error {
+				if time.Since(account.PasswordChangedAt) > time.Minute {
+					t.Fatalf("expected change date now, got %v", account.PasswordChangedAt)
+				}
+				return nil
+			},
+		}, nil, nil, nil, nil)
+
+		if err := uc.UpdateAccount(ctx, &domain.Account{AccountId: 1, ServiceName: "x", Password: "new-pass"}); err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+	})
+
+	t.Run("lookup error -> ErrAccountNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			getByID: func(ctx context.Context, accountId int64) (*domain.Account, error) {
+				return nil, errors.New("db down")
+			},
+		}, nil, nil, nil, nil)
+
+		if err := uc.UpdateAccount(ctx, &domain.Account{AccountId: 1, ServiceName: "x"}); !errors.Is(err, domain.ErrAccountNotFound) {
+			t.Fatalf("expected ErrAccountNotFound, got: %v", err)
+		}
+	})
+}
@@ -2,7 +2,17 @@ package account_obj
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	domain "server/internal/app/domain/account_obj"
+	"server/internal/app/domain/audit"
+	"server/internal/app/domain/custom_field"
+	"server/internal/app/domain/org"
+	"server/internal/app/domain/share"
+	"server/internal/app/usecases/access"
+	"server/internal/pkg/totp"
+	"strings"
+	"time"
 )
 
 type Repository interface {
@@ -10,14 +20,42 @@ type Repository interface {
 	GetByID(ctx context.Context, accountId int64) (*domain.Account, error)
 	Create(ctx context.Context, account *domain.Account) (int64, error)
 	Update(ctx context.Context, account *domain.Account) error
+	Delete(ctx context.Context, accountId int64) error
+	SetBreachCount(ctx context.Context, accountId int64, count int) error
+}
+
+// BreachChecker tells how many times a password was seen in known breaches
+type BreachChecker interface {
+	Count(password string) (int, error)
+}
+
+// ShareChecker returns the access of a user to an item of another user or share.ErrShareNotFound
+type ShareChecker interface {
+	Access(ctx context.Context, userId int64, item share.Item) (*share.Access, error)
+}
+
+// OrgChecker checks the role of a member against an action, non-members get org.ErrOrgNotFound
+type OrgChecker interface {
+	Authorize(ctx context.Context, userId, orgId int64, action string) error
+}
+
+// Auditor writes access to items into the audit log
+type Auditor interface {
+	Record(ctx context.Context, e audit.Event)
 }
 
 type AccountObj struct {
-	repo Repository
+	repo     Repository
+	breaches BreachChecker
+	shares   ShareChecker
+	orgs     OrgChecker
+	audit    Auditor
 }
 
-func New(repo Repository) *AccountObj {
-	return &AccountObj{repo: repo}
+// New creates the use case, breaches may be nil when no breach dataset is configured,
+// without shares only owners can open their accounts, without auditor nothing is logged
+func New(repo Repository, breaches BreachChecker, shares ShareChecker, orgs OrgChecker, auditor Auditor) *AccountObj {
+	return &AccountObj{repo: repo, breaches: breaches, shares: shares, orgs: orgs, audit: auditor}
 }
 
 func (a *AccountObj) GetAccountsList(ctx context.Context, userId int64) ([]*domain.Account, error) {
@@ -37,16 +75,31 @@ func (a *AccountObj) GetAccountsList(ctx context.Context, userId int64) ([]*doma
 	return list, nil
 }
 
-func (a *AccountObj) GetAccount(ctx context.Context, accountId int64) (*domain.Account, error) {
+// GetAccount returns an account of the user or one shared with the user
+func (a *AccountObj) GetAccount(ctx context.Context, userId, accountId int64) (*domain.Account, error) {
+	account, err := a.getAccount(ctx, userId, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	a.record(ctx, userId, account, audit.ActionItemRead)
+	return account, nil
+}
+
+func (a *AccountObj) getAccount(ctx context.Context, userId, accountId int64) (*domain.Account, error) {
 	if accountId <= 0 {
 		return nil, domain.ErrInvalidAccountID
 	}
 
 	account, err := a.repo.GetByID(ctx, accountId)
-	if err != nil {
+	if err != nil || account == nil {
 		return nil, domain.ErrAccountNotFound
 	}
 
+	if err := a.authorize(ctx, userId, account, org.ActionRead); err != nil {
+		return nil, err
+	}
+
 	return account, nil
 }
 
@@ -59,14 +112,38 @@ func (a *AccountObj) CreateNewAccountObj(ctx context.Context, account *domain.Ac
 		return 0, domain.ErrEmptyServiceName
 	}
 
+	if err := normalizeTOTP(account); err != nil {
+		return 0, err
+	}
+
+	if err := custom_field.Normalize(account.CustomFields); err != nil {
+		return 0, fmt.Errorf("%w: %w", domain.ErrInvalidCustomFields, err)
+	}
+
+	if err := a.checkBreaches(account); err != nil {
+		return 0, err
+	}
+
+	account.PasswordChangedAt = time.Now()
+
+	if account.OrgID != 0 {
+		if err := a.authorizeOrg(ctx, account.UserId, account.OrgID, org.ActionCreate); err != nil {
+			return 0, err
+		}
+	}
+
 	id, err := a.repo.Create(ctx, account)
 	if err != nil {
 		return 0, domain.ErrFailedCreateAccount
 	}
 
+	account.AccountId = id
+	a.record(ctx, account.UserId, account, audit.ActionCreate)
+
 	return id, nil
 }
 
+// UpdateAccount saves the account for its owner or a user with a write share, account.UserId is the caller
 func (a *AccountObj) UpdateAccount(ctx context.Context, account *domain.Account) error {
 	if account.AccountId <= 0 {
 		return domain.ErrInvalidAccountID
@@ -76,9 +153,222 @@ func (a *AccountObj) UpdateAccount(ctx context.Context, account *domain.Account)
 		return domain.ErrEmptyServiceName
 	}
 
+	if err := normalizeTOTP(account); err != nil {
+		return err
+	}
+
+	if err := custom_field.Normalize(account.CustomFields); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInvalidCustomFields, err)
+	}
+
+	current, err := a.repo.GetByID(ctx, account.AccountId)
+	if err != nil || current == nil {
+		return domain.ErrAccountNotFound
+	}
+
+	if err := a.authorize(ctx, account.UserId, current, org.ActionUpdate); err != nil {
+		return err
+	}
+
+	if err := a.checkBreaches(account); err != nil {
+		return err
+	}
+
+	trackPasswordChange(account, current)
+
 	if err := a.repo.Update(ctx, account); err != nil {
 		return domain.ErrFailedUpdateAccount
 	}
 
+	a.record(ctx, account.UserId, current, audit.ActionUpdate)
+
+	return nil
+}
+
+// GetTOTPCode returns the current one-time code of the account and how long it stays valid
+func (a *AccountObj) GetTOTPCode(ctx context.Context, userId, accountId int64) (*domain.TOTPCode, error) {
+	account, err := a.getAccount(ctx, userId, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.TOTP == "" {
+		return nil, domain.ErrTOTPNotConfigured
+	}
+
+	key, err := totp.Parse(account.TOTP)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidTOTPSecret, err)
+	}
+
+	now := time.Now()
+
+	code, err := key.Code(now)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidTOTPSecret, err)
+	}
+
+	a.record(ctx, userId, account, audit.ActionDecrypt)
+
+	return &domain.TOTPCode{
+		Code:      code,
+		Period:    key.Period,
+		Remaining: key.Remaining(now),
+	}, nil
+}
+
+// BreachReport checks the stored passwords of the user against the breach dataset again,
+// the dataset may have been updated since the passwords were saved
+func (a *AccountObj) BreachReport(ctx context.Context, userId int64) (*domain.BreachReport, error) {
+	if userId <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	list, err := a.repo.GetByUserID(ctx, userId)
+	if err != nil {
+		return nil, domain.ErrAccountNotFound
+	}
+
+	report := &domain.BreachReport{
+		Enabled:     a.breaches != nil,
+		Compromised: make([]*domain.Account, 0),
+	}
+
+	for _, account := range list {
+		if a.breaches != nil {
+			count, err := a.breachCount(account.Password)
+			if err != nil {
+				return nil, err
+			}
+
+			if count != account.BreachCount {
+				if err := a.repo.SetBreachCount(ctx, account.AccountId, count); err != nil {
+					return nil, fmt.Errorf("update breach flag of account id=%d: %w", account.AccountId, err)
+				}
+				account.BreachCount = count
+			}
+			report.Checked++
+		}
+
+		if account.Compromised() {
+			report.Compromised = append(report.Compromised, account)
+		}
+	}
+
+	return report, nil
+}
+
+// help func
+
+// checkBreaches flags the account when its password is listed in the breach dataset
+func (a *AccountObj) checkBreaches(account *domain.Account) error {
+	if a.breaches == nil {
+		return nil
+	}
+
+	count, err := a.breachCount(account.Password)
+	if err != nil {
+		return err
+	}
+
+	account.BreachCount = count
+	return nil
+}
+
+// DeleteAccount removes the account for its owner or a member whose role allows deleting
+func (a *AccountObj) DeleteAccount(ctx context.Context, userId, accountId int64) error {
+	if accountId <= 0 {
+		return domain.ErrInvalidAccountID
+	}
+
+	current, err := a.repo.GetByID(ctx, accountId)
+	if err != nil || current == nil {
+		return domain.ErrAccountNotFound
+	}
+
+	if err := a.authorize(ctx, userId, current, org.ActionDelete); err != nil {
+		return err
+	}
+
+	if err := a.repo.Delete(ctx, accountId); err != nil {
+		return domain.ErrFailedDeleteAccount
+	}
+
+	a.record(ctx, userId, current, audit.ActionDelete)
+
+	return nil
+}
+
+// authorize checks items of organizations by the member role, personal items by ownership and shares,
+// accounts without access are reported as not found
+func (a *AccountObj) authorize(ctx context.Context, userId int64, account *domain.Account, action string) error {
+	shared, err := access.Check(ctx, a.orgs, a.shares, userId, access.Item{
+		OrgID:   account.OrgID,
+		OwnerID: account.UserId,
+		Share:   share.Item{Type: share.ItemAccount, ID: account.AccountId},
+	}, action)
+	if err != nil {
+		if errors.Is(err, access.ErrNotFound) {
+			return domain.ErrAccountNotFound
+		}
+		return err
+	}
+
+	account.Shared = shared
+	return nil
+}
+
+// authorizeOrg checks the role of the caller in the organization, without orgs nobody is a member
+func (a *AccountObj) authorizeOrg(ctx context.Context, userId, orgId int64, action string) error {
+	if a.orgs == nil {
+		return org.ErrOrgNotFound
+	}
+	return a.orgs.Authorize(ctx, userId, orgId, action)
+}
+
+// record writes the action of the caller on the account into the audit log
+func (a *AccountObj) record(ctx context.Context, userId int64, account *domain.Account, action string) {
+	if a.audit == nil {
+		return
+	}
+	a.audit.Record(ctx, audit.Event{
+		UserID:   userId,
+		OwnerID:  account.UserId,
+		Action:   action,
+		ItemType: audit.ItemAccount,
+		ItemID:   account.AccountId,
+	})
+}
+
+// trackPasswordChange keeps the change date of the stored password unless a new one is set
+func trackPasswordChange(account, current *domain.Account) {
+	account.PasswordChangedAt = time.Now()
+	if current.Password == account.Password && !current.PasswordChangedAt.IsZero() {
+		account.PasswordChangedAt = current.PasswordChangedAt
+	}
+}
+
+func (a *AccountObj) breachCount(password string) (int, error) {
+	if password == "" {
+		return 0, nil
+	}
+
+	count, err := a.breaches.Count(password)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", domain.ErrBreachCheckFailed, err)
+	}
+	return count, nil
+}
+
+// normalizeTOTP trims the TOTP secret and rejects the ones codes can't be generated from
+func normalizeTOTP(account *domain.Account) error {
+	account.TOTP = strings.TrimSpace(account.TOTP)
+	if account.TOTP == "" {
+		return nil
+	}
+
+	if _, err := totp.Parse(account.TOTP); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInvalidTOTPSecret, err)
+	}
 	return nil
 }
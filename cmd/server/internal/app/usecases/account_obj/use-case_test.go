@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	domain "server/internal/app/domain/account_obj"
+	"server/internal/app/domain/audit"
+	"server/internal/app/domain/org"
+	"server/internal/app/domain/share"
 )
 
 type repoFake struct {
@@ -13,6 +17,9 @@ type repoFake struct {
 	getByID     func(ctx context.Context, accountId int64) (*domain.Account, error)
 	create      func(ctx context.Context, account *domain.Account) (int64, error)
 	update      func(ctx context.Context, account *domain.Account) error
+	delete      func(ctx context.Context, accountId int64) error
+
+	setBreachCount func(ctx context.Context, accountId int64, count int) error
 }
 
 func (r *repoFake) GetByUserID(ctx context.Context, userId int64) ([]*domain.Account, error) {
@@ -26,7 +33,7 @@ func (r *repoFake) GetByID(ctx context.Context, accountId int64) (*domain.Accoun
 	if r.getByID != nil {
 		return r.getByID(ctx, accountId)
 	}
-	return nil, nil
+	return &domain.Account{AccountId: accountId, UserId: 1}, nil
 }
 
 func (r *repoFake) Create(ctx context.Context, account *domain.Account) (int64, error) {
@@ -43,6 +50,41 @@ func (r *repoFake) Update(ctx context.Context, account *domain.Account) error {
 	return nil
 }
 
+func (r *repoFake) Delete(ctx context.Context, accountId int64) error {
+	if r.delete != nil {
+		return r.delete(ctx, accountId)
+	}
+	return nil
+}
+
+func (r *repoFake) SetBreachCount(ctx context.Context, accountId int64, count int) error {
+	if r.setBreachCount != nil {
+		return r.setBreachCount(ctx, accountId, count)
+	}
+	return nil
+}
+
+// sharesFake grants access by grantee id, users without an entry get ErrShareNotFound
+type sharesFake map[int64]string
+
+func (s sharesFake) Access(ctx context.Context, userId int64, item share.Item) (*share.Access, error) {
+	perm, ok := s[userId]
+	if !ok {
+		return nil, share.ErrShareNotFound
+	}
+	return &share.Access{OwnerID: 1, OwnerName: "alice", Permission: perm}, nil
+}
+
+// breachesFake knows passwords by plain text, the real dataset compares SHA-1
+type breachesFake map[string]int
+
+func (b breachesFake) Count(password string) (int, error) {
+	if password == "broken" {
+		return 0, errors.New("dataset read error")
+	}
+	return b[password], nil
+}
+
 func TestAccountObj_GetAccountsList(t *testing.T) {
 	t.Parallel()
 
@@ -51,7 +93,7 @@ func TestAccountObj_GetAccountsList(t *testing.T) {
 	t.Run("invalid userId -> ErrInvalidUserID", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
+		uc := New(&repoFake{}, nil, nil, nil, nil)
 		_, err := uc.GetAccountsList(ctx, 0)
 		if !errors.Is(err, domain.ErrInvalidUserID) {
 			t.Fatalf("expected ErrInvalidUserID, got: %v", err)
@@ -65,7 +107,7 @@ func TestAccountObj_GetAccountsList(t *testing.T) {
 			getByUserID: func(ctx context.Context, userId int64) ([]*domain.Account, error) {
 				return nil, errors.New("db down")
 			},
-		})
+		}, nil, nil, nil, nil)
 
 		_, err := uc.GetAccountsList(ctx, 1)
 		if !errors.Is(err, domain.ErrAccountNotFound) {
@@ -80,7 +122,7 @@ func TestAccountObj_GetAccountsList(t *testing.T) {
 			getByUserID: func(ctx context.Context, userId int64) ([]*domain.Account, error) {
 				return []*domain.Account{}, nil
 			},
-		})
+		}, nil, nil, nil, nil)
 
 		_, err := uc.GetAccountsList(ctx, 1)
 		if !errors.Is(err, domain.ErrEmptyAccountsList) {
@@ -100,7 +142,7 @@ func TestAccountObj_GetAccountsList(t *testing.T) {
 			getByUserID: func(ctx context.Context, userId int64) ([]*domain.Account, error) {
 				return want, nil
 			},
-		})
+		}, nil, nil, nil, nil)
 
 		got, err := uc.GetAccountsList(ctx, 1)
 		if err != nil {
@@ -123,8 +165,8 @@ func TestAccountObj_GetAccount(t *testing.T) {
 	t.Run("invalid accountId -> ErrInvalidAccountID", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
-		_, err := uc.GetAccount(ctx, -1)
+		uc := New(&repoFake{}, nil, nil, nil, nil)
+		_, err := uc.GetAccount(ctx, 1, -1)
 		if !errors.Is(err, domain.ErrInvalidAccountID) {
 			t.Fatalf("expected ErrInvalidAccountID, got: %v", err)
 		}
@@ -137,9 +179,9 @@ func TestAccountObj_GetAccount(t *testing.T) {
 			getByID: func(ctx context.Context, accountId int64) (*domain.Account, error) {
 				return nil, errors.New("not found in db")
 			},
-		})
+		}, nil, nil, nil, nil)
 
-		_, err := uc.GetAccount(ctx, 123)
+		_, err := uc.GetAccount(ctx, 1, 123)
 		if !errors.Is(err, domain.ErrAccountNotFound) {
 			t.Fatalf("expected ErrAccountNotFound, got: %v", err)
 		}
@@ -154,9 +196,9 @@ func TestAccountObj_GetAccount(t *testing.T) {
 			getByID: func(ctx context.Context, accountId int64) (*domain.Account, error) {
 				return want, nil
 			},
-		})
+		}, nil, nil, nil, nil)
 
-		got, err := uc.GetAccount(ctx, 7)
+		got, err := uc.GetAccount(ctx, 1, 7)
 		if err != nil {
 			t.Fatalf("expected nil err, got: %v", err)
 		}
@@ -174,7 +216,7 @@ func TestAccountObj_CreateNewAccountObj(t *testing.T) {
 	t.Run("invalid userId -> ErrInvalidUserID", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
+		uc := New(&repoFake{}, nil, nil, nil, nil)
 		_, err := uc.CreateNewAccountObj(ctx, &domain.Account{UserId: 0, ServiceName: "x"})
 		if !errors.Is(err, domain.ErrInvalidUserID) {
 			t.Fatalf("expected ErrInvalidUserID, got: %v", err)
@@ -184,7 +226,7 @@ func TestAccountObj_CreateNewAccountObj(t *testing.T) {
 	t.Run("empty serviceName -> ErrEmptyServiceName", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
+		uc := New(&repoFake{}, nil, nil, nil, nil)
 		_, err := uc.CreateNewAccountObj(ctx, &domain.Account{UserId: 1, ServiceName: ""})
 		if !errors.Is(err, domain.ErrEmptyServiceName) {
 			t.Fatalf("expected ErrEmptyServiceName, got: %v", err)
@@ -198,7 +240,7 @@ func TestAccountObj_CreateNewAccountObj(t *testing.T) {
 			create: func(ctx context.Context, account *domain.Account) (int64, error) {
 				return 0, errors.New("insert failed")
 			},
-		})
+		}, nil, nil, nil, nil)
 
 		_, err := uc.CreateNewAccountObj(ctx, &domain.Account{UserId: 1, ServiceName: "telegram"})
 		if !errors.Is(err, domain.ErrFailedCreateAccount) {
@@ -219,7 +261,7 @@ func TestAccountObj_CreateNewAccountObj(t *testing.T) {
 				}
 				return 42, nil
 			},
-		})
+		}, nil, nil, nil, nil)
 
 		id, err := uc.CreateNewAccountObj(ctx, &domain.Account{UserId: 1, ServiceName: "telegram"})
 		if err != nil {
@@ -239,7 +281,7 @@ func TestAccountObj_UpdateAccount(t *testing.T) {
 	t.Run("invalid accountId -> ErrInvalidAccountID", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
+		uc := New(&repoFake{}, nil, nil, nil, nil)
 		err := uc.UpdateAccount(ctx, &domain.Account{AccountId: 0, ServiceName: "x"})
 		if !errors.Is(err, domain.ErrInvalidAccountID) {
 			t.Fatalf("expected ErrInvalidAccountID, got: %v", err)
@@ -249,8 +291,8 @@ func TestAccountObj_UpdateAccount(t *testing.T) {
 	t.Run("empty serviceName -> ErrEmptyServiceName", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
-		err := uc.UpdateAccount(ctx, &domain.Account{AccountId: 1, ServiceName: ""})
+		uc := New(&repoFake{}, nil, nil, nil, nil)
+		err := uc.UpdateAccount(ctx, &domain.Account{AccountId: 1, UserId: 1, ServiceName: ""})
 		if !errors.Is(err, domain.ErrEmptyServiceName) {
 			t.Fatalf("expected ErrEmptyServiceName, got: %v", err)
 		}
@@ -263,9 +305,9 @@ func TestAccountObj_UpdateAccount(t *testing.T) {
 			update: func(ctx context.Context, account *domain.Account) error {
 				return errors.New("update failed")
 			},
-		})
+		}, nil, nil, nil, nil)
 
-		err := uc.UpdateAccount(ctx, &domain.Account{AccountId: 1, ServiceName: "telegram"})
+		err := uc.UpdateAccount(ctx, &domain.Account{AccountId: 1, UserId: 1, ServiceName: "telegram"})
 		if !errors.Is(err, domain.ErrFailedUpdateAccount) {
 			t.Fatalf("expected ErrFailedUpdateAccount, got: %v", err)
 		}
@@ -287,9 +329,9 @@ func TestAccountObj_UpdateAccount(t *testing.T) {
 				}
 				return nil
 			},
-		})
+		}, nil, nil, nil, nil)
 
-		err := uc.UpdateAccount(ctx, &domain.Account{AccountId: 99, ServiceName: "amoCRM"})
+		err := uc.UpdateAccount(ctx, &domain.Account{AccountId: 99, UserId: 1, ServiceName: "amoCRM"})
 		if err != nil {
 			t.Fatalf("expected nil err, got: %v", err)
 		}
@@ -298,3 +340,418 @@ func TestAccountObj_UpdateAccount(t *testing.T) {
 		}
 	})
 }
+
+func TestAccountObj_TOTPSecretValidation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("create with invalid secret -> ErrInvalidTOTPSecret", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			create: func(ctx context.Context, account *domain.Account) (int64, error) {
+				t.Fatalf("Create must not be called")
+				return 0, nil
+			},
+		}, nil, nil, nil, nil)
+
+		_, err := uc.CreateNewAccountObj(ctx, &domain.Account{UserId: 1, ServiceName: "github", TOTP: "not a secret!"})
+		if !errors.Is(err, domain.ErrInvalidTOTPSecret) {
+			t.Fatalf("expected ErrInvalidTOTPSecret, got: %v", err)
+		}
+	})
+
+	t.Run("update with otpauth uri -> trimmed and stored", func(t *testing.T) {
+		t.Parallel()
+
+		const uri = "otpauth://totp/GitHub:john?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ&issuer=GitHub"
+
+		uc := New(&repoFake{
+			update: func(ctx context.Context, account *domain.Account) error {
+				if account.TOTP != uri {
+					t.Fatalf("expected trimmed uri, got %q", account.TOTP)
+				}
+				return nil
+			},
+		}, nil, nil, nil, nil)
+
+		if err := uc.UpdateAccount(ctx, &domain.Account{AccountId: 1, UserId: 1, ServiceName: "github", TOTP: "  " + uri + "\n"}); err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+	})
+}
+
+func TestAccountObj_GetTOTPCode(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	withTOTP := func(secret string) *repoFake {
+		return &repoFake{
+			getByID: func(ctx context.Context, accountId int64) (*domain.Account, error) {
+				return &domain.Account{AccountId: accountId, UserId: 1, ServiceName: "github", TOTP: secret}, nil
+			},
+		}
+	}
+
+	t.Run("repo error -> ErrAccountNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			getByID: func(ctx context.Context, accountId int64) (*domain.Account, error) {
+				return nil, errors.New("db down")
+			},
+		}, nil, nil, nil, nil)
+
+		if _, err := uc.GetTOTPCode(ctx, 1, 1); !errors.Is(err, domain.ErrAccountNotFound) {
+			t.Fatalf("expected ErrAccountNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("no secret -> ErrTOTPNotConfigured", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(withTOTP(""), nil, nil, nil, nil)
+		if _, err := uc.GetTOTPCode(ctx, 1, 1); !errors.Is(err, domain.ErrTOTPNotConfigured) {
+			t.Fatalf("expected ErrTOTPNotConfigured, got: %v", err)
+		}
+	})
+
+	t.Run("broken stored secret -> ErrInvalidTOTPSecret", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(withTOTP("otpauth://totp/x?secret=GEZDGNBV&algorithm=MD5"), nil, nil, nil, nil)
+		if _, err := uc.GetTOTPCode(ctx, 1, 1); !errors.Is(err, domain.ErrInvalidTOTPSecret) {
+			t.Fatalf("expected ErrInvalidTOTPSecret, got: %v", err)
+		}
+	})
+
+	t.Run("ok -> code and remaining validity", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(withTOTP("otpauth://totp/x?secret=GEZDGNBVGY3TQOJQ&digits=8&period=60"), nil, nil, nil, nil)
+
+		code, err := uc.GetTOTPCode(ctx, 1, 1)
+		if err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+		if len(code.Code) != 8 {
+			t.Fatalf("expected 8 digit code, got %q", code.Code)
+		}
+		if code.Period != time.Minute || code.Remaining <= 0 || code.Remaining > time.Minute {
+			t.Fatalf("unexpected validity: period=%v remaining=%v", code.Period, code.Remaining)
+		}
+	})
+}
+
+func TestAccountObj_BreachCheckOnSave(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	breaches := breachesFake{"password": 3861493}
+
+	t.Run("breached password -> saved with breach count", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			create: func(ctx context.Context, account *domain.Account) (int64, error) {
+				if account.BreachCount != 3861493 || !account.Compromised() {
+					t.Fatalf("expected compromised account, got %+v", account)
+				}
+				return 1, nil
+			},
+		}, breaches, nil, nil, nil)
+
+		if _, err := uc.CreateNewAccountObj(ctx, &domain.Account{UserId: 1, ServiceName: "github", Password: "password"}); err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+	})
+
+	t.Run("changed password -> flag cleared", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			update: func(ctx context.Context, account *domain.Account) error {
+				if account.Compromised() {
+					t.Fatalf("expected clean account, got %+v", account)
+				}
+				return nil
+			},
+		}, breaches, nil, nil, nil)
+
+		err := uc.UpdateAccount(ctx, &domain.Account{AccountId: 1, UserId: 1, ServiceName: "github", Password: "kX9#vQ2!", BreachCount: 10})
+		if err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+	})
+
+	t.Run("dataset error -> ErrBreachCheckFailed", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{}, breaches, nil, nil, nil)
+
+		_, err := uc.CreateNewAccountObj(ctx, &domain.Account{UserId: 1, ServiceName: "github", Password: "broken"})
+		if !errors.Is(err, domain.ErrBreachCheckFailed) {
+			t.Fatalf("expected ErrBreachCheckFailed, got: %v", err)
+		}
+	})
+}
+
+func TestAccountObj_BreachReport(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	stored := func() []*domain.Account {
+		return []*domain.Account{
+			{AccountId: 1, ServiceName: "github", Password: "password"},
+			{AccountId: 2, ServiceName: "gitlab", Password: "kX9#vQ2!", BreachCount: 4},
+			{AccountId: 3, ServiceName: "mail", Password: "123456", BreachCount: 2},
+		}
+	}
+
+	t.Run("invalid userId -> ErrInvalidUserID", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := New(&repoFake{}, nil, nil, nil, nil).BreachReport(ctx, 0); !errors.Is(err, domain.ErrInvalidUserID) {
+			t.Fatalf("expected ErrInvalidUserID, got: %v", err)
+		}
+	})
+
+	t.Run("no dataset -> last known flags", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			getByUserID: func(ctx context.Context, userId int64) ([]*domain.Account, error) { return stored(), nil },
+			setBreachCount: func(ctx context.Context, accountId int64, count int) error {
+				t.Fatalf("SetBreachCount must not be called")
+				return nil
+			},
+		}, nil, nil, nil, nil)
+
+		report, err := uc.BreachReport(ctx, 1)
+		if err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+		if report.Enabled || report.Checked != 0 || len(report.Compromised) != 2 {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+	})
+
+	t.Run("dataset -> flags refreshed", func(t *testing.T) {
+		t.Parallel()
+
+		updated := map[int64]int{}
+
+		uc := New(&repoFake{
+			getByUserID: func(ctx context.Context, userId int64) ([]*domain.Account, error) { return stored(), nil },
+			setBreachCount: func(ctx context.Context, accountId int64, count int) error {
+				updated[accountId] = count
+				return nil
+			},
+		}, breachesFake{"password": 3861493, "123456": 2}, nil, nil, nil)
+
+		report, err := uc.BreachReport(ctx, 1)
+		if err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+		if !report.Enabled || report.Checked != 3 {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+		if len(report.Compromised) != 2 || report.Compromised[0].AccountId != 1 || report.Compromised[1].AccountId != 3 {
+			t.Fatalf("unexpected compromised accounts: %+v", report.Compromised)
+		}
+		if len(updated) != 2 || updated[1] != 3861493 || updated[2] != 0 {
+			t.Fatalf("expected only changed flags updated, got %v", updated)
+		}
+	})
+}
+
+func TestAccountObj_SharedAccess(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	shares := sharesFake{2: share.PermRead, 3: share.PermWrite}
+
+	t.Run("owner only without shares -> ErrAccountNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := New(&repoFake{}, nil, nil, nil, nil).GetAccount(ctx, 2, 5); !errors.Is(err, domain.ErrAccountNotFound) {
+			t.Fatalf("expected ErrAccountNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("not shared -> ErrAccountNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := New(&repoFake{}, nil, shares, nil, nil).GetAccount(ctx, 4, 5); !errors.Is(err, domain.ErrAccountNotFound) {
+			t.Fatalf("expected ErrAccountNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("read share -> account with owner", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := New(&repoFake{}, nil, shares, nil, nil).GetAccount(ctx, 2, 5)
+		if err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+		if got.Shared == nil || got.Shared.OwnerName != "alice" || got.Shared.Permission != share.PermRead {
+			t.Fatalf("unexpected shared info: %+v", got.Shared)
+		}
+	})
+
+	t.Run("update with read share -> ErrReadOnly", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			update: func(ctx context.Context, account *domain.Account) error {
+				t.Fatal("repo must not be called")
+				return nil
+			},
+		}, nil, shares, nil, nil)
+
+		err := uc.UpdateAccount(ctx, &domain.Account{AccountId: 5, UserId: 2, ServiceName: "github"})
+		if !errors.Is(err, share.ErrReadOnly) {
+			t.Fatalf("expected ErrReadOnly, got: %v", err)
+		}
+	})
+
+	t.Run("update with write share -> saved", func(t *testing.T) {
+		t.Parallel()
+
+		var saved *domain.Account
+		uc := New(&repoFake{
+			update: func(ctx context.Context, account *domain.Account) error {
+				saved = account
+				return nil
+			},
+		}, nil, shares, nil, nil)
+
+		if err := uc.UpdateAccount(ctx, &domain.Account{AccountId: 5, UserId: 3, ServiceName: "github"}); err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+		if saved == nil || saved.AccountId != 5 {
+			t.Fatalf("expected account to be saved, got: %+v", saved)
+		}
+	})
+}
+
+// orgsFake holds the roles of members of every organization, other users are not members
+type orgsFake map[int64]string
+
+func (o orgsFake) Authorize(ctx context.Context, userId, orgId int64, action string) error {
+	role, ok := o[userId]
+	if !ok {
+		return org.ErrOrgNotFound
+	}
+	if !org.Allowed(role, action) {
+		return org.ErrForbidden
+	}
+	return nil
+}
+
+func TestAccountObj_OrgAccess(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := &repoFake{getByID: func(ctx context.Context, accountId int64) (*domain.Account, error) {
+		return &domain.Account{AccountId: accountId, UserId: 1, OrgID: 7}, nil
+	}}
+	uc := New(repo, nil, nil, orgsFake{1: org.RoleOwner, 3: org.RoleEditor, 4: org.RoleViewer}, nil)
+
+	if _, err := uc.GetAccount(ctx, 9, 5); !errors.Is(err, domain.ErrAccountNotFound) {
+		t.Fatalf("non-member: expected ErrAccountNotFound, got: %v", err)
+	}
+
+	if _, err := uc.GetAccount(ctx, 4, 5); err != nil {
+		t.Fatalf("viewer read: unexpected error: %v", err)
+	}
+
+	if err := uc.UpdateAccount(ctx, &domain.Account{AccountId: 5, UserId: 4, ServiceName: "svc"}); !errors.Is(err, org.ErrForbidden) {
+		t.Fatalf("viewer update: expected ErrForbidden, got: %v", err)
+	}
+
+	if err := uc.UpdateAccount(ctx, &domain.Account{AccountId: 5, UserId: 3, ServiceName: "svc"}); err != nil {
+		t.Fatalf("editor update: unexpected error: %v", err)
+	}
+
+	if err := uc.DeleteAccount(ctx, 3, 5); !errors.Is(err, org.ErrForbidden) {
+		t.Fatalf("editor delete: expected ErrForbidden, got: %v", err)
+	}
+
+	if _, err := uc.CreateNewAccountObj(ctx, &domain.Account{UserId: 4, OrgID: 7, ServiceName: "svc"}); !errors.Is(err, org.ErrForbidden) {
+		t.Fatalf("viewer create: expected ErrForbidden, got: %v", err)
+	}
+
+	var deleted int64
+	repo.delete = func(ctx context.Context, accountId int64) error {
+		deleted = accountId
+		return nil
+	}
+	if err := uc.DeleteAccount(ctx, 1, 5); err != nil || deleted != 5 {
+		t.Fatalf("owner delete: deleted=%d err=%v", deleted, err)
+	}
+}
+
+type auditFake struct {
+	events []audit.Event
+}
+
+func (f *auditFake) Record(ctx context.Context, e audit.Event) {
+	f.events = append(f.events, e)
+}
+
+func TestAccountObj_Audit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := &repoFake{
+		getByID: func(ctx context.Context, accountId int64) (*domain.Account, error) {
+			return &domain.Account{AccountId: accountId, UserId: 1, ServiceName: "github", TOTP: "GEZDGNBVGY3TQOJQ"}, nil
+		},
+		create: func(ctx context.Context, account *domain.Account) (int64, error) { return 8, nil },
+		update: func(ctx context.Context, account *domain.Account) error { return nil },
+		delete: func(ctx context.Context, accountId int64) error { return nil },
+	}
+	log := &auditFake{}
+	uc := New(repo, nil, sharesFake{}, nil, log)
+
+	if _, err := uc.CreateNewAccountObj(ctx, &domain.Account{UserId: 1, ServiceName: "github"}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := uc.GetAccount(ctx, 1, 5); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if _, err := uc.GetTOTPCode(ctx, 1, 5); err != nil {
+		t.Fatalf("totp: %v", err)
+	}
+	if err := uc.UpdateAccount(ctx, &domain.Account{AccountId: 5, UserId: 1, ServiceName: "github"}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := uc.DeleteAccount(ctx, 1, 5); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := uc.GetAccount(ctx, 2, 5); !errors.Is(err, domain.ErrAccountNotFound) {
+		t.Fatalf("stranger: expected ErrAccountNotFound, got: %v", err)
+	}
+
+	want := []audit.Event{
+		{UserID: 1, OwnerID: 1, Action: audit.ActionCreate, ItemType: audit.ItemAccount, ItemID: 8},
+		{UserID: 1, OwnerID: 1, Action: audit.ActionItemRead, ItemType: audit.ItemAccount, ItemID: 5},
+		{UserID: 1, OwnerID: 1, Action: audit.ActionDecrypt, ItemType: audit.ItemAccount, ItemID: 5},
+		{UserID: 1, OwnerID: 1, Action: audit.ActionUpdate, ItemType: audit.ItemAccount, ItemID: 5},
+		{UserID: 1, OwnerID: 1, Action: audit.ActionDelete, ItemType: audit.ItemAccount, ItemID: 5},
+	}
+	if len(log.events) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), log.events)
+	}
+	for i := range want {
+		got := log.events[i]
+		if got.UserID != want[i].UserID || got.OwnerID != want[i].OwnerID || got.Action != want[i].Action ||
+			got.ItemType != want[i].ItemType || got.ItemID != want[i].ItemID {
+			t.Fatalf("event %d: expected %+v, got %+v", i, want[i], got)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	domain "server/internal/app/domain/attachment"
+	file "server/internal/app/domain/file_obj"
+	"server/internal/app/domain/org"
+	"server/internal/app/domain/share"
+	"server/internal/app/usecases/access"
+)
+
+type Repository interface {
+	// ItemOwner returns the user and organization of the item or ErrItemNotFound
+	ItemOwner(ctx context.Context, item domain.Item) (domain.Owner, error)
+	// FileOwner returns the user of an uploaded file or ErrFileNotFound
+	FileOwner(ctx context.Context, fileID int64) (int64, error)
+
+	ListByItem(ctx context.Context, item domain.Item) ([]*domain.Attachment, error)
+	Attach(ctx context.Context, userID int64, item domain.Item, fileID int64) (int64, error)
+	Detach(ctx context.Context, item domain.Item, fileID int64) error
+}
+
+// FileOpener streams a file after the caller checked the access to it
+type FileOpener interface {
+	OpenFile(ctx context.Context, userID, fileID int64) (*file.File, io.ReadCloser, error)
+}
+
+// ShareChecker returns the access of a user to an item of another user or share.ErrShareNotFound
+type ShareChecker interface {
+	Access(ctx context.Context, userID int64, item share.Item) (*share.Access, error)
+}
+
+// OrgChecker checks the role of a member against an action, non-members get org.ErrOrgNotFound
+type OrgChecker interface {
+	Authorize(ctx context.Context, userID, orgID int64, action string) error
+}
+
+type Attachments struct {
+	repo   Repository
+	files  FileOpener
+	shares ShareChecker
+	orgs   OrgChecker
+}
+
+// New creates the use case, without shares and orgs only owners reach the attachments of their items
+func New(repo Repository, files FileOpener, shares ShareChecker, orgs OrgChecker) *Attachments {
+	return &Attachments{repo: repo, files: files, shares: shares, orgs: orgs}
+}
+
+// ListAttachments returns the files attached to an item the user can read
+func (a *Attachments) ListAttachments(ctx context.Context, userID int64, item domain.Item) ([]*domain.Attachment, error) {
+	if err := a.checkItem(ctx, userID, item, org.ActionRead); err != nil {
+		return nil, err
+	}
+
+	list, err := a.repo.ListByItem(ctx, item)
+	if err != nil {
+		return nil, fmt.Errorf("list attachments of %s %d: %w", item.Type, item.ID, err)
+	}
+
+	if len(list) == 0 {
+		return nil, domain.ErrEmptyAttachmentsList
+	}
+
+	return list, nil
+}
+
+// Download streams a file attached to an item the user can read, the file may belong to another user
+func (a *Attachments) Download(ctx context.Context, userID int64, item domain.Item, fileID int64) (*file.File, io.ReadCloser, error) {
+	if err := a.checkItem(ctx, userID, item, org.ActionRead); err != nil {
+		return nil, nil, err
+	}
+
+	if fileID <= 0 {
+		return nil, nil, domain.ErrInvalidFileID
+	}
+
+	list, err := a.repo.ListByItem(ctx, item)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list attachments of %s %d: %w", item.Type, item.ID, err)
+	}
+
+	attached := false
+	for _, at := range list {
+		if at.FileID == fileID {
+			attached = true
+			break
+		}
+	}
+	if !attached {
+		return nil, nil, domain.ErrAttachmentNotFound
+	}
+
+	f, rc, err := a.files.OpenFile(ctx, userID, fileID)
+	if err != nil {
+		if errors.Is(err, file.ErrFileNotFound) {
+			return nil, nil, domain.ErrFileNotFound
+		}
+		return nil, nil, fmt.Errorf("open file id=%d: %w", fileID, err)
+	}
+
+	return f, rc, nil
+}
+
+// Attach links a file of the user to an item the user can change
+func (a *Attachments) Attach(ctx context.Context, userID int64, item domain.Item, fileID int64) (int64, error) {
+	if err := a.checkItem(ctx, userID, item, org.ActionUpdate); err != nil {
+		return 0, err
+	}
+
+	if fileID <= 0 {
+		return 0, domain.ErrInvalidFileID
+	}
+
+	owner, err := a.repo.FileOwner(ctx, fileID)
+	if err != nil {
+		if errors.Is(err, domain.ErrFileNotFound) {
+			return 0, err
+		}
+		return 0, fmt.Errorf("get file id=%d: %w", fileID, err)
+	}
+
+	// files of other users are not revealed
+	if owner != userID {
+		return 0, domain.ErrFileNotFound
+	}
+
+	return a.repo.Attach(ctx, userID, item, fileID)
+}
+
+// Detach removes the link, the file itself is kept
+func (a *Attachments) Detach(ctx context.Context, userID int64, item domain.Item, fileID int64) error {
+	if err := a.checkItem(ctx, userID, item, org.ActionUpdate); err != nil {
+		return err
+	}
+
+	if fileID <= 0 {
+		return domain.ErrInvalidFileID
+	}
+
+	return a.repo.Detach(ctx, item, fileID)
+}
+
+// help func
+
+// checkItem validates the item and the access of the user to it, items the user can not read
+// are reported as not found
+func (a *Attachments) checkItem(ctx context.Context, userID int64, item domain.Item, action string) error {
+	if userID <= 0 {
+		return domain.ErrInvalidUserID
+	}
+
+	switch item.Type {
+	case domain.ItemAccount, domain.ItemBankCard, domain.ItemText:
+	default:
+		return domain.ErrInvalidItem
+	}
+
+	if item.ID <= 0 {
+		return domain.ErrInvalidItemID
+	}
+
+	owner, err := a.repo.ItemOwner(ctx, item)
+	if err != nil {
+		if errors.Is(err, domain.ErrItemNotFound) {
+			return err
+		}
+		return fmt.Errorf("get %s id=%d: %w", item.Type, item.ID, err)
+	}
+
+	return a.authorize(ctx, userID, item, owner, action)
+}
+
+// authorize checks the role for organization items, then the owner, then a share of the item
+func (a *Attachments) authorize(ctx context.Context, userID int64, item domain.Item, owner domain.Owner, action string) error {
+	// item types of attachments and shares are the same strings
+	_, err := access.Check(ctx, a.orgs, a.shares, userID, access.Item{
+		OrgID:   owner.OrgID,
+		OwnerID: owner.UserID,
+		Share:   share.Item{Type: item.Type, ID: item.ID},
+	}, action)
+	if errors.Is(err, access.ErrNotFound) {
+		return domain.ErrItemNotFound
+	}
+	return err
+}
@@ -0,0 +1,311 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	domain "server/internal/app/domain/attachment"
+	file "server/internal/app/domain/file_obj"
+	"server/internal/app/domain/org"
+	"server/internal/app/domain/share"
+)
+
+type repoFake struct {
+	itemOwner  func(ctx context.Context, item domain.Item) (domain.Owner, error)
+	fileOwner  func(ctx context.Context, fileID int64) (int64, error)
+	listByItem func(ctx context.Context, item domain.Item) ([]*domain.Attachment, error)
+	attach     func(ctx context.Context, userID int64, item domain.Item, fileID int64) (int64, error)
+	detach     func(ctx context.Context, item domain.Item, fileID int64) error
+}
+
+func (r *repoFake) ItemOwner(ctx context.Context, item domain.Item) (domain.Owner, error) {
+	if r.itemOwner != nil {
+		return r.itemOwner(ctx, item)
+	}
+	return domain.Owner{UserID: 7}, nil
+}
+func (r *repoFake) FileOwner(ctx context.Context, fileID int64) (int64, error) {
+	if r.fileOwner != nil {
+		return r.fileOwner(ctx, fileID)
+	}
+	return 7, nil
+}
+func (r *repoFake) ListByItem(ctx context.Context, item domain.Item) ([]*domain.Attachment, error) {
+	if r.listByItem != nil {
+		return r.listByItem(ctx, item)
+	}
+	return nil, nil
+}
+func (r *repoFake) Attach(ctx context.Context, userID int64, item domain.Item, fileID int64) (int64, error) {
+	if r.attach != nil {
+		return r.attach(ctx, userID, item, fileID)
+	}
+	return 1, nil
+}
+func (r *repoFake) Detach(ctx context.Context, item domain.Item, fileID int64) error {
+	if r.detach != nil {
+		return r.detach(ctx, item, fileID)
+	}
+	return nil
+}
+
+// sharesFake shares item 3 of user 8 with user 7 read-only and item 4 with write access
+type sharesFake struct{}
+
+func (sharesFake) Access(ctx context.Context, userID int64, item share.Item) (*share.Access, error) {
+	if userID != 7 {
+		return nil, share.ErrShareNotFound
+	}
+	switch item.ID {
+	case 3:
+		return &share.Access{OwnerID: 8, Permission: share.PermRead}, nil
+	case 4:
+		return &share.Access{OwnerID: 8, Permission: share.PermWrite}, nil
+	default:
+		return nil, share.ErrShareNotFound
+	}
+}
+
+// orgsFake makes user 7 a viewer of organization 2, other organizations don't know the user
+type orgsFake struct{}
+
+func (orgsFake) Authorize(ctx context.Context, userID, orgID int64, action string) error {
+	if userID != 7 || orgID != 2 {
+		return org.ErrOrgNotFound
+	}
+	if !org.Allowed(org.RoleViewer, action) {
+		return org.ErrForbidden
+	}
+	return nil
+}
+
+type filesFake struct {
+	opened int64
+}
+
+func (f *filesFake) OpenFile(ctx context.Context, userID, fileID int64) (*file.File, io.ReadCloser, error) {
+	f.opened = fileID
+	return &file.File{ID: fileID, UserID: 8, Title: "scan.pdf"}, io.NopCloser(strings.NewReader("pdf")), nil
+}
+
+func ownedBy(owner domain.Owner) func(ctx context.Context, item domain.Item) (domain.Owner, error) {
+	return func(ctx context.Context, item domain.Item) (domain.Owner, error) { return owner, nil }
+}
+
+func TestAttachments_Authorize(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	attached := func(ctx context.Context, item domain.Item) ([]*domain.Attachment, error) {
+		return []*domain.Attachment{{FileID: 5}}, nil
+	}
+
+	tests := []struct {
+		name    string
+		owner   domain.Owner
+		item    domain.Item
+		attach  bool
+		wantErr error
+	}{
+		{name: "read-only share -> list ok", owner: domain.Owner{UserID: 8}, item: domain.Item{Type: domain.ItemText, ID: 3}},
+		{name: "read-only share -> attach ErrReadOnly", owner: domain.Owner{UserID: 8}, item: domain.Item{Type: domain.ItemText, ID: 3}, attach: true, wantErr: share.ErrReadOnly},
+		{name: "write share -> attach ok", owner: domain.Owner{UserID: 8}, item: domain.Item{Type: domain.ItemText, ID: 4}, attach: true},
+		{name: "not shared -> ErrItemNotFound", owner: domain.Owner{UserID: 8}, item: domain.Item{Type: domain.ItemText, ID: 9}, wantErr: domain.ErrItemNotFound},
+		{name: "org member -> list ok", owner: domain.Owner{UserID: 8, OrgID: 2}, item: domain.Item{Type: domain.ItemAccount, ID: 9}},
+		{name: "org viewer -> attach org.ErrForbidden", owner: domain.Owner{UserID: 8, OrgID: 2}, item: domain.Item{Type: domain.ItemAccount, ID: 9}, attach: true, wantErr: org.ErrForbidden},
+		{name: "creator who left the org -> ErrItemNotFound", owner: domain.Owner{UserID: 7, OrgID: 5}, item: domain.Item{Type: domain.ItemAccount, ID: 9}, wantErr: domain.ErrItemNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc := New(&repoFake{itemOwner: ownedBy(tc.owner), listByItem: attached}, &filesFake{}, sharesFake{}, orgsFake{})
+
+			var err error
+			if tc.attach {
+				_, err = uc.Attach(ctx, 7, tc.item, 5)
+			} else {
+				_, err = uc.ListAttachments(ctx, 7, tc.item)
+			}
+
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAttachments_Download(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	shared := domain.Item{Type: domain.ItemText, ID: 3}
+	repo := &repoFake{
+		itemOwner: ownedBy(domain.Owner{UserID: 8}),
+		listByItem: func(ctx context.Context, item domain.Item) ([]*domain.Attachment, error) {
+			return []*domain.Attachment{{FileID: 5}}, nil
+		},
+	}
+
+	t.Run("file not attached -> ErrAttachmentNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		files := &filesFake{}
+		if _, _, err := New(repo, files, sharesFake{}, orgsFake{}).Download(ctx, 7, shared, 6); !errors.Is(err, domain.ErrAttachmentNotFound) {
+			t.Fatalf("expected ErrAttachmentNotFound, got: %v", err)
+		}
+		if files.opened != 0 {
+			t.Fatalf("file must not be opened, opened=%d", files.opened)
+		}
+	})
+
+	t.Run("share recipient -> file of the owner", func(t *testing.T) {
+		t.Parallel()
+
+		files := &filesFake{}
+		f, rc, err := New(repo, files, sharesFake{}, orgsFake{}).Download(ctx, 7, shared, 5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer rc.Close()
+
+		if f.ID != 5 || files.opened != 5 {
+			t.Fatalf("unexpected file: %+v, opened=%d", f, files.opened)
+		}
+	})
+
+	t.Run("no access -> ErrItemNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		if _, _, err := New(repo, &filesFake{}, sharesFake{}, orgsFake{}).Download(ctx, 9, shared, 5); !errors.Is(err, domain.ErrItemNotFound) {
+			t.Fatalf("expected ErrItemNotFound, got: %v", err)
+		}
+	})
+}
+
+func TestAttachments_Attach(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	card := domain.Item{Type: domain.ItemBankCard, ID: 3}
+
+	tests := []struct {
+		name    string
+		repo    *repoFake
+		userID  int64
+		item    domain.Item
+		fileID  int64
+		wantErr error
+	}{
+		{name: "invalid user -> ErrInvalidUserID", repo: &repoFake{}, userID: 0, item: card, fileID: 5, wantErr: domain.ErrInvalidUserID},
+		{name: "unknown type -> ErrInvalidItem", repo: &repoFake{}, userID: 7, item: domain.Item{Type: "file", ID: 3}, fileID: 5, wantErr: domain.ErrInvalidItem},
+		{name: "invalid item id -> ErrInvalidItemID", repo: &repoFake{}, userID: 7, item: domain.Item{Type: domain.ItemText}, fileID: 5, wantErr: domain.ErrInvalidItemID},
+		{name: "invalid file id -> ErrInvalidFileID", repo: &repoFake{}, userID: 7, item: card, fileID: 0, wantErr: domain.ErrInvalidFileID},
+		{
+			name:    "item of another user -> ErrItemNotFound",
+			repo:    &repoFake{itemOwner: func(ctx context.Context, item domain.Item) (domain.Owner, error) { return domain.Owner{UserID: 8}, nil }},
+			userID:  7,
+			item:    card,
+			fileID:  5,
+			wantErr: domain.ErrItemNotFound,
+		},
+		{
+			name:    "file of another user -> ErrFileNotFound",
+			repo:    &repoFake{fileOwner: func(ctx context.Context, fileID int64) (int64, error) { return 8, nil }},
+			userID:  7,
+			item:    card,
+			fileID:  5,
+			wantErr: domain.ErrFileNotFound,
+		},
+		{
+			name: "already attached -> ErrAlreadyAttached",
+			repo: &repoFake{attach: func(ctx context.Context, userID int64, item domain.Item, fileID int64) (int64, error) {
+				return 0, domain.ErrAlreadyAttached
+			}},
+			userID:  7,
+			item:    card,
+			fileID:  5,
+			wantErr: domain.ErrAlreadyAttached,
+		},
+		{name: "ok", repo: &repoFake{}, userID: 7, item: card, fileID: 5},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(tc.repo, nil, nil, nil).Attach(ctx, tc.userID, tc.item, tc.fileID)
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAttachments_ListAttachments(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	text := domain.Item{Type: domain.ItemText, ID: 2}
+
+	t.Run("empty -> ErrEmptyAttachmentsList", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := New(&repoFake{}, nil, nil, nil).ListAttachments(ctx, 7, text); !errors.Is(err, domain.ErrEmptyAttachmentsList) {
+			t.Fatalf("expected ErrEmptyAttachmentsList, got: %v", err)
+		}
+	})
+
+	t.Run("ok -> list of the item", func(t *testing.T) {
+		t.Parallel()
+
+		var got domain.Item
+		uc := New(&repoFake{
+			listByItem: func(ctx context.Context, item domain.Item) ([]*domain.Attachment, error) {
+				got = item
+				return []*domain.Attachment{{FileID: 5}}, nil
+			},
+		}, nil, nil, nil)
+
+		list, err := uc.ListAttachments(ctx, 7, text)
+		if err != nil || len(list) != 1 || got != text {
+			t.Fatalf("unexpected result: %v, %v, item=%+v", list, err, got)
+		}
+	})
+}
+
+func TestAttachments_Detach(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	account := domain.Item{Type: domain.ItemAccount, ID: 4}
+
+	uc := New(&repoFake{
+		detach: func(ctx context.Context, item domain.Item, fileID int64) error {
+			return domain.ErrAttachmentNotFound
+		},
+	}, nil, nil, nil)
+
+	if err := uc.Detach(ctx, 7, account, 5); !errors.Is(err, domain.ErrAttachmentNotFound) {
+		t.Fatalf("expected ErrAttachmentNotFound, got: %v", err)
+	}
+	if err := uc.Detach(ctx, 7, account, -1); !errors.Is(err, domain.ErrInvalidFileID) {
+		t.Fatalf("expected ErrInvalidFileID, got: %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	domain "server/internal/app/domain/audit"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 500
+	// verifyPage is how many events are read at once while walking the chain
+	verifyPage = 1000
+)
+
+type Repository interface {
+	// Append seals the event with the hash of the last stored one and stores it,
+	// appends are serialized so the chain has no forks
+	Append(ctx context.Context, e *domain.Event) error
+	// Search returns the newest events matching the filter first
+	Search(ctx context.Context, f domain.Filter) ([]*domain.Event, error)
+	// Chain returns up to limit events with id greater than afterID in id order
+	Chain(ctx context.Context, afterID int64, limit int) ([]*domain.Event, error)
+}
+
+type Audit struct {
+	repo Repository
+	now  func() time.Time
+
+	// last is the result of the latest Verify, users only see this one
+	mu   sync.RWMutex
+	last *domain.Verification
+}
+
+func New(repo Repository) *Audit {
+	return &Audit{repo: repo, now: time.Now}
+}
+
+// Record appends an event with the client of the request, a failed write is logged and
+// does not fail the action that is audited
+func (a *Audit) Record(ctx context.Context, e domain.Event) {
+	client := domain.ClientFrom(ctx)
+	e.IP, e.UserAgent = client.IP, client.UserAgent
+	// postgres keeps microseconds, the digest must survive the round trip
+	e.CreatedAt = a.now().UTC().Truncate(time.Microsecond)
+
+	// the action may be cancelled by the client after it happened, the event is still written
+	if err := a.repo.Append(context.WithoutCancel(ctx), &e); err != nil {
+		logger.Log.Error("audit: append failed",
+			zap.String("action", e.Action), zap.Int64("user_id", e.UserID), zap.Error(err))
+	}
+}
+
+// Search returns events of the caller and events on items the caller owns
+func (a *Audit) Search(ctx context.Context, f domain.Filter) ([]*domain.Event, error) {
+	if f.UserID <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if f.Action != "" && !domain.ValidAction(f.Action) {
+		return nil, domain.ErrInvalidAction
+	}
+
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return nil, domain.ErrInvalidRange
+	}
+
+	if f.Limit == 0 {
+		f.Limit = defaultLimit
+	}
+	if f.Limit < 0 || f.Limit > maxLimit {
+		return nil, domain.ErrInvalidLimit
+	}
+
+	list, err := a.repo.Search(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("search audit events of user id=%d: %w", f.UserID, err)
+	}
+
+	if len(list) == 0 {
+		return nil, domain.ErrEmptyLog
+	}
+
+	return list, nil
+}
+
+// LastVerification returns the result of the latest chain walk, the walk itself runs as a job
+// because it reads the whole log
+func (a *Audit) LastVerification(ctx context.Context) (*domain.Verification, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.last == nil {
+		return nil, domain.ErrNotVerified
+	}
+
+	res := *a.last
+	return &res, nil
+}
+
+// Verify walks the whole chain and reports the first event that was changed, removed
+// or inserted out of order, the result is kept for LastVerification
+func (a *Audit) Verify(ctx context.Context) (*domain.Verification, error) {
+	res, err := a.verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	a.last = res
+	a.mu.Unlock()
+
+	return res, nil
+}
+
+func (a *Audit) verify(ctx context.Context) (*domain.Verification, error) {
+	res := &domain.Verification{Valid: true, VerifiedAt: a.now()}
+
+	var (
+		prev    []byte
+		afterID int64
+	)
+	for {
+		page, err := a.repo.Chain(ctx, afterID, verifyPage)
+		if err != nil {
+			return nil, fmt.Errorf("read audit chain after id=%d: %w", afterID, err)
+		}
+
+		for _, e := range page {
+			if !e.Follows(prev) {
+				res.Valid, res.BrokenAt = false, e.ID
+				return res, nil
+			}
+			prev, afterID = e.Hash, e.ID
+			res.Checked++
+		}
+
+		if len(page) < verifyPage {
+			return res, nil
+		}
+	}
+}
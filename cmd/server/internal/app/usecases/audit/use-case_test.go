@@ -0,0 +1,180 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "server/internal/app/domain/audit"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// repoFake appends like the database does, events are sealed with the last hash
+type repoFake struct {
+	events    []*domain.Event
+	appendErr error
+	lastF     domain.Filter
+}
+
+func (r *repoFake) Append(ctx context.Context, e *domain.Event) error {
+	if r.appendErr != nil {
+		return r.appendErr
+	}
+	var prev []byte
+	if n := len(r.events); n > 0 {
+		prev = r.events[n-1].Hash
+	}
+	e.ID = int64(len(r.events) + 1)
+	e.Seal(prev)
+	cp := *e
+	r.events = append(r.events, &cp)
+	return nil
+}
+
+func (r *repoFake) Search(ctx context.Context, f domain.Filter) ([]*domain.Event, error) {
+	r.lastF = f
+	var out []*domain.Event
+	for _, e := range r.events {
+		if e.UserID == f.UserID || e.OwnerID == f.UserID {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (r *repoFake) Chain(ctx context.Context, afterID int64, limit int) ([]*domain.Event, error) {
+	var out []*domain.Event
+	for _, e := range r.events {
+		if e.ID > afterID && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func newAt(repo Repository, now time.Time) *Audit {
+	a := New(repo)
+	a.now = func() time.Time { return now }
+	return a
+}
+
+func TestAudit_RecordTakesClientFromContext(t *testing.T) {
+	repo := &repoFake{}
+	now := time.Date(2026, 5, 1, 12, 0, 0, 123456789, time.UTC)
+
+	ctx := domain.WithClient(context.Background(), domain.Client{IP: "10.0.0.1", UserAgent: "cli/1.0"})
+	newAt(repo, now).Record(ctx, domain.Event{UserID: 1, Action: domain.ActionItemRead, ItemType: domain.ItemAccount, ItemID: 4})
+
+	if len(repo.events) != 1 {
+		t.Fatalf("expected one event, got %d", len(repo.events))
+	}
+	e := repo.events[0]
+	if e.IP != "10.0.0.1" || e.UserAgent != "cli/1.0" {
+		t.Fatalf("client not recorded: %+v", e)
+	}
+	if !e.CreatedAt.Equal(now.Truncate(time.Microsecond)) {
+		t.Fatalf("expected time truncated to microseconds, got %s", e.CreatedAt)
+	}
+}
+
+func TestAudit_RecordFailureDoesNotPanic(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	New(&repoFake{appendErr: errors.New("db down")}).Record(context.Background(), domain.Event{UserID: 1, Action: domain.ActionLogin})
+}
+
+func TestAudit_Verify(t *testing.T) {
+	repo := &repoFake{}
+	a := newAt(repo, time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	for i := 0; i < 3; i++ {
+		a.Record(context.Background(), domain.Event{UserID: 1, Action: domain.ActionItemRead, ItemType: domain.ItemText, ItemID: int64(i + 1)})
+	}
+
+	res, err := a.Verify(context.Background())
+	if err != nil || !res.Valid || res.Checked != 3 {
+		t.Fatalf("Verify = %+v, %v", res, err)
+	}
+
+	// somebody hides which text was read
+	repo.events[1].ItemID = 99
+
+	res, err = a.Verify(context.Background())
+	if err != nil || res.Valid || res.BrokenAt != 2 || res.Checked != 1 {
+		t.Fatalf("expected the chain to break at 2, got %+v, %v", res, err)
+	}
+}
+
+func TestAudit_LastVerification(t *testing.T) {
+	repo := &repoFake{}
+	a := newAt(repo, time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+	a.Record(context.Background(), domain.Event{UserID: 1, Action: domain.ActionLogin})
+
+	if _, err := a.LastVerification(context.Background()); !errors.Is(err, domain.ErrNotVerified) {
+		t.Fatalf("expected ErrNotVerified before the first walk, got %v", err)
+	}
+
+	if _, err := a.Verify(context.Background()); err != nil {
+		t.Fatalf("Verify error: %v", err)
+	}
+
+	res, err := a.LastVerification(context.Background())
+	if err != nil || !res.Valid || !res.VerifiedAt.Equal(time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("LastVerification = %+v, %v", res, err)
+	}
+}
+
+func TestAudit_VerifyDetectsRemovedEvent(t *testing.T) {
+	repo := &repoFake{}
+	a := newAt(repo, time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	for i := 0; i < 3; i++ {
+		a.Record(context.Background(), domain.Event{UserID: 1, Action: domain.ActionLogin})
+	}
+	repo.events = append(repo.events[:1], repo.events[2:]...)
+
+	res, err := a.Verify(context.Background())
+	if err != nil || res.Valid || res.BrokenAt != 3 {
+		t.Fatalf("expected the chain to break at 3, got %+v, %v", res, err)
+	}
+}
+
+func TestAudit_Search(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  domain.Filter
+		wantErr error
+	}{
+		{name: "invalid user", filter: domain.Filter{}, wantErr: domain.ErrInvalidUserID},
+		{name: "unknown action", filter: domain.Filter{UserID: 1, Action: "peek"}, wantErr: domain.ErrInvalidAction},
+		{name: "inverted range", filter: domain.Filter{UserID: 1, From: time.Unix(200, 0), To: time.Unix(100, 0)}, wantErr: domain.ErrInvalidRange},
+		{name: "limit too large", filter: domain.Filter{UserID: 1, Limit: maxLimit + 1}, wantErr: domain.ErrInvalidLimit},
+		{name: "nothing found", filter: domain.Filter{UserID: 7}, wantErr: domain.ErrEmptyLog},
+		{name: "owner sees reads of others", filter: domain.Filter{UserID: 1, Action: domain.ActionItemRead}},
+	}
+
+	repo := &repoFake{}
+	a := New(repo)
+	a.Record(context.Background(), domain.Event{UserID: 2, OwnerID: 1, Action: domain.ActionItemRead, ItemType: domain.ItemAccount, ItemID: 4})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := a.Search(context.Background(), tt.filter)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil || len(list) != 1 {
+				t.Fatalf("Search = %v, %v", list, err)
+			}
+			if repo.lastF.Limit != defaultLimit {
+				t.Fatalf("expected default limit, got %d", repo.lastF.Limit)
+			}
+		})
+	}
+}
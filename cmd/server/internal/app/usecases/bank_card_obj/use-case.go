@@ -2,8 +2,16 @@ package bank_card_obj
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"server/internal/app/domain/audit"
 	domain "server/internal/app/domain/bank_card_obj"
+	"server/internal/app/domain/custom_field"
+	"server/internal/app/domain/org"
+	"server/internal/app/domain/share"
+	"server/internal/app/usecases/access"
 )
 
 type Repository interface {
@@ -11,26 +19,54 @@ type Repository interface {
 	GetByID(ctx context.Context, cardId int64) (*domain.BankCard, error)
 	Create(ctx context.Context, card *domain.BankCard) (int64, error)
 	Update(ctx context.Context, card *domain.BankCard) error
+	Delete(ctx context.Context, cardId int64) error
+}
+
+// ShareChecker returns the access of a user to an item of another user or share.ErrShareNotFound
+type ShareChecker interface {
+	Access(ctx context.Context, userId int64, item share.Item) (*share.Access, error)
+}
+
+// OrgChecker checks the role of a member against an action, non-members get org.ErrOrgNotFound
+type OrgChecker interface {
+	Authorize(ctx context.Context, userId, orgId int64, action string) error
+}
+
+// Auditor writes access to items into the audit log
+type Auditor interface {
+	Record(ctx context.Context, e audit.Event)
 }
 
 type BankCardObj struct {
-	repo Repository
+	repo   Repository
+	shares ShareChecker
+	orgs   OrgChecker
+	audit  Auditor
 }
 
-func New(repo Repository) *BankCardObj {
-	return &BankCardObj{repo: repo}
+// New creates the use case, without shares only owners can open their cards,
+// without auditor nothing is logged
+func New(repo Repository, shares ShareChecker, orgs OrgChecker, auditor Auditor) *BankCardObj {
+	return &BankCardObj{repo: repo, shares: shares, orgs: orgs, audit: auditor}
 }
 
-func (b *BankCardObj) GetBankCard(ctx context.Context, cardId int64) (*domain.BankCard, error) {
+// GetBankCard returns a card of the user or one shared with the user
+func (b *BankCardObj) GetBankCard(ctx context.Context, userId, cardId int64) (*domain.BankCard, error) {
 	if cardId <= 0 {
 		return nil, domain.ErrInvalidCardID
 	}
 
 	card, err := b.repo.GetByID(ctx, cardId)
-	if err != nil {
+	if err != nil || card == nil {
 		return nil, domain.ErrBankCardNotFound
 	}
 
+	if err := b.authorize(ctx, userId, card, org.ActionRead); err != nil {
+		return nil, err
+	}
+
+	b.record(ctx, userId, card, audit.ActionItemRead)
+
 	return card, nil
 }
 
@@ -60,12 +96,14 @@ func (b *BankCardObj) CreateNewBankCardObj(ctx context.Context, card *domain.Ban
 		return 0, domain.ErrInvalidUserID
 	}
 
-	if card.Bank == "" {
-		return 0, domain.ErrEmptyBankName
+	if err := validateCard(card, time.Now(), true); err != nil {
+		return 0, err
 	}
 
-	if card.Pid == "" {
-		return 0, domain.ErrEmptyPID
+	if card.OrgID != 0 {
+		if err := b.authorizeOrg(ctx, card.UserId, card.OrgID, org.ActionCreate); err != nil {
+			return 0, err
+		}
 	}
 
 	id, err := b.repo.Create(ctx, card)
@@ -73,9 +111,13 @@ func (b *BankCardObj) CreateNewBankCardObj(ctx context.Context, card *domain.Ban
 		return 0, domain.ErrFaildeCreateBankCardObject
 	}
 
+	card.CardId = id
+	b.record(ctx, card.UserId, card, audit.ActionCreate)
+
 	return id, nil
 }
 
+// UpdateBankCard saves the card for its owner or a user with a write share, card.UserId is the caller
 func (b *BankCardObj) UpdateBankCard(ctx context.Context, card *domain.BankCard) error {
 	if card == nil {
 		return domain.ErrFailedUpdateBankCard
@@ -85,17 +127,131 @@ func (b *BankCardObj) UpdateBankCard(ctx context.Context, card *domain.BankCard)
 		return domain.ErrInvalidUserID
 	}
 
-	if card.Bank == "" {
-		return domain.ErrEmptyBankName
+	// a saved card stays editable after its expiry, e.g. to enter the dates of the renewed one
+	if err := validateCard(card, time.Now(), false); err != nil {
+		return err
+	}
+
+	current, err := b.repo.GetByID(ctx, card.CardId)
+	if err != nil || current == nil {
+		return domain.ErrBankCardNotFound
 	}
 
-	if card.Pid == "" {
-		return domain.ErrEmptyPID
+	if err := b.authorize(ctx, card.UserId, current, org.ActionUpdate); err != nil {
+		return err
 	}
 
 	if err := b.repo.Update(ctx, card); err != nil {
 		return domain.ErrFailedUpdateBankCard
 	}
 
+	b.record(ctx, card.UserId, current, audit.ActionUpdate)
+
+	return nil
+}
+
+// DeleteBankCard removes the card for its owner or a member whose role allows deleting
+func (b *BankCardObj) DeleteBankCard(ctx context.Context, userId, cardId int64) error {
+	if cardId <= 0 {
+		return domain.ErrInvalidCardID
+	}
+
+	current, err := b.repo.GetByID(ctx, cardId)
+	if err != nil || current == nil {
+		return domain.ErrBankCardNotFound
+	}
+
+	if err := b.authorize(ctx, userId, current, org.ActionDelete); err != nil {
+		return err
+	}
+
+	if err := b.repo.Delete(ctx, cardId); err != nil {
+		return domain.ErrFailedDeleteBankCard
+	}
+
+	b.record(ctx, userId, current, audit.ActionDelete)
+
+	return nil
+}
+
+// authorize checks items of organizations by the member role, personal items by ownership and shares,
+// cards without access are reported as not found
+func (b *BankCardObj) authorize(ctx context.Context, userId int64, card *domain.BankCard, action string) error {
+	shared, err := access.Check(ctx, b.orgs, b.shares, userId, access.Item{
+		OrgID:   card.OrgID,
+		OwnerID: card.UserId,
+		Share:   share.Item{Type: share.ItemBankCard, ID: card.CardId},
+	}, action)
+	if err != nil {
+		if errors.Is(err, access.ErrNotFound) {
+			return domain.ErrBankCardNotFound
+		}
+		return err
+	}
+
+	card.Shared = shared
 	return nil
 }
+
+// authorizeOrg checks the role of the caller in the organization, without orgs nobody is a member
+func (b *BankCardObj) authorizeOrg(ctx context.Context, userId, orgId int64, action string) error {
+	if b.orgs == nil {
+		return org.ErrOrgNotFound
+	}
+	return b.orgs.Authorize(ctx, userId, orgId, action)
+}
+
+// validateCard normalizes the card in place and checks number, expiry, CVV and PIN,
+// a card past its expiry month is refused only when it is a new one
+func validateCard(card *domain.BankCard, now time.Time, newCard bool) error {
+	if card.Bank == "" {
+		return domain.ErrEmptyBankName
+	}
+
+	card.Number = domain.NormalizeNumber(card.Number)
+	if card.Number == "" {
+		return domain.ErrEmptyCardNumber
+	}
+	if len(card.Number) < 12 || len(card.Number) > 19 || !domain.ValidLuhn(card.Number) {
+		return domain.ErrInvalidCardNumber
+	}
+
+	card.ExpiryYear = domain.NormalizeExpiryYear(card.ExpiryYear)
+	if card.ExpiryMonth < 1 || card.ExpiryMonth > 12 || card.ExpiryYear < 2000 {
+		return domain.ErrInvalidExpiry
+	}
+	if newCard && domain.Expired(card.ExpiryMonth, card.ExpiryYear, now) {
+		return domain.ErrCardExpired
+	}
+	if card.ExpiresAt.IsZero() {
+		card.ExpiresAt = domain.ExpiryEnd(card.ExpiryMonth, card.ExpiryYear, time.UTC)
+	}
+
+	if card.CVV != "" && (!domain.IsDigits(card.CVV) || len(card.CVV) < 3 || len(card.CVV) > 4) {
+		return domain.ErrInvalidCVV
+	}
+
+	if card.PIN != "" && (!domain.IsDigits(card.PIN) || len(card.PIN) < 4 || len(card.PIN) > 12) {
+		return domain.ErrInvalidPIN
+	}
+
+	if err := custom_field.Normalize(card.CustomFields); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInvalidCustomFields, err)
+	}
+
+	return nil
+}
+
+// record writes the action of the caller on the card into the audit log
+func (b *BankCardObj) record(ctx context.Context, userId int64, card *domain.BankCard, action string) {
+	if b.audit == nil {
+		return
+	}
+	b.audit.Record(ctx, audit.Event{
+		UserID:   userId,
+		OwnerID:  card.UserId,
+		Action:   action,
+		ItemType: audit.ItemBankCard,
+		ItemID:   card.CardId,
+	})
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	domain "server/internal/app/domain/bank_card_obj"
+	"server/internal/app/domain/org"
 )
 
 type repoFake struct {
@@ -13,6 +15,7 @@ type repoFake struct {
 	getByID     func(ctx context.Context, cardId int64) (*domain.BankCard, error)
 	create      func(ctx context.Context, card *domain.BankCard) (int64, error)
 	update      func(ctx context.Context, card *domain.BankCard) error
+	delete      func(ctx context.Context, cardId int64) error
 }
 
 func (r *repoFake) GetByUserID(ctx context.Context, userId int64) ([]*domain.BankCard, error) {
@@ -26,7 +29,7 @@ func (r *repoFake) GetByID(ctx context.Context, cardId int64) (*domain.BankCard,
 	if r.getByID != nil {
 		return r.getByID(ctx, cardId)
 	}
-	return nil, nil
+	return &domain.BankCard{CardId: cardId, UserId: 1}, nil
 }
 
 func (r *repoFake) Create(ctx context.Context, card *domain.BankCard) (int64, error) {
@@ -43,6 +46,29 @@ func (r *repoFake) Update(ctx context.Context, card *domain.BankCard) error {
 	return nil
 }
 
+func (r *repoFake) Delete(ctx context.Context, cardId int64) error {
+	if r.delete != nil {
+		return r.delete(ctx, cardId)
+	}
+	return nil
+}
+
+// card returns a valid card, modify adjusts it for a single case
+func card(modify func(c *domain.BankCard)) *domain.BankCard {
+	c := &domain.BankCard{
+		UserId:      1,
+		Bank:        "maib",
+		Number:      "4242424242424242",
+		ExpiryMonth: 12,
+		ExpiryYear:  time.Now().Year() + 2,
+		CVV:         "123",
+	}
+	if modify != nil {
+		modify(c)
+	}
+	return c
+}
+
 func TestBankCardObj_GetBankCard(t *testing.T) {
 	t.Parallel()
 
@@ -51,8 +77,8 @@ func TestBankCardObj_GetBankCard(t *testing.T) {
 	t.Run("invalid cardId -> ErrInvalidCardID", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
-		_, err := uc.GetBankCard(ctx, 0)
+		uc := New(&repoFake{}, nil, nil, nil)
+		_, err := uc.GetBankCard(ctx, 1, 0)
 		if !errors.Is(err, domain.ErrInvalidCardID) {
 			t.Fatalf("expected ErrInvalidCardID, got: %v", err)
 		}
@@ -65,9 +91,9 @@ func TestBankCardObj_GetBankCard(t *testing.T) {
 			getByID: func(ctx context.Context, cardId int64) (*domain.BankCard, error) {
 				return nil, errors.New("db error")
 			},
-		})
+		}, nil, nil, nil)
 
-		_, err := uc.GetBankCard(ctx, 10)
+		_, err := uc.GetBankCard(ctx, 1, 10)
 		if !errors.Is(err, domain.ErrBankCardNotFound) {
 			t.Fatalf("expected ErrBankCardNotFound, got: %v", err)
 		}
@@ -76,15 +102,15 @@ func TestBankCardObj_GetBankCard(t *testing.T) {
 	t.Run("ok -> returns card", func(t *testing.T) {
 		t.Parallel()
 
-		want := &domain.BankCard{CardId: 7, UserId: 1, Bank: "maib", Pid: "PID123"}
+		want := &domain.BankCard{CardId: 7, UserId: 1, Bank: "maib", Number: "4242424242424242"}
 
 		uc := New(&repoFake{
 			getByID: func(ctx context.Context, cardId int64) (*domain.BankCard, error) {
 				return want, nil
 			},
-		})
+		}, nil, nil, nil)
 
-		got, err := uc.GetBankCard(ctx, 7)
+		got, err := uc.GetBankCard(ctx, 1, 7)
 		if err != nil {
 			t.Fatalf("expected nil err, got: %v", err)
 		}
@@ -102,7 +128,7 @@ func TestBankCardObj_GetBankCardList(t *testing.T) {
 	t.Run("invalid userId -> ErrInvalidUserID", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
+		uc := New(&repoFake{}, nil, nil, nil)
 		_, err := uc.GetBankCardList(ctx, -1)
 		if !errors.Is(err, domain.ErrInvalidUserID) {
 			t.Fatalf("expected ErrInvalidUserID, got: %v", err)
@@ -116,7 +142,7 @@ func TestBankCardObj_GetBankCardList(t *testing.T) {
 			getByUserID: func(ctx context.Context, userId int64) ([]*domain.BankCard, error) {
 				return nil, errors.New("select failed")
 			},
-		})
+		}, nil, nil, nil)
 
 		_, err := uc.GetBankCardList(ctx, 1)
 		if !errors.Is(err, domain.ErrBankCardNotFound) {
@@ -131,7 +157,7 @@ func TestBankCardObj_GetBankCardList(t *testing.T) {
 			getByUserID: func(ctx context.Context, userId int64) ([]*domain.BankCard, error) {
 				return []*domain.BankCard{}, nil
 			},
-		})
+		}, nil, nil, nil)
 
 		_, err := uc.GetBankCardList(ctx, 1)
 		if !errors.Is(err, domain.ErrBankCardNotFound) {
@@ -143,15 +169,15 @@ func TestBankCardObj_GetBankCardList(t *testing.T) {
 		t.Parallel()
 
 		want := []*domain.BankCard{
-			{CardId: 1, UserId: 1, Bank: "maib", Pid: "PID1"},
-			{CardId: 2, UserId: 1, Bank: "victoriabank", Pid: "PID2"},
+			{CardId: 1, UserId: 1, Bank: "maib", Number: "4242424242424242"},
+			{CardId: 2, UserId: 1, Bank: "victoriabank", Number: "5555555555554444"},
 		}
 
 		uc := New(&repoFake{
 			getByUserID: func(ctx context.Context, userId int64) ([]*domain.BankCard, error) {
 				return want, nil
 			},
-		})
+		}, nil, nil, nil)
 
 		got, err := uc.GetBankCardList(ctx, 1)
 		if err != nil {
@@ -174,7 +200,7 @@ func TestBankCardObj_CreateNewBankCardObj(t *testing.T) {
 	t.Run("nil card -> ErrFaildeCreateBankCardObject", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
+		uc := New(&repoFake{}, nil, nil, nil)
 		_, err := uc.CreateNewBankCardObj(ctx, nil)
 		if !errors.Is(err, domain.ErrFaildeCreateBankCardObject) {
 			t.Fatalf("expected ErrFaildeCreateBankCardObject, got: %v", err)
@@ -184,8 +210,8 @@ func TestBankCardObj_CreateNewBankCardObj(t *testing.T) {
 	t.Run("invalid userId -> ErrInvalidUserID", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
-		_, err := uc.CreateNewBankCardObj(ctx, &domain.BankCard{UserId: 0, Bank: "maib", Pid: "PID"})
+		uc := New(&repoFake{}, nil, nil, nil)
+		_, err := uc.CreateNewBankCardObj(ctx, card(func(c *domain.BankCard) { c.UserId = 0 }))
 		if !errors.Is(err, domain.ErrInvalidUserID) {
 			t.Fatalf("expected ErrInvalidUserID, got: %v", err)
 		}
@@ -194,20 +220,30 @@ func TestBankCardObj_CreateNewBankCardObj(t *testing.T) {
 	t.Run("empty bank -> ErrEmptyBankName", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
-		_, err := uc.CreateNewBankCardObj(ctx, &domain.BankCard{UserId: 1, Bank: "", Pid: "PID"})
+		uc := New(&repoFake{}, nil, nil, nil)
+		_, err := uc.CreateNewBankCardObj(ctx, card(func(c *domain.BankCard) { c.Bank = "" }))
 		if !errors.Is(err, domain.ErrEmptyBankName) {
 			t.Fatalf("expected ErrEmptyBankName, got: %v", err)
 		}
 	})
 
-	t.Run("empty pid -> ErrEmptyPID", func(t *testing.T) {
+	t.Run("empty number -> ErrEmptyCardNumber", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{}, nil, nil, nil)
+		_, err := uc.CreateNewBankCardObj(ctx, card(func(c *domain.BankCard) { c.Number = "" }))
+		if !errors.Is(err, domain.ErrEmptyCardNumber) {
+			t.Fatalf("expected ErrEmptyCardNumber, got: %v", err)
+		}
+	})
+
+	t.Run("expired card -> ErrCardExpired", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
-		_, err := uc.CreateNewBankCardObj(ctx, &domain.BankCard{UserId: 1, Bank: "maib", Pid: ""})
-		if !errors.Is(err, domain.ErrEmptyPID) {
-			t.Fatalf("expected ErrEmptyPID, got: %v", err)
+		uc := New(&repoFake{}, nil, nil, nil)
+		_, err := uc.CreateNewBankCardObj(ctx, card(func(c *domain.BankCard) { c.ExpiryMonth = 1; c.ExpiryYear = time.Now().Year() - 1 }))
+		if !errors.Is(err, domain.ErrCardExpired) {
+			t.Fatalf("expected ErrCardExpired, got: %v", err)
 		}
 	})
 
@@ -218,9 +254,9 @@ func TestBankCardObj_CreateNewBankCardObj(t *testing.T) {
 			create: func(ctx context.Context, card *domain.BankCard) (int64, error) {
 				return 0, errors.New("insert failed")
 			},
-		})
+		}, nil, nil, nil)
 
-		_, err := uc.CreateNewBankCardObj(ctx, &domain.BankCard{UserId: 1, Bank: "maib", Pid: "PID"})
+		_, err := uc.CreateNewBankCardObj(ctx, card(nil))
 		if !errors.Is(err, domain.ErrFaildeCreateBankCardObject) {
 			t.Fatalf("expected ErrFaildeCreateBankCardObject, got: %v", err)
 		}
@@ -237,14 +273,14 @@ func TestBankCardObj_CreateNewBankCardObj(t *testing.T) {
 				if card.Bank != "maib" {
 					t.Fatalf("expected Bank=maib, got %q", card.Bank)
 				}
-				if card.Pid != "PID123" {
-					t.Fatalf("expected Pid=PID123, got %q", card.Pid)
+				if card.Number != "4242424242424242" {
+					t.Fatalf("expected normalized Number, got %q", card.Number)
 				}
 				return 100, nil
 			},
-		})
+		}, nil, nil, nil)
 
-		id, err := uc.CreateNewBankCardObj(ctx, &domain.BankCard{UserId: 1, Bank: "maib", Pid: "PID123"})
+		id, err := uc.CreateNewBankCardObj(ctx, card(func(c *domain.BankCard) { c.Number = "4242 4242 4242 4242" }))
 		if err != nil {
 			t.Fatalf("expected nil err, got: %v", err)
 		}
@@ -262,7 +298,7 @@ func TestBankCardObj_UpdateBankCard(t *testing.T) {
 	t.Run("nil card -> ErrFailedUpdateBankCard", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
+		uc := New(&repoFake{}, nil, nil, nil)
 		err := uc.UpdateBankCard(ctx, nil)
 		if !errors.Is(err, domain.ErrFailedUpdateBankCard) {
 			t.Fatalf("expected ErrFailedUpdateBankCard, got: %v", err)
@@ -272,8 +308,8 @@ func TestBankCardObj_UpdateBankCard(t *testing.T) {
 	t.Run("invalid userId -> ErrInvalidUserID", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
-		err := uc.UpdateBankCard(ctx, &domain.BankCard{UserId: 0, Bank: "maib", Pid: "PID"})
+		uc := New(&repoFake{}, nil, nil, nil)
+		err := uc.UpdateBankCard(ctx, card(func(c *domain.BankCard) { c.UserId = 0 }))
 		if !errors.Is(err, domain.ErrInvalidUserID) {
 			t.Fatalf("expected ErrInvalidUserID, got: %v", err)
 		}
@@ -282,20 +318,44 @@ func TestBankCardObj_UpdateBankCard(t *testing.T) {
 	t.Run("empty bank -> ErrEmptyBankName", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
-		err := uc.UpdateBankCard(ctx, &domain.BankCard{UserId: 1, Bank: "", Pid: "PID"})
+		uc := New(&repoFake{}, nil, nil, nil)
+		err := uc.UpdateBankCard(ctx, card(func(c *domain.BankCard) { c.Bank = "" }))
 		if !errors.Is(err, domain.ErrEmptyBankName) {
 			t.Fatalf("expected ErrEmptyBankName, got: %v", err)
 		}
 	})
 
-	t.Run("empty pid -> ErrEmptyPID", func(t *testing.T) {
+	t.Run("empty number -> ErrEmptyCardNumber", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{}, nil, nil, nil)
+		err := uc.UpdateBankCard(ctx, card(func(c *domain.BankCard) { c.Number = "" }))
+		if !errors.Is(err, domain.ErrEmptyCardNumber) {
+			t.Fatalf("expected ErrEmptyCardNumber, got: %v", err)
+		}
+	})
+
+	t.Run("expired card -> still updated", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
-		err := uc.UpdateBankCard(ctx, &domain.BankCard{UserId: 1, Bank: "maib", Pid: ""})
-		if !errors.Is(err, domain.ErrEmptyPID) {
-			t.Fatalf("expected ErrEmptyPID, got: %v", err)
+		var saved *domain.BankCard
+		uc := New(&repoFake{
+			update: func(ctx context.Context, card *domain.BankCard) error {
+				saved = card
+				return nil
+			},
+		}, nil, nil, nil)
+
+		expired := card(func(c *domain.BankCard) {
+			c.ExpiryMonth = 1
+			c.ExpiryYear = time.Now().Year() - 1
+			c.PIN = "4321"
+		})
+		if err := uc.UpdateBankCard(ctx, expired); err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+		if saved == nil || saved.PIN != "4321" {
+			t.Fatalf("expected the expired card to be saved, got %+v", saved)
 		}
 	})
 
@@ -306,9 +366,9 @@ func TestBankCardObj_UpdateBankCard(t *testing.T) {
 			update: func(ctx context.Context, card *domain.BankCard) error {
 				return errors.New("update failed")
 			},
-		})
+		}, nil, nil, nil)
 
-		err := uc.UpdateBankCard(ctx, &domain.BankCard{UserId: 1, Bank: "maib", Pid: "PID"})
+		err := uc.UpdateBankCard(ctx, card(nil))
 		if !errors.Is(err, domain.ErrFailedUpdateBankCard) {
 			t.Fatalf("expected ErrFailedUpdateBankCard, got: %v", err)
 		}
@@ -328,14 +388,14 @@ func TestBankCardObj_UpdateBankCard(t *testing.T) {
 				if card.Bank != "maib" {
 					t.Fatalf("expected Bank=maib, got %q", card.Bank)
 				}
-				if card.Pid != "PID1" {
-					t.Fatalf("expected Pid=PID1, got %q", card.Pid)
+				if card.Number != "4242424242424242" {
+					t.Fatalf("expected Number=4242424242424242, got %q", card.Number)
 				}
 				return nil
 			},
-		})
+		}, nil, nil, nil)
 
-		err := uc.UpdateBankCard(ctx, &domain.BankCard{UserId: 1, Bank: "maib", Pid: "PID1"})
+		err := uc.UpdateBankCard(ctx, card(nil))
 		if err != nil {
 			t.Fatalf("expected nil err, got: %v", err)
 		}
@@ -344,3 +404,177 @@ func TestBankCardObj_UpdateBankCard(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateCard(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		modify  func(c *domain.BankCard)
+		wantErr error
+	}{
+		{name: "valid visa", modify: nil},
+		{name: "number with spaces and dashes", modify: func(c *domain.BankCard) { c.Number = "4242-4242 4242-4242" }},
+		{name: "amex 15 digits", modify: func(c *domain.BankCard) { c.Number = "378282246310005"; c.CVV = "1234" }},
+		{name: "luhn failure", modify: func(c *domain.BankCard) { c.Number = "4242424242424241" }, wantErr: domain.ErrInvalidCardNumber},
+		{name: "letters in number", modify: func(c *domain.BankCard) { c.Number = "4242abcd42424242" }, wantErr: domain.ErrInvalidCardNumber},
+		{name: "too short", modify: func(c *domain.BankCard) { c.Number = "42" }, wantErr: domain.ErrInvalidCardNumber},
+		{name: "month 13", modify: func(c *domain.BankCard) { c.ExpiryMonth = 13 }, wantErr: domain.ErrInvalidExpiry},
+		{name: "month 0", modify: func(c *domain.BankCard) { c.ExpiryMonth = 0 }, wantErr: domain.ErrInvalidExpiry},
+		{name: "two digit year", modify: func(c *domain.BankCard) { c.ExpiryMonth = 1; c.ExpiryYear = 27 }},
+		{name: "expires this month", modify: func(c *domain.BankCard) { c.ExpiryMonth = 3; c.ExpiryYear = 2026 }},
+		{name: "expired last month", modify: func(c *domain.BankCard) { c.ExpiryMonth = 2; c.ExpiryYear = 2026 }, wantErr: domain.ErrCardExpired},
+		{name: "cvv with letters", modify: func(c *domain.BankCard) { c.CVV = "12a" }, wantErr: domain.ErrInvalidCVV},
+		{name: "cvv too long", modify: func(c *domain.BankCard) { c.CVV = "12345" }, wantErr: domain.ErrInvalidCVV},
+		{name: "cvv is optional", modify: func(c *domain.BankCard) { c.CVV = "" }},
+		{name: "pin too short", modify: func(c *domain.BankCard) { c.PIN = "12" }, wantErr: domain.ErrInvalidPIN},
+		{name: "pin ok", modify: func(c *domain.BankCard) { c.PIN = "0000" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := card(func(c *domain.BankCard) { c.ExpiryYear = 2027 })
+			if tt.modify != nil {
+				tt.modify(c)
+			}
+
+			err := validateCard(c, now, true)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("expected nil, got: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateCard_ExpiresAt(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("not set -> end of expiry month", func(t *testing.T) {
+		t.Parallel()
+
+		c := card(func(c *domain.BankCard) { c.ExpiryMonth = 12; c.ExpiryYear = 2027 })
+		if err := validateCard(c, now, true); err != nil {
+			t.Fatalf("expected nil, got: %v", err)
+		}
+
+		want := time.Date(2028, time.January, 1, 0, 0, 0, 0, time.UTC)
+		if !c.ExpiresAt.Equal(want) {
+			t.Fatalf("expected ExpiresAt=%v, got %v", want, c.ExpiresAt)
+		}
+	})
+
+	t.Run("set -> kept", func(t *testing.T) {
+		t.Parallel()
+
+		custom := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+		c := card(func(c *domain.BankCard) { c.ExpiryYear = 2027; c.ExpiresAt = custom })
+		if err := validateCard(c, now, true); err != nil {
+			t.Fatalf("expected nil, got: %v", err)
+		}
+		if !c.ExpiresAt.Equal(custom) {
+			t.Fatalf("expected ExpiresAt=%v, got %v", custom, c.ExpiresAt)
+		}
+	})
+}
+
+func TestBankCard_BrandAndMask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		number string
+		brand  string
+		masked string
+	}{
+		{"4242424242424242", domain.BrandVisa, "**** 4242"},
+		{"5555 5555 5555 4444", domain.BrandMastercard, "**** 4444"},
+		{"2223003122003222", domain.BrandMastercard, "**** 3222"},
+		{"378282246310005", domain.BrandAmex, "**** 0005"},
+		{"6011111111111117", domain.BrandDiscover, "**** 1117"},
+		{"3530111333300000", domain.BrandJCB, "**** 0000"},
+		{"36227206271667", domain.BrandDiners, "**** 1667"},
+		{"6200000000000005", domain.BrandUnionPay, "**** 0005"},
+		{"2200000000000004", domain.BrandMir, "**** 0004"},
+		{"9999999999999995", domain.BrandUnknown, "**** 9995"},
+		{"12", domain.BrandUnknown, "****"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			t.Parallel()
+
+			c := &domain.BankCard{Number: tt.number}
+			if got := c.Brand(); got != tt.brand {
+				t.Fatalf("brand: expected %q, got %q", tt.brand, got)
+			}
+			if got := c.Masked(); got != tt.masked {
+				t.Fatalf("masked: expected %q, got %q", tt.masked, got)
+			}
+		})
+	}
+}
+
+// orgsFake holds the roles of members of every organization, other users are not members
+type orgsFake map[int64]string
+
+func (o orgsFake) Authorize(ctx context.Context, userId, orgId int64, action string) error {
+	role, ok := o[userId]
+	if !ok {
+		return org.ErrOrgNotFound
+	}
+	if !org.Allowed(role, action) {
+		return org.ErrForbidden
+	}
+	return nil
+}
+
+func TestBankCardObj_OrgAccess(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := &repoFake{getByID: func(ctx context.Context, cardId int64) (*domain.BankCard, error) {
+		return &domain.BankCard{CardId: cardId, UserId: 1, OrgID: 7}, nil
+	}}
+	uc := New(repo, nil, orgsFake{1: org.RoleOwner, 3: org.RoleEditor, 4: org.RoleViewer}, nil)
+
+	if _, err := uc.GetBankCard(ctx, 9, 5); !errors.Is(err, domain.ErrBankCardNotFound) {
+		t.Fatalf("non-member: expected ErrBankCardNotFound, got: %v", err)
+	}
+
+	if _, err := uc.GetBankCard(ctx, 4, 5); err != nil {
+		t.Fatalf("viewer read: unexpected error: %v", err)
+	}
+
+	if err := uc.UpdateBankCard(ctx, card(func(c *domain.BankCard) { c.CardId, c.UserId = 5, 4 })); !errors.Is(err, org.ErrForbidden) {
+		t.Fatalf("viewer update: expected ErrForbidden, got: %v", err)
+	}
+
+	if err := uc.UpdateBankCard(ctx, card(func(c *domain.BankCard) { c.CardId, c.UserId = 5, 3 })); err != nil {
+		t.Fatalf("editor update: unexpected error: %v", err)
+	}
+
+	if err := uc.DeleteBankCard(ctx, 3, 5); !errors.Is(err, org.ErrForbidden) {
+		t.Fatalf("editor delete: expected ErrForbidden, got: %v", err)
+	}
+
+	if _, err := uc.CreateNewBankCardObj(ctx, card(func(c *domain.BankCard) { c.UserId, c.OrgID = 4, 7 })); !errors.Is(err, org.ErrForbidden) {
+		t.Fatalf("viewer create: expected ErrForbidden, got: %v", err)
+	}
+
+	var deleted int64
+	repo.delete = func(ctx context.Context, cardId int64) error {
+		deleted = cardId
+		return nil
+	}
+	if err := uc.DeleteBankCard(ctx, 1, 5); err != nil || deleted != 5 {
+		t.Fatalf("owner delete: deleted=%d err=%v", deleted, err)
+	}
+}
@@ -0,0 +1,159 @@
+package cert_obj
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"server/internal/app/domain/audit"
+	domain "server/internal/app/domain/cert_obj"
+	"server/internal/pkg/x509cert"
+)
+
+type Repository interface {
+	GetByUserID(ctx context.Context, userId int64, search string) ([]*domain.Certificate, error)
+	GetExpiring(ctx context.Context, userId int64, until time.Time) ([]*domain.Certificate, error)
+	GetByID(ctx context.Context, certId int64) (*domain.Certificate, error)
+	Create(ctx context.Context, cert *domain.Certificate) (int64, error)
+}
+
+// Auditor writes access to items into the audit log
+type Auditor interface {
+	Record(ctx context.Context, e audit.Event)
+}
+
+type CertObj struct {
+	repo  Repository
+	audit Auditor
+}
+
+// New creates the use case, without auditor nothing is logged
+func New(repo Repository, auditor Auditor) *CertObj {
+	return &CertObj{repo: repo, audit: auditor}
+}
+
+// GetCertificateList returns certificates of the user, search matches subject, issuer and SANs
+func (c *CertObj) GetCertificateList(ctx context.Context, userId int64, search string) ([]*domain.Certificate, error) {
+	if userId <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	list, err := c.repo.GetByUserID(ctx, userId, strings.TrimSpace(search))
+	if err != nil {
+		return nil, domain.ErrCertificateNotFound
+	}
+
+	if len(list) == 0 {
+		return nil, domain.ErrEmptyCertificatesList
+	}
+
+	return list, nil
+}
+
+// GetExpiringCertificates returns certificates expiring within the window, expired ones included
+func (c *CertObj) GetExpiringCertificates(ctx context.Context, userId int64, within time.Duration) ([]*domain.Certificate, error) {
+	if userId <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if within <= 0 || within > domain.MaxExpiryWindow {
+		return nil, domain.ErrInvalidExpiryWindow
+	}
+
+	list, err := c.repo.GetExpiring(ctx, userId, time.Now().Add(within))
+	if err != nil {
+		return nil, domain.ErrCertificateNotFound
+	}
+
+	if len(list) == 0 {
+		return nil, domain.ErrEmptyCertificatesList
+	}
+
+	return list, nil
+}
+
+// GetCertificate returns the certificate with its private key, certificates of other users are reported as not found
+func (c *CertObj) GetCertificate(ctx context.Context, userId, certId int64) (*domain.Certificate, error) {
+	if certId <= 0 {
+		return nil, domain.ErrInvalidCertificateID
+	}
+
+	cert, err := c.repo.GetByID(ctx, certId)
+	if err != nil || cert.UserId != userId {
+		return nil, domain.ErrCertificateNotFound
+	}
+
+	c.record(ctx, cert, audit.ActionItemRead)
+
+	return cert, nil
+}
+
+// ImportCertificate parses the PEM bundle and stores it, the private key must match the leaf certificate
+func (c *CertObj) ImportCertificate(ctx context.Context, req domain.ImportRequest) (*domain.Certificate, error) {
+	if req.UserId <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if strings.TrimSpace(req.Title) == "" {
+		return nil, domain.ErrEmptyTitle
+	}
+
+	bundle, err := x509cert.Parse(req.Bundle, req.PrivateKey)
+	if err != nil {
+		return nil, mapBundleError(err)
+	}
+
+	cert := &domain.Certificate{
+		UserId:       req.UserId,
+		Title:        strings.TrimSpace(req.Title),
+		Certificate:  bundle.Certificate,
+		PrivateKey:   bundle.PrivateKey,
+		Subject:      bundle.Subject,
+		Issuer:       bundle.Issuer,
+		SANs:         bundle.SANs,
+		SerialNumber: bundle.SerialNumber,
+		Fingerprint:  bundle.Fingerprint,
+		NotBefore:    bundle.NotBefore,
+		NotAfter:     bundle.NotAfter,
+	}
+
+	id, err := c.repo.Create(ctx, cert)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrFailedCreateCertificate, err)
+	}
+	cert.CertId = id
+
+	c.record(ctx, cert, audit.ActionCreate)
+
+	return cert, nil
+}
+
+// help func
+
+// record writes the action of the owner on the certificate into the audit log, only owners reach certificates
+func (c *CertObj) record(ctx context.Context, cert *domain.Certificate, action string) {
+	if c.audit == nil {
+		return
+	}
+	c.audit.Record(ctx, audit.Event{
+		UserID:   cert.UserId,
+		OwnerID:  cert.UserId,
+		Action:   action,
+		ItemType: audit.ItemCert,
+		ItemID:   cert.CertId,
+	})
+}
+
+// mapBundleError turns PEM parsing errors into domain errors
+func mapBundleError(err error) error {
+	switch {
+	case errors.Is(err, x509cert.ErrKeyMismatch):
+		return domain.ErrKeyMismatch
+	case errors.Is(err, x509cert.ErrInvalidKey), errors.Is(err, x509cert.ErrEncryptedKey), errors.Is(err, x509cert.ErrMultipleKeys):
+		return fmt.Errorf("%w: %w", domain.ErrInvalidPrivateKey, err)
+	default:
+		return fmt.Errorf("%w: %w", domain.ErrInvalidCertificate, err)
+	}
+}
@@ -0,0 +1,228 @@
+package cert_obj
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	domain "server/internal/app/domain/cert_obj"
+)
+
+type repoFake struct {
+	getByUserID func(ctx context.Context, userId int64, search string) ([]*domain.Certificate, error)
+	getExpiring func(ctx context.Context, userId int64, until time.Time) ([]*domain.Certificate, error)
+	getByID     func(ctx context.Context, certId int64) (*domain.Certificate, error)
+	create      func(ctx context.Context, cert *domain.Certificate) (int64, error)
+}
+
+func (r *repoFake) GetByUserID(ctx context.Context, userId int64, search string) ([]*domain.Certificate, error) {
+	if r.getByUserID != nil {
+		return r.getByUserID(ctx, userId, search)
+	}
+	return nil, nil
+}
+func (r *repoFake) GetExpiring(ctx context.Context, userId int64, until time.Time) ([]*domain.Certificate, error) {
+	if r.getExpiring != nil {
+		return r.getExpiring(ctx, userId, until)
+	}
+	return nil, nil
+}
+func (r *repoFake) GetByID(ctx context.Context, certId int64) (*domain.Certificate, error) {
+	if r.getByID != nil {
+		return r.getByID(ctx, certId)
+	}
+	return nil, nil
+}
+func (r *repoFake) Create(ctx context.Context, cert *domain.Certificate) (int64, error) {
+	if r.create != nil {
+		return r.create(ctx, cert)
+	}
+	return 1, nil
+}
+
+// selfSigned returns a PEM certificate for example.com and its PEM key
+func selfSigned(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(30 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %v", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestCertObj_ImportCertificate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	certPEM, keyPEM := selfSigned(t)
+	_, otherKeyPEM := selfSigned(t)
+
+	t.Run("empty title -> ErrEmptyTitle", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(&repoFake{}, nil).ImportCertificate(ctx, domain.ImportRequest{UserId: 7, Title: " ", Bundle: certPEM})
+		if !errors.Is(err, domain.ErrEmptyTitle) {
+			t.Fatalf("expected ErrEmptyTitle, got: %v", err)
+		}
+	})
+
+	t.Run("not a certificate -> ErrInvalidCertificate", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(&repoFake{}, nil).ImportCertificate(ctx, domain.ImportRequest{UserId: 7, Title: "web", Bundle: "junk"})
+		if !errors.Is(err, domain.ErrInvalidCertificate) {
+			t.Fatalf("expected ErrInvalidCertificate, got: %v", err)
+		}
+	})
+
+	t.Run("foreign key -> ErrKeyMismatch", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(&repoFake{}, nil).ImportCertificate(ctx, domain.ImportRequest{UserId: 7, Title: "web", Bundle: certPEM, PrivateKey: otherKeyPEM})
+		if !errors.Is(err, domain.ErrKeyMismatch) {
+			t.Fatalf("expected ErrKeyMismatch, got: %v", err)
+		}
+	})
+
+	t.Run("ok -> parsed fields stored", func(t *testing.T) {
+		t.Parallel()
+
+		var stored *domain.Certificate
+		uc := New(&repoFake{
+			create: func(ctx context.Context, cert *domain.Certificate) (int64, error) {
+				stored = cert
+				return 11, nil
+			},
+		}, nil)
+
+		cert, err := uc.ImportCertificate(ctx, domain.ImportRequest{UserId: 7, Title: " web ", Bundle: certPEM, PrivateKey: keyPEM})
+		if err != nil {
+			t.Fatalf("ImportCertificate: %v", err)
+		}
+		if cert.CertId != 11 || stored.Title != "web" || stored.UserId != 7 {
+			t.Fatalf("unexpected certificate: %+v", cert)
+		}
+		if stored.Subject != "CN=example.com" || len(stored.SANs) != 1 || stored.PrivateKey == "" || stored.NotAfter.IsZero() {
+			t.Fatalf("parsed fields not stored: %+v", stored)
+		}
+	})
+
+	t.Run("repo error -> ErrFailedCreateCertificate", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			create: func(ctx context.Context, cert *domain.Certificate) (int64, error) {
+				return 0, errors.New("db down")
+			},
+		}, nil)
+
+		_, err := uc.ImportCertificate(ctx, domain.ImportRequest{UserId: 7, Title: "web", Bundle: certPEM})
+		if !errors.Is(err, domain.ErrFailedCreateCertificate) {
+			t.Fatalf("expected ErrFailedCreateCertificate, got: %v", err)
+		}
+	})
+}
+
+func TestCertObj_GetCertificate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	uc := New(&repoFake{
+		getByID: func(ctx context.Context, certId int64) (*domain.Certificate, error) {
+			return &domain.Certificate{CertId: certId, UserId: 7}, nil
+		},
+	}, nil)
+
+	if _, err := uc.GetCertificate(ctx, 7, 0); !errors.Is(err, domain.ErrInvalidCertificateID) {
+		t.Fatalf("expected ErrInvalidCertificateID, got: %v", err)
+	}
+	if _, err := uc.GetCertificate(ctx, 8, 3); !errors.Is(err, domain.ErrCertificateNotFound) {
+		t.Fatalf("expected ErrCertificateNotFound, got: %v", err)
+	}
+	if cert, err := uc.GetCertificate(ctx, 7, 3); err != nil || cert.CertId != 3 {
+		t.Fatalf("unexpected result: %+v, %v", cert, err)
+	}
+}
+
+func TestCertObj_GetCertificateList(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var gotSearch string
+	uc := New(&repoFake{
+		getByUserID: func(ctx context.Context, userId int64, search string) ([]*domain.Certificate, error) {
+			gotSearch = search
+			if search == "none" {
+				return nil, nil
+			}
+			return []*domain.Certificate{{CertId: 1}}, nil
+		},
+	}, nil)
+
+	if _, err := uc.GetCertificateList(ctx, 0, ""); !errors.Is(err, domain.ErrInvalidUserID) {
+		t.Fatalf("expected ErrInvalidUserID, got: %v", err)
+	}
+	if _, err := uc.GetCertificateList(ctx, 7, "none"); !errors.Is(err, domain.ErrEmptyCertificatesList) {
+		t.Fatalf("expected ErrEmptyCertificatesList, got: %v", err)
+	}
+	if list, err := uc.GetCertificateList(ctx, 7, " example.com "); err != nil || len(list) != 1 || gotSearch != "example.com" {
+		t.Fatalf("unexpected result: %v, %v, search=%q", list, err, gotSearch)
+	}
+}
+
+func TestCertObj_GetExpiringCertificates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var gotUntil time.Time
+	uc := New(&repoFake{
+		getExpiring: func(ctx context.Context, userId int64, until time.Time) ([]*domain.Certificate, error) {
+			gotUntil = until
+			return []*domain.Certificate{{CertId: 1}}, nil
+		},
+	}, nil)
+
+	for _, within := range []time.Duration{0, -time.Hour, domain.MaxExpiryWindow + time.Hour} {
+		if _, err := uc.GetExpiringCertificates(ctx, 7, within); !errors.Is(err, domain.ErrInvalidExpiryWindow) {
+			t.Fatalf("within=%v: expected ErrInvalidExpiryWindow, got: %v", within, err)
+		}
+	}
+
+	want := time.Now().Add(30 * 24 * time.Hour)
+	if _, err := uc.GetExpiringCertificates(ctx, 7, 30*24*time.Hour); err != nil {
+		t.Fatalf("GetExpiringCertificates: %v", err)
+	}
+	if d := gotUntil.Sub(want); d < -time.Minute || d > time.Minute {
+		t.Fatalf("unexpected until: %v, want about %v", gotUntil, want)
+	}
+}
@@ -0,0 +1,301 @@
+package emergency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	domain "server/internal/app/domain/emergency"
+	"server/internal/app/domain/share"
+)
+
+type Repository interface {
+	// UserIDByName returns the id of a registered user or ErrUserNotFound
+	UserIDByName(ctx context.Context, username string) (int64, error)
+
+	// Create adds a contact, ErrAlreadyContact when the grantee is already trusted by the grantor
+	Create(ctx context.Context, c *domain.Contact) (int64, error)
+	GetByID(ctx context.Context, contactID int64) (*domain.Contact, error)
+	ListByGrantor(ctx context.Context, grantorID int64) ([]*domain.Contact, error)
+	ListByGrantee(ctx context.Context, granteeID int64) ([]*domain.Contact, error)
+	// SetStatus changes the status, requestedAt is stored as is so a zero value keeps the column NULL
+	SetStatus(ctx context.Context, contactID int64, status string, requestedAt time.Time) error
+	Delete(ctx context.Context, contactID int64) error
+
+	// ForItem returns the contact of the grantee with the owner of a personal item or ErrContactNotFound
+	ForItem(ctx context.Context, granteeID int64, item share.Item) (*domain.Contact, error)
+	// VaultItems lists the personal accounts, cards and texts of the grantor
+	VaultItems(ctx context.Context, grantorID int64) ([]*domain.Item, error)
+}
+
+// ShareChecker returns the access of a user to an item of another user or share.ErrShareNotFound
+type ShareChecker interface {
+	Access(ctx context.Context, userId int64, item share.Item) (*share.Access, error)
+}
+
+type Emergency struct {
+	repo   Repository
+	shares ShareChecker
+	opts   domain.Options
+	now    func() time.Time
+}
+
+// New creates the use case, it wraps shares so item usecases see emergency access as a share
+func New(repo Repository, shares ShareChecker, opts domain.Options) *Emergency {
+	return &Emergency{repo: repo, shares: shares, opts: opts, now: time.Now}
+}
+
+// AddContact lets username request access to the vault of the grantor, zero wait uses the default
+func (e *Emergency) AddContact(ctx context.Context, grantorID int64, username, accessType string, wait time.Duration) (*domain.Contact, error) {
+	if grantorID <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return nil, domain.ErrInvalidUsername
+	}
+
+	if !domain.ValidAccessType(accessType) {
+		return nil, domain.ErrInvalidAccessType
+	}
+
+	if wait == 0 {
+		wait = e.opts.DefaultWait
+	}
+	if wait <= 0 || wait < e.opts.MinWait || (e.opts.MaxWait > 0 && wait > e.opts.MaxWait) {
+		return nil, domain.ErrInvalidWait
+	}
+
+	granteeID, err := e.repo.UserIDByName(ctx, username)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get user %q: %w", username, err)
+	}
+
+	if granteeID == grantorID {
+		return nil, domain.ErrSelfContact
+	}
+
+	c := &domain.Contact{
+		GrantorID:   grantorID,
+		GranteeID:   granteeID,
+		GranteeName: username,
+		AccessType:  accessType,
+		Wait:        wait,
+		Status:      domain.StatusIdle,
+		CreatedAt:   e.now(),
+	}
+
+	id, err := e.repo.Create(ctx, c)
+	if err != nil {
+		if errors.Is(err, domain.ErrAlreadyContact) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("add emergency contact %q: %w", username, err)
+	}
+	c.ID = id
+
+	return c, nil
+}
+
+// Trusted lists the contacts the user designated
+func (e *Emergency) Trusted(ctx context.Context, grantorID int64) ([]*domain.Contact, error) {
+	if grantorID <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	list, err := e.repo.ListByGrantor(ctx, grantorID)
+	if err != nil {
+		return nil, fmt.Errorf("list contacts of user id=%d: %w", grantorID, err)
+	}
+
+	if len(list) == 0 {
+		return nil, domain.ErrEmptyContacts
+	}
+
+	return list, nil
+}
+
+// TrustedBy lists the users that designated the user as their contact
+func (e *Emergency) TrustedBy(ctx context.Context, granteeID int64) ([]*domain.Contact, error) {
+	if granteeID <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	list, err := e.repo.ListByGrantee(ctx, granteeID)
+	if err != nil {
+		return nil, fmt.Errorf("list grantors of user id=%d: %w", granteeID, err)
+	}
+
+	if len(list) == 0 {
+		return nil, domain.ErrEmptyContacts
+	}
+
+	return list, nil
+}
+
+// Remove deletes a contact, the grantor removes it and the contact may step down
+func (e *Emergency) Remove(ctx context.Context, userID, contactID int64) error {
+	c, err := e.contact(ctx, userID, contactID)
+	if err != nil {
+		return err
+	}
+
+	if c.GrantorID != userID && c.GranteeID != userID {
+		return domain.ErrContactNotFound
+	}
+
+	if err := e.repo.Delete(ctx, contactID); err != nil {
+		return fmt.Errorf("delete emergency contact id=%d: %w", contactID, err)
+	}
+
+	return nil
+}
+
+// RequestAccess starts the waiting period, a rejected contact may request again
+func (e *Emergency) RequestAccess(ctx context.Context, granteeID, contactID int64) (*domain.Contact, error) {
+	c, err := e.contact(ctx, granteeID, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.GranteeID != granteeID {
+		return nil, domain.ErrContactNotFound
+	}
+
+	if c.Status == domain.StatusRequested || c.Status == domain.StatusApproved {
+		return nil, domain.ErrAlreadyRequested
+	}
+
+	return e.setStatus(ctx, c, domain.StatusRequested, e.now())
+}
+
+// Approve gives access before the waiting period ends
+func (e *Emergency) Approve(ctx context.Context, grantorID, contactID int64) (*domain.Contact, error) {
+	c, err := e.grantorContact(ctx, grantorID, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Status != domain.StatusRequested {
+		return nil, domain.ErrNotRequested
+	}
+
+	return e.setStatus(ctx, c, domain.StatusApproved, c.RequestedAt)
+}
+
+// Reject stops a pending request, rejecting a granted one takes the access back
+func (e *Emergency) Reject(ctx context.Context, grantorID, contactID int64) (*domain.Contact, error) {
+	c, err := e.grantorContact(ctx, grantorID, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Status != domain.StatusRequested && c.Status != domain.StatusApproved {
+		return nil, domain.ErrNotRequested
+	}
+
+	return e.setStatus(ctx, c, domain.StatusRejected, time.Time{})
+}
+
+// Vault lists the items of the grantor once the contact has access
+func (e *Emergency) Vault(ctx context.Context, granteeID, contactID int64) ([]*domain.Item, error) {
+	c, err := e.contact(ctx, granteeID, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.GranteeID != granteeID {
+		return nil, domain.ErrContactNotFound
+	}
+
+	if !c.Granted(e.now()) {
+		return nil, domain.ErrAccessNotGranted
+	}
+
+	list, err := e.repo.VaultItems(ctx, c.GrantorID)
+	if err != nil {
+		return nil, fmt.Errorf("list vault of user id=%d: %w", c.GrantorID, err)
+	}
+
+	if len(list) == 0 {
+		return nil, domain.ErrEmptyVault
+	}
+
+	return list, nil
+}
+
+// Access is used by the item usecases in place of shares: direct shares come first,
+// then a granted emergency contact with the owner of the item
+func (e *Emergency) Access(ctx context.Context, userID int64, item share.Item) (*share.Access, error) {
+	if e.shares != nil {
+		access, err := e.shares.Access(ctx, userID, item)
+		if err == nil || !errors.Is(err, share.ErrShareNotFound) {
+			return access, err
+		}
+	}
+
+	c, err := e.repo.ForItem(ctx, userID, item)
+	if err != nil {
+		if errors.Is(err, domain.ErrContactNotFound) {
+			return nil, share.ErrShareNotFound
+		}
+		return nil, fmt.Errorf("get emergency contact for %s id=%d: %w", item.Type, item.ID, err)
+	}
+
+	if !c.Granted(e.now()) {
+		return nil, share.ErrShareNotFound
+	}
+
+	return &share.Access{OwnerID: c.GrantorID, OwnerName: c.GrantorName, Permission: c.Permission()}, nil
+}
+
+// help func
+
+// contact loads a contact, callers check which side of it the user is on
+func (e *Emergency) contact(ctx context.Context, userID, contactID int64) (*domain.Contact, error) {
+	if userID <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if contactID <= 0 {
+		return nil, domain.ErrInvalidContactID
+	}
+
+	c, err := e.repo.GetByID(ctx, contactID)
+	if err != nil {
+		if errors.Is(err, domain.ErrContactNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get emergency contact id=%d: %w", contactID, err)
+	}
+
+	return c, nil
+}
+
+func (e *Emergency) grantorContact(ctx context.Context, grantorID, contactID int64) (*domain.Contact, error) {
+	c, err := e.contact(ctx, grantorID, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.GrantorID != grantorID {
+		return nil, domain.ErrContactNotFound
+	}
+
+	return c, nil
+}
+
+func (e *Emergency) setStatus(ctx context.Context, c *domain.Contact, status string, requestedAt time.Time) (*domain.Contact, error) {
+	if err := e.repo.SetStatus(ctx, c.ID, status, requestedAt); err != nil {
+		return nil, fmt.Errorf("set status of emergency contact id=%d: %w", c.ID, err)
+	}
+
+	c.Status, c.RequestedAt = status, requestedAt
+	return c, nil
+}
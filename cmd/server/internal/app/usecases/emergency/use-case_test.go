@@ -0,0 +1,291 @@
+package emergency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "server/internal/app/domain/emergency"
+	"server/internal/app/domain/share"
+)
+
+// repoFake keeps contacts in memory, users are alice=1, bob=2 and carol=3
+type repoFake struct {
+	contacts map[int64]*domain.Contact
+	items    []*domain.Item
+}
+
+func newRepoFake() *repoFake {
+	return &repoFake{contacts: map[int64]*domain.Contact{}}
+}
+
+func (r *repoFake) UserIDByName(ctx context.Context, username string) (int64, error) {
+	switch username {
+	case "alice":
+		return 1, nil
+	case "bob":
+		return 2, nil
+	case "carol":
+		return 3, nil
+	}
+	return 0, domain.ErrUserNotFound
+}
+
+func (r *repoFake) Create(ctx context.Context, c *domain.Contact) (int64, error) {
+	for _, x := range r.contacts {
+		if x.GrantorID == c.GrantorID && x.GranteeID == c.GranteeID {
+			return 0, domain.ErrAlreadyContact
+		}
+	}
+	id := int64(len(r.contacts) + 1)
+	cp := *c
+	cp.ID, cp.GrantorName = id, "alice"
+	r.contacts[id] = &cp
+	return id, nil
+}
+
+func (r *repoFake) GetByID(ctx context.Context, contactID int64) (*domain.Contact, error) {
+	c, ok := r.contacts[contactID]
+	if !ok {
+		return nil, domain.ErrContactNotFound
+	}
+	cp := *c
+	return &cp, nil
+}
+
+func (r *repoFake) ListByGrantor(ctx context.Context, grantorID int64) ([]*domain.Contact, error) {
+	var out []*domain.Contact
+	for _, c := range r.contacts {
+		if c.GrantorID == grantorID {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (r *repoFake) ListByGrantee(ctx context.Context, granteeID int64) ([]*domain.Contact, error) {
+	var out []*domain.Contact
+	for _, c := range r.contacts {
+		if c.GranteeID == granteeID {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (r *repoFake) SetStatus(ctx context.Context, contactID int64, status string, requestedAt time.Time) error {
+	r.contacts[contactID].Status = status
+	r.contacts[contactID].RequestedAt = requestedAt
+	return nil
+}
+
+func (r *repoFake) Delete(ctx context.Context, contactID int64) error {
+	delete(r.contacts, contactID)
+	return nil
+}
+
+// ForItem treats every item as a personal item of alice
+func (r *repoFake) ForItem(ctx context.Context, granteeID int64, item share.Item) (*domain.Contact, error) {
+	for _, c := range r.contacts {
+		if c.GrantorID == 1 && c.GranteeID == granteeID {
+			cp := *c
+			return &cp, nil
+		}
+	}
+	return nil, domain.ErrContactNotFound
+}
+
+func (r *repoFake) VaultItems(ctx context.Context, grantorID int64) ([]*domain.Item, error) {
+	return r.items, nil
+}
+
+// sharesFake grants read access to user 3 only
+type sharesFake struct{}
+
+func (sharesFake) Access(ctx context.Context, userId int64, item share.Item) (*share.Access, error) {
+	if userId == 3 {
+		return &share.Access{OwnerID: 1, OwnerName: "alice", Permission: share.PermRead}, nil
+	}
+	return nil, share.ErrShareNotFound
+}
+
+var opts = domain.Options{DefaultWait: 48 * time.Hour, MinWait: time.Hour, MaxWait: 30 * 24 * time.Hour}
+
+// newAt returns a use case with a clock the test can move
+func newAt(repo Repository, now *time.Time) *Emergency {
+	e := New(repo, sharesFake{}, opts)
+	e.now = func() time.Time { return *now }
+	return e
+}
+
+func TestEmergency_AddContact(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		username string
+		access   string
+		wait     time.Duration
+		wantErr  error
+	}{
+		{name: "empty username", username: " ", access: domain.AccessView, wantErr: domain.ErrInvalidUsername},
+		{name: "unknown access type", username: "bob", access: "admin", wantErr: domain.ErrInvalidAccessType},
+		{name: "wait below minimum", username: "bob", access: domain.AccessView, wait: time.Minute, wantErr: domain.ErrInvalidWait},
+		{name: "wait above maximum", username: "bob", access: domain.AccessView, wait: 90 * 24 * time.Hour, wantErr: domain.ErrInvalidWait},
+		{name: "self", username: "alice", access: domain.AccessView, wantErr: domain.ErrSelfContact},
+		{name: "unknown user", username: "dave", access: domain.AccessView, wantErr: domain.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newAt(newRepoFake(), &now).AddContact(ctx, 1, tt.username, tt.access, tt.wait)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	t.Run("default wait and duplicate", func(t *testing.T) {
+		uc := newAt(newRepoFake(), &now)
+
+		c, err := uc.AddContact(ctx, 1, "bob", domain.AccessView, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if c.ID == 0 || c.Wait != opts.DefaultWait || c.Status != domain.StatusIdle {
+			t.Fatalf("unexpected contact: %+v", c)
+		}
+
+		if _, err := uc.AddContact(ctx, 1, "bob", domain.AccessTakeover, 0); !errors.Is(err, domain.ErrAlreadyContact) {
+			t.Fatalf("expected ErrAlreadyContact, got: %v", err)
+		}
+	})
+}
+
+func TestEmergency_WaitingPeriod(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := newRepoFake()
+	repo.items = []*domain.Item{{Type: share.ItemText, ID: 5, Title: "wifi"}}
+	uc := newAt(repo, &now)
+	item := share.Item{Type: share.ItemText, ID: 5}
+
+	c, err := uc.AddContact(ctx, 1, "bob", domain.AccessView, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("add contact: %v", err)
+	}
+
+	if _, err := uc.Vault(ctx, 2, c.ID); !errors.Is(err, domain.ErrAccessNotGranted) {
+		t.Fatalf("idle contact: expected ErrAccessNotGranted, got: %v", err)
+	}
+
+	if _, err := uc.RequestAccess(ctx, 1, c.ID); !errors.Is(err, domain.ErrContactNotFound) {
+		t.Fatalf("grantor can not request: expected ErrContactNotFound, got: %v", err)
+	}
+
+	if _, err := uc.RequestAccess(ctx, 2, c.ID); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if _, err := uc.RequestAccess(ctx, 2, c.ID); !errors.Is(err, domain.ErrAlreadyRequested) {
+		t.Fatalf("second request: expected ErrAlreadyRequested, got: %v", err)
+	}
+
+	now = now.Add(23 * time.Hour)
+	if _, err := uc.Access(ctx, 2, item); !errors.Is(err, share.ErrShareNotFound) {
+		t.Fatalf("during waiting period: expected ErrShareNotFound, got: %v", err)
+	}
+
+	now = now.Add(time.Hour)
+	access, err := uc.Access(ctx, 2, item)
+	if err != nil || access.Permission != share.PermRead || access.OwnerName != "alice" {
+		t.Fatalf("after waiting period: %+v, %v", access, err)
+	}
+
+	items, err := uc.Vault(ctx, 2, c.ID)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("vault: %+v, %v", items, err)
+	}
+
+	if _, err := uc.Reject(ctx, 2, c.ID); !errors.Is(err, domain.ErrContactNotFound) {
+		t.Fatalf("contact can not reject: expected ErrContactNotFound, got: %v", err)
+	}
+
+	if _, err := uc.Reject(ctx, 1, c.ID); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if _, err := uc.Access(ctx, 2, item); !errors.Is(err, share.ErrShareNotFound) {
+		t.Fatalf("after reject: expected ErrShareNotFound, got: %v", err)
+	}
+}
+
+func TestEmergency_ApproveTakeover(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	uc := newAt(newRepoFake(), &now)
+
+	c, err := uc.AddContact(ctx, 1, "bob", domain.AccessTakeover, 0)
+	if err != nil {
+		t.Fatalf("add contact: %v", err)
+	}
+
+	if _, err := uc.Approve(ctx, 1, c.ID); !errors.Is(err, domain.ErrNotRequested) {
+		t.Fatalf("approve without request: expected ErrNotRequested, got: %v", err)
+	}
+
+	if _, err := uc.RequestAccess(ctx, 2, c.ID); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+
+	got, err := uc.Approve(ctx, 1, c.ID)
+	if err != nil || got.Status != domain.StatusApproved {
+		t.Fatalf("approve: %+v, %v", got, err)
+	}
+
+	access, err := uc.Access(ctx, 2, share.Item{Type: share.ItemAccount, ID: 1})
+	if err != nil || !access.CanWrite() {
+		t.Fatalf("takeover access: %+v, %v", access, err)
+	}
+}
+
+func TestEmergency_AccessPrefersShares(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	access, err := newAt(newRepoFake(), &now).Access(context.Background(), 3, share.Item{Type: share.ItemText, ID: 1})
+	if err != nil || access.Permission != share.PermRead {
+		t.Fatalf("expected the direct share, got: %+v, %v", access, err)
+	}
+}
+
+func TestEmergency_Remove(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Now()
+	uc := newAt(newRepoFake(), &now)
+
+	c, err := uc.AddContact(ctx, 1, "bob", domain.AccessView, 0)
+	if err != nil {
+		t.Fatalf("add contact: %v", err)
+	}
+
+	if err := uc.Remove(ctx, 3, c.ID); !errors.Is(err, domain.ErrContactNotFound) {
+		t.Fatalf("stranger: expected ErrContactNotFound, got: %v", err)
+	}
+
+	if err := uc.Remove(ctx, 2, c.ID); err != nil {
+		t.Fatalf("contact steps down: %v", err)
+	}
+
+	if _, err := uc.Trusted(ctx, 1); !errors.Is(err, domain.ErrEmptyContacts) {
+		t.Fatalf("expected ErrEmptyContacts, got: %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package file_obj
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	domain "server/internal/app/domain/file_obj"
+	"time"
+)
+
+// ProcessOperations finishes operations left behind by interrupted uploads and deletes.
+// It returns the number of completed operations, failed ones are rescheduled with backoff.
+func (u *FileObj) ProcessOperations(ctx context.Context, opts domain.OutboxOptions) (int, error) {
+	now := time.Now()
+
+	ops, err := u.repo.ClaimOperations(ctx, now.Add(-u.staleAfter(opts)), opts.Lease, opts.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("claim operations: %w", err)
+	}
+
+	var (
+		done int
+		errs []error
+	)
+
+	for _, op := range ops {
+		if err := u.applyOperation(ctx, op); err != nil {
+			next := time.Now().Add(op.RetryDelay())
+			if rErr := u.repo.RetryOperation(ctx, op, err.Error(), next); rErr != nil {
+				errs = append(errs, fmt.Errorf("reschedule operation id=%d: %w", op.ID, rErr))
+			}
+			errs = append(errs, fmt.Errorf("%s operation id=%d key=%s: %w", op.Type, op.ID, op.Storage.ObjectKey, err))
+			continue
+		}
+		done++
+	}
+
+	return done, errors.Join(errs...)
+}
+
+// staleAfter never lets the outbox claim an upload its request may still be writing,
+// the lease covers the metadata update that follows the storage write
+func (u *FileObj) staleAfter(opts domain.OutboxOptions) time.Duration {
+	if u.uploadTimeout <= 0 {
+		return opts.StaleAfter
+	}
+	return max(opts.StaleAfter, u.uploadTimeout+opts.Lease)
+}
+
+func (u *FileObj) applyOperation(ctx context.Context, op *domain.Operation) error {
+	switch op.Type {
+	case domain.OperationUpload:
+		return u.finishUpload(ctx, op)
+	case domain.OperationDelete:
+		if err := u.storage.DeleteObject(ctx, op.Storage.BucketName, op.Storage.ObjectKey); err != nil {
+			return err
+		}
+		return u.repo.CompleteOperation(ctx, op)
+	default:
+		return fmt.Errorf("unknown operation type %q", op.Type)
+	}
+}
+
+// finishUpload keeps the file when its object made it to storage intact and drops it otherwise
+func (u *FileObj) finishUpload(ctx context.Context, op *domain.Operation) error {
+	obj, err := u.storage.StatObject(ctx, op.Storage.BucketName, op.Storage.ObjectKey)
+	if err != nil {
+		if errors.Is(err, domain.ErrObjectNotFound) {
+			return u.repo.AbortUpload(ctx, op.FileID)
+		}
+		return err
+	}
+
+	if obj.SizeBytes != op.SizeBytes {
+		if err := u.storage.DeleteObject(ctx, op.Storage.BucketName, op.Storage.ObjectKey); err != nil {
+			return err
+		}
+		return u.repo.AbortUpload(ctx, op.FileID)
+	}
+
+	err = u.repo.MarkUploaded(ctx, op.FileID, obj.ETag)
+	if errors.Is(err, domain.ErrFileNotFound) {
+		// the row is gone already, only the operation is left
+		return u.repo.CompleteOperation(ctx, op)
+	}
+	return err
+}
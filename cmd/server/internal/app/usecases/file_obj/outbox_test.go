@@ -0,0 +1,282 @@
+package file_obj
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "server/internal/app/domain/file_obj"
+)
+
+func TestFileObj_DeleteFile(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	file := func() *domain.File {
+		return &domain.File{ID: 5, UserID: 1, Storage: domain.StorageRef{BucketName: "b", ObjectKey: "k"}}
+	}
+
+	t.Run("invalid fileID -> ErrInvalidFileID", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{}, &storageFake{}, nil, 0)
+		if err := uc.DeleteFile(ctx, 1, 0); !errors.Is(err, domain.ErrInvalidFileID) {
+			t.Fatalf("expected ErrInvalidFileID, got: %v", err)
+		}
+	})
+
+	t.Run("other user -> ErrInvalidUserID", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			getByID: func(ctx context.Context, id int64) (*domain.File, error) { return file(), nil },
+			delete: func(ctx context.Context, id int64) error {
+				t.Fatalf("Delete must not be called")
+				return nil
+			},
+		}, &storageFake{}, nil, 0)
+
+		if err := uc.DeleteFile(ctx, 2, 5); !errors.Is(err, domain.ErrInvalidUserID) {
+			t.Fatalf("expected ErrInvalidUserID, got: %v", err)
+		}
+	})
+
+	t.Run("storage error -> nil, operation left for the outbox", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			getByID: func(ctx context.Context, id int64) (*domain.File, error) { return file(), nil },
+			completeOperation: func(ctx context.Context, op *domain.Operation) error {
+				t.Fatalf("CompleteOperation must not be called")
+				return nil
+			},
+		}, &storageFake{
+			deleteObject: func(ctx context.Context, bucket, key string) error { return errors.New("minio down") },
+		}, nil, 0)
+
+		if err := uc.DeleteFile(ctx, 1, 5); err != nil {
+			t.Fatalf("expected nil, got: %v", err)
+		}
+	})
+
+	t.Run("ok -> row, object and operation removed", func(t *testing.T) {
+		t.Parallel()
+
+		var deleted, removed, completed bool
+
+		uc := New(&repoFake{
+			getByID: func(ctx context.Context, id int64) (*domain.File, error) { return file(), nil },
+			delete: func(ctx context.Context, id int64) error {
+				deleted = true
+				return nil
+			},
+			completeOperation: func(ctx context.Context, op *domain.Operation) error {
+				if op.Type != domain.OperationDelete || op.Storage.ObjectKey != "k" {
+					t.Fatalf("unexpected operation: %+v", op)
+				}
+				completed = true
+				return nil
+			},
+		}, &storageFake{
+			deleteObject: func(ctx context.Context, bucket, key string) error {
+				removed = true
+				return nil
+			},
+		}, nil, 0)
+
+		if err := uc.DeleteFile(ctx, 1, 5); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if !deleted || !removed || !completed {
+			t.Fatalf("expected all steps, got deleted=%v removed=%v completed=%v", deleted, removed, completed)
+		}
+	})
+}
+
+func TestFileObj_ProcessOperations(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	opts := domain.OutboxOptions{StaleAfter: time.Minute, Lease: time.Minute, BatchSize: 10}
+
+	op := func(typ, key string, size int64) *domain.Operation {
+		return &domain.Operation{
+			ID:        1,
+			Type:      typ,
+			FileID:    5,
+			Storage:   domain.StorageRef{BucketName: "b", ObjectKey: key},
+			SizeBytes: size,
+			Attempts:  1,
+		}
+	}
+
+	claim := func(ops ...*domain.Operation) func(context.Context, time.Time, time.Duration, int) ([]*domain.Operation, error) {
+		return func(ctx context.Context, staleBefore time.Time, lease time.Duration, limit int) ([]*domain.Operation, error) {
+			if limit != 10 || lease != time.Minute {
+				t.Fatalf("unexpected claim args lease=%v limit=%d", lease, limit)
+			}
+			return ops, nil
+		}
+	}
+
+	t.Run("claim error -> wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		claimErr := errors.New("db down")
+		uc := New(&repoFake{
+			claimOperations: func(ctx context.Context, staleBefore time.Time, lease time.Duration, limit int) ([]*domain.Operation, error) {
+				return nil, claimErr
+			},
+		}, &storageFake{}, nil, 0)
+
+		if _, err := uc.ProcessOperations(ctx, opts); !errors.Is(err, claimErr) {
+			t.Fatalf("expected wrapped claimErr, got: %v", err)
+		}
+	})
+
+	t.Run("upload with object -> MarkUploaded with storage ETag", func(t *testing.T) {
+		t.Parallel()
+
+		var etag string
+		uc := New(&repoFake{
+			claimOperations: claim(op(domain.OperationUpload, "k", 3)),
+			markUploaded: func(ctx context.Context, fileID int64, e string) error {
+				etag = e
+				return nil
+			},
+		}, &storageFake{
+			statObject: func(ctx context.Context, bucket, key string) (domain.ObjectInfo, error) {
+				return domain.ObjectInfo{Key: key, SizeBytes: 3, ETag: "etag-s"}, nil
+			},
+		}, nil, 0)
+
+		done, err := uc.ProcessOperations(ctx, opts)
+		if err != nil || done != 1 {
+			t.Fatalf("expected 1 done, got %d, %v", done, err)
+		}
+		if etag != "etag-s" {
+			t.Fatalf("expected ETag from storage, got %q", etag)
+		}
+	})
+
+	t.Run("upload without object -> AbortUpload", func(t *testing.T) {
+		t.Parallel()
+
+		aborted := false
+		uc := New(&repoFake{
+			claimOperations: claim(op(domain.OperationUpload, "k", 3)),
+			abortUpload: func(ctx context.Context, fileID int64) error {
+				aborted = fileID == 5
+				return nil
+			},
+		}, &storageFake{
+			statObject: func(ctx context.Context, bucket, key string) (domain.ObjectInfo, error) {
+				return domain.ObjectInfo{}, domain.ErrObjectNotFound
+			},
+		}, nil, 0)
+
+		if _, err := uc.ProcessOperations(ctx, opts); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if !aborted {
+			t.Fatalf("expected AbortUpload(5)")
+		}
+	})
+
+	t.Run("upload with truncated object -> object removed, upload aborted", func(t *testing.T) {
+		t.Parallel()
+
+		var removed, aborted bool
+		uc := New(&repoFake{
+			claimOperations: claim(op(domain.OperationUpload, "k", 3)),
+			abortUpload: func(ctx context.Context, fileID int64) error {
+				aborted = true
+				return nil
+			},
+		}, &storageFake{
+			statObject: func(ctx context.Context, bucket, key string) (domain.ObjectInfo, error) {
+				return domain.ObjectInfo{Key: key, SizeBytes: 1}, nil
+			},
+			deleteObject: func(ctx context.Context, bucket, key string) error {
+				removed = true
+				return nil
+			},
+		}, nil, 0)
+
+		if _, err := uc.ProcessOperations(ctx, opts); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if !removed || !aborted {
+			t.Fatalf("expected removed and aborted, got %v %v", removed, aborted)
+		}
+	})
+
+	t.Run("delete failure -> rescheduled with backoff", func(t *testing.T) {
+		t.Parallel()
+
+		storageErr := errors.New("minio down")
+		var (
+			lastErr string
+			next    time.Time
+		)
+		uc := New(&repoFake{
+			claimOperations: claim(op(domain.OperationDelete, "k", 0)),
+			retryOperation: func(ctx context.Context, op *domain.Operation, e string, n time.Time) error {
+				lastErr, next = e, n
+				return nil
+			},
+		}, &storageFake{
+			deleteObject: func(ctx context.Context, bucket, key string) error { return storageErr },
+		}, nil, 0)
+
+		done, err := uc.ProcessOperations(ctx, opts)
+		if !errors.Is(err, storageErr) || done != 0 {
+			t.Fatalf("expected storageErr and 0 done, got %d, %v", done, err)
+		}
+		if lastErr != storageErr.Error() {
+			t.Fatalf("expected last error recorded, got %q", lastErr)
+		}
+		if time.Until(next) < time.Second {
+			t.Fatalf("expected next attempt in the future, got %v", next)
+		}
+	})
+
+	t.Run("delete ok -> operation completed", func(t *testing.T) {
+		t.Parallel()
+
+		completed := false
+		uc := New(&repoFake{
+			claimOperations: claim(op(domain.OperationDelete, "k", 0)),
+			completeOperation: func(ctx context.Context, op *domain.Operation) error {
+				completed = true
+				return nil
+			},
+		}, &storageFake{}, nil, 0)
+
+		done, err := uc.ProcessOperations(ctx, opts)
+		if err != nil || done != 1 || !completed {
+			t.Fatalf("expected completed operation, got done=%d err=%v completed=%v", done, err, completed)
+		}
+	})
+
+	t.Run("upload timeout -> running uploads are not claimed before timeout and lease", func(t *testing.T) {
+		t.Parallel()
+
+		var gotStaleBefore time.Time
+		uc := New(&repoFake{
+			claimOperations: func(ctx context.Context, staleBefore time.Time, lease time.Duration, limit int) ([]*domain.Operation, error) {
+				gotStaleBefore = staleBefore
+				return nil, nil
+			},
+		}, &storageFake{}, nil, 10*time.Minute)
+
+		if _, err := uc.ProcessOperations(ctx, opts); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if age := time.Since(gotStaleBefore); age < 11*time.Minute {
+			t.Fatalf("expected operations older than upload timeout + lease, got %v", age)
+		}
+	})
+}
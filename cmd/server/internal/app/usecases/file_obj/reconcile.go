@@ -0,0 +1,106 @@
+package file_obj
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	domain "server/internal/app/domain/file_obj"
+	"time"
+)
+
+// Reconcile compares objects in the bucket with file_data rows. It reports objects without metadata,
+// metadata without objects and size/ETag mismatches, and removes the first two when opts.Repair is set.
+func (u *FileObj) Reconcile(ctx context.Context, opts domain.ReconcileOptions) (*domain.ReconcileReport, error) {
+	if opts.Bucket == "" {
+		return nil, domain.ErrEmptyBucketName
+	}
+
+	report := &domain.ReconcileReport{
+		Bucket:    opts.Bucket,
+		StartedAt: time.Now(),
+	}
+
+	// rows go first: a file finished between the two calls then shows up as a fresh object,
+	// which the grace period keeps, instead of a row without an object
+	files, err := u.repo.ListByBucket(ctx, opts.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("list files bucket=%s: %w", opts.Bucket, err)
+	}
+
+	objects, err := u.storage.ListObjects(ctx, opts.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("list objects bucket=%s: %w", opts.Bucket, err)
+	}
+
+	report.ObjectsTotal = len(objects)
+	report.FilesTotal = len(files)
+
+	byKey := make(map[string]domain.ObjectInfo, len(objects))
+	for _, obj := range objects {
+		byKey[obj.Key] = obj
+	}
+
+	graceLimit := report.StartedAt.Add(-opts.GracePeriod)
+	for _, f := range files {
+		obj, ok := byKey[f.Storage.ObjectKey]
+		if !ok {
+			// pending uploads belong to the outbox
+			if f.Status == domain.StatusPending {
+				continue
+			}
+			if opts.GracePeriod > 0 && f.CreatedAt.After(graceLimit) {
+				continue
+			}
+			report.DanglingFiles = append(report.DanglingFiles, f)
+			continue
+		}
+		delete(byKey, f.Storage.ObjectKey)
+
+		if obj.SizeBytes != f.SizeBytes || (f.ETag != "" && !domain.SameETag(obj.ETag, f.ETag)) {
+			report.Mismatches = append(report.Mismatches, domain.Mismatch{File: f, Object: obj})
+		}
+	}
+
+	// whatever is left in byKey has no metadata
+	for _, obj := range objects {
+		if _, ok := byKey[obj.Key]; !ok {
+			continue
+		}
+		if opts.GracePeriod > 0 && obj.LastModified.After(graceLimit) {
+			continue
+		}
+		report.OrphanObjects = append(report.OrphanObjects, obj)
+	}
+
+	if opts.Repair {
+		if err := u.repair(ctx, report); err != nil {
+			return report, err
+		}
+	}
+
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+func (u *FileObj) repair(ctx context.Context, report *domain.ReconcileReport) error {
+	var errs []error
+
+	for _, obj := range report.OrphanObjects {
+		if err := u.storage.DeleteObject(ctx, report.Bucket, obj.Key); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		report.RemovedObjects++
+	}
+
+	for _, f := range report.DanglingFiles {
+		err := u.repo.Delete(ctx, f.ID)
+		if err != nil && !errors.Is(err, domain.ErrFileNotFound) {
+			errs = append(errs, fmt.Errorf("delete file meta id=%d: %w", f.ID, err))
+			continue
+		}
+		report.RemovedFiles++
+	}
+
+	return errors.Join(errs...)
+}
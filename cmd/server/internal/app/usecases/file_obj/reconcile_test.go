@@ -0,0 +1,227 @@
+package file_obj
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "server/internal/app/domain/file_obj"
+)
+
+func reconcileFixture() ([]domain.ObjectInfo, []*domain.File) {
+	old := time.Now().Add(-24 * time.Hour)
+
+	objects := []domain.ObjectInfo{
+		{Key: "ok", SizeBytes: 10, ETag: "aaa", LastModified: old},
+		{Key: "orphan-old", SizeBytes: 5, ETag: "bbb", LastModified: old},
+		{Key: "orphan-fresh", SizeBytes: 5, ETag: "ccc", LastModified: time.Now()},
+		{Key: "size-diff", SizeBytes: 11, ETag: "ddd", LastModified: old},
+		{Key: "etag-diff", SizeBytes: 3, ETag: "eee", LastModified: old},
+	}
+	files := []*domain.File{
+		{ID: 1, Storage: domain.StorageRef{BucketName: "b", ObjectKey: "ok"}, SizeBytes: 10, ETag: `"AAA"`},
+		{ID: 2, Storage: domain.StorageRef{BucketName: "b", ObjectKey: "size-diff"}, SizeBytes: 10, ETag: "ddd"},
+		{ID: 3, Storage: domain.StorageRef{BucketName: "b", ObjectKey: "etag-diff"}, SizeBytes: 3, ETag: "zzz"},
+		{ID: 4, Storage: domain.StorageRef{BucketName: "b", ObjectKey: "missing"}, SizeBytes: 1},
+		{ID: 5, Storage: domain.StorageRef{BucketName: "b", ObjectKey: "uploading"}, SizeBytes: 1, Status: domain.StatusPending},
+	}
+	return objects, files
+}
+
+func TestFileObj_Reconcile(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("empty bucket -> ErrEmptyBucketName", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{}, &storageFake{}, nil, 0)
+		_, err := uc.Reconcile(ctx, domain.ReconcileOptions{})
+		if !errors.Is(err, domain.ErrEmptyBucketName) {
+			t.Fatalf("expected ErrEmptyBucketName, got: %v", err)
+		}
+	})
+
+	t.Run("file finished while the bucket is listed -> not dangling", func(t *testing.T) {
+		t.Parallel()
+
+		objects, files := reconcileFixture()
+		racing := &domain.File{ID: 9, Storage: domain.StorageRef{BucketName: "b", ObjectKey: "racing"}, SizeBytes: 1, CreatedAt: time.Now()}
+		listed := false
+		var deletedIDs []int64
+
+		uc := New(&repoFake{
+			listByBucket: func(ctx context.Context, bucket string) ([]*domain.File, error) {
+				if listed {
+					return append(files, racing), nil
+				}
+				return files, nil
+			},
+			delete: func(ctx context.Context, id int64) error {
+				deletedIDs = append(deletedIDs, id)
+				return nil
+			},
+		}, &storageFake{
+			listObjects: func(ctx context.Context, bucket string) ([]domain.ObjectInfo, error) {
+				// the upload completes and is marked ready right after the listing
+				listed = true
+				return objects, nil
+			},
+		}, nil, 0)
+
+		report, err := uc.Reconcile(ctx, domain.ReconcileOptions{Bucket: "b", Repair: true, GracePeriod: time.Hour})
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		for _, f := range report.DanglingFiles {
+			if f.ID == racing.ID {
+				t.Fatalf("racing upload reported as dangling: %+v", report.DanglingFiles)
+			}
+		}
+		if len(deletedIDs) != 1 || deletedIDs[0] != 4 {
+			t.Fatalf("only the old dangling row may be deleted, got: %v", deletedIDs)
+		}
+	})
+
+	t.Run("fresh row without object -> kept by the grace period", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			listByBucket: func(ctx context.Context, bucket string) ([]*domain.File, error) {
+				return []*domain.File{{ID: 7, Storage: domain.StorageRef{BucketName: "b", ObjectKey: "new"}, CreatedAt: time.Now()}}, nil
+			},
+		}, &storageFake{}, nil, 0)
+
+		report, err := uc.Reconcile(ctx, domain.ReconcileOptions{Bucket: "b", GracePeriod: time.Hour})
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if len(report.DanglingFiles) != 0 {
+			t.Fatalf("expected no dangling rows, got: %+v", report.DanglingFiles)
+		}
+	})
+
+	t.Run("storage list error -> wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		listErr := errors.New("minio down")
+		uc := New(&repoFake{}, &storageFake{
+			listObjects: func(ctx context.Context, bucket string) ([]domain.ObjectInfo, error) {
+				return nil, listErr
+			},
+		}, nil, 0)
+
+		_, err := uc.Reconcile(ctx, domain.ReconcileOptions{Bucket: "b"})
+		if !errors.Is(err, listErr) {
+			t.Fatalf("expected wrapped listErr, got: %v", err)
+		}
+	})
+
+	t.Run("report only -> finds orphans, dangling rows and mismatches without deleting", func(t *testing.T) {
+		t.Parallel()
+
+		objects, files := reconcileFixture()
+		deleted := 0
+
+		uc := New(&repoFake{
+			listByBucket: func(ctx context.Context, bucket string) ([]*domain.File, error) { return files, nil },
+			delete: func(ctx context.Context, id int64) error {
+				deleted++
+				return nil
+			},
+		}, &storageFake{
+			listObjects: func(ctx context.Context, bucket string) ([]domain.ObjectInfo, error) { return objects, nil },
+			deleteObject: func(ctx context.Context, bucket, key string) error {
+				deleted++
+				return nil
+			},
+		}, nil, 0)
+
+		report, err := uc.Reconcile(ctx, domain.ReconcileOptions{Bucket: "b", GracePeriod: time.Hour})
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+
+		if len(report.OrphanObjects) != 1 || report.OrphanObjects[0].Key != "orphan-old" {
+			t.Fatalf("expected only orphan-old (fresh one is in grace period), got: %+v", report.OrphanObjects)
+		}
+		if len(report.DanglingFiles) != 1 || report.DanglingFiles[0].ID != 4 {
+			t.Fatalf("expected dangling file id=4, got: %+v", report.DanglingFiles)
+		}
+		if len(report.Mismatches) != 2 {
+			t.Fatalf("expected 2 mismatches, got: %+v", report.Mismatches)
+		}
+		if report.Clean() {
+			t.Fatalf("report must not be clean")
+		}
+		if deleted != 0 {
+			t.Fatalf("nothing must be deleted without Repair, deleted=%d", deleted)
+		}
+	})
+
+	t.Run("repair -> deletes orphan objects and dangling rows", func(t *testing.T) {
+		t.Parallel()
+
+		objects, files := reconcileFixture()
+
+		var (
+			deletedKeys []string
+			deletedIDs  []int64
+		)
+
+		uc := New(&repoFake{
+			listByBucket: func(ctx context.Context, bucket string) ([]*domain.File, error) { return files, nil },
+			delete: func(ctx context.Context, id int64) error {
+				deletedIDs = append(deletedIDs, id)
+				return nil
+			},
+		}, &storageFake{
+			listObjects: func(ctx context.Context, bucket string) ([]domain.ObjectInfo, error) { return objects, nil },
+			deleteObject: func(ctx context.Context, bucket, key string) error {
+				deletedKeys = append(deletedKeys, key)
+				return nil
+			},
+		}, nil, 0)
+
+		report, err := uc.Reconcile(ctx, domain.ReconcileOptions{Bucket: "b", Repair: true, GracePeriod: time.Hour})
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+
+		if len(deletedKeys) != 1 || deletedKeys[0] != "orphan-old" {
+			t.Fatalf("unexpected deleted objects: %v", deletedKeys)
+		}
+		if len(deletedIDs) != 1 || deletedIDs[0] != 4 {
+			t.Fatalf("unexpected deleted rows: %v", deletedIDs)
+		}
+		if report.RemovedObjects != 1 || report.RemovedFiles != 1 {
+			t.Fatalf("unexpected removed counters: objects=%d files=%d", report.RemovedObjects, report.RemovedFiles)
+		}
+	})
+
+	t.Run("repair errors are returned together with the report", func(t *testing.T) {
+		t.Parallel()
+
+		objects, files := reconcileFixture()
+		rmErr := errors.New("remove failed")
+
+		uc := New(&repoFake{
+			listByBucket: func(ctx context.Context, bucket string) ([]*domain.File, error) { return files, nil },
+		}, &storageFake{
+			listObjects: func(ctx context.Context, bucket string) ([]domain.ObjectInfo, error) { return objects, nil },
+			deleteObject: func(ctx context.Context, bucket, key string) error {
+				return rmErr
+			},
+		}, nil, 0)
+
+		report, err := uc.Reconcile(ctx, domain.ReconcileOptions{Bucket: "b", Repair: true, GracePeriod: time.Hour})
+		if !errors.Is(err, rmErr) {
+			t.Fatalf("expected rmErr, got: %v", err)
+		}
+		if report == nil || report.RemovedObjects != 0 || report.RemovedFiles != 1 {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+	})
+}
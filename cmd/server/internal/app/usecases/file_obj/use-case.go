@@ -6,14 +6,23 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"server/internal/app/domain/audit"
 	domain "server/internal/app/domain/file_obj"
+	"time"
 )
 
 type Repository interface {
 	Create(ctx context.Context, f *domain.File) (int64, error)
 	GetByID(ctx context.Context, id int64) (*domain.File, error)
 	ListByUserID(ctx context.Context, userID int64) ([]*domain.File, error)
+	ListByBucket(ctx context.Context, bucket string) ([]*domain.File, error)
 	Delete(ctx context.Context, id int64) error
+
+	MarkUploaded(ctx context.Context, fileID int64, etag string) error
+	AbortUpload(ctx context.Context, fileID int64) error
+	ClaimOperations(ctx context.Context, staleBefore time.Time, lease time.Duration, limit int) ([]*domain.Operation, error)
+	CompleteOperation(ctx context.Context, op *domain.Operation) error
+	RetryOperation(ctx context.Context, op *domain.Operation, lastErr string, next time.Time) error
 }
 
 type ObjectStorage interface {
@@ -24,15 +33,27 @@ type ObjectStorage interface {
 		bucket string,
 		objectKey string,
 	) (io.ReadCloser, error)
+	ListObjects(ctx context.Context, bucket string) ([]domain.ObjectInfo, error)
+	StatObject(ctx context.Context, bucket string, key string) (domain.ObjectInfo, error)
+}
+
+// Auditor writes access to items into the audit log
+type Auditor interface {
+	Record(ctx context.Context, e audit.Event)
 }
 
 type FileObj struct {
 	repo    Repository
 	storage ObjectStorage
+	audit   Auditor
+
+	uploadTimeout time.Duration
 }
 
-func New(repo Repository, storage ObjectStorage) *FileObj {
-	return &FileObj{repo: repo, storage: storage}
+// New creates the use case, without auditor nothing is logged.
+// uploadTimeout bounds the storage write of an upload, zero leaves it to the request context.
+func New(repo Repository, storage ObjectStorage, auditor Auditor, uploadTimeout time.Duration) *FileObj {
+	return &FileObj{repo: repo, storage: storage, audit: auditor, uploadTimeout: uploadTimeout}
 }
 
 func (u *FileObj) GetByID(ctx context.Context, fileID int64) (*domain.File, error) {
@@ -70,6 +91,8 @@ func (u *FileObj) GetFileList(ctx context.Context, userID int64) ([]*domain.File
 	return list, nil
 }
 
+// UploadAndCreate records the file as pending with an upload operation before touching storage,
+// so a crash between the two steps is finished or rolled back by ProcessOperations.
 func (u *FileObj) UploadAndCreate(ctx context.Context, file *domain.File, data []byte) (int64, error) {
 	if file == nil {
 		return 0, fmt.Errorf("file is nil")
@@ -77,8 +100,21 @@ func (u *FileObj) UploadAndCreate(ctx context.Context, file *domain.File, data [
 	if u.storage == nil {
 		return 0, fmt.Errorf("storage is nil")
 	}
+
+	id, err := u.repo.Create(ctx, file)
+	if err != nil {
+		return 0, fmt.Errorf("create file meta: %w", err)
+	}
+
+	putCtx := ctx
+	if u.uploadTimeout > 0 {
+		var cancel context.CancelFunc
+		putCtx, cancel = context.WithTimeout(ctx, u.uploadTimeout)
+		defer cancel()
+	}
+
 	etag, err := u.storage.PutObject(
-		ctx,
+		putCtx,
 		file.Storage.BucketName,
 		file.Storage.ObjectKey,
 		bytes.NewReader(data),
@@ -86,22 +122,72 @@ func (u *FileObj) UploadAndCreate(ctx context.Context, file *domain.File, data [
 		file.ContentType,
 	)
 	if err != nil {
+		// rollback metadata, the outbox retries it if this fails too
+		_ = u.repo.AbortUpload(ctx, id)
 		return 0, fmt.Errorf("upload to storage bucket=%s key=%s: %w",
 			file.Storage.BucketName, file.Storage.ObjectKey, err)
 	}
 
 	file.ETag = etag
 
-	id, err := u.repo.Create(ctx, file)
-	if err != nil {
-		// rollback storage
-		_ = u.storage.DeleteObject(ctx, file.Storage.BucketName, file.Storage.ObjectKey)
-		return 0, fmt.Errorf("create file meta: %w", err)
+	if err := u.repo.MarkUploaded(ctx, id, etag); err != nil {
+		return 0, fmt.Errorf("mark file uploaded id=%d: %w", id, err)
 	}
+	file.Status = domain.StatusReady
+
+	u.record(ctx, file.UserID, id, audit.ActionCreate)
 
 	return id, nil
 }
 
+// DeleteFile removes file metadata and its object. The object removal is recorded in the outbox,
+// so it is retried by ProcessOperations when storage is unavailable.
+func (u *FileObj) DeleteFile(ctx context.Context, userID, fileID int64) error {
+	if fileID <= 0 {
+		return domain.ErrInvalidFileID
+	}
+
+	f, err := u.repo.GetByID(ctx, fileID)
+	if err != nil {
+		if errors.Is(err, domain.ErrFileNotFound) {
+			return err
+		}
+		return fmt.Errorf("get file by id=%d: %w", fileID, err)
+	}
+
+	if f.UserID != userID {
+		return domain.ErrInvalidUserID
+	}
+
+	if err := u.repo.Delete(ctx, fileID); err != nil {
+		if errors.Is(err, domain.ErrFileNotFound) {
+			return err
+		}
+		return fmt.Errorf("delete file meta id=%d: %w", fileID, err)
+	}
+
+	op := &domain.Operation{Type: domain.OperationDelete, FileID: fileID, Storage: f.Storage}
+
+	// metadata is already gone, on failure the outbox removes the object later
+	if err := u.storage.DeleteObject(ctx, f.Storage.BucketName, f.Storage.ObjectKey); err == nil {
+		_ = u.repo.CompleteOperation(ctx, op)
+	}
+
+	u.record(ctx, userID, fileID, audit.ActionDelete)
+
+	return nil
+}
+
+// RemoveObjects removes objects whose metadata is already deleted, like the files of a deleted account.
+// Objects that fail stay queued and are removed later by ProcessOperations
+func (u *FileObj) RemoveObjects(ctx context.Context, ops []*domain.Operation) {
+	for _, op := range ops {
+		if err := u.storage.DeleteObject(ctx, op.Storage.BucketName, op.Storage.ObjectKey); err == nil {
+			_ = u.repo.CompleteOperation(ctx, op)
+		}
+	}
+}
+
 func (u *FileObj) GetFileStream(ctx context.Context, userID, fileID int64) (*domain.File, io.ReadCloser, error) {
 
 	f, err := u.repo.GetByID(ctx, fileID)
@@ -120,5 +206,55 @@ func (u *FileObj) GetFileStream(ctx context.Context, userID, fileID int64) (*dom
 		return nil, nil, fmt.Errorf("get object: %w", err)
 	}
 
+	u.record(ctx, userID, fileID, audit.ActionDownload)
+
 	return f, rc, nil
 }
+
+// OpenFile streams a file the caller may read through something else, e.g. an attachment of an item
+// shared with them, the access has to be checked before
+func (u *FileObj) OpenFile(ctx context.Context, userID, fileID int64) (*domain.File, io.ReadCloser, error) {
+	if fileID <= 0 {
+		return nil, nil, domain.ErrInvalidFileID
+	}
+
+	f, err := u.repo.GetByID(ctx, fileID)
+	if err != nil {
+		if errors.Is(err, domain.ErrFileNotFound) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("get file by id=%d: %w", fileID, err)
+	}
+
+	rc, err := u.storage.GetObjectReader(ctx, f.Storage.BucketName, f.Storage.ObjectKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get object: %w", err)
+	}
+
+	if u.audit != nil {
+		u.audit.Record(ctx, audit.Event{
+			UserID:   userID,
+			OwnerID:  f.UserID,
+			Action:   audit.ActionDownload,
+			ItemType: audit.ItemFile,
+			ItemID:   fileID,
+		})
+	}
+
+	return f, rc, nil
+}
+
+// record writes the action of the owner on the file into the audit log, only owners reach files
+// outside of OpenFile
+func (u *FileObj) record(ctx context.Context, userID, fileID int64, action string) {
+	if u.audit == nil {
+		return
+	}
+	u.audit.Record(ctx, audit.Event{
+		UserID:   userID,
+		OwnerID:  userID,
+		Action:   action,
+		ItemType: audit.ItemFile,
+		ItemID:   fileID,
+	})
+}
@@ -7,7 +7,9 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"time"
 
+	"server/internal/app/domain/audit"
 	domain "server/internal/app/domain/file_obj"
 )
 
@@ -15,7 +17,14 @@ type repoFake struct {
 	create       func(ctx context.Context, f *domain.File) (int64, error)
 	getByID      func(ctx context.Context, id int64) (*domain.File, error)
 	listByUserID func(ctx context.Context, userID int64) ([]*domain.File, error)
+	listByBucket func(ctx context.Context, bucket string) ([]*domain.File, error)
 	delete       func(ctx context.Context, id int64) error
+
+	markUploaded      func(ctx context.Context, fileID int64, etag string) error
+	abortUpload       func(ctx context.Context, fileID int64) error
+	claimOperations   func(ctx context.Context, staleBefore time.Time, lease time.Duration, limit int) ([]*domain.Operation, error)
+	completeOperation func(ctx context.Context, op *domain.Operation) error
+	retryOperation    func(ctx context.Context, op *domain.Operation, lastErr string, next time.Time) error
 }
 
 func (r *repoFake) Create(ctx context.Context, f *domain.File) (int64, error) {
@@ -36,17 +45,55 @@ func (r *repoFake) ListByUserID(ctx context.Context, userID int64) ([]*domain.Fi
 	}
 	return nil, nil
 }
+func (r *repoFake) ListByBucket(ctx context.Context, bucket string) ([]*domain.File, error) {
+	if r.listByBucket != nil {
+		return r.listByBucket(ctx, bucket)
+	}
+	return nil, nil
+}
 func (r *repoFake) Delete(ctx context.Context, id int64) error {
 	if r.delete != nil {
 		return r.delete(ctx, id)
 	}
 	return nil
 }
+func (r *repoFake) MarkUploaded(ctx context.Context, fileID int64, etag string) error {
+	if r.markUploaded != nil {
+		return r.markUploaded(ctx, fileID, etag)
+	}
+	return nil
+}
+func (r *repoFake) AbortUpload(ctx context.Context, fileID int64) error {
+	if r.abortUpload != nil {
+		return r.abortUpload(ctx, fileID)
+	}
+	return nil
+}
+func (r *repoFake) ClaimOperations(ctx context.Context, staleBefore time.Time, lease time.Duration, limit int) ([]*domain.Operation, error) {
+	if r.claimOperations != nil {
+		return r.claimOperations(ctx, staleBefore, lease, limit)
+	}
+	return nil, nil
+}
+func (r *repoFake) CompleteOperation(ctx context.Context, op *domain.Operation) error {
+	if r.completeOperation != nil {
+		return r.completeOperation(ctx, op)
+	}
+	return nil
+}
+func (r *repoFake) RetryOperation(ctx context.Context, op *domain.Operation, lastErr string, next time.Time) error {
+	if r.retryOperation != nil {
+		return r.retryOperation(ctx, op, lastErr, next)
+	}
+	return nil
+}
 
 type storageFake struct {
 	putObject       func(ctx context.Context, bucket, key string, body io.Reader, size int64, contentType string) (string, error)
 	deleteObject    func(ctx context.Context, bucket, key string) error
 	getObjectReader func(ctx context.Context, bucket, objectKey string) (io.ReadCloser, error)
+	listObjects     func(ctx context.Context, bucket string) ([]domain.ObjectInfo, error)
+	statObject      func(ctx context.Context, bucket, key string) (domain.ObjectInfo, error)
 }
 
 func (s *storageFake) PutObject(ctx context.Context, bucket, key string, body io.Reader, size int64, contentType string) (string, error) {
@@ -68,6 +115,28 @@ func (s *storageFake) GetObjectReader(ctx context.Context, bucket, objectKey str
 	return nil, nil
 }
 
+func (s *storageFake) ListObjects(ctx context.Context, bucket string) ([]domain.ObjectInfo, error) {
+	if s.listObjects != nil {
+		return s.listObjects(ctx, bucket)
+	}
+	return nil, nil
+}
+
+func (s *storageFake) StatObject(ctx context.Context, bucket, key string) (domain.ObjectInfo, error) {
+	if s.statObject != nil {
+		return s.statObject(ctx, bucket, key)
+	}
+	return domain.ObjectInfo{}, nil
+}
+
+type auditFake struct {
+	events []audit.Event
+}
+
+func (a *auditFake) Record(ctx context.Context, e audit.Event) {
+	a.events = append(a.events, e)
+}
+
 type nopCloser struct{ io.Reader }
 
 func (n nopCloser) Close() error { return nil }
@@ -80,7 +149,7 @@ func TestFileObj_GetByID(t *testing.T) {
 	t.Run("invalid fileID -> ErrInvalidFileID", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{}, &storageFake{})
+		uc := New(&repoFake{}, &storageFake{}, nil, 0)
 		_, err := uc.GetByID(ctx, 0)
 		if !errors.Is(err, domain.ErrInvalidFileID) {
 			t.Fatalf("expected ErrInvalidFileID, got: %v", err)
@@ -94,7 +163,7 @@ func TestFileObj_GetByID(t *testing.T) {
 			getByID: func(ctx context.Context, id int64) (*domain.File, error) {
 				return nil, domain.ErrFileNotFound
 			},
-		}, &storageFake{})
+		}, &storageFake{}, nil, 0)
 
 		_, err := uc.GetByID(ctx, 10)
 		if !errors.Is(err, domain.ErrFileNotFound) {
@@ -111,7 +180,7 @@ func TestFileObj_GetByID(t *testing.T) {
 			getByID: func(ctx context.Context, id int64) (*domain.File, error) {
 				return nil, dbErr
 			},
-		}, &storageFake{})
+		}, &storageFake{}, nil, 0)
 
 		_, err := uc.GetByID(ctx, 99)
 		if err == nil {
@@ -134,7 +203,7 @@ func TestFileObj_GetByID(t *testing.T) {
 			getByID: func(ctx context.Context, id int64) (*domain.File, error) {
 				return want, nil
 			},
-		}, &storageFake{})
+		}, &storageFake{}, nil, 0)
 
 		got, err := uc.GetByID(ctx, 1)
 		if err != nil {
@@ -154,7 +223,7 @@ func TestFileObj_GetFileList(t *testing.T) {
 	t.Run("invalid userID -> ErrInvalidUserID", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{}, &storageFake{})
+		uc := New(&repoFake{}, &storageFake{}, nil, 0)
 		_, err := uc.GetFileList(ctx, 0)
 		if !errors.Is(err, domain.ErrInvalidUserID) {
 			t.Fatalf("expected ErrInvalidUserID, got: %v", err)
@@ -170,7 +239,7 @@ func TestFileObj_GetFileList(t *testing.T) {
 			listByUserID: func(ctx context.Context, userID int64) ([]*domain.File, error) {
 				return nil, dbErr
 			},
-		}, &storageFake{})
+		}, &storageFake{}, nil, 0)
 
 		_, err := uc.GetFileList(ctx, 7)
 		if err == nil {
@@ -191,7 +260,7 @@ func TestFileObj_GetFileList(t *testing.T) {
 			listByUserID: func(ctx context.Context, userID int64) ([]*domain.File, error) {
 				return []*domain.File{}, nil
 			},
-		}, &storageFake{})
+		}, &storageFake{}, nil, 0)
 
 		_, err := uc.GetFileList(ctx, 7)
 		if !errors.Is(err, domain.ErrEmptyFilesList) {
@@ -208,7 +277,7 @@ func TestFileObj_GetFileList(t *testing.T) {
 			listByUserID: func(ctx context.Context, userID int64) ([]*domain.File, error) {
 				return want, nil
 			},
-		}, &storageFake{})
+		}, &storageFake{}, nil, 0)
 
 		got, err := uc.GetFileList(ctx, 7)
 		if err != nil {
@@ -243,7 +312,7 @@ func TestFileObj_UploadAndCreate(t *testing.T) {
 	t.Run("file nil -> error", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{}, &storageFake{})
+		uc := New(&repoFake{}, &storageFake{}, nil, 0)
 		_, err := uc.UploadAndCreate(ctx, nil, []byte("abc"))
 		if err == nil || !strings.Contains(err.Error(), "file is nil") {
 			t.Fatalf("expected 'file is nil' error, got: %v", err)
@@ -253,23 +322,52 @@ func TestFileObj_UploadAndCreate(t *testing.T) {
 	t.Run("storage nil -> error", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{}, nil)
+		uc := New(&repoFake{}, nil, nil, 0)
 		_, err := uc.UploadAndCreate(ctx, baseFile(), []byte("abc"))
 		if err == nil || !strings.Contains(err.Error(), "storage is nil") {
 			t.Fatalf("expected 'storage is nil' error, got: %v", err)
 		}
 	})
 
+	t.Run("upload timeout -> storage write gets a deadline", func(t *testing.T) {
+		t.Parallel()
+
+		hasDeadline := false
+		uc := New(&repoFake{}, &storageFake{
+			putObject: func(ctx context.Context, bucket, key string, body io.Reader, size int64, contentType string) (string, error) {
+				_, hasDeadline = ctx.Deadline()
+				return "etag", nil
+			},
+		}, nil, time.Minute)
+
+		if _, err := uc.UploadAndCreate(ctx, baseFile(), []byte("abc")); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if !hasDeadline {
+			t.Fatalf("expected PutObject context with deadline")
+		}
+	})
+
 	t.Run("PutObject error -> wrapped with bucket/key", func(t *testing.T) {
 		t.Parallel()
 
 		putErr := errors.New("s3 down")
 
-		uc := New(&repoFake{}, &storageFake{
+		var aborted int64
+
+		uc := New(&repoFake{
+			create: func(ctx context.Context, f *domain.File) (int64, error) {
+				return 42, nil
+			},
+			abortUpload: func(ctx context.Context, fileID int64) error {
+				aborted = fileID
+				return nil
+			},
+		}, &storageFake{
 			putObject: func(ctx context.Context, bucket, key string, body io.Reader, size int64, contentType string) (string, error) {
 				return "", putErr
 			},
-		})
+		}, nil, 0)
 
 		_, err := uc.UploadAndCreate(ctx, baseFile(), []byte("abc"))
 		if err == nil {
@@ -281,38 +379,28 @@ func TestFileObj_UploadAndCreate(t *testing.T) {
 		if !strings.Contains(err.Error(), "upload to storage bucket=b key=k") {
 			t.Fatalf("expected context in error, got: %v", err)
 		}
+		if aborted != 42 {
+			t.Fatalf("expected AbortUpload(42), got %d", aborted)
+		}
 	})
 
-	t.Run("repo.Create error -> rollback DeleteObject called, error wrapped", func(t *testing.T) {
+	t.Run("repo.Create error -> storage untouched, error wrapped", func(t *testing.T) {
 		t.Parallel()
 
 		createErr := errors.New("insert failed")
 
-		deleted := false
-
 		uc := New(&repoFake{
 			create: func(ctx context.Context, f *domain.File) (int64, error) {
 				return 0, createErr
 			},
 		}, &storageFake{
 			putObject: func(ctx context.Context, bucket, key string, body io.Reader, size int64, contentType string) (string, error) {
-				b, _ := io.ReadAll(body)
-				if string(b) != "abc" {
-					t.Fatalf("expected body=abc, got %q", string(b))
-				}
-				return "etag-1", nil
+				t.Fatalf("PutObject must not be called")
+				return "", nil
 			},
-			deleteObject: func(ctx context.Context, bucket, key string) error {
-				if bucket != "b" || key != "k" {
-					t.Fatalf("expected delete bucket=b key=k, got bucket=%s key=%s", bucket, key)
-				}
-				deleted = true
-				return nil
-			},
-		})
+		}, nil, 0)
 
-		f := baseFile()
-		_, err := uc.UploadAndCreate(ctx, f, []byte("abc"))
+		_, err := uc.UploadAndCreate(ctx, baseFile(), []byte("abc"))
 		if err == nil {
 			t.Fatalf("expected error, got nil")
 		}
@@ -322,11 +410,33 @@ func TestFileObj_UploadAndCreate(t *testing.T) {
 		if !strings.Contains(err.Error(), "create file meta") {
 			t.Fatalf("expected context in error, got: %v", err)
 		}
-		if !deleted {
-			t.Fatalf("expected rollback DeleteObject to be called")
-		}
-		if f.ETag != "etag-1" {
-			t.Fatalf("expected ETag=etag-1, got %q", f.ETag)
+	})
+
+	t.Run("MarkUploaded error -> wrapped, object kept for the outbox", func(t *testing.T) {
+		t.Parallel()
+
+		markErr := errors.New("update failed")
+
+		uc := New(&repoFake{
+			create: func(ctx context.Context, f *domain.File) (int64, error) {
+				return 42, nil
+			},
+			markUploaded: func(ctx context.Context, fileID int64, etag string) error {
+				return markErr
+			},
+		}, &storageFake{
+			putObject: func(ctx context.Context, bucket, key string, body io.Reader, size int64, contentType string) (string, error) {
+				return "etag-1", nil
+			},
+			deleteObject: func(ctx context.Context, bucket, key string) error {
+				t.Fatalf("DeleteObject must not be called")
+				return nil
+			},
+		}, nil, 0)
+
+		_, err := uc.UploadAndCreate(ctx, baseFile(), []byte("abc"))
+		if !errors.Is(err, markErr) {
+			t.Fatalf("expected wrapped markErr, got: %v", err)
 		}
 	})
 
@@ -335,11 +445,14 @@ func TestFileObj_UploadAndCreate(t *testing.T) {
 
 		uc := New(&repoFake{
 			create: func(ctx context.Context, f *domain.File) (int64, error) {
-				if f.ETag != "etag-ok" {
-					t.Fatalf("expected ETag=etag-ok, got %q", f.ETag)
-				}
 				return 777, nil
 			},
+			markUploaded: func(ctx context.Context, fileID int64, etag string) error {
+				if fileID != 777 || etag != "etag-ok" {
+					t.Fatalf("expected MarkUploaded(777, etag-ok), got (%d, %q)", fileID, etag)
+				}
+				return nil
+			},
 		}, &storageFake{
 			putObject: func(ctx context.Context, bucket, key string, body io.Reader, size int64, contentType string) (string, error) {
 				if bucket != "b" || key != "k" {
@@ -357,7 +470,7 @@ func TestFileObj_UploadAndCreate(t *testing.T) {
 				}
 				return "etag-ok", nil
 			},
-		})
+		}, nil, 0)
 
 		f := baseFile()
 		id, err := uc.UploadAndCreate(ctx, f, []byte("abc"))
@@ -385,7 +498,7 @@ func TestFileObj_GetFileStream(t *testing.T) {
 			getByID: func(ctx context.Context, id int64) (*domain.File, error) {
 				return nil, domain.ErrFileNotFound
 			},
-		}, &storageFake{})
+		}, &storageFake{}, nil, 0)
 
 		_, _, err := uc.GetFileStream(ctx, 1, 10)
 		if !errors.Is(err, domain.ErrFileNotFound) {
@@ -412,7 +525,7 @@ func TestFileObj_GetFileStream(t *testing.T) {
 				t.Fatalf("storage.GetObjectReader must NOT be called on user mismatch")
 				return nil, nil
 			},
-		})
+		}, nil, 0)
 
 		_, _, err := uc.GetFileStream(ctx, 1, 10)
 		if !errors.Is(err, domain.ErrInvalidUserID) {
@@ -440,7 +553,7 @@ func TestFileObj_GetFileStream(t *testing.T) {
 			getObjectReader: func(ctx context.Context, bucket, objectKey string) (io.ReadCloser, error) {
 				return nil, stErr
 			},
-		})
+		}, nil, 0)
 
 		_, _, err := uc.GetFileStream(ctx, 1, 10)
 		if err == nil {
@@ -479,7 +592,7 @@ func TestFileObj_GetFileStream(t *testing.T) {
 				}
 				return rc, nil
 			},
-		})
+		}, nil, 0)
 
 		gotFile, gotRC, err := uc.GetFileStream(ctx, 1, 10)
 		if err != nil {
@@ -499,3 +612,84 @@ func TestFileObj_GetFileStream(t *testing.T) {
 		}
 	})
 }
+
+func TestFileObj_OpenFile(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("file of another user -> opened, audit names the owner", func(t *testing.T) {
+		t.Parallel()
+
+		auditor := &auditFake{}
+		uc := New(&repoFake{
+			getByID: func(ctx context.Context, id int64) (*domain.File, error) {
+				return &domain.File{ID: id, UserID: 8, Storage: domain.StorageRef{BucketName: "b", ObjectKey: "k"}}, nil
+			},
+		}, &storageFake{
+			getObjectReader: func(ctx context.Context, bucket, objectKey string) (io.ReadCloser, error) {
+				return nopCloser{strings.NewReader("abc")}, nil
+			},
+		}, auditor, 0)
+
+		f, rc, err := uc.OpenFile(ctx, 7, 10)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		defer rc.Close()
+
+		if f.UserID != 8 {
+			t.Fatalf("unexpected file: %+v", f)
+		}
+		if len(auditor.events) != 1 || auditor.events[0].UserID != 7 || auditor.events[0].OwnerID != 8 {
+			t.Fatalf("unexpected audit events: %+v", auditor.events)
+		}
+	})
+
+	t.Run("missing -> ErrFileNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			getByID: func(ctx context.Context, id int64) (*domain.File, error) {
+				return nil, domain.ErrFileNotFound
+			},
+		}, &storageFake{}, nil, 0)
+
+		if _, _, err := uc.OpenFile(ctx, 7, 10); !errors.Is(err, domain.ErrFileNotFound) {
+			t.Fatalf("expected ErrFileNotFound, got: %v", err)
+		}
+	})
+}
+
+func TestFileObj_RemoveObjects(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ops := []*domain.Operation{
+		{ID: 1, Type: domain.OperationDelete, FileID: 10, Storage: domain.StorageRef{BucketName: "b", ObjectKey: "7/a"}},
+		{ID: 2, Type: domain.OperationDelete, FileID: 11, Storage: domain.StorageRef{BucketName: "b", ObjectKey: "7/b"}},
+	}
+
+	var completed []int64
+	uc := New(&repoFake{
+		completeOperation: func(ctx context.Context, op *domain.Operation) error {
+			completed = append(completed, op.ID)
+			return nil
+		},
+	}, &storageFake{
+		deleteObject: func(ctx context.Context, bucket, key string) error {
+			if key == "7/b" {
+				return errors.New("storage down")
+			}
+			return nil
+		},
+	}, nil, 0)
+
+	uc.RemoveObjects(ctx, ops)
+
+	// the failed object stays queued for the outbox
+	if len(completed) != 1 || completed[0] != 1 {
+		t.Fatalf("expected only operation 1 completed, got %v", completed)
+	}
+}
@@ -0,0 +1,312 @@
+package folder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	domain "server/internal/app/domain/folder"
+	"server/internal/app/domain/org"
+)
+
+type Repository interface {
+	GetByUserID(ctx context.Context, userID int64) ([]*domain.Folder, error)
+	// GetByID returns ErrFolderNotFound for unknown folders
+	GetByID(ctx context.Context, folderID int64) (*domain.Folder, error)
+	// Create and Update return ErrFolderExists when a sibling has the same name
+	Create(ctx context.Context, f *domain.Folder) (int64, error)
+	Update(ctx context.Context, f *domain.Folder) error
+	Delete(ctx context.Context, folderID int64) error
+
+	// ListItems returns objects of all types placed in the folder, nil folderID is the root
+	ListItems(ctx context.Context, userID int64, folderID *int64) ([]domain.Item, error)
+	// ItemOwner returns the user and organization of the object or ErrItemNotFound
+	ItemOwner(ctx context.Context, itemType string, itemID int64) (domain.Owner, error)
+	MoveItem(ctx context.Context, itemType string, itemID int64, folderID *int64) error
+}
+
+// OrgChecker checks the role of a member against an action, non-members get org.ErrOrgNotFound
+type OrgChecker interface {
+	Authorize(ctx context.Context, userID, orgID int64, action string) error
+}
+
+type Folders struct {
+	repo Repository
+	orgs OrgChecker
+}
+
+// New creates the use case, without orgs organization items can not be filed
+func New(repo Repository, orgs OrgChecker) *Folders {
+	return &Folders{repo: repo, orgs: orgs}
+}
+
+// ListFolders returns all folders of the user, the tree is built by the client from ParentID
+func (f *Folders) ListFolders(ctx context.Context, userID int64) ([]*domain.Folder, error) {
+	if userID <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	list, err := f.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list folders: %w", err)
+	}
+
+	if len(list) == 0 {
+		return nil, domain.ErrEmptyFolders
+	}
+
+	return list, nil
+}
+
+// GetContents returns subfolders and objects of a folder, nil folderID is the root
+func (f *Folders) GetContents(ctx context.Context, userID int64, folderID *int64) (*domain.Contents, error) {
+	if userID <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	out := &domain.Contents{}
+	if folderID != nil {
+		folder, err := f.owned(ctx, userID, *folderID)
+		if err != nil {
+			return nil, err
+		}
+		out.Folder = folder
+	}
+
+	all, err := f.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list folders: %w", err)
+	}
+	for _, child := range all {
+		if sameParent(child.ParentID, folderID) {
+			out.Folders = append(out.Folders, child)
+		}
+	}
+
+	out.Items, err = f.repo.ListItems(ctx, userID, folderID)
+	if err != nil {
+		return nil, fmt.Errorf("list folder items: %w", err)
+	}
+
+	return out, nil
+}
+
+func (f *Folders) CreateFolder(ctx context.Context, userID int64, parentID *int64, name string) (*domain.Folder, error) {
+	if userID <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, domain.ErrEmptyName
+	}
+
+	if parentID != nil {
+		if _, err := f.owned(ctx, userID, *parentID); err != nil {
+			return nil, err
+		}
+	}
+
+	folder := &domain.Folder{UserID: userID, ParentID: parentID, Name: name}
+
+	id, err := f.repo.Create(ctx, folder)
+	if err != nil {
+		if errors.Is(err, domain.ErrFolderExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("create folder: %w", err)
+	}
+	folder.ID = id
+
+	return folder, nil
+}
+
+func (f *Folders) RenameFolder(ctx context.Context, userID, folderID int64, name string) (*domain.Folder, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, domain.ErrEmptyName
+	}
+
+	folder, err := f.owned(ctx, userID, folderID)
+	if err != nil {
+		return nil, err
+	}
+	folder.Name = name
+
+	if err := f.update(ctx, folder); err != nil {
+		return nil, err
+	}
+
+	return folder, nil
+}
+
+// MoveFolder puts a folder under another one or into the root when parentID is nil,
+// a folder can not become a descendant of itself
+func (f *Folders) MoveFolder(ctx context.Context, userID, folderID int64, parentID *int64) (*domain.Folder, error) {
+	folder, err := f.owned(ctx, userID, folderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID != nil {
+		if _, err := f.owned(ctx, userID, *parentID); err != nil {
+			return nil, err
+		}
+
+		all, err := f.repo.GetByUserID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("list folders: %w", err)
+		}
+		if isDescendant(all, *parentID, folderID) {
+			return nil, domain.ErrFolderCycle
+		}
+	}
+	folder.ParentID = parentID
+
+	if err := f.update(ctx, folder); err != nil {
+		return nil, err
+	}
+
+	return folder, nil
+}
+
+// DeleteFolder removes the folder with its subfolders, objects inside are moved to the root
+func (f *Folders) DeleteFolder(ctx context.Context, userID, folderID int64) error {
+	if _, err := f.owned(ctx, userID, folderID); err != nil {
+		return err
+	}
+
+	if err := f.repo.Delete(ctx, folderID); err != nil {
+		return fmt.Errorf("delete folder id=%d: %w", folderID, err)
+	}
+
+	return nil
+}
+
+// MoveItem places an object into a folder or into the root when folderID is nil
+func (f *Folders) MoveItem(ctx context.Context, userID int64, item domain.Item, folderID *int64) error {
+	if userID <= 0 {
+		return domain.ErrInvalidUserID
+	}
+
+	if !domain.ValidItemType(item.Type) {
+		return domain.ErrInvalidItem
+	}
+
+	if item.ID <= 0 {
+		return domain.ErrInvalidItemID
+	}
+
+	owner, err := f.repo.ItemOwner(ctx, item.Type, item.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrItemNotFound) {
+			return err
+		}
+		return fmt.Errorf("get %s id=%d: %w", item.Type, item.ID, err)
+	}
+
+	if err := f.authorize(ctx, userID, owner); err != nil {
+		return err
+	}
+
+	if folderID != nil {
+		if _, err := f.owned(ctx, userID, *folderID); err != nil {
+			return err
+		}
+	}
+
+	if err := f.repo.MoveItem(ctx, item.Type, item.ID, folderID); err != nil {
+		return fmt.Errorf("move %s id=%d: %w", item.Type, item.ID, err)
+	}
+
+	return nil
+}
+
+// help func
+
+// owned returns a folder of the user, folders of other users are reported as not found
+func (f *Folders) owned(ctx context.Context, userID, folderID int64) (*domain.Folder, error) {
+	if userID <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if folderID <= 0 {
+		return nil, domain.ErrInvalidFolderID
+	}
+
+	folder, err := f.repo.GetByID(ctx, folderID)
+	if err != nil {
+		if errors.Is(err, domain.ErrFolderNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get folder id=%d: %w", folderID, err)
+	}
+
+	if folder.UserID != userID {
+		return nil, domain.ErrFolderNotFound
+	}
+
+	return folder, nil
+}
+
+// authorize lets owners file their objects and members allowed to update file organization objects,
+// objects of other users and organizations are not revealed
+func (f *Folders) authorize(ctx context.Context, userID int64, owner domain.Owner) error {
+	if owner.OrgID == 0 {
+		if owner.UserID != userID {
+			return domain.ErrItemNotFound
+		}
+		return nil
+	}
+
+	if f.orgs == nil {
+		return domain.ErrItemNotFound
+	}
+
+	err := f.orgs.Authorize(ctx, userID, owner.OrgID, org.ActionUpdate)
+	if errors.Is(err, org.ErrOrgNotFound) {
+		return domain.ErrItemNotFound
+	}
+	return err
+}
+
+func (f *Folders) update(ctx context.Context, folder *domain.Folder) error {
+	if err := f.repo.Update(ctx, folder); err != nil {
+		if errors.Is(err, domain.ErrFolderExists) {
+			return err
+		}
+		return fmt.Errorf("update folder id=%d: %w", folder.ID, err)
+	}
+
+	return nil
+}
+
+// isDescendant reports whether folder id is ancestor itself or lies below it
+func isDescendant(all []*domain.Folder, id, ancestor int64) bool {
+	parents := make(map[int64]*int64, len(all))
+	for _, f := range all {
+		parents[f.ID] = f.ParentID
+	}
+
+	// the walk is bounded by the number of folders in case the stored tree is broken
+	for i := 0; i <= len(all); i++ {
+		if id == ancestor {
+			return true
+		}
+		parent, ok := parents[id]
+		if !ok || parent == nil {
+			return false
+		}
+		id = *parent
+	}
+
+	return true
+}
+
+func sameParent(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
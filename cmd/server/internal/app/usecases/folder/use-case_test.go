@@ -0,0 +1,338 @@
+package folder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "server/internal/app/domain/folder"
+	"server/internal/app/domain/org"
+)
+
+func ptr(v int64) *int64 { return &v }
+
+// tree is 1 -> 2 -> 3 of user 7 and folder 9 of user 8
+func tree() []*domain.Folder {
+	return []*domain.Folder{
+		{ID: 1, UserID: 7, Name: "work"},
+		{ID: 2, UserID: 7, ParentID: ptr(1), Name: "servers"},
+		{ID: 3, UserID: 7, ParentID: ptr(2), Name: "prod"},
+		{ID: 9, UserID: 8, Name: "foreign"},
+	}
+}
+
+type repoFake struct {
+	getByUserID func(ctx context.Context, userID int64) ([]*domain.Folder, error)
+	create      func(ctx context.Context, f *domain.Folder) (int64, error)
+	update      func(ctx context.Context, f *domain.Folder) error
+	delete      func(ctx context.Context, folderID int64) error
+	listItems   func(ctx context.Context, userID int64, folderID *int64) ([]domain.Item, error)
+	itemOwner   func(ctx context.Context, itemType string, itemID int64) (domain.Owner, error)
+	moveItem    func(ctx context.Context, itemType string, itemID int64, folderID *int64) error
+}
+
+func (r *repoFake) GetByUserID(ctx context.Context, userID int64) ([]*domain.Folder, error) {
+	if r.getByUserID != nil {
+		return r.getByUserID(ctx, userID)
+	}
+	var out []*domain.Folder
+	for _, f := range tree() {
+		if f.UserID == userID {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+func (r *repoFake) GetByID(ctx context.Context, folderID int64) (*domain.Folder, error) {
+	for _, f := range tree() {
+		if f.ID == folderID {
+			return f, nil
+		}
+	}
+	return nil, domain.ErrFolderNotFound
+}
+func (r *repoFake) Create(ctx context.Context, f *domain.Folder) (int64, error) {
+	if r.create != nil {
+		return r.create(ctx, f)
+	}
+	return 10, nil
+}
+func (r *repoFake) Update(ctx context.Context, f *domain.Folder) error {
+	if r.update != nil {
+		return r.update(ctx, f)
+	}
+	return nil
+}
+func (r *repoFake) Delete(ctx context.Context, folderID int64) error {
+	if r.delete != nil {
+		return r.delete(ctx, folderID)
+	}
+	return nil
+}
+func (r *repoFake) ListItems(ctx context.Context, userID int64, folderID *int64) ([]domain.Item, error) {
+	if r.listItems != nil {
+		return r.listItems(ctx, userID, folderID)
+	}
+	return nil, nil
+}
+func (r *repoFake) ItemOwner(ctx context.Context, itemType string, itemID int64) (domain.Owner, error) {
+	if r.itemOwner != nil {
+		return r.itemOwner(ctx, itemType, itemID)
+	}
+	return domain.Owner{UserID: 7}, nil
+}
+
+// orgsFake makes user 7 an editor of organization 3 and a viewer of organization 4
+type orgsFake struct{}
+
+func (orgsFake) Authorize(ctx context.Context, userID, orgID int64, action string) error {
+	roles := map[int64]string{3: org.RoleEditor, 4: org.RoleViewer}
+	role, ok := roles[orgID]
+	if userID != 7 || !ok {
+		return org.ErrOrgNotFound
+	}
+	if !org.Allowed(role, action) {
+		return org.ErrForbidden
+	}
+	return nil
+}
+func (r *repoFake) MoveItem(ctx context.Context, itemType string, itemID int64, folderID *int64) error {
+	if r.moveItem != nil {
+		return r.moveItem(ctx, itemType, itemID, folderID)
+	}
+	return nil
+}
+
+func TestFolders_CreateFolder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		repo     *repoFake
+		userID   int64
+		parentID *int64
+		title    string
+		wantErr  error
+	}{
+		{name: "invalid user -> ErrInvalidUserID", repo: &repoFake{}, userID: 0, title: "a", wantErr: domain.ErrInvalidUserID},
+		{name: "blank name -> ErrEmptyName", repo: &repoFake{}, userID: 7, title: "  ", wantErr: domain.ErrEmptyName},
+		{name: "unknown parent -> ErrFolderNotFound", repo: &repoFake{}, userID: 7, parentID: ptr(42), title: "a", wantErr: domain.ErrFolderNotFound},
+		{name: "parent of another user -> ErrFolderNotFound", repo: &repoFake{}, userID: 7, parentID: ptr(9), title: "a", wantErr: domain.ErrFolderNotFound},
+		{
+			name: "duplicate -> ErrFolderExists",
+			repo: &repoFake{create: func(ctx context.Context, f *domain.Folder) (int64, error) {
+				return 0, domain.ErrFolderExists
+			}},
+			userID:  7,
+			title:   "work",
+			wantErr: domain.ErrFolderExists,
+		},
+		{name: "ok in root", repo: &repoFake{}, userID: 7, title: "home"},
+		{name: "ok nested", repo: &repoFake{}, userID: 7, parentID: ptr(3), title: "db"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			folder, err := New(tc.repo, nil).CreateFolder(ctx, tc.userID, tc.parentID, tc.title)
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if folder.ID != 10 || folder.ParentID != tc.parentID {
+					t.Fatalf("unexpected folder: %+v", folder)
+				}
+				return
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFolders_MoveFolder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		folderID int64
+		parentID *int64
+		wantErr  error
+	}{
+		{name: "into itself -> ErrFolderCycle", folderID: 2, parentID: ptr(2), wantErr: domain.ErrFolderCycle},
+		{name: "into own subfolder -> ErrFolderCycle", folderID: 1, parentID: ptr(3), wantErr: domain.ErrFolderCycle},
+		{name: "folder of another user -> ErrFolderNotFound", folderID: 9, wantErr: domain.ErrFolderNotFound},
+		{name: "under folder of another user -> ErrFolderNotFound", folderID: 3, parentID: ptr(9), wantErr: domain.ErrFolderNotFound},
+		{name: "to root ok", folderID: 3},
+		{name: "up the tree ok", folderID: 3, parentID: ptr(1)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var saved *domain.Folder
+			repo := &repoFake{update: func(ctx context.Context, f *domain.Folder) error {
+				saved = f
+				return nil
+			}}
+
+			_, err := New(repo, nil).MoveFolder(ctx, 7, tc.folderID, tc.parentID)
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if saved == nil || saved.ParentID != tc.parentID {
+					t.Fatalf("folder not saved with new parent: %+v", saved)
+				}
+				return
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+			if saved != nil {
+				t.Fatalf("folder must not be saved on error")
+			}
+		})
+	}
+}
+
+func TestFolders_GetContents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("root -> top level folders and root items", func(t *testing.T) {
+		t.Parallel()
+
+		var gotFolder *int64
+		uc := New(&repoFake{listItems: func(ctx context.Context, userID int64, folderID *int64) ([]domain.Item, error) {
+			gotFolder = folderID
+			return []domain.Item{{Type: domain.ItemText, ID: 5, Title: "note"}}, nil
+		}}, nil)
+
+		c, err := uc.GetContents(ctx, 7, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if c.Folder != nil || len(c.Folders) != 1 || c.Folders[0].ID != 1 || len(c.Items) != 1 || gotFolder != nil {
+			t.Fatalf("unexpected contents: %+v", c)
+		}
+	})
+
+	t.Run("nested -> direct children only", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := New(&repoFake{}, nil).GetContents(ctx, 7, ptr(1))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if c.Folder.ID != 1 || len(c.Folders) != 1 || c.Folders[0].ID != 2 {
+			t.Fatalf("unexpected contents: %+v", c)
+		}
+	})
+
+	t.Run("folder of another user -> ErrFolderNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := New(&repoFake{}, nil).GetContents(ctx, 7, ptr(9)); !errors.Is(err, domain.ErrFolderNotFound) {
+			t.Fatalf("expected ErrFolderNotFound, got: %v", err)
+		}
+	})
+}
+
+func TestFolders_MoveItem(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	key := domain.Item{Type: domain.ItemSSHKey, ID: 4}
+	shared := domain.Item{Type: domain.ItemAccount, ID: 6}
+
+	tests := []struct {
+		name     string
+		repo     *repoFake
+		item     domain.Item
+		folderID *int64
+		wantErr  error
+	}{
+		{name: "unknown type -> ErrInvalidItem", repo: &repoFake{}, item: domain.Item{Type: "note", ID: 1}, wantErr: domain.ErrInvalidItem},
+		{name: "invalid id -> ErrInvalidItemID", repo: &repoFake{}, item: domain.Item{Type: domain.ItemCert}, wantErr: domain.ErrInvalidItemID},
+		{
+			name: "item of another user -> ErrItemNotFound",
+			repo: &repoFake{itemOwner: func(ctx context.Context, itemType string, itemID int64) (domain.Owner, error) {
+				return domain.Owner{UserID: 8}, nil
+			}},
+			item:    key,
+			wantErr: domain.ErrItemNotFound,
+		},
+		{
+			name: "own item of a left organization -> ErrItemNotFound",
+			repo: &repoFake{itemOwner: func(ctx context.Context, itemType string, itemID int64) (domain.Owner, error) {
+				return domain.Owner{UserID: 7, OrgID: 5}, nil
+			}},
+			item:    shared,
+			wantErr: domain.ErrItemNotFound,
+		},
+		{
+			name: "org item as viewer -> org.ErrForbidden",
+			repo: &repoFake{itemOwner: func(ctx context.Context, itemType string, itemID int64) (domain.Owner, error) {
+				return domain.Owner{UserID: 8, OrgID: 4}, nil
+			}},
+			item:    shared,
+			wantErr: org.ErrForbidden,
+		},
+		{
+			name: "org item of another member as editor ok",
+			repo: &repoFake{itemOwner: func(ctx context.Context, itemType string, itemID int64) (domain.Owner, error) {
+				return domain.Owner{UserID: 8, OrgID: 3}, nil
+			}},
+			item:     shared,
+			folderID: ptr(3),
+		},
+		{name: "folder of another user -> ErrFolderNotFound", repo: &repoFake{}, item: key, folderID: ptr(9), wantErr: domain.ErrFolderNotFound},
+		{name: "into folder ok", repo: &repoFake{}, item: key, folderID: ptr(3)},
+		{name: "to root ok", repo: &repoFake{}, item: key},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := New(tc.repo, orgsFake{}).MoveItem(ctx, 7, tc.item, tc.folderID)
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFolders_DeleteFolder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var deleted int64
+	uc := New(&repoFake{delete: func(ctx context.Context, folderID int64) error {
+		deleted = folderID
+		return nil
+	}}, nil)
+
+	if err := uc.DeleteFolder(ctx, 7, 9); !errors.Is(err, domain.ErrFolderNotFound) {
+		t.Fatalf("expected ErrFolderNotFound, got: %v", err)
+	}
+	if err := uc.DeleteFolder(ctx, 7, 2); err != nil || deleted != 2 {
+		t.Fatalf("unexpected result: %v, deleted=%d", err, deleted)
+	}
+}
@@ -0,0 +1,99 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "server/internal/app/domain/notification"
+)
+
+type Repository interface {
+	// CreateReminders adds a notification for every object expiring before until, existing reminders are kept
+	CreateReminders(ctx context.Context, until time.Time) (int, error)
+	ListByUserID(ctx context.Context, userId int64, unreadOnly bool) ([]*domain.Notification, error)
+	CountUnread(ctx context.Context, userId int64) (int, error)
+	MarkRead(ctx context.Context, userId, notificationId int64) error
+	MarkAllRead(ctx context.Context, userId int64) (int, error)
+}
+
+type Notifications struct {
+	repo Repository
+}
+
+func New(repo Repository) *Notifications {
+	return &Notifications{repo: repo}
+}
+
+// GenerateReminders is run by the reminder job, it is safe to call repeatedly
+func (n *Notifications) GenerateReminders(ctx context.Context, opts domain.ReminderOptions) (int, error) {
+	if opts.LeadTime <= 0 {
+		return 0, domain.ErrInvalidLeadTime
+	}
+
+	created, err := n.repo.CreateReminders(ctx, time.Now().Add(opts.LeadTime))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", domain.ErrFailedGenerateReminders, err)
+	}
+
+	return created, nil
+}
+
+func (n *Notifications) GetNotifications(ctx context.Context, userId int64, unreadOnly bool) ([]*domain.Notification, error) {
+	if userId <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	list, err := n.repo.ListByUserID(ctx, userId, unreadOnly)
+	if err != nil {
+		return nil, domain.ErrFailedGetNotifications
+	}
+
+	return list, nil
+}
+
+func (n *Notifications) CountUnread(ctx context.Context, userId int64) (int, error) {
+	if userId <= 0 {
+		return 0, domain.ErrInvalidUserID
+	}
+
+	count, err := n.repo.CountUnread(ctx, userId)
+	if err != nil {
+		return 0, domain.ErrFailedGetNotifications
+	}
+
+	return count, nil
+}
+
+func (n *Notifications) MarkRead(ctx context.Context, userId, notificationId int64) error {
+	if userId <= 0 {
+		return domain.ErrInvalidUserID
+	}
+
+	if notificationId <= 0 {
+		return domain.ErrInvalidNotificationID
+	}
+
+	if err := n.repo.MarkRead(ctx, userId, notificationId); err != nil {
+		if errors.Is(err, domain.ErrNotificationNotFound) {
+			return domain.ErrNotificationNotFound
+		}
+		return domain.ErrFailedMarkRead
+	}
+
+	return nil
+}
+
+func (n *Notifications) MarkAllRead(ctx context.Context, userId int64) (int, error) {
+	if userId <= 0 {
+		return 0, domain.ErrInvalidUserID
+	}
+
+	count, err := n.repo.MarkAllRead(ctx, userId)
+	if err != nil {
+		return 0, domain.ErrFailedMarkRead
+	}
+
+	return count, nil
+}
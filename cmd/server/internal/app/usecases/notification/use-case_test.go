@@ -0,0 +1,295 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "server/internal/app/domain/notification"
+)
+
+type repoFake struct {
+	createReminders func(ctx context.Context, until time.Time) (int, error)
+	listByUserID    func(ctx context.Context, userId int64, unreadOnly bool) ([]*domain.Notification, error)
+	countUnread     func(ctx context.Context, userId int64) (int, error)
+	markRead        func(ctx context.Context, userId, notificationId int64) error
+	markAllRead     func(ctx context.Context, userId int64) (int, error)
+}
+
+func (r *repoFake) CreateReminders(ctx context.Context, until time.Time) (int, error) {
+	if r.createReminders != nil {
+		return r.createReminders(ctx, until)
+	}
+	return 0, nil
+}
+func (r *repoFake) ListByUserID(ctx context.Context, userId int64, unreadOnly bool) ([]*domain.Notification, error) {
+	if r.listByUserID != nil {
+		return r.listByUserID(ctx, userId, unreadOnly)
+	}
+	return nil, nil
+}
+func (r *repoFake) CountUnread(ctx context.Context, userId int64) (int, error) {
+	if r.countUnread != nil {
+		return r.countUnread(ctx, userId)
+	}
+	return 0, nil
+}
+func (r *repoFake) MarkRead(ctx context.Context, userId, notificationId int64) error {
+	if r.markRead != nil {
+		return r.markRead(ctx, userId, notificationId)
+	}
+	return nil
+}
+func (r *repoFake) MarkAllRead(ctx context.Context, userId int64) (int, error) {
+	if r.markAllRead != nil {
+		return r.markAllRead(ctx, userId)
+	}
+	return 0, nil
+}
+
+func TestNotifications_GenerateReminders(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("zero lead time -> ErrInvalidLeadTime", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{})
+		_, err := uc.GenerateReminders(ctx, domain.ReminderOptions{})
+		if !errors.Is(err, domain.ErrInvalidLeadTime) {
+			t.Fatalf("expected ErrInvalidLeadTime, got: %v", err)
+		}
+	})
+
+	t.Run("repo error -> ErrFailedGenerateReminders", func(t *testing.T) {
+		t.Parallel()
+
+		dbErr := errors.New("db down")
+		uc := New(&repoFake{
+			createReminders: func(ctx context.Context, until time.Time) (int, error) {
+				return 0, dbErr
+			},
+		})
+
+		_, err := uc.GenerateReminders(ctx, domain.ReminderOptions{LeadTime: time.Hour})
+		if !errors.Is(err, domain.ErrFailedGenerateReminders) || !errors.Is(err, dbErr) {
+			t.Fatalf("expected ErrFailedGenerateReminders wrapping db error, got: %v", err)
+		}
+	})
+
+	t.Run("ok -> reminders until now + lead time", func(t *testing.T) {
+		t.Parallel()
+
+		lead := 7 * 24 * time.Hour
+		var gotUntil time.Time
+
+		uc := New(&repoFake{
+			createReminders: func(ctx context.Context, until time.Time) (int, error) {
+				gotUntil = until
+				return 3, nil
+			},
+		})
+
+		before := time.Now()
+		created, err := uc.GenerateReminders(ctx, domain.ReminderOptions{LeadTime: lead})
+		if err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+		if created != 3 {
+			t.Fatalf("expected 3, got %d", created)
+		}
+		if gotUntil.Before(before.Add(lead)) || gotUntil.After(time.Now().Add(lead)) {
+			t.Fatalf("unexpected until: %v", gotUntil)
+		}
+	})
+}
+
+func TestNotifications_GetNotifications(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("invalid userId -> ErrInvalidUserID", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{})
+		_, err := uc.GetNotifications(ctx, 0, false)
+		if !errors.Is(err, domain.ErrInvalidUserID) {
+			t.Fatalf("expected ErrInvalidUserID, got: %v", err)
+		}
+	})
+
+	t.Run("repo error -> ErrFailedGetNotifications", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			listByUserID: func(ctx context.Context, userId int64, unreadOnly bool) ([]*domain.Notification, error) {
+				return nil, errors.New("db down")
+			},
+		})
+
+		_, err := uc.GetNotifications(ctx, 1, false)
+		if !errors.Is(err, domain.ErrFailedGetNotifications) {
+			t.Fatalf("expected ErrFailedGetNotifications, got: %v", err)
+		}
+	})
+
+	t.Run("empty inbox -> empty list, no error", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{})
+		list, err := uc.GetNotifications(ctx, 1, true)
+		if err != nil || len(list) != 0 {
+			t.Fatalf("expected empty list, got %v, %v", list, err)
+		}
+	})
+
+	t.Run("ok -> passes unread filter", func(t *testing.T) {
+		t.Parallel()
+
+		var gotUnread bool
+		uc := New(&repoFake{
+			listByUserID: func(ctx context.Context, userId int64, unreadOnly bool) ([]*domain.Notification, error) {
+				gotUnread = unreadOnly
+				return []*domain.Notification{{ID: 1, UserID: userId}}, nil
+			},
+		})
+
+		list, err := uc.GetNotifications(ctx, 7, true)
+		if err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+		if len(list) != 1 || list[0].UserID != 7 || !gotUnread {
+			t.Fatalf("unexpected result: %+v unread=%v", list, gotUnread)
+		}
+	})
+}
+
+func TestNotifications_CountUnread(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("invalid userId -> ErrInvalidUserID", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{})
+		if _, err := uc.CountUnread(ctx, -1); !errors.Is(err, domain.ErrInvalidUserID) {
+			t.Fatalf("expected ErrInvalidUserID, got: %v", err)
+		}
+	})
+
+	t.Run("ok -> count", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			countUnread: func(ctx context.Context, userId int64) (int, error) {
+				return 4, nil
+			},
+		})
+
+		count, err := uc.CountUnread(ctx, 1)
+		if err != nil || count != 4 {
+			t.Fatalf("expected 4, got %d, %v", count, err)
+		}
+	})
+}
+
+func TestNotifications_MarkRead(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("invalid notificationId -> ErrInvalidNotificationID", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{})
+		if err := uc.MarkRead(ctx, 1, 0); !errors.Is(err, domain.ErrInvalidNotificationID) {
+			t.Fatalf("expected ErrInvalidNotificationID, got: %v", err)
+		}
+	})
+
+	t.Run("not found -> ErrNotificationNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			markRead: func(ctx context.Context, userId, notificationId int64) error {
+				return domain.ErrNotificationNotFound
+			},
+		})
+
+		if err := uc.MarkRead(ctx, 1, 5); !errors.Is(err, domain.ErrNotificationNotFound) {
+			t.Fatalf("expected ErrNotificationNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("repo error -> ErrFailedMarkRead", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			markRead: func(ctx context.Context, userId, notificationId int64) error {
+				return errors.New("db down")
+			},
+		})
+
+		if err := uc.MarkRead(ctx, 1, 5); !errors.Is(err, domain.ErrFailedMarkRead) {
+			t.Fatalf("expected ErrFailedMarkRead, got: %v", err)
+		}
+	})
+
+	t.Run("ok -> nil", func(t *testing.T) {
+		t.Parallel()
+
+		var gotUser, gotID int64
+		uc := New(&repoFake{
+			markRead: func(ctx context.Context, userId, notificationId int64) error {
+				gotUser, gotID = userId, notificationId
+				return nil
+			},
+		})
+
+		if err := uc.MarkRead(ctx, 1, 5); err != nil {
+			t.Fatalf("expected nil, got: %v", err)
+		}
+		if gotUser != 1 || gotID != 5 {
+			t.Fatalf("unexpected args: user=%d id=%d", gotUser, gotID)
+		}
+	})
+}
+
+func TestNotifications_MarkAllRead(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("repo error -> ErrFailedMarkRead", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			markAllRead: func(ctx context.Context, userId int64) (int, error) {
+				return 0, errors.New("db down")
+			},
+		})
+
+		if _, err := uc.MarkAllRead(ctx, 1); !errors.Is(err, domain.ErrFailedMarkRead) {
+			t.Fatalf("expected ErrFailedMarkRead, got: %v", err)
+		}
+	})
+
+	t.Run("ok -> count", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			markAllRead: func(ctx context.Context, userId int64) (int, error) {
+				return 2, nil
+			},
+		})
+
+		count, err := uc.MarkAllRead(ctx, 1)
+		if err != nil || count != 2 {
+			t.Fatalf("expected 2, got %d, %v", count, err)
+		}
+	})
+}
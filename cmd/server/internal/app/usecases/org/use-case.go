@@ -0,0 +1,287 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	domain "server/internal/app/domain/org"
+)
+
+type Repository interface {
+	// Create stores the organization with the user as its first owner
+	Create(ctx context.Context, name string, ownerID int64) (*domain.Org, error)
+	ListByUser(ctx context.Context, userID int64) ([]*domain.Org, error)
+	Delete(ctx context.Context, orgID int64) error
+
+	// Role returns the role of the user in the organization or ErrNotMember
+	Role(ctx context.Context, orgID, userID int64) (string, error)
+	// UserIDByName returns the id of a registered user or ErrUserNotFound
+	UserIDByName(ctx context.Context, username string) (int64, error)
+
+	Members(ctx context.Context, orgID int64) ([]*domain.Member, error)
+	// AddMember returns ErrAlreadyMember when the user is in the organization
+	AddMember(ctx context.Context, orgID, userID int64, role string) error
+	SetRole(ctx context.Context, orgID, userID int64, role string) error
+	RemoveMember(ctx context.Context, orgID, userID int64) error
+	CountOwners(ctx context.Context, orgID int64) (int, error)
+
+	ListItems(ctx context.Context, orgID int64) ([]*domain.Item, error)
+}
+
+type Orgs struct {
+	repo Repository
+}
+
+func New(repo Repository) *Orgs {
+	return &Orgs{repo: repo}
+}
+
+// Authorize checks the role of the user in the organization against the action.
+// Item usecases call it for every operation on an org-owned item.
+func (o *Orgs) Authorize(ctx context.Context, userID, orgID int64, action string) error {
+	_, err := o.authorize(ctx, userID, orgID, action)
+	return err
+}
+
+func (o *Orgs) CreateOrg(ctx context.Context, userID int64, name string) (*domain.Org, error) {
+	if userID <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, domain.ErrEmptyName
+	}
+
+	org, err := o.repo.Create(ctx, name, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrFailedCreation, err)
+	}
+
+	return org, nil
+}
+
+// ListOrgs returns the organizations of the user with the role in each
+func (o *Orgs) ListOrgs(ctx context.Context, userID int64) ([]*domain.Org, error) {
+	if userID <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	list, err := o.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list organizations of user id=%d: %w", userID, err)
+	}
+
+	if len(list) == 0 {
+		return nil, domain.ErrEmptyOrgs
+	}
+
+	return list, nil
+}
+
+func (o *Orgs) DeleteOrg(ctx context.Context, userID, orgID int64) error {
+	if _, err := o.authorize(ctx, userID, orgID, domain.ActionDeleteOrg); err != nil {
+		return err
+	}
+
+	if err := o.repo.Delete(ctx, orgID); err != nil {
+		return fmt.Errorf("delete organization id=%d: %w", orgID, err)
+	}
+
+	return nil
+}
+
+// Members is visible to every member of the organization
+func (o *Orgs) Members(ctx context.Context, userID, orgID int64) ([]*domain.Member, error) {
+	if _, err := o.authorize(ctx, userID, orgID, domain.ActionRead); err != nil {
+		return nil, err
+	}
+
+	list, err := o.repo.Members(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("list members of organization id=%d: %w", orgID, err)
+	}
+
+	return list, nil
+}
+
+// Items lists the accounts, cards and texts owned by the organization
+func (o *Orgs) Items(ctx context.Context, userID, orgID int64) ([]*domain.Item, error) {
+	if _, err := o.authorize(ctx, userID, orgID, domain.ActionRead); err != nil {
+		return nil, err
+	}
+
+	list, err := o.repo.ListItems(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("list items of organization id=%d: %w", orgID, err)
+	}
+
+	if len(list) == 0 {
+		return nil, domain.ErrEmptyOrgItems
+	}
+
+	return list, nil
+}
+
+func (o *Orgs) AddMember(ctx context.Context, userID, orgID int64, username, role string) error {
+	if !domain.ValidRole(role) {
+		return domain.ErrInvalidRole
+	}
+
+	actor, err := o.authorize(ctx, userID, orgID, domain.ActionManageMembers)
+	if err != nil {
+		return err
+	}
+
+	if !domain.CanAssign(actor, role) {
+		return domain.ErrForbidden
+	}
+
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return domain.ErrInvalidUsername
+	}
+
+	memberID, err := o.repo.UserIDByName(ctx, username)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return err
+		}
+		return fmt.Errorf("get user %q: %w", username, err)
+	}
+
+	if err := o.repo.AddMember(ctx, orgID, memberID, role); err != nil {
+		if errors.Is(err, domain.ErrAlreadyMember) {
+			return err
+		}
+		return fmt.Errorf("add user id=%d to organization id=%d: %w", memberID, orgID, err)
+	}
+
+	return nil
+}
+
+// ChangeRole is allowed when the actor outranks both the current and the new role
+func (o *Orgs) ChangeRole(ctx context.Context, userID, orgID, memberID int64, role string) error {
+	if !domain.ValidRole(role) {
+		return domain.ErrInvalidRole
+	}
+
+	actor, current, err := o.manage(ctx, userID, orgID, memberID)
+	if err != nil {
+		return err
+	}
+
+	if !domain.CanAssign(actor, role) {
+		return domain.ErrForbidden
+	}
+
+	if current == domain.RoleOwner && role != domain.RoleOwner {
+		if err := o.keepOwner(ctx, orgID); err != nil {
+			return err
+		}
+	}
+
+	if err := o.repo.SetRole(ctx, orgID, memberID, role); err != nil {
+		return fmt.Errorf("set role of user id=%d in organization id=%d: %w", memberID, orgID, err)
+	}
+
+	return nil
+}
+
+// RemoveMember removes another member or lets the user leave the organization
+func (o *Orgs) RemoveMember(ctx context.Context, userID, orgID, memberID int64) error {
+	var current string
+
+	if userID == memberID {
+		role, err := o.authorize(ctx, userID, orgID, domain.ActionRead)
+		if err != nil {
+			return err
+		}
+		current = role
+	} else {
+		_, role, err := o.manage(ctx, userID, orgID, memberID)
+		if err != nil {
+			return err
+		}
+		current = role
+	}
+
+	if current == domain.RoleOwner {
+		if err := o.keepOwner(ctx, orgID); err != nil {
+			return err
+		}
+	}
+
+	if err := o.repo.RemoveMember(ctx, orgID, memberID); err != nil {
+		return fmt.Errorf("remove user id=%d from organization id=%d: %w", memberID, orgID, err)
+	}
+
+	return nil
+}
+
+// authorize returns the role of the user when it allows the action.
+// Non-members get ErrOrgNotFound so the organization is not disclosed.
+func (o *Orgs) authorize(ctx context.Context, userID, orgID int64, action string) (string, error) {
+	if userID <= 0 {
+		return "", domain.ErrInvalidUserID
+	}
+	if orgID <= 0 {
+		return "", domain.ErrInvalidOrgID
+	}
+
+	role, err := o.repo.Role(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotMember) {
+			return "", domain.ErrOrgNotFound
+		}
+		return "", fmt.Errorf("get role of user id=%d in organization id=%d: %w", userID, orgID, err)
+	}
+
+	if !domain.Allowed(role, action) {
+		return "", domain.ErrForbidden
+	}
+
+	return role, nil
+}
+
+// manage checks that the user may change the member and returns both roles
+func (o *Orgs) manage(ctx context.Context, userID, orgID, memberID int64) (string, string, error) {
+	if memberID <= 0 {
+		return "", "", domain.ErrInvalidUserID
+	}
+
+	actor, err := o.authorize(ctx, userID, orgID, domain.ActionManageMembers)
+	if err != nil {
+		return "", "", err
+	}
+
+	current, err := o.repo.Role(ctx, orgID, memberID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotMember) {
+			return "", "", err
+		}
+		return "", "", fmt.Errorf("get role of user id=%d in organization id=%d: %w", memberID, orgID, err)
+	}
+
+	if !domain.CanAssign(actor, current) {
+		return "", "", domain.ErrForbidden
+	}
+
+	return actor, current, nil
+}
+
+// keepOwner fails when the only owner is about to be demoted or removed
+func (o *Orgs) keepOwner(ctx context.Context, orgID int64) error {
+	n, err := o.repo.CountOwners(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("count owners of organization id=%d: %w", orgID, err)
+	}
+
+	if n <= 1 {
+		return domain.ErrLastOwner
+	}
+
+	return nil
+}
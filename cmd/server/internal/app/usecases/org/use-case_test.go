@@ -0,0 +1,275 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "server/internal/app/domain/org"
+)
+
+// repoFake keeps the members of a single organization, user ids by role:
+// 1 owner, 2 admin, 3 editor, 4 viewer
+type repoFake struct {
+	roles map[int64]string
+	users map[string]int64
+
+	added   map[int64]string
+	removed []int64
+	deleted bool
+}
+
+func newRepo() *repoFake {
+	return &repoFake{
+		roles: map[int64]string{1: domain.RoleOwner, 2: domain.RoleAdmin, 3: domain.RoleEditor, 4: domain.RoleViewer},
+		users: map[string]int64{"alice": 1, "bob": 2, "carol": 3, "dave": 4, "erin": 5},
+		added: map[int64]string{},
+	}
+}
+
+func (r *repoFake) Create(ctx context.Context, name string, ownerID int64) (*domain.Org, error) {
+	return &domain.Org{ID: 1, Name: name, Role: domain.RoleOwner}, nil
+}
+func (r *repoFake) ListByUser(ctx context.Context, userID int64) ([]*domain.Org, error) {
+	if role, ok := r.roles[userID]; ok {
+		return []*domain.Org{{ID: 1, Name: "team", Role: role}}, nil
+	}
+	return nil, nil
+}
+func (r *repoFake) Delete(ctx context.Context, orgID int64) error {
+	r.deleted = true
+	return nil
+}
+func (r *repoFake) Role(ctx context.Context, orgID, userID int64) (string, error) {
+	if role, ok := r.roles[userID]; ok && orgID == 1 {
+		return role, nil
+	}
+	return "", domain.ErrNotMember
+}
+func (r *repoFake) UserIDByName(ctx context.Context, username string) (int64, error) {
+	if id, ok := r.users[username]; ok {
+		return id, nil
+	}
+	return 0, domain.ErrUserNotFound
+}
+func (r *repoFake) Members(ctx context.Context, orgID int64) ([]*domain.Member, error) {
+	var list []*domain.Member
+	for id, role := range r.roles {
+		list = append(list, &domain.Member{OrgID: orgID, UserID: id, Role: role})
+	}
+	return list, nil
+}
+func (r *repoFake) AddMember(ctx context.Context, orgID, userID int64, role string) error {
+	if _, ok := r.roles[userID]; ok {
+		return domain.ErrAlreadyMember
+	}
+	r.added[userID] = role
+	return nil
+}
+func (r *repoFake) SetRole(ctx context.Context, orgID, userID int64, role string) error {
+	r.roles[userID] = role
+	return nil
+}
+func (r *repoFake) RemoveMember(ctx context.Context, orgID, userID int64) error {
+	r.removed = append(r.removed, userID)
+	return nil
+}
+func (r *repoFake) CountOwners(ctx context.Context, orgID int64) (int, error) {
+	n := 0
+	for _, role := range r.roles {
+		if role == domain.RoleOwner {
+			n++
+		}
+	}
+	return n, nil
+}
+func (r *repoFake) ListItems(ctx context.Context, orgID int64) ([]*domain.Item, error) {
+	return nil, nil
+}
+
+func TestOrgs_Authorize(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		userID  int64
+		orgID   int64
+		action  string
+		wantErr error
+	}{
+		{name: "viewer reads", userID: 4, orgID: 1, action: domain.ActionRead},
+		{name: "viewer updates -> ErrForbidden", userID: 4, orgID: 1, action: domain.ActionUpdate, wantErr: domain.ErrForbidden},
+		{name: "editor updates", userID: 3, orgID: 1, action: domain.ActionUpdate},
+		{name: "editor deletes -> ErrForbidden", userID: 3, orgID: 1, action: domain.ActionDelete, wantErr: domain.ErrForbidden},
+		{name: "admin deletes", userID: 2, orgID: 1, action: domain.ActionDelete},
+		{name: "admin deletes org -> ErrForbidden", userID: 2, orgID: 1, action: domain.ActionDeleteOrg, wantErr: domain.ErrForbidden},
+		{name: "owner deletes org", userID: 1, orgID: 1, action: domain.ActionDeleteOrg},
+		{name: "outsider -> ErrOrgNotFound", userID: 5, orgID: 1, action: domain.ActionRead, wantErr: domain.ErrOrgNotFound},
+		{name: "invalid org -> ErrInvalidOrgID", userID: 1, orgID: 0, action: domain.ActionRead, wantErr: domain.ErrInvalidOrgID},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := New(newRepo()).Authorize(ctx, tc.userID, tc.orgID, tc.action)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestOrgs_AddMember(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		userID   int64
+		username string
+		role     string
+		wantErr  error
+	}{
+		{name: "invalid role -> ErrInvalidRole", userID: 1, username: "erin", role: "god", wantErr: domain.ErrInvalidRole},
+		{name: "editor can not manage -> ErrForbidden", userID: 3, username: "erin", role: domain.RoleViewer, wantErr: domain.ErrForbidden},
+		{name: "admin can not add admins -> ErrForbidden", userID: 2, username: "erin", role: domain.RoleAdmin, wantErr: domain.ErrForbidden},
+		{name: "unknown user -> ErrUserNotFound", userID: 2, username: "nobody", role: domain.RoleViewer, wantErr: domain.ErrUserNotFound},
+		{name: "member again -> ErrAlreadyMember", userID: 2, username: "dave", role: domain.RoleViewer, wantErr: domain.ErrAlreadyMember},
+		{name: "admin adds editor", userID: 2, username: "erin", role: domain.RoleEditor},
+		{name: "owner adds owner", userID: 1, username: "erin", role: domain.RoleOwner},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := newRepo()
+			err := New(repo).AddMember(ctx, tc.userID, 1, tc.username, tc.role)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got: %v", tc.wantErr, err)
+			}
+			if tc.wantErr == nil && repo.added[5] != tc.role {
+				t.Fatalf("expected user to be added as %s, got: %v", tc.role, repo.added)
+			}
+		})
+	}
+}
+
+func TestOrgs_ChangeRole(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("admin promotes viewer to editor", func(t *testing.T) {
+		t.Parallel()
+
+		repo := newRepo()
+		if err := New(repo).ChangeRole(ctx, 2, 1, 4, domain.RoleEditor); err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+		if repo.roles[4] != domain.RoleEditor {
+			t.Fatalf("role not changed: %v", repo.roles)
+		}
+	})
+
+	t.Run("admin demotes owner -> ErrForbidden", func(t *testing.T) {
+		t.Parallel()
+
+		if err := New(newRepo()).ChangeRole(ctx, 2, 1, 1, domain.RoleViewer); !errors.Is(err, domain.ErrForbidden) {
+			t.Fatalf("expected ErrForbidden, got: %v", err)
+		}
+	})
+
+	t.Run("only owner demotes self -> ErrLastOwner", func(t *testing.T) {
+		t.Parallel()
+
+		if err := New(newRepo()).ChangeRole(ctx, 1, 1, 1, domain.RoleAdmin); !errors.Is(err, domain.ErrLastOwner) {
+			t.Fatalf("expected ErrLastOwner, got: %v", err)
+		}
+	})
+
+	t.Run("not a member -> ErrNotMember", func(t *testing.T) {
+		t.Parallel()
+
+		if err := New(newRepo()).ChangeRole(ctx, 1, 1, 5, domain.RoleViewer); !errors.Is(err, domain.ErrNotMember) {
+			t.Fatalf("expected ErrNotMember, got: %v", err)
+		}
+	})
+}
+
+func TestOrgs_RemoveMember(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("viewer leaves", func(t *testing.T) {
+		t.Parallel()
+
+		repo := newRepo()
+		if err := New(repo).RemoveMember(ctx, 4, 1, 4); err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+		if len(repo.removed) != 1 || repo.removed[0] != 4 {
+			t.Fatalf("unexpected removed: %v", repo.removed)
+		}
+	})
+
+	t.Run("editor removes viewer -> ErrForbidden", func(t *testing.T) {
+		t.Parallel()
+
+		if err := New(newRepo()).RemoveMember(ctx, 3, 1, 4); !errors.Is(err, domain.ErrForbidden) {
+			t.Fatalf("expected ErrForbidden, got: %v", err)
+		}
+	})
+
+	t.Run("last owner leaves -> ErrLastOwner", func(t *testing.T) {
+		t.Parallel()
+
+		if err := New(newRepo()).RemoveMember(ctx, 1, 1, 1); !errors.Is(err, domain.ErrLastOwner) {
+			t.Fatalf("expected ErrLastOwner, got: %v", err)
+		}
+	})
+}
+
+func TestOrgs_CreateAndList(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uc := New(newRepo())
+
+	if _, err := uc.CreateOrg(ctx, 1, "   "); !errors.Is(err, domain.ErrEmptyName) {
+		t.Fatalf("expected ErrEmptyName, got: %v", err)
+	}
+
+	org, err := uc.CreateOrg(ctx, 1, " team ")
+	if err != nil || org.Name != "team" || org.Role != domain.RoleOwner {
+		t.Fatalf("unexpected result: %+v, %v", org, err)
+	}
+
+	if _, err := uc.ListOrgs(ctx, 5); !errors.Is(err, domain.ErrEmptyOrgs) {
+		t.Fatalf("expected ErrEmptyOrgs, got: %v", err)
+	}
+
+	if _, err := uc.Items(ctx, 4, 1); !errors.Is(err, domain.ErrEmptyOrgItems) {
+		t.Fatalf("expected ErrEmptyOrgItems, got: %v", err)
+	}
+}
+
+func TestOrgs_DeleteOrg(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	repo := newRepo()
+	if err := New(repo).DeleteOrg(ctx, 2, 1); !errors.Is(err, domain.ErrForbidden) || repo.deleted {
+		t.Fatalf("expected ErrForbidden without delete, got: %v", err)
+	}
+
+	if err := New(repo).DeleteOrg(ctx, 1, 1); err != nil || !repo.deleted {
+		t.Fatalf("expected org to be deleted, got: %v", err)
+	}
+}
@@ -0,0 +1,209 @@
+package revocation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	domain "server/internal/app/domain/user"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// syncOverlap is how far back every sync reads again, an entry is created at the start of its
+// transaction and may commit after entries created later were read already
+const syncOverlap = time.Minute
+
+var errInvalidRevocation = errors.New("revocation needs a jti, a session or a cutoff and an expiry")
+
+type Repository interface {
+	Add(ctx context.Context, r *domain.Revocation) (int64, error)
+	// Since returns the entries created at since or later that are still needed, the oldest first
+	Since(ctx context.Context, since, now time.Time) ([]*domain.Revocation, error)
+	// DeleteExpired removes the entries whose tokens have expired and returns how many were removed
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// List keeps the revoked access tokens in memory so checks of requests don't hit the database,
+// entries written by other instances are picked up every syncEvery
+type List struct {
+	repo      Repository
+	syncEvery time.Duration
+	now       func() time.Time
+
+	// syncMu lets one request reload the list while the others wait for the result
+	syncMu    sync.Mutex
+	mu        sync.RWMutex
+	byJTI     map[string]time.Time
+	bySession map[int64]time.Time
+	byUser    map[int64]*domain.Revocation
+	// lastCreated is the newest CreatedAt read, it comes from the database clock
+	lastCreated time.Time
+	syncedAt    time.Time
+}
+
+func New(repo Repository, syncEvery time.Duration) *List {
+	return &List{
+		repo:      repo,
+		syncEvery: syncEvery,
+		now:       time.Now,
+		byJTI:     make(map[string]time.Time),
+		bySession: make(map[int64]time.Time),
+		byUser:    make(map[int64]*domain.Revocation),
+	}
+}
+
+// Revoke stores the entry and applies it on this instance at once
+func (l *List) Revoke(ctx context.Context, r *domain.Revocation) error {
+	if (r.JTI == "" && r.SessionID == 0 && r.IssuedBefore.IsZero()) || r.ExpiresAt.IsZero() {
+		return errInvalidRevocation
+	}
+
+	id, err := l.repo.Add(ctx, r)
+	if err != nil {
+		return fmt.Errorf("store revocation: %w", err)
+	}
+	r.ID = id
+
+	// lastCreated is left to the sync, entries of other instances created earlier may still be unread
+	l.mu.Lock()
+	l.merge(r)
+	l.mu.Unlock()
+
+	return nil
+}
+
+// Revoked tells if the access token is revoked. When the database can't be reached the cached
+// list is used, tokens revoked on this instance are still refused
+func (l *List) Revoked(ctx context.Context, jti string, userID, sessionID int64, issuedAt time.Time) bool {
+	l.sync(ctx)
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if jti != "" {
+		if _, ok := l.byJTI[jti]; ok {
+			return true
+		}
+	}
+	if sessionID != 0 {
+		if _, ok := l.bySession[sessionID]; ok {
+			return true
+		}
+	}
+
+	r, ok := l.byUser[userID]
+	return ok && r.Covers("", userID, 0, issuedAt)
+}
+
+// Prune drops the entries of expired tokens from memory and from the database, it is run by a background job
+func (l *List) Prune(ctx context.Context) (int64, error) {
+	now := l.now()
+
+	l.mu.Lock()
+	for jti, exp := range l.byJTI {
+		if !now.Before(exp) {
+			delete(l.byJTI, jti)
+		}
+	}
+	for sessionID, exp := range l.bySession {
+		if !now.Before(exp) {
+			delete(l.bySession, sessionID)
+		}
+	}
+	for userID, r := range l.byUser {
+		if r.Expired(now) {
+			delete(l.byUser, userID)
+		}
+	}
+	l.mu.Unlock()
+
+	n, err := l.repo.DeleteExpired(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("prune revocations: %w", err)
+	}
+	return n, nil
+}
+
+// help func
+
+// sync reads the entries added since the last sync once syncEvery has passed
+func (l *List) sync(ctx context.Context) {
+	if !l.stale() {
+		return
+	}
+
+	l.syncMu.Lock()
+	defer l.syncMu.Unlock()
+
+	// another request may have synced while this one waited
+	if !l.stale() {
+		return
+	}
+
+	l.mu.RLock()
+	since := l.lastCreated
+	l.mu.RUnlock()
+
+	// entries read again are merged again, merge doesn't mind duplicates
+	if !since.IsZero() {
+		since = since.Add(-syncOverlap)
+	}
+
+	now := l.now()
+	list, err := l.repo.Since(ctx, since, now)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// a failed sync is retried after syncEvery too, so a database outage doesn't add a query to every request
+	l.syncedAt = now
+	if err != nil {
+		logger.Log.Error("revocation list sync failed", zap.Error(err))
+		return
+	}
+
+	for _, r := range list {
+		l.merge(r)
+		if r.CreatedAt.After(l.lastCreated) {
+			l.lastCreated = r.CreatedAt
+		}
+	}
+}
+
+func (l *List) stale() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.syncedAt.IsZero() || l.now().Sub(l.syncedAt) >= l.syncEvery
+}
+
+// merge adds the entry to the maps, a user keeps the latest cutoff only since it covers the earlier ones
+func (l *List) merge(r *domain.Revocation) {
+	if r.JTI != "" {
+		if exp, ok := l.byJTI[r.JTI]; !ok || r.ExpiresAt.After(exp) {
+			l.byJTI[r.JTI] = r.ExpiresAt
+		}
+		return
+	}
+	if r.SessionID != 0 {
+		if exp, ok := l.bySession[r.SessionID]; !ok || r.ExpiresAt.After(exp) {
+			l.bySession[r.SessionID] = r.ExpiresAt
+		}
+		return
+	}
+
+	cur, ok := l.byUser[r.UserID]
+	if !ok {
+		l.byUser[r.UserID] = &domain.Revocation{UserID: r.UserID, IssuedBefore: r.IssuedBefore, ExpiresAt: r.ExpiresAt}
+		return
+	}
+	if r.IssuedBefore.After(cur.IssuedBefore) {
+		cur.IssuedBefore = r.IssuedBefore
+	}
+	if r.ExpiresAt.After(cur.ExpiresAt) {
+		cur.ExpiresAt = r.ExpiresAt
+	}
+}
@@ -0,0 +1,237 @@
+package revocation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "server/internal/app/domain/user"
+	"server/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// repoFake keeps entries in memory, ids grow like a sequence and entries are created at the clock
+// of the list unless the test sets CreatedAt
+type repoFake struct {
+	entries  []*domain.Revocation
+	sinceErr error
+	queries  int
+	clock    *time.Time
+}
+
+func (r *repoFake) Add(ctx context.Context, rev *domain.Revocation) (int64, error) {
+	cp := *rev
+	cp.ID = int64(len(r.entries) + 1)
+	if cp.CreatedAt.IsZero() {
+		cp.CreatedAt = *r.clock
+	}
+	r.entries = append(r.entries, &cp)
+	return cp.ID, nil
+}
+
+func (r *repoFake) Since(ctx context.Context, since, now time.Time) ([]*domain.Revocation, error) {
+	r.queries++
+	if r.sinceErr != nil {
+		return nil, r.sinceErr
+	}
+	var out []*domain.Revocation
+	for _, e := range r.entries {
+		if !e.CreatedAt.Before(since) && !e.Expired(now) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (r *repoFake) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	var kept []*domain.Revocation
+	for _, e := range r.entries {
+		if !e.Expired(now) {
+			kept = append(kept, e)
+		}
+	}
+	n := int64(len(r.entries) - len(kept))
+	r.entries = kept
+	return n, nil
+}
+
+// newList returns a list with a clock the test moves
+func newList(repo *repoFake) (*List, *time.Time) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo.clock = &now
+	l := New(repo, 5*time.Second)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestList_RevokeJTI(t *testing.T) {
+	ctx := context.Background()
+	repo := &repoFake{}
+	l, now := newList(repo)
+
+	if err := l.Revoke(ctx, &domain.Revocation{JTI: "a", UserID: 7, ExpiresAt: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !l.Revoked(ctx, "a", 7, 0, now.Add(-time.Minute)) {
+		t.Fatalf("expected token a to be revoked")
+	}
+	if l.Revoked(ctx, "b", 7, 0, now.Add(-time.Minute)) {
+		t.Fatalf("expected token b of the same user to stay valid")
+	}
+	if len(repo.entries) != 1 {
+		t.Fatalf("expected the entry to be stored, got %d", len(repo.entries))
+	}
+}
+
+func TestList_RevokeUser(t *testing.T) {
+	ctx := context.Background()
+	l, now := newList(&repoFake{})
+
+	cutoff := *now
+	if err := l.Revoke(ctx, &domain.Revocation{UserID: 7, IssuedBefore: cutoff, ExpiresAt: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !l.Revoked(ctx, "a", 7, 0, cutoff.Add(-time.Second)) {
+		t.Fatalf("expected a token issued before the cutoff to be revoked")
+	}
+	if l.Revoked(ctx, "b", 7, 0, cutoff) {
+		t.Fatalf("expected a token issued at the cutoff to stay valid")
+	}
+	if l.Revoked(ctx, "c", 8, 0, cutoff.Add(-time.Second)) {
+		t.Fatalf("expected tokens of other users to stay valid")
+	}
+}
+
+func TestList_RevokeSession(t *testing.T) {
+	ctx := context.Background()
+	l, now := newList(&repoFake{})
+
+	if err := l.Revoke(ctx, &domain.Revocation{UserID: 7, SessionID: 31, ExpiresAt: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !l.Revoked(ctx, "a", 7, 31, now.Add(time.Minute)) {
+		t.Fatalf("expected every token of the session to be revoked")
+	}
+	if l.Revoked(ctx, "b", 7, 32, now.Add(-time.Minute)) {
+		t.Fatalf("expected tokens of other sessions to stay valid")
+	}
+}
+
+func TestList_RevokeInvalid(t *testing.T) {
+	l, now := newList(&repoFake{})
+
+	if err := l.Revoke(context.Background(), &domain.Revocation{UserID: 7, ExpiresAt: now.Add(time.Hour)}); !errors.Is(err, errInvalidRevocation) {
+		t.Fatalf("expected errInvalidRevocation, got: %v", err)
+	}
+	if err := l.Revoke(context.Background(), &domain.Revocation{JTI: "a", UserID: 7}); !errors.Is(err, errInvalidRevocation) {
+		t.Fatalf("expected errInvalidRevocation, got: %v", err)
+	}
+}
+
+func TestList_Sync(t *testing.T) {
+	logger.Log = zap.NewNop()
+
+	ctx := context.Background()
+	repo := &repoFake{}
+	l, now := newList(repo)
+
+	// first check loads the list
+	if l.Revoked(ctx, "a", 7, 0, *now) {
+		t.Fatalf("expected empty list")
+	}
+	if repo.queries != 1 {
+		t.Fatalf("expected one query, got %d", repo.queries)
+	}
+
+	// another instance revokes a token
+	_, _ = repo.Add(ctx, &domain.Revocation{JTI: "a", UserID: 7, ExpiresAt: now.Add(time.Hour)})
+
+	if l.Revoked(ctx, "a", 7, 0, *now) {
+		t.Fatalf("expected the cached list to be used before syncEvery passes")
+	}
+	if repo.queries != 1 {
+		t.Fatalf("expected no query before syncEvery, got %d", repo.queries)
+	}
+
+	*now = now.Add(5 * time.Second)
+	if !l.Revoked(ctx, "a", 7, 0, *now) {
+		t.Fatalf("expected the entry of the other instance after sync")
+	}
+
+	// a failed sync keeps the cache and is not retried on every check
+	repo.sinceErr = errors.New("db down")
+	*now = now.Add(5 * time.Second)
+	if !l.Revoked(ctx, "a", 7, 0, *now) {
+		t.Fatalf("expected cached entries to be used when sync fails")
+	}
+	l.Revoked(ctx, "a", 7, 0, *now)
+	if repo.queries != 3 {
+		t.Fatalf("expected 3 queries, got %d", repo.queries)
+	}
+}
+
+func TestList_SyncLateCommit(t *testing.T) {
+	ctx := context.Background()
+	repo := &repoFake{}
+	l, now := newList(repo)
+
+	// another instance starts its insert, a later one commits and is read first
+	started := *now
+	*now = now.Add(time.Second)
+	_, _ = repo.Add(ctx, &domain.Revocation{JTI: "b", UserID: 7, ExpiresAt: now.Add(time.Hour)})
+	if !l.Revoked(ctx, "b", 7, 0, *now) {
+		t.Fatalf("expected the committed entry after the first sync")
+	}
+
+	// the entry started earlier commits now with an older created_at and a larger id
+	_, _ = repo.Add(ctx, &domain.Revocation{JTI: "a", UserID: 7, ExpiresAt: now.Add(time.Hour), CreatedAt: started})
+
+	*now = now.Add(5 * time.Second)
+	if !l.Revoked(ctx, "a", 7, 0, *now) {
+		t.Fatalf("expected the late entry to be read within the overlap")
+	}
+	if !l.Revoked(ctx, "b", 7, 0, *now) {
+		t.Fatalf("expected the entry read again to stay revoked")
+	}
+}
+
+func TestList_Prune(t *testing.T) {
+	ctx := context.Background()
+	repo := &repoFake{}
+	l, now := newList(repo)
+
+	_ = l.Revoke(ctx, &domain.Revocation{JTI: "short", UserID: 7, ExpiresAt: now.Add(time.Minute)})
+	_ = l.Revoke(ctx, &domain.Revocation{JTI: "long", UserID: 7, ExpiresAt: now.Add(time.Hour)})
+	_ = l.Revoke(ctx, &domain.Revocation{UserID: 8, IssuedBefore: *now, ExpiresAt: now.Add(time.Minute)})
+	_ = l.Revoke(ctx, &domain.Revocation{UserID: 9, SessionID: 31, ExpiresAt: now.Add(time.Minute)})
+
+	*now = now.Add(2 * time.Minute)
+
+	n, err := l.Prune(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("expected 3 entries removed, got %d", n)
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if _, ok := l.byJTI["short"]; ok {
+		t.Fatalf("expected expired jti to be pruned")
+	}
+	if _, ok := l.byJTI["long"]; !ok {
+		t.Fatalf("expected live jti to stay")
+	}
+	if _, ok := l.byUser[8]; ok {
+		t.Fatalf("expected expired user cutoff to be pruned")
+	}
+	if _, ok := l.bySession[31]; ok {
+		t.Fatalf("expected expired session to be pruned")
+	}
+}
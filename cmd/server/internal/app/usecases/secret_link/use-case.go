@@ -0,0 +1,200 @@
+package secret_link
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domain "server/internal/app/domain/secret_link"
+)
+
+// idBytes of randomness make link ids impossible to guess
+const idBytes = 16
+
+const maxLabelLen = 128
+
+type Repository interface {
+	Create(ctx context.Context, link *domain.Link) error
+	// ListByUser returns links of the user without their ciphertext
+	ListByUser(ctx context.Context, userID int64) ([]*domain.Link, error)
+	// Delete removes a link of the user or returns ErrLinkNotFound
+	Delete(ctx context.Context, userID int64, linkID string) error
+
+	// Consume counts a view of a live link and returns its ciphertext, a link that is
+	// expired or has no views left is ErrLinkNotFound, so one view is never served twice
+	Consume(ctx context.Context, linkID string, now time.Time) (*domain.Secret, error)
+	// Burn removes a link after its last view
+	Burn(ctx context.Context, linkID string) error
+	// DeleteDead removes expired and burned links and returns how many were removed
+	DeleteDead(ctx context.Context, now time.Time) (int64, error)
+}
+
+type Links struct {
+	repo  Repository
+	opts  domain.Options
+	now   func() time.Time
+	newID func() (string, error)
+}
+
+func New(repo Repository, opts domain.Options) *Links {
+	return &Links{repo: repo, opts: opts, now: time.Now, newID: randomID}
+}
+
+// CreateLink stores a ciphertext the client encrypted, zero ttl and maxViews use the defaults
+func (l *Links) CreateLink(ctx context.Context, userID int64, label string, ciphertext []byte, ttl time.Duration, maxViews int) (*domain.Link, error) {
+	if userID <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if len(ciphertext) == 0 {
+		return nil, domain.ErrEmptySecret
+	}
+
+	if l.opts.MaxSize > 0 && len(ciphertext) > l.opts.MaxSize {
+		return nil, domain.ErrSecretTooLarge
+	}
+
+	label = strings.TrimSpace(label)
+	if utf8.RuneCountInString(label) > maxLabelLen {
+		return nil, domain.ErrLabelTooLong
+	}
+
+	if ttl == 0 {
+		ttl = l.opts.DefaultTTL
+	}
+	if ttl <= 0 || (l.opts.MaxTTL > 0 && ttl > l.opts.MaxTTL) {
+		return nil, domain.ErrInvalidTTL
+	}
+
+	if maxViews == 0 {
+		maxViews = 1
+	}
+	if maxViews < 0 || (l.opts.MaxViews > 0 && maxViews > l.opts.MaxViews) {
+		return nil, domain.ErrInvalidViews
+	}
+
+	id, err := l.newID()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrFailedCreateLink, err)
+	}
+
+	now := l.now()
+	link := &domain.Link{
+		ID:         id,
+		UserID:     userID,
+		Label:      label,
+		Ciphertext: ciphertext,
+		MaxViews:   maxViews,
+		ExpiresAt:  now.Add(ttl),
+		CreatedAt:  now,
+	}
+
+	if err := l.repo.Create(ctx, link); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrFailedCreateLink, err)
+	}
+
+	return link, nil
+}
+
+// ListLinks returns the links of the user that can still be opened
+func (l *Links) ListLinks(ctx context.Context, userID int64) ([]*domain.Link, error) {
+	if userID <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	list, err := l.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list links of user id=%d: %w", userID, err)
+	}
+
+	// dead links wait for the purge job, they are not shown
+	now := l.now()
+	live := list[:0]
+	for _, link := range list {
+		if link.ViewsLeft() > 0 && now.Before(link.ExpiresAt) {
+			live = append(live, link)
+		}
+	}
+
+	if len(live) == 0 {
+		return nil, domain.ErrEmptyLinks
+	}
+
+	return live, nil
+}
+
+// DeleteLink revokes a link before it is read
+func (l *Links) DeleteLink(ctx context.Context, userID int64, linkID string) error {
+	if userID <= 0 {
+		return domain.ErrInvalidUserID
+	}
+
+	if !validID(linkID) {
+		return domain.ErrLinkNotFound
+	}
+
+	if err := l.repo.Delete(ctx, userID, linkID); err != nil {
+		if errors.Is(err, domain.ErrLinkNotFound) {
+			return err
+		}
+		return fmt.Errorf("delete link %s: %w", linkID, err)
+	}
+
+	return nil
+}
+
+// Open serves a link to an anonymous reader and burns it after the last view
+func (l *Links) Open(ctx context.Context, linkID string) (*domain.Secret, error) {
+	if !validID(linkID) {
+		return nil, domain.ErrLinkNotFound
+	}
+
+	secret, err := l.repo.Consume(ctx, linkID, l.now())
+	if err != nil {
+		if errors.Is(err, domain.ErrLinkNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("open link %s: %w", linkID, err)
+	}
+
+	if secret.Burned() {
+		// the view is already counted and can not be served again, a failed burn
+		// only leaves the ciphertext until the purge job removes it
+		_ = l.repo.Burn(ctx, linkID)
+	}
+
+	return secret, nil
+}
+
+// PurgeDead removes expired and burned links, it is run by a background job
+func (l *Links) PurgeDead(ctx context.Context) (int64, error) {
+	n, err := l.repo.DeleteDead(ctx, l.now())
+	if err != nil {
+		return 0, fmt.Errorf("purge secret links: %w", err)
+	}
+	return n, nil
+}
+
+// help func
+
+func randomID() (string, error) {
+	b := make([]byte, idBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// validID rejects ids that can not be produced by randomID without a database round trip
+func validID(id string) bool {
+	if len(id) != base64.RawURLEncoding.EncodedLen(idBytes) {
+		return false
+	}
+	_, err := base64.RawURLEncoding.DecodeString(id)
+	return err == nil
+}
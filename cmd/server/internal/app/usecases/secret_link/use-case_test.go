@@ -0,0 +1,209 @@
+package secret_link
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domain "server/internal/app/domain/secret_link"
+)
+
+// repoFake keeps links in memory with the same view accounting as the database
+type repoFake struct {
+	links  map[string]*domain.Link
+	burned []string
+}
+
+func newRepoFake() *repoFake {
+	return &repoFake{links: map[string]*domain.Link{}}
+}
+
+func (r *repoFake) Create(ctx context.Context, link *domain.Link) error {
+	cp := *link
+	r.links[link.ID] = &cp
+	return nil
+}
+
+func (r *repoFake) ListByUser(ctx context.Context, userID int64) ([]*domain.Link, error) {
+	var out []*domain.Link
+	for _, link := range r.links {
+		if link.UserID == userID {
+			out = append(out, link)
+		}
+	}
+	return out, nil
+}
+
+func (r *repoFake) Delete(ctx context.Context, userID int64, linkID string) error {
+	link, ok := r.links[linkID]
+	if !ok || link.UserID != userID {
+		return domain.ErrLinkNotFound
+	}
+	delete(r.links, linkID)
+	return nil
+}
+
+func (r *repoFake) Consume(ctx context.Context, linkID string, now time.Time) (*domain.Secret, error) {
+	link, ok := r.links[linkID]
+	if !ok || !now.Before(link.ExpiresAt) || link.ViewsLeft() == 0 {
+		return nil, domain.ErrLinkNotFound
+	}
+	link.Views++
+	return &domain.Secret{Ciphertext: link.Ciphertext, ViewsLeft: link.ViewsLeft(), ExpiresAt: link.ExpiresAt}, nil
+}
+
+func (r *repoFake) Burn(ctx context.Context, linkID string) error {
+	r.burned = append(r.burned, linkID)
+	delete(r.links, linkID)
+	return nil
+}
+
+func (r *repoFake) DeleteDead(ctx context.Context, now time.Time) (int64, error) {
+	var n int64
+	for id, link := range r.links {
+		if !now.Before(link.ExpiresAt) || link.ViewsLeft() == 0 {
+			delete(r.links, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+var testOpts = domain.Options{DefaultTTL: time.Hour, MaxTTL: 24 * time.Hour, MaxViews: 5, MaxSize: 64}
+
+// newAt returns a use case whose clock is read from now
+func newAt(repo Repository, now *time.Time) *Links {
+	l := New(repo, testOpts)
+	l.now = func() time.Time { return *now }
+	return l
+}
+
+func TestLinks_CreateLink(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		userID     int64
+		label      string
+		ciphertext []byte
+		ttl        time.Duration
+		views      int
+		wantErr    error
+	}{
+		{name: "defaults", userID: 1, ciphertext: []byte("x")},
+		{name: "invalid user", userID: 0, ciphertext: []byte("x"), wantErr: domain.ErrInvalidUserID},
+		{name: "empty secret", userID: 1, wantErr: domain.ErrEmptySecret},
+		{name: "too large", userID: 1, ciphertext: make([]byte, 65), wantErr: domain.ErrSecretTooLarge},
+		{name: "label too long", userID: 1, label: strings.Repeat("a", 129), ciphertext: []byte("x"), wantErr: domain.ErrLabelTooLong},
+		{name: "ttl over max", userID: 1, ciphertext: []byte("x"), ttl: 25 * time.Hour, wantErr: domain.ErrInvalidTTL},
+		{name: "negative ttl", userID: 1, ciphertext: []byte("x"), ttl: -time.Minute, wantErr: domain.ErrInvalidTTL},
+		{name: "views over max", userID: 1, ciphertext: []byte("x"), views: 6, wantErr: domain.ErrInvalidViews},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			link, err := newAt(newRepoFake(), &now).CreateLink(context.Background(), tt.userID, tt.label, tt.ciphertext, tt.ttl, tt.views)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateLink error: %v", err)
+			}
+			if link.MaxViews != 1 || !link.ExpiresAt.Equal(now.Add(time.Hour)) || !validID(link.ID) {
+				t.Fatalf("unexpected link: %+v", link)
+			}
+		})
+	}
+}
+
+func TestLinks_OpenBurnsAfterLastView(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	repo := newRepoFake()
+	l := newAt(repo, &now)
+
+	link, err := l.CreateLink(context.Background(), 1, "wifi", []byte("sealed"), 0, 2)
+	if err != nil {
+		t.Fatalf("CreateLink error: %v", err)
+	}
+
+	first, err := l.Open(context.Background(), link.ID)
+	if err != nil || first.ViewsLeft != 1 || string(first.Ciphertext) != "sealed" {
+		t.Fatalf("first Open = %+v, %v", first, err)
+	}
+	if len(repo.burned) != 0 {
+		t.Fatalf("link burned too early")
+	}
+
+	second, err := l.Open(context.Background(), link.ID)
+	if err != nil || !second.Burned() {
+		t.Fatalf("second Open = %+v, %v", second, err)
+	}
+	if len(repo.burned) != 1 {
+		t.Fatalf("expected the link to be burned")
+	}
+
+	if _, err := l.Open(context.Background(), link.ID); !errors.Is(err, domain.ErrLinkNotFound) {
+		t.Fatalf("expected ErrLinkNotFound after burn, got %v", err)
+	}
+}
+
+func TestLinks_OpenExpired(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	repo := newRepoFake()
+	l := newAt(repo, &now)
+
+	link, err := l.CreateLink(context.Background(), 1, "", []byte("sealed"), time.Hour, 3)
+	if err != nil {
+		t.Fatalf("CreateLink error: %v", err)
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := l.Open(context.Background(), link.ID); !errors.Is(err, domain.ErrLinkNotFound) {
+		t.Fatalf("expected ErrLinkNotFound for an expired link, got %v", err)
+	}
+
+	if _, err := l.ListLinks(context.Background(), 1); !errors.Is(err, domain.ErrEmptyLinks) {
+		t.Fatalf("expected expired links to be hidden, got %v", err)
+	}
+
+	n, err := l.PurgeDead(context.Background())
+	if err != nil || n != 1 {
+		t.Fatalf("PurgeDead = %d, %v", n, err)
+	}
+}
+
+func TestLinks_OpenRejectsMalformedID(t *testing.T) {
+	l := New(nil, testOpts)
+
+	for _, id := range []string{"", "short", "../../../../etc/passwd", strings.Repeat("!", 22)} {
+		if _, err := l.Open(context.Background(), id); !errors.Is(err, domain.ErrLinkNotFound) {
+			t.Fatalf("Open(%q): expected ErrLinkNotFound, got %v", id, err)
+		}
+	}
+}
+
+func TestLinks_DeleteLink(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	repo := newRepoFake()
+	l := newAt(repo, &now)
+
+	link, err := l.CreateLink(context.Background(), 1, "", []byte("sealed"), 0, 0)
+	if err != nil {
+		t.Fatalf("CreateLink error: %v", err)
+	}
+
+	if err := l.DeleteLink(context.Background(), 2, link.ID); !errors.Is(err, domain.ErrLinkNotFound) {
+		t.Fatalf("expected other users to get ErrLinkNotFound, got %v", err)
+	}
+	if err := l.DeleteLink(context.Background(), 1, link.ID); err != nil {
+		t.Fatalf("DeleteLink error: %v", err)
+	}
+	if _, err := l.Open(context.Background(), link.ID); !errors.Is(err, domain.ErrLinkNotFound) {
+		t.Fatalf("expected a deleted link to be gone, got %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	domain "server/internal/app/domain/share"
+)
+
+type Repository interface {
+	// ItemOwner returns the user of the item or ErrItemNotFound
+	ItemOwner(ctx context.Context, item domain.Item) (int64, error)
+	// UserIDByName returns the id of a registered user or ErrUserNotFound
+	UserIDByName(ctx context.Context, username string) (int64, error)
+
+	// Upsert creates the share or changes the permission of an existing one
+	Upsert(ctx context.Context, s *domain.Share) (int64, error)
+	GetByID(ctx context.Context, shareID int64) (*domain.Share, error)
+	Delete(ctx context.Context, shareID int64) error
+
+	ListByItem(ctx context.Context, item domain.Item) ([]*domain.Share, error)
+	ListByGrantee(ctx context.Context, granteeID int64) ([]*domain.Share, error)
+	// Access returns the share of the item for the user or ErrShareNotFound
+	Access(ctx context.Context, userID int64, item domain.Item) (*domain.Access, error)
+}
+
+type Shares struct {
+	repo Repository
+}
+
+func New(repo Repository) *Shares {
+	return &Shares{repo: repo}
+}
+
+// ShareItem gives a registered user read or write access to an item of the owner,
+// sharing the item with the same user again changes the permission
+func (s *Shares) ShareItem(ctx context.Context, ownerID int64, item domain.Item, username, permission string) (*domain.Share, error) {
+	if err := s.checkOwner(ctx, ownerID, item); err != nil {
+		return nil, err
+	}
+
+	if !domain.ValidPermission(permission) {
+		return nil, domain.ErrInvalidPermission
+	}
+
+	granteeID, err := s.repo.UserIDByName(ctx, strings.TrimSpace(username))
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get user %q: %w", username, err)
+	}
+
+	if granteeID == ownerID {
+		return nil, domain.ErrShareWithSelf
+	}
+
+	sh := &domain.Share{
+		Item:        item,
+		OwnerID:     ownerID,
+		GranteeID:   granteeID,
+		GranteeName: strings.TrimSpace(username),
+		Permission:  permission,
+	}
+
+	id, err := s.repo.Upsert(ctx, sh)
+	if err != nil {
+		return nil, fmt.Errorf("share %s id=%d: %w", item.Type, item.ID, err)
+	}
+	sh.ID = id
+
+	return sh, nil
+}
+
+// ListItemShares returns who has access to an item, only the owner can see it
+func (s *Shares) ListItemShares(ctx context.Context, ownerID int64, item domain.Item) ([]*domain.Share, error) {
+	if err := s.checkOwner(ctx, ownerID, item); err != nil {
+		return nil, err
+	}
+
+	list, err := s.repo.ListByItem(ctx, item)
+	if err != nil {
+		return nil, fmt.Errorf("list shares of %s id=%d: %w", item.Type, item.ID, err)
+	}
+
+	if len(list) == 0 {
+		return nil, domain.ErrEmptyShares
+	}
+
+	return list, nil
+}
+
+// SharedWithMe returns the items other users shared with the user
+func (s *Shares) SharedWithMe(ctx context.Context, userID int64) ([]*domain.Share, error) {
+	if userID <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	list, err := s.repo.ListByGrantee(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list shares of user id=%d: %w", userID, err)
+	}
+
+	if len(list) == 0 {
+		return nil, domain.ErrEmptyShares
+	}
+
+	return list, nil
+}
+
+// Revoke removes a share, the owner revokes it and the grantee may give it up
+func (s *Shares) Revoke(ctx context.Context, userID, shareID int64) error {
+	if userID <= 0 {
+		return domain.ErrInvalidUserID
+	}
+
+	if shareID <= 0 {
+		return domain.ErrInvalidShareID
+	}
+
+	sh, err := s.repo.GetByID(ctx, shareID)
+	if err != nil {
+		if errors.Is(err, domain.ErrShareNotFound) {
+			return err
+		}
+		return fmt.Errorf("get share id=%d: %w", shareID, err)
+	}
+
+	if sh.OwnerID != userID && sh.GranteeID != userID {
+		return domain.ErrShareNotFound
+	}
+
+	return s.repo.Delete(ctx, shareID)
+}
+
+// Access is used by the item usecases to authorize users other than the owner
+func (s *Shares) Access(ctx context.Context, userID int64, item domain.Item) (*domain.Access, error) {
+	if userID <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	return s.repo.Access(ctx, userID, item)
+}
+
+// help func
+
+// checkOwner validates the item and its ownership, items of other users are reported as not found
+func (s *Shares) checkOwner(ctx context.Context, userID int64, item domain.Item) error {
+	if userID <= 0 {
+		return domain.ErrInvalidUserID
+	}
+
+	if !domain.ValidItemType(item.Type) {
+		return domain.ErrInvalidItem
+	}
+
+	if item.ID <= 0 {
+		return domain.ErrInvalidItemID
+	}
+
+	owner, err := s.repo.ItemOwner(ctx, item)
+	if err != nil {
+		if errors.Is(err, domain.ErrItemNotFound) {
+			return err
+		}
+		return fmt.Errorf("get %s id=%d: %w", item.Type, item.ID, err)
+	}
+
+	if owner != userID {
+		return domain.ErrItemNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "server/internal/app/domain/share"
+)
+
+type repoFake struct {
+	itemOwner     func(ctx context.Context, item domain.Item) (int64, error)
+	userIDByName  func(ctx context.Context, username string) (int64, error)
+	upsert        func(ctx context.Context, s *domain.Share) (int64, error)
+	getByID       func(ctx context.Context, shareID int64) (*domain.Share, error)
+	delete        func(ctx context.Context, shareID int64) error
+	listByItem    func(ctx context.Context, item domain.Item) ([]*domain.Share, error)
+	listByGrantee func(ctx context.Context, granteeID int64) ([]*domain.Share, error)
+	access        func(ctx context.Context, userID int64, item domain.Item) (*domain.Access, error)
+}
+
+func (r *repoFake) ItemOwner(ctx context.Context, item domain.Item) (int64, error) {
+	if r.itemOwner != nil {
+		return r.itemOwner(ctx, item)
+	}
+	return 7, nil
+}
+func (r *repoFake) UserIDByName(ctx context.Context, username string) (int64, error) {
+	if r.userIDByName != nil {
+		return r.userIDByName(ctx, username)
+	}
+	return 8, nil
+}
+func (r *repoFake) Upsert(ctx context.Context, s *domain.Share) (int64, error) {
+	if r.upsert != nil {
+		return r.upsert(ctx, s)
+	}
+	return 1, nil
+}
+func (r *repoFake) GetByID(ctx context.Context, shareID int64) (*domain.Share, error) {
+	if r.getByID != nil {
+		return r.getByID(ctx, shareID)
+	}
+	return &domain.Share{ID: shareID, OwnerID: 7, GranteeID: 8}, nil
+}
+func (r *repoFake) Delete(ctx context.Context, shareID int64) error {
+	if r.delete != nil {
+		return r.delete(ctx, shareID)
+	}
+	return nil
+}
+func (r *repoFake) ListByItem(ctx context.Context, item domain.Item) ([]*domain.Share, error) {
+	if r.listByItem != nil {
+		return r.listByItem(ctx, item)
+	}
+	return nil, nil
+}
+func (r *repoFake) ListByGrantee(ctx context.Context, granteeID int64) ([]*domain.Share, error) {
+	if r.listByGrantee != nil {
+		return r.listByGrantee(ctx, granteeID)
+	}
+	return nil, nil
+}
+func (r *repoFake) Access(ctx context.Context, userID int64, item domain.Item) (*domain.Access, error) {
+	if r.access != nil {
+		return r.access(ctx, userID, item)
+	}
+	return nil, domain.ErrShareNotFound
+}
+
+func TestShares_ShareItem(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	account := domain.Item{Type: domain.ItemAccount, ID: 3}
+
+	tests := []struct {
+		name       string
+		repo       *repoFake
+		item       domain.Item
+		username   string
+		permission string
+		wantErr    error
+	}{
+		{name: "unknown type -> ErrInvalidItem", repo: &repoFake{}, item: domain.Item{Type: "file", ID: 3}, username: "bob", permission: domain.PermRead, wantErr: domain.ErrInvalidItem},
+		{name: "invalid permission -> ErrInvalidPermission", repo: &repoFake{}, item: account, username: "bob", permission: "admin", wantErr: domain.ErrInvalidPermission},
+		{
+			name:       "item of another user -> ErrItemNotFound",
+			repo:       &repoFake{itemOwner: func(ctx context.Context, item domain.Item) (int64, error) { return 9, nil }},
+			item:       account,
+			username:   "bob",
+			permission: domain.PermRead,
+			wantErr:    domain.ErrItemNotFound,
+		},
+		{
+			name:       "unknown user -> ErrUserNotFound",
+			repo:       &repoFake{userIDByName: func(ctx context.Context, username string) (int64, error) { return 0, domain.ErrUserNotFound }},
+			item:       account,
+			username:   "nobody",
+			permission: domain.PermRead,
+			wantErr:    domain.ErrUserNotFound,
+		},
+		{
+			name:       "sharing with the owner -> ErrShareWithSelf",
+			repo:       &repoFake{userIDByName: func(ctx context.Context, username string) (int64, error) { return 7, nil }},
+			item:       account,
+			username:   "alice",
+			permission: domain.PermWrite,
+			wantErr:    domain.ErrShareWithSelf,
+		},
+		{name: "ok", repo: &repoFake{}, item: account, username: " bob ", permission: domain.PermWrite},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			sh, err := New(tc.repo).ShareItem(ctx, 7, tc.item, tc.username, tc.permission)
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if sh.ID != 1 || sh.GranteeID != 8 || sh.GranteeName != "bob" || sh.Permission != domain.PermWrite {
+					t.Fatalf("unexpected share: %+v", sh)
+				}
+				return
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestShares_Revoke(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		userID  int64
+		wantErr error
+	}{
+		{name: "owner revokes", userID: 7},
+		{name: "grantee gives up", userID: 8},
+		{name: "stranger -> ErrShareNotFound", userID: 9, wantErr: domain.ErrShareNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			deleted := false
+			uc := New(&repoFake{delete: func(ctx context.Context, shareID int64) error {
+				deleted = true
+				return nil
+			}})
+
+			err := uc.Revoke(ctx, tc.userID, 4)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+			if deleted != (tc.wantErr == nil) {
+				t.Fatalf("deleted=%v with err=%v", deleted, err)
+			}
+		})
+	}
+}
+
+func TestShares_Lists(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("nothing shared with me -> ErrEmptyShares", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := New(&repoFake{}).SharedWithMe(ctx, 8); !errors.Is(err, domain.ErrEmptyShares) {
+			t.Fatalf("expected ErrEmptyShares, got: %v", err)
+		}
+	})
+
+	t.Run("shares of a foreign item -> ErrItemNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{itemOwner: func(ctx context.Context, item domain.Item) (int64, error) { return 9, nil }})
+		if _, err := uc.ListItemShares(ctx, 7, domain.Item{Type: domain.ItemText, ID: 2}); !errors.Is(err, domain.ErrItemNotFound) {
+			t.Fatalf("expected ErrItemNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("shares of own item", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{listByItem: func(ctx context.Context, item domain.Item) ([]*domain.Share, error) {
+			return []*domain.Share{{ID: 1, GranteeName: "bob"}}, nil
+		}})
+		list, err := uc.ListItemShares(ctx, 7, domain.Item{Type: domain.ItemText, ID: 2})
+		if err != nil || len(list) != 1 {
+			t.Fatalf("unexpected result: %v, %v", list, err)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package ssh_key_obj
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"server/internal/app/domain/audit"
+	domain "server/internal/app/domain/ssh_key_obj"
+	"server/internal/pkg/sshkey"
+)
+
+type Repository interface {
+	GetByUserID(ctx context.Context, userId int64) ([]*domain.SSHKey, error)
+	GetByID(ctx context.Context, keyId int64) (*domain.SSHKey, error)
+	Create(ctx context.Context, key *domain.SSHKey) (int64, error)
+}
+
+// Auditor writes access to items into the audit log
+type Auditor interface {
+	Record(ctx context.Context, e audit.Event)
+}
+
+type SSHKeyObj struct {
+	repo  Repository
+	audit Auditor
+}
+
+// New creates the use case, without auditor nothing is logged
+func New(repo Repository, auditor Auditor) *SSHKeyObj {
+	return &SSHKeyObj{repo: repo, audit: auditor}
+}
+
+func (s *SSHKeyObj) GetSSHKeyList(ctx context.Context, userId int64) ([]*domain.SSHKey, error) {
+	if userId <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	list, err := s.repo.GetByUserID(ctx, userId)
+	if err != nil {
+		return nil, domain.ErrSSHKeyNotFound
+	}
+
+	if len(list) == 0 {
+		return nil, domain.ErrEmptySSHKeysList
+	}
+
+	return list, nil
+}
+
+// GetSSHKey returns the key with its private part, keys of other users are reported as not found
+func (s *SSHKeyObj) GetSSHKey(ctx context.Context, userId, keyId int64) (*domain.SSHKey, error) {
+	if keyId <= 0 {
+		return nil, domain.ErrInvalidSSHKeyID
+	}
+
+	key, err := s.repo.GetByID(ctx, keyId)
+	if err != nil || key.UserId != userId {
+		return nil, domain.ErrSSHKeyNotFound
+	}
+
+	s.record(ctx, key, audit.ActionItemRead)
+
+	return key, nil
+}
+
+// ImportSSHKey stores an existing private key, a passphrase protected key is stored decrypted
+func (s *SSHKeyObj) ImportSSHKey(ctx context.Context, req domain.ImportRequest) (*domain.SSHKey, error) {
+	if err := validate(req.UserId, req.Title); err != nil {
+		return nil, err
+	}
+
+	parsed, err := sshkey.Parse(req.PrivateKey, req.Passphrase, req.Comment)
+	if err != nil {
+		return nil, mapKeyError(err)
+	}
+
+	return s.create(ctx, req.UserId, req.Title, parsed)
+}
+
+// GenerateSSHKey creates a new Ed25519 or RSA key pair on the server
+func (s *SSHKeyObj) GenerateSSHKey(ctx context.Context, req domain.GenerateRequest) (*domain.SSHKey, error) {
+	if err := validate(req.UserId, req.Title); err != nil {
+		return nil, err
+	}
+
+	generated, err := sshkey.Generate(req.Type, req.Bits, req.Comment)
+	if err != nil {
+		return nil, mapKeyError(err)
+	}
+
+	return s.create(ctx, req.UserId, req.Title, generated)
+}
+
+// help func
+
+func (s *SSHKeyObj) create(ctx context.Context, userId int64, title string, k *sshkey.Key) (*domain.SSHKey, error) {
+	key := &domain.SSHKey{
+		UserId:      userId,
+		Title:       strings.TrimSpace(title),
+		Type:        k.Type,
+		PrivateKey:  k.PrivateKey,
+		PublicKey:   k.PublicKey,
+		Fingerprint: k.Fingerprint,
+		Comment:     k.Comment,
+	}
+
+	id, err := s.repo.Create(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrFailedCreateSSHKey, err)
+	}
+	key.KeyId = id
+
+	s.record(ctx, key, audit.ActionCreate)
+
+	return key, nil
+}
+
+// record writes the action of the owner on the key into the audit log, only owners reach keys
+func (s *SSHKeyObj) record(ctx context.Context, key *domain.SSHKey, action string) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, audit.Event{
+		UserID:   key.UserId,
+		OwnerID:  key.UserId,
+		Action:   action,
+		ItemType: audit.ItemSSHKey,
+		ItemID:   key.KeyId,
+	})
+}
+
+func validate(userId int64, title string) error {
+	if userId <= 0 {
+		return domain.ErrInvalidUserID
+	}
+
+	if strings.TrimSpace(title) == "" {
+		return domain.ErrEmptyTitle
+	}
+
+	return nil
+}
+
+// mapKeyError turns key parsing and generation errors into domain errors
+func mapKeyError(err error) error {
+	switch {
+	case errors.Is(err, sshkey.ErrPassphraseRequired):
+		return domain.ErrPassphraseRequired
+	case errors.Is(err, sshkey.ErrWrongPassphrase):
+		return domain.ErrWrongPassphrase
+	case errors.Is(err, sshkey.ErrUnsupportedType), errors.Is(err, sshkey.ErrInvalidBits):
+		return fmt.Errorf("%w: %w", domain.ErrUnsupportedKeyType, err)
+	case errors.Is(err, sshkey.ErrInvalidKey):
+		return fmt.Errorf("%w: %w", domain.ErrInvalidPrivateKey, err)
+	default:
+		return fmt.Errorf("%w: %w", domain.ErrFailedCreateSSHKey, err)
+	}
+}
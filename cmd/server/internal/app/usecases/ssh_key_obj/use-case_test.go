@@ -0,0 +1,220 @@
+package ssh_key_obj
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	domain "server/internal/app/domain/ssh_key_obj"
+	"server/internal/pkg/sshkey"
+
+	"golang.org/x/crypto/ssh"
+)
+
+type repoFake struct {
+	getByUserID func(ctx context.Context, userId int64) ([]*domain.SSHKey, error)
+	getByID     func(ctx context.Context, keyId int64) (*domain.SSHKey, error)
+	create      func(ctx context.Context, key *domain.SSHKey) (int64, error)
+}
+
+func (r *repoFake) GetByUserID(ctx context.Context, userId int64) ([]*domain.SSHKey, error) {
+	if r.getByUserID != nil {
+		return r.getByUserID(ctx, userId)
+	}
+	return nil, nil
+}
+func (r *repoFake) GetByID(ctx context.Context, keyId int64) (*domain.SSHKey, error) {
+	if r.getByID != nil {
+		return r.getByID(ctx, keyId)
+	}
+	return nil, nil
+}
+func (r *repoFake) Create(ctx context.Context, key *domain.SSHKey) (int64, error) {
+	if r.create != nil {
+		return r.create(ctx, key)
+	}
+	return 1, nil
+}
+
+func TestSSHKeyObj_GetSSHKey(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	uc := New(&repoFake{
+		getByID: func(ctx context.Context, keyId int64) (*domain.SSHKey, error) {
+			return &domain.SSHKey{KeyId: keyId, UserId: 7}, nil
+		},
+	}, nil)
+
+	t.Run("invalid id -> ErrInvalidSSHKeyID", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := uc.GetSSHKey(ctx, 7, 0); !errors.Is(err, domain.ErrInvalidSSHKeyID) {
+			t.Fatalf("expected ErrInvalidSSHKeyID, got: %v", err)
+		}
+	})
+
+	t.Run("key of another user -> ErrSSHKeyNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := uc.GetSSHKey(ctx, 8, 3); !errors.Is(err, domain.ErrSSHKeyNotFound) {
+			t.Fatalf("expected ErrSSHKeyNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("own key -> returned", func(t *testing.T) {
+		t.Parallel()
+
+		key, err := uc.GetSSHKey(ctx, 7, 3)
+		if err != nil || key.KeyId != 3 {
+			t.Fatalf("unexpected result: %+v, %v", key, err)
+		}
+	})
+}
+
+func TestSSHKeyObj_GetSSHKeyList(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("empty -> ErrEmptySSHKeysList", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{}, nil)
+		if _, err := uc.GetSSHKeyList(ctx, 7); !errors.Is(err, domain.ErrEmptySSHKeysList) {
+			t.Fatalf("expected ErrEmptySSHKeysList, got: %v", err)
+		}
+	})
+
+	t.Run("repo error -> ErrSSHKeyNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			getByUserID: func(ctx context.Context, userId int64) ([]*domain.SSHKey, error) {
+				return nil, errors.New("db error")
+			},
+		}, nil)
+		if _, err := uc.GetSSHKeyList(ctx, 7); !errors.Is(err, domain.ErrSSHKeyNotFound) {
+			t.Fatalf("expected ErrSSHKeyNotFound, got: %v", err)
+		}
+	})
+}
+
+func TestSSHKeyObj_GenerateSSHKey(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("empty title -> ErrEmptyTitle", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{}, nil)
+		_, err := uc.GenerateSSHKey(ctx, domain.GenerateRequest{UserId: 7, Title: " "})
+		if !errors.Is(err, domain.ErrEmptyTitle) {
+			t.Fatalf("expected ErrEmptyTitle, got: %v", err)
+		}
+	})
+
+	t.Run("unknown type -> ErrUnsupportedKeyType", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{}, nil)
+		_, err := uc.GenerateSSHKey(ctx, domain.GenerateRequest{UserId: 7, Title: "deploy", Type: "dsa"})
+		if !errors.Is(err, domain.ErrUnsupportedKeyType) {
+			t.Fatalf("expected ErrUnsupportedKeyType, got: %v", err)
+		}
+	})
+
+	t.Run("ed25519 -> stored with fingerprint", func(t *testing.T) {
+		t.Parallel()
+
+		var saved *domain.SSHKey
+		uc := New(&repoFake{
+			create: func(ctx context.Context, key *domain.SSHKey) (int64, error) {
+				saved = key
+				return 42, nil
+			},
+		}, nil)
+
+		key, err := uc.GenerateSSHKey(ctx, domain.GenerateRequest{UserId: 7, Title: "deploy", Type: sshkey.TypeEd25519, Comment: "ci"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if key.KeyId != 42 || saved == nil || saved.UserId != 7 {
+			t.Fatalf("unexpected key: %+v", key)
+		}
+		if key.Type != ssh.KeyAlgoED25519 || key.Fingerprint == "" || key.PrivateKey == "" {
+			t.Fatalf("key pair not filled: %+v", key)
+		}
+	})
+
+	t.Run("repo error -> ErrFailedCreateSSHKey", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			create: func(ctx context.Context, key *domain.SSHKey) (int64, error) {
+				return 0, errors.New("db error")
+			},
+		}, nil)
+
+		_, err := uc.GenerateSSHKey(ctx, domain.GenerateRequest{UserId: 7, Title: "deploy"})
+		if !errors.Is(err, domain.ErrFailedCreateSSHKey) {
+			t.Fatalf("expected ErrFailedCreateSSHKey, got: %v", err)
+		}
+	})
+}
+
+func TestSSHKeyObj_ImportSSHKey(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	generated, err := sshkey.Generate(sshkey.TypeEd25519, 0, "")
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+	raw, err := ssh.ParseRawPrivateKey([]byte(generated.PrivateKey))
+	if err != nil {
+		t.Fatalf("ParseRawPrivateKey error: %v", err)
+	}
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(raw, "", []byte("secret"))
+	if err != nil {
+		t.Fatalf("MarshalPrivateKeyWithPassphrase error: %v", err)
+	}
+	protected := string(pem.EncodeToMemory(block))
+
+	tests := []struct {
+		name       string
+		privateKey string
+		passphrase string
+		wantErr    error
+	}{
+		{name: "protected without passphrase -> ErrPassphraseRequired", privateKey: protected, wantErr: domain.ErrPassphraseRequired},
+		{name: "wrong passphrase -> ErrWrongPassphrase", privateKey: protected, passphrase: "nope", wantErr: domain.ErrWrongPassphrase},
+		{name: "garbage -> ErrInvalidPrivateKey", privateKey: "-----BEGIN nothing", wantErr: domain.ErrInvalidPrivateKey},
+		{name: "protected with passphrase -> ok", privateKey: protected, passphrase: "secret"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc := New(&repoFake{}, nil)
+			key, err := uc.ImportSSHKey(ctx, domain.ImportRequest{UserId: 7, Title: "laptop", PrivateKey: tc.privateKey, Passphrase: tc.passphrase})
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got: %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if key.Fingerprint != generated.Fingerprint {
+				t.Fatalf("expected fingerprint %q, got %q", generated.Fingerprint, key.Fingerprint)
+			}
+		})
+	}
+}
@@ -2,8 +2,15 @@ package text_obj
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"server/internal/app/domain/audit"
+	"server/internal/app/domain/custom_field"
+	"server/internal/app/domain/org"
+	"server/internal/app/domain/share"
 	domain "server/internal/app/domain/text_obj"
+	"server/internal/app/usecases/access"
 )
 
 type Repository interface {
@@ -11,26 +18,54 @@ type Repository interface {
 	GetByID(ctx context.Context, textId int64) (*domain.Text, error)
 	Create(ctx context.Context, text *domain.Text) (int64, error)
 	Update(ctx context.Context, text *domain.Text) error
+	Delete(ctx context.Context, textId int64) error
+}
+
+// ShareChecker returns the access of a user to an item of another user or share.ErrShareNotFound
+type ShareChecker interface {
+	Access(ctx context.Context, userId int64, item share.Item) (*share.Access, error)
+}
+
+// OrgChecker checks the role of a member against an action, non-members get org.ErrOrgNotFound
+type OrgChecker interface {
+	Authorize(ctx context.Context, userId, orgId int64, action string) error
+}
+
+// Auditor writes access to items into the audit log
+type Auditor interface {
+	Record(ctx context.Context, e audit.Event)
 }
 
 type TextObj struct {
-	repo Repository
+	repo   Repository
+	shares ShareChecker
+	orgs   OrgChecker
+	audit  Auditor
 }
 
-func New(repo Repository) *TextObj {
-	return &TextObj{repo: repo}
+// New creates the use case, without shares only owners can open their texts,
+// without auditor nothing is logged
+func New(repo Repository, shares ShareChecker, orgs OrgChecker, auditor Auditor) *TextObj {
+	return &TextObj{repo: repo, shares: shares, orgs: orgs, audit: auditor}
 }
 
-func (b *TextObj) GetText(ctx context.Context, textId int64) (*domain.Text, error) {
+// GetText returns a text of the user or one shared with the user
+func (b *TextObj) GetText(ctx context.Context, userId, textId int64) (*domain.Text, error) {
 	if textId <= 0 {
 		return nil, domain.ErrInvalidTextID
 	}
 
 	item, err := b.repo.GetByID(ctx, textId)
-	if err != nil {
+	if err != nil || item == nil {
 		return nil, domain.ErrTextNotFound
 	}
 
+	if err := b.authorize(ctx, userId, item, org.ActionRead); err != nil {
+		return nil, err
+	}
+
+	b.record(ctx, userId, item, audit.ActionItemRead)
+
 	return item, nil
 }
 
@@ -68,14 +103,28 @@ func (b *TextObj) CreateNewTextObj(ctx context.Context, text *domain.Text) (int6
 		return 0, domain.ErrEmptyText
 	}
 
+	if err := custom_field.Normalize(text.CustomFields); err != nil {
+		return 0, fmt.Errorf("%w: %w", domain.ErrInvalidCustomFields, err)
+	}
+
+	if text.OrgID != 0 {
+		if err := b.authorizeOrg(ctx, text.UserId, text.OrgID, org.ActionCreate); err != nil {
+			return 0, err
+		}
+	}
+
 	id, err := b.repo.Create(ctx, text)
 	if err != nil {
 		return 0, domain.ErrFailedCreateText
 	}
 
+	text.TextId = id
+	b.record(ctx, text.UserId, text, audit.ActionCreate)
+
 	return id, nil
 }
 
+// UpdateText saves the text for its owner or a user with a write share, text.UserId is the caller
 func (b *TextObj) UpdateText(ctx context.Context, text *domain.Text) error {
 	if text == nil {
 		return domain.ErrFailedUpdateText
@@ -93,9 +142,91 @@ func (b *TextObj) UpdateText(ctx context.Context, text *domain.Text) error {
 		return domain.ErrEmptyText
 	}
 
+	if err := custom_field.Normalize(text.CustomFields); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInvalidCustomFields, err)
+	}
+
+	current, err := b.repo.GetByID(ctx, text.TextId)
+	if err != nil || current == nil {
+		return domain.ErrTextNotFound
+	}
+
+	if err := b.authorize(ctx, text.UserId, current, org.ActionUpdate); err != nil {
+		return err
+	}
+
 	if err := b.repo.Update(ctx, text); err != nil {
 		return domain.ErrFailedUpdateText
 	}
 
+	b.record(ctx, text.UserId, current, audit.ActionUpdate)
+
+	return nil
+}
+
+// DeleteText removes the text for its owner or a member whose role allows deleting
+func (b *TextObj) DeleteText(ctx context.Context, userId, textId int64) error {
+	if textId <= 0 {
+		return domain.ErrInvalidTextID
+	}
+
+	current, err := b.repo.GetByID(ctx, textId)
+	if err != nil || current == nil {
+		return domain.ErrTextNotFound
+	}
+
+	if err := b.authorize(ctx, userId, current, org.ActionDelete); err != nil {
+		return err
+	}
+
+	if err := b.repo.Delete(ctx, textId); err != nil {
+		return domain.ErrFailedDeleteText
+	}
+
+	b.record(ctx, userId, current, audit.ActionDelete)
+
+	return nil
+}
+
+// help func
+
+// authorize checks items of organizations by the member role, personal items by ownership and shares,
+// texts without access are reported as not found
+func (b *TextObj) authorize(ctx context.Context, userId int64, text *domain.Text, action string) error {
+	shared, err := access.Check(ctx, b.orgs, b.shares, userId, access.Item{
+		OrgID:   text.OrgID,
+		OwnerID: text.UserId,
+		Share:   share.Item{Type: share.ItemText, ID: text.TextId},
+	}, action)
+	if err != nil {
+		if errors.Is(err, access.ErrNotFound) {
+			return domain.ErrTextNotFound
+		}
+		return err
+	}
+
+	text.Shared = shared
 	return nil
 }
+
+// authorizeOrg checks the role of the caller in the organization, without orgs nobody is a member
+func (b *TextObj) authorizeOrg(ctx context.Context, userId, orgId int64, action string) error {
+	if b.orgs == nil {
+		return org.ErrOrgNotFound
+	}
+	return b.orgs.Authorize(ctx, userId, orgId, action)
+}
+
+// record writes the action of the caller on the text into the audit log
+func (b *TextObj) record(ctx context.Context, userId int64, text *domain.Text, action string) {
+	if b.audit == nil {
+		return
+	}
+	b.audit.Record(ctx, audit.Event{
+		UserID:   userId,
+		OwnerID:  text.UserId,
+		Action:   action,
+		ItemType: audit.ItemText,
+		ItemID:   text.TextId,
+	})
+}
@@ -3,8 +3,12 @@ package text_obj
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 
+	"server/internal/app/domain/custom_field"
+	"server/internal/app/domain/org"
+	"server/internal/app/domain/share"
 	domain "server/internal/app/domain/text_obj"
 )
 
@@ -13,6 +17,7 @@ type repoFake struct {
 	getByID     func(ctx context.Context, textId int64) (*domain.Text, error)
 	create      func(ctx context.Context, text *domain.Text) (int64, error)
 	update      func(ctx context.Context, text *domain.Text) error
+	delete      func(ctx context.Context, textId int64) error
 }
 
 func (r *repoFake) GetByUserID(ctx context.Context, userId int64) ([]*domain.Text, error) {
@@ -25,7 +30,7 @@ func (r *repoFake) GetByID(ctx context.Context, textId int64) (*domain.Text, err
 	if r.getByID != nil {
 		return r.getByID(ctx, textId)
 	}
-	return nil, nil
+	return &domain.Text{TextId: textId, UserId: 1}, nil
 }
 func (r *repoFake) Create(ctx context.Context, text *domain.Text) (int64, error) {
 	if r.create != nil {
@@ -40,6 +45,13 @@ func (r *repoFake) Update(ctx context.Context, text *domain.Text) error {
 	return nil
 }
 
+func (r *repoFake) Delete(ctx context.Context, textId int64) error {
+	if r.delete != nil {
+		return r.delete(ctx, textId)
+	}
+	return nil
+}
+
 func TestTextObj_GetText(t *testing.T) {
 	t.Parallel()
 
@@ -48,8 +60,8 @@ func TestTextObj_GetText(t *testing.T) {
 	t.Run("invalid textId -> ErrInvalidTextID", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
-		_, err := uc.GetText(ctx, 0)
+		uc := New(&repoFake{}, nil, nil, nil)
+		_, err := uc.GetText(ctx, 1, 0)
 		if !errors.Is(err, domain.ErrInvalidTextID) {
 			t.Fatalf("expected ErrInvalidTextID, got: %v", err)
 		}
@@ -62,9 +74,9 @@ func TestTextObj_GetText(t *testing.T) {
 			getByID: func(ctx context.Context, textId int64) (*domain.Text, error) {
 				return nil, errors.New("db error")
 			},
-		})
+		}, nil, nil, nil)
 
-		_, err := uc.GetText(ctx, 1)
+		_, err := uc.GetText(ctx, 1, 1)
 		if !errors.Is(err, domain.ErrTextNotFound) {
 			t.Fatalf("expected ErrTextNotFound, got: %v", err)
 		}
@@ -79,9 +91,9 @@ func TestTextObj_GetText(t *testing.T) {
 			getByID: func(ctx context.Context, textId int64) (*domain.Text, error) {
 				return want, nil
 			},
-		})
+		}, nil, nil, nil)
 
-		got, err := uc.GetText(ctx, 7)
+		got, err := uc.GetText(ctx, 1, 7)
 		if err != nil {
 			t.Fatalf("expected nil err, got: %v", err)
 		}
@@ -99,7 +111,7 @@ func TestTextObj_GetTextList(t *testing.T) {
 	t.Run("invalid userId -> ErrInvalidUserID", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
+		uc := New(&repoFake{}, nil, nil, nil)
 		_, err := uc.GetTextList(ctx, 0)
 		if !errors.Is(err, domain.ErrInvalidUserID) {
 			t.Fatalf("expected ErrInvalidUserID, got: %v", err)
@@ -113,7 +125,7 @@ func TestTextObj_GetTextList(t *testing.T) {
 			getByUserID: func(ctx context.Context, userId int64) ([]*domain.Text, error) {
 				return nil, errors.New("db error")
 			},
-		})
+		}, nil, nil, nil)
 
 		_, err := uc.GetTextList(ctx, 1)
 		if !errors.Is(err, domain.ErrTextNotFound) {
@@ -128,7 +140,7 @@ func TestTextObj_GetTextList(t *testing.T) {
 			getByUserID: func(ctx context.Context, userId int64) ([]*domain.Text, error) {
 				return []*domain.Text{}, nil
 			},
-		})
+		}, nil, nil, nil)
 
 		_, err := uc.GetTextList(ctx, 1)
 		if !errors.Is(err, domain.ErrEmptyTextsList) {
@@ -148,7 +160,7 @@ func TestTextObj_GetTextList(t *testing.T) {
 			getByUserID: func(ctx context.Context, userId int64) ([]*domain.Text, error) {
 				return want, nil
 			},
-		})
+		}, nil, nil, nil)
 
 		got, err := uc.GetTextList(ctx, 1)
 		if err != nil {
@@ -171,7 +183,7 @@ func TestTextObj_CreateNewTextObj(t *testing.T) {
 	t.Run("nil text -> ErrFailedCreateText", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
+		uc := New(&repoFake{}, nil, nil, nil)
 		_, err := uc.CreateNewTextObj(ctx, nil)
 		if !errors.Is(err, domain.ErrFailedCreateText) {
 			t.Fatalf("expected ErrFailedCreateText, got: %v", err)
@@ -181,7 +193,7 @@ func TestTextObj_CreateNewTextObj(t *testing.T) {
 	t.Run("invalid userId -> ErrInvalidUserID", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
+		uc := New(&repoFake{}, nil, nil, nil)
 		_, err := uc.CreateNewTextObj(ctx, &domain.Text{UserId: 0, Title: "t", Text: "x"})
 		if !errors.Is(err, domain.ErrInvalidUserID) {
 			t.Fatalf("expected ErrInvalidUserID, got: %v", err)
@@ -191,7 +203,7 @@ func TestTextObj_CreateNewTextObj(t *testing.T) {
 	t.Run("empty title -> ErrEmptyTitle", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
+		uc := New(&repoFake{}, nil, nil, nil)
 		_, err := uc.CreateNewTextObj(ctx, &domain.Text{UserId: 1, Title: "", Text: "x"})
 		if !errors.Is(err, domain.ErrEmptyTitle) {
 			t.Fatalf("expected ErrEmptyTitle, got: %v", err)
@@ -201,7 +213,7 @@ func TestTextObj_CreateNewTextObj(t *testing.T) {
 	t.Run("empty text -> ErrEmptyText", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
+		uc := New(&repoFake{}, nil, nil, nil)
 		_, err := uc.CreateNewTextObj(ctx, &domain.Text{UserId: 1, Title: "t", Text: ""})
 		if !errors.Is(err, domain.ErrEmptyText) {
 			t.Fatalf("expected ErrEmptyText, got: %v", err)
@@ -215,7 +227,7 @@ func TestTextObj_CreateNewTextObj(t *testing.T) {
 			create: func(ctx context.Context, text *domain.Text) (int64, error) {
 				return 0, errors.New("insert failed")
 			},
-		})
+		}, nil, nil, nil)
 
 		_, err := uc.CreateNewTextObj(ctx, &domain.Text{UserId: 1, Title: "t", Text: "x"})
 		if !errors.Is(err, domain.ErrFailedCreateText) {
@@ -239,7 +251,7 @@ func TestTextObj_CreateNewTextObj(t *testing.T) {
 				}
 				return 55, nil
 			},
-		})
+		}, nil, nil, nil)
 
 		id, err := uc.CreateNewTextObj(ctx, &domain.Text{UserId: 1, Title: "hello", Text: "world"})
 		if err != nil {
@@ -259,7 +271,7 @@ func TestTextObj_UpdateText(t *testing.T) {
 	t.Run("nil text -> ErrFailedUpdateText", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
+		uc := New(&repoFake{}, nil, nil, nil)
 		err := uc.UpdateText(ctx, nil)
 		if !errors.Is(err, domain.ErrFailedUpdateText) {
 			t.Fatalf("expected ErrFailedUpdateText, got: %v", err)
@@ -269,7 +281,7 @@ func TestTextObj_UpdateText(t *testing.T) {
 	t.Run("invalid userId -> ErrInvalidUserID", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
+		uc := New(&repoFake{}, nil, nil, nil)
 		err := uc.UpdateText(ctx, &domain.Text{UserId: 0, Title: "t", Text: "x"})
 		if !errors.Is(err, domain.ErrInvalidUserID) {
 			t.Fatalf("expected ErrInvalidUserID, got: %v", err)
@@ -279,7 +291,7 @@ func TestTextObj_UpdateText(t *testing.T) {
 	t.Run("empty title -> ErrEmptyTitle", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
+		uc := New(&repoFake{}, nil, nil, nil)
 		err := uc.UpdateText(ctx, &domain.Text{UserId: 1, Title: "", Text: "x"})
 		if !errors.Is(err, domain.ErrEmptyTitle) {
 			t.Fatalf("expected ErrEmptyTitle, got: %v", err)
@@ -289,7 +301,7 @@ func TestTextObj_UpdateText(t *testing.T) {
 	t.Run("empty text -> ErrEmptyText", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
+		uc := New(&repoFake{}, nil, nil, nil)
 		err := uc.UpdateText(ctx, &domain.Text{UserId: 1, Title: "t", Text: ""})
 		if !errors.Is(err, domain.ErrEmptyText) {
 			t.Fatalf("expected ErrEmptyText, got: %v", err)
@@ -303,7 +315,7 @@ func TestTextObj_UpdateText(t *testing.T) {
 			update: func(ctx context.Context, text *domain.Text) error {
 				return errors.New("update failed")
 			},
-		})
+		}, nil, nil, nil)
 
 		err := uc.UpdateText(ctx, &domain.Text{UserId: 1, Title: "t", Text: "x"})
 		if !errors.Is(err, domain.ErrFailedUpdateText) {
@@ -330,7 +342,7 @@ func TestTextObj_UpdateText(t *testing.T) {
 				}
 				return nil
 			},
-		})
+		}, nil, nil, nil)
 
 		err := uc.UpdateText(ctx, &domain.Text{UserId: 1, Title: "title", Text: "body"})
 		if err != nil {
@@ -341,3 +353,178 @@ func TestTextObj_UpdateText(t *testing.T) {
 		}
 	})
 }
+
+func TestTextObj_CustomFields(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	invalid := []struct {
+		name    string
+		fields  []custom_field.Field
+		wantErr error
+	}{
+		{
+			name:    "empty name -> ErrInvalidFieldName",
+			fields:  []custom_field.Field{{Name: "  ", Value: "x"}},
+			wantErr: custom_field.ErrInvalidFieldName,
+		},
+		{
+			name:    "duplicate name -> ErrDuplicateFieldName",
+			fields:  []custom_field.Field{{Name: "pin", Value: "1"}, {Name: "pin", Value: "2"}},
+			wantErr: custom_field.ErrDuplicateFieldName,
+		},
+		{
+			name:    "unknown type -> ErrInvalidFieldKind",
+			fields:  []custom_field.Field{{Name: "pin", Kind: "secret", Value: "1"}},
+			wantErr: custom_field.ErrInvalidFieldKind,
+		},
+		{
+			name:    "relative url -> ErrInvalidFieldURL",
+			fields:  []custom_field.Field{{Name: "site", Kind: custom_field.KindURL, Value: "example.com"}},
+			wantErr: custom_field.ErrInvalidFieldURL,
+		},
+		{
+			name:    "too many fields -> ErrTooManyFields",
+			fields:  make([]custom_field.Field, custom_field.MaxFields+1),
+			wantErr: custom_field.ErrTooManyFields,
+		},
+	}
+
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc := New(&repoFake{
+				create: func(ctx context.Context, text *domain.Text) (int64, error) {
+					t.Fatal("repo must not be called")
+					return 0, nil
+				},
+			}, nil, nil, nil)
+
+			_, err := uc.CreateNewTextObj(ctx, &domain.Text{UserId: 1, Title: "t", Text: "body", CustomFields: tc.fields})
+			if !errors.Is(err, domain.ErrInvalidCustomFields) || !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected ErrInvalidCustomFields wrapping %v, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+
+	t.Run("valid fields -> normalized and kept in order", func(t *testing.T) {
+		t.Parallel()
+
+		var saved *domain.Text
+		uc := New(&repoFake{
+			update: func(ctx context.Context, text *domain.Text) error {
+				saved = text
+				return nil
+			},
+		}, nil, nil, nil)
+
+		err := uc.UpdateText(ctx, &domain.Text{TextId: 3, UserId: 1, Title: "t", Text: "body", CustomFields: []custom_field.Field{
+			{Name: " recovery email ", Value: "me@example.com"},
+			{Name: "answer", Kind: custom_field.KindHidden, Value: "rex"},
+			{Name: "site", Kind: custom_field.KindURL, Value: "https://example.com"},
+		}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := []custom_field.Field{
+			{Name: "recovery email", Kind: custom_field.KindText, Value: "me@example.com"},
+			{Name: "answer", Kind: custom_field.KindHidden, Value: "rex"},
+			{Name: "site", Kind: custom_field.KindURL, Value: "https://example.com"},
+		}
+		if !reflect.DeepEqual(saved.CustomFields, want) {
+			t.Fatalf("expected %+v, got %+v", want, saved.CustomFields)
+		}
+	})
+}
+
+// sharesFake grants access by grantee id, users without an entry get ErrShareNotFound
+type sharesFake map[int64]string
+
+func (s sharesFake) Access(ctx context.Context, userId int64, item share.Item) (*share.Access, error) {
+	perm, ok := s[userId]
+	if !ok {
+		return nil, share.ErrShareNotFound
+	}
+	return &share.Access{OwnerID: 1, OwnerName: "alice", Permission: perm}, nil
+}
+
+func TestTextObj_SharedAccess(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uc := New(&repoFake{}, sharesFake{2: share.PermRead}, nil, nil)
+
+	if _, err := uc.GetText(ctx, 4, 5); !errors.Is(err, domain.ErrTextNotFound) {
+		t.Fatalf("expected ErrTextNotFound, got: %v", err)
+	}
+
+	got, err := uc.GetText(ctx, 2, 5)
+	if err != nil || got.Shared == nil || got.Shared.OwnerName != "alice" {
+		t.Fatalf("unexpected result: %+v, %v", got, err)
+	}
+
+	err = uc.UpdateText(ctx, &domain.Text{TextId: 5, UserId: 2, Title: "t", Text: "x"})
+	if !errors.Is(err, share.ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly, got: %v", err)
+	}
+}
+
+// orgsFake holds the roles of members of every organization, other users are not members
+type orgsFake map[int64]string
+
+func (o orgsFake) Authorize(ctx context.Context, userId, orgId int64, action string) error {
+	role, ok := o[userId]
+	if !ok {
+		return org.ErrOrgNotFound
+	}
+	if !org.Allowed(role, action) {
+		return org.ErrForbidden
+	}
+	return nil
+}
+
+func TestTextObj_OrgAccess(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := &repoFake{getByID: func(ctx context.Context, textId int64) (*domain.Text, error) {
+		return &domain.Text{TextId: textId, UserId: 1, OrgID: 7}, nil
+	}}
+	uc := New(repo, nil, orgsFake{1: org.RoleOwner, 3: org.RoleEditor, 4: org.RoleViewer}, nil)
+
+	if _, err := uc.GetText(ctx, 9, 5); !errors.Is(err, domain.ErrTextNotFound) {
+		t.Fatalf("non-member: expected ErrTextNotFound, got: %v", err)
+	}
+
+	if _, err := uc.GetText(ctx, 4, 5); err != nil {
+		t.Fatalf("viewer read: unexpected error: %v", err)
+	}
+
+	if err := uc.UpdateText(ctx, &domain.Text{TextId: 5, UserId: 4, Title: "t", Text: "x"}); !errors.Is(err, org.ErrForbidden) {
+		t.Fatalf("viewer update: expected ErrForbidden, got: %v", err)
+	}
+
+	if err := uc.UpdateText(ctx, &domain.Text{TextId: 5, UserId: 3, Title: "t", Text: "x"}); err != nil {
+		t.Fatalf("editor update: unexpected error: %v", err)
+	}
+
+	if err := uc.DeleteText(ctx, 3, 5); !errors.Is(err, org.ErrForbidden) {
+		t.Fatalf("editor delete: expected ErrForbidden, got: %v", err)
+	}
+
+	if _, err := uc.CreateNewTextObj(ctx, &domain.Text{UserId: 4, OrgID: 7, Title: "t", Text: "x"}); !errors.Is(err, org.ErrForbidden) {
+		t.Fatalf("viewer create: expected ErrForbidden, got: %v", err)
+	}
+
+	var deleted int64
+	repo.delete = func(ctx context.Context, textId int64) error {
+		deleted = textId
+		return nil
+	}
+	if err := uc.DeleteText(ctx, 1, 5); err != nil || deleted != 5 {
+		t.Fatalf("owner delete: deleted=%d err=%v", deleted, err)
+	}
+}
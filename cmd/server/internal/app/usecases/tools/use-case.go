@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	domain "server/internal/app/domain/tools"
+	"server/internal/pkg/passgen"
+)
+
+type Tools struct{}
+
+func New() *Tools {
+	return &Tools{}
+}
+
+// GeneratePassword returns a random password or a diceware passphrase with its estimated entropy
+func (t *Tools) GeneratePassword(ctx context.Context, opts domain.PasswordOptions) (*domain.GeneratedPassword, error) {
+	switch opts.Mode {
+	case "", domain.PasswordModeRandom:
+		return generateRandom(opts)
+	case domain.PasswordModePassphrase:
+		return generatePassphrase(opts)
+	default:
+		return nil, domain.ErrUnknownPasswordMode
+	}
+}
+
+func generateRandom(opts domain.PasswordOptions) (*domain.GeneratedPassword, error) {
+	gen := passgen.Options{
+		Length:           opts.Length,
+		Lower:            opts.Lower,
+		Upper:            opts.Upper,
+		Digits:           opts.Digits,
+		Symbols:          opts.Symbols,
+		ExcludeAmbiguous: opts.ExcludeAmbiguous,
+	}
+
+	if gen.Length == 0 {
+		gen.Length = domain.DefaultPasswordLength
+	}
+	if !gen.Lower && !gen.Upper && !gen.Digits && !gen.Symbols {
+		gen.Lower, gen.Upper, gen.Digits, gen.Symbols = true, true, true, true
+	}
+
+	password, err := passgen.Generate(gen)
+	if err != nil {
+		return nil, mapGenError(err)
+	}
+
+	return &domain.GeneratedPassword{
+		Password: password,
+		Mode:     domain.PasswordModeRandom,
+		Entropy:  passgen.Entropy(gen),
+	}, nil
+}
+
+func generatePassphrase(opts domain.PasswordOptions) (*domain.GeneratedPassword, error) {
+	gen := passgen.PassphraseOptions{
+		Words:      opts.Words,
+		Separator:  opts.Separator,
+		Capitalize: opts.Capitalize,
+	}
+
+	if gen.Words == 0 {
+		gen.Words = domain.DefaultPassphraseWords
+	}
+
+	password, err := passgen.Passphrase(gen)
+	if err != nil {
+		return nil, mapGenError(err)
+	}
+
+	return &domain.GeneratedPassword{
+		Password: password,
+		Mode:     domain.PasswordModePassphrase,
+		Entropy:  passgen.PassphraseEntropy(gen.Words),
+	}, nil
+}
+
+// help func
+
+func mapGenError(err error) error {
+	switch {
+	case errors.Is(err, passgen.ErrInvalidLength),
+		errors.Is(err, passgen.ErrNoCharClasses),
+		errors.Is(err, passgen.ErrInvalidWordCount):
+		return fmt.Errorf("%w: %w", domain.ErrInvalidPasswordOptions, err)
+	default:
+		return fmt.Errorf("%w: %w", domain.ErrFailedGeneratePassword, err)
+	}
+}
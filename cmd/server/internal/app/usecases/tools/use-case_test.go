@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	domain "server/internal/app/domain/tools"
+)
+
+func TestTools_GeneratePassword(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("unknown mode -> ErrUnknownPasswordMode", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New().GeneratePassword(ctx, domain.PasswordOptions{Mode: "pin"})
+		if !errors.Is(err, domain.ErrUnknownPasswordMode) {
+			t.Fatalf("expected ErrUnknownPasswordMode, got: %v", err)
+		}
+	})
+
+	t.Run("invalid length -> ErrInvalidPasswordOptions", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New().GeneratePassword(ctx, domain.PasswordOptions{Length: 2})
+		if !errors.Is(err, domain.ErrInvalidPasswordOptions) {
+			t.Fatalf("expected ErrInvalidPasswordOptions, got: %v", err)
+		}
+	})
+
+	t.Run("invalid word count -> ErrInvalidPasswordOptions", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New().GeneratePassword(ctx, domain.PasswordOptions{Mode: domain.PasswordModePassphrase, Words: 100})
+		if !errors.Is(err, domain.ErrInvalidPasswordOptions) {
+			t.Fatalf("expected ErrInvalidPasswordOptions, got: %v", err)
+		}
+	})
+
+	t.Run("defaults -> 20 chars of every class", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := New().GeneratePassword(ctx, domain.PasswordOptions{})
+		if err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+		if got.Mode != domain.PasswordModeRandom || len(got.Password) != domain.DefaultPasswordLength {
+			t.Fatalf("unexpected password: %+v", got)
+		}
+		if got.Entropy < 120 {
+			t.Fatalf("expected at least 120 bits for 20 chars of 4 classes, got %v", got.Entropy)
+		}
+	})
+
+	t.Run("passphrase -> words joined with separator", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := New().GeneratePassword(ctx, domain.PasswordOptions{Mode: domain.PasswordModePassphrase, Words: 5, Separator: "."})
+		if err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
+		}
+		if n := len(strings.Split(got.Password, ".")); n != 5 {
+			t.Fatalf("expected 5 words, got %d in %q", n, got.Password)
+		}
+		if got.Entropy < 64 || got.Entropy > 65 {
+			t.Fatalf("expected ~64.6 bits, got %v", got.Entropy)
+		}
+	})
+}
@@ -0,0 +1,423 @@
+package two_factor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"server/internal/app/domain/audit"
+	domain "server/internal/app/domain/two_factor"
+	"server/internal/app/domain/user"
+	"server/internal/pkg/token"
+	"server/internal/pkg/totp"
+)
+
+const (
+	secretBytes = 20
+	// skew accepts the code of the previous and the next time step for clocks that drift
+	skew = 1
+
+	challengeBytes = 32
+	// recoveryCodeBytes give codes of 16 base32 characters, shown in groups of four
+	recoveryCodeBytes = 10
+	recoveryCodeLen   = 16
+)
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type Repository interface {
+	// Get returns the secret of the user or domain.ErrNotEnrolled
+	Get(ctx context.Context, userId int64) (*domain.TwoFactor, error)
+	// Save stores a pending secret in place of an older pending one, an enabled one is domain.ErrAlreadyEnabled
+	Save(ctx context.Context, userId int64, secret []byte) error
+	// Enable turns the pending secret on, keeps the counter of the confirming code and replaces the recovery codes
+	Enable(ctx context.Context, userId, counter int64, codeHashes []string) error
+	// Delete removes the secret and the recovery codes
+	Delete(ctx context.Context, userId int64) error
+	// UseCounter moves the last accepted time step forward, false if the step is not newer
+	UseCounter(ctx context.Context, userId, counter int64) (bool, error)
+	// UseAttempt counts a code of the user before it is checked, false while logins are locked.
+	// The attempt reaching maxFailures locks them until lockUntil
+	UseAttempt(ctx context.Context, userId int64, now time.Time, maxFailures int, lockUntil time.Time) (bool, error)
+	// ResetAttempts starts the count over after an accepted code
+	ResetAttempts(ctx context.Context, userId int64) error
+	// UseRecoveryCode spends an unused code, false if there is none with the hash
+	UseRecoveryCode(ctx context.Context, userId int64, codeHash string, now time.Time) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userId int64, codeHashes []string) error
+	RecoveryCodesLeft(ctx context.Context, userId int64) (int, error)
+
+	// CreateChallenge stores a challenge and removes the expired ones of every user
+	CreateChallenge(ctx context.Context, c *domain.Challenge, now time.Time) error
+	// UseChallenge counts an attempt against a live challenge, expired ones and ones
+	// out of attempts are domain.ErrChallengeNotFound
+	UseChallenge(ctx context.Context, tokenHash string, now time.Time, maxAttempts int) (*domain.Challenge, error)
+	DeleteChallenge(ctx context.Context, id int64) error
+}
+
+// UserFinder names the account in authenticator apps
+type UserFinder interface {
+	GetById(ctx context.Context, id int64) (*user.User, error)
+}
+
+// Auditor writes changes of the second factor into the audit log
+type Auditor interface {
+	Record(ctx context.Context, e audit.Event)
+}
+
+type TwoFactor struct {
+	repo  Repository
+	users UserFinder
+	opts  domain.Options
+	audit Auditor
+	now   func() time.Time
+}
+
+// New creates the use case, without auditor nothing is logged
+func New(repo Repository, users UserFinder, opts domain.Options, auditor Auditor) *TwoFactor {
+	return &TwoFactor{repo: repo, users: users, opts: opts, audit: auditor, now: time.Now}
+}
+
+// Setup generates a new pending secret, it does nothing until a code of it is confirmed by Enable
+func (f *TwoFactor) Setup(ctx context.Context, userId int64) (*domain.Enrollment, error) {
+	if userId <= 0 {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	current, err := f.repo.Get(ctx, userId)
+	if err != nil && !errors.Is(err, domain.ErrNotEnrolled) {
+		return nil, fmt.Errorf("failed to get two-factor: %w", err)
+	}
+	if current != nil && current.Enabled {
+		return nil, domain.ErrAlreadyEnabled
+	}
+
+	u, err := f.users.GetById(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id: %w", err)
+	}
+
+	secret, err := totp.GenerateSecret(secretBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.repo.Save(ctx, userId, secret); err != nil {
+		if errors.Is(err, domain.ErrAlreadyEnabled) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to save two-factor secret: %w", err)
+	}
+
+	key := f.key(secret)
+	key.Account = u.Username
+
+	return &domain.Enrollment{Secret: totp.EncodeSecret(secret), URI: key.URI()}, nil
+}
+
+// Enable turns the pending secret on with a code of it and returns the recovery codes,
+// they are shown once and only their hashes are kept
+func (f *TwoFactor) Enable(ctx context.Context, userId int64, code string) ([]string, error) {
+	tf, err := f.repo.Get(ctx, userId)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotEnrolled) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get two-factor: %w", err)
+	}
+	if tf.Enabled {
+		return nil, domain.ErrAlreadyEnabled
+	}
+
+	var counter uint64
+	err = f.guard(ctx, userId, func() error {
+		var ok bool
+		counter, ok = f.key(tf.Secret).Match(normalize(code), f.now(), skew)
+		if !ok {
+			return domain.ErrInvalidCode
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := f.recoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.repo.Enable(ctx, userId, int64(counter), hashes); err != nil {
+		return nil, fmt.Errorf("failed to enable two-factor: %w", err)
+	}
+
+	f.record(ctx, userId, audit.ActionTwoFactorEnable, "")
+	return codes, nil
+}
+
+// Disable turns the second factor off, a current or a recovery code is required so a stolen session can't do it
+func (f *TwoFactor) Disable(ctx context.Context, userId int64, code string) error {
+	tf, err := f.enabled(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if err := f.verify(ctx, tf, code); err != nil {
+		return err
+	}
+
+	if err := f.repo.Delete(ctx, userId); err != nil {
+		return fmt.Errorf("failed to disable two-factor: %w", err)
+	}
+
+	f.record(ctx, userId, audit.ActionTwoFactorDisable, "")
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces every recovery code of the user after checking a code
+func (f *TwoFactor) RegenerateRecoveryCodes(ctx context.Context, userId int64, code string) ([]string, error) {
+	tf, err := f.enabled(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.verify(ctx, tf, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := f.recoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.repo.ReplaceRecoveryCodes(ctx, userId, hashes); err != nil {
+		return nil, fmt.Errorf("failed to replace recovery codes: %w", err)
+	}
+
+	f.record(ctx, userId, audit.ActionRecoveryCodesRenew, "")
+	return codes, nil
+}
+
+// Status tells if the second factor is on and how many recovery codes are left
+func (f *TwoFactor) Status(ctx context.Context, userId int64) (*domain.Status, error) {
+	enabled, err := f.Enabled(ctx, userId)
+	if err != nil || !enabled {
+		return &domain.Status{}, err
+	}
+
+	left, err := f.repo.RecoveryCodesLeft(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	return &domain.Status{Enabled: true, RecoveryCodesLeft: left}, nil
+}
+
+// Enabled tells if a login of the user needs a code, a pending secret does not count
+func (f *TwoFactor) Enabled(ctx context.Context, userId int64) (bool, error) {
+	tf, err := f.repo.Get(ctx, userId)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotEnrolled) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get two-factor: %w", err)
+	}
+	return tf.Enabled, nil
+}
+
+// Challenge starts the second step of a login, the returned token is traded with a code in Resolve
+func (f *TwoFactor) Challenge(ctx context.Context, userId int64, device string) (string, time.Time, error) {
+	b := make([]byte, challengeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", time.Time{}, err
+	}
+	challenge := base64.RawURLEncoding.EncodeToString(b)
+
+	now := f.now()
+	c := &domain.Challenge{
+		UserID:     userId,
+		TokenHash:  token.HashVerifier(challenge),
+		DeviceName: device,
+		ExpiresAt:  now.Add(f.opts.ChallengeTTL),
+	}
+	if err := f.repo.CreateChallenge(ctx, c, now); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to create login challenge: %w", err)
+	}
+
+	return challenge, c.ExpiresAt, nil
+}
+
+// Resolve checks the code against the challenge and returns it for the session to be started,
+// a challenge is good for one login and a limited number of codes. Codes are counted for the user
+// too, a new challenge doesn't give more of them
+func (f *TwoFactor) Resolve(ctx context.Context, challenge, code string) (*domain.Challenge, error) {
+	if challenge == "" {
+		return nil, domain.ErrChallengeNotFound
+	}
+
+	c, err := f.repo.UseChallenge(ctx, token.HashVerifier(challenge), f.now(), f.opts.MaxAttempts)
+	if err != nil {
+		if errors.Is(err, domain.ErrChallengeNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to use login challenge: %w", err)
+	}
+
+	tf, err := f.enabled(ctx, c.UserID)
+	if err != nil {
+		// 2FA was turned off in between, the login starts over with the password alone
+		if errors.Is(err, domain.ErrNotEnrolled) {
+			return nil, domain.ErrChallengeNotFound
+		}
+		return nil, err
+	}
+
+	if err := f.verify(ctx, tf, code); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidCode):
+			f.record(ctx, c.UserID, audit.ActionLoginFailed, "two-factor code")
+		case errors.Is(err, domain.ErrLocked):
+			f.record(ctx, c.UserID, audit.ActionLoginFailed, "two-factor locked")
+		}
+		return nil, err
+	}
+
+	if err := f.repo.DeleteChallenge(ctx, c.ID); err != nil {
+		return nil, fmt.Errorf("failed to delete login challenge: %w", err)
+	}
+
+	return c, nil
+}
+
+// help func
+
+// enabled returns the secret of a user who turned the second factor on
+func (f *TwoFactor) enabled(ctx context.Context, userId int64) (*domain.TwoFactor, error) {
+	tf, err := f.repo.Get(ctx, userId)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotEnrolled) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get two-factor: %w", err)
+	}
+	if !tf.Enabled {
+		return nil, domain.ErrNotEnrolled
+	}
+	return tf, nil
+}
+
+// guard counts a code of the user against MaxFailures before check runs, while the user is locked
+// no code is checked at all. An accepted code starts the count over
+func (f *TwoFactor) guard(ctx context.Context, userId int64, check func() error) error {
+	now := f.now()
+
+	// the attempt is counted before the code is checked so parallel guesses can't exceed the limit
+	ok, err := f.repo.UseAttempt(ctx, userId, now, f.opts.MaxFailures, now.Add(f.opts.Lockout))
+	if err != nil {
+		return fmt.Errorf("failed to count two-factor attempt: %w", err)
+	}
+	if !ok {
+		return domain.ErrLocked
+	}
+
+	if err := check(); err != nil {
+		return err
+	}
+
+	if err := f.repo.ResetAttempts(ctx, userId); err != nil {
+		return fmt.Errorf("failed to reset two-factor attempts: %w", err)
+	}
+	return nil
+}
+
+// verify accepts a TOTP code once per time step or an unused recovery code, through the guard
+func (f *TwoFactor) verify(ctx context.Context, tf *domain.TwoFactor, code string) error {
+	return f.guard(ctx, tf.UserID, func() error {
+		return f.check(ctx, tf, normalize(code))
+	})
+}
+
+// check matches a normalized code, a TOTP step or a recovery code is spent by it
+func (f *TwoFactor) check(ctx context.Context, tf *domain.TwoFactor, code string) error {
+	if len(code) == recoveryCodeLen {
+		ok, err := f.repo.UseRecoveryCode(ctx, tf.UserID, token.HashVerifier(code), f.now())
+		if err != nil {
+			return fmt.Errorf("failed to use recovery code: %w", err)
+		}
+		if !ok {
+			return domain.ErrInvalidCode
+		}
+		f.record(ctx, tf.UserID, audit.ActionRecoveryCodeUse, "")
+		return nil
+	}
+
+	counter, ok := f.key(tf.Secret).Match(code, f.now(), skew)
+	if !ok || int64(counter) <= tf.LastCounter {
+		return domain.ErrInvalidCode
+	}
+
+	// a concurrent request may have taken the step in between
+	ok, err := f.repo.UseCounter(ctx, tf.UserID, int64(counter))
+	if err != nil {
+		return fmt.Errorf("failed to use totp code: %w", err)
+	}
+	if !ok {
+		return domain.ErrInvalidCode
+	}
+	return nil
+}
+
+func (f *TwoFactor) key(secret []byte) *totp.Key {
+	return &totp.Key{
+		Secret:    secret,
+		Algorithm: totp.AlgorithmSHA1,
+		Digits:    totp.DefaultDigits,
+		Period:    totp.DefaultPeriod,
+		Issuer:    f.opts.Issuer,
+	}
+}
+
+// recoveryCodes returns new codes in the form shown to the user and their hashes
+func (f *TwoFactor) recoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, f.opts.RecoveryCodes)
+	hashes := make([]string, 0, f.opts.RecoveryCodes)
+
+	for range f.opts.RecoveryCodes {
+		b := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(recoveryEncoding.EncodeToString(b))
+
+		codes = append(codes, group(code))
+		hashes = append(hashes, token.HashVerifier(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// group splits a recovery code into groups of four for reading
+func group(code string) string {
+	var parts []string
+	for len(code) > 4 {
+		parts = append(parts, code[:4])
+		code = code[4:]
+	}
+	return strings.Join(append(parts, code), "-")
+}
+
+// normalize drops what users type around a code, recovery codes are matched in lower case
+func normalize(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(code))
+}
+
+func (f *TwoFactor) record(ctx context.Context, userId int64, action, details string) {
+	if f.audit == nil {
+		return
+	}
+	f.audit.Record(ctx, audit.Event{UserID: userId, Action: action, Details: details})
+}
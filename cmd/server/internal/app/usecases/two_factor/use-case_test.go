@@ -0,0 +1,565 @@
+package two_factor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/app/config"
+	"server/internal/app/domain/audit"
+	domain "server/internal/app/domain/two_factor"
+	"server/internal/app/domain/user"
+	"server/internal/pkg/totp"
+)
+
+func init() {
+	config.InitTestConfig()
+}
+
+// repoFake keeps secrets, recovery codes and challenges in memory with the same rules as the database
+type repoFake struct {
+	factors    map[int64]*domain.TwoFactor
+	codes      map[int64]map[string]bool
+	challenges map[string]*domain.Challenge
+	nextID     int64
+	failures   map[int64]int
+	lockedTill map[int64]time.Time
+}
+
+func newRepoFake() *repoFake {
+	return &repoFake{
+		factors:    map[int64]*domain.TwoFactor{},
+		codes:      map[int64]map[string]bool{},
+		challenges: map[string]*domain.Challenge{},
+		failures:   map[int64]int{},
+		lockedTill: map[int64]time.Time{},
+	}
+}
+
+func (r *repoFake) Get(ctx context.Context, userId int64) (*domain.TwoFactor, error) {
+	tf, ok := r.factors[userId]
+	if !ok {
+		return nil, domain.ErrNotEnrolled
+	}
+	cp := *tf
+	return &cp, nil
+}
+
+func (r *repoFake) Save(ctx context.Context, userId int64, secret []byte) error {
+	if tf, ok := r.factors[userId]; ok && tf.Enabled {
+		return domain.ErrAlreadyEnabled
+	}
+	r.factors[userId] = &domain.TwoFactor{UserID: userId, Secret: secret}
+	return nil
+}
+
+func (r *repoFake) Enable(ctx context.Context, userId, counter int64, codeHashes []string) error {
+	tf := r.factors[userId]
+	tf.Enabled, tf.LastCounter = true, counter
+	return r.ReplaceRecoveryCodes(ctx, userId, codeHashes)
+}
+
+func (r *repoFake) Delete(ctx context.Context, userId int64) error {
+	delete(r.factors, userId)
+	delete(r.codes, userId)
+	return nil
+}
+
+func (r *repoFake) UseCounter(ctx context.Context, userId, counter int64) (bool, error) {
+	tf := r.factors[userId]
+	if counter <= tf.LastCounter {
+		return false, nil
+	}
+	tf.LastCounter = counter
+	return true, nil
+}
+
+func (r *repoFake) UseAttempt(ctx context.Context, userId int64, now time.Time, maxFailures int, lockUntil time.Time) (bool, error) {
+	if until, ok := r.lockedTill[userId]; ok {
+		if now.Before(until) {
+			return false, nil
+		}
+		delete(r.lockedTill, userId)
+		r.failures[userId] = 0
+	}
+	r.failures[userId]++
+	if r.failures[userId] >= maxFailures {
+		r.lockedTill[userId] = lockUntil
+	}
+	return true, nil
+}
+
+func (r *repoFake) ResetAttempts(ctx context.Context, userId int64) error {
+	delete(r.failures, userId)
+	delete(r.lockedTill, userId)
+	return nil
+}
+
+func (r *repoFake) UseRecoveryCode(ctx context.Context, userId int64, codeHash string, now time.Time) (bool, error) {
+	unused, ok := r.codes[userId][codeHash]
+	if !ok || !unused {
+		return false, nil
+	}
+	r.codes[userId][codeHash] = false
+	return true, nil
+}
+
+func (r *repoFake) ReplaceRecoveryCodes(ctx context.Context, userId int64, codeHashes []string) error {
+	r.codes[userId] = map[string]bool{}
+	for _, h := range codeHashes {
+		r.codes[userId][h] = true
+	}
+	return nil
+}
+
+func (r *repoFake) RecoveryCodesLeft(ctx context.Context, userId int64) (int, error) {
+	n := 0
+	for _, unused := range r.codes[userId] {
+		if unused {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *repoFake) CreateChallenge(ctx context.Context, c *domain.Challenge, now time.Time) error {
+	r.nextID++
+	cp := *c
+	cp.ID = r.nextID
+	r.challenges[c.TokenHash] = &cp
+	return nil
+}
+
+func (r *repoFake) UseChallenge(ctx context.Context, tokenHash string, now time.Time, maxAttempts int) (*domain.Challenge, error) {
+	c, ok := r.challenges[tokenHash]
+	if !ok || !now.Before(c.ExpiresAt) || c.Attempts >= maxAttempts {
+		return nil, domain.ErrChallengeNotFound
+	}
+	c.Attempts++
+	cp := *c
+	return &cp, nil
+}
+
+func (r *repoFake) DeleteChallenge(ctx context.Context, id int64) error {
+	for k, c := range r.challenges {
+		if c.ID == id {
+			delete(r.challenges, k)
+		}
+	}
+	return nil
+}
+
+type usersFake struct{}
+
+func (usersFake) GetById(ctx context.Context, id int64) (*user.User, error) {
+	return &user.User{ID: id, Username: "alice"}, nil
+}
+
+type auditFake struct {
+	actions []string
+}
+
+func (a *auditFake) Record(ctx context.Context, e audit.Event) {
+	a.actions = append(a.actions, e.Action)
+}
+
+var testOpts = domain.Options{
+	Issuer:        "Vault",
+	ChallengeTTL:  5 * time.Minute,
+	MaxAttempts:   3,
+	RecoveryCodes: 4,
+	MaxFailures:   5,
+	Lockout:       15 * time.Minute,
+}
+
+// newAt returns a use case whose clock is read from now
+func newAt(repo Repository, auditor Auditor, now *time.Time) *TwoFactor {
+	f := New(repo, usersFake{}, testOpts, auditor)
+	f.now = func() time.Time { return *now }
+	return f
+}
+
+// codeAt computes the code an authenticator app shows for the enrolled secret
+func codeAt(t *testing.T, repo *repoFake, userId int64, at time.Time) string {
+	t.Helper()
+
+	key := &totp.Key{Secret: repo.factors[userId].Secret}
+	code, err := key.Code(at)
+	if err != nil {
+		t.Fatalf("Code error: %v", err)
+	}
+	return code
+}
+
+// enroll sets up and enables 2FA for the user and returns the recovery codes
+func enroll(t *testing.T, f *TwoFactor, repo *repoFake, userId int64, at time.Time) []string {
+	t.Helper()
+
+	if _, err := f.Setup(context.Background(), userId); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+	codes, err := f.Enable(context.Background(), userId, codeAt(t, repo, userId, at))
+	if err != nil {
+		t.Fatalf("Enable error: %v", err)
+	}
+	return codes
+}
+
+// lockOut spends the failure budget of the user with wrong recovery codes
+func lockOut(t *testing.T, f *TwoFactor, userId int64) {
+	t.Helper()
+
+	for i := range testOpts.MaxFailures {
+		if err := f.Disable(context.Background(), userId, "aaaa-bbbb-cccc-dddd"); !errors.Is(err, domain.ErrInvalidCode) {
+			t.Fatalf("attempt %d: expected ErrInvalidCode, got %v", i, err)
+		}
+	}
+}
+
+func TestTwoFactor_Setup(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("returns otpauth uri of a pending secret", func(t *testing.T) {
+		repo := newRepoFake()
+		f := newAt(repo, nil, &now)
+
+		e, err := f.Setup(context.Background(), 7)
+		if err != nil {
+			t.Fatalf("Setup error: %v", err)
+		}
+
+		key, err := totp.Parse(e.URI)
+		if err != nil {
+			t.Fatalf("Parse uri error: %v", err)
+		}
+		if key.Issuer != "Vault" || key.Account != "alice" || totp.EncodeSecret(key.Secret) != e.Secret {
+			t.Fatalf("unexpected key: %+v", key)
+		}
+		if repo.factors[7].Enabled {
+			t.Fatalf("secret must stay pending until confirmed")
+		}
+	})
+
+	t.Run("enabled -> ErrAlreadyEnabled", func(t *testing.T) {
+		repo := newRepoFake()
+		f := newAt(repo, nil, &now)
+		enroll(t, f, repo, 7, now)
+
+		if _, err := f.Setup(context.Background(), 7); !errors.Is(err, domain.ErrAlreadyEnabled) {
+			t.Fatalf("expected ErrAlreadyEnabled, got %v", err)
+		}
+	})
+
+	t.Run("invalid user", func(t *testing.T) {
+		f := newAt(newRepoFake(), nil, &now)
+
+		if _, err := f.Setup(context.Background(), 0); !errors.Is(err, domain.ErrInvalidUserID) {
+			t.Fatalf("expected ErrInvalidUserID, got %v", err)
+		}
+	})
+}
+
+func TestTwoFactor_Enable(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("not set up -> ErrNotEnrolled", func(t *testing.T) {
+		f := newAt(newRepoFake(), nil, &now)
+
+		if _, err := f.Enable(context.Background(), 7, "123456"); !errors.Is(err, domain.ErrNotEnrolled) {
+			t.Fatalf("expected ErrNotEnrolled, got %v", err)
+		}
+	})
+
+	t.Run("wrong code -> ErrInvalidCode", func(t *testing.T) {
+		repo := newRepoFake()
+		f := newAt(repo, nil, &now)
+		if _, err := f.Setup(context.Background(), 7); err != nil {
+			t.Fatalf("Setup error: %v", err)
+		}
+
+		wrong := codeAt(t, repo, 7, now.Add(-time.Hour))
+		if _, err := f.Enable(context.Background(), 7, wrong); !errors.Is(err, domain.ErrInvalidCode) {
+			t.Fatalf("expected ErrInvalidCode, got %v", err)
+		}
+		if repo.factors[7].Enabled {
+			t.Fatalf("expected 2FA to stay off")
+		}
+	})
+
+	t.Run("locked -> ErrLocked even for the right code", func(t *testing.T) {
+		repo := newRepoFake()
+		f := newAt(repo, nil, &now)
+		if _, err := f.Setup(context.Background(), 7); err != nil {
+			t.Fatalf("Setup error: %v", err)
+		}
+
+		for range testOpts.MaxFailures {
+			_, _ = f.Enable(context.Background(), 7, "000000")
+		}
+		if _, err := f.Enable(context.Background(), 7, codeAt(t, repo, 7, now)); !errors.Is(err, domain.ErrLocked) {
+			t.Fatalf("expected ErrLocked, got %v", err)
+		}
+	})
+
+	t.Run("ok -> recovery codes stored hashed", func(t *testing.T) {
+		repo := newRepoFake()
+		auditor := &auditFake{}
+		f := newAt(repo, auditor, &now)
+
+		codes := enroll(t, f, repo, 7, now)
+
+		if len(codes) != testOpts.RecoveryCodes {
+			t.Fatalf("expected %d codes, got %d", testOpts.RecoveryCodes, len(codes))
+		}
+		for _, c := range codes {
+			if len(c) != 19 || strings.Count(c, "-") != 3 {
+				t.Fatalf("unexpected code format %q", c)
+			}
+			if _, ok := repo.codes[7][c]; ok {
+				t.Fatalf("recovery code stored in plain")
+			}
+		}
+		if !repo.factors[7].Enabled || repo.factors[7].LastCounter == 0 {
+			t.Fatalf("expected enabled with the counter of the code, got %+v", repo.factors[7])
+		}
+		if len(auditor.actions) != 1 || auditor.actions[0] != audit.ActionTwoFactorEnable {
+			t.Fatalf("unexpected audit: %v", auditor.actions)
+		}
+	})
+}
+
+func TestTwoFactor_ChallengeResolve(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("totp code resolves once", func(t *testing.T) {
+		repo := newRepoFake()
+		f := newAt(repo, nil, &now)
+		enroll(t, f, repo, 7, now)
+
+		at := now.Add(time.Minute)
+		f.now = func() time.Time { return at }
+
+		challenge, expiresAt, err := f.Challenge(context.Background(), 7, "laptop")
+		if err != nil {
+			t.Fatalf("Challenge error: %v", err)
+		}
+		if !expiresAt.Equal(at.Add(testOpts.ChallengeTTL)) {
+			t.Fatalf("unexpected expiry %v", expiresAt)
+		}
+
+		c, err := f.Resolve(context.Background(), challenge, codeAt(t, repo, 7, at))
+		if err != nil {
+			t.Fatalf("Resolve error: %v", err)
+		}
+		if c.UserID != 7 || c.DeviceName != "laptop" {
+			t.Fatalf("unexpected challenge: %+v", c)
+		}
+
+		if _, err := f.Resolve(context.Background(), challenge, codeAt(t, repo, 7, at)); !errors.Is(err, domain.ErrChallengeNotFound) {
+			t.Fatalf("expected ErrChallengeNotFound on second use, got %v", err)
+		}
+	})
+
+	t.Run("code of the enabling step can't be replayed", func(t *testing.T) {
+		repo := newRepoFake()
+		f := newAt(repo, nil, &now)
+		enroll(t, f, repo, 7, now)
+
+		challenge, _, _ := f.Challenge(context.Background(), 7, "")
+		if _, err := f.Resolve(context.Background(), challenge, codeAt(t, repo, 7, now)); !errors.Is(err, domain.ErrInvalidCode) {
+			t.Fatalf("expected ErrInvalidCode, got %v", err)
+		}
+	})
+
+	t.Run("recovery code works once", func(t *testing.T) {
+		repo := newRepoFake()
+		auditor := &auditFake{}
+		f := newAt(repo, auditor, &now)
+		codes := enroll(t, f, repo, 7, now)
+
+		challenge, _, _ := f.Challenge(context.Background(), 7, "")
+		if _, err := f.Resolve(context.Background(), challenge, strings.ToUpper(codes[0])); err != nil {
+			t.Fatalf("Resolve with recovery code error: %v", err)
+		}
+
+		challenge, _, _ = f.Challenge(context.Background(), 7, "")
+		if _, err := f.Resolve(context.Background(), challenge, codes[0]); !errors.Is(err, domain.ErrInvalidCode) {
+			t.Fatalf("expected spent code to fail, got %v", err)
+		}
+
+		status, err := f.Status(context.Background(), 7)
+		if err != nil || !status.Enabled || status.RecoveryCodesLeft != testOpts.RecoveryCodes-1 {
+			t.Fatalf("Status = %+v, %v", status, err)
+		}
+		if auditor.actions[1] != audit.ActionRecoveryCodeUse || auditor.actions[2] != audit.ActionLoginFailed {
+			t.Fatalf("unexpected audit: %v", auditor.actions)
+		}
+	})
+
+	t.Run("attempts are limited", func(t *testing.T) {
+		repo := newRepoFake()
+		f := newAt(repo, nil, &now)
+		enroll(t, f, repo, 7, now)
+
+		challenge, _, _ := f.Challenge(context.Background(), 7, "")
+		for range testOpts.MaxAttempts {
+			if _, err := f.Resolve(context.Background(), challenge, "000000"); !errors.Is(err, domain.ErrInvalidCode) {
+				t.Fatalf("expected ErrInvalidCode, got %v", err)
+			}
+		}
+
+		if _, err := f.Resolve(context.Background(), challenge, "000000"); !errors.Is(err, domain.ErrChallengeNotFound) {
+			t.Fatalf("expected ErrChallengeNotFound after the last attempt, got %v", err)
+		}
+	})
+
+	t.Run("new challenge doesn't reset the attempts of the user", func(t *testing.T) {
+		repo := newRepoFake()
+		at := now
+		f := newAt(repo, nil, &at)
+		enroll(t, f, repo, 7, now)
+		at = at.Add(time.Minute)
+
+		// every challenge is used up to its own limit, the user runs out first
+		for i := range testOpts.MaxFailures {
+			challenge, _, _ := f.Challenge(context.Background(), 7, "")
+			if _, err := f.Resolve(context.Background(), challenge, "000000"); !errors.Is(err, domain.ErrInvalidCode) {
+				t.Fatalf("attempt %d: expected ErrInvalidCode, got %v", i, err)
+			}
+		}
+
+		challenge, _, _ := f.Challenge(context.Background(), 7, "")
+		if _, err := f.Resolve(context.Background(), challenge, codeAt(t, repo, 7, at)); !errors.Is(err, domain.ErrLocked) {
+			t.Fatalf("expected ErrLocked with a fresh challenge, got %v", err)
+		}
+
+		// the lock passes, a right code resolves and starts the count over
+		at = at.Add(testOpts.Lockout)
+		challenge, _, _ = f.Challenge(context.Background(), 7, "")
+		if _, err := f.Resolve(context.Background(), challenge, codeAt(t, repo, 7, at)); err != nil {
+			t.Fatalf("expected the code to resolve after the lockout, got %v", err)
+		}
+		if repo.failures[7] != 0 {
+			t.Fatalf("expected attempts reset after an accepted code, got %d", repo.failures[7])
+		}
+	})
+
+	t.Run("expired challenge", func(t *testing.T) {
+		repo := newRepoFake()
+		at := now
+		f := newAt(repo, nil, &at)
+		enroll(t, f, repo, 7, now)
+
+		challenge, _, _ := f.Challenge(context.Background(), 7, "")
+		at = at.Add(testOpts.ChallengeTTL)
+
+		if _, err := f.Resolve(context.Background(), challenge, codeAt(t, repo, 7, at)); !errors.Is(err, domain.ErrChallengeNotFound) {
+			t.Fatalf("expected ErrChallengeNotFound, got %v", err)
+		}
+	})
+}
+
+func TestTwoFactor_Disable(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("not enabled -> ErrNotEnrolled", func(t *testing.T) {
+		f := newAt(newRepoFake(), nil, &now)
+
+		if err := f.Disable(context.Background(), 7, "123456"); !errors.Is(err, domain.ErrNotEnrolled) {
+			t.Fatalf("expected ErrNotEnrolled, got %v", err)
+		}
+	})
+
+	t.Run("wrong code keeps 2FA on", func(t *testing.T) {
+		repo := newRepoFake()
+		f := newAt(repo, nil, &now)
+		enroll(t, f, repo, 7, now)
+
+		if err := f.Disable(context.Background(), 7, "aaaa-bbbb-cccc-dddd"); !errors.Is(err, domain.ErrInvalidCode) {
+			t.Fatalf("expected ErrInvalidCode, got %v", err)
+		}
+		if enabled, _ := f.Enabled(context.Background(), 7); !enabled {
+			t.Fatalf("expected 2FA to stay on")
+		}
+	})
+
+	t.Run("failure limit reached -> ErrLocked, 2FA stays on", func(t *testing.T) {
+		repo := newRepoFake()
+		at := now
+		f := newAt(repo, nil, &at)
+		codes := enroll(t, f, repo, 7, now)
+		lockOut(t, f, 7)
+
+		at = at.Add(30 * time.Second)
+		if err := f.Disable(context.Background(), 7, codeAt(t, repo, 7, at)); !errors.Is(err, domain.ErrLocked) {
+			t.Fatalf("expected ErrLocked for a right TOTP code, got %v", err)
+		}
+		if err := f.Disable(context.Background(), 7, codes[0]); !errors.Is(err, domain.ErrLocked) {
+			t.Fatalf("expected ErrLocked for a right recovery code, got %v", err)
+		}
+		if enabled, _ := f.Enabled(context.Background(), 7); !enabled {
+			t.Fatalf("expected 2FA to stay on")
+		}
+		if left, _ := repo.RecoveryCodesLeft(context.Background(), 7); left != testOpts.RecoveryCodes {
+			t.Fatalf("expected no recovery code spent while locked, %d left", left)
+		}
+	})
+
+	t.Run("ok with a new code", func(t *testing.T) {
+		repo := newRepoFake()
+		f := newAt(repo, nil, &now)
+		enroll(t, f, repo, 7, now)
+
+		at := now.Add(30 * time.Second)
+		f.now = func() time.Time { return at }
+
+		if err := f.Disable(context.Background(), 7, codeAt(t, repo, 7, at)); err != nil {
+			t.Fatalf("Disable error: %v", err)
+		}
+		if enabled, _ := f.Enabled(context.Background(), 7); enabled {
+			t.Fatalf("expected 2FA to be off")
+		}
+		if len(repo.codes[7]) != 0 {
+			t.Fatalf("expected recovery codes to be removed")
+		}
+	})
+}
+
+func TestTwoFactor_RegenerateRecoveryCodes(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("ok -> old codes replaced", func(t *testing.T) {
+		repo := newRepoFake()
+		f := newAt(repo, nil, &now)
+		old := enroll(t, f, repo, 7, now)
+
+		codes, err := f.RegenerateRecoveryCodes(context.Background(), 7, old[0])
+		if err != nil {
+			t.Fatalf("RegenerateRecoveryCodes error: %v", err)
+		}
+		if len(codes) != testOpts.RecoveryCodes {
+			t.Fatalf("expected %d codes, got %d", testOpts.RecoveryCodes, len(codes))
+		}
+
+		challenge, _, _ := f.Challenge(context.Background(), 7, "")
+		if _, err := f.Resolve(context.Background(), challenge, old[1]); !errors.Is(err, domain.ErrInvalidCode) {
+			t.Fatalf("expected old codes to be replaced, got %v", err)
+		}
+	})
+
+	t.Run("failure limit reached -> ErrLocked, codes kept", func(t *testing.T) {
+		repo := newRepoFake()
+		f := newAt(repo, nil, &now)
+		old := enroll(t, f, repo, 7, now)
+		lockOut(t, f, 7)
+
+		if _, err := f.RegenerateRecoveryCodes(context.Background(), 7, old[0]); !errors.Is(err, domain.ErrLocked) {
+			t.Fatalf("expected ErrLocked, got %v", err)
+		}
+		if left, _ := repo.RecoveryCodesLeft(context.Background(), 7); left != testOpts.RecoveryCodes {
+			t.Fatalf("expected the old codes to stay, %d left", left)
+		}
+	})
+}
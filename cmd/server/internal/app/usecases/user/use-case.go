@@ -4,30 +4,85 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"server/internal/app/domain/audit"
+	file "server/internal/app/domain/file_obj"
+	twoFactor "server/internal/app/domain/two_factor"
 	domain "server/internal/app/domain/user"
 	hasher "server/internal/pkg/hash/argon2"
 	"server/internal/pkg/token"
+	"strings"
 	"time"
 )
 
 type Repository interface {
 	CreateNewUser(ctx context.Context, user *domain.User) (int64, error)
 	GetByUsername(ctx context.Context, username string) (*domain.User, error)
-	GetTokens(ctx context.Context, userId int64) (*token.Tokens, error)
-	AddTokens(ctx context.Context, userId int64, token *token.Tokens) error
-	UpdateTokens(ctx context.Context, userId int64, token *token.Tokens) error
+	GetById(ctx context.Context, id int64) (*domain.User, error)
+	// UpdatePassword stores the new hash, revokes every session of the user but the kept one
+	// and returns the ids of the revoked sessions
+	UpdatePassword(ctx context.Context, userId int64, passwordHash string, keepSessionId int64) ([]int64, error)
+	UpdateUsername(ctx context.Context, userId int64, username string) error
+	// Delete removes the user with all rows and queues removal of the stored file objects,
+	// the queued operations are returned
+	Delete(ctx context.Context, userId int64) ([]*file.Operation, error)
+	// GetTokens finds the session of a refresh token by the selector
+	GetTokens(ctx context.Context, selector string) (*token.Tokens, error)
+	// AddTokens starts a session with the hashed refresh token and returns the session id
+	AddTokens(ctx context.Context, session *domain.Session, token *token.Tokens) (int64, error)
+	// UpdateTokens rotates the refresh token of an active session and keeps the replaced one as used,
+	// with prevHash the session must still hold it or domain.ErrRefreshTokenReused is returned
+	UpdateTokens(ctx context.Context, sessionId int64, ip, prevHash string, token *token.Tokens) error
+	// RefreshTokenUsed tells if the verifier hash is of a token the session has rotated already
+	RefreshTokenUsed(ctx context.Context, sessionId int64, hash string) (bool, error)
+	// GetSessions returns the active sessions of the user, the last used first
+	GetSessions(ctx context.Context, userId int64) ([]*domain.Session, error)
+	RevokeSession(ctx context.Context, userId, sessionId int64) error
+	// RevokeSessions signs out every session of the user and returns how many were active
+	RevokeSessions(ctx context.Context, userId int64) (int64, error)
+}
+
+// FileRemover removes objects of deleted files from storage, objects it fails on are left to the outbox
+type FileRemover interface {
+	RemoveObjects(ctx context.Context, ops []*file.Operation)
+}
+
+// Auditor writes sign-ins into the audit log
+type Auditor interface {
+	Record(ctx context.Context, e audit.Event)
+}
+
+// Revoker keeps the access tokens taken out of use before they expire
+type Revoker interface {
+	Revoke(ctx context.Context, r *domain.Revocation) error
+	Revoked(ctx context.Context, jti string, userID, sessionID int64, issuedAt time.Time) bool
+}
+
+// SecondFactor asks users who turned 2FA on for a code after the password
+type SecondFactor interface {
+	Enabled(ctx context.Context, userId int64) (bool, error)
+	// Challenge starts the second step of a login and returns its token
+	Challenge(ctx context.Context, userId int64, device string) (string, time.Time, error)
+	// Resolve checks the code of a challenge and returns the challenge to start the session for
+	Resolve(ctx context.Context, challenge, code string) (*twoFactor.Challenge, error)
 }
 
 type User struct {
-	repo Repository
+	repo    Repository
+	files   FileRemover
+	audit   Auditor
+	revoker Revoker
+	factor  SecondFactor
 }
 
-func New(repo Repository) *User {
-	return &User{repo: repo}
+// New creates the use case, without files the objects of deleted accounts are removed by the outbox job,
+// without auditor nothing is logged, without revoker access tokens stay valid until they expire,
+// without factor the password alone signs in
+func New(repo Repository, files FileRemover, auditor Auditor, revoker Revoker, factor SecondFactor) *User {
+	return &User{repo: repo, files: files, audit: auditor, revoker: revoker, factor: factor}
 }
 
-// RegisterNewUser Creates new user, JWT and Refresh token
-func (u *User) RegisterNewUser(ctx context.Context, username, password string) (*token.Tokens, error) {
+// RegisterNewUser Creates new user, JWT and Refresh token, the device name labels the first session
+func (u *User) RegisterNewUser(ctx context.Context, username, password, device string) (*token.Tokens, error) {
 	// create new empty User object
 	user := domain.NewUser()
 
@@ -50,51 +105,158 @@ func (u *User) RegisterNewUser(ctx context.Context, username, password string) (
 	}
 
 	// create tokens
-	return u.createTokens(ctx, userID)
+	return u.createTokens(ctx, userID, device)
 }
 
-// Login compare hashed password from database with password from request, create tokens
-func (u *User) Login(ctx context.Context, username, password string) (*token.Tokens, error) {
+// Login compare hashed password from database with password from request, create tokens.
+// Every login starts a new session named after the device. Users with 2FA get only a challenge,
+// the session is started by LoginTwoFactor
+func (u *User) Login(ctx context.Context, username, password, device string) (*token.Tokens, error) {
 	user, err := u.repo.GetByUsername(ctx, username)
 	if err != nil {
 		if !errors.Is(err, domain.ErrUserNotFound) {
 			return nil, fmt.Errorf("failed to find user by username: %w", err)
 		}
+		u.record(ctx, 0, audit.ActionLoginFailed, username)
 		return nil, fmt.Errorf("got unexpected err: %w", err)
 	}
 	ok, err := hasher.VerifyString(password, user.Password)
 	if !ok {
+		u.record(ctx, user.ID, audit.ActionLoginFailed, username)
 		return nil, domain.ErrPasswordMismatch
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify password: %w", err)
 	}
 
-	return u.createTokens(ctx, user.ID)
+	if u.factor != nil {
+		enabled, err := u.factor.Enabled(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check two-factor: %w", err)
+		}
+		if enabled {
+			challenge, expiresAt, err := u.factor.Challenge(ctx, user.ID, device)
+			if err != nil {
+				return nil, err
+			}
+			return &token.Tokens{UserId: user.ID, Challenge: challenge, ChallengeExpAt: expiresAt}, nil
+		}
+	}
+
+	tokens, err := u.createTokens(ctx, user.ID, device)
+	if err != nil {
+		return nil, err
+	}
+
+	u.record(ctx, user.ID, audit.ActionLogin, "")
+	return tokens, nil
+}
+
+// LoginTwoFactor finishes a login with the challenge of Login and a TOTP or recovery code
+func (u *User) LoginTwoFactor(ctx context.Context, challenge, code string) (*token.Tokens, error) {
+	if u.factor == nil {
+		return nil, twoFactor.ErrChallengeNotFound
+	}
+
+	c, err := u.factor.Resolve(ctx, challenge, code)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := u.createTokens(ctx, c.UserID, c.DeviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	u.record(ctx, c.UserID, audit.ActionLogin, "two-factor")
+	return tokens, nil
 }
 
-// Authenticate verify JWT token and get UserID from it
-func (u *User) Authenticate(t string) (int64, error) {
+// Authenticate verify JWT token and get UserID and SessionID from it
+func (u *User) Authenticate(t string) (*token.Claims, error) {
 	jwt, err := token.VerifyJWT(t)
 	if err != nil {
-		return 0, domain.ErrTokenNotValid
+		return nil, domain.ErrTokenNotValid
+	}
+	return jwt, nil
+}
+
+// Revoked tells if the access token was revoked by a logout or with its session
+func (u *User) Revoked(ctx context.Context, claims *token.Claims) bool {
+	if u.revoker == nil {
+		return false
+	}
+
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+	return u.revoker.Revoked(ctx, claims.ID, claims.UserID, claims.SessionID, issuedAt)
+}
+
+// Logout signs the session of the token out and revokes the token itself
+func (u *User) Logout(ctx context.Context, claims *token.Claims) error {
+	// a session revoked from another device is signed out already, the token still has to go
+	err := u.repo.RevokeSession(ctx, claims.UserID, claims.SessionID)
+	if err != nil && !errors.Is(err, domain.ErrSessionNotFound) {
+		return fmt.Errorf("failed to revoke session: %w", err)
 	}
-	return jwt.UserID, nil
+
+	if u.revoker != nil && claims.ID != "" && claims.ExpiresAt != nil {
+		err := u.revoker.Revoke(ctx, &domain.Revocation{
+			JTI:       claims.ID,
+			UserID:    claims.UserID,
+			ExpiresAt: claims.ExpiresAt.Time,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to revoke token: %w", err)
+		}
+	}
+
+	u.record(ctx, claims.UserID, audit.ActionLogout, fmt.Sprintf("session %d", claims.SessionID))
+	return nil
 }
 
-// RefreshJWTToken refresh JWT token by refresh token from request, create tokens
-func (u *User) RefreshJWTToken(ctx context.Context, jwt, refreshToken string) (*token.Tokens, error) {
-	// get UserId from jwt payload
-	claim, err := token.VerifyJWT(jwt) // fixme
+// LogoutEverywhere signs out every session of the user and revokes all access tokens issued so far
+func (u *User) LogoutEverywhere(ctx context.Context, userId int64) error {
+	n, err := u.repo.RevokeSessions(ctx, userId)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to revoke sessions: %w", err)
 	}
 
-	// get tokens by UserID
-	tokens, err := u.repo.GetTokens(ctx, claim.UserID)
+	if u.revoker != nil {
+		// iat has a precision of a second, tokens issued within the current one are revoked too
+		now := time.Now()
+		err := u.revoker.Revoke(ctx, &domain.Revocation{
+			UserID:       userId,
+			IssuedBefore: now.Truncate(time.Second).Add(time.Second),
+			ExpiresAt:    now.Add(token.JWTLifetime()),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to revoke tokens: %w", err)
+		}
+	}
+
+	u.record(ctx, userId, audit.ActionLogoutAll, fmt.Sprintf("%d sessions", n))
+	return nil
+}
+
+// RefreshJWTToken issues a new pair of tokens for the refresh token alone, the JWT may have expired by then
+func (u *User) RefreshJWTToken(ctx context.Context, refreshToken string) (*token.Tokens, error) {
+	selector, verifier, ok := token.SplitRefreshToken(refreshToken)
+	if !ok {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	// get tokens of the session the selector names
+	tokens, err := u.repo.GetTokens(ctx, selector)
 	if err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenNotFound) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
 		return nil, err
 	}
+	userId, sessionId := tokens.UserId, tokens.SessionID
 
 	// check if tokens not revoked
 	if tokens.Revoked {
@@ -106,56 +268,242 @@ func (u *User) RefreshJWTToken(ctx context.Context, jwt, refreshToken string) (*
 	}
 
 	// verify hashed tokens
-	ok, err := hasher.VerifyString(refreshToken, tokens.RefreshToken)
+	if !token.VerifyVerifier(verifier, tokens.RefreshToken) {
+		if u.reused(ctx, sessionId, verifier) {
+			return nil, u.revokeFamily(ctx, userId, sessionId)
+		}
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	tokens, err = u.updateTokens(ctx, userId, sessionId, tokens.RefreshToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify refresh token: %w", err)
+		// the token was rotated by a concurrent refresh, one of the two is not the owner
+		if errors.Is(err, domain.ErrRefreshTokenReused) {
+			return nil, u.revokeFamily(ctx, userId, sessionId)
+		}
+		return nil, err
 	}
-	if !ok {
-		return nil, domain.ErrInvalidRefreshToken
+
+	u.record(ctx, userId, audit.ActionRefresh, "")
+	return tokens, nil
+}
+
+// reused tells if the verifier is one the session has rotated already
+func (u *User) reused(ctx context.Context, sessionId int64, verifier string) bool {
+	used, err := u.repo.RefreshTokenUsed(ctx, sessionId, token.HashVerifier(verifier))
+	return err == nil && used
+}
+
+// revokeFamily signs the session out after a replay of its refresh token, the thief and the owner
+// can't be told apart so both have to log in again
+func (u *User) revokeFamily(ctx context.Context, userId, sessionId int64) error {
+	err := u.repo.RevokeSession(ctx, userId, sessionId)
+	if err != nil && !errors.Is(err, domain.ErrSessionNotFound) {
+		return fmt.Errorf("failed to revoke session after refresh token reuse: %w", err)
 	}
-	return u.updateTokens(ctx, claim.UserID)
+	if err := u.revokeSessions(ctx, userId, sessionId); err != nil {
+		return err
+	}
+
+	u.record(ctx, userId, audit.ActionRefreshReuse, fmt.Sprintf("session %d", sessionId))
+	return domain.ErrRefreshTokenReused
 }
 
-func (u *User) createTokens(ctx context.Context, userID int64) (*token.Tokens, error) {
-	// create new empty Tokens
-	t := token.NewTokens(userID)
+// revokeSessions takes the access tokens of signed out sessions out of use, the sessions can't be
+// refreshed anymore so the tokens are dead once the lifetime of a token has passed
+func (u *User) revokeSessions(ctx context.Context, userId int64, sessionIds ...int64) error {
+	if u.revoker == nil {
+		return nil
+	}
 
-	// create jwt
-	jwt, err := token.CreateNewJWT(userID)
+	expiresAt := time.Now().Add(token.JWTLifetime())
+	for _, id := range sessionIds {
+		err := u.revoker.Revoke(ctx, &domain.Revocation{UserID: userId, SessionID: id, ExpiresAt: expiresAt})
+		if err != nil {
+			return fmt.Errorf("failed to revoke tokens of session %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// record writes a sign-in event, failed logins keep the username that was tried
+func (u *User) record(ctx context.Context, userID int64, action, details string) {
+	if u.audit == nil {
+		return
+	}
+	u.audit.Record(ctx, audit.Event{UserID: userID, Action: action, Details: details})
+}
+
+// ChangePassword replaces the password after checking the current one, every other session is signed out
+// and the caller gets a new pair of tokens for its own session
+func (u *User) ChangePassword(ctx context.Context, userId, sessionId int64, oldPassword, newPassword string) (*token.Tokens, error) {
+	if newPassword == "" {
+		return nil, domain.ErrEmptyPassword
+	}
+
+	if err := u.verifyPassword(ctx, userId, oldPassword); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := hasher.HashString(newPassword)
 	if err != nil {
 		return nil, err
 	}
-	t.AddJWTToken(jwt)
+
+	revoked, err := u.repo.UpdatePassword(ctx, userId, hashedPassword, sessionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update password: %w", err)
+	}
+	if err := u.revokeSessions(ctx, userId, revoked...); err != nil {
+		return nil, err
+	}
+
+	u.record(ctx, userId, audit.ActionPasswordChange, "")
+
+	return u.updateTokens(ctx, userId, sessionId, "")
+}
+
+// Sessions lists the signed-in devices of the user and marks the one of the request
+func (u *User) Sessions(ctx context.Context, userId, currentSessionId int64) ([]*domain.Session, error) {
+	list, err := u.repo.GetSessions(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	for _, s := range list {
+		s.Current = s.ID == currentSessionId
+	}
+	return list, nil
+}
+
+// RevokeSession signs a device of the user out, its refresh token and access tokens stop working at once
+func (u *User) RevokeSession(ctx context.Context, userId, sessionId int64) error {
+	if sessionId <= 0 {
+		return domain.ErrSessionNotFound
+	}
+
+	if err := u.repo.RevokeSession(ctx, userId, sessionId); err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	if err := u.revokeSessions(ctx, userId, sessionId); err != nil {
+		return err
+	}
+
+	u.record(ctx, userId, audit.ActionSessionRevoke, fmt.Sprintf("session %d", sessionId))
+	return nil
+}
+
+// ChangeUsername renames the user after checking the password
+func (u *User) ChangeUsername(ctx context.Context, userId int64, password, username string) error {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return domain.ErrEmptyUsername
+	}
+
+	if err := u.verifyPassword(ctx, userId, password); err != nil {
+		return err
+	}
+
+	if err := u.repo.UpdateUsername(ctx, userId, username); err != nil {
+		if errors.Is(err, domain.ErrUsernameAlreadyExists) || errors.Is(err, domain.ErrUserNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to update username: %w", err)
+	}
+
+	u.record(ctx, userId, audit.ActionUsernameChange, username)
+	return nil
+}
+
+// DeleteAccount removes the user after checking the password. The last owner of an organization
+// can't leave it, organization items and their files move to another owner, the rest of the rows go
+// with the user, stored files are queued for removal in the same transaction and removed right away when possible
+func (u *User) DeleteAccount(ctx context.Context, userId int64, password string) error {
+	if err := u.verifyPassword(ctx, userId, password); err != nil {
+		return err
+	}
+
+	ops, err := u.repo.Delete(ctx, userId)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrLastOrgOwner) {
+			return err
+		}
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	// the rows are gone already, the outbox removes what is left on failure
+	if u.files != nil && len(ops) > 0 {
+		u.files.RemoveObjects(context.WithoutCancel(ctx), ops)
+	}
+
+	u.record(ctx, userId, audit.ActionAccountDelete, fmt.Sprintf("%d files", len(ops)))
+	return nil
+}
+
+// verifyPassword checks the current password of the user before changes to the account
+func (u *User) verifyPassword(ctx context.Context, userId int64, password string) error {
+	user, err := u.repo.GetById(ctx, userId)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to find user by id: %w", err)
+	}
+
+	ok, err := hasher.VerifyString(password, user.Password)
+	if !ok {
+		return domain.ErrPasswordMismatch
+	}
+	if err != nil {
+		return fmt.Errorf("failed to verify password: %w", err)
+	}
+	return nil
+}
+
+func (u *User) createTokens(ctx context.Context, userID int64, device string) (*token.Tokens, error) {
+	// create new empty Tokens
+	t := token.NewTokens(userID)
 
 	// create refresh token
 	refreshToken := token.CreateRefreshToken()
-
-	// add hashed refresh token in database
 	t.AddRefreshToken(refreshToken)
 
 	// hash refresh token
-	hashedRefreshToken, err := hasher.HashString(refreshToken)
+	t.RefreshToken = token.HashVerifier(refreshToken)
+	t.Selector = token.CreateSelector()
+
+	// save hashed refresh token as a new session, the jwt carries its id
+	client := audit.ClientFrom(ctx)
+	session := domain.NewSession(userID, device, client.UserAgent, client.IP)
+
+	sessionID, err := u.repo.AddTokens(ctx, session, t)
 	if err != nil {
-		return nil, fmt.Errorf("failed to hash refresh token: %w", err)
+		return nil, fmt.Errorf("failed to add t: %w", err)
 	}
-	t.RefreshToken = hashedRefreshToken
+	t.SessionID = sessionID
 
-	// save t in database
-	err = u.repo.AddTokens(ctx, userID, t)
+	// create jwt
+	jwt, err := token.CreateNewJWT(userID, t.SessionID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to add t: %w", err)
+		return nil, err
 	}
+	t.AddJWTToken(jwt)
 
-	t.RefreshToken = refreshToken
+	t.RefreshToken = token.JoinRefreshToken(t.Selector, refreshToken)
 	return t, nil
 }
 
-func (u *User) updateTokens(ctx context.Context, userID int64) (*token.Tokens, error) {
+// updateTokens rotates the tokens of the session, prevHash is the refresh token hash the caller verified
+func (u *User) updateTokens(ctx context.Context, userID, sessionID int64, prevHash string) (*token.Tokens, error) {
 	// create new empty Tokens
 	tokens := token.NewTokens(userID)
+	tokens.SessionID = sessionID
 
 	// create jwt
-	jwt, err := token.CreateNewJWT(userID)
+	jwt, err := token.CreateNewJWT(userID, sessionID)
 	if err != nil {
 		return nil, err
 	}
@@ -168,18 +516,14 @@ func (u *User) updateTokens(ctx context.Context, userID int64) (*token.Tokens, e
 	tokens.AddRefreshToken(refreshToken)
 
 	// hash refresh token
-	hashedRefreshToken, err := hasher.HashString(refreshToken)
-	if err != nil {
-		return nil, fmt.Errorf("failed to hash refresh token: %w", err)
-	}
-	tokens.RefreshToken = hashedRefreshToken
+	tokens.RefreshToken = token.HashVerifier(refreshToken)
 
-	// save tokens in database
-	err = u.repo.UpdateTokens(ctx, userID, tokens)
+	// save tokens of the session in database, the selector of the session is kept
+	err = u.repo.UpdateTokens(ctx, sessionID, audit.ClientFrom(ctx).IP, prevHash, tokens)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update  tokens: %w", err)
 	}
 
-	tokens.RefreshToken = refreshToken
+	tokens.RefreshToken = token.JoinRefreshToken(tokens.Selector, refreshToken)
 	return tokens, nil
 }
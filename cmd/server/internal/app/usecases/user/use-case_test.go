@@ -4,12 +4,17 @@ import (
 	"context"
 	"errors"
 	"server/internal/app/config"
+	"server/internal/app/domain/audit"
+	file "server/internal/app/domain/file_obj"
+	twoFactor "server/internal/app/domain/two_factor"
 	domain "server/internal/app/domain/user"
 	hasher "server/internal/pkg/hash/argon2"
 	"server/internal/pkg/token"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/golang-jwt/jwt/v5"
 )
 
 func init() {
@@ -19,9 +24,18 @@ func init() {
 type repoFake struct {
 	createNewUser func(ctx context.Context, user *domain.User) (int64, error)
 	getByUsername func(ctx context.Context, username string) (*domain.User, error)
-	getTokens     func(ctx context.Context, userId int64) (*token.Tokens, error)
-	addTokens     func(ctx context.Context, userId int64, t *token.Tokens) error
-	updateTokens  func(ctx context.Context, userId int64, t *token.Tokens) error
+	getTokens     func(ctx context.Context, selector string) (*token.Tokens, error)
+	addTokens     func(ctx context.Context, session *domain.Session, t *token.Tokens) (int64, error)
+	updateTokens  func(ctx context.Context, sessionId int64, ip, prevHash string, t *token.Tokens) error
+	tokenUsed     func(ctx context.Context, sessionId int64, hash string) (bool, error)
+	getSessions   func(ctx context.Context, userId int64) ([]*domain.Session, error)
+	revokeSession func(ctx context.Context, userId, sessionId int64) error
+	revokeAll     func(ctx context.Context, userId int64) (int64, error)
+
+	getById        func(ctx context.Context, id int64) (*domain.User, error)
+	updatePassword func(ctx context.Context, userId int64, passwordHash string, keepSessionId int64) ([]int64, error)
+	updateUsername func(ctx context.Context, userId int64, username string) error
+	deleteUser     func(ctx context.Context, userId int64) ([]*file.Operation, error)
 }
 
 func (r *repoFake) CreateNewUser(ctx context.Context, user *domain.User) (int64, error) {
@@ -36,59 +50,126 @@ func (r *repoFake) GetByUsername(ctx context.Context, username string) (*domain.
 	}
 	return nil, nil
 }
-func (r *repoFake) GetTokens(ctx context.Context, userId int64) (*token.Tokens, error) {
+func (r *repoFake) GetTokens(ctx context.Context, selector string) (*token.Tokens, error) {
 	if r.getTokens != nil {
-		return r.getTokens(ctx, userId)
+		return r.getTokens(ctx, selector)
 	}
 	return nil, nil
 }
-func (r *repoFake) AddTokens(ctx context.Context, userId int64, t *token.Tokens) error {
+func (r *repoFake) AddTokens(ctx context.Context, session *domain.Session, t *token.Tokens) (int64, error) {
 	if r.addTokens != nil {
-		return r.addTokens(ctx, userId, t)
+		return r.addTokens(ctx, session, t)
 	}
-	return nil
+	return 1, nil
 }
-func (r *repoFake) UpdateTokens(ctx context.Context, userId int64, t *token.Tokens) error {
+func (r *repoFake) UpdateTokens(ctx context.Context, sessionId int64, ip, prevHash string, t *token.Tokens) error {
 	if r.updateTokens != nil {
-		return r.updateTokens(ctx, userId, t)
+		return r.updateTokens(ctx, sessionId, ip, prevHash, t)
+	}
+	return nil
+}
+func (r *repoFake) RefreshTokenUsed(ctx context.Context, sessionId int64, hash string) (bool, error) {
+	if r.tokenUsed != nil {
+		return r.tokenUsed(ctx, sessionId, hash)
+	}
+	return false, nil
+}
+func (r *repoFake) GetSessions(ctx context.Context, userId int64) ([]*domain.Session, error) {
+	if r.getSessions != nil {
+		return r.getSessions(ctx, userId)
+	}
+	return nil, nil
+}
+func (r *repoFake) RevokeSession(ctx context.Context, userId, sessionId int64) error {
+	if r.revokeSession != nil {
+		return r.revokeSession(ctx, userId, sessionId)
+	}
+	return nil
+}
+func (r *repoFake) RevokeSessions(ctx context.Context, userId int64) (int64, error) {
+	if r.revokeAll != nil {
+		return r.revokeAll(ctx, userId)
+	}
+	return 0, nil
+}
+func (r *repoFake) GetById(ctx context.Context, id int64) (*domain.User, error) {
+	if r.getById != nil {
+		return r.getById(ctx, id)
+	}
+	return nil, domain.ErrUserNotFound
+}
+func (r *repoFake) UpdatePassword(ctx context.Context, userId int64, passwordHash string, keepSessionId int64) ([]int64, error) {
+	if r.updatePassword != nil {
+		return r.updatePassword(ctx, userId, passwordHash, keepSessionId)
+	}
+	return nil, nil
+}
+func (r *repoFake) UpdateUsername(ctx context.Context, userId int64, username string) error {
+	if r.updateUsername != nil {
+		return r.updateUsername(ctx, userId, username)
 	}
 	return nil
 }
+func (r *repoFake) Delete(ctx context.Context, userId int64) ([]*file.Operation, error) {
+	if r.deleteUser != nil {
+		return r.deleteUser(ctx, userId)
+	}
+	return nil, nil
+}
+
+type filesFake struct {
+	removed []*file.Operation
+}
+
+func (f *filesFake) RemoveObjects(ctx context.Context, ops []*file.Operation) {
+	f.removed = append(f.removed, ops...)
+}
 
 func TestUser_RefreshJWTToken(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
 
-	t.Run("invalid jwt -> returns verify error (not mapped)", func(t *testing.T) {
+	t.Run("malformed refresh token -> ErrInvalidRefreshToken", func(t *testing.T) {
 		t.Parallel()
 
-		uc := New(&repoFake{})
-		_, err := uc.RefreshJWTToken(ctx, "bad-jwt", "refresh")
-		if err == nil {
-			t.Fatalf("expected error, got nil")
+		uc := New(&repoFake{
+			getTokens: func(ctx context.Context, selector string) (*token.Tokens, error) {
+				panic("a token without selector must not be looked up")
+			},
+		}, nil, nil, nil, nil)
+		_, err := uc.RefreshJWTToken(ctx, "no-selector")
+		if !errors.Is(err, domain.ErrInvalidRefreshToken) {
+			t.Fatalf("expected ErrInvalidRefreshToken, got: %v", err)
 		}
 	})
 
-	t.Run("repo.GetTokens error -> returned as-is", func(t *testing.T) {
+	t.Run("unknown selector -> ErrInvalidRefreshToken", func(t *testing.T) {
 		t.Parallel()
 
-		jwt, err := token.CreateNewJWT(1)
-		if err != nil {
-			t.Fatalf("CreateNewJWT error: %v", err)
+		uc := New(&repoFake{
+			getTokens: func(ctx context.Context, selector string) (*token.Tokens, error) {
+				return nil, domain.ErrRefreshTokenNotFound
+			},
+		}, nil, nil, nil, nil)
+
+		_, err := uc.RefreshJWTToken(ctx, "sel.refresh")
+		if !errors.Is(err, domain.ErrInvalidRefreshToken) {
+			t.Fatalf("expected ErrInvalidRefreshToken, got: %v", err)
 		}
+	})
+
+	t.Run("repo.GetTokens error -> returned as-is", func(t *testing.T) {
+		t.Parallel()
 
 		dbErr := errors.New("db down")
 		uc := New(&repoFake{
-			getTokens: func(ctx context.Context, userId int64) (*token.Tokens, error) {
+			getTokens: func(ctx context.Context, selector string) (*token.Tokens, error) {
 				return nil, dbErr
 			},
-		})
+		}, nil, nil, nil, nil)
 
-		_, err = uc.RefreshJWTToken(ctx, jwt, "refresh")
-		if err == nil {
-			t.Fatalf("expected error, got nil")
-		}
+		_, err := uc.RefreshJWTToken(ctx, "sel.refresh")
 		if !errors.Is(err, dbErr) {
 			t.Fatalf("expected wrapped/returned dbErr, got: %v", err)
 		}
@@ -97,23 +178,19 @@ func TestUser_RefreshJWTToken(t *testing.T) {
 	t.Run("tokens revoked -> ErrTokenRevoked", func(t *testing.T) {
 		t.Parallel()
 
-		jwt, err := token.CreateNewJWT(10)
-		if err != nil {
-			t.Fatalf("CreateNewJWT error: %v", err)
-		}
-
 		uc := New(&repoFake{
-			getTokens: func(ctx context.Context, userId int64) (*token.Tokens, error) {
+			getTokens: func(ctx context.Context, selector string) (*token.Tokens, error) {
 				return &token.Tokens{
-					UserId:            userId,
+					UserId:            10,
+					SessionID:         20,
 					Revoked:           true,
 					RefreshTokenExpAt: time.Now().Add(10 * time.Minute),
 					RefreshToken:      "hash-doesnt-matter",
 				}, nil
 			},
-		})
+		}, nil, nil, nil, nil)
 
-		_, err = uc.RefreshJWTToken(ctx, jwt, "any")
+		_, err := uc.RefreshJWTToken(ctx, "sel.any")
 		if !errors.Is(err, domain.ErrTokenRevoked) {
 			t.Fatalf("expected ErrTokenRevoked, got: %v", err)
 		}
@@ -122,148 +199,895 @@ func TestUser_RefreshJWTToken(t *testing.T) {
 	t.Run("refresh expired -> ErrRefreshTokenExpired", func(t *testing.T) {
 		t.Parallel()
 
-		jwt, err := token.CreateNewJWT(11)
-		if err != nil {
-			t.Fatalf("CreateNewJWT error: %v", err)
-		}
-
 		uc := New(&repoFake{
-			getTokens: func(ctx context.Context, userId int64) (*token.Tokens, error) {
+			getTokens: func(ctx context.Context, selector string) (*token.Tokens, error) {
 				return &token.Tokens{
-					UserId:            userId,
-					Revoked:           false,
+					UserId:            11,
+					SessionID:         20,
 					RefreshTokenExpAt: time.Now().Add(-1 * time.Minute),
 					RefreshToken:      "hash-doesnt-matter",
 				}, nil
 			},
-		})
+		}, nil, nil, nil, nil)
 
-		_, err = uc.RefreshJWTToken(ctx, jwt, "any")
+		_, err := uc.RefreshJWTToken(ctx, "sel.any")
 		if !errors.Is(err, domain.ErrRefreshTokenExpired) {
 			t.Fatalf("expected ErrRefreshTokenExpired, got: %v", err)
 		}
 	})
 
-	t.Run("invalid refresh token -> ErrInvalidRefreshToken", func(t *testing.T) {
+	t.Run("invalid verifier -> ErrInvalidRefreshToken", func(t *testing.T) {
 		t.Parallel()
 
-		jwt, err := token.CreateNewJWT(12)
-		if err != nil {
-			t.Fatalf("CreateNewJWT error: %v", err)
-		}
-
-		hashed, err := hasher.HashString("right-refresh")
-		if err != nil {
-			t.Fatalf("HashString error: %v", err)
-		}
+		hashed := token.HashVerifier("right-refresh")
 
 		uc := New(&repoFake{
-			getTokens: func(ctx context.Context, userId int64) (*token.Tokens, error) {
+			getTokens: func(ctx context.Context, selector string) (*token.Tokens, error) {
 				return &token.Tokens{
-					UserId:            userId,
-					Revoked:           false,
+					UserId:            12,
+					SessionID:         20,
 					RefreshTokenExpAt: time.Now().Add(10 * time.Minute),
 					RefreshToken:      hashed,
 				}, nil
 			},
-		})
+		}, nil, nil, nil, nil)
 
-		_, err = uc.RefreshJWTToken(ctx, jwt, "wrong-refresh")
+		_, err := uc.RefreshJWTToken(ctx, "sel.wrong-refresh")
 		if !errors.Is(err, domain.ErrInvalidRefreshToken) {
 			t.Fatalf("expected ErrInvalidRefreshToken, got: %v", err)
 		}
 	})
 
-	t.Run("verify refresh returns error -> wrapped", func(t *testing.T) {
+	t.Run("ok -> session of the selector rotated, returns new tokens", func(t *testing.T) {
 		t.Parallel()
 
-		jwt, err := token.CreateNewJWT(13)
-		if err != nil {
-			t.Fatalf("CreateNewJWT error: %v", err)
-		}
+		userId := int64(1001)
+		sessionId := int64(31)
 
-		badHash := "not-a-valid-hash-format"
+		hashed := token.HashVerifier("refresh-plain")
+
+		updateCalled := false
+		var stored string
 
 		uc := New(&repoFake{
-			getTokens: func(ctx context.Context, userId int64) (*token.Tokens, error) {
+			getTokens: func(ctx context.Context, selector string) (*token.Tokens, error) {
+				if selector != "sel" {
+					t.Fatalf("expected selector=sel, got %q", selector)
+				}
 				return &token.Tokens{
 					UserId:            userId,
-					Revoked:           false,
+					SessionID:         sessionId,
+					Selector:          selector,
 					RefreshTokenExpAt: time.Now().Add(10 * time.Minute),
-					RefreshToken:      badHash,
+					RefreshToken:      hashed,
 				}, nil
 			},
-		})
+			updateTokens: func(ctx context.Context, sid int64, ip, prevHash string, tks *token.Tokens) error {
+				updateCalled = true
+				if sid != sessionId {
+					t.Fatalf("expected sid=%d, got %d", sessionId, sid)
+				}
+				if prevHash != hashed {
+					t.Fatalf("expected the verified hash to be rotated, got %q", prevHash)
+				}
+				if ip != "10.0.0.1" {
+					t.Fatalf("expected client ip, got %q", ip)
+				}
+				if tks.RefreshToken == "" || tks.RefreshToken == "refresh-plain" {
+					t.Fatalf("expected hashed refresh in repo, got %q", tks.RefreshToken)
+				}
+				if tks.JWTToken == "" {
+					t.Fatalf("expected jwt set")
+				}
+				tks.Selector = "sel"
+				stored = tks.RefreshToken
+				return nil
+			},
+		}, nil, nil, nil, nil)
 
-		_, err = uc.RefreshJWTToken(ctx, jwt, "any-refresh")
-		if err == nil {
-			t.Fatalf("expected error, got nil")
+		newTokens, err := uc.RefreshJWTToken(audit.WithClient(ctx, audit.Client{IP: "10.0.0.1"}), "sel.refresh-plain")
+		if err != nil {
+			t.Fatalf("expected nil err, got: %v", err)
 		}
-		if !strings.Contains(err.Error(), "failed to verify refresh token") {
-			t.Fatalf("expected wrapped verify error, got: %v", err)
+		if !updateCalled {
+			t.Fatalf("expected UpdateTokens to be called")
+		}
+
+		selector, verifier, ok := token.SplitRefreshToken(newTokens.RefreshToken)
+		if !ok || selector != "sel" || verifier == "refresh-plain" {
+			t.Fatalf("expected a new verifier of the same selector, got %q", newTokens.RefreshToken)
+		}
+		if !token.VerifyVerifier(verifier, stored) {
+			t.Fatalf("expected the stored hash to match the new verifier")
+		}
+
+		claims, err := token.VerifyJWT(newTokens.JWTToken)
+		if err != nil || claims.SessionID != sessionId || claims.UserID != userId {
+			t.Fatalf("expected new jwt of session %d, got %+v err=%v", sessionId, claims, err)
 		}
 	})
+}
+
+func TestUser_RefreshReuse(t *testing.T) {
+	t.Parallel()
 
-	t.Run("ok -> UpdateTokens called, returns new tokens", func(t *testing.T) {
+	ctx := context.Background()
+
+	current := token.HashVerifier("refresh-2")
+	used := token.HashVerifier("refresh-1")
+
+	session := func(userId int64) func(ctx context.Context, selector string) (*token.Tokens, error) {
+		return func(ctx context.Context, selector string) (*token.Tokens, error) {
+			return &token.Tokens{
+				UserId:            userId,
+				SessionID:         31,
+				Selector:          selector,
+				RefreshTokenExpAt: time.Now().Add(10 * time.Minute),
+				RefreshToken:      current,
+			}, nil
+		}
+	}
+
+	t.Run("rotated token replayed -> session revoked and event recorded", func(t *testing.T) {
 		t.Parallel()
 
-		userId := int64(1001)
+		var revokedUser, revokedSession int64
+		log := &auditFake{}
+		revoker := &revokerFake{}
+		uc := New(&repoFake{
+			getTokens: session(21),
+			tokenUsed: func(ctx context.Context, sessionId int64, hash string) (bool, error) {
+				if sessionId != 31 {
+					t.Fatalf("unexpected session: %d", sessionId)
+				}
+				return hash == used, nil
+			},
+			revokeSession: func(ctx context.Context, userId, sessionId int64) error {
+				revokedUser, revokedSession = userId, sessionId
+				return nil
+			},
+			updateTokens: func(ctx context.Context, sessionId int64, ip, prevHash string, t *token.Tokens) error {
+				panic("a replayed token must not be rotated")
+			},
+		}, nil, log, revoker, nil)
 
-		jwt, err := token.CreateNewJWT(userId)
-		if err != nil {
-			t.Fatalf("CreateNewJWT error: %v", err)
+		_, err := uc.RefreshJWTToken(ctx, "sel.refresh-1")
+		if !errors.Is(err, domain.ErrRefreshTokenReused) {
+			t.Fatalf("expected ErrRefreshTokenReused, got: %v", err)
+		}
+		if revokedUser != 21 || revokedSession != 31 {
+			t.Fatalf("expected session 31 of user 21 revoked, got user=%d session=%d", revokedUser, revokedSession)
+		}
+		if !uc.Revoked(ctx, &token.Claims{UserID: 21, SessionID: 31}) {
+			t.Fatal("expected access tokens of the session to be revoked")
+		}
+		if len(log.events) != 1 || log.events[0].Action != audit.ActionRefreshReuse || log.events[0].UserID != 21 {
+			t.Fatalf("unexpected audit events: %+v", log.events)
 		}
+	})
+
+	t.Run("unknown token -> ErrInvalidRefreshToken, session kept", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			getTokens: session(22),
+			tokenUsed: func(ctx context.Context, sessionId int64, hash string) (bool, error) {
+				return hash == used, nil
+			},
+			revokeSession: func(ctx context.Context, userId, sessionId int64) error {
+				panic("a wrong token must not revoke the session")
+			},
+		}, nil, nil, nil, nil)
+
+		_, err := uc.RefreshJWTToken(ctx, "sel.guess")
+		if !errors.Is(err, domain.ErrInvalidRefreshToken) {
+			t.Fatalf("expected ErrInvalidRefreshToken, got: %v", err)
+		}
+	})
+
+	t.Run("concurrent rotation -> session revoked", func(t *testing.T) {
+		t.Parallel()
+
+		revoked := false
+		log := &auditFake{}
+		uc := New(&repoFake{
+			getTokens: session(23),
+			updateTokens: func(ctx context.Context, sessionId int64, ip, prevHash string, t *token.Tokens) error {
+				return domain.ErrRefreshTokenReused
+			},
+			revokeSession: func(ctx context.Context, userId, sessionId int64) error {
+				revoked = true
+				return nil
+			},
+		}, nil, log, nil, nil)
+
+		_, err := uc.RefreshJWTToken(ctx, "sel.refresh-2")
+		if !errors.Is(err, domain.ErrRefreshTokenReused) {
+			t.Fatalf("expected ErrRefreshTokenReused, got: %v", err)
+		}
+		if !revoked || len(log.events) != 1 || log.events[0].Action != audit.ActionRefreshReuse {
+			t.Fatalf("expected the session revoked and logged, revoked=%v events=%+v", revoked, log.events)
+		}
+	})
+}
+
+type auditFake struct {
+	events []audit.Event
+}
+
+func (f *auditFake) Record(ctx context.Context, e audit.Event) {
+	f.events = append(f.events, e)
+}
+
+func TestUser_LoginAudit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	hashed, err := hasher.HashString("secret")
+	if err != nil {
+		t.Fatalf("HashString error: %v", err)
+	}
+
+	repo := &repoFake{
+		getByUsername: func(ctx context.Context, username string) (*domain.User, error) {
+			if username != "alice" {
+				return nil, domain.ErrUserNotFound
+			}
+			return &domain.User{ID: 7, Username: "alice", Password: hashed}, nil
+		},
+	}
+
+	log := &auditFake{}
+	uc := New(repo, nil, log, nil, nil)
+
+	if _, err := uc.Login(ctx, "bob", "secret", ""); err == nil {
+		t.Fatalf("unknown user: expected error")
+	}
+	if _, err := uc.Login(ctx, "alice", "wrong", ""); !errors.Is(err, domain.ErrPasswordMismatch) {
+		t.Fatalf("wrong password: expected ErrPasswordMismatch, got: %v", err)
+	}
+	if _, err := uc.Login(ctx, "alice", "secret", ""); err != nil {
+		t.Fatalf("login: unexpected error: %v", err)
+	}
+
+	want := []audit.Event{
+		{UserID: 0, Action: audit.ActionLoginFailed, Details: "bob"},
+		{UserID: 7, Action: audit.ActionLoginFailed, Details: "alice"},
+		{UserID: 7, Action: audit.ActionLogin},
+	}
+	if len(log.events) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), log.events)
+	}
+	for i := range want {
+		got := log.events[i]
+		if got.UserID != want[i].UserID || got.Action != want[i].Action || got.Details != want[i].Details {
+			t.Fatalf("event %d: expected %+v, got %+v", i, want[i], got)
+		}
+	}
+}
+
+// withPassword is a user with id 7 whose password is "secret"
+func withPassword(t *testing.T) func(ctx context.Context, id int64) (*domain.User, error) {
+	t.Helper()
+
+	hashed, err := hasher.HashString("secret")
+	if err != nil {
+		t.Fatalf("HashString error: %v", err)
+	}
+	return func(ctx context.Context, id int64) (*domain.User, error) {
+		if id != 7 {
+			return nil, domain.ErrUserNotFound
+		}
+		return &domain.User{ID: 7, Username: "alice", Password: hashed}, nil
+	}
+}
+
+func TestUser_ChangePassword(t *testing.T) {
+	t.Parallel()
 
-		hashed, err := hasher.HashString("refresh-plain")
+	ctx := context.Background()
+
+	t.Run("empty new password -> ErrEmptyPassword", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{getById: withPassword(t)}, nil, nil, nil, nil)
+		if _, err := uc.ChangePassword(ctx, 7, 31, "secret", ""); !errors.Is(err, domain.ErrEmptyPassword) {
+			t.Fatalf("expected ErrEmptyPassword, got: %v", err)
+		}
+	})
+
+	t.Run("wrong old password -> ErrPasswordMismatch, nothing stored", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			getById: withPassword(t),
+			updatePassword: func(ctx context.Context, userId int64, passwordHash string, keepSessionId int64) ([]int64, error) {
+				t.Fatalf("UpdatePassword must not be called")
+				return nil, nil
+			},
+		}, nil, nil, nil, nil)
+
+		if _, err := uc.ChangePassword(ctx, 7, 31, "wrong", "new-secret"); !errors.Is(err, domain.ErrPasswordMismatch) {
+			t.Fatalf("expected ErrPasswordMismatch, got: %v", err)
+		}
+	})
+
+	t.Run("ok -> new hash stored, other sessions revoked, tokens of the session rotated", func(t *testing.T) {
+		t.Parallel()
+
+		var stored string
+		var kept, rotated int64
+		log := &auditFake{}
+		revoker := &revokerFake{}
+
+		uc := New(&repoFake{
+			getById: withPassword(t),
+			updatePassword: func(ctx context.Context, userId int64, passwordHash string, keepSessionId int64) ([]int64, error) {
+				stored, kept = passwordHash, keepSessionId
+				return []int64{30, 32}, nil
+			},
+			updateTokens: func(ctx context.Context, sessionId int64, ip, prevHash string, tk *token.Tokens) error {
+				rotated = sessionId
+				return nil
+			},
+		}, nil, log, revoker, nil)
+
+		tokens, err := uc.ChangePassword(ctx, 7, 31, "secret", "new-secret")
 		if err != nil {
-			t.Fatalf("HashString error: %v", err)
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tokens == nil || tokens.JWTToken == "" || tokens.RefreshToken == "" {
+			t.Fatalf("expected new tokens, got %+v", tokens)
+		}
+		if kept != 31 || rotated != 31 {
+			t.Fatalf("expected session 31 kept and rotated, got kept=%d rotated=%d", kept, rotated)
+		}
+		if !uc.Revoked(ctx, &token.Claims{UserID: 7, SessionID: 30}) || !uc.Revoked(ctx, &token.Claims{UserID: 7, SessionID: 32}) {
+			t.Fatal("expected access tokens of the other sessions to be revoked")
+		}
+		if uc.Revoked(ctx, &token.Claims{UserID: 7, SessionID: 31}) {
+			t.Fatal("expected access tokens of the kept session to stay valid")
+		}
+		if ok, _ := hasher.VerifyString("new-secret", stored); !ok {
+			t.Fatalf("expected hash of the new password to be stored")
 		}
+		if len(log.events) != 1 || log.events[0].Action != audit.ActionPasswordChange {
+			t.Fatalf("unexpected audit events: %+v", log.events)
+		}
+	})
+}
 
-		updateCalled := false
+func TestUser_ChangeUsername(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("blank username -> ErrEmptyUsername", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{getById: withPassword(t)}, nil, nil, nil, nil)
+		if err := uc.ChangeUsername(ctx, 7, "secret", "  "); !errors.Is(err, domain.ErrEmptyUsername) {
+			t.Fatalf("expected ErrEmptyUsername, got: %v", err)
+		}
+	})
+
+	t.Run("wrong password -> ErrPasswordMismatch", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{getById: withPassword(t)}, nil, nil, nil, nil)
+		if err := uc.ChangeUsername(ctx, 7, "wrong", "bob"); !errors.Is(err, domain.ErrPasswordMismatch) {
+			t.Fatalf("expected ErrPasswordMismatch, got: %v", err)
+		}
+	})
+
+	t.Run("taken -> ErrUsernameAlreadyExists", func(t *testing.T) {
+		t.Parallel()
 
 		uc := New(&repoFake{
-			getTokens: func(ctx context.Context, uid int64) (*token.Tokens, error) {
-				if uid != userId {
-					t.Fatalf("expected userId=%d, got %d", userId, uid)
-				}
-				return &token.Tokens{
-					UserId:            uid,
-					Revoked:           false,
-					RefreshTokenExpAt: time.Now().Add(10 * time.Minute),
-					RefreshToken:      hashed,
-				}, nil
+			getById: withPassword(t),
+			updateUsername: func(ctx context.Context, userId int64, username string) error {
+				return domain.ErrUsernameAlreadyExists
 			},
-			updateTokens: func(ctx context.Context, uid int64, tks *token.Tokens) error {
-				updateCalled = true
-				if uid != userId {
-					t.Fatalf("expected uid=%d, got %d", userId, uid)
-				}
-				if tks.RefreshToken == "" {
-					t.Fatalf("expected non-empty refresh hash")
-				}
-				if tks.RefreshToken == "refresh-plain" {
-					t.Fatalf("expected hashed refresh in repo, got plain")
-				}
-				if tks.JWTToken == "" {
-					t.Fatalf("expected jwt set")
-				}
+		}, nil, nil, nil, nil)
+
+		if err := uc.ChangeUsername(ctx, 7, "secret", "bob"); !errors.Is(err, domain.ErrUsernameAlreadyExists) {
+			t.Fatalf("expected ErrUsernameAlreadyExists, got: %v", err)
+		}
+	})
+
+	t.Run("ok -> trimmed username stored", func(t *testing.T) {
+		t.Parallel()
+
+		var got string
+		uc := New(&repoFake{
+			getById: withPassword(t),
+			updateUsername: func(ctx context.Context, userId int64, username string) error {
+				got = username
+				return nil
+			},
+		}, nil, nil, nil, nil)
+
+		if err := uc.ChangeUsername(ctx, 7, "secret", " bob "); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "bob" {
+			t.Fatalf("expected %q, got %q", "bob", got)
+		}
+	})
+}
+
+func TestUser_DeleteAccount(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("wrong password -> ErrPasswordMismatch, nothing deleted", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			getById: withPassword(t),
+			deleteUser: func(ctx context.Context, userId int64) ([]*file.Operation, error) {
+				t.Fatalf("Delete must not be called")
+				return nil, nil
+			},
+		}, nil, nil, nil, nil)
+
+		if err := uc.DeleteAccount(ctx, 7, "wrong"); !errors.Is(err, domain.ErrPasswordMismatch) {
+			t.Fatalf("expected ErrPasswordMismatch, got: %v", err)
+		}
+	})
+
+	t.Run("repo error -> wrapped, no objects removed", func(t *testing.T) {
+		t.Parallel()
+
+		files := &filesFake{}
+		uc := New(&repoFake{
+			getById: withPassword(t),
+			deleteUser: func(ctx context.Context, userId int64) ([]*file.Operation, error) {
+				return nil, errors.New("db down")
+			},
+		}, files, nil, nil, nil)
+
+		if err := uc.DeleteAccount(ctx, 7, "secret"); err == nil || !strings.Contains(err.Error(), "db down") {
+			t.Fatalf("expected wrapped db error, got: %v", err)
+		}
+		if len(files.removed) != 0 {
+			t.Fatalf("expected no objects removed, got %d", len(files.removed))
+		}
+	})
+
+	t.Run("last org owner -> ErrLastOrgOwner, no objects removed", func(t *testing.T) {
+		t.Parallel()
+
+		files := &filesFake{}
+		uc := New(&repoFake{
+			getById: withPassword(t),
+			deleteUser: func(ctx context.Context, userId int64) ([]*file.Operation, error) {
+				return nil, domain.ErrLastOrgOwner
+			},
+		}, files, nil, nil, nil)
+
+		if err := uc.DeleteAccount(ctx, 7, "secret"); !errors.Is(err, domain.ErrLastOrgOwner) {
+			t.Fatalf("expected ErrLastOrgOwner, got: %v", err)
+		}
+		if len(files.removed) != 0 {
+			t.Fatalf("expected no objects removed, got %d", len(files.removed))
+		}
+	})
+
+	t.Run("ok -> queued objects removed from storage", func(t *testing.T) {
+		t.Parallel()
+
+		ops := []*file.Operation{
+			{ID: 1, Type: file.OperationDelete, FileID: 10, Storage: file.StorageRef{BucketName: "b", ObjectKey: "7/a"}},
+			{ID: 2, Type: file.OperationDelete, FileID: 11, Storage: file.StorageRef{BucketName: "b", ObjectKey: "7/b"}},
+		}
+		files := &filesFake{}
+		log := &auditFake{}
+
+		uc := New(&repoFake{
+			getById: withPassword(t),
+			deleteUser: func(ctx context.Context, userId int64) ([]*file.Operation, error) {
+				return ops, nil
+			},
+		}, files, log, nil, nil)
+
+		if err := uc.DeleteAccount(ctx, 7, "secret"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(files.removed) != 2 || files.removed[1].Storage.ObjectKey != "7/b" {
+			t.Fatalf("unexpected removed objects: %+v", files.removed)
+		}
+		if len(log.events) != 1 || log.events[0].Action != audit.ActionAccountDelete || log.events[0].UserID != 7 {
+			t.Fatalf("unexpected audit events: %+v", log.events)
+		}
+	})
+}
+
+func TestUser_LoginSession(t *testing.T) {
+	t.Parallel()
+
+	hashed, err := hasher.HashString("secret")
+	if err != nil {
+		t.Fatalf("HashString error: %v", err)
+	}
+
+	var got *domain.Session
+	uc := New(&repoFake{
+		getByUsername: func(ctx context.Context, username string) (*domain.User, error) {
+			return &domain.User{ID: 7, Username: "alice", Password: hashed}, nil
+		},
+		addTokens: func(ctx context.Context, session *domain.Session, tk *token.Tokens) (int64, error) {
+			got = session
+			return 31, nil
+		},
+	}, nil, nil, nil, nil)
+
+	ctx := audit.WithClient(context.Background(), audit.Client{IP: "10.0.0.1", UserAgent: "Go-http-client/1.1"})
+
+	tokens, err := uc.Login(ctx, "alice", "secret", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got == nil || got.UserID != 7 || got.DeviceName != "Go-http-client/1.1" || got.IP != "10.0.0.1" {
+		t.Fatalf("unexpected session: %+v", got)
+	}
+
+	claims, err := token.VerifyJWT(tokens.JWTToken)
+	if err != nil {
+		t.Fatalf("VerifyJWT error: %v", err)
+	}
+	if claims.SessionID != 31 || tokens.SessionID != 31 {
+		t.Fatalf("expected session 31 in jwt, got %d", claims.SessionID)
+	}
+
+	if _, err := uc.Login(ctx, "alice", "secret", "laptop"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.DeviceName != "laptop" {
+		t.Fatalf("expected device name from the request, got %q", got.DeviceName)
+	}
+}
+
+func TestUser_Sessions(t *testing.T) {
+	t.Parallel()
+
+	uc := New(&repoFake{
+		getSessions: func(ctx context.Context, userId int64) ([]*domain.Session, error) {
+			return []*domain.Session{{ID: 32, UserID: userId}, {ID: 31, UserID: userId}}, nil
+		},
+	}, nil, nil, nil, nil)
+
+	list, err := uc.Sessions(context.Background(), 7, 31)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 2 || list[0].Current || !list[1].Current {
+		t.Fatalf("expected only session 31 to be current, got %+v %+v", list[0], list[1])
+	}
+}
+
+func TestUser_RevokeSession(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("invalid id -> ErrSessionNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{}, nil, nil, nil, nil)
+		if err := uc.RevokeSession(ctx, 7, 0); !errors.Is(err, domain.ErrSessionNotFound) {
+			t.Fatalf("expected ErrSessionNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("not found -> ErrSessionNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		uc := New(&repoFake{
+			revokeSession: func(ctx context.Context, userId, sessionId int64) error {
+				return domain.ErrSessionNotFound
+			},
+		}, nil, nil, nil, nil)
+		if err := uc.RevokeSession(ctx, 7, 31); !errors.Is(err, domain.ErrSessionNotFound) {
+			t.Fatalf("expected ErrSessionNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("ok -> revoked and logged", func(t *testing.T) {
+		t.Parallel()
+
+		var gotUser, gotSession int64
+		log := &auditFake{}
+		revoker := &revokerFake{}
+		uc := New(&repoFake{
+			revokeSession: func(ctx context.Context, userId, sessionId int64) error {
+				gotUser, gotSession = userId, sessionId
+				return nil
+			},
+		}, nil, log, revoker, nil)
+
+		if err := uc.RevokeSession(ctx, 7, 31); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if gotUser != 7 || gotSession != 31 {
+			t.Fatalf("unexpected args: user=%d session=%d", gotUser, gotSession)
+		}
+		if !uc.Revoked(ctx, &token.Claims{UserID: 7, SessionID: 31}) {
+			t.Fatal("expected access tokens of the session to be revoked")
+		}
+		if uc.Revoked(ctx, &token.Claims{UserID: 7, SessionID: 32}) {
+			t.Fatal("expected access tokens of other sessions to stay valid")
+		}
+		if len(log.events) != 1 || log.events[0].Action != audit.ActionSessionRevoke {
+			t.Fatalf("unexpected audit events: %+v", log.events)
+		}
+	})
+}
+
+type revokerFake struct {
+	revoked []*domain.Revocation
+}
+
+func (f *revokerFake) Revoke(ctx context.Context, r *domain.Revocation) error {
+	f.revoked = append(f.revoked, r)
+	return nil
+}
+
+func (f *revokerFake) Revoked(ctx context.Context, jti string, userID, sessionID int64, issuedAt time.Time) bool {
+	for _, r := range f.revoked {
+		if r.Covers(jti, userID, sessionID, issuedAt) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestUser_Logout(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	expiresAt := time.Now().Add(10 * time.Minute)
+	claims := &token.Claims{
+		UserID:    7,
+		SessionID: 31,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti-1",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	t.Run("ok -> session and token revoked", func(t *testing.T) {
+		t.Parallel()
+
+		var gotUser, gotSession int64
+		revoker := &revokerFake{}
+		log := &auditFake{}
+		uc := New(&repoFake{
+			revokeSession: func(ctx context.Context, userId, sessionId int64) error {
+				gotUser, gotSession = userId, sessionId
 				return nil
 			},
+		}, nil, log, revoker, nil)
+
+		if err := uc.Logout(ctx, claims); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if gotUser != 7 || gotSession != 31 {
+			t.Fatalf("unexpected args: user=%d session=%d", gotUser, gotSession)
+		}
+		if len(revoker.revoked) != 1 || revoker.revoked[0].JTI != "jti-1" || !revoker.revoked[0].ExpiresAt.Equal(claims.ExpiresAt.Time) {
+			t.Fatalf("unexpected revocations: %+v", revoker.revoked)
+		}
+		if !uc.Revoked(ctx, claims) {
+			t.Fatal("expected the token to be revoked")
+		}
+		if len(log.events) != 1 || log.events[0].Action != audit.ActionLogout {
+			t.Fatalf("unexpected audit events: %+v", log.events)
+		}
+	})
+
+	t.Run("session revoked already -> token still revoked", func(t *testing.T) {
+		t.Parallel()
+
+		revoker := &revokerFake{}
+		uc := New(&repoFake{
+			revokeSession: func(ctx context.Context, userId, sessionId int64) error {
+				return domain.ErrSessionNotFound
+			},
+		}, nil, nil, revoker, nil)
+
+		if err := uc.Logout(ctx, claims); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(revoker.revoked) != 1 {
+			t.Fatalf("expected one revocation, got %+v", revoker.revoked)
+		}
+	})
+
+	t.Run("repo error -> err", func(t *testing.T) {
+		t.Parallel()
+
+		dbErr := errors.New("db down")
+		uc := New(&repoFake{
+			revokeSession: func(ctx context.Context, userId, sessionId int64) error {
+				return dbErr
+			},
+		}, nil, nil, &revokerFake{}, nil)
+
+		if err := uc.Logout(ctx, claims); !errors.Is(err, dbErr) {
+			t.Fatalf("expected dbErr, got: %v", err)
+		}
+	})
+}
+
+func TestUser_LogoutEverywhere(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var gotUser int64
+	revoker := &revokerFake{}
+	log := &auditFake{}
+	uc := New(&repoFake{
+		revokeAll: func(ctx context.Context, userId int64) (int64, error) {
+			gotUser = userId
+			return 3, nil
+		},
+	}, nil, log, revoker, nil)
+
+	issued := time.Now()
+	if err := uc.LogoutEverywhere(ctx, 7); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotUser != 7 {
+		t.Fatalf("unexpected user: %d", gotUser)
+	}
+	if len(revoker.revoked) != 1 || revoker.revoked[0].JTI != "" || revoker.revoked[0].UserID != 7 {
+		t.Fatalf("unexpected revocations: %+v", revoker.revoked)
+	}
+
+	// iat is stored in seconds, a token from the same second must be covered
+	old := &token.Claims{UserID: 7, RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(issued.Truncate(time.Second))}}
+	if !uc.Revoked(ctx, old) {
+		t.Fatal("expected tokens issued before the logout to be revoked")
+	}
+	other := &token.Claims{UserID: 8, RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(issued)}}
+	if uc.Revoked(ctx, other) {
+		t.Fatal("tokens of other users must stay valid")
+	}
+	later := &token.Claims{UserID: 7, RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(issued.Add(2 * time.Second))}}
+	if uc.Revoked(ctx, later) {
+		t.Fatal("tokens issued after the logout must stay valid")
+	}
+	if len(log.events) != 1 || log.events[0].Action != audit.ActionLogoutAll || log.events[0].Details != "3 sessions" {
+		t.Fatalf("unexpected audit events: %+v", log.events)
+	}
+}
+
+func TestUser_RevokedWithoutRevoker(t *testing.T) {
+	t.Parallel()
+
+	uc := New(&repoFake{}, nil, nil, nil, nil)
+	if uc.Revoked(context.Background(), &token.Claims{UserID: 7}) {
+		t.Fatal("without revoker no token is revoked")
+	}
+	if err := uc.Logout(context.Background(), &token.Claims{UserID: 7, SessionID: 31}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+type factorFake struct {
+	enabled bool
+	device  string
+	resolve func(ctx context.Context, challenge, code string) (*twoFactor.Challenge, error)
+}
+
+func (f *factorFake) Enabled(ctx context.Context, userId int64) (bool, error) {
+	return f.enabled, nil
+}
+func (f *factorFake) Challenge(ctx context.Context, userId int64, device string) (string, time.Time, error) {
+	f.device = device
+	return "challenge", time.Now().Add(5 * time.Minute), nil
+}
+func (f *factorFake) Resolve(ctx context.Context, challenge, code string) (*twoFactor.Challenge, error) {
+	return f.resolve(ctx, challenge, code)
+}
+
+func TestUser_LoginTwoFactor(t *testing.T) {
+	t.Parallel()
+
+	hashed, err := hasher.HashString("secret")
+	if err != nil {
+		t.Fatalf("HashString error: %v", err)
+	}
+
+	newRepo := func(sessions *int) *repoFake {
+		return &repoFake{
+			getByUsername: func(ctx context.Context, username string) (*domain.User, error) {
+				return &domain.User{ID: 7, Username: "alice", Password: hashed}, nil
+			},
+			addTokens: func(ctx context.Context, session *domain.Session, tk *token.Tokens) (int64, error) {
+				*sessions++
+				return 31, nil
+			},
+		}
+	}
+
+	t.Run("password of a 2FA user gives a challenge only", func(t *testing.T) {
+		var sessions int
+		factor := &factorFake{enabled: true}
+		uc := New(newRepo(&sessions), nil, nil, nil, factor)
+
+		tokens, err := uc.Login(context.Background(), "alice", "secret", "laptop")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tokens.Challenge != "challenge" || tokens.JWTToken != "" || tokens.RefreshToken != "" {
+			t.Fatalf("expected a challenge without tokens, got %+v", tokens)
+		}
+		if sessions != 0 || factor.device != "laptop" {
+			t.Fatalf("expected no session before the code, got %d sessions, device %q", sessions, factor.device)
+		}
+	})
+
+	t.Run("2FA off -> tokens right away", func(t *testing.T) {
+		var sessions int
+		uc := New(newRepo(&sessions), nil, nil, nil, &factorFake{})
+
+		tokens, err := uc.Login(context.Background(), "alice", "secret", "")
+		if err != nil || tokens.Challenge != "" || tokens.JWTToken == "" || sessions != 1 {
+			t.Fatalf("Login = %+v, %v, %d sessions", tokens, err, sessions)
+		}
+	})
+
+	t.Run("code starts the session of the challenge", func(t *testing.T) {
+		var sessions int
+		auditor := &auditFake{}
+		uc := New(newRepo(&sessions), nil, auditor, nil, &factorFake{
+			resolve: func(ctx context.Context, challenge, code string) (*twoFactor.Challenge, error) {
+				if challenge != "challenge" || code != "123456" {
+					t.Fatalf("unexpected args: %q %q", challenge, code)
+				}
+				return &twoFactor.Challenge{UserID: 7, DeviceName: "laptop"}, nil
+			},
 		})
 
-		newTokens, err := uc.RefreshJWTToken(ctx, jwt, "refresh-plain")
+		tokens, err := uc.LoginTwoFactor(context.Background(), "challenge", "123456")
 		if err != nil {
-			t.Fatalf("expected nil err, got: %v", err)
+			t.Fatalf("unexpected error: %v", err)
 		}
-		if !updateCalled {
-			t.Fatalf("expected UpdateTokens to be called")
+		if tokens.JWTToken == "" || tokens.SessionID != 31 || sessions != 1 {
+			t.Fatalf("unexpected tokens: %+v", tokens)
 		}
+		if len(auditor.events) != 1 || auditor.events[0].Action != audit.ActionLogin || auditor.events[0].UserID != 7 {
+			t.Fatalf("unexpected audit: %+v", auditor.events)
+		}
+	})
 
-		if newTokens.RefreshToken == "" {
-			t.Fatalf("expected plain refresh token in response")
+	t.Run("wrong code -> no session", func(t *testing.T) {
+		var sessions int
+		uc := New(newRepo(&sessions), nil, nil, nil, &factorFake{
+			resolve: func(ctx context.Context, challenge, code string) (*twoFactor.Challenge, error) {
+				return nil, twoFactor.ErrInvalidCode
+			},
+		})
+
+		if _, err := uc.LoginTwoFactor(context.Background(), "challenge", "000000"); !errors.Is(err, twoFactor.ErrInvalidCode) {
+			t.Fatalf("expected ErrInvalidCode, got %v", err)
+		}
+		if sessions != 0 {
+			t.Fatalf("expected no session, got %d", sessions)
 		}
-		if newTokens.JWTToken == "" {
-			t.Fatalf("expected jwt in response")
+	})
+
+	t.Run("without factor -> ErrChallengeNotFound", func(t *testing.T) {
+		uc := New(&repoFake{}, nil, nil, nil, nil)
+
+		if _, err := uc.LoginTwoFactor(context.Background(), "challenge", "123456"); !errors.Is(err, twoFactor.ErrChallengeNotFound) {
+			t.Fatalf("expected ErrChallengeNotFound, got %v", err)
 		}
 	})
 }
@@ -12,12 +12,12 @@ func (mc *MinioAdapter) InitMinio() error {
 	ctx := context.Background()
 
 	// Проверка наличия бакета и его создание, если не существует
-	exists, err := mc.mc.BucketExists(ctx, config.App.GetMinioBucketName())
+	exists, err := mc.CL.BucketExists(ctx, config.App.GetMinioBucketName())
 	if err != nil {
 		return err
 	}
 	if !exists {
-		err := mc.mc.MakeBucket(ctx, config.App.GetMinioBucketName(), minio.MakeBucketOptions{})
+		err := mc.CL.MakeBucket(ctx, config.App.GetMinioBucketName(), minio.MakeBucketOptions{})
 		if err != nil {
 			return err
 		}
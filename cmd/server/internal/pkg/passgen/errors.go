@@ -0,0 +1,9 @@
+package passgen
+
+import "errors"
+
+var (
+	ErrInvalidLength    = errors.New("invalid password length")
+	ErrNoCharClasses    = errors.New("no character classes selected")
+	ErrInvalidWordCount = errors.New("invalid passphrase word count")
+)
@@ -0,0 +1,244 @@
+package passgen
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+const (
+	MinLength = 4
+	MaxLength = 256
+
+	MinWords = 3
+	MaxWords = 20
+
+	DefaultSeparator = "-"
+)
+
+const (
+	lower   = "abcdefghijklmnopqrstuvwxyz"
+	upper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digits  = "0123456789"
+	symbols = "!@#$%^&*()-_=+[]{};:,.<>?/~"
+
+	// characters that are easy to confuse when read or typed by hand
+	ambiguous = "0Oo1lI|"
+)
+
+// EFF large wordlist, https://www.eff.org/dice (CC BY 3.0)
+//
+//go:embed eff_large_wordlist.txt
+var wordlistRaw string
+
+var wordlist = strings.Fields(wordlistRaw)
+
+// Options describe a random character password
+type Options struct {
+	Length           int
+	Lower            bool
+	Upper            bool
+	Digits           bool
+	Symbols          bool
+	ExcludeAmbiguous bool
+}
+
+// PassphraseOptions describe a diceware passphrase
+type PassphraseOptions struct {
+	Words      int
+	Separator  string
+	Capitalize bool
+}
+
+// Generate returns a password with at least one character of every selected class
+func Generate(opts Options) (string, error) {
+	if opts.Length < MinLength || opts.Length > MaxLength {
+		return "", fmt.Errorf("%w: must be between %d and %d", ErrInvalidLength, MinLength, MaxLength)
+	}
+
+	classes := opts.classes()
+	if len(classes) == 0 {
+		return "", ErrNoCharClasses
+	}
+	if opts.Length < len(classes) {
+		return "", fmt.Errorf("%w: shorter than the number of character classes", ErrInvalidLength)
+	}
+
+	out := make([]byte, 0, opts.Length)
+
+	for _, class := range classes {
+		c, err := pick(class)
+		if err != nil {
+			return "", err
+		}
+		out = append(out, c)
+	}
+
+	all := strings.Join(classes, "")
+	for len(out) < opts.Length {
+		c, err := pick(all)
+		if err != nil {
+			return "", err
+		}
+		out = append(out, c)
+	}
+
+	// the guaranteed characters must not always lead the password
+	for i := len(out) - 1; i > 0; i-- {
+		j, err := randInt(i + 1)
+		if err != nil {
+			return "", err
+		}
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return string(out), nil
+}
+
+// Passphrase returns words picked uniformly from the embedded wordlist
+func Passphrase(opts PassphraseOptions) (string, error) {
+	if opts.Words < MinWords || opts.Words > MaxWords {
+		return "", fmt.Errorf("%w: must be between %d and %d", ErrInvalidWordCount, MinWords, MaxWords)
+	}
+
+	sep := opts.Separator
+	if sep == "" {
+		sep = DefaultSeparator
+	}
+
+	words := make([]string, opts.Words)
+	for i := range words {
+		n, err := randInt(len(wordlist))
+		if err != nil {
+			return "", err
+		}
+
+		word := wordlist[n]
+		if opts.Capitalize {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		words[i] = word
+	}
+
+	return strings.Join(words, sep), nil
+}
+
+// Entropy estimates the strength of a password generated with opts in bits
+func Entropy(opts Options) float64 {
+	size := len(strings.Join(opts.classes(), ""))
+	if size == 0 || opts.Length <= 0 {
+		return 0
+	}
+	return float64(opts.Length) * math.Log2(float64(size))
+}
+
+// PassphraseEntropy estimates the strength of a passphrase of n words in bits
+func PassphraseEntropy(words int) float64 {
+	if words <= 0 {
+		return 0
+	}
+	return float64(words) * math.Log2(float64(len(wordlist)))
+}
+
+// WordlistSize is the number of words passphrases are picked from
+func WordlistSize() int {
+	return len(wordlist)
+}
+
+func (o Options) classes() []string {
+	var classes []string
+
+	for _, c := range []struct {
+		on  bool
+		set string
+	}{
+		{o.Lower, lower},
+		{o.Upper, upper},
+		{o.Digits, digits},
+		{o.Symbols, symbols},
+	} {
+		if !c.on {
+			continue
+		}
+
+		set := c.set
+		if o.ExcludeAmbiguous {
+			set = strings.Map(func(r rune) rune {
+				if strings.ContainsRune(ambiguous, r) {
+					return -1
+				}
+				return r
+			}, set)
+		}
+		classes = append(classes, set)
+	}
+
+	return classes
+}
+
+func pick(set string) (byte, error) {
+	n, err := randInt(len(set))
+	if err != nil {
+		return 0, err
+	}
+	return set[n], nil
+}
+
+func randInt(max int) (int, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
+	if err != nil {
+		return 0, fmt.Errorf("read random: %w", err)
+	}
+	return int(n.Int64()), nil
+}
+
+// EstimateEntropy estimates the strength of an existing password in bits from the
+// character classes it uses, runs of one repeated character only count once
+func EstimateEntropy(password string) float64 {
+	var (
+		pool    int
+		seen    [4]bool
+		length  int
+		prev    rune
+		classes = []string{lower, upper, digits}
+	)
+
+	for i, r := range password {
+		if i > 0 && r == prev {
+			continue
+		}
+		prev = r
+		length++
+
+		class := 3
+		for c, set := range classes {
+			if strings.ContainsRune(set, r) {
+				class = c
+				break
+			}
+		}
+		seen[class] = true
+	}
+
+	for class, ok := range seen {
+		if !ok {
+			continue
+		}
+		switch class {
+		case 0, 1:
+			pool += 26
+		case 2:
+			pool += 10
+		default:
+			pool += 33
+		}
+	}
+
+	if pool == 0 {
+		return 0
+	}
+	return float64(length) * math.Log2(float64(pool))
+}
@@ -0,0 +1,134 @@
+package passgen
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	opts := Options{Length: 24, Lower: true, Upper: true, Digits: true, Symbols: true, ExcludeAmbiguous: true}
+
+	for i := 0; i < 200; i++ {
+		got, err := Generate(opts)
+		if err != nil {
+			t.Fatalf("Generate error: %v", err)
+		}
+		if len(got) != 24 {
+			t.Fatalf("expected 24 chars, got %d", len(got))
+		}
+		if strings.ContainsAny(got, ambiguous) {
+			t.Fatalf("expected no ambiguous chars, got %q", got)
+		}
+		for _, class := range []string{lower, upper, digits, symbols} {
+			if !strings.ContainsAny(got, class) {
+				t.Fatalf("expected a char of every class in %q", got)
+			}
+		}
+	}
+}
+
+func TestGenerate_DigitsOnly(t *testing.T) {
+	t.Parallel()
+
+	got, err := Generate(Options{Length: 6, Digits: true})
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+	if strings.Trim(got, digits) != "" {
+		t.Fatalf("expected digits only, got %q", got)
+	}
+}
+
+func TestGenerate_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts Options
+		want error
+	}{
+		{"too short", Options{Length: 3, Lower: true}, ErrInvalidLength},
+		{"too long", Options{Length: MaxLength + 1, Lower: true}, ErrInvalidLength},
+		{"no classes", Options{Length: 16}, ErrNoCharClasses},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := Generate(tt.opts); !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got: %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestPassphrase(t *testing.T) {
+	t.Parallel()
+
+	if WordlistSize() != 7776 {
+		t.Fatalf("expected the EFF large wordlist, got %d words", WordlistSize())
+	}
+
+	got, err := Passphrase(PassphraseOptions{Words: 6, Separator: " ", Capitalize: true})
+	if err != nil {
+		t.Fatalf("Passphrase error: %v", err)
+	}
+
+	words := strings.Split(got, " ")
+	if len(words) != 6 {
+		t.Fatalf("expected 6 words, got %q", got)
+	}
+	for _, w := range words {
+		if w == "" || w[:1] != strings.ToUpper(w[:1]) {
+			t.Fatalf("expected capitalized words, got %q", got)
+		}
+	}
+
+	if _, err := Passphrase(PassphraseOptions{Words: 2}); !errors.Is(err, ErrInvalidWordCount) {
+		t.Fatalf("expected ErrInvalidWordCount, got: %v", err)
+	}
+}
+
+func TestEntropy(t *testing.T) {
+	t.Parallel()
+
+	// 10 digits with 2 ambiguous removed, log2(8) = 3 bits per char
+	if got := Entropy(Options{Length: 10, Digits: true, ExcludeAmbiguous: true}); math.Abs(got-30) > 1e-9 {
+		t.Fatalf("expected 30 bits, got %v", got)
+	}
+	if got := Entropy(Options{Length: 10}); got != 0 {
+		t.Fatalf("expected 0 bits without classes, got %v", got)
+	}
+	// log2(7776) ~ 12.925 bits per word
+	if got := PassphraseEntropy(6); got < 77.5 || got > 77.6 {
+		t.Fatalf("expected ~77.5 bits, got %v", got)
+	}
+}
+
+func TestEstimateEntropy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		password string
+		min, max float64
+	}{
+		{"", 0, 0},
+		// 6 digits, log2(10) ~ 3.32 bits each
+		{"123456", 19.9, 20},
+		// repeated characters count once
+		{"aaaaaaaaaaaaaaaa", 4.7, 4.71},
+		// 12 chars of all classes, log2(95) ~ 6.57 bits each
+		{"kX9#vQ2!mZ7$", 78.8, 78.9},
+	}
+
+	for _, tt := range tests {
+		if got := EstimateEntropy(tt.password); got < tt.min || got > tt.max {
+			t.Fatalf("EstimateEntropy(%q): expected [%v, %v], got %v", tt.password, tt.min, tt.max, got)
+		}
+	}
+}
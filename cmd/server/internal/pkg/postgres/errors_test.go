@@ -0,0 +1,36 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "same constraint", err: &pgconn.PgError{Code: "23505", ConstraintName: "uq_test"}, want: true},
+		{name: "wrapped", err: fmt.Errorf("insert: %w", &pgconn.PgError{Code: "23505", ConstraintName: "uq_test"}), want: true},
+		{name: "other constraint", err: &pgconn.PgError{Code: "23505", ConstraintName: "uq_other"}},
+		{name: "other code", err: &pgconn.PgError{Code: "23503", ConstraintName: "uq_test"}},
+		{name: "not a postgres error", err: errors.New("db error")},
+		{name: "nil", err: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsUniqueViolation(tt.err, "uq_test"); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
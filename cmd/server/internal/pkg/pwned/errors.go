@@ -0,0 +1,8 @@
+package pwned
+
+import "errors"
+
+var (
+	ErrInvalidDataset = errors.New("invalid pwned passwords dataset")
+	ErrInvalidRecord  = errors.New("invalid pwned passwords record")
+)
@@ -0,0 +1,187 @@
+package pwned
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	hashLen   = sha1.Size * 2
+	prefixLen = 5
+)
+
+// Dataset looks passwords up in a local copy of the HIBP "pwned passwords" SHA-1 list.
+//
+// Two layouts are supported:
+//   - a single file of "HASH:COUNT" lines ordered by hash, searched with binary search;
+//   - a directory of k-anonymity range files "ABCDE.txt" with "SUFFIX:COUNT" lines,
+//     as written by the official downloader.
+//
+// Passwords never leave the process, only their SHA-1 is compared.
+type Dataset struct {
+	path string
+	dir  bool
+	file *os.File
+	size int64
+}
+
+// Open checks the dataset at path, a directory is treated as range files
+func Open(path string) (*Dataset, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDataset, err)
+	}
+
+	if info.IsDir() {
+		return &Dataset{path: path, dir: true}, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDataset, err)
+	}
+
+	return &Dataset{path: path, file: f, size: info.Size()}, nil
+}
+
+func (d *Dataset) Close() error {
+	if d.file == nil {
+		return nil
+	}
+	return d.file.Close()
+}
+
+// Count returns how many times the password was seen in breaches, 0 when it is not listed
+func (d *Dataset) Count(password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	if d.dir {
+		return d.countInRange(hash)
+	}
+	return d.countInFile(hash)
+}
+
+func (d *Dataset) countInRange(hash string) (int, error) {
+	f, err := os.Open(filepath.Join(d.path, hash[:prefixLen]+".txt"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer f.Close()
+
+	suffix := hash[prefixLen:]
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		got, count, err := parseRecord(line)
+		if err != nil {
+			return 0, err
+		}
+		if strings.EqualFold(got, suffix) {
+			return count, nil
+		}
+	}
+
+	return 0, scanner.Err()
+}
+
+// countInFile binary searches line starts, lo and hi are byte offsets that always point at a line start
+func (d *Dataset) countInFile(hash string) (int, error) {
+	lo, hi := int64(0), d.size
+
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+
+		start, err := d.lineStart(mid, lo)
+		if err != nil {
+			return 0, err
+		}
+
+		line, next, err := d.readLine(start)
+		if err != nil {
+			return 0, err
+		}
+
+		got, count, err := parseRecord(line)
+		if err != nil {
+			return 0, err
+		}
+
+		switch cmp := strings.Compare(strings.ToUpper(got), hash); {
+		case cmp == 0:
+			return count, nil
+		case cmp < 0:
+			lo = next
+		default:
+			hi = start
+		}
+	}
+
+	return 0, nil
+}
+
+// lineStart returns the start of the line containing off, not going before floor
+func (d *Dataset) lineStart(off, floor int64) (int64, error) {
+	const chunk = 128
+
+	for off > floor {
+		from := off - chunk
+		if from < floor {
+			from = floor
+		}
+
+		buf := make([]byte, off-from)
+		if _, err := d.file.ReadAt(buf, from); err != nil && !errors.Is(err, io.EOF) {
+			return 0, err
+		}
+
+		if i := strings.LastIndexByte(string(buf), '\n'); i >= 0 {
+			return from + int64(i) + 1, nil
+		}
+		off = from
+	}
+
+	return floor, nil
+}
+
+// readLine returns the line at off without its line break and the offset of the next line
+func (d *Dataset) readLine(off int64) (string, int64, error) {
+	r := bufio.NewReader(io.NewSectionReader(d.file, off, d.size-off))
+
+	line, err := r.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", 0, err
+	}
+
+	return strings.TrimRight(line, "\r\n"), off + int64(len(line)), nil
+}
+
+func parseRecord(line string) (string, int, error) {
+	hash, countRaw, ok := strings.Cut(line, ":")
+	if !ok || hash == "" || len(hash) > hashLen {
+		return "", 0, fmt.Errorf("%w: %q", ErrInvalidRecord, line)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(countRaw))
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %q", ErrInvalidRecord, line)
+	}
+
+	return hash, count, nil
+}
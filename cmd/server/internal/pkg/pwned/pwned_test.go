@@ -0,0 +1,88 @@
+package pwned
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDataset_Count(t *testing.T) {
+	t.Parallel()
+
+	for _, path := range []string{"testdata/pwned-sha1-ordered.txt", "testdata/ranges"} {
+		d, err := Open(path)
+		if err != nil {
+			t.Fatalf("Open(%s) error: %v", path, err)
+		}
+		defer d.Close()
+
+		tests := []struct {
+			password string
+			want     int
+		}{
+			{"password", 3861493},
+			{"123456", 37359195},
+			{"hunter2", 17043},
+			{"correct horse battery staple", 384},
+			{"P@ssw0rd", 92423},
+			{"not in the dataset at all", 0},
+			{"", 0},
+		}
+
+		for _, tt := range tests {
+			got, err := d.Count(tt.password)
+			if err != nil {
+				t.Fatalf("%s: Count(%q) error: %v", path, tt.password, err)
+			}
+			if got != tt.want {
+				t.Fatalf("%s: Count(%q): expected %d, got %d", path, tt.password, tt.want, got)
+			}
+		}
+	}
+}
+
+func TestDataset_CountSingleRecord(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "one.txt")
+	// sha1("password") without a trailing line break
+	if err := os.WriteFile(path, []byte("5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:7"), 0o600); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+
+	d, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open error: %v", err)
+	}
+	defer d.Close()
+
+	for password, want := range map[string]int{"password": 7, "123456": 0, "zzz": 0} {
+		if got, err := d.Count(password); err != nil || got != want {
+			t.Fatalf("Count(%q): expected %d, got %d, %v", password, want, got, err)
+		}
+	}
+}
+
+func TestDataset_Invalid(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Open("testdata/missing.txt"); !errors.Is(err, ErrInvalidDataset) {
+		t.Fatalf("expected ErrInvalidDataset, got: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "broken.txt")
+	if err := os.WriteFile(path, []byte("not a record\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+
+	d, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open error: %v", err)
+	}
+	defer d.Close()
+
+	if _, err := d.Count("password"); !errors.Is(err, ErrInvalidRecord) {
+		t.Fatalf("expected ErrInvalidRecord, got: %v", err)
+	}
+}
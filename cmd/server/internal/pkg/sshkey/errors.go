@@ -0,0 +1,11 @@
+package sshkey
+
+import "errors"
+
+var (
+	ErrUnsupportedType    = errors.New("unsupported ssh key type")
+	ErrInvalidBits        = errors.New("invalid rsa key size")
+	ErrInvalidKey         = errors.New("invalid ssh private key")
+	ErrPassphraseRequired = errors.New("ssh key is passphrase protected")
+	ErrWrongPassphrase    = errors.New("wrong ssh key passphrase")
+)
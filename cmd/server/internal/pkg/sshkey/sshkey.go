@@ -0,0 +1,122 @@
+package sshkey
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	TypeEd25519 = "ed25519"
+	TypeRSA     = "rsa"
+
+	DefaultRSABits = 4096
+	MinRSABits     = 2048
+	// MaxRSABits keeps server side generation cheap, larger keys take seconds of CPU per request
+	MaxRSABits = 4096
+)
+
+// Key is an SSH key pair, the private part is an unencrypted OpenSSH PEM block
+type Key struct {
+	// Type is the SSH algorithm name, e.g. "ssh-ed25519" or "ssh-rsa"
+	Type       string
+	PrivateKey string
+	// PublicKey is an authorized_keys line including the comment
+	PublicKey string
+	// Fingerprint is the OpenSSH SHA256 fingerprint, e.g. "SHA256:...".
+	Fingerprint string
+	Comment     string
+}
+
+// Generate creates a new key pair, bits is used for RSA only and defaults to DefaultRSABits
+func Generate(kind string, bits int, comment string) (*Key, error) {
+	var private crypto.PrivateKey
+
+	switch strings.ToLower(kind) {
+	case TypeEd25519, "":
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		private = key
+
+	case TypeRSA:
+		if bits == 0 {
+			bits = DefaultRSABits
+		}
+		if bits < MinRSABits || bits > MaxRSABits {
+			return nil, fmt.Errorf("%w: %d, expected %d..%d", ErrInvalidBits, bits, MinRSABits, MaxRSABits)
+		}
+
+		key, err := rsa.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			return nil, err
+		}
+		private = key
+
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedType, kind)
+	}
+
+	return fromPrivate(private, comment)
+}
+
+// Parse reads a PEM encoded private key (OpenSSH, PKCS#1, PKCS#8 or SEC 1),
+// the passphrase is only used for encrypted keys
+func Parse(pemKey, passphrase, comment string) (*Key, error) {
+	private, err := ssh.ParseRawPrivateKey([]byte(pemKey))
+
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		if passphrase == "" {
+			return nil, ErrPassphraseRequired
+		}
+		private, err = ssh.ParseRawPrivateKeyWithPassphrase([]byte(pemKey), []byte(passphrase))
+	}
+
+	switch {
+	case errors.Is(err, x509.IncorrectPasswordError):
+		return nil, ErrWrongPassphrase
+	case err != nil:
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	return fromPrivate(private, comment)
+}
+
+func fromPrivate(private crypto.PrivateKey, comment string) (*Key, error) {
+	signer, err := ssh.NewSignerFromKey(private)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnsupportedType, err)
+	}
+
+	comment = strings.TrimSpace(comment)
+
+	block, err := ssh.MarshalPrivateKey(private, comment)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnsupportedType, err)
+	}
+
+	public := signer.PublicKey()
+
+	authorized := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(public)))
+	if comment != "" {
+		authorized += " " + comment
+	}
+
+	return &Key{
+		Type:        public.Type(),
+		PrivateKey:  string(pem.EncodeToMemory(block)),
+		PublicKey:   authorized,
+		Fingerprint: ssh.FingerprintSHA256(public),
+		Comment:     comment,
+	}, nil
+}
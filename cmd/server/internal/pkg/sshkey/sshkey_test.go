@@ -0,0 +1,155 @@
+package sshkey
+
+import (
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		kind     string
+		bits     int
+		wantType string
+		wantErr  error
+	}{
+		{name: "ed25519 -> ssh-ed25519", kind: TypeEd25519, wantType: ssh.KeyAlgoED25519},
+		{name: "empty kind -> ed25519", kind: "", wantType: ssh.KeyAlgoED25519},
+		{name: "rsa 2048 -> ssh-rsa", kind: TypeRSA, bits: 2048, wantType: ssh.KeyAlgoRSA},
+		{name: "rsa 1024 -> ErrInvalidBits", kind: TypeRSA, bits: 1024, wantErr: ErrInvalidBits},
+		{name: "rsa 8192 -> ErrInvalidBits", kind: TypeRSA, bits: 8192, wantErr: ErrInvalidBits},
+		{name: "dsa -> ErrUnsupportedType", kind: "dsa", wantErr: ErrUnsupportedType},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := Generate(tc.kind, tc.bits, "me@laptop")
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Generate error: %v", err)
+			}
+
+			if key.Type != tc.wantType {
+				t.Fatalf("expected type %q, got %q", tc.wantType, key.Type)
+			}
+			if !strings.HasPrefix(key.Fingerprint, "SHA256:") {
+				t.Fatalf("unexpected fingerprint %q", key.Fingerprint)
+			}
+			if !strings.HasSuffix(key.PublicKey, " me@laptop") {
+				t.Fatalf("public key must end with the comment: %q", key.PublicKey)
+			}
+
+			block, _ := pem.Decode([]byte(key.PrivateKey))
+			if block == nil || block.Type != "OPENSSH PRIVATE KEY" {
+				t.Fatalf("expected OpenSSH PEM, got %q", key.PrivateKey)
+			}
+
+			// the private and the public part belong together
+			signer, err := ssh.ParsePrivateKey([]byte(key.PrivateKey))
+			if err != nil {
+				t.Fatalf("generated key does not parse: %v", err)
+			}
+			if got := ssh.FingerprintSHA256(signer.PublicKey()); got != key.Fingerprint {
+				t.Fatalf("fingerprint mismatch: %q != %q", got, key.Fingerprint)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	generated, err := Generate(TypeEd25519, 0, "")
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+
+	raw, err := ssh.ParseRawPrivateKey([]byte(generated.PrivateKey))
+	if err != nil {
+		t.Fatalf("ParseRawPrivateKey error: %v", err)
+	}
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(raw, "", []byte("secret"))
+	if err != nil {
+		t.Fatalf("MarshalPrivateKeyWithPassphrase error: %v", err)
+	}
+	protected := string(pem.EncodeToMemory(block))
+
+	t.Run("plain key -> same fingerprint", func(t *testing.T) {
+		t.Parallel()
+
+		key, err := Parse(generated.PrivateKey, "", "work")
+		if err != nil {
+			t.Fatalf("Parse error: %v", err)
+		}
+		if key.Fingerprint != generated.Fingerprint {
+			t.Fatalf("expected %q, got %q", generated.Fingerprint, key.Fingerprint)
+		}
+		if key.Comment != "work" {
+			t.Fatalf("expected comment %q, got %q", "work", key.Comment)
+		}
+	})
+
+	t.Run("protected key + passphrase -> decrypted", func(t *testing.T) {
+		t.Parallel()
+
+		key, err := Parse(protected, "secret", "")
+		if err != nil {
+			t.Fatalf("Parse error: %v", err)
+		}
+		if key.Fingerprint != generated.Fingerprint {
+			t.Fatalf("expected %q, got %q", generated.Fingerprint, key.Fingerprint)
+		}
+		if _, err := ssh.ParsePrivateKey([]byte(key.PrivateKey)); err != nil {
+			t.Fatalf("stored key must not need the passphrase: %v", err)
+		}
+	})
+
+	t.Run("protected key without passphrase -> ErrPassphraseRequired", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := Parse(protected, "", ""); !errors.Is(err, ErrPassphraseRequired) {
+			t.Fatalf("expected ErrPassphraseRequired, got %v", err)
+		}
+	})
+
+	t.Run("wrong passphrase -> ErrWrongPassphrase", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := Parse(protected, "nope", ""); !errors.Is(err, ErrWrongPassphrase) {
+			t.Fatalf("expected ErrWrongPassphrase, got %v", err)
+		}
+	})
+
+	t.Run("plain key + passphrase -> passphrase ignored", func(t *testing.T) {
+		t.Parallel()
+
+		key, err := Parse(generated.PrivateKey, "secret", "")
+		if err != nil {
+			t.Fatalf("Parse error: %v", err)
+		}
+		if key.Fingerprint != generated.Fingerprint {
+			t.Fatalf("expected %q, got %q", generated.Fingerprint, key.Fingerprint)
+		}
+	})
+
+	t.Run("garbage -> ErrInvalidKey", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := Parse("not a key", "", ""); !errors.Is(err, ErrInvalidKey) {
+			t.Fatalf("expected ErrInvalidKey, got %v", err)
+		}
+	})
+}
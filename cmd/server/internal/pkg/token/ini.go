@@ -6,12 +6,18 @@ import (
 )
 
 type Tokens struct {
-	UserId            int64
+	UserId    int64
+	SessionID int64
+	// Selector finds the session by the refresh token, it is the same for every token of the session
+	Selector          string
 	JWTToken          string
 	JWTExpAp          time.Time
 	RefreshToken      string
 	RefreshTokenExpAt time.Time
 	Revoked           bool
+	// Challenge is set in place of the tokens when the login waits for a second factor
+	Challenge      string
+	ChallengeExpAt time.Time
 }
 
 func NewTokens(userId int64) *Tokens {
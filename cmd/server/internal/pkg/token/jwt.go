@@ -1,6 +1,7 @@
 package token
 
 import (
+	"encoding/base64"
 	"server/internal/app/config"
 	domain "server/internal/app/domain/user"
 	"strconv"
@@ -11,8 +12,12 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const jtiBytes = 16
+
 type Claims struct {
 	UserID int64 `json:"user_id"`
+	// SessionID is the row of the refresh token issued together with the JWT
+	SessionID int64 `json:"sid"`
 	jwt.RegisteredClaims
 }
 
@@ -22,14 +27,22 @@ type RefreshTokenCmd struct {
 	UserID       int64 `json:"user_id"`
 }
 
-func CreateNewJWT(userID int64) (string, error) {
-	expirationTime := time.Now().Add(config.App.GetJWTLifetime() * time.Minute)
+// JWTLifetime is how long an access token stays valid
+func JWTLifetime() time.Duration {
+	return config.App.GetJWTLifetime() * time.Minute
+}
+
+func CreateNewJWT(userID, sessionID int64) (string, error) {
+	expirationTime := time.Now().Add(JWTLifetime())
 	userIDString := strconv.FormatInt(userID, 10)
 
 	// Create the claims
 	claims := &Claims{
-		UserID: userID,
+		UserID:    userID,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			// jti lets a single token be revoked before it expires
+			ID:        newJTI(),
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    config.App.GetIssuer(),
@@ -49,6 +62,11 @@ func CreateNewJWT(userID int64) (string, error) {
 	return tokenString, nil
 }
 
+// newJTI returns a random id of a token
+func newJTI() string {
+	return base64.RawURLEncoding.EncodeToString(generateRandom(jtiBytes))
+}
+
 func VerifyJWT(jwtString string) (*Claims, error) {
 	keyFunc := func(token *jwt.Token) (any, error) {
 		if token.Method.Alg() != jwt.SigningMethodHS256.Alg() {
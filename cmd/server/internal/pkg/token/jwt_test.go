@@ -35,7 +35,7 @@ func TestCreateNewJWT_ThenVerify_OK(t *testing.T) {
 
 	userID := int64(123)
 
-	j, err := CreateNewJWT(userID)
+	j, err := CreateNewJWT(userID, 45)
 	if err != nil {
 		t.Fatalf("CreateNewJWT error: %v", err)
 	}
@@ -50,6 +50,24 @@ func TestCreateNewJWT_ThenVerify_OK(t *testing.T) {
 	if claims.UserID != userID {
 		t.Fatalf("expected UserID=%d, got %d", userID, claims.UserID)
 	}
+	if claims.SessionID != 45 {
+		t.Fatalf("expected SessionID=45, got %d", claims.SessionID)
+	}
+	if claims.ID == "" {
+		t.Fatalf("expected jti to be set")
+	}
+
+	other, err := CreateNewJWT(userID, 45)
+	if err != nil {
+		t.Fatalf("CreateNewJWT error: %v", err)
+	}
+	otherClaims, err := VerifyJWT(other)
+	if err != nil {
+		t.Fatalf("VerifyJWT error: %v", err)
+	}
+	if otherClaims.ID == claims.ID {
+		t.Fatalf("expected every token to get its own jti")
+	}
 
 	if claims.Issuer != config.App.GetIssuer() {
 		t.Fatalf("expected Issuer=%q, got %q", config.App.GetIssuer(), claims.Issuer)
@@ -74,7 +92,7 @@ func TestVerifyJWT_InvalidString(t *testing.T) {
 func TestVerifyJWT_UserIDZero_ReturnsErrTokenNotValid(t *testing.T) {
 	requireJWTConfig(t)
 
-	j, err := CreateNewJWT(0)
+	j, err := CreateNewJWT(0, 1)
 	if err != nil {
 		t.Fatalf("CreateNewJWT error: %v", err)
 	}
@@ -1,13 +1,57 @@
 package token
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"server/internal/app/config"
 	"server/internal/pkg/logger"
+	"strings"
 )
 
+const (
+	selectorBytes = 16
+	// refreshTokenSep can't be a part of the URL-safe base64 selector
+	refreshTokenSep = "."
+)
+
+// CreateRefreshToken returns the verifier part of a refresh token, URL-safe so it survives JSON and headers
 func CreateRefreshToken() string {
-	return string(generateRandom(config.App.GetRefreshTokenLength()))
+	return base64.RawURLEncoding.EncodeToString(generateRandom(config.App.GetRefreshTokenLength()))
+}
+
+// HashVerifier returns the stored form of a verifier. The verifier is random and long so a keyed
+// SHA-256 is enough, a slow password hash would only cost CPU and memory on every refresh
+func HashVerifier(verifier string) string {
+	mac := hmac.New(sha256.New, []byte(config.App.GetRefreshTokenSecret()))
+	mac.Write([]byte(verifier))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyVerifier compares a verifier of the client with the stored hash in constant time
+func VerifyVerifier(verifier, hash string) bool {
+	return hmac.Equal([]byte(HashVerifier(verifier)), []byte(hash))
+}
+
+// CreateSelector returns the lookup part of a refresh token, it is stored in plain and names the session
+func CreateSelector() string {
+	return base64.RawURLEncoding.EncodeToString(generateRandom(selectorBytes))
+}
+
+// JoinRefreshToken builds the refresh token handed to the client from the selector and the verifier
+func JoinRefreshToken(selector, verifier string) string {
+	return selector + refreshTokenSep + verifier
+}
+
+// SplitRefreshToken parses a refresh token of the client, ok is false if a part is missing
+func SplitRefreshToken(refreshToken string) (selector, verifier string, ok bool) {
+	selector, verifier, ok = strings.Cut(refreshToken, refreshTokenSep)
+	if !ok || selector == "" || verifier == "" {
+		return "", "", false
+	}
+	return selector, verifier, true
 }
 
 func generateRandom(size int) []byte {
@@ -0,0 +1,115 @@
+package token
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"server/internal/app/config"
+	hasher "server/internal/pkg/hash/argon2"
+)
+
+func TestCreateRefreshToken_URLSafe(t *testing.T) {
+	config.InitTestConfig()
+
+	rt := CreateRefreshToken()
+	raw, err := base64.RawURLEncoding.DecodeString(rt)
+	if err != nil {
+		t.Fatalf("expected URL-safe base64, got %q: %v", rt, err)
+	}
+	if len(raw) != config.App.GetRefreshTokenLength() {
+		t.Fatalf("expected %d random bytes, got %d", config.App.GetRefreshTokenLength(), len(raw))
+	}
+	if strings.Contains(rt, refreshTokenSep) {
+		t.Fatalf("verifier must not hold the separator: %q", rt)
+	}
+}
+
+func TestHashVerifier_Verify(t *testing.T) {
+	config.InitTestConfig()
+
+	verifier := CreateRefreshToken()
+	hash := HashVerifier(verifier)
+
+	if hash == verifier || len(hash) != 64 {
+		t.Fatalf("expected hex SHA-256, got %q", hash)
+	}
+	if HashVerifier(verifier) != hash {
+		t.Fatal("expected the hash to be deterministic so it can be looked up")
+	}
+	if !VerifyVerifier(verifier, hash) {
+		t.Fatal("expected the verifier to match its hash")
+	}
+	if VerifyVerifier(CreateRefreshToken(), hash) {
+		t.Fatal("expected another verifier to be rejected")
+	}
+}
+
+func TestRefreshToken_JoinThenSplit(t *testing.T) {
+	selector := CreateSelector()
+	if selector == "" || strings.Contains(selector, refreshTokenSep) {
+		t.Fatalf("unexpected selector %q", selector)
+	}
+	if CreateSelector() == selector {
+		t.Fatal("expected selectors to be unique")
+	}
+
+	// the verifier may hold the separator too, the selector ends at the first one
+	gotSelector, gotVerifier, ok := SplitRefreshToken(JoinRefreshToken(selector, "ver.ifier"))
+	if !ok || gotSelector != selector || gotVerifier != "ver.ifier" {
+		t.Fatalf("SplitRefreshToken = %q, %q, %v", gotSelector, gotVerifier, ok)
+	}
+}
+
+func TestSplitRefreshToken_Invalid(t *testing.T) {
+	for _, s := range []string{"", "no-separator", ".verifier", "selector."} {
+		if _, _, ok := SplitRefreshToken(s); ok {
+			t.Fatalf("expected %q to be rejected", s)
+		}
+	}
+}
+
+// The refresh verifier used to be hashed with the Argon2id password params, compare with:
+//
+//	go test -bench=RefreshVerifier -benchmem ./internal/pkg/token/
+func BenchmarkRefreshVerifier(b *testing.B) {
+	config.InitTestConfig()
+	verifier := CreateRefreshToken()
+
+	b.Run("hmac-sha256/hash", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = HashVerifier(verifier)
+		}
+	})
+
+	b.Run("hmac-sha256/verify", func(b *testing.B) {
+		hash := HashVerifier(verifier)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if !VerifyVerifier(verifier, hash) {
+				b.Fatal("verify failed")
+			}
+		}
+	})
+
+	b.Run("argon2id/hash", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := hasher.HashString(verifier); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("argon2id/verify", func(b *testing.B) {
+		hash, err := hasher.HashString(verifier)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if ok, _ := hasher.VerifyString(verifier, hash); !ok {
+				b.Fatal("verify failed")
+			}
+		}
+	})
+}
@@ -0,0 +1,10 @@
+package totp
+
+import "errors"
+
+var (
+	ErrInvalidSecret    = errors.New("invalid totp secret")
+	ErrInvalidURI       = errors.New("invalid otpauth uri")
+	ErrUnsupportedAlgo  = errors.New("unsupported totp algorithm")
+	ErrUnsupportedParam = errors.New("unsupported totp parameter")
+)
@@ -0,0 +1,262 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	AlgorithmSHA1   = "SHA1"
+	AlgorithmSHA256 = "SHA256"
+	AlgorithmSHA512 = "SHA512"
+
+	DefaultDigits = 6
+	DefaultPeriod = 30 * time.Second
+)
+
+var b32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Key holds everything needed to compute RFC 6238 codes
+type Key struct {
+	Secret    []byte
+	Algorithm string
+	Digits    int
+	Period    time.Duration
+	Issuer    string
+	Account   string
+}
+
+// Parse accepts a base32 secret or an otpauth://totp/ URI
+func Parse(s string) (*Key, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(strings.ToLower(s), "otpauth://") {
+		return parseURI(s)
+	}
+
+	secret, err := DecodeSecret(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Key{
+		Secret:    secret,
+		Algorithm: AlgorithmSHA1,
+		Digits:    DefaultDigits,
+		Period:    DefaultPeriod,
+	}, nil
+}
+
+// DecodeSecret decodes base32 ignoring case, spaces and padding, the way authenticator apps show it
+func DecodeSecret(s string) ([]byte, error) {
+	s = strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(s))
+	s = strings.TrimRight(s, "=")
+
+	secret, err := b32.DecodeString(s)
+	if err != nil || len(secret) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return secret, nil
+}
+
+// EncodeSecret returns the unpadded base32 form of the secret
+func EncodeSecret(secret []byte) string {
+	return b32.EncodeToString(secret)
+}
+
+// GenerateSecret returns a random secret of size bytes, 20 is the RFC 4226 recommendation
+func GenerateSecret(size int) ([]byte, error) {
+	secret := make([]byte, size)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func parseURI(s string) (*Key, error) {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme != "otpauth" || !strings.EqualFold(u.Host, "totp") {
+		return nil, ErrInvalidURI
+	}
+
+	q := u.Query()
+
+	secret, err := DecodeSecret(q.Get("secret"))
+	if err != nil {
+		return nil, err
+	}
+
+	key := &Key{
+		Secret:    secret,
+		Algorithm: AlgorithmSHA1,
+		Digits:    DefaultDigits,
+		Period:    DefaultPeriod,
+		Issuer:    q.Get("issuer"),
+	}
+
+	// label is "issuer:account" or just "account"
+	label := strings.TrimPrefix(u.Path, "/")
+	if issuer, account, ok := strings.Cut(label, ":"); ok {
+		key.Account = strings.TrimSpace(account)
+		if key.Issuer == "" {
+			key.Issuer = issuer
+		}
+	} else {
+		key.Account = label
+	}
+
+	if v := q.Get("algorithm"); v != "" {
+		key.Algorithm = strings.ToUpper(v)
+		if _, err := key.hash(); err != nil {
+			return nil, err
+		}
+	}
+
+	if v := q.Get("digits"); v != "" {
+		d, err := strconv.Atoi(v)
+		if err != nil || d < 6 || d > 10 {
+			return nil, fmt.Errorf("digits=%s: %w", v, ErrUnsupportedParam)
+		}
+		key.Digits = d
+	}
+
+	if v := q.Get("period"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil || p <= 0 {
+			return nil, fmt.Errorf("period=%s: %w", v, ErrUnsupportedParam)
+		}
+		key.Period = time.Duration(p) * time.Second
+	}
+
+	return key, nil
+}
+
+// URI renders the key as an otpauth:// URI understood by authenticator apps
+func (k *Key) URI() string {
+	label := k.Account
+	if k.Issuer != "" {
+		label = k.Issuer + ":" + k.Account
+	}
+
+	q := url.Values{}
+	q.Set("secret", EncodeSecret(k.Secret))
+	if k.Issuer != "" {
+		q.Set("issuer", k.Issuer)
+	}
+	q.Set("algorithm", k.Algorithm)
+	q.Set("digits", strconv.Itoa(k.Digits))
+	q.Set("period", strconv.Itoa(int(k.Period/time.Second)))
+
+	u := url.URL{Scheme: "otpauth", Host: "totp", Path: "/" + label, RawQuery: q.Encode()}
+	return u.String()
+}
+
+// Code returns the code valid at t
+func (k *Key) Code(t time.Time) (string, error) {
+	return k.codeAt(k.counter(t))
+}
+
+// Remaining is how long the code valid at t stays valid
+func (k *Key) Remaining(t time.Time) time.Duration {
+	period := k.period()
+	elapsed := time.Duration(t.UnixNano()) % period
+	return period - elapsed
+}
+
+// Validate checks code against the time step of t and skew steps around it
+func (k *Key) Validate(code string, t time.Time, skew int) bool {
+	_, ok := k.Match(code, t, skew)
+	return ok
+}
+
+// Match is Validate that also returns the time step of the code, callers keep it
+// to refuse the same code twice
+func (k *Key) Match(code string, t time.Time, skew int) (uint64, bool) {
+	if len(code) != k.digits() {
+		return 0, false
+	}
+
+	counter := k.counter(t)
+	for i := -skew; i <= skew; i++ {
+		c := int64(counter) + int64(i)
+		if c < 0 {
+			continue
+		}
+		expected, err := k.codeAt(uint64(c))
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return uint64(c), true
+		}
+	}
+	return 0, false
+}
+
+func (k *Key) counter(t time.Time) uint64 {
+	return uint64(t.Unix()) / uint64(k.period()/time.Second)
+}
+
+// codeAt is HOTP (RFC 4226) with the configured hash
+func (k *Key) codeAt(counter uint64) (string, error) {
+	newHash, err := k.hash()
+	if err != nil {
+		return "", err
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(newHash, k.Secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	bin := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	digits := k.digits()
+	mod := uint64(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", digits, uint64(bin)%mod), nil
+}
+
+func (k *Key) hash() (func() hash.Hash, error) {
+	switch strings.ToUpper(k.Algorithm) {
+	case "", AlgorithmSHA1:
+		return sha1.New, nil
+	case AlgorithmSHA256:
+		return sha256.New, nil
+	case AlgorithmSHA512:
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("%s: %w", k.Algorithm, ErrUnsupportedAlgo)
+	}
+}
+
+func (k *Key) digits() int {
+	if k.Digits <= 0 {
+		return DefaultDigits
+	}
+	return k.Digits
+}
+
+func (k *Key) period() time.Duration {
+	if k.Period < time.Second {
+		return DefaultPeriod
+	}
+	return k.Period
+}
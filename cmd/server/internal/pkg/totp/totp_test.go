@@ -0,0 +1,201 @@
+package totp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 Appendix B, the seed is repeated to the hash block size
+var rfcSeeds = map[string][]byte{
+	AlgorithmSHA1:   []byte("12345678901234567890"),
+	AlgorithmSHA256: []byte("12345678901234567890123456789012"),
+	AlgorithmSHA512: []byte("1234567890123456789012345678901234567890123456789012345678901234"),
+}
+
+func TestKey_Code_RFC6238Vectors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		unix int64
+		algo string
+		code string
+	}{
+		{59, AlgorithmSHA1, "94287082"},
+		{59, AlgorithmSHA256, "46119246"},
+		{59, AlgorithmSHA512, "90693936"},
+		{1111111109, AlgorithmSHA1, "07081804"},
+		{1111111109, AlgorithmSHA256, "68084774"},
+		{1111111109, AlgorithmSHA512, "25091201"},
+		{1111111111, AlgorithmSHA1, "14050471"},
+		{1111111111, AlgorithmSHA256, "67062674"},
+		{1111111111, AlgorithmSHA512, "99943326"},
+		{1234567890, AlgorithmSHA1, "89005924"},
+		{1234567890, AlgorithmSHA256, "91819424"},
+		{1234567890, AlgorithmSHA512, "93441116"},
+		{2000000000, AlgorithmSHA1, "69279037"},
+		{2000000000, AlgorithmSHA256, "90698825"},
+		{2000000000, AlgorithmSHA512, "38618901"},
+		{20000000000, AlgorithmSHA1, "65353130"},
+		{20000000000, AlgorithmSHA256, "77737706"},
+		{20000000000, AlgorithmSHA512, "47863826"},
+	}
+
+	for _, tt := range tests {
+		key := &Key{Secret: rfcSeeds[tt.algo], Algorithm: tt.algo, Digits: 8, Period: 30 * time.Second}
+
+		got, err := key.Code(time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatalf("Code(%d, %s) error: %v", tt.unix, tt.algo, err)
+		}
+		if got != tt.code {
+			t.Fatalf("Code(%d, %s): expected %s, got %s", tt.unix, tt.algo, tt.code, got)
+		}
+	}
+}
+
+func TestParse_Secret(t *testing.T) {
+	t.Parallel()
+
+	// base32 of "12345678901234567890"
+	key, err := Parse("gezd gnbv gy3t qojq gezd gnbv gy3t qojq")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if string(key.Secret) != "12345678901234567890" {
+		t.Fatalf("unexpected secret %q", key.Secret)
+	}
+	if key.Digits != 6 || key.Period != 30*time.Second || key.Algorithm != AlgorithmSHA1 {
+		t.Fatalf("unexpected defaults: %+v", key)
+	}
+
+	code, err := key.Code(time.Unix(59, 0))
+	if err != nil || code != "287082" {
+		t.Fatalf("expected 6 digit RFC code 287082, got %q, %v", code, err)
+	}
+}
+
+func TestParse_URI(t *testing.T) {
+	t.Parallel()
+
+	key, err := Parse("otpauth://totp/ACME%20Co:john@example.com?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZA&issuer=ACME%20Co&algorithm=SHA256&digits=8&period=60")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if key.Issuer != "ACME Co" || key.Account != "john@example.com" {
+		t.Fatalf("unexpected label: issuer=%q account=%q", key.Issuer, key.Account)
+	}
+	if key.Algorithm != AlgorithmSHA256 || key.Digits != 8 || key.Period != time.Minute {
+		t.Fatalf("unexpected params: %+v", key)
+	}
+
+	again, err := Parse(key.URI())
+	if err != nil {
+		t.Fatalf("Parse(URI()) error: %v", err)
+	}
+	if string(again.Secret) != string(key.Secret) || again.Issuer != key.Issuer || again.Period != key.Period {
+		t.Fatalf("round trip mismatch: %+v vs %+v", again, key)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		want error
+	}{
+		{"empty", "", ErrInvalidSecret},
+		{"not base32", "not a secret!", ErrInvalidSecret},
+		{"hotp uri", "otpauth://hotp/x?secret=GEZDGNBV", ErrInvalidURI},
+		{"uri without secret", "otpauth://totp/x", ErrInvalidSecret},
+		{"md5", "otpauth://totp/x?secret=GEZDGNBV&algorithm=MD5", ErrUnsupportedAlgo},
+		{"digits", "otpauth://totp/x?secret=GEZDGNBV&digits=4", ErrUnsupportedParam},
+		{"period", "otpauth://totp/x?secret=GEZDGNBV&period=0", ErrUnsupportedParam},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := Parse(tt.in); !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got: %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestKey_Remaining(t *testing.T) {
+	t.Parallel()
+
+	key := &Key{Secret: rfcSeeds[AlgorithmSHA1]}
+
+	if got := key.Remaining(time.Unix(59, 0)); got != time.Second {
+		t.Fatalf("expected 1s, got %v", got)
+	}
+	if got := key.Remaining(time.Unix(60, 0)); got != 30*time.Second {
+		t.Fatalf("expected 30s, got %v", got)
+	}
+}
+
+func TestKey_Validate(t *testing.T) {
+	t.Parallel()
+
+	key := &Key{Secret: rfcSeeds[AlgorithmSHA1], Digits: 8}
+	now := time.Unix(1111111111, 0)
+
+	if !key.Validate("14050471", now, 0) {
+		t.Fatalf("expected current code to be valid")
+	}
+	// 1111111109 is the previous step
+	if key.Validate("07081804", now, 0) {
+		t.Fatalf("expected previous code to be rejected without skew")
+	}
+	if !key.Validate("07081804", now, 1) {
+		t.Fatalf("expected previous code to be accepted with skew=1")
+	}
+	if key.Validate("1405047", now, 1) {
+		t.Fatalf("expected short code to be rejected")
+	}
+}
+
+func TestKey_Match(t *testing.T) {
+	t.Parallel()
+
+	key := &Key{Secret: rfcSeeds[AlgorithmSHA1], Digits: 8}
+	now := time.Unix(1111111111, 0)
+	step := uint64(1111111111 / 30)
+
+	if c, ok := key.Match("14050471", now, 1); !ok || c != step {
+		t.Fatalf("Match current = %d, %v, want %d", c, ok, step)
+	}
+	if c, ok := key.Match("07081804", now, 1); !ok || c != step-1 {
+		t.Fatalf("Match previous = %d, %v, want %d", c, ok, step-1)
+	}
+	if _, ok := key.Match("00000000", now, 1); ok {
+		t.Fatalf("expected wrong code to be rejected")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	t.Parallel()
+
+	secret, err := GenerateSecret(20)
+	if err != nil {
+		t.Fatalf("GenerateSecret error: %v", err)
+	}
+	if len(secret) != 20 {
+		t.Fatalf("expected 20 bytes, got %d", len(secret))
+	}
+
+	encoded := EncodeSecret(secret)
+	if strings.Contains(encoded, "=") {
+		t.Fatalf("expected unpadded base32, got %q", encoded)
+	}
+	decoded, err := DecodeSecret(strings.ToLower(encoded))
+	if err != nil || string(decoded) != string(secret) {
+		t.Fatalf("decode round trip failed: %v", err)
+	}
+}
@@ -0,0 +1,12 @@
+package x509cert
+
+import "errors"
+
+var (
+	ErrNoCertificate = errors.New("no certificate found in PEM data")
+	ErrInvalidPEM    = errors.New("invalid PEM data")
+	ErrInvalidKey    = errors.New("invalid or unsupported private key")
+	ErrEncryptedKey  = errors.New("encrypted private keys are not supported")
+	ErrMultipleKeys  = errors.New("more than one private key in PEM data")
+	ErrKeyMismatch   = errors.New("private key does not match the certificate")
+)
@@ -0,0 +1,163 @@
+// Package x509cert parses PEM bundles of X.509 certificates and their private keys.
+package x509cert
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Bundle is a parsed certificate chain, the first certificate is the leaf
+type Bundle struct {
+	// Certificate is the PEM encoded chain, leaf first
+	Certificate string
+	// PrivateKey is the PKCS #8 PEM encoded key of the leaf, empty when the bundle has none
+	PrivateKey string
+
+	Subject      string
+	Issuer       string
+	SANs         []string
+	SerialNumber string
+	// Fingerprint is the SHA-256 of the DER encoded leaf certificate
+	Fingerprint string
+	NotBefore   time.Time
+	NotAfter    time.Time
+}
+
+// Parse reads the certificates and the private key from bundle and key, the key
+// may be a part of the bundle as well. When a key is present it must match the
+// leaf certificate.
+func Parse(bundle, key string) (*Bundle, error) {
+	var (
+		certs   []*x509.Certificate
+		private crypto.Signer
+	)
+
+	rest := []byte(bundle + "\n" + key)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		switch {
+		case block.Type == "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidPEM, err)
+			}
+			certs = append(certs, cert)
+
+		case block.Type == "ENCRYPTED PRIVATE KEY" || block.Headers["Proc-Type"] != "":
+			return nil, ErrEncryptedKey
+
+		case strings.HasSuffix(block.Type, "PRIVATE KEY"):
+			if private != nil {
+				return nil, ErrMultipleKeys
+			}
+			k, err := parsePrivateKey(block)
+			if err != nil {
+				return nil, err
+			}
+			private = k
+		}
+	}
+
+	if len(bytes.TrimSpace(rest)) != 0 {
+		return nil, ErrInvalidPEM
+	}
+
+	if len(certs) == 0 {
+		return nil, ErrNoCertificate
+	}
+
+	leaf := certs[0]
+
+	b := &Bundle{
+		Subject:      leaf.Subject.String(),
+		Issuer:       leaf.Issuer.String(),
+		SANs:         sans(leaf),
+		SerialNumber: leaf.SerialNumber.Text(16),
+		Fingerprint:  fingerprint(leaf),
+		NotBefore:    leaf.NotBefore.UTC(),
+		NotAfter:     leaf.NotAfter.UTC(),
+	}
+
+	var chain bytes.Buffer
+	for _, c := range certs {
+		_ = pem.Encode(&chain, &pem.Block{Type: "CERTIFICATE", Bytes: c.Raw})
+	}
+	b.Certificate = chain.String()
+
+	if private != nil {
+		if !matches(private, leaf) {
+			return nil, ErrKeyMismatch
+		}
+
+		der, err := x509.MarshalPKCS8PrivateKey(private)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+		}
+		b.PrivateKey = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	}
+
+	return b, nil
+}
+
+// help func
+
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	var (
+		key any
+		err error
+	)
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+	return signer, nil
+}
+
+func matches(key crypto.Signer, cert *x509.Certificate) bool {
+	pub, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+	return ok && pub.Equal(cert.PublicKey)
+}
+
+// sans lists DNS names, IP addresses, emails and URIs of the certificate
+func sans(cert *x509.Certificate) []string {
+	out := make([]string, 0, len(cert.DNSNames)+len(cert.IPAddresses)+len(cert.EmailAddresses)+len(cert.URIs))
+	out = append(out, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		out = append(out, ip.String())
+	}
+	out = append(out, cert.EmailAddresses...)
+	for _, u := range cert.URIs {
+		out = append(out, u.String())
+	}
+	return out
+}
+
+func fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return "SHA256:" + strings.ToUpper(hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,163 @@
+package x509cert
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newCert(t *testing.T, cn string, key crypto.Signer, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, string) {
+	t.Helper()
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(0x1234),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:              time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		DNSNames:              []string{cn, "www." + cn},
+		IPAddresses:           []net.IP{net.ParseIP("10.0.0.1")},
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+	}
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate: %v", err)
+	}
+	return cert, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func newECKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey: %v", err)
+	}
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+}
+
+func TestParse_ChainWithKey(t *testing.T) {
+	t.Parallel()
+
+	caKey, _ := newECKey(t)
+	ca, caPEM := newCert(t, "Test CA", caKey, nil, nil)
+
+	leafKey, leafKeyPEM := newECKey(t)
+	_, leafPEM := newCert(t, "example.com", leafKey, ca, caKey)
+
+	b, err := Parse(leafPEM+caPEM, leafKeyPEM)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if b.Subject != "CN=example.com" || b.Issuer != "CN=Test CA" {
+		t.Fatalf("unexpected subject/issuer: %q / %q", b.Subject, b.Issuer)
+	}
+	if got := strings.Join(b.SANs, ","); got != "example.com,www.example.com,10.0.0.1" {
+		t.Fatalf("unexpected SANs: %s", got)
+	}
+	if !b.NotAfter.Equal(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected NotAfter: %v", b.NotAfter)
+	}
+	if b.SerialNumber != "1234" || !strings.HasPrefix(b.Fingerprint, "SHA256:") {
+		t.Fatalf("unexpected serial/fingerprint: %s %s", b.SerialNumber, b.Fingerprint)
+	}
+	if strings.Count(b.Certificate, "BEGIN CERTIFICATE") != 2 {
+		t.Fatalf("expected chain of 2 certificates, got:\n%s", b.Certificate)
+	}
+	if !strings.Contains(b.PrivateKey, "BEGIN PRIVATE KEY") {
+		t.Fatalf("expected PKCS #8 key, got:\n%s", b.PrivateKey)
+	}
+}
+
+func TestParse_KeyInBundle_RSA(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	_, certPEM := newCert(t, "rsa.example.com", key, nil, nil)
+
+	b, err := Parse(keyPEM+certPEM, "")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if b.PrivateKey == "" {
+		t.Fatalf("expected private key from the bundle")
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	t.Parallel()
+
+	key, keyPEM := newECKey(t)
+	_, certPEM := newCert(t, "example.com", key, nil, nil)
+	_, otherKeyPEM := newECKey(t)
+
+	tests := []struct {
+		name    string
+		bundle  string
+		key     string
+		wantErr error
+	}{
+		{name: "certificate only -> ok", bundle: certPEM},
+		{name: "not PEM -> ErrInvalidPEM", bundle: "hello", wantErr: ErrInvalidPEM},
+		{name: "key only -> ErrNoCertificate", bundle: keyPEM, wantErr: ErrNoCertificate},
+		{name: "other key -> ErrKeyMismatch", bundle: certPEM, key: otherKeyPEM, wantErr: ErrKeyMismatch},
+		{name: "two keys -> ErrMultipleKeys", bundle: certPEM + keyPEM, key: keyPEM, wantErr: ErrMultipleKeys},
+		{
+			name:    "encrypted key -> ErrEncryptedKey",
+			bundle:  certPEM,
+			key:     string(pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte{1}})),
+			wantErr: ErrEncryptedKey,
+		},
+		{
+			name:    "broken key -> ErrInvalidKey",
+			bundle:  certPEM,
+			key:     string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte{1, 2, 3}})),
+			wantErr: ErrInvalidKey,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse(tc.bundle, tc.key)
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
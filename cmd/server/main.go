@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"os"
 	"server/internal/app"
 	"server/internal/app/config"
 	"server/internal/pkg/logger"
@@ -18,6 +21,22 @@ func main() {
 		return
 	}
 
+	if config.App.GetReconcileRunOnce() {
+		report, err := application.Reconcile(context.Background())
+		if err != nil {
+			logger.Log.Error("Reconciliation failed", zap.Error(err))
+		}
+		if report != nil {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			_ = enc.Encode(report)
+		}
+		if err != nil || !report.Clean() {
+			os.Exit(1)
+		}
+		return
+	}
+
 	err = application.Start()
 	if err != nil {
 		logger.Log.Error("Failed to start application", zap.Error(err))
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE file_data
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'ready';
+
+ALTER TABLE file_data
+    ADD CONSTRAINT chk_file_data_status CHECK (status IN ('pending', 'ready'));
+
+-- pending object storage operations, written in the same transaction as file_data
+-- no FK to file_data or users: delete operations must outlive the rows they clean up after
+CREATE TABLE IF NOT EXISTS file_operations (
+                                               id              BIGSERIAL PRIMARY KEY,
+                                               operation       TEXT NOT NULL,
+                                               file_id         BIGINT,
+
+                                               bucket_name     TEXT NOT NULL,
+                                               object_key      TEXT NOT NULL,
+                                               size_bytes      BIGINT NOT NULL DEFAULT 0,
+
+                                               attempts        INT NOT NULL DEFAULT 0,
+                                               last_error      TEXT,
+                                               created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                               next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+                                               CONSTRAINT chk_file_operations_operation CHECK (operation IN ('upload', 'delete')),
+                                               CONSTRAINT uq_file_operations_object UNIQUE (operation, bucket_name, object_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_file_operations_next_attempt ON file_operations (next_attempt_at);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS file_operations;
+
+ALTER TABLE file_data DROP CONSTRAINT IF EXISTS chk_file_data_status;
+ALTER TABLE file_data DROP COLUMN IF EXISTS status;
+
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- pid was an opaque encrypted card number
+ALTER TABLE bank_data RENAME COLUMN pid TO number;
+
+ALTER TABLE bank_data
+    ADD COLUMN IF NOT EXISTS holder_name  TEXT,
+    ADD COLUMN IF NOT EXISTS expiry_month SMALLINT,
+    ADD COLUMN IF NOT EXISTS expiry_year  SMALLINT,
+    ADD COLUMN IF NOT EXISTS cvv          BYTEA,
+    ADD COLUMN IF NOT EXISTS pin          BYTEA,
+    ADD COLUMN IF NOT EXISTS notes        TEXT;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE bank_data
+    DROP COLUMN IF EXISTS holder_name,
+    DROP COLUMN IF EXISTS expiry_month,
+    DROP COLUMN IF EXISTS expiry_year,
+    DROP COLUMN IF EXISTS cvv,
+    DROP COLUMN IF EXISTS pin,
+    DROP COLUMN IF EXISTS notes;
+
+ALTER TABLE bank_data RENAME COLUMN number TO pid;
+
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- encrypted base32 secret or otpauth:// URI
+ALTER TABLE account_data
+    ADD COLUMN IF NOT EXISTS totp_secret BYTEA;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE account_data
+    DROP COLUMN IF EXISTS totp_secret;
+
+-- +goose StatementEnd
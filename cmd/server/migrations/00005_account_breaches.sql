@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- how many times the password was seen in the pwned passwords dataset, 0 when not listed
+ALTER TABLE account_data
+    ADD COLUMN IF NOT EXISTS breach_count INTEGER NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE account_data
+    DROP COLUMN IF EXISTS breach_count;
+
+-- +goose StatementEnd
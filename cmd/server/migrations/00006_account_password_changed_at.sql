@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- existing passwords are considered changed at migration time
+ALTER TABLE account_data
+    ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE account_data
+    DROP COLUMN IF EXISTS password_changed_at;
+
+-- +goose StatementEnd
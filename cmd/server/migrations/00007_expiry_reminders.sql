@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- optional expiry date of every object type, NULL means the secret does not expire
+ALTER TABLE account_data ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+ALTER TABLE bank_data    ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+ALTER TABLE text_data    ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+ALTER TABLE file_data    ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+
+-- existing cards expire after the last day of their expiry month
+UPDATE bank_data
+SET expires_at = make_timestamptz(expiry_year, expiry_month, 1, 0, 0, 0, 'UTC') + INTERVAL '1 month'
+WHERE expires_at IS NULL AND expiry_month IS NOT NULL AND expiry_year IS NOT NULL;
+
+-- reminders about expiring objects, one per object and expiry date
+-- no FK to the object tables: the object type is part of the key
+CREATE TABLE IF NOT EXISTS notifications (
+                                             id          BIGSERIAL PRIMARY KEY,
+                                             user_id     BIGINT NOT NULL,
+
+                                             object_type TEXT NOT NULL,
+                                             object_id   BIGINT NOT NULL,
+                                             title       TEXT,
+                                             expires_at  TIMESTAMPTZ NOT NULL,
+
+                                             created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                             read_at     TIMESTAMPTZ,
+
+                                             CONSTRAINT fk_notifications_user
+                                                 FOREIGN KEY (user_id)
+                                                     REFERENCES users(id)
+                                                     ON DELETE CASCADE,
+
+                                             CONSTRAINT chk_notifications_object_type CHECK (object_type IN ('account', 'card', 'text', 'file')),
+                                             CONSTRAINT uq_notifications_object UNIQUE (object_type, object_id, expires_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user ON notifications (user_id, read_at);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS notifications;
+
+ALTER TABLE file_data    DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE text_data    DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE bank_data    DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE account_data DROP COLUMN IF EXISTS expires_at;
+
+-- +goose StatementEnd
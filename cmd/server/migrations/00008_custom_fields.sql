@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ordered list of {name, type, value}, hidden values are encrypted by the application
+ALTER TABLE account_data ADD COLUMN IF NOT EXISTS custom_fields JSONB NOT NULL DEFAULT '[]'::jsonb;
+ALTER TABLE bank_data    ADD COLUMN IF NOT EXISTS custom_fields JSONB NOT NULL DEFAULT '[]'::jsonb;
+ALTER TABLE text_data    ADD COLUMN IF NOT EXISTS custom_fields JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE text_data    DROP COLUMN IF EXISTS custom_fields;
+ALTER TABLE bank_data    DROP COLUMN IF EXISTS custom_fields;
+ALTER TABLE account_data DROP COLUMN IF EXISTS custom_fields;
+
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS ssh_key_data (
+                                            id          BIGSERIAL PRIMARY KEY,
+                                            user_id     BIGINT NOT NULL,
+
+                                            title       TEXT NOT NULL,
+                                            key_type    TEXT NOT NULL,
+                                            -- OpenSSH PEM, encrypted by the application
+                                            private_key BYTEA NOT NULL,
+                                            public_key  TEXT NOT NULL,
+                                            fingerprint TEXT NOT NULL,
+                                            comment     TEXT,
+
+                                            created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+                                            CONSTRAINT fk_ssh_key_data_user
+                                                FOREIGN KEY (user_id)
+                                                    REFERENCES users(id)
+                                                    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_ssh_key_data_user ON ssh_key_data (user_id);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS ssh_key_data;
+
+-- +goose StatementEnd
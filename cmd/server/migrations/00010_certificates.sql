@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS cert_data (
+                                         id            BIGSERIAL PRIMARY KEY,
+                                         user_id       BIGINT NOT NULL,
+
+                                         title         TEXT NOT NULL,
+                                         -- PEM chain, leaf first
+                                         certificate   TEXT NOT NULL,
+                                         -- PKCS #8 PEM, encrypted by the application, NULL for certificates without a key
+                                         private_key   BYTEA,
+
+                                         -- fields of the leaf certificate, kept in columns for search and expiry tracking
+                                         subject       TEXT NOT NULL,
+                                         issuer        TEXT NOT NULL,
+                                         -- newline separated DNS names, IP addresses, emails and URIs
+                                         sans          TEXT NOT NULL DEFAULT '',
+                                         serial_number TEXT NOT NULL,
+                                         fingerprint   TEXT NOT NULL,
+                                         not_before    TIMESTAMPTZ NOT NULL,
+                                         not_after     TIMESTAMPTZ NOT NULL,
+
+                                         created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+                                         CONSTRAINT fk_cert_data_user
+                                             FOREIGN KEY (user_id)
+                                                 REFERENCES users(id)
+                                                 ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_cert_data_user_not_after ON cert_data (user_id, not_after);
+
+-- certificates take part in expiry reminders
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS chk_notifications_object_type;
+ALTER TABLE notifications ADD CONSTRAINT chk_notifications_object_type
+    CHECK (object_type IN ('account', 'card', 'text', 'file', 'cert'));
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM notifications WHERE object_type = 'cert';
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS chk_notifications_object_type;
+ALTER TABLE notifications ADD CONSTRAINT chk_notifications_object_type
+    CHECK (object_type IN ('account', 'card', 'text', 'file'));
+
+DROP TABLE IF EXISTS cert_data;
+
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- links between files and the items they belong to, exactly one item column is set.
+-- Deleting a file or an item removes its links, files themselves are kept.
+CREATE TABLE IF NOT EXISTS attachments (
+                                           id         BIGSERIAL PRIMARY KEY,
+                                           user_id    BIGINT NOT NULL,
+                                           file_id    BIGINT NOT NULL,
+
+                                           account_id BIGINT,
+                                           bank_id    BIGINT,
+                                           text_id    BIGINT,
+
+                                           created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+                                           CONSTRAINT fk_attachments_user
+                                               FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+                                           CONSTRAINT fk_attachments_file
+                                               FOREIGN KEY (file_id) REFERENCES file_data(id) ON DELETE CASCADE,
+                                           CONSTRAINT fk_attachments_account
+                                               FOREIGN KEY (account_id) REFERENCES account_data(id) ON DELETE CASCADE,
+                                           CONSTRAINT fk_attachments_bank
+                                               FOREIGN KEY (bank_id) REFERENCES bank_data(id) ON DELETE CASCADE,
+                                           CONSTRAINT fk_attachments_text
+                                               FOREIGN KEY (text_id) REFERENCES text_data(id) ON DELETE CASCADE,
+
+                                           CONSTRAINT chk_attachments_one_item CHECK (num_nonnulls(account_id, bank_id, text_id) = 1)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_attachments_account ON attachments (account_id, file_id) WHERE account_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_attachments_bank    ON attachments (bank_id, file_id)    WHERE bank_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_attachments_text    ON attachments (text_id, file_id)    WHERE text_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_attachments_file ON attachments (file_id);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS attachments;
+
+-- +goose StatementEnd
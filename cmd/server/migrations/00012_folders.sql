@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- user folders, a folder without parent lives in the root.
+-- Deleting a folder removes its subfolders, objects inside fall back to the root.
+CREATE TABLE IF NOT EXISTS folders (
+                                       id         BIGSERIAL PRIMARY KEY,
+                                       user_id    BIGINT NOT NULL,
+                                       parent_id  BIGINT,
+
+                                       name       TEXT NOT NULL,
+                                       created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+                                       CONSTRAINT fk_folders_user
+                                           FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+                                       CONSTRAINT fk_folders_parent
+                                           FOREIGN KEY (parent_id) REFERENCES folders(id) ON DELETE CASCADE,
+                                       CONSTRAINT chk_folders_not_self CHECK (parent_id IS NULL OR parent_id <> id)
+);
+
+-- names are unique among siblings, root folders share parent 0
+CREATE UNIQUE INDEX IF NOT EXISTS uq_folders_name ON folders (user_id, COALESCE(parent_id, 0), lower(name));
+CREATE INDEX IF NOT EXISTS idx_folders_parent ON folders (parent_id);
+
+-- every object belongs to at most one folder
+ALTER TABLE account_data ADD COLUMN IF NOT EXISTS folder_id BIGINT REFERENCES folders(id) ON DELETE SET NULL;
+ALTER TABLE bank_data    ADD COLUMN IF NOT EXISTS folder_id BIGINT REFERENCES folders(id) ON DELETE SET NULL;
+ALTER TABLE text_data    ADD COLUMN IF NOT EXISTS folder_id BIGINT REFERENCES folders(id) ON DELETE SET NULL;
+ALTER TABLE file_data    ADD COLUMN IF NOT EXISTS folder_id BIGINT REFERENCES folders(id) ON DELETE SET NULL;
+ALTER TABLE ssh_key_data ADD COLUMN IF NOT EXISTS folder_id BIGINT REFERENCES folders(id) ON DELETE SET NULL;
+ALTER TABLE cert_data    ADD COLUMN IF NOT EXISTS folder_id BIGINT REFERENCES folders(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_account_data_folder ON account_data (folder_id);
+CREATE INDEX IF NOT EXISTS idx_bank_data_folder    ON bank_data (folder_id);
+CREATE INDEX IF NOT EXISTS idx_text_data_folder    ON text_data (folder_id);
+CREATE INDEX IF NOT EXISTS idx_file_data_folder    ON file_data (folder_id);
+CREATE INDEX IF NOT EXISTS idx_ssh_key_data_folder ON ssh_key_data (folder_id);
+CREATE INDEX IF NOT EXISTS idx_cert_data_folder    ON cert_data (folder_id);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE account_data DROP COLUMN IF EXISTS folder_id;
+ALTER TABLE bank_data    DROP COLUMN IF EXISTS folder_id;
+ALTER TABLE text_data    DROP COLUMN IF EXISTS folder_id;
+ALTER TABLE file_data    DROP COLUMN IF EXISTS folder_id;
+ALTER TABLE ssh_key_data DROP COLUMN IF EXISTS folder_id;
+ALTER TABLE cert_data    DROP COLUMN IF EXISTS folder_id;
+
+DROP TABLE IF EXISTS folders;
+
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- items shared with other users, exactly one item column is set.
+-- Deleting the item or either user removes the share.
+CREATE TABLE IF NOT EXISTS shares (
+                                      id         BIGSERIAL PRIMARY KEY,
+                                      owner_id   BIGINT NOT NULL,
+                                      grantee_id BIGINT NOT NULL,
+
+                                      account_id BIGINT,
+                                      bank_id    BIGINT,
+                                      text_id    BIGINT,
+
+                                      permission TEXT NOT NULL,
+                                      created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+                                      CONSTRAINT fk_shares_owner
+                                          FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE,
+                                      CONSTRAINT fk_shares_grantee
+                                          FOREIGN KEY (grantee_id) REFERENCES users(id) ON DELETE CASCADE,
+                                      CONSTRAINT fk_shares_account
+                                          FOREIGN KEY (account_id) REFERENCES account_data(id) ON DELETE CASCADE,
+                                      CONSTRAINT fk_shares_bank
+                                          FOREIGN KEY (bank_id) REFERENCES bank_data(id) ON DELETE CASCADE,
+                                      CONSTRAINT fk_shares_text
+                                          FOREIGN KEY (text_id) REFERENCES text_data(id) ON DELETE CASCADE,
+
+                                      CONSTRAINT chk_shares_one_item CHECK (num_nonnulls(account_id, bank_id, text_id) = 1),
+                                      CONSTRAINT chk_shares_permission CHECK (permission IN ('read', 'write')),
+                                      CONSTRAINT chk_shares_not_owner CHECK (owner_id <> grantee_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_shares_account ON shares (account_id, grantee_id) WHERE account_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_shares_bank    ON shares (bank_id, grantee_id)    WHERE bank_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_shares_text    ON shares (text_id, grantee_id)    WHERE text_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_shares_grantee ON shares (grantee_id);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS shares;
+
+-- +goose StatementEnd
@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- shared vaults of a team, items with org_id set belong to the organization
+-- and are visible to its members according to their role
+CREATE TABLE IF NOT EXISTS organizations (
+                                             id         BIGSERIAL PRIMARY KEY,
+                                             name       TEXT NOT NULL,
+                                             created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS org_members (
+                                           org_id     BIGINT NOT NULL,
+                                           user_id    BIGINT NOT NULL,
+                                           role       TEXT NOT NULL,
+                                           created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+                                           PRIMARY KEY (org_id, user_id),
+
+                                           CONSTRAINT fk_org_members_org
+                                               FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE,
+                                           CONSTRAINT fk_org_members_user
+                                               FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+
+                                           CONSTRAINT chk_org_members_role CHECK (role IN ('owner', 'admin', 'editor', 'viewer'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_org_members_user ON org_members (user_id);
+
+-- user_id of an org item is the member who created it
+ALTER TABLE account_data ADD COLUMN IF NOT EXISTS org_id BIGINT REFERENCES organizations(id) ON DELETE CASCADE;
+ALTER TABLE bank_data    ADD COLUMN IF NOT EXISTS org_id BIGINT REFERENCES organizations(id) ON DELETE CASCADE;
+ALTER TABLE text_data    ADD COLUMN IF NOT EXISTS org_id BIGINT REFERENCES organizations(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_account_data_org ON account_data (org_id);
+CREATE INDEX IF NOT EXISTS idx_bank_data_org    ON bank_data (org_id);
+CREATE INDEX IF NOT EXISTS idx_text_data_org    ON text_data (org_id);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE account_data DROP COLUMN IF EXISTS org_id;
+ALTER TABLE bank_data    DROP COLUMN IF EXISTS org_id;
+ALTER TABLE text_data    DROP COLUMN IF EXISTS org_id;
+
+DROP TABLE IF EXISTS org_members;
+DROP TABLE IF EXISTS organizations;
+
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- trusted contacts that may request access to the personal vault of the grantor.
+-- A request is granted once wait_seconds pass without a rejection or when the grantor approves it.
+CREATE TABLE IF NOT EXISTS emergency_contacts (
+                                                  id           BIGSERIAL PRIMARY KEY,
+                                                  grantor_id   BIGINT NOT NULL,
+                                                  grantee_id   BIGINT NOT NULL,
+
+                                                  access_type  TEXT NOT NULL,
+                                                  wait_seconds BIGINT NOT NULL,
+                                                  status       TEXT NOT NULL DEFAULT 'idle',
+                                                  requested_at TIMESTAMPTZ,
+                                                  created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+                                                  CONSTRAINT fk_emergency_grantor
+                                                      FOREIGN KEY (grantor_id) REFERENCES users(id) ON DELETE CASCADE,
+                                                  CONSTRAINT fk_emergency_grantee
+                                                      FOREIGN KEY (grantee_id) REFERENCES users(id) ON DELETE CASCADE,
+
+                                                  CONSTRAINT uq_emergency_contacts UNIQUE (grantor_id, grantee_id),
+                                                  CONSTRAINT chk_emergency_access_type CHECK (access_type IN ('view', 'takeover')),
+                                                  CONSTRAINT chk_emergency_status CHECK (status IN ('idle', 'requested', 'approved', 'rejected')),
+                                                  CONSTRAINT chk_emergency_wait CHECK (wait_seconds > 0),
+                                                  CONSTRAINT chk_emergency_not_self CHECK (grantor_id <> grantee_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_emergency_contacts_grantee ON emergency_contacts (grantee_id);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS emergency_contacts;
+
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- one-time secrets for people outside the system, the key to the ciphertext
+-- is only in the fragment of the link and never reaches the server
+CREATE TABLE IF NOT EXISTS secret_links (
+                                            id         TEXT PRIMARY KEY,
+                                            user_id    BIGINT NOT NULL,
+                                            label      TEXT NOT NULL DEFAULT '',
+                                            ciphertext BYTEA NOT NULL,
+                                            max_views  INT NOT NULL,
+                                            views      INT NOT NULL DEFAULT 0,
+                                            expires_at TIMESTAMPTZ NOT NULL,
+                                            created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+                                            CONSTRAINT fk_secret_links_user
+                                                FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+
+                                            CONSTRAINT chk_secret_links_views CHECK (max_views > 0 AND views >= 0 AND views <= max_views)
+);
+
+CREATE INDEX IF NOT EXISTS idx_secret_links_user    ON secret_links (user_id);
+CREATE INDEX IF NOT EXISTS idx_secret_links_expires ON secret_links (expires_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS secret_links;
+
+-- +goose StatementEnd
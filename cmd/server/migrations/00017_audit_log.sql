@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- append-only security log, hash is sha256 over prev_hash and the fields of the row
+-- so an edited or removed row breaks the chain; users are not referenced by a foreign
+-- key because events must outlive deleted accounts
+CREATE TABLE IF NOT EXISTS audit_log (
+                                         id         BIGSERIAL PRIMARY KEY,
+                                         user_id    BIGINT NOT NULL DEFAULT 0,
+                                         owner_id   BIGINT NOT NULL DEFAULT 0,
+                                         action     TEXT NOT NULL,
+                                         item_type  TEXT NOT NULL DEFAULT '',
+                                         item_id    BIGINT NOT NULL DEFAULT 0,
+                                         ip         TEXT NOT NULL DEFAULT '',
+                                         user_agent TEXT NOT NULL DEFAULT '',
+                                         details    TEXT NOT NULL DEFAULT '',
+                                         created_at TIMESTAMPTZ NOT NULL,
+                                         prev_hash  BYTEA NULL,
+                                         hash       BYTEA NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_user  ON audit_log (user_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_owner ON audit_log (owner_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_item  ON audit_log (item_type, item_id);
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS trg_audit_log_append_only ON audit_log;
+DROP FUNCTION IF EXISTS audit_log_append_only();
+DROP TABLE IF EXISTS audit_log;
+
+-- +goose StatementEnd
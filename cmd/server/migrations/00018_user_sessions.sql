@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- every row of user_tokens is a signed-in device, the row id is the session id
+-- carried by the JWT so refresh finds the token of its own device
+ALTER TABLE user_tokens
+    ADD COLUMN IF NOT EXISTS device_name  TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS ip           TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS idx_user_tokens_user ON user_tokens (user_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_user_tokens_user;
+
+ALTER TABLE user_tokens
+    DROP COLUMN IF EXISTS last_used_at,
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS ip,
+    DROP COLUMN IF EXISTS device_name;
+
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- access tokens revoked before they expire, a row with jti revokes one token,
+-- a row without it revokes every token of the user issued before issued_before;
+-- rows are removed once expires_at passes because the tokens are dead by then
+CREATE TABLE IF NOT EXISTS token_revocations (
+                                                 id            BIGSERIAL PRIMARY KEY,
+                                                 jti           TEXT NOT NULL DEFAULT '',
+                                                 user_id       BIGINT NOT NULL,
+                                                 issued_before TIMESTAMPTZ NULL,
+                                                 expires_at    TIMESTAMPTZ NOT NULL,
+                                                 created_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_token_revocations_expires ON token_revocations (expires_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS token_revocations;
+
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- a session is a family of refresh tokens, every refresh moves the replaced token here;
+-- a token found here was used already, presenting it again revokes the whole session
+CREATE TABLE IF NOT EXISTS used_refresh_tokens (
+                                                   id            BIGSERIAL PRIMARY KEY,
+                                                   session_id    BIGINT NOT NULL,
+                                                   refresh_token TEXT NOT NULL,
+                                                   expires_at    TIMESTAMPTZ NOT NULL,
+                                                   used_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+                                                   CONSTRAINT fk_used_refresh_tokens_session
+                                                       FOREIGN KEY (session_id)
+                                                           REFERENCES user_tokens(id)
+                                                           ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_used_refresh_tokens_session ON used_refresh_tokens (session_id, id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS used_refresh_tokens;
+
+-- +goose StatementEnd
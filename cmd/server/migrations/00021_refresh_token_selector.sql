@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- refresh tokens are "selector.verifier", the selector finds the session without a JWT
+-- and stays the same for the session, the verifier is rotated and stored hashed
+ALTER TABLE user_tokens
+    ADD COLUMN IF NOT EXISTS selector TEXT;
+
+-- sessions from before get a selector too so their rotated tokens can be used,
+-- the tokens the clients hold have none and need a new login
+UPDATE user_tokens
+SET selector = md5(random()::text || clock_timestamp()::text || id::text)
+WHERE selector IS NULL;
+
+ALTER TABLE user_tokens
+    ALTER COLUMN selector SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_tokens_selector ON user_tokens (selector);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_user_tokens_selector;
+
+ALTER TABLE user_tokens
+    DROP COLUMN IF EXISTS selector;
+
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- refresh token verifiers are stored as HMAC-SHA256 instead of Argon2id, the old hashes can't be
+-- checked any more so their sessions are signed out and need a new login
+UPDATE user_tokens
+SET revoked_at = now()
+WHERE revoked_at IS NULL AND refresh_token LIKE '$argon2%';
+
+DELETE FROM used_refresh_tokens WHERE refresh_token LIKE '$argon2%';
+
+-- a replayed token is found by its hash instead of checking every used one
+DROP INDEX IF EXISTS idx_used_refresh_tokens_session;
+CREATE INDEX IF NOT EXISTS idx_used_refresh_tokens_hash ON used_refresh_tokens (session_id, refresh_token);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_used_refresh_tokens_hash;
+CREATE INDEX IF NOT EXISTS idx_used_refresh_tokens_session ON used_refresh_tokens (session_id, id);
+
+-- +goose StatementEnd
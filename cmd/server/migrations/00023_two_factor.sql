@@ -0,0 +1,62 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- TOTP second factor of a user, the secret is AES encrypted; enabled_at stays NULL until
+-- the user confirms a code, last_counter is the time step of the last accepted code so
+-- a code can not be replayed within its window
+CREATE TABLE IF NOT EXISTS user_two_factor (
+                                               user_id      BIGINT PRIMARY KEY,
+                                               secret       BYTEA NOT NULL,
+                                               enabled_at   TIMESTAMPTZ NULL,
+                                               last_counter BIGINT NOT NULL DEFAULT 0,
+                                               created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+                                               CONSTRAINT fk_user_two_factor_user
+                                                   FOREIGN KEY (user_id)
+                                                       REFERENCES users(id)
+                                                       ON DELETE CASCADE
+);
+
+-- one-time codes for a lost authenticator, only the keyed hash is stored
+CREATE TABLE IF NOT EXISTS recovery_codes (
+                                              id        BIGSERIAL PRIMARY KEY,
+                                              user_id   BIGINT NOT NULL,
+                                              code_hash TEXT NOT NULL,
+                                              used_at   TIMESTAMPTZ NULL,
+
+                                              CONSTRAINT fk_recovery_codes_user
+                                                  FOREIGN KEY (user_id)
+                                                      REFERENCES users(id)
+                                                      ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_recovery_codes_user ON recovery_codes (user_id, code_hash);
+
+-- the first step of a login with 2FA, the client holds the token and trades it with a code for a session
+CREATE TABLE IF NOT EXISTS login_challenges (
+                                                id          BIGSERIAL PRIMARY KEY,
+                                                user_id     BIGINT NOT NULL,
+                                                token_hash  TEXT NOT NULL UNIQUE,
+                                                device_name TEXT NOT NULL DEFAULT '',
+                                                attempts    INT NOT NULL DEFAULT 0,
+                                                expires_at  TIMESTAMPTZ NOT NULL,
+                                                created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+                                                CONSTRAINT fk_login_challenges_user
+                                                    FOREIGN KEY (user_id)
+                                                        REFERENCES users(id)
+                                                        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_challenges_expires ON login_challenges (expires_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS login_challenges;
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS user_two_factor;
+
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- a row with session_id revokes every access token of that session, signed out sessions
+-- can't be refreshed so the tokens they hold are the last ones
+ALTER TABLE token_revocations
+    ADD COLUMN IF NOT EXISTS session_id BIGINT NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE token_revocations
+    DROP COLUMN IF EXISTS session_id;
+
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- instances sync the revocations by created_at with an overlap, ids are taken before
+-- the commit so a smaller id can show up after a larger one
+CREATE INDEX IF NOT EXISTS idx_token_revocations_created ON token_revocations (created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_token_revocations_created;
+
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- codes tried by the user across login challenges since the last accepted one, a new challenge
+-- doesn't reset them; once max failures are reached every code of the user waits until locked_until
+ALTER TABLE user_two_factor
+    ADD COLUMN IF NOT EXISTS failed_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS locked_until    TIMESTAMPTZ NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE user_two_factor
+    DROP COLUMN IF EXISTS locked_until,
+    DROP COLUMN IF EXISTS failed_attempts;
+
+-- +goose StatementEnd